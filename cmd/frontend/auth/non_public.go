@@ -65,6 +65,15 @@ var (
 		router.SignOut:           {},
 		router.ResetPasswordInit: {},
 		router.ResetPasswordCode: {},
+
+		// The feed token in the URL authenticates the request (as its user),
+		// so that feed readers can fetch the feed without signing in.
+		router.DiscussionsFeed: {},
+
+		// The unsubscribe token in the URL (or form) authorizes unsubscribing
+		// its user from its thread, so that the links in notification emails
+		// work without signing in.
+		router.DiscussionsUnsubscribe: {},
 	}
 	anonymousAccessibleUIRoutes = map[string]struct{}{
 		uirouter.RouteSignIn:        {},
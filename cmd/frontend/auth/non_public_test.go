@@ -38,6 +38,10 @@ func TestAllowAnonymousRequest(t *testing.T) {
 		{req: req("POST", "/"), want: false},
 		{req: req("POST", "/-/sign-in"), want: true},
 		{req: req("GET", "/sign-in"), want: true},
+		{req: req("GET", "/-/discussions/feed?token=x"), want: true},
+		{req: req("POST", "/-/discussions/feed"), want: false},
+		{req: req("GET", "/-/discussions/unsubscribe?token=x"), want: true},
+		{req: req("POST", "/-/discussions/unsubscribe"), want: true},
 		{req: req("GET", "/doesntexist"), want: false},
 		{req: req("POST", "/doesntexist"), want: false},
 		{req: req("GET", "/doesnt/exist"), want: false},
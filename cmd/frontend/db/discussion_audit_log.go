@@ -0,0 +1,263 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+)
+
+// discussionAuditLog provides access to the `discussion_audit_log` table,
+// which is an append-only log of every mutation of discussion threads and
+// comments. A database trigger prevents entries from being modified or
+// deleted.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionAuditLog struct{}
+
+// Create appends an entry to the audit log.
+func (l *discussionAuditLog) Create(ctx context.Context, newEntry *types.DiscussionAuditLogEntry) (*types.DiscussionAuditLogEntry, error) {
+	if Mocks.DiscussionAuditLog.Create != nil {
+		return Mocks.DiscussionAuditLog.Create(ctx, newEntry)
+	}
+	return l.create(ctx, dbconn.Global, newEntry)
+}
+
+func (*discussionAuditLog) create(ctx context.Context, dbh dbHandle, newEntry *types.DiscussionAuditLogEntry) (*types.DiscussionAuditLogEntry, error) {
+	// Validate the input entry.
+	if newEntry == nil {
+		return nil, errors.New("newEntry is nil")
+	}
+	if newEntry.ID != 0 {
+		return nil, errors.New("newEntry.ID must be zero")
+	}
+	if newEntry.ObjectKind == "" || newEntry.ObjectID == 0 {
+		return nil, errors.New("newEntry.ObjectKind and newEntry.ObjectID must be specified")
+	}
+	if newEntry.Action == "" {
+		return nil, errors.New("newEntry.Action must be specified")
+	}
+	if !newEntry.CreatedAt.IsZero() {
+		return nil, errors.New("newEntry.CreatedAt must not be specified")
+	}
+
+	newEntry.CreatedAt = time.Now()
+	err := dbh.QueryRowContext(ctx, `INSERT INTO discussion_audit_log(
+		actor_user_id,
+		actor_ip,
+		actor_forwarded_for,
+		object_kind,
+		object_id,
+		action,
+		before,
+		after,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		newEntry.ActorUserID,
+		newEntry.ActorIP,
+		newEntry.ActorForwardedFor,
+		newEntry.ObjectKind,
+		newEntry.ObjectID,
+		newEntry.Action,
+		nullJSON(newEntry.Before),
+		nullJSON(newEntry.After),
+		newEntry.CreatedAt,
+	).Scan(&newEntry.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newEntry, nil
+}
+
+// nullJSON returns a value that stores b in a jsonb column, or NULL if b is
+// empty.
+func nullJSON(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
+
+// discussionAuditSnapshot returns the JSON representation of the current
+// database row of the object, or nil if it does not exist. Threads include
+// their target.
+//
+// 🚨 SECURITY: No permissions are checked. The snapshot must only be stored
+// in the audit log, which only site admins can read.
+func discussionAuditSnapshot(ctx context.Context, dbh dbHandle, kind types.DiscussionAuditLogObjectKind, objectID int64) ([]byte, error) {
+	var q string
+	switch kind {
+	case types.DiscussionAuditLogObjectThread:
+		q = `SELECT to_jsonb(t) || jsonb_build_object('target_repo', to_jsonb(tr)) FROM discussion_threads t
+			LEFT JOIN discussion_threads_target_repo tr ON tr.id = t.target_repo_id
+			WHERE t.id=$1`
+	case types.DiscussionAuditLogObjectComment:
+		q = "SELECT to_jsonb(c) FROM discussion_comments c WHERE c.id=$1"
+	default:
+		return nil, fmt.Errorf("unknown audit log object kind %q", kind)
+	}
+	var snapshot []byte
+	err := dbh.QueryRowContext(ctx, q, objectID).Scan(&snapshot)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return snapshot, err
+}
+
+// recordDiscussionAudit records in the audit log that the actor performed the
+// action on the object. before is the object's snapshot (from
+// discussionAuditSnapshot) from before the action; the snapshot from after it
+// is taken here. Nothing is recorded if the action did not change the object.
+func recordDiscussionAudit(ctx context.Context, dbh dbHandle, kind types.DiscussionAuditLogObjectKind, objectID int64, action string, before []byte) error {
+	after, err := discussionAuditSnapshot(ctx, dbh, kind, objectID)
+	if err != nil {
+		return err
+	}
+	if before != nil && bytes.Equal(before, after) {
+		return nil
+	}
+	entry := &types.DiscussionAuditLogEntry{
+		ObjectKind: kind,
+		ObjectID:   objectID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	}
+	if a := actor.FromContext(ctx); a.IsAuthenticated() {
+		entry.ActorUserID = &a.UID
+	}
+	if c := requestclient.FromContext(ctx); c != nil {
+		entry.ActorIP = &c.IP
+		if c.ForwardedFor != "" {
+			entry.ActorForwardedFor = &c.ForwardedFor
+		}
+	}
+	if _, err := DiscussionAuditLog.create(ctx, dbh, entry); err != nil {
+		return fmt.Errorf("recording audit log entry: %s", err)
+	}
+	return nil
+}
+
+type DiscussionAuditLogListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// ActorUserID, when non-zero, specifies that only entries of mutations
+	// performed by this user should be returned.
+	ActorUserID int32
+
+	// ObjectKind and ObjectID, when non-zero, specify that only entries of
+	// mutations of this object should be returned.
+	ObjectKind types.DiscussionAuditLogObjectKind
+	ObjectID   int64
+
+	// CreatedAfter and CreatedBefore, when non-nil, specify that only entries
+	// created in this time range should be returned.
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// List returns the entries matching the options, newest first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (a *discussionAuditLog) List(ctx context.Context, opts *DiscussionAuditLogListOptions) ([]*types.DiscussionAuditLogEntry, error) {
+	if Mocks.DiscussionAuditLog.List != nil {
+		return Mocks.DiscussionAuditLog.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := a.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY created_at DESC, id DESC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+	return a.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+// Count counts the entries matching the options.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (a *discussionAuditLog) Count(ctx context.Context, opts *DiscussionAuditLogListOptions) (int, error) {
+	if Mocks.DiscussionAuditLog.Count != nil {
+		return Mocks.DiscussionAuditLog.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := a.getListSQL(opts)
+	var count int
+	q := sqlf.Sprintf("SELECT count(id) FROM discussion_audit_log WHERE %s", sqlf.Join(conds, "AND"))
+	err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&count)
+	return count, err
+}
+
+func (*discussionAuditLog) getListSQL(opts *DiscussionAuditLogListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.ActorUserID != 0 {
+		conds = append(conds, sqlf.Sprintf("actor_user_id=%v", opts.ActorUserID))
+	}
+	if opts.ObjectKind != "" {
+		conds = append(conds, sqlf.Sprintf("object_kind=%v", opts.ObjectKind))
+	}
+	if opts.ObjectID != 0 {
+		conds = append(conds, sqlf.Sprintf("object_id=%v", opts.ObjectID))
+	}
+	if opts.CreatedAfter != nil {
+		conds = append(conds, sqlf.Sprintf("created_at > %v", *opts.CreatedAfter))
+	}
+	if opts.CreatedBefore != nil {
+		conds = append(conds, sqlf.Sprintf("created_at < %v", *opts.CreatedBefore))
+	}
+	return conds
+}
+
+func (*discussionAuditLog) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionAuditLogEntry, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			id,
+			actor_user_id,
+			actor_ip,
+			actor_forwarded_for,
+			object_kind,
+			object_id,
+			action,
+			before,
+			after,
+			created_at
+		FROM discussion_audit_log `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*types.DiscussionAuditLogEntry{}
+	defer rows.Close()
+	for rows.Next() {
+		entry := &types.DiscussionAuditLogEntry{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ActorUserID,
+			&entry.ActorIP,
+			&entry.ActorForwardedFor,
+			&entry.ObjectKind,
+			&entry.ObjectID,
+			&entry.Action,
+			&entry.Before,
+			&entry.After,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
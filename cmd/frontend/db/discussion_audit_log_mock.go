@@ -0,0 +1,13 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionAuditLog struct {
+	Create func(ctx context.Context, newEntry *types.DiscussionAuditLogEntry) (*types.DiscussionAuditLogEntry, error)
+	List   func(ctx context.Context, opts *DiscussionAuditLogListOptions) ([]*types.DiscussionAuditLogEntry, error)
+	Count  func(ctx context.Context, opts *DiscussionAuditLogListOptions) (int, error)
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+)
+
+func TestDiscussionAuditLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	ctx = actor.WithActor(ctx, &actor.Actor{UID: user.ID})
+	ctx = requestclient.WithClient(ctx, &requestclient.Client{IP: "192.0.2.1"})
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Title: strPtr("new title")}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &DiscussionAuditLogListOptions{ObjectKind: types.DiscussionAuditLogObjectThread, ObjectID: thread.ID}
+	entries, err := DiscussionAuditLog.List(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Action != "update" || entries[1].Action != "create" {
+		t.Fatalf("got entries %+v, want update and create entries", entries)
+	}
+	update := entries[0]
+	if update.ActorUserID == nil || *update.ActorUserID != user.ID {
+		t.Errorf("got actor %v, want %d", update.ActorUserID, user.ID)
+	}
+	if update.ActorIP == nil || *update.ActorIP != "192.0.2.1" {
+		t.Errorf("got actor IP %v, want 192.0.2.1", update.ActorIP)
+	}
+	if update.Before == nil || update.After == nil {
+		t.Errorf("got before %s and after %s, want both", update.Before, update.After)
+	}
+	if count, err := DiscussionAuditLog.Count(ctx, &DiscussionAuditLogListOptions{ActorUserID: user.ID + 1}); err != nil {
+		t.Fatal(err)
+	} else if count != 0 {
+		t.Errorf("got %d entries for another actor, want 0", count)
+	}
+
+	// The audit log is immutable.
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_audit_log SET action='x' WHERE id=$1", update.ID); err == nil {
+		t.Error("expected error updating an audit log entry")
+	}
+	if _, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_audit_log WHERE id=$1", update.ID); err == nil {
+		t.Error("expected error deleting an audit log entry")
+	}
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// The discussions cache is an optional Redis cache of the threads and of the
+// comments of each thread, which are read on every view of a thread. It is
+// enabled by the discussions.cache site configuration.
+//
+// Threads are cached as stored, before permissions are checked, so the
+// permissions of a cached thread's repository are checked every time it is
+// read from the cache. Entries are deleted whenever the thread or its comments
+// are modified by a store method; the TTL bounds how stale an entry can become
+// when the database is modified by other means (such as the deletion of the
+// thread's repository).
+const (
+	discussionThreadsCacheName        = "discussion_threads"
+	discussionThreadCommentsCacheName = "discussion_thread_comments"
+
+	defaultDiscussionsCacheTTLSeconds = 300
+)
+
+// discussionsCacheTTL returns the TTL of the discussions cache entries (in
+// seconds), or 0 if the cache is disabled.
+func discussionsCacheTTL() int {
+	dc := conf.Get().Discussions
+	if dc == nil || dc.Cache == nil {
+		return 0
+	}
+	if dc.Cache.TtlSeconds > 0 {
+		return dc.Cache.TtlSeconds
+	}
+	return defaultDiscussionsCacheTTLSeconds
+}
+
+func discussionsCacheGet(name string, id int64, v interface{}) bool {
+	b, ok := rcache.New(name).Get(strconv.FormatInt(id, 10))
+	if ok {
+		if err := json.Unmarshal(b, v); err != nil {
+			log15.Warn("discussions: invalid cache entry", "cache", name, "id", id, "error", err)
+			ok = false
+		}
+	}
+	result := "miss"
+	if ok {
+		result = "hit"
+	}
+	discussionsCacheRequestsTotal.WithLabelValues(name, result).Inc()
+	return ok
+}
+
+func discussionsCacheSet(name string, ttl int, id int64, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log15.Warn("discussions: unable to cache", "cache", name, "id", id, "error", err)
+		return
+	}
+	rcache.NewWithTTL(name, ttl).Set(strconv.FormatInt(id, 10), b)
+}
+
+// invalidateDiscussionThreads deletes the cached threads and thread comments
+// of the given threads. It must be called after every change to the threads
+// or their comments (once the change is committed).
+func invalidateDiscussionThreads(threadIDs ...int64) {
+	if discussionsCacheTTL() == 0 {
+		return
+	}
+	for _, id := range threadIDs {
+		key := strconv.FormatInt(id, 10)
+		rcache.New(discussionThreadsCacheName).Delete(key)
+		rcache.New(discussionThreadCommentsCacheName).Delete(key)
+	}
+}
+
+// invalidateDiscussionCommentThread is like invalidateDiscussionThreads, for
+// the thread of the given comment.
+func invalidateDiscussionCommentThread(ctx context.Context, commentID int64) {
+	if discussionsCacheTTL() == 0 {
+		return
+	}
+	var threadID int64
+	if err := dbconn.Global.QueryRowContext(ctx, "SELECT thread_id FROM discussion_comments WHERE id=$1", commentID).Scan(&threadID); err != nil {
+		if err != sql.ErrNoRows {
+			log15.Warn("discussions: unable to invalidate cached thread of comment", "comment", commentID, "error", err)
+		}
+		return
+	}
+	invalidateDiscussionThreads(threadID)
+}
+
+// discussionThreadIDsByAuthor returns the IDs of the threads that the user
+// authored or commented on (i.e., the threads that must be invalidated when
+// the user's threads and comments are deleted). It returns nil if the cache
+// is disabled.
+func discussionThreadIDsByAuthor(ctx context.Context, dbh dbHandle, userID int32) ([]int64, error) {
+	if discussionsCacheTTL() == 0 {
+		return nil, nil
+	}
+	rows, err := dbh.QueryContext(ctx, `SELECT id FROM discussion_threads WHERE author_user_id=$1
+		UNION SELECT thread_id FROM discussion_comments WHERE author_user_id=$1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var threadIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		threadIDs = append(threadIDs, id)
+	}
+	return threadIDs, rows.Err()
+}
+
+// getCached returns the thread from the cache, if it is cached and the current
+// user can access it. Otherwise (e.g. because the thread's repository was
+// deleted in the meantime), the caller must read the thread from the
+// database.
+func (*discussionThreads) getCached(ctx context.Context, threadID int64) (*types.DiscussionThread, error) {
+	var thread types.DiscussionThread
+	if !discussionsCacheGet(discussionThreadsCacheName, threadID, &thread) {
+		return nil, nil
+	}
+
+	// 🚨 SECURITY: These are the same checks as authzConds. Threads whose
+	// repository was deleted are visible only to site admins, so they are
+	// never read from the cache.
+	if thread.RepoDeletedAt != nil {
+		return nil, nil
+	}
+	if thread.TargetRepo != nil {
+		repos, err := Repos.getReposBySQL(ctx, true, sqlf.Sprintf("id=%d", thread.TargetRepo.RepoID))
+		if err != nil {
+			return nil, err
+		}
+		if len(repos) == 0 {
+			return nil, nil
+		}
+	}
+	return &thread, nil
+}
+
+// cacheable reports whether the options only select the comments of a single
+// thread (possibly only its top-level comments), which can be served from the
+// cached comments of the thread.
+func (opts *DiscussionCommentsListOptions) cacheable() bool {
+	return opts.ThreadID != nil &&
+		opts.AuthorUserID == nil &&
+		opts.CommentID == nil &&
+		opts.ParentCommentID == nil &&
+		!opts.Reported &&
+		opts.Resolved == nil &&
+		opts.Hidden == nil &&
+		opts.CreatedBefore == nil &&
+		opts.CreatedAfter == nil
+}
+
+// filterCached returns the comments (of the thread, oldest first) that match
+// the cacheable options.
+func (opts *DiscussionCommentsListOptions) filterCached(comments []*types.DiscussionComment) []*types.DiscussionComment {
+	if opts.TopLevel {
+		topLevel := make([]*types.DiscussionComment, 0, len(comments))
+		for _, c := range comments {
+			if c.ParentCommentID == nil {
+				topLevel = append(topLevel, c)
+			}
+		}
+		comments = topLevel
+	}
+	if opts.LimitOffset != nil {
+		if opts.Offset >= len(comments) {
+			return []*types.DiscussionComment{}
+		}
+		comments = comments[opts.Offset:]
+		if opts.Limit < len(comments) {
+			comments = comments[:opts.Limit]
+		}
+	}
+	return comments
+}
+
+// listThreadCached returns all comments of the thread, oldest first, from the
+// cache or (if they are not cached) from the database.
+func (c *discussionComments) listThreadCached(ctx context.Context, ttl int, threadID int64) ([]*types.DiscussionComment, error) {
+	var comments []*types.DiscussionComment
+	if discussionsCacheGet(discussionThreadCommentsCacheName, threadID, &comments) {
+		return comments, nil
+	}
+	q := sqlf.Sprintf("WHERE deleted_at IS NULL AND thread_id=%v ORDER BY id ASC", threadID)
+	comments, err := c.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	discussionsCacheSet(discussionThreadCommentsCacheName, ttl, threadID, comments)
+	return comments, nil
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestDiscussionCommentsListOptions_cacheable(t *testing.T) {
+	threadID := int64(1)
+	resolved := true
+	tests := map[string]struct {
+		opts *DiscussionCommentsListOptions
+		want bool
+	}{
+		"thread":           {&DiscussionCommentsListOptions{ThreadID: &threadID}, true},
+		"thread top-level": {&DiscussionCommentsListOptions{ThreadID: &threadID, TopLevel: true, LimitOffset: &LimitOffset{Limit: 10}}, true},
+		"all comments":     {&DiscussionCommentsListOptions{}, false},
+		"thread resolved":  {&DiscussionCommentsListOptions{ThreadID: &threadID, Resolved: &resolved}, false},
+		"thread reported":  {&DiscussionCommentsListOptions{ThreadID: &threadID, Reported: true}, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.opts.cacheable(); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiscussionCommentsListOptions_filterCached(t *testing.T) {
+	parentID := int64(1)
+	comments := []*types.DiscussionComment{
+		{ID: 1},
+		{ID: 2, ParentCommentID: &parentID},
+		{ID: 3},
+		{ID: 4},
+	}
+	ids := func(comments []*types.DiscussionComment) []int64 {
+		ids := []int64{}
+		for _, c := range comments {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+	tests := map[string]struct {
+		opts *DiscussionCommentsListOptions
+		want []int64
+	}{
+		"all":              {&DiscussionCommentsListOptions{}, []int64{1, 2, 3, 4}},
+		"top-level":        {&DiscussionCommentsListOptions{TopLevel: true}, []int64{1, 3, 4}},
+		"limit":            {&DiscussionCommentsListOptions{LimitOffset: &LimitOffset{Limit: 2}}, []int64{1, 2}},
+		"top-level offset": {&DiscussionCommentsListOptions{TopLevel: true, LimitOffset: &LimitOffset{Limit: 5, Offset: 1}}, []int64{3, 4}},
+		"offset past end":  {&DiscussionCommentsListOptions{LimitOffset: &LimitOffset{Limit: 5, Offset: 4}}, []int64{}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ids(test.opts.filterCached(comments)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiscussionCache_invalidation(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	rcache.SetupForTest(t)
+	ctx := context.Background()
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{Cache: &schema.DiscussionsCache{}},
+	}})
+	defer conf.Mock(nil)
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+
+	// Read the thread and its comments, so that they are cached.
+	if _, err := DiscussionThreads.Get(ctx, thread.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	title := "Updated"
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Title: &title}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DiscussionThreads.Get(ctx, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != title {
+		t.Errorf("got title %q, want %q", got.Title, title)
+	}
+
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0].ID != comment.ID {
+		t.Errorf("got comments %+v, want the new comment", comments)
+	}
+	if got, err := DiscussionThreads.Get(ctx, thread.ID); err != nil {
+		t.Fatal(err)
+	} else if got.CommentCount != 1 {
+		t.Errorf("got comment count %d, want 1", got.CommentCount)
+	}
+}
+
+func TestDiscussionCache_commentsAuthz(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	rcache.SetupForTest(t)
+	ctx := context.Background()
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{Cache: &schema.DiscussionsCache{}},
+	}})
+	defer conf.Mock(nil)
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	if _, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the comments while the repository is accessible, so that they are
+	// cached.
+	if comments, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID}); err != nil {
+		t.Fatal(err)
+	} else if len(comments) != 1 {
+		t.Fatalf("got %d comments, want 1", len(comments))
+	}
+
+	MockAuthzFilter = func(context.Context, []*types.Repo, authz.Perms) ([]*types.Repo, error) {
+		return nil, nil
+	}
+	defer func() { MockAuthzFilter = nil }()
+
+	comments, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("got %d cached comments in an inaccessible repository, want none", len(comments))
+	}
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionCommentAttachments provides access to the
+// `discussion_comment_attachments` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionCommentAttachments struct{}
+
+// ErrAttachmentNotFound is the error returned by Discussions methods to
+// indicate that the attachment could not be found.
+type ErrAttachmentNotFound struct {
+	// AttachmentID is the attachment that was not found.
+	AttachmentID int64
+}
+
+func (e *ErrAttachmentNotFound) Error() string {
+	return fmt.Sprintf("attachment %d not found", e.AttachmentID)
+}
+
+func (e *ErrAttachmentNotFound) NotFound() bool { return true }
+
+// attachmentUploadTokenTTL is how long the upload token of a new attachment is
+// valid for.
+const attachmentUploadTokenTTL = time.Hour
+
+// Create creates a new attachment whose contents have not been uploaded yet. It
+// returns the attachment and a token that authorizes uploading its contents
+// (see GetByUploadToken) until the returned expiry time.
+//
+// 🚨 SECURITY: The caller must ensure the token is ONLY given to the author of
+// the attachment's comment.
+func (*discussionCommentAttachments) Create(ctx context.Context, newAttachment *types.DiscussionCommentAttachment) (attachment *types.DiscussionCommentAttachment, token string, expiresAt time.Time, err error) {
+	if Mocks.DiscussionCommentAttachments.Create != nil {
+		return Mocks.DiscussionCommentAttachments.Create(ctx, newAttachment)
+	}
+
+	// Validate the input attachment.
+	if newAttachment == nil {
+		return nil, "", time.Time{}, errors.New("newAttachment is nil")
+	}
+	if newAttachment.ID != 0 {
+		return nil, "", time.Time{}, errors.New("newAttachment.ID must be zero")
+	}
+	if newAttachment.CommentID == 0 {
+		return nil, "", time.Time{}, errors.New("newAttachment.CommentID must be specified")
+	}
+	if newAttachment.Filename == "" || len(newAttachment.Filename) > 255 {
+		return nil, "", time.Time{}, errors.New("attachment filename must be between 1 and 255 bytes long")
+	}
+	if newAttachment.Size <= 0 {
+		return nil, "", time.Time{}, errors.New("attachment size must be positive")
+	}
+	if newAttachment.UploadedAt != nil {
+		return nil, "", time.Time{}, errors.New("newAttachment.UploadedAt must not be specified")
+	}
+	if !newAttachment.CreatedAt.IsZero() {
+		return nil, "", time.Time{}, errors.New("newAttachment.CreatedAt must not be specified")
+	}
+
+	// Only the SHA-256 hash of the token is stored, so that the token cannot
+	// be read from the database (like access tokens).
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	newAttachment.CreatedAt = time.Now()
+	expiresAt = newAttachment.CreatedAt.Add(attachmentUploadTokenTTL)
+	err = dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_comment_attachments(
+		comment_id,
+		filename,
+		content_type,
+		size,
+		upload_token_sha256,
+		upload_expires_at,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		newAttachment.CommentID,
+		newAttachment.Filename,
+		newAttachment.ContentType,
+		newAttachment.Size,
+		toSHA256Bytes(b[:]),
+		expiresAt,
+		newAttachment.CreatedAt,
+	).Scan(&newAttachment.ID)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	return newAttachment, hex.EncodeToString(b[:]), expiresAt, nil
+}
+
+// GetByUploadToken returns the attachment whose contents the given token
+// authorizes uploading. If there is none, or the token has expired or already
+// been used, ErrInvalidToken is returned.
+func (a *discussionCommentAttachments) GetByUploadToken(ctx context.Context, token string) (*types.DiscussionCommentAttachment, error) {
+	if Mocks.DiscussionCommentAttachments.GetByUploadToken != nil {
+		return Mocks.DiscussionCommentAttachments.GetByUploadToken(ctx, token)
+	}
+	value, err := hex.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	attachments, err := a.getBySQL(ctx, "WHERE upload_token_sha256=$1 AND upload_expires_at > $2 AND uploaded_at IS NULL", toSHA256Bytes(value), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, ErrInvalidToken
+	}
+	return attachments[0], nil
+}
+
+// MarkUploaded records that the attachment's contents have been uploaded,
+// which invalidates its upload token.
+func (a *discussionCommentAttachments) MarkUploaded(ctx context.Context, attachmentID int64) (*types.DiscussionCommentAttachment, error) {
+	if Mocks.DiscussionCommentAttachments.MarkUploaded != nil {
+		return Mocks.DiscussionCommentAttachments.MarkUploaded(ctx, attachmentID)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comment_attachments SET uploaded_at=$1, upload_token_sha256=NULL, upload_expires_at=NULL WHERE id=$2 AND uploaded_at IS NULL", time.Now(), attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if nrows == 0 {
+		return nil, &ErrAttachmentNotFound{AttachmentID: attachmentID}
+	}
+	return a.Get(ctx, attachmentID)
+}
+
+// Get returns the attachment, whether or not its contents have been uploaded.
+func (a *discussionCommentAttachments) Get(ctx context.Context, attachmentID int64) (*types.DiscussionCommentAttachment, error) {
+	if Mocks.DiscussionCommentAttachments.Get != nil {
+		return Mocks.DiscussionCommentAttachments.Get(ctx, attachmentID)
+	}
+	attachments, err := a.getBySQL(ctx, "WHERE id=$1", attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, &ErrAttachmentNotFound{AttachmentID: attachmentID}
+	}
+	return attachments[0], nil
+}
+
+// List returns the attachments of the comment whose contents have been
+// uploaded, oldest first.
+func (a *discussionCommentAttachments) List(ctx context.Context, commentID int64) ([]*types.DiscussionCommentAttachment, error) {
+	if Mocks.DiscussionCommentAttachments.List != nil {
+		return Mocks.DiscussionCommentAttachments.List(ctx, commentID)
+	}
+	return a.getBySQL(ctx, "WHERE comment_id=$1 AND uploaded_at IS NOT NULL ORDER BY id ASC", commentID)
+}
+
+func (*discussionCommentAttachments) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionCommentAttachment, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT id, comment_id, filename, content_type, size, uploaded_at, created_at FROM discussion_comment_attachments "+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*types.DiscussionCommentAttachment
+	for rows.Next() {
+		a := &types.DiscussionCommentAttachment{}
+		if err := rows.Scan(&a.ID, &a.CommentID, &a.Filename, &a.ContentType, &a.Size, &a.UploadedAt, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionCommentAttachments struct {
+	Create           func(ctx context.Context, newAttachment *types.DiscussionCommentAttachment) (*types.DiscussionCommentAttachment, string, time.Time, error)
+	GetByUploadToken func(ctx context.Context, token string) (*types.DiscussionCommentAttachment, error)
+	MarkUploaded     func(ctx context.Context, attachmentID int64) (*types.DiscussionCommentAttachment, error)
+	Get              func(ctx context.Context, attachmentID int64) (*types.DiscussionCommentAttachment, error)
+	List             func(ctx context.Context, commentID int64) ([]*types.DiscussionCommentAttachment, error)
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionCommentAttachments(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attachment, token, _, err := DiscussionCommentAttachments.Create(ctx, &types.DiscussionCommentAttachment{
+		CommentID:   comment.ID,
+		Filename:    "screenshot.png",
+		ContentType: "image/png",
+		Size:        123,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Attachments are not listed until their contents have been uploaded.
+	if attachments, err := DiscussionCommentAttachments.List(ctx, comment.ID); err != nil {
+		t.Fatal(err)
+	} else if len(attachments) != 0 {
+		t.Errorf("got %d attachments, want 0", len(attachments))
+	}
+
+	if _, err := DiscussionCommentAttachments.GetByUploadToken(ctx, "invalid"); err != ErrInvalidToken {
+		t.Errorf("got error %v, want %v", err, ErrInvalidToken)
+	}
+	got, err := DiscussionCommentAttachments.GetByUploadToken(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != attachment.ID {
+		t.Errorf("got attachment %d, want %d", got.ID, attachment.ID)
+	}
+	// Only the hash of the token is stored.
+	var stored []byte
+	if err := dbconn.Global.QueryRowContext(ctx, "SELECT upload_token_sha256 FROM discussion_comment_attachments WHERE id=$1", attachment.ID).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if string(stored) == token || len(stored) != 32 {
+		t.Errorf("got stored token %x, want the SHA-256 hash of the token", stored)
+	}
+
+	uploaded, err := DiscussionCommentAttachments.MarkUploaded(ctx, attachment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uploaded.UploadedAt == nil {
+		t.Error("expected attachment to be uploaded")
+	}
+	// The token can only be used once.
+	if _, err := DiscussionCommentAttachments.GetByUploadToken(ctx, token); err != ErrInvalidToken {
+		t.Errorf("got error %v, want %v", err, ErrInvalidToken)
+	}
+	if attachments, err := DiscussionCommentAttachments.List(ctx, comment.ID); err != nil {
+		t.Fatal(err)
+	} else if len(attachments) != 1 || attachments[0].Filename != "screenshot.png" {
+		t.Errorf("got attachments %+v, want screenshot.png", attachments)
+	}
+}
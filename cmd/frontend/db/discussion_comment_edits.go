@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionCommentEdits provides access to the `discussion_comment_edits`
+// table. Edits are recorded by DiscussionComments.Update.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionCommentEdits struct{}
+
+type DiscussionCommentEditsListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// CommentID, when non-zero, specifies that only edits of this comment
+	// should be returned.
+	CommentID int64
+}
+
+// List returns the edits matching the options, most recent first.
+func (e *discussionCommentEdits) List(ctx context.Context, opts *DiscussionCommentEditsListOptions) ([]*types.DiscussionCommentEdit, error) {
+	if Mocks.DiscussionCommentEdits.List != nil {
+		return Mocks.DiscussionCommentEdits.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := e.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY created_at DESC, id DESC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+	return e.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (e *discussionCommentEdits) Count(ctx context.Context, opts *DiscussionCommentEditsListOptions) (int, error) {
+	if Mocks.DiscussionCommentEdits.Count != nil {
+		return Mocks.DiscussionCommentEdits.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := e.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
+	return e.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (*discussionCommentEdits) getListSQL(opts *DiscussionCommentEditsListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.CommentID != 0 {
+		conds = append(conds, sqlf.Sprintf("comment_id=%v", opts.CommentID))
+	}
+	return conds
+}
+
+func (*discussionCommentEdits) getCountBySQL(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+	rows := dbconn.Global.QueryRowContext(ctx, "SELECT count(id) FROM discussion_comment_edits e "+query, args...)
+	err := rows.Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
+
+// getBySQL returns edits matching the SQL query, if any exist.
+func (*discussionCommentEdits) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionCommentEdit, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			e.id,
+			e.comment_id,
+			e.editor_user_id,
+			e.previous_contents,
+			e.created_at
+		FROM discussion_comment_edits e `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	edits := []*types.DiscussionCommentEdit{}
+	defer rows.Close()
+	for rows.Next() {
+		edit := &types.DiscussionCommentEdit{}
+		err := rows.Scan(
+			&edit.ID,
+			&edit.CommentID,
+			&edit.EditorUserID,
+			&edit.PreviousContents,
+			&edit.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
@@ -0,0 +1,12 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionCommentEdits struct {
+	List  func(ctx context.Context, opts *DiscussionCommentEditsListOptions) ([]*types.DiscussionCommentEdit, error)
+	Count func(ctx context.Context, opts *DiscussionCommentEditsListOptions) (int, error)
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionCommentEdits(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	editor, err := Users.Create(ctx, NewUser{Email: "b@b.com", Username: "u2", Password: "p", EmailVerificationCode: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, update := range []struct {
+		contents     string
+		editorUserID int32
+	}{
+		{"b", 0},
+		{"b", editor.ID}, // unchanged contents are not recorded
+		{"c", editor.ID},
+	} {
+		if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{
+			Contents:     strPtr(update.contents),
+			EditorUserID: update.editorUserID,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := &DiscussionCommentEditsListOptions{CommentID: comment.ID}
+	edits, err := DiscussionCommentEdits.List(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edits) != 2 {
+		t.Fatalf("got %d edits, want 2", len(edits))
+	}
+	if edits[0].PreviousContents != "b" || edits[0].EditorUserID != editor.ID {
+		t.Errorf("got most recent edit %+v, want previous contents %q by user %d", edits[0], "b", editor.ID)
+	}
+	if edits[1].PreviousContents != "a" || edits[1].EditorUserID != user.ID {
+		t.Errorf("got first edit %+v, want previous contents %q by user %d", edits[1], "a", user.ID)
+	}
+	if count, err := DiscussionCommentEdits.Count(ctx, opts); err != nil {
+		t.Fatal(err)
+	} else if count != 2 {
+		t.Errorf("got count %d, want 2", count)
+	}
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// discussionCommentMentions provides access to the
+// `discussion_comment_mentions` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionCommentMentions struct{}
+
+// Set replaces the mentions of the comment with the given users and
+// organizations.
+func (*discussionCommentMentions) Set(ctx context.Context, commentID int64, userIDs, orgIDs []int32) error {
+	if Mocks.DiscussionCommentMentions.Set != nil {
+		return Mocks.DiscussionCommentMentions.Set(ctx, commentID, userIDs, orgIDs)
+	}
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM discussion_comment_mentions WHERE comment_id=$1", commentID); err != nil {
+			return err
+		}
+		if len(userIDs) > 0 {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO discussion_comment_mentions(comment_id, user_id)
+				SELECT DISTINCT $1::bigint, unnest($2::integer[])`, commentID, pq.Array(userIDs)); err != nil {
+				return err
+			}
+		}
+		if len(orgIDs) > 0 {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO discussion_comment_mentions(comment_id, org_id)
+				SELECT DISTINCT $1::bigint, unnest($2::integer[])`, commentID, pq.Array(orgIDs)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// List returns the mentions of the comment, users first.
+func (*discussionCommentMentions) List(ctx context.Context, commentID int64) ([]*types.DiscussionCommentMention, error) {
+	if Mocks.DiscussionCommentMentions.List != nil {
+		return Mocks.DiscussionCommentMentions.List(ctx, commentID)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT comment_id, user_id, org_id FROM discussion_comment_mentions
+		WHERE comment_id=$1 ORDER BY user_id ASC NULLS LAST, org_id ASC`, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	mentions := []*types.DiscussionCommentMention{}
+	for rows.Next() {
+		mention := &types.DiscussionCommentMention{}
+		if err := rows.Scan(&mention.CommentID, &mention.UserID, &mention.OrgID); err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, mention)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
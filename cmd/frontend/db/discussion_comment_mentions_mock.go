@@ -0,0 +1,12 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionCommentMentions struct {
+	Set  func(ctx context.Context, commentID int64, userIDs, orgIDs []int32) error
+	List func(ctx context.Context, commentID int64) ([]*types.DiscussionCommentMention, error)
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionCommentMentions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	org, err := Orgs.Create(ctx, "o", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Duplicates are ignored.
+	if err := DiscussionCommentMentions.Set(ctx, comment.ID, []int32{user.ID, user.ID}, []int32{org.ID}); err != nil {
+		t.Fatal(err)
+	}
+	mentions, err := DiscussionCommentMentions.List(ctx, comment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mentions) != 2 {
+		t.Fatalf("got %d mentions, want 2", len(mentions))
+	}
+	if mentions[0].UserID == nil || *mentions[0].UserID != user.ID || mentions[0].OrgID != nil {
+		t.Errorf("got first mention %+v, want user %d", mentions[0], user.ID)
+	}
+	if mentions[1].OrgID == nil || *mentions[1].OrgID != org.ID || mentions[1].UserID != nil {
+		t.Errorf("got second mention %+v, want org %d", mentions[1], org.ID)
+	}
+
+	// Setting the mentions replaces the previous ones.
+	if err := DiscussionCommentMentions.Set(ctx, comment.ID, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	mentions, err = DiscussionCommentMentions.List(ctx, comment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mentions) != 0 {
+		t.Errorf("got %d mentions, want none", len(mentions))
+	}
+}
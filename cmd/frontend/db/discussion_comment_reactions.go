@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionCommentReactions provides access to the
+// `discussion_comment_reactions` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionCommentReactions struct{}
+
+var validDiscussionReactionContents = map[types.DiscussionReactionContent]bool{
+	types.DiscussionReactionThumbsUp:   true,
+	types.DiscussionReactionThumbsDown: true,
+	types.DiscussionReactionLaugh:      true,
+	types.DiscussionReactionHooray:     true,
+	types.DiscussionReactionConfused:   true,
+	types.DiscussionReactionHeart:      true,
+	types.DiscussionReactionRocket:     true,
+	types.DiscussionReactionEyes:       true,
+}
+
+// Add adds the user's reaction to the comment. Adding a reaction that the
+// user already added is a no-op.
+func (*discussionCommentReactions) Add(ctx context.Context, commentID int64, userID int32, content types.DiscussionReactionContent) error {
+	if Mocks.DiscussionCommentReactions.Add != nil {
+		return Mocks.DiscussionCommentReactions.Add(ctx, commentID, userID, content)
+	}
+	// The thread's reaction count changes.
+	defer invalidateDiscussionCommentThread(ctx, commentID)
+	if !validDiscussionReactionContents[content] {
+		return fmt.Errorf("invalid reaction content %q", content)
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_comment_reactions(comment_id, user_id, content) VALUES($1, $2, $3)
+		ON CONFLICT DO NOTHING`, commentID, userID, content)
+	return err
+}
+
+// Remove removes the user's reaction from the comment. Removing a reaction
+// that the user did not add is a no-op.
+func (*discussionCommentReactions) Remove(ctx context.Context, commentID int64, userID int32, content types.DiscussionReactionContent) error {
+	if Mocks.DiscussionCommentReactions.Remove != nil {
+		return Mocks.DiscussionCommentReactions.Remove(ctx, commentID, userID, content)
+	}
+	defer invalidateDiscussionCommentThread(ctx, commentID)
+	_, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_comment_reactions WHERE comment_id=$1 AND user_id=$2 AND content=$3", commentID, userID, content)
+	return err
+}
+
+// Groups returns the reactions to the comment grouped by content, in the
+// order that each content was first used. ViewerHasReacted is computed for the
+// given user (which may be 0 for anonymous viewers).
+func (*discussionCommentReactions) Groups(ctx context.Context, commentID int64, viewerUserID int32) ([]*types.DiscussionReactionGroup, error) {
+	if Mocks.DiscussionCommentReactions.Groups != nil {
+		return Mocks.DiscussionCommentReactions.Groups(ctx, commentID, viewerUserID)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT content, count(*), bool_or(user_id=$2)
+		FROM discussion_comment_reactions
+		WHERE comment_id=$1
+		GROUP BY content
+		ORDER BY min(created_at) ASC, content ASC`, commentID, viewerUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	groups := []*types.DiscussionReactionGroup{}
+	for rows.Next() {
+		group := &types.DiscussionReactionGroup{}
+		if err := rows.Scan(&group.Content, &group.Count, &group.ViewerHasReacted); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
@@ -0,0 +1,13 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionCommentReactions struct {
+	Add    func(ctx context.Context, commentID int64, userID int32, content types.DiscussionReactionContent) error
+	Remove func(ctx context.Context, commentID int64, userID int32, content types.DiscussionReactionContent) error
+	Groups func(ctx context.Context, commentID int64, viewerUserID int32) ([]*types.DiscussionReactionGroup, error)
+}
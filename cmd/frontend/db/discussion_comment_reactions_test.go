@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionCommentReactions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	other, err := Users.Create(ctx, NewUser{Email: "b@b.com", Username: "u2", Password: "p", EmailVerificationCode: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range []struct {
+		userID  int32
+		content types.DiscussionReactionContent
+	}{
+		{user.ID, types.DiscussionReactionHeart},
+		{user.ID, types.DiscussionReactionHeart}, // duplicates are ignored
+		{other.ID, types.DiscussionReactionHeart},
+		{other.ID, types.DiscussionReactionRocket},
+	} {
+		if err := DiscussionCommentReactions.Add(ctx, comment.ID, r.userID, r.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := DiscussionCommentReactions.Add(ctx, comment.ID, user.ID, "SMILE"); err == nil {
+		t.Error("expected error adding reaction with invalid content")
+	}
+
+	groups, err := DiscussionCommentReactions.Groups(ctx, comment.ID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*types.DiscussionReactionGroup{
+		{Content: types.DiscussionReactionHeart, Count: 2, ViewerHasReacted: true},
+		{Content: types.DiscussionReactionRocket, Count: 1, ViewerHasReacted: false},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("got groups %+v, want %+v", groups, want)
+	}
+
+	if err := DiscussionCommentReactions.Remove(ctx, comment.ID, other.ID, types.DiscussionReactionRocket); err != nil {
+		t.Fatal(err)
+	}
+	groups, err = DiscussionCommentReactions.Groups(ctx, comment.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []*types.DiscussionReactionGroup{
+		{Content: types.DiscussionReactionHeart, Count: 2, ViewerHasReacted: false},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("got groups %+v, want %+v", groups, want)
+	}
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionCommentSuggestions provides access to the
+// `discussion_comment_suggestions` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionCommentSuggestions struct{}
+
+// ErrSuggestionNotFound is the error returned by Discussions methods to
+// indicate that the comment has no suggestion.
+type ErrSuggestionNotFound struct {
+	// CommentID is the comment whose suggestion was not found.
+	CommentID int64
+}
+
+func (e *ErrSuggestionNotFound) Error() string {
+	return fmt.Sprintf("suggestion for comment %d not found", e.CommentID)
+}
+
+func (e *ErrSuggestionNotFound) NotFound() bool { return true }
+
+// ErrSuggestionAlreadyApplied is returned by MarkApplied when the suggestion
+// has already been applied.
+var ErrSuggestionAlreadyApplied = errors.New("the suggestion has already been applied")
+
+func (*discussionCommentSuggestions) Create(ctx context.Context, newSuggestion *types.DiscussionCommentSuggestion) (*types.DiscussionCommentSuggestion, error) {
+	if Mocks.DiscussionCommentSuggestions.Create != nil {
+		return Mocks.DiscussionCommentSuggestions.Create(ctx, newSuggestion)
+	}
+
+	// Validate the input suggestion.
+	if newSuggestion == nil {
+		return nil, errors.New("newSuggestion is nil")
+	}
+	if newSuggestion.CommentID == 0 {
+		return nil, errors.New("newSuggestion.CommentID must be specified")
+	}
+	if len([]rune(newSuggestion.Contents)) > 100000 {
+		return nil, errors.New("suggestion too long (must be less than 100,000 UTF-8 characters)")
+	}
+	if newSuggestion.AppliedAt != nil || newSuggestion.AppliedByUserID != nil || newSuggestion.AppliedCommitID != nil {
+		return nil, errors.New("newSuggestion must not be applied")
+	}
+
+	if _, err := dbconn.Global.ExecContext(ctx, "INSERT INTO discussion_comment_suggestions(comment_id, contents) VALUES ($1, $2)", newSuggestion.CommentID, newSuggestion.Contents); err != nil {
+		return nil, err
+	}
+	return newSuggestion, nil
+}
+
+func (*discussionCommentSuggestions) Get(ctx context.Context, commentID int64) (*types.DiscussionCommentSuggestion, error) {
+	if Mocks.DiscussionCommentSuggestions.Get != nil {
+		return Mocks.DiscussionCommentSuggestions.Get(ctx, commentID)
+	}
+	s := &types.DiscussionCommentSuggestion{}
+	err := dbconn.Global.QueryRowContext(ctx, `SELECT comment_id, contents, applied_at, applied_by_user_id, applied_commit_id
+		FROM discussion_comment_suggestions WHERE comment_id=$1`, commentID).Scan(
+		&s.CommentID,
+		&s.Contents,
+		&s.AppliedAt,
+		&s.AppliedByUserID,
+		&s.AppliedCommitID,
+	)
+	if err == sql.ErrNoRows {
+		return nil, &ErrSuggestionNotFound{CommentID: commentID}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MarkApplied records that the user applied the comment's suggestion in the
+// given commit. It returns ErrSuggestionAlreadyApplied if the suggestion was
+// already applied.
+func (s *discussionCommentSuggestions) MarkApplied(ctx context.Context, commentID int64, userID int32, commitID string) (*types.DiscussionCommentSuggestion, error) {
+	if Mocks.DiscussionCommentSuggestions.MarkApplied != nil {
+		return Mocks.DiscussionCommentSuggestions.MarkApplied(ctx, commentID, userID, commitID)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, `UPDATE discussion_comment_suggestions
+		SET applied_at=$2, applied_by_user_id=$3, applied_commit_id=$4
+		WHERE comment_id=$1 AND applied_at IS NULL`, commentID, time.Now(), userID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if nrows == 0 {
+		if _, err := s.Get(ctx, commentID); err != nil {
+			return nil, err
+		}
+		return nil, ErrSuggestionAlreadyApplied
+	}
+	return s.Get(ctx, commentID)
+}
@@ -0,0 +1,13 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionCommentSuggestions struct {
+	Create      func(ctx context.Context, newSuggestion *types.DiscussionCommentSuggestion) (*types.DiscussionCommentSuggestion, error)
+	Get         func(ctx context.Context, commentID int64) (*types.DiscussionCommentSuggestion, error)
+	MarkApplied func(ctx context.Context, commentID int64, userID int32, commitID string) (*types.DiscussionCommentSuggestion, error)
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionCommentSuggestions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := DiscussionCommentSuggestions.Get(ctx, comment.ID); err == nil {
+		t.Fatal("expected error getting nonexistent suggestion")
+	} else if _, ok := err.(*ErrSuggestionNotFound); !ok {
+		t.Fatalf("got error %v, want *ErrSuggestionNotFound", err)
+	}
+
+	if _, err := DiscussionCommentSuggestions.Create(ctx, &types.DiscussionCommentSuggestion{CommentID: comment.ID, Contents: "x := 1"}); err != nil {
+		t.Fatal(err)
+	}
+	suggestion, err := DiscussionCommentSuggestions.Get(ctx, comment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suggestion.Contents != "x := 1" || suggestion.AppliedAt != nil {
+		t.Errorf("got suggestion %+v, want unapplied suggestion %q", suggestion, "x := 1")
+	}
+
+	applied, err := DiscussionCommentSuggestions.MarkApplied(ctx, comment.ID, user.ID, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied.AppliedAt == nil || applied.AppliedByUserID == nil || *applied.AppliedByUserID != user.ID || applied.AppliedCommitID == nil || *applied.AppliedCommitID != "abc" {
+		t.Errorf("got suggestion %+v, want applied by user %d in commit abc", applied, user.ID)
+	}
+	if _, err := DiscussionCommentSuggestions.MarkApplied(ctx, comment.ID, user.ID, "def"); err != ErrSuggestionAlreadyApplied {
+		t.Errorf("got error %v, want %v", err, ErrSuggestionAlreadyApplied)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/lib/pq"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 )
 
 // TODO(slimsag:discussions): future: tests for DiscussionComments.List
@@ -32,11 +33,23 @@ func (e *ErrCommentNotFound) Error() string {
 	return fmt.Sprintf("comment %d not found", e.CommentID)
 }
 
-func (c *discussionComments) Create(ctx context.Context, newComment *types.DiscussionComment) (*types.DiscussionComment, error) {
+func (e *ErrCommentNotFound) NotFound() bool { return true }
+
+func (c *discussionComments) Create(ctx context.Context, newComment *types.DiscussionComment) (_ *types.DiscussionComment, err error) {
 	if Mocks.DiscussionComments.Create != nil {
 		return Mocks.DiscussionComments.Create(ctx, newComment)
 	}
 
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionComments.Create", &err)
+	defer done()
+
+	if newComment != nil {
+		defer invalidateDiscussionThreads(newComment.ThreadID)
+	}
+	return c.create(ctx, dbconn.Global, newComment)
+}
+
+func (c *discussionComments) create(ctx context.Context, dbh dbHandle, newComment *types.DiscussionComment) (*types.DiscussionComment, error) {
 	// Validate the input comment.
 	if newComment == nil {
 		return nil, errors.New("newComment is nil")
@@ -56,19 +69,24 @@ func (c *discussionComments) Create(ctx context.Context, newComment *types.Discu
 	if newComment.DeletedAt != nil {
 		return nil, errors.New("newComment.DeletedAt must not be specified")
 	}
+	if newComment.HiddenAt != nil || newComment.HiddenByUserID != nil {
+		return nil, errors.New("newComment.HiddenAt and newComment.HiddenByUserID must not be specified")
+	}
 
 	// Create the comment.
 	newComment.CreatedAt = time.Now()
 	newComment.UpdatedAt = newComment.CreatedAt
 
-	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_comments(
+	err := dbh.QueryRowContext(ctx, `INSERT INTO discussion_comments(
 		thread_id,
+		parent_comment_id,
 		author_user_id,
 		contents,
 		created_at,
 		updated_at
-	) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+	) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
 		newComment.ThreadID,
+		newComment.ParentCommentID,
 		newComment.AuthorUserID,
 		newComment.Contents,
 		newComment.CreatedAt,
@@ -77,13 +95,27 @@ func (c *discussionComments) Create(ctx context.Context, newComment *types.Discu
 	if err != nil {
 		return nil, err
 	}
+
+	// New activity on a stale thread means that it is no longer stale.
+	if _, err := dbh.ExecContext(ctx, "UPDATE discussion_threads SET stale_at=NULL WHERE id=$1 AND stale_at IS NOT NULL", newComment.ThreadID); err != nil {
+		return nil, err
+	}
+	if err := recordDiscussionAudit(ctx, dbh, types.DiscussionAuditLogObjectComment, newComment.ID, "create", nil); err != nil {
+		return nil, err
+	}
 	return newComment, nil
 }
 
 type DiscussionCommentsUpdateOptions struct {
-	// Contents, when non-nil, specifies the new contents of the comment.
+	// Contents, when non-nil, specifies the new contents of the comment. The
+	// previous contents are recorded in the comment's edit history (see
+	// DiscussionCommentEdits).
 	Contents *string
 
+	// EditorUserID is the user who is changing the contents of the comment. If
+	// zero, the comment's author is recorded as the editor.
+	EditorUserID int32
+
 	// Delete, when true, specifies that the comment should be deleted. This
 	// operation cannot be undone.
 	Delete bool
@@ -96,80 +128,139 @@ type DiscussionCommentsUpdateOptions struct {
 	// cleared (e.g. after review by an admin)
 	ClearReports bool
 
-	// noThreadDelete prevents calling DiscussionThreads.Delete when the comment
-	// being deleted is the first comment in the thread. This should ONLY be
-	// used by DiscussionThreads.Delete to avoid circular calls.
-	noThreadDelete bool
+	// Resolve, when non-nil, specifies whether the discussion formed by the
+	// comment and its replies is resolved. Replies themselves cannot be
+	// resolved, so it has no effect on them.
+	Resolve *bool
+
+	// ResolverUserID is the user who is resolving the comment. It is ignored
+	// unless Resolve is true.
+	ResolverUserID int32
+
+	// Hide, when non-nil, specifies whether the comment is hidden (by a
+	// moderator) or not. Unlike deleting, hiding a comment keeps its contents.
+	Hide *bool
+
+	// HiderUserID is the user who is hiding the comment. It is ignored unless
+	// Hide is true.
+	HiderUserID int32
+
+	// ExpectedUpdatedAt, when non-nil, specifies that the comment must not
+	// have been updated since this time, or else ErrConcurrentUpdate is
+	// returned and nothing is updated (see
+	// DiscussionThreadsUpdateOptions.ExpectedUpdatedAt).
+	ExpectedUpdatedAt *time.Time
 }
 
-func (c *discussionComments) Update(ctx context.Context, commentID int64, opts *DiscussionCommentsUpdateOptions) (*types.DiscussionComment, error) {
+func (c *discussionComments) Update(ctx context.Context, commentID int64, opts *DiscussionCommentsUpdateOptions) (_ *types.DiscussionComment, err error) {
 	if Mocks.DiscussionComments.Update != nil {
 		return Mocks.DiscussionComments.Update(ctx, commentID, opts)
 	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionComments.Update", &err)
+	defer done()
+	defer invalidateDiscussionCommentThread(ctx, commentID)
+
 	if opts == nil {
 		return nil, errors.New("options must not be nil")
 	}
 	now := time.Now()
+	err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		var threadID, firstCommentID int64
+		if err := tx.QueryRowContext(ctx, "SELECT thread_id FROM discussion_comments WHERE id=$1 AND deleted_at IS NULL", commentID).Scan(&threadID); err != nil {
+			if err == sql.ErrNoRows {
+				return &ErrCommentNotFound{CommentID: commentID}
+			}
+			return err
+		}
+		if opts.Delete {
+			// Lock the thread before the comment (in the same order as
+			// DiscussionThreads.Update), because deleting the first comment
+			// in a thread implicitly means deleting the thread itself.
+			if _, err := tx.ExecContext(ctx, "SELECT id FROM discussion_threads WHERE id=$1 FOR UPDATE", threadID); err != nil {
+				return err
+			}
+			if err := tx.QueryRowContext(ctx, "SELECT id FROM discussion_comments WHERE thread_id=$1 AND deleted_at IS NULL ORDER BY id ASC LIMIT 1", threadID).Scan(&firstCommentID); err != nil {
+				return err
+			}
+		}
+		if err := lockAndCheckUpdatedAt(ctx, tx, "discussion_comments", commentID, opts.ExpectedUpdatedAt, &ErrCommentNotFound{CommentID: commentID}); err != nil {
+			return err
+		}
+		if opts.Delete && commentID == firstCommentID {
+			return deleteDiscussionThread(ctx, tx, threadID, now)
+		}
 
-	// TODO(slimsag:discussions): should be in a transaction
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectComment, commentID)
+		if err != nil {
+			return err
+		}
 
-	anyUpdate := false
-	if opts.Contents != nil {
-		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET contents=$1 WHERE id=$2 AND deleted_at IS NULL", *opts.Contents, commentID); err != nil {
-			return nil, err
+		anyUpdate := false
+		if opts.Contents != nil {
+			anyUpdate = true
+			if err := updateDiscussionCommentContents(ctx, tx, commentID, opts.EditorUserID, *opts.Contents, now); err != nil {
+				return err
+			}
 		}
-	}
-	var deletingFirstComment bool
-	if opts.Delete {
-		// Deleting the first comment in a thread implicitly means deleting the thread itself.
-		var (
-			deletingFirstComment bool
-			threadID             int64
-		)
-		if !opts.noThreadDelete {
-			comment, err := c.Get(ctx, commentID)
-			if err != nil {
-				return nil, err
+		if opts.Delete {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL", now, commentID); err != nil {
+				return err
 			}
-			comments, err := c.List(ctx, &DiscussionCommentsListOptions{
-				ThreadID: &comment.ThreadID,
-			})
-			if err != nil {
-				return nil, err
+		}
+		if opts.Report != nil {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET reports=ARRAY_APPEND(reports,$1) WHERE id=$2 AND deleted_at IS NULL", *opts.Report, commentID); err != nil {
+				return err
 			}
-			deletingFirstComment = comment.ID == comments[0].ID
-			threadID = comment.ThreadID
 		}
-		if deletingFirstComment {
-			_, err := DiscussionThreads.Update(ctx, threadID, &DiscussionThreadsUpdateOptions{Delete: true})
-			if err != nil {
-				return nil, err
+		if opts.ClearReports {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET reports='{}' WHERE id=$1 AND deleted_at IS NULL", commentID); err != nil {
+				return err
 			}
 		}
-	}
-	if !deletingFirstComment && opts.Delete {
-		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL", now, commentID); err != nil {
-			return nil, err
+		if opts.Resolve != nil {
+			anyUpdate = true
+			if *opts.Resolve {
+				// Resolving an already-resolved comment keeps its original resolver.
+				if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET resolved_at=$1, resolved_by_user_id=$2 WHERE id=$3 AND deleted_at IS NULL AND parent_comment_id IS NULL AND resolved_at IS NULL", now, opts.ResolverUserID, commentID); err != nil {
+					return err
+				}
+			} else {
+				if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET resolved_at=NULL, resolved_by_user_id=NULL WHERE id=$1 AND deleted_at IS NULL", commentID); err != nil {
+					return err
+				}
+			}
 		}
-	}
-	if opts.Report != nil {
-		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET reports=ARRAY_APPEND(reports,$1) WHERE id=$2 AND deleted_at IS NULL", *opts.Report, commentID); err != nil {
-			return nil, err
+		if opts.Hide != nil {
+			anyUpdate = true
+			if *opts.Hide {
+				// Hiding an already-hidden comment keeps its original hider.
+				if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET hidden_at=$1, hidden_by_user_id=$2 WHERE id=$3 AND deleted_at IS NULL AND hidden_at IS NULL", now, opts.HiderUserID, commentID); err != nil {
+					return err
+				}
+			} else {
+				if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET hidden_at=NULL, hidden_by_user_id=NULL WHERE id=$1 AND deleted_at IS NULL", commentID); err != nil {
+					return err
+				}
+			}
 		}
-	}
-	if opts.ClearReports {
-		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET reports='{}' WHERE id=$1 AND deleted_at IS NULL", commentID); err != nil {
-			return nil, err
+		if !anyUpdate {
+			return nil
 		}
-	}
-	if anyUpdate {
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_comments SET updated_at=$1 WHERE id=$2 AND deleted_at IS NULL", now, commentID); err != nil {
-			return nil, err
+		if err := bumpUpdatedAt(ctx, tx, "discussion_comments", commentID, now, opts.ExpectedUpdatedAt != nil); err != nil {
+			return err
+		}
+		action := "update"
+		if opts.Delete {
+			action = "delete"
 		}
+		return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectComment, commentID, action, before)
+	})
+	if err != nil {
+		return nil, err
 	}
 	if opts.Delete {
 		return nil, nil
@@ -177,6 +268,20 @@ func (c *discussionComments) Update(ctx context.Context, commentID int64, opts *
 	return c.Get(ctx, commentID)
 }
 
+// updateDiscussionCommentContents sets the contents of the comment, recording
+// its previous contents in its edit history (unless they are unchanged). The
+// edit is attributed to the editor, or to the comment's author if editorUserID
+// is 0.
+func updateDiscussionCommentContents(ctx context.Context, tx *sql.Tx, commentID int64, editorUserID int32, contents string, now time.Time) error {
+	if _, err := tx.ExecContext(ctx, `INSERT INTO discussion_comment_edits(comment_id, editor_user_id, previous_contents, created_at)
+		SELECT id, COALESCE(NULLIF($2, 0), author_user_id), contents, $3 FROM discussion_comments WHERE id=$1 AND deleted_at IS NULL AND contents <> $4`,
+		commentID, editorUserID, now, contents); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET contents=$1 WHERE id=$2 AND deleted_at IS NULL", contents, commentID)
+	return err
+}
+
 type DiscussionCommentsListOptions struct {
 	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
 	*LimitOffset
@@ -193,32 +298,74 @@ type DiscussionCommentsListOptions struct {
 	// be returned.
 	CommentID *int64
 
+	// ParentCommentID, when non-nil, specifies that only replies to this
+	// comment should be returned.
+	ParentCommentID *int64
+
+	// TopLevel, when true, specifies that only comments that are not replies
+	// to another comment should be returned.
+	TopLevel bool
+
 	// Reported, when true, returns only threads that have at least one report.
 	Reported bool
 
+	// Resolved, when non-nil, specifies that only comments that are (or are
+	// not) resolved should be returned.
+	Resolved *bool
+
+	// Hidden, when non-nil, specifies that only comments that are (or are
+	// not) hidden should be returned.
+	Hidden *bool
+
 	// CreatedBefore, when non-nil, specifies that only comments that were
 	// created before this time should be returned.
 	CreatedBefore *time.Time
 	CreatedAfter  *time.Time
 }
 
-func (c *discussionComments) List(ctx context.Context, opts *DiscussionCommentsListOptions) ([]*types.DiscussionComment, error) {
+func (c *discussionComments) List(ctx context.Context, opts *DiscussionCommentsListOptions) (_ []*types.DiscussionComment, err error) {
 	if Mocks.DiscussionComments.List != nil {
 		return Mocks.DiscussionComments.List(ctx, opts)
 	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionComments.List", &err)
+	defer done()
+
 	if opts == nil {
 		return nil, errors.New("options must not be nil")
 	}
-	conds := c.getListSQL(opts)
+	if ttl := discussionsCacheTTL(); ttl != 0 && opts.cacheable() {
+		// 🚨 SECURITY: The cached comments of a thread are shared by all
+		// users, so check that the current user can access the thread before
+		// returning them (like authzConds does for the uncached comments).
+		if _, err := DiscussionThreads.Get(ctx, *opts.ThreadID); err != nil {
+			if _, ok := err.(*ErrThreadNotFound); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+		comments, err := c.listThreadCached(ctx, ttl, *opts.ThreadID)
+		if err != nil {
+			return nil, err
+		}
+		return opts.filterCached(comments), nil
+	}
+	conds, err := c.authzConds(ctx, c.getListSQL(opts))
+	if err != nil {
+		return nil, err
+	}
 	q := sqlf.Sprintf("WHERE %s ORDER BY id ASC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
 	return c.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 }
 
-func (c *discussionComments) Get(ctx context.Context, commentID int64) (*types.DiscussionComment, error) {
+func (c *discussionComments) Get(ctx context.Context, commentID int64) (_ *types.DiscussionComment, err error) {
 	if Mocks.DiscussionComments.Get != nil {
 		return Mocks.DiscussionComments.Get(commentID)
 	}
 
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionComments.Get", &err)
+	defer done()
+
 	comments, err := c.List(ctx, &DiscussionCommentsListOptions{
 		CommentID: &commentID,
 	})
@@ -231,18 +378,42 @@ func (c *discussionComments) Get(ctx context.Context, commentID int64) (*types.D
 	return comments[0], nil
 }
 
-func (c *discussionComments) Count(ctx context.Context, opts *DiscussionCommentsListOptions) (int, error) {
+func (c *discussionComments) Count(ctx context.Context, opts *DiscussionCommentsListOptions) (_ int, err error) {
 	if Mocks.DiscussionComments.Count != nil {
 		return Mocks.DiscussionComments.Count(ctx, opts)
 	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionComments.Count", &err)
+	defer done()
+
 	if opts == nil {
 		return 0, errors.New("options must not be nil")
 	}
-	conds := c.getListSQL(opts)
+	conds, err := c.authzConds(ctx, c.getListSQL(opts))
+	if err != nil {
+		return 0, err
+	}
 	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
 	return c.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 }
 
+// authzConds returns conds with an additional condition that restricts the
+// comments to those on threads that the current user can read (see
+// discussionThreads.authzConds). A thread's first comment is the thread's
+// contents, so comments are exactly as visible as their threads.
+//
+// 🚨 SECURITY: This enforces repository permissions for discussion comments.
+func (*discussionComments) authzConds(ctx context.Context, conds []*sqlf.Query) ([]*sqlf.Query, error) {
+	// Only the target repositories of the matching comments' threads are
+	// checked.
+	threadConds := []*sqlf.Query{sqlf.Sprintf("id IN (SELECT thread_id FROM discussion_comments WHERE %s)", sqlf.Join(conds, "AND"))}
+	threadConds, err := DiscussionThreads.authzConds(ctx, threadConds)
+	if err != nil {
+		return nil, err
+	}
+	return append(conds, sqlf.Sprintf("thread_id IN (SELECT id FROM discussion_threads WHERE %s)", sqlf.Join(threadConds[1:], "AND"))), nil
+}
+
 func (*discussionComments) getListSQL(opts *DiscussionCommentsListOptions) (conds []*sqlf.Query) {
 	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
 	conds = append(conds, sqlf.Sprintf("deleted_at IS NULL"))
@@ -255,9 +426,29 @@ func (*discussionComments) getListSQL(opts *DiscussionCommentsListOptions) (cond
 	if opts.CommentID != nil {
 		conds = append(conds, sqlf.Sprintf("id=%v", *opts.CommentID))
 	}
+	if opts.ParentCommentID != nil {
+		conds = append(conds, sqlf.Sprintf("parent_comment_id=%v", *opts.ParentCommentID))
+	}
+	if opts.TopLevel {
+		conds = append(conds, sqlf.Sprintf("parent_comment_id IS NULL"))
+	}
 	if opts.Reported {
 		conds = append(conds, sqlf.Sprintf("array_length(reports,1) > 0"))
 	}
+	if opts.Resolved != nil {
+		if *opts.Resolved {
+			conds = append(conds, sqlf.Sprintf("resolved_at IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("resolved_at IS NULL"))
+		}
+	}
+	if opts.Hidden != nil {
+		if *opts.Hidden {
+			conds = append(conds, sqlf.Sprintf("hidden_at IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("hidden_at IS NULL"))
+		}
+	}
 	if opts.CreatedBefore != nil {
 		conds = append(conds, sqlf.Sprintf("created_at < %v", *opts.CreatedBefore))
 	}
@@ -283,11 +474,16 @@ func (*discussionComments) getBySQL(ctx context.Context, query string, args ...i
 		SELECT
 			c.id,
 			c.thread_id,
+			c.parent_comment_id,
 			c.author_user_id,
 			c.contents,
 			c.created_at,
 			c.updated_at,
-			c.reports
+			c.reports,
+			c.resolved_at,
+			c.resolved_by_user_id,
+			c.hidden_at,
+			c.hidden_by_user_id
 		FROM discussion_comments c `+query, args...)
 	if err != nil {
 		return nil, err
@@ -300,11 +496,16 @@ func (*discussionComments) getBySQL(ctx context.Context, query string, args ...i
 		err := rows.Scan(
 			&comment.ID,
 			&comment.ThreadID,
+			&comment.ParentCommentID,
 			&comment.AuthorUserID,
 			&comment.Contents,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
 			pq.Array(&comment.Reports),
+			&comment.ResolvedAt,
+			&comment.ResolvedByUserID,
+			&comment.HiddenAt,
+			&comment.HiddenByUserID,
 		)
 		if err != nil {
 			return nil, err
@@ -2,9 +2,11 @@ package db
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
@@ -100,3 +102,228 @@ func TestDiscussionComments_Create(t *testing.T) {
 		t.Fatal("expected to not find deleted thread", err)
 	}
 }
+
+func TestDiscussionComments_Replies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	create := func(parentCommentID *int64) *types.DiscussionComment {
+		t.Helper()
+		comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{
+			ThreadID:        thread.ID,
+			ParentCommentID: parentCommentID,
+			AuthorUserID:    user.ID,
+			Contents:        "c",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return comment
+	}
+	parent := create(nil)
+	reply1 := create(&parent.ID)
+	reply2 := create(&parent.ID)
+	create(&reply1.ID) // nested replies are not direct replies to the parent
+	other := create(nil)
+
+	replies, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ParentCommentID: &parent.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replies) != 2 || replies[0].ID != reply1.ID || replies[1].ID != reply2.ID {
+		t.Errorf("got replies %+v, want comments %d and %d", replies, reply1.ID, reply2.ID)
+	}
+	if replies[0].ParentCommentID == nil || *replies[0].ParentCommentID != parent.ID {
+		t.Errorf("got reply parent %v, want %d", replies[0].ParentCommentID, parent.ID)
+	}
+
+	topLevel, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID, TopLevel: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topLevel) != 2 || topLevel[0].ID != parent.ID || topLevel[1].ID != other.ID {
+		t.Errorf("got top-level comments %+v, want comments %d and %d", topLevel, parent.ID, other.ID)
+	}
+}
+
+func TestDiscussionComments_Resolve(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, ParentCommentID: &comment.ID, AuthorUserID: user.ID, Contents: "r"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolve := func(commentID int64, resolve bool) *types.DiscussionComment {
+		t.Helper()
+		updated, err := DiscussionComments.Update(ctx, commentID, &DiscussionCommentsUpdateOptions{Resolve: &resolve, ResolverUserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return updated
+	}
+	countResolved := func() int {
+		t.Helper()
+		resolved := true
+		count, err := DiscussionComments.Count(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID, TopLevel: true, Resolved: &resolved})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if updated := resolve(comment.ID, true); updated.ResolvedAt == nil || updated.ResolvedByUserID == nil || *updated.ResolvedByUserID != user.ID {
+		t.Errorf("got resolved at %v by %v, want resolved by user %d", updated.ResolvedAt, updated.ResolvedByUserID, user.ID)
+	}
+	if updated := resolve(reply.ID, true); updated.ResolvedAt != nil {
+		t.Error("expected reply to not be resolvable")
+	}
+	if got := countResolved(); got != 1 {
+		t.Errorf("got %d resolved comments, want 1", got)
+	}
+
+	if updated := resolve(comment.ID, false); updated.ResolvedAt != nil || updated.ResolvedByUserID != nil {
+		t.Errorf("got resolved at %v by %v, want unresolved", updated.ResolvedAt, updated.ResolvedByUserID)
+	}
+	if got := countResolved(); got != 0 {
+		t.Errorf("got %d resolved comments, want 0", got)
+	}
+}
+
+func TestDiscussionComments_Hide(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hide := func(hide bool) *types.DiscussionComment {
+		t.Helper()
+		updated, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{Hide: &hide, HiderUserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return updated
+	}
+	countHidden := func() int {
+		t.Helper()
+		hidden := true
+		count, err := DiscussionComments.Count(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID, Hidden: &hidden})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if updated := hide(true); updated.HiddenAt == nil || updated.HiddenByUserID == nil || *updated.HiddenByUserID != user.ID {
+		t.Errorf("got hidden at %v by %v, want hidden by user %d", updated.HiddenAt, updated.HiddenByUserID, user.ID)
+	} else if updated.Contents != "c" {
+		t.Errorf("got contents %q, want hidden comment to keep its contents", updated.Contents)
+	}
+	if got := countHidden(); got != 1 {
+		t.Errorf("got %d hidden comments, want 1", got)
+	}
+
+	if updated := hide(false); updated.HiddenAt != nil || updated.HiddenByUserID != nil {
+		t.Errorf("got hidden at %v by %v, want not hidden", updated.HiddenAt, updated.HiddenByUserID)
+	}
+	if got := countHidden(); got != 0 {
+		t.Errorf("got %d hidden comments, want 0", got)
+	}
+}
+
+func TestDiscussionComments_AuthzFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a thread with a comment in a public and a private repository.
+	var commentIDs []int64
+	for _, name := range []api.RepoName{"public", "private"} {
+		if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: name, Enabled: true}); err != nil {
+			t.Fatal(err)
+		}
+		repo, err := Repos.GetByName(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        string(name),
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID, Path: strPtr("a")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: string(name)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	publicCommentID, privateCommentID := commentIDs[0], commentIDs[1]
+
+	MockAuthzFilter = func(ctx context.Context, repos []*types.Repo, p authz.Perms) ([]*types.Repo, error) {
+		var filtered []*types.Repo
+		for _, repo := range repos {
+			if repo.Name != "private" {
+				filtered = append(filtered, repo)
+			}
+		}
+		return filtered, nil
+	}
+	defer func() { MockAuthzFilter = nil }()
+
+	if _, err := DiscussionComments.Get(ctx, privateCommentID); err == nil {
+		t.Error("expected error getting comment in private repository")
+	}
+	comments, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{AuthorUserID: &user.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotIDs []int64
+	for _, comment := range comments {
+		gotIDs = append(gotIDs, comment.ID)
+	}
+	if want := []int64{publicCommentID}; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("got comment IDs %v, want %v", gotIDs, want)
+	}
+	count, err := DiscussionComments.Count(ctx, &DiscussionCommentsListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got count %d, want 1", count)
+	}
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionFeedTokens provides access to the `discussion_feed_tokens` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionFeedTokens struct{}
+
+// ErrFeedTokenNotFound is the error returned by DiscussionFeedTokens methods
+// to indicate that the feed token could not be found.
+type ErrFeedTokenNotFound struct {
+	// ID is the feed token that was not found.
+	ID int64
+}
+
+func (e *ErrFeedTokenNotFound) Error() string {
+	return fmt.Sprintf("feed token %d not found", e.ID)
+}
+
+func (e *ErrFeedTokenNotFound) NotFound() bool { return true }
+
+// Create creates a feed token and returns it along with its secret value,
+// which is needed to read the feed. Only the SHA-256 hash of the value is
+// stored, so the value cannot be retrieved later.
+//
+// 🚨 SECURITY: The caller must ensure the value is ONLY given to the token's
+// user. Anyone with the value can read the feed with the user's permissions
+// until the token is revoked.
+func (*discussionFeedTokens) Create(ctx context.Context, newToken *types.DiscussionFeedToken) (*types.DiscussionFeedToken, string, error) {
+	if Mocks.DiscussionFeedTokens.Create != nil {
+		return Mocks.DiscussionFeedTokens.Create(ctx, newToken)
+	}
+
+	// Validate the input token.
+	if newToken == nil {
+		return nil, "", errors.New("newToken is nil")
+	}
+	if newToken.ID != 0 {
+		return nil, "", errors.New("newToken.ID must be zero")
+	}
+	if newToken.UserID == 0 {
+		return nil, "", errors.New("newToken.UserID must be specified")
+	}
+	switch newToken.Kind {
+	case types.DiscussionFeedRepository:
+		if newToken.RepoID == nil {
+			return nil, "", errors.New("newToken.RepoID must be specified for repository feeds")
+		}
+	case types.DiscussionFeedAssigned, types.DiscussionFeedMentioned:
+		if newToken.RepoID != nil {
+			return nil, "", errors.New("newToken.RepoID must only be specified for repository feeds")
+		}
+	default:
+		return nil, "", fmt.Errorf("invalid feed kind %q", newToken.Kind)
+	}
+	if !newToken.CreatedAt.IsZero() || newToken.LastUsedAt != nil || newToken.RevokedAt != nil {
+		return nil, "", errors.New("newToken.CreatedAt, LastUsedAt and RevokedAt must not be specified")
+	}
+
+	var b [20]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, "", err
+	}
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_feed_tokens(
+		user_id,
+		value_sha256,
+		kind,
+		repo_id
+	) VALUES ($1, $2, $3, $4) RETURNING id, created_at`,
+		newToken.UserID,
+		toSHA256Bytes(b[:]),
+		newToken.Kind,
+		newToken.RepoID,
+	).Scan(&newToken.ID, &newToken.CreatedAt)
+	if err != nil {
+		return nil, "", err
+	}
+	return newToken, hex.EncodeToString(b[:]), nil
+}
+
+// Lookup returns the feed token with the given (hex-encoded) value, and
+// records that it was used. If there is no such token, or it was revoked, or
+// its user was deleted, ErrInvalidToken is returned.
+//
+// 🚨 SECURITY: This returns a token if and only if the value corresponds to a
+// valid, non-revoked feed token of a non-deleted user.
+func (*discussionFeedTokens) Lookup(ctx context.Context, token string) (*types.DiscussionFeedToken, error) {
+	if Mocks.DiscussionFeedTokens.Lookup != nil {
+		return Mocks.DiscussionFeedTokens.Lookup(ctx, token)
+	}
+	value, err := hex.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	t := &types.DiscussionFeedToken{}
+	err = dbconn.Global.QueryRowContext(ctx, `UPDATE discussion_feed_tokens t SET last_used_at=now()
+		WHERE t.value_sha256=$1 AND t.revoked_at IS NULL AND EXISTS (SELECT 1 FROM users u WHERE u.id=t.user_id AND u.deleted_at IS NULL)
+		RETURNING t.id, t.user_id, t.kind, t.repo_id, t.created_at, t.last_used_at, t.revoked_at`,
+		toSHA256Bytes(value),
+	).Scan(&t.ID, &t.UserID, &t.Kind, &t.RepoID, &t.CreatedAt, &t.LastUsedAt, &t.RevokedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetByID returns the feed token with the given ID, including if it was
+// revoked.
+func (s *discussionFeedTokens) GetByID(ctx context.Context, id int64) (*types.DiscussionFeedToken, error) {
+	if Mocks.DiscussionFeedTokens.GetByID != nil {
+		return Mocks.DiscussionFeedTokens.GetByID(ctx, id)
+	}
+	tokens, err := s.list(ctx, "WHERE id=$1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, &ErrFeedTokenNotFound{ID: id}
+	}
+	return tokens[0], nil
+}
+
+// List returns the user's feed tokens that have not been revoked, oldest
+// first.
+func (s *discussionFeedTokens) List(ctx context.Context, userID int32) ([]*types.DiscussionFeedToken, error) {
+	if Mocks.DiscussionFeedTokens.List != nil {
+		return Mocks.DiscussionFeedTokens.List(ctx, userID)
+	}
+	return s.list(ctx, "WHERE user_id=$1 AND revoked_at IS NULL ORDER BY id ASC", userID)
+}
+
+func (*discussionFeedTokens) list(ctx context.Context, where string, args ...interface{}) ([]*types.DiscussionFeedToken, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT id, user_id, kind, repo_id, created_at, last_used_at, revoked_at FROM discussion_feed_tokens "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []*types.DiscussionFeedToken{}
+	defer rows.Close()
+	for rows.Next() {
+		t := &types.DiscussionFeedToken{}
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Kind, &t.RepoID, &t.CreatedAt, &t.LastUsedAt, &t.RevokedAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Revoke revokes the feed token, so that its feed can no longer be read. It
+// returns ErrFeedTokenNotFound if there is no such token or it was already
+// revoked.
+func (*discussionFeedTokens) Revoke(ctx context.Context, id int64) error {
+	if Mocks.DiscussionFeedTokens.Revoke != nil {
+		return Mocks.DiscussionFeedTokens.Revoke(ctx, id)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_feed_tokens SET revoked_at=now() WHERE id=$1 AND revoked_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return &ErrFeedTokenNotFound{ID: id}
+	}
+	return nil
+}
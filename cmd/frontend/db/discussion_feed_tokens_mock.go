@@ -0,0 +1,15 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionFeedTokens struct {
+	Create  func(ctx context.Context, newToken *types.DiscussionFeedToken) (*types.DiscussionFeedToken, string, error)
+	Lookup  func(ctx context.Context, token string) (*types.DiscussionFeedToken, error)
+	GetByID func(ctx context.Context, id int64) (*types.DiscussionFeedToken, error)
+	List    func(ctx context.Context, userID int32) ([]*types.DiscussionFeedToken, error)
+	Revoke  func(ctx context.Context, id int64) error
+}
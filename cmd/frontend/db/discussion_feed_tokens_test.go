@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionFeedTokens(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, _ := createTestDiscussionThread(ctx, t)
+
+	repoFeed, repoValue, err := DiscussionFeedTokens.Create(ctx, &types.DiscussionFeedToken{UserID: user.ID, Kind: types.DiscussionFeedRepository, RepoID: &repo.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assignedFeed, _, err := DiscussionFeedTokens.Create(ctx, &types.DiscussionFeedToken{UserID: user.ID, Kind: types.DiscussionFeedAssigned})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := DiscussionFeedTokens.Create(ctx, &types.DiscussionFeedToken{UserID: user.ID, Kind: types.DiscussionFeedRepository}); err == nil {
+		t.Error("expected error creating repository feed without repository")
+	}
+	if _, _, err := DiscussionFeedTokens.Create(ctx, &types.DiscussionFeedToken{UserID: user.ID, Kind: "x"}); err == nil {
+		t.Error("expected error creating feed with invalid kind")
+	}
+
+	got, err := DiscussionFeedTokens.Lookup(ctx, repoValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != repoFeed.ID || got.UserID != user.ID || got.Kind != types.DiscussionFeedRepository || got.LastUsedAt == nil {
+		t.Errorf("got feed token %+v, want the repository feed token (used)", got)
+	}
+	if _, err := DiscussionFeedTokens.Lookup(ctx, "0000"); err != ErrInvalidToken {
+		t.Errorf("got error %v, want %v", err, ErrInvalidToken)
+	}
+
+	if err := DiscussionFeedTokens.Revoke(ctx, repoFeed.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscussionFeedTokens.Revoke(ctx, repoFeed.ID); err == nil {
+		t.Error("expected error revoking feed token twice")
+	}
+	if _, err := DiscussionFeedTokens.Lookup(ctx, repoValue); err != ErrInvalidToken {
+		t.Errorf("got error %v for revoked token, want %v", err, ErrInvalidToken)
+	}
+	tokens, err := DiscussionFeedTokens.List(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].ID != assignedFeed.ID {
+		t.Errorf("got feed tokens %+v, want only the assigned feed token", tokens)
+	}
+	if revoked, err := DiscussionFeedTokens.GetByID(ctx, repoFeed.ID); err != nil {
+		t.Fatal(err)
+	} else if revoked.RevokedAt == nil {
+		t.Error("want RevokedAt to be set")
+	}
+}
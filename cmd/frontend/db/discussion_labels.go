@@ -0,0 +1,291 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionLabels provides access to the `discussion_labels` and
+// `discussion_threads_labels` tables.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionLabels struct{}
+
+// ErrLabelNotFound is the error returned by Discussions methods to indicate
+// that the label could not be found.
+type ErrLabelNotFound struct {
+	// LabelID is the label that was not found.
+	LabelID int64
+}
+
+func (e *ErrLabelNotFound) Error() string {
+	return fmt.Sprintf("label %d not found", e.LabelID)
+}
+
+func (e *ErrLabelNotFound) NotFound() bool { return true }
+
+// ErrNameAlreadyExists is the error returned by Discussions methods to
+// indicate that a label, thread template, or saved reply could not be created
+// or renamed because another one already has the name.
+type ErrNameAlreadyExists struct {
+	// Message describes the conflict, e.g. "a label named "bug" already exists
+	// in this repository".
+	Message string
+}
+
+func (e *ErrNameAlreadyExists) Error() string { return e.Message }
+
+// labelColorPattern matches a hex color string such as "#ff0000".
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func (l *discussionLabels) Create(ctx context.Context, newLabel *types.DiscussionLabel) (*types.DiscussionLabel, error) {
+	if Mocks.DiscussionLabels.Create != nil {
+		return Mocks.DiscussionLabels.Create(ctx, newLabel)
+	}
+
+	// Validate the input label.
+	if newLabel == nil {
+		return nil, errors.New("newLabel is nil")
+	}
+	if newLabel.ID != 0 {
+		return nil, errors.New("newLabel.ID must be zero")
+	}
+	if newLabel.RepoID == 0 {
+		return nil, errors.New("newLabel.RepoID must be specified")
+	}
+	if strings.TrimSpace(newLabel.Name) == "" {
+		return nil, errors.New("newLabel.Name must be present (and not whitespace)")
+	}
+	if len([]rune(newLabel.Name)) > 100 {
+		return nil, errors.New("newLabel.Name too long (must be less than 100 UTF-8 characters)")
+	}
+	if !labelColorPattern.MatchString(newLabel.Color) {
+		return nil, errors.New(`newLabel.Color must be a hex color string (e.g. "#ff0000")`)
+	}
+	if !newLabel.CreatedAt.IsZero() {
+		return nil, errors.New("newLabel.CreatedAt must not be specified")
+	}
+	if !newLabel.UpdatedAt.IsZero() {
+		return nil, errors.New("newLabel.UpdatedAt must not be specified")
+	}
+
+	newLabel.CreatedAt = time.Now()
+	newLabel.UpdatedAt = newLabel.CreatedAt
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_labels(
+		repo_id,
+		name,
+		description,
+		color,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		newLabel.RepoID,
+		newLabel.Name,
+		newLabel.Description,
+		newLabel.Color,
+		newLabel.CreatedAt,
+		newLabel.UpdatedAt,
+	).Scan(&newLabel.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "discussion_labels_repo_id_name_idx" {
+			return nil, &ErrNameAlreadyExists{Message: fmt.Sprintf("a label named %q already exists in this repository", newLabel.Name)}
+		}
+		return nil, err
+	}
+	return newLabel, nil
+}
+
+func (l *discussionLabels) Get(ctx context.Context, labelID int64) (*types.DiscussionLabel, error) {
+	if Mocks.DiscussionLabels.Get != nil {
+		return Mocks.DiscussionLabels.Get(labelID)
+	}
+
+	labels, err := l.List(ctx, &DiscussionLabelsListOptions{
+		LabelIDs: []int64{labelID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, &ErrLabelNotFound{LabelID: labelID}
+	}
+	return labels[0], nil
+}
+
+// AddToThread adds the labels to the thread. Labels that are already present
+// on the thread are ignored. It returns the IDs of the labels that were newly
+// added.
+//
+// The caller is responsible for ensuring the labels belong to the thread's
+// repository.
+func (l *discussionLabels) AddToThread(ctx context.Context, threadID int64, labelIDs []int64) ([]int64, error) {
+	if Mocks.DiscussionLabels.AddToThread != nil {
+		return Mocks.DiscussionLabels.AddToThread(ctx, threadID, labelIDs)
+	}
+	return l.addToThread(ctx, dbconn.Global, threadID, labelIDs)
+}
+
+func (l *discussionLabels) addToThread(ctx context.Context, dbh dbHandle, threadID int64, labelIDs []int64) ([]int64, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+	return l.queryLabelIDs(ctx, dbh, `INSERT INTO discussion_threads_labels(thread_id, label_id)
+		SELECT $1, unnest($2::bigint[])
+		ON CONFLICT DO NOTHING
+		RETURNING label_id`,
+		threadID, pq.Array(labelIDs),
+	)
+}
+
+// RemoveFromThread removes the labels from the thread. Labels that are not
+// present on the thread are ignored. It returns the IDs of the labels that
+// were removed.
+func (l *discussionLabels) RemoveFromThread(ctx context.Context, threadID int64, labelIDs []int64) ([]int64, error) {
+	if Mocks.DiscussionLabels.RemoveFromThread != nil {
+		return Mocks.DiscussionLabels.RemoveFromThread(ctx, threadID, labelIDs)
+	}
+	return l.removeFromThread(ctx, dbconn.Global, threadID, labelIDs)
+}
+
+func (l *discussionLabels) removeFromThread(ctx context.Context, dbh dbHandle, threadID int64, labelIDs []int64) ([]int64, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+	return l.queryLabelIDs(ctx, dbh, "DELETE FROM discussion_threads_labels WHERE thread_id=$1 AND label_id = ANY($2) RETURNING label_id", threadID, pq.Array(labelIDs))
+}
+
+func (*discussionLabels) queryLabelIDs(ctx context.Context, dbh dbHandle, query string, args ...interface{}) ([]int64, error) {
+	rows, err := dbh.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	labelIDs := []int64{}
+	for rows.Next() {
+		var labelID int64
+		if err := rows.Scan(&labelID); err != nil {
+			return nil, err
+		}
+		labelIDs = append(labelIDs, labelID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return labelIDs, nil
+}
+
+type DiscussionLabelsListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// LabelIDs, when len() > 0, specifies that only labels with one of these
+	// IDs should be returned.
+	LabelIDs []int64
+
+	// RepoID, when non-zero, specifies that only labels in this repository
+	// should be returned.
+	RepoID api.RepoID
+
+	// ThreadID, when non-zero, specifies that only labels that have been added
+	// to this thread should be returned.
+	ThreadID int64
+}
+
+func (l *discussionLabels) List(ctx context.Context, opts *DiscussionLabelsListOptions) ([]*types.DiscussionLabel, error) {
+	if Mocks.DiscussionLabels.List != nil {
+		return Mocks.DiscussionLabels.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := l.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY name ASC, id ASC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+	return l.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (l *discussionLabels) Count(ctx context.Context, opts *DiscussionLabelsListOptions) (int, error) {
+	if Mocks.DiscussionLabels.Count != nil {
+		return Mocks.DiscussionLabels.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := l.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
+	return l.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (*discussionLabels) getListSQL(opts *DiscussionLabelsListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.LabelIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id = ANY(%v)", pq.Array(opts.LabelIDs)))
+	}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id=%v", opts.RepoID))
+	}
+	if opts.ThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT label_id FROM discussion_threads_labels WHERE thread_id=%v)", opts.ThreadID))
+	}
+	return conds
+}
+
+func (*discussionLabels) getCountBySQL(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+	rows := dbconn.Global.QueryRowContext(ctx, "SELECT count(id) FROM discussion_labels l "+query, args...)
+	err := rows.Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
+
+// getBySQL returns labels matching the SQL query, if any exist.
+func (*discussionLabels) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionLabel, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			l.id,
+			l.repo_id,
+			l.name,
+			l.description,
+			l.color,
+			l.created_at,
+			l.updated_at
+		FROM discussion_labels l `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []*types.DiscussionLabel{}
+	defer rows.Close()
+	for rows.Next() {
+		label := &types.DiscussionLabel{}
+		err := rows.Scan(
+			&label.ID,
+			&label.RepoID,
+			&label.Name,
+			&label.Description,
+			&label.Color,
+			&label.CreatedAt,
+			&label.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
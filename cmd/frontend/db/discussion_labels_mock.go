@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionLabels struct {
+	Create           func(ctx context.Context, newLabel *types.DiscussionLabel) (*types.DiscussionLabel, error)
+	Get              func(labelID int64) (*types.DiscussionLabel, error)
+	AddToThread      func(ctx context.Context, threadID int64, labelIDs []int64) ([]int64, error)
+	RemoveFromThread func(ctx context.Context, threadID int64, labelIDs []int64) ([]int64, error)
+	List             func(ctx context.Context, opts *DiscussionLabelsListOptions) ([]*types.DiscussionLabel, error)
+	Count            func(ctx context.Context, opts *DiscussionLabelsListOptions) (int, error)
+}
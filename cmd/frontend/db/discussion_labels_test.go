@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionLabels_CreateGet(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	_, repo, _ := createTestDiscussionThread(ctx, t)
+
+	label, err := DiscussionLabels.Create(ctx, &types.DiscussionLabel{
+		RepoID:      repo.ID,
+		Name:        "bug",
+		Description: strPtr("Something isn't working"),
+		Color:       "#d73a4a",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotLabel, err := DiscussionLabels.Get(ctx, label.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotLabel.Name != label.Name || gotLabel.Color != label.Color || gotLabel.RepoID != label.RepoID {
+		t.Errorf("got label %+v, want %+v", gotLabel, label)
+	}
+
+	// Label names are unique per repository.
+	if _, err := DiscussionLabels.Create(ctx, &types.DiscussionLabel{RepoID: repo.ID, Name: "bug", Color: "#000000"}); err == nil {
+		t.Error("expected error creating label with duplicate name")
+	}
+
+	// Colors must be hex colors.
+	if _, err := DiscussionLabels.Create(ctx, &types.DiscussionLabel{RepoID: repo.ID, Name: "other", Color: "red"}); err == nil {
+		t.Error("expected error creating label with invalid color")
+	}
+
+	if _, err := DiscussionLabels.Get(ctx, label.ID+1); err == nil {
+		t.Error("expected label not found error")
+	} else if _, ok := err.(*ErrLabelNotFound); !ok {
+		t.Errorf("got error %v, want label not found", err)
+	}
+}
+
+func TestDiscussionLabels_AddRemoveThread(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	_, repo, thread := createTestDiscussionThread(ctx, t)
+
+	var labelIDs []int64
+	for _, name := range []string{"bug", "enhancement", "question"} {
+		label, err := DiscussionLabels.Create(ctx, &types.DiscussionLabel{RepoID: repo.ID, Name: name, Color: "#ffffff"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	threadLabelIDs := func() []int64 {
+		labels, err := DiscussionLabels.List(ctx, &DiscussionLabelsListOptions{ThreadID: thread.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []int64{}
+		for _, label := range labels {
+			ids = append(ids, label.ID)
+		}
+		return ids
+	}
+
+	// Adding the same label twice is a no-op.
+	added, err := DiscussionLabels.AddToThread(ctx, thread.ID, labelIDs[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := labelIDs[:2]; !reflect.DeepEqual(added, want) {
+		t.Errorf("got added labels %v, want %v", added, want)
+	}
+	added, err = DiscussionLabels.AddToThread(ctx, thread.ID, labelIDs[1:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 0 {
+		t.Errorf("got added labels %v, want none", added)
+	}
+	if got, want := threadLabelIDs(), labelIDs[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got thread labels %v, want %v", got, want)
+	}
+
+	removed, err := DiscussionLabels.RemoveFromThread(ctx, thread.ID, labelIDs[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := labelIDs[:1]; !reflect.DeepEqual(removed, want) {
+		t.Errorf("got removed labels %v, want %v", removed, want)
+	}
+	if got, want := threadLabelIDs(), labelIDs[1:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got thread labels %v, want %v", got, want)
+	}
+
+	count, err := DiscussionLabels.Count(ctx, &DiscussionLabelsListOptions{RepoID: repo.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("got %d labels in repository, want 3", count)
+	}
+}
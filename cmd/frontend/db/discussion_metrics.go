@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var discussionsQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "src",
+	Subsystem: "discussions",
+	Name:      "db_query_duration_seconds",
+	Help:      "Time spent on discussion thread and comment store methods.",
+	Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+}, []string{"method", "success"})
+
+var discussionsCacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "src",
+	Subsystem: "discussions",
+	Name:      "cache_requests_total",
+	Help:      "The total number of reads from the discussions cache, by cache (threads or thread comments) and result (hit or miss).",
+}, []string{"cache", "result"})
+
+func init() {
+	prometheus.MustRegister(discussionsQueryDuration)
+	prometheus.MustRegister(discussionsCacheRequestsTotal)
+}
+
+// observeDiscussionsQuery starts a trace span and starts timing a call to a
+// discussions store method (such as "DiscussionThreads.List"). The returned
+// func finishes the span and records the duration and whether *err is nil. It
+// is meant to be deferred, with err pointing to the method's named error
+// result.
+func observeDiscussionsQuery(ctx context.Context, method string, err *error) (context.Context, func()) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db."+method)
+	start := time.Now()
+	return ctx, func() {
+		if *err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", (*err).Error())
+		}
+		span.Finish()
+		discussionsQueryDuration.WithLabelValues(method, strconv.FormatBool(*err == nil)).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,330 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionMilestones provides access to the `discussion_milestones` table
+// and the `milestone_id` column of the `discussion_threads` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionMilestones struct{}
+
+// ErrMilestoneNotFound is the error returned by Discussions methods to
+// indicate that the milestone could not be found.
+type ErrMilestoneNotFound struct {
+	// MilestoneID is the milestone that was not found.
+	MilestoneID int64
+}
+
+func (e *ErrMilestoneNotFound) Error() string {
+	return fmt.Sprintf("milestone %d not found", e.MilestoneID)
+}
+
+func (e *ErrMilestoneNotFound) NotFound() bool { return true }
+
+func (m *discussionMilestones) Create(ctx context.Context, newMilestone *types.DiscussionMilestone) (*types.DiscussionMilestone, error) {
+	if Mocks.DiscussionMilestones.Create != nil {
+		return Mocks.DiscussionMilestones.Create(ctx, newMilestone)
+	}
+
+	// Validate the input milestone.
+	if newMilestone == nil {
+		return nil, errors.New("newMilestone is nil")
+	}
+	if newMilestone.ID != 0 {
+		return nil, errors.New("newMilestone.ID must be zero")
+	}
+	if (newMilestone.RepoID == nil) == (newMilestone.OrgID == nil) {
+		return nil, errors.New("exactly one of newMilestone.RepoID and newMilestone.OrgID must be specified")
+	}
+	if err := validateMilestoneTitle(newMilestone.Title); err != nil {
+		return nil, err
+	}
+	if newMilestone.ClosedAt != nil {
+		return nil, errors.New("newMilestone.ClosedAt must not be specified")
+	}
+	if !newMilestone.CreatedAt.IsZero() {
+		return nil, errors.New("newMilestone.CreatedAt must not be specified")
+	}
+	if !newMilestone.UpdatedAt.IsZero() {
+		return nil, errors.New("newMilestone.UpdatedAt must not be specified")
+	}
+
+	newMilestone.CreatedAt = time.Now()
+	newMilestone.UpdatedAt = newMilestone.CreatedAt
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_milestones(
+		repo_id,
+		org_id,
+		title,
+		description,
+		due_date,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		newMilestone.RepoID,
+		newMilestone.OrgID,
+		newMilestone.Title,
+		newMilestone.Description,
+		newMilestone.DueDate,
+		newMilestone.CreatedAt,
+		newMilestone.UpdatedAt,
+	).Scan(&newMilestone.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newMilestone, nil
+}
+
+func validateMilestoneTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return errors.New("milestone title must be present (and not whitespace)")
+	}
+	if len([]rune(title)) > 200 {
+		return errors.New("milestone title too long (must be less than 200 UTF-8 characters)")
+	}
+	return nil
+}
+
+func (m *discussionMilestones) Get(ctx context.Context, milestoneID int64) (*types.DiscussionMilestone, error) {
+	if Mocks.DiscussionMilestones.Get != nil {
+		return Mocks.DiscussionMilestones.Get(milestoneID)
+	}
+
+	milestones, err := m.List(ctx, &DiscussionMilestonesListOptions{
+		MilestoneIDs: []int64{milestoneID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(milestones) == 0 {
+		return nil, &ErrMilestoneNotFound{MilestoneID: milestoneID}
+	}
+	return milestones[0], nil
+}
+
+type DiscussionMilestonesUpdateOptions struct {
+	// Title, when non-nil, updates the milestone's title.
+	Title *string
+
+	// Description, when non-nil, updates the milestone's description. An
+	// empty string clears the description.
+	Description *string
+
+	// DueDate, when non-nil, updates the milestone's due date. ClearDueDate
+	// removes it.
+	DueDate      *time.Time
+	ClearDueDate bool
+
+	// Close, when non-nil, specifies whether the milestone is closed or not.
+	Close *bool
+}
+
+func (m *discussionMilestones) Update(ctx context.Context, milestoneID int64, opts *DiscussionMilestonesUpdateOptions) (*types.DiscussionMilestone, error) {
+	if Mocks.DiscussionMilestones.Update != nil {
+		return Mocks.DiscussionMilestones.Update(ctx, milestoneID, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	if opts.DueDate != nil && opts.ClearDueDate {
+		return nil, errors.New("only one of DueDate and ClearDueDate may be specified")
+	}
+	now := time.Now()
+
+	sets := []*sqlf.Query{sqlf.Sprintf("updated_at=%v", now)}
+	if opts.Title != nil {
+		if err := validateMilestoneTitle(*opts.Title); err != nil {
+			return nil, err
+		}
+		sets = append(sets, sqlf.Sprintf("title=%v", *opts.Title))
+	}
+	if opts.Description != nil {
+		var description *string
+		if *opts.Description != "" {
+			description = opts.Description
+		}
+		sets = append(sets, sqlf.Sprintf("description=%v", description))
+	}
+	if opts.DueDate != nil {
+		sets = append(sets, sqlf.Sprintf("due_date=%v", *opts.DueDate))
+	}
+	if opts.ClearDueDate {
+		sets = append(sets, sqlf.Sprintf("due_date=NULL"))
+	}
+	if opts.Close != nil {
+		var closedAt *time.Time
+		if *opts.Close {
+			closedAt = &now
+		}
+		sets = append(sets, sqlf.Sprintf("closed_at=%v", closedAt))
+	}
+	q := sqlf.Sprintf("UPDATE discussion_milestones SET %s WHERE id=%v", sqlf.Join(sets, ", "), milestoneID)
+	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if nrows == 0 {
+		return nil, &ErrMilestoneNotFound{MilestoneID: milestoneID}
+	}
+	return m.Get(ctx, milestoneID)
+}
+
+// SetOnThread sets the milestone of the thread. If milestoneID is nil, the
+// thread's milestone is cleared.
+//
+// The caller is responsible for ensuring the milestone may be used on the
+// thread.
+func (m *discussionMilestones) SetOnThread(ctx context.Context, threadID int64, milestoneID *int64) error {
+	if Mocks.DiscussionMilestones.SetOnThread != nil {
+		return Mocks.DiscussionMilestones.SetOnThread(ctx, threadID, milestoneID)
+	}
+	defer invalidateDiscussionThreads(threadID)
+	res, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET milestone_id=$1, updated_at=now() WHERE id=$2 AND deleted_at IS NULL", milestoneID, threadID)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return &ErrThreadNotFound{ThreadID: threadID}
+	}
+	return nil
+}
+
+type DiscussionMilestonesListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// MilestoneIDs, when len() > 0, specifies that only milestones with one
+	// of these IDs should be returned.
+	MilestoneIDs []int64
+
+	// RepoID, when non-zero, specifies that only milestones in this
+	// repository should be returned.
+	RepoID api.RepoID
+
+	// OrgID, when non-zero, specifies that only milestones in this
+	// organization should be returned.
+	OrgID int32
+
+	// Closed, when non-nil, specifies whether only closed (true) or only open
+	// (false) milestones should be returned.
+	Closed *bool
+}
+
+func (m *discussionMilestones) List(ctx context.Context, opts *DiscussionMilestonesListOptions) ([]*types.DiscussionMilestone, error) {
+	if Mocks.DiscussionMilestones.List != nil {
+		return Mocks.DiscussionMilestones.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := m.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY due_date ASC NULLS LAST, id ASC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+	return m.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (m *discussionMilestones) Count(ctx context.Context, opts *DiscussionMilestonesListOptions) (int, error) {
+	if Mocks.DiscussionMilestones.Count != nil {
+		return Mocks.DiscussionMilestones.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := m.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
+	return m.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (*discussionMilestones) getListSQL(opts *DiscussionMilestonesListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.MilestoneIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id = ANY(%v)", pq.Array(opts.MilestoneIDs)))
+	}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id=%v", opts.RepoID))
+	}
+	if opts.OrgID != 0 {
+		conds = append(conds, sqlf.Sprintf("org_id=%v", opts.OrgID))
+	}
+	if opts.Closed != nil {
+		if *opts.Closed {
+			conds = append(conds, sqlf.Sprintf("closed_at IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("closed_at IS NULL"))
+		}
+	}
+	return conds
+}
+
+func (*discussionMilestones) getCountBySQL(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+	rows := dbconn.Global.QueryRowContext(ctx, "SELECT count(id) FROM discussion_milestones m "+query, args...)
+	err := rows.Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
+
+// getBySQL returns milestones matching the SQL query, if any exist.
+func (*discussionMilestones) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionMilestone, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			m.id,
+			m.repo_id,
+			m.org_id,
+			m.title,
+			m.description,
+			m.due_date,
+			m.closed_at,
+			m.created_at,
+			m.updated_at
+		FROM discussion_milestones m `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones := []*types.DiscussionMilestone{}
+	defer rows.Close()
+	for rows.Next() {
+		milestone := &types.DiscussionMilestone{}
+		err := rows.Scan(
+			&milestone.ID,
+			&milestone.RepoID,
+			&milestone.OrgID,
+			&milestone.Title,
+			&milestone.Description,
+			&milestone.DueDate,
+			&milestone.ClosedAt,
+			&milestone.CreatedAt,
+			&milestone.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		milestones = append(milestones, milestone)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return milestones, nil
+}
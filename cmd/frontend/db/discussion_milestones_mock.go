@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionMilestones struct {
+	Create      func(ctx context.Context, newMilestone *types.DiscussionMilestone) (*types.DiscussionMilestone, error)
+	Get         func(milestoneID int64) (*types.DiscussionMilestone, error)
+	Update      func(ctx context.Context, milestoneID int64, opts *DiscussionMilestonesUpdateOptions) (*types.DiscussionMilestone, error)
+	SetOnThread func(ctx context.Context, threadID int64, milestoneID *int64) error
+	List        func(ctx context.Context, opts *DiscussionMilestonesListOptions) ([]*types.DiscussionMilestone, error)
+	Count       func(ctx context.Context, opts *DiscussionMilestonesListOptions) (int, error)
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionMilestones_CreateUpdateList(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	_, repo, thread := createTestDiscussionThread(ctx, t)
+	org, err := Orgs.Create(ctx, "o", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A milestone must belong to exactly one of a repository or organization.
+	if _, err := DiscussionMilestones.Create(ctx, &types.DiscussionMilestone{Title: "v1"}); err == nil {
+		t.Error("expected error creating milestone without an owner")
+	}
+
+	repoMilestone, err := DiscussionMilestones.Create(ctx, &types.DiscussionMilestone{RepoID: &repo.ID, Title: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgMilestone, err := DiscussionMilestones.Create(ctx, &types.DiscussionMilestone{OrgID: &org.ID, Title: "Q1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Close the repository milestone.
+	closed, err := DiscussionMilestones.Update(ctx, repoMilestone.ID, &DiscussionMilestonesUpdateOptions{
+		Title: strPtr("v1.0"),
+		Close: boolPtr(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Title != "v1.0" || closed.ClosedAt == nil {
+		t.Errorf("got milestone %+v, want closed milestone titled v1.0", closed)
+	}
+
+	// List milestones.
+	milestoneIDs := func(opts *DiscussionMilestonesListOptions) []int64 {
+		t.Helper()
+		milestones, err := DiscussionMilestones.List(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []int64{}
+		for _, m := range milestones {
+			ids = append(ids, m.ID)
+		}
+		return ids
+	}
+	if got, want := milestoneIDs(&DiscussionMilestonesListOptions{RepoID: repo.ID}), []int64{repoMilestone.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got milestones %v, want %v", got, want)
+	}
+	if got, want := milestoneIDs(&DiscussionMilestonesListOptions{OrgID: org.ID}), []int64{orgMilestone.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got milestones %v, want %v", got, want)
+	}
+	if got, want := milestoneIDs(&DiscussionMilestonesListOptions{Closed: boolPtr(false)}), []int64{orgMilestone.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got open milestones %v, want %v", got, want)
+	}
+
+	// Set and clear the thread's milestone.
+	if err := DiscussionMilestones.SetOnThread(ctx, thread.ID, &orgMilestone.ID); err != nil {
+		t.Fatal(err)
+	}
+	gotThread, err := DiscussionThreads.Get(ctx, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotThread.MilestoneID == nil || *gotThread.MilestoneID != orgMilestone.ID {
+		t.Errorf("got thread milestone %v, want %d", gotThread.MilestoneID, orgMilestone.ID)
+	}
+	count, err := DiscussionThreads.Count(ctx, &DiscussionThreadsListOptions{MilestoneID: orgMilestone.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %d threads in milestone, want 1", count)
+	}
+	if err := DiscussionMilestones.SetOnThread(ctx, thread.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	gotThread, err = DiscussionThreads.Get(ctx, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotThread.MilestoneID != nil {
+		t.Errorf("got thread milestone %v, want nil", *gotThread.MilestoneID)
+	}
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionRoles provides access to the `discussion_roles` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionRoles struct{}
+
+// Set gives the user the role in the repository or organization, replacing
+// the role that the user previously had there (if any).
+func (*discussionRoles) Set(ctx context.Context, role *types.DiscussionRole) error {
+	if Mocks.DiscussionRoles.Set != nil {
+		return Mocks.DiscussionRoles.Set(ctx, role)
+	}
+	if role == nil {
+		return errors.New("role is nil")
+	}
+	if (role.RepoID == nil) == (role.OrgID == nil) {
+		return errors.New("exactly one of role.RepoID and role.OrgID must be specified")
+	}
+	switch role.Role {
+	case types.DiscussionRoleContributor, types.DiscussionRoleTriager, types.DiscussionRoleMaintainer:
+	default:
+		return errors.New("invalid role.Role")
+	}
+
+	scope := "repo_id"
+	if role.OrgID != nil {
+		scope = "org_id"
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_roles(user_id, repo_id, org_id, role) VALUES($1, $2, $3, $4)
+		ON CONFLICT (`+scope+`, user_id) WHERE `+scope+` IS NOT NULL DO UPDATE SET role=excluded.role`,
+		role.UserID, role.RepoID, role.OrgID, role.Role)
+	return err
+}
+
+// Remove removes the user's role in the repository or organization (exactly
+// one of which must be specified). Removing a role that the user does not
+// have is a no-op. It reports whether the user previously had a role.
+func (*discussionRoles) Remove(ctx context.Context, userID int32, repoID *api.RepoID, orgID *int32) (bool, error) {
+	if Mocks.DiscussionRoles.Remove != nil {
+		return Mocks.DiscussionRoles.Remove(ctx, userID, repoID, orgID)
+	}
+	if (repoID == nil) == (orgID == nil) {
+		return false, errors.New("exactly one of repoID and orgID must be specified")
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_roles WHERE user_id=$1 AND (repo_id=$2 OR org_id=$3)", userID, repoID, orgID)
+	if err != nil {
+		return false, err
+	}
+	nrows, err := res.RowsAffected()
+	return nrows > 0, err
+}
+
+type DiscussionRolesListOptions struct {
+	// UserID, when non-zero, specifies that only roles of this user should be
+	// returned.
+	UserID int32
+
+	// RepoID, when non-zero, specifies that only roles in this repository
+	// should be returned.
+	RepoID api.RepoID
+
+	// OrgID, when non-zero, specifies that only roles in this organization
+	// should be returned.
+	OrgID int32
+}
+
+// List returns the roles matching the options, in the order in which they were
+// created.
+func (*discussionRoles) List(ctx context.Context, opts *DiscussionRolesListOptions) ([]*types.DiscussionRole, error) {
+	if Mocks.DiscussionRoles.List != nil {
+		return Mocks.DiscussionRoles.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id=%v", opts.UserID))
+	}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id=%v", opts.RepoID))
+	}
+	if opts.OrgID != 0 {
+		conds = append(conds, sqlf.Sprintf("org_id=%v", opts.OrgID))
+	}
+	q := sqlf.Sprintf("SELECT user_id, repo_id, org_id, role, created_at FROM discussion_roles WHERE %s ORDER BY created_at ASC, id ASC", sqlf.Join(conds, "AND"))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	roles := []*types.DiscussionRole{}
+	for rows.Next() {
+		var role types.DiscussionRole
+		if err := rows.Scan(&role.UserID, &role.RepoID, &role.OrgID, &role.Role, &role.CreatedAt); err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
@@ -0,0 +1,14 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type MockDiscussionRoles struct {
+	Set    func(ctx context.Context, role *types.DiscussionRole) error
+	Remove func(ctx context.Context, userID int32, repoID *api.RepoID, orgID *int32) (bool, error)
+	List   func(ctx context.Context, opts *DiscussionRolesListOptions) ([]*types.DiscussionRole, error)
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionRoles(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, _ := createTestDiscussionThread(ctx, t)
+	org, err := Orgs.Create(ctx, "o", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type role struct {
+		repo bool
+		kind types.DiscussionRoleKind
+	}
+	roles := func(opts *DiscussionRolesListOptions) []role {
+		list, err := DiscussionRoles.List(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var roles []role
+		for _, r := range list {
+			if r.UserID != user.ID {
+				t.Errorf("got user %d, want %d", r.UserID, user.ID)
+			}
+			roles = append(roles, role{repo: r.RepoID != nil, kind: r.Role})
+		}
+		return roles
+	}
+
+	// Setting a role again replaces it.
+	for _, kind := range []types.DiscussionRoleKind{types.DiscussionRoleContributor, types.DiscussionRoleMaintainer} {
+		if err := DiscussionRoles.Set(ctx, &types.DiscussionRole{UserID: user.ID, RepoID: &repo.ID, Role: kind}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := DiscussionRoles.Set(ctx, &types.DiscussionRole{UserID: user.ID, OrgID: &org.ID, Role: types.DiscussionRoleTriager}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := roles(&DiscussionRolesListOptions{UserID: user.ID}), []role{{repo: true, kind: types.DiscussionRoleMaintainer}, {kind: types.DiscussionRoleTriager}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got roles %+v, want %+v", got, want)
+	}
+	if got, want := roles(&DiscussionRolesListOptions{OrgID: org.ID}), []role{{kind: types.DiscussionRoleTriager}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got org roles %+v, want %+v", got, want)
+	}
+
+	if err := DiscussionRoles.Set(ctx, &types.DiscussionRole{UserID: user.ID, RepoID: &repo.ID, OrgID: &org.ID, Role: types.DiscussionRoleTriager}); err == nil {
+		t.Error("expected error for a role in both a repository and an organization")
+	}
+	if err := DiscussionRoles.Set(ctx, &types.DiscussionRole{UserID: user.ID, RepoID: &repo.ID, Role: "x"}); err == nil {
+		t.Error("expected error for invalid role")
+	}
+
+	for i, want := range []bool{true, false} {
+		removed, err := DiscussionRoles.Remove(ctx, user.ID, &repo.ID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if removed != want {
+			t.Errorf("%d: got removed %v, want %v", i, removed, want)
+		}
+	}
+	if got, want := roles(&DiscussionRolesListOptions{RepoID: repo.ID}), []role(nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("got repo roles %+v, want none", got)
+	}
+}
@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionRules provides access to the `discussion_rules` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionRules struct{}
+
+// ErrRuleNotFound is the error returned by DiscussionRules methods to indicate
+// that the rule could not be found.
+type ErrRuleNotFound struct {
+	// RuleID is the rule that was not found.
+	RuleID int64
+}
+
+func (e *ErrRuleNotFound) Error() string {
+	return fmt.Sprintf("rule %d not found", e.RuleID)
+}
+
+func (e *ErrRuleNotFound) NotFound() bool { return true }
+
+// Create creates a rule. The caller is responsible for checking that the
+// rule's labels belong to the rule's repository.
+func (r *discussionRules) Create(ctx context.Context, newRule *types.DiscussionRule) (*types.DiscussionRule, error) {
+	if Mocks.DiscussionRules.Create != nil {
+		return Mocks.DiscussionRules.Create(ctx, newRule)
+	}
+
+	// Validate the input rule.
+	if newRule == nil {
+		return nil, errors.New("newRule is nil")
+	}
+	if newRule.ID != 0 {
+		return nil, errors.New("newRule.ID must be zero")
+	}
+	if newRule.RepoID == 0 {
+		return nil, errors.New("newRule.RepoID must be specified")
+	}
+	if strings.TrimSpace(newRule.Name) == "" {
+		return nil, errors.New("newRule.Name must be present (and not whitespace)")
+	}
+	if strings.TrimSpace(newRule.Query) == "" {
+		return nil, errors.New("newRule.Query must be present (and not whitespace)")
+	}
+	if !newRule.CreatedAt.IsZero() || !newRule.UpdatedAt.IsZero() {
+		return nil, errors.New("newRule.CreatedAt and UpdatedAt must not be specified")
+	}
+
+	newRule.CreatedAt = time.Now()
+	newRule.UpdatedAt = newRule.CreatedAt
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_rules(
+		repo_id,
+		name,
+		query,
+		label_ids,
+		assignee_user_ids,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		newRule.RepoID,
+		newRule.Name,
+		newRule.Query,
+		pq.Array(nonNilInt64s(newRule.LabelIDs)),
+		pq.Array(nonNilInt32s(newRule.AssigneeUserIDs)),
+		newRule.CreatedAt,
+		newRule.UpdatedAt,
+	).Scan(&newRule.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newRule, nil
+}
+
+func (r *discussionRules) Get(ctx context.Context, ruleID int64) (*types.DiscussionRule, error) {
+	if Mocks.DiscussionRules.Get != nil {
+		return Mocks.DiscussionRules.Get(ctx, ruleID)
+	}
+	rules, err := r.getBySQL(ctx, sqlf.Sprintf("WHERE id=%v", ruleID))
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, &ErrRuleNotFound{RuleID: ruleID}
+	}
+	return rules[0], nil
+}
+
+// List returns the rules of the repository, oldest first (which is the order
+// in which they are applied).
+func (r *discussionRules) List(ctx context.Context, repoID api.RepoID) ([]*types.DiscussionRule, error) {
+	if Mocks.DiscussionRules.List != nil {
+		return Mocks.DiscussionRules.List(ctx, repoID)
+	}
+	return r.getBySQL(ctx, sqlf.Sprintf("WHERE repo_id=%v ORDER BY id ASC", repoID))
+}
+
+type DiscussionRulesUpdateOptions struct {
+	Name            *string
+	Query           *string
+	LabelIDs        *[]int64
+	AssigneeUserIDs *[]int32
+}
+
+// Update updates the rule's fields that are set in the options. The caller is
+// responsible for checking that the rule's labels belong to the rule's
+// repository.
+func (r *discussionRules) Update(ctx context.Context, ruleID int64, opts *DiscussionRulesUpdateOptions) (*types.DiscussionRule, error) {
+	if Mocks.DiscussionRules.Update != nil {
+		return Mocks.DiscussionRules.Update(ctx, ruleID, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	if opts.Name != nil && strings.TrimSpace(*opts.Name) == "" {
+		return nil, errors.New("Name must be present (and not whitespace)")
+	}
+	if opts.Query != nil && strings.TrimSpace(*opts.Query) == "" {
+		return nil, errors.New("Query must be present (and not whitespace)")
+	}
+	now := time.Now()
+
+	var sets []*sqlf.Query
+	if opts.Name != nil {
+		sets = append(sets, sqlf.Sprintf("name=%v", *opts.Name))
+	}
+	if opts.Query != nil {
+		sets = append(sets, sqlf.Sprintf("query=%v", *opts.Query))
+	}
+	if opts.LabelIDs != nil {
+		sets = append(sets, sqlf.Sprintf("label_ids=%v", pq.Array(nonNilInt64s(*opts.LabelIDs))))
+	}
+	if opts.AssigneeUserIDs != nil {
+		sets = append(sets, sqlf.Sprintf("assignee_user_ids=%v", pq.Array(nonNilInt32s(*opts.AssigneeUserIDs))))
+	}
+	if len(sets) == 0 {
+		return r.Get(ctx, ruleID)
+	}
+	sets = append(sets, sqlf.Sprintf("updated_at=%v", now))
+	q := sqlf.Sprintf("UPDATE discussion_rules SET %s WHERE id=%v", sqlf.Join(sets, ", "), ruleID)
+	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if nrows == 0 {
+		return nil, &ErrRuleNotFound{RuleID: ruleID}
+	}
+	return r.Get(ctx, ruleID)
+}
+
+func (r *discussionRules) Delete(ctx context.Context, ruleID int64) error {
+	if Mocks.DiscussionRules.Delete != nil {
+		return Mocks.DiscussionRules.Delete(ctx, ruleID)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_rules WHERE id=$1", ruleID)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return &ErrRuleNotFound{RuleID: ruleID}
+	}
+	return nil
+}
+
+func (*discussionRules) getBySQL(ctx context.Context, query *sqlf.Query) ([]*types.DiscussionRule, error) {
+	q := sqlf.Sprintf("SELECT id, repo_id, name, query, label_ids, assignee_user_ids, created_at, updated_at FROM discussion_rules %s", query)
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	rules := []*types.DiscussionRule{}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			rule            = &types.DiscussionRule{}
+			labelIDs        pq.Int64Array
+			assigneeUserIDs pq.Int64Array
+		)
+		if err := rows.Scan(&rule.ID, &rule.RepoID, &rule.Name, &rule.Query, &labelIDs, &assigneeUserIDs, &rule.CreatedAt, &rule.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rule.LabelIDs = []int64(labelIDs)
+		for _, id := range assigneeUserIDs {
+			rule.AssigneeUserIDs = append(rule.AssigneeUserIDs, int32(id))
+		}
+		rules = append(rules, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func nonNilInt64s(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
+
+func nonNilInt32s(ids []int32) []int32 {
+	if ids == nil {
+		return []int32{}
+	}
+	return ids
+}
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type MockDiscussionRules struct {
+	Create func(ctx context.Context, newRule *types.DiscussionRule) (*types.DiscussionRule, error)
+	Get    func(ctx context.Context, ruleID int64) (*types.DiscussionRule, error)
+	List   func(ctx context.Context, repoID api.RepoID) ([]*types.DiscussionRule, error)
+	Update func(ctx context.Context, ruleID int64, opts *DiscussionRulesUpdateOptions) (*types.DiscussionRule, error)
+	Delete func(ctx context.Context, ruleID int64) error
+}
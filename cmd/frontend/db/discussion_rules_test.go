@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, _ := createTestDiscussionThread(ctx, t)
+
+	rule, err := DiscussionRules.Create(ctx, &types.DiscussionRule{
+		RepoID:          repo.ID,
+		Name:            "Triage bugs",
+		Query:           "title:bug",
+		LabelIDs:        []int64{1, 2},
+		AssigneeUserIDs: []int32{user.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DiscussionRules.Get(ctx, rule.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{1, 2}; !reflect.DeepEqual(got.LabelIDs, want) {
+		t.Errorf("got LabelIDs %v, want %v", got.LabelIDs, want)
+	}
+	if want := []int32{user.ID}; !reflect.DeepEqual(got.AssigneeUserIDs, want) {
+		t.Errorf("got AssigneeUserIDs %v, want %v", got.AssigneeUserIDs, want)
+	}
+
+	// Invalid rules are rejected.
+	for _, r := range []*types.DiscussionRule{
+		{RepoID: repo.ID, Name: " ", Query: "title:bug"},
+		{RepoID: repo.ID, Name: "n", Query: ""},
+		{Name: "n", Query: "title:bug"},
+	} {
+		if _, err := DiscussionRules.Create(ctx, r); err == nil {
+			t.Errorf("rule %+v: got nil error, want error", r)
+		}
+	}
+
+	query := "label:bug"
+	labelIDs := []int64{}
+	if _, err := DiscussionRules.Update(ctx, rule.ID, &DiscussionRulesUpdateOptions{Query: &query, LabelIDs: &labelIDs}); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := DiscussionRules.List(ctx, repo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Query != query || len(rules[0].LabelIDs) != 0 || rules[0].Name != "Triage bugs" {
+		t.Errorf("got rules %+v, want 1 updated rule", rules)
+	}
+
+	if err := DiscussionRules.Delete(ctx, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionRules.Get(ctx, rule.ID); err == nil {
+		t.Error("got nil error getting deleted rule, want not found")
+	}
+	if err := DiscussionRules.Delete(ctx, rule.ID); err == nil {
+		t.Error("got nil error deleting deleted rule, want not found")
+	}
+}
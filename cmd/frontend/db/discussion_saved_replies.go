@@ -0,0 +1,287 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionSavedReplies provides access to the `discussion_saved_replies`
+// table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionSavedReplies struct{}
+
+// ErrSavedReplyNotFound is the error returned by Discussions methods to
+// indicate that the saved reply could not be found.
+type ErrSavedReplyNotFound struct {
+	// SavedReplyID is the saved reply that was not found.
+	SavedReplyID int64
+}
+
+func (e *ErrSavedReplyNotFound) Error() string {
+	return fmt.Sprintf("saved reply %d not found", e.SavedReplyID)
+}
+
+func (e *ErrSavedReplyNotFound) NotFound() bool { return true }
+
+func (r *discussionSavedReplies) Create(ctx context.Context, newReply *types.DiscussionSavedReply) (*types.DiscussionSavedReply, error) {
+	if Mocks.DiscussionSavedReplies.Create != nil {
+		return Mocks.DiscussionSavedReplies.Create(ctx, newReply)
+	}
+
+	// Validate the input saved reply.
+	if newReply == nil {
+		return nil, errors.New("newReply is nil")
+	}
+	if newReply.ID != 0 {
+		return nil, errors.New("newReply.ID must be zero")
+	}
+	if newReply.UserID == 0 {
+		return nil, errors.New("newReply.UserID must be specified")
+	}
+	if err := validateSavedReplyName(newReply.Name); err != nil {
+		return nil, err
+	}
+	if err := validateSavedReplyContents(newReply.Contents); err != nil {
+		return nil, err
+	}
+	if !newReply.CreatedAt.IsZero() {
+		return nil, errors.New("newReply.CreatedAt must not be specified")
+	}
+	if !newReply.UpdatedAt.IsZero() {
+		return nil, errors.New("newReply.UpdatedAt must not be specified")
+	}
+
+	newReply.CreatedAt = time.Now()
+	newReply.UpdatedAt = newReply.CreatedAt
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_saved_replies(
+		user_id,
+		name,
+		contents,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		newReply.UserID,
+		newReply.Name,
+		newReply.Contents,
+		newReply.CreatedAt,
+		newReply.UpdatedAt,
+	).Scan(&newReply.ID)
+	if err != nil {
+		return nil, savedReplyUniqueNameError(err, newReply.Name)
+	}
+	return newReply, nil
+}
+
+func validateSavedReplyName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("saved reply name must be present (and not whitespace)")
+	}
+	if len([]rune(name)) > 100 {
+		return errors.New("saved reply name too long (must be less than 100 UTF-8 characters)")
+	}
+	return nil
+}
+
+func validateSavedReplyContents(contents string) error {
+	if strings.TrimSpace(contents) == "" {
+		return errors.New("saved reply contents must be present (and not whitespace)")
+	}
+	return nil
+}
+
+// savedReplyUniqueNameError returns a friendly error if err is due to the user
+// already having a saved reply with the name. Otherwise, it returns err.
+func savedReplyUniqueNameError(err error, name string) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "discussion_saved_replies_user_id_name_idx" {
+		return &ErrNameAlreadyExists{Message: fmt.Sprintf("you already have a saved reply named %q", name)}
+	}
+	return err
+}
+
+func (r *discussionSavedReplies) Get(ctx context.Context, savedReplyID int64) (*types.DiscussionSavedReply, error) {
+	if Mocks.DiscussionSavedReplies.Get != nil {
+		return Mocks.DiscussionSavedReplies.Get(savedReplyID)
+	}
+
+	replies, err := r.List(ctx, &DiscussionSavedRepliesListOptions{
+		SavedReplyIDs: []int64{savedReplyID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(replies) == 0 {
+		return nil, &ErrSavedReplyNotFound{SavedReplyID: savedReplyID}
+	}
+	return replies[0], nil
+}
+
+type DiscussionSavedRepliesUpdateOptions struct {
+	// Name, when non-nil, updates the saved reply's name.
+	Name *string
+
+	// Contents, when non-nil, updates the saved reply's contents.
+	Contents *string
+}
+
+func (r *discussionSavedReplies) Update(ctx context.Context, savedReplyID int64, opts *DiscussionSavedRepliesUpdateOptions) (*types.DiscussionSavedReply, error) {
+	if Mocks.DiscussionSavedReplies.Update != nil {
+		return Mocks.DiscussionSavedReplies.Update(ctx, savedReplyID, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+
+	sets := []*sqlf.Query{sqlf.Sprintf("updated_at=%v", time.Now())}
+	if opts.Name != nil {
+		if err := validateSavedReplyName(*opts.Name); err != nil {
+			return nil, err
+		}
+		sets = append(sets, sqlf.Sprintf("name=%v", *opts.Name))
+	}
+	if opts.Contents != nil {
+		if err := validateSavedReplyContents(*opts.Contents); err != nil {
+			return nil, err
+		}
+		sets = append(sets, sqlf.Sprintf("contents=%v", *opts.Contents))
+	}
+	q := sqlf.Sprintf("UPDATE discussion_saved_replies SET %s WHERE id=%v", sqlf.Join(sets, ", "), savedReplyID)
+	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		if opts.Name != nil {
+			err = savedReplyUniqueNameError(err, *opts.Name)
+		}
+		return nil, err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if nrows == 0 {
+		return nil, &ErrSavedReplyNotFound{SavedReplyID: savedReplyID}
+	}
+	return r.Get(ctx, savedReplyID)
+}
+
+func (r *discussionSavedReplies) Delete(ctx context.Context, savedReplyID int64) error {
+	if Mocks.DiscussionSavedReplies.Delete != nil {
+		return Mocks.DiscussionSavedReplies.Delete(ctx, savedReplyID)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_saved_replies WHERE id=$1", savedReplyID)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return &ErrSavedReplyNotFound{SavedReplyID: savedReplyID}
+	}
+	return nil
+}
+
+type DiscussionSavedRepliesListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// SavedReplyIDs, when len() > 0, specifies that only saved replies with one
+	// of these IDs should be returned.
+	SavedReplyIDs []int64
+
+	// UserID, when non-zero, specifies that only saved replies of this user
+	// should be returned.
+	UserID int32
+}
+
+func (r *discussionSavedReplies) List(ctx context.Context, opts *DiscussionSavedRepliesListOptions) ([]*types.DiscussionSavedReply, error) {
+	if Mocks.DiscussionSavedReplies.List != nil {
+		return Mocks.DiscussionSavedReplies.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := r.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY name ASC, id ASC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+	return r.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (r *discussionSavedReplies) Count(ctx context.Context, opts *DiscussionSavedRepliesListOptions) (int, error) {
+	if Mocks.DiscussionSavedReplies.Count != nil {
+		return Mocks.DiscussionSavedReplies.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := r.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
+	return r.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (*discussionSavedReplies) getListSQL(opts *DiscussionSavedRepliesListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.SavedReplyIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id = ANY(%v)", pq.Array(opts.SavedReplyIDs)))
+	}
+	if opts.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id=%v", opts.UserID))
+	}
+	return conds
+}
+
+func (*discussionSavedReplies) getCountBySQL(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+	rows := dbconn.Global.QueryRowContext(ctx, "SELECT count(id) FROM discussion_saved_replies r "+query, args...)
+	err := rows.Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
+
+// getBySQL returns saved replies matching the SQL query, if any exist.
+func (*discussionSavedReplies) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionSavedReply, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			r.id,
+			r.user_id,
+			r.name,
+			r.contents,
+			r.created_at,
+			r.updated_at
+		FROM discussion_saved_replies r `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := []*types.DiscussionSavedReply{}
+	defer rows.Close()
+	for rows.Next() {
+		reply := &types.DiscussionSavedReply{}
+		err := rows.Scan(
+			&reply.ID,
+			&reply.UserID,
+			&reply.Name,
+			&reply.Contents,
+			&reply.CreatedAt,
+			&reply.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		replies = append(replies, reply)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return replies, nil
+}
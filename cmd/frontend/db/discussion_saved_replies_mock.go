@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionSavedReplies struct {
+	Create func(ctx context.Context, newReply *types.DiscussionSavedReply) (*types.DiscussionSavedReply, error)
+	Get    func(savedReplyID int64) (*types.DiscussionSavedReply, error)
+	Update func(ctx context.Context, savedReplyID int64, opts *DiscussionSavedRepliesUpdateOptions) (*types.DiscussionSavedReply, error)
+	Delete func(ctx context.Context, savedReplyID int64) error
+	List   func(ctx context.Context, opts *DiscussionSavedRepliesListOptions) ([]*types.DiscussionSavedReply, error)
+	Count  func(ctx context.Context, opts *DiscussionSavedRepliesListOptions) (int, error)
+}
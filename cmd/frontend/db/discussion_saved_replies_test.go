@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionSavedReplies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, _ := createTestDiscussionThread(ctx, t)
+
+	reply, err := DiscussionSavedReplies.Create(ctx, &types.DiscussionSavedReply{
+		UserID:   user.ID,
+		Name:     "Thanks",
+		Contents: "Thanks for the report!",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Saved reply names are unique per user.
+	if _, err := DiscussionSavedReplies.Create(ctx, &types.DiscussionSavedReply{UserID: user.ID, Name: "Thanks", Contents: "x"}); err == nil {
+		t.Error("expected error creating duplicate saved reply")
+	}
+
+	updated, err := DiscussionSavedReplies.Update(ctx, reply.ID, &DiscussionSavedRepliesUpdateOptions{Contents: strPtr("Thanks!")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Name != "Thanks" || updated.Contents != "Thanks!" {
+		t.Errorf("got saved reply %+v, want updated contents", updated)
+	}
+	if _, err := DiscussionSavedReplies.Update(ctx, reply.ID, &DiscussionSavedRepliesUpdateOptions{Name: strPtr(" ")}); err == nil {
+		t.Error("expected error updating saved reply with blank name")
+	}
+
+	count, err := DiscussionSavedReplies.Count(ctx, &DiscussionSavedRepliesListOptions{UserID: user.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %d saved replies, want 1", count)
+	}
+
+	if err := DiscussionSavedReplies.Delete(ctx, reply.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionSavedReplies.Get(ctx, reply.ID); err == nil {
+		t.Error("expected error getting deleted saved reply")
+	}
+}
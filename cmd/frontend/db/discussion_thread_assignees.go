@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionThreadAssignees provides access to the `discussion_threads_assignees` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadAssignees struct{}
+
+// Add assigns the user to the thread. Assigning a user who is already
+// assigned to the thread is a no-op. It reports whether the user was newly
+// assigned.
+func (a *discussionThreadAssignees) Add(ctx context.Context, threadID int64, userID int32) (bool, error) {
+	if Mocks.DiscussionThreadAssignees.Add != nil {
+		return Mocks.DiscussionThreadAssignees.Add(ctx, threadID, userID)
+	}
+	return a.add(ctx, dbconn.Global, threadID, userID)
+}
+
+func (*discussionThreadAssignees) add(ctx context.Context, dbh dbHandle, threadID int64, userID int32) (bool, error) {
+	res, err := dbh.ExecContext(ctx, "INSERT INTO discussion_threads_assignees(thread_id, user_id) VALUES($1, $2) ON CONFLICT DO NOTHING", threadID, userID)
+	if err != nil {
+		return false, err
+	}
+	nrows, err := res.RowsAffected()
+	return nrows > 0, err
+}
+
+// Remove unassigns the user from the thread. Unassigning a user who is not
+// assigned to the thread is a no-op. It reports whether the user was
+// previously assigned.
+func (a *discussionThreadAssignees) Remove(ctx context.Context, threadID int64, userID int32) (bool, error) {
+	if Mocks.DiscussionThreadAssignees.Remove != nil {
+		return Mocks.DiscussionThreadAssignees.Remove(ctx, threadID, userID)
+	}
+	return a.remove(ctx, dbconn.Global, threadID, userID)
+}
+
+func (*discussionThreadAssignees) remove(ctx context.Context, dbh dbHandle, threadID int64, userID int32) (bool, error) {
+	res, err := dbh.ExecContext(ctx, "DELETE FROM discussion_threads_assignees WHERE thread_id=$1 AND user_id=$2", threadID, userID)
+	if err != nil {
+		return false, err
+	}
+	nrows, err := res.RowsAffected()
+	return nrows > 0, err
+}
+
+// ListUserIDs returns the IDs of the users assigned to the thread, in the
+// order in which they were assigned.
+func (*discussionThreadAssignees) ListUserIDs(ctx context.Context, threadID int64) ([]int32, error) {
+	if Mocks.DiscussionThreadAssignees.ListUserIDs != nil {
+		return Mocks.DiscussionThreadAssignees.ListUserIDs(ctx, threadID)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT user_id FROM discussion_threads_assignees WHERE thread_id=$1 ORDER BY created_at ASC, user_id ASC", threadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	userIDs := []int32{}
+	for rows.Next() {
+		var userID int32
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// ListThreadIDs returns the IDs of the threads that any of the given users
+// are assigned to.
+func (*discussionThreadAssignees) ListThreadIDs(ctx context.Context, userIDs []int32) ([]int64, error) {
+	if Mocks.DiscussionThreadAssignees.ListThreadIDs != nil {
+		return Mocks.DiscussionThreadAssignees.ListThreadIDs(ctx, userIDs)
+	}
+	if len(userIDs) == 0 {
+		return nil, errors.New("userIDs must not be empty")
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT DISTINCT thread_id FROM discussion_threads_assignees WHERE user_id = ANY($1)", pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	threadIDs := []int64{}
+	for rows.Next() {
+		var threadID int64
+		if err := rows.Scan(&threadID); err != nil {
+			return nil, err
+		}
+		threadIDs = append(threadIDs, threadID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return threadIDs, nil
+}
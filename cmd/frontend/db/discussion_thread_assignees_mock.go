@@ -0,0 +1,10 @@
+package db
+
+import "context"
+
+type MockDiscussionThreadAssignees struct {
+	Add           func(ctx context.Context, threadID int64, userID int32) (bool, error)
+	Remove        func(ctx context.Context, threadID int64, userID int32) (bool, error)
+	ListUserIDs   func(ctx context.Context, threadID int64) ([]int32, error)
+	ListThreadIDs func(ctx context.Context, userIDs []int32) ([]int64, error)
+}
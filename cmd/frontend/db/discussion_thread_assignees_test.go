@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadAssignees(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	user2, err := Users.Create(ctx, NewUser{
+		Email:                 "b@b.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assignees := func() []int32 {
+		userIDs, err := DiscussionThreadAssignees.ListUserIDs(ctx, thread.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return userIDs
+	}
+
+	// Assigning the same user twice is a no-op.
+	for i, userID := range []int32{user.ID, user2.ID, user.ID} {
+		added, err := DiscussionThreadAssignees.Add(ctx, thread.ID, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := i < 2; added != want {
+			t.Errorf("%d: got added %v, want %v", i, added, want)
+		}
+	}
+	if got, want := assignees(), []int32{user.ID, user2.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got assignees %v, want %v", got, want)
+	}
+
+	threadIDs, err := DiscussionThreadAssignees.ListThreadIDs(ctx, []int32{user2.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{thread.ID}; !reflect.DeepEqual(threadIDs, want) {
+		t.Errorf("got assigned threads %v, want %v", threadIDs, want)
+	}
+
+	if removed, err := DiscussionThreadAssignees.Remove(ctx, thread.ID, user.ID); err != nil {
+		t.Fatal(err)
+	} else if !removed {
+		t.Error("got removed false, want true")
+	}
+	if got, want := assignees(), []int32{user2.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got assignees %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// discussionThreadDependencies provides access to the
+// `discussion_thread_dependencies` table, which records which threads are
+// blocked by which other threads.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadDependencies struct{}
+
+// ErrThreadDependencyCycle is returned by DiscussionThreadDependencies.Add when
+// adding the dependency would make a thread (transitively) blocked by itself.
+var ErrThreadDependencyCycle = errors.New("the dependency would create a cycle (a thread cannot be blocked by itself)")
+
+// Add records that the thread is blocked by the other thread. It returns
+// whether the dependency was added (false if it already existed), or
+// ErrThreadDependencyCycle if the other thread is already (transitively)
+// blocked by the thread.
+//
+// To list the threads that block (or are blocked by) a thread, use
+// DiscussionThreads.List with the BlocksThreadID (or BlockedByThreadID)
+// option.
+func (*discussionThreadDependencies) Add(ctx context.Context, threadID, blockedByThreadID int64) (added bool, err error) {
+	if Mocks.DiscussionThreadDependencies.Add != nil {
+		return Mocks.DiscussionThreadDependencies.Add(ctx, threadID, blockedByThreadID)
+	}
+	if threadID == blockedByThreadID {
+		return false, ErrThreadDependencyCycle
+	}
+	err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		// Serialize additions so that concurrent additions cannot create a
+		// cycle that neither of them detects.
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE discussion_thread_dependencies IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return err
+		}
+		var cycle bool
+		err := tx.QueryRowContext(ctx, `WITH RECURSIVE blockers(id) AS (
+				SELECT $2::bigint
+				UNION
+				SELECT d.blocked_by_thread_id FROM discussion_thread_dependencies d JOIN blockers b ON d.thread_id = b.id
+			)
+			SELECT EXISTS(SELECT 1 FROM blockers WHERE id=$1)`, threadID, blockedByThreadID,
+		).Scan(&cycle)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrThreadDependencyCycle
+		}
+		res, err := tx.ExecContext(ctx, "INSERT INTO discussion_thread_dependencies(thread_id, blocked_by_thread_id) VALUES($1, $2) ON CONFLICT DO NOTHING", threadID, blockedByThreadID)
+		if err != nil {
+			return err
+		}
+		nrows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		added = nrows > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return added, nil
+}
+
+// Remove removes the dependency of the thread on the other thread. It returns
+// whether the dependency existed.
+func (*discussionThreadDependencies) Remove(ctx context.Context, threadID, blockedByThreadID int64) (removed bool, err error) {
+	if Mocks.DiscussionThreadDependencies.Remove != nil {
+		return Mocks.DiscussionThreadDependencies.Remove(ctx, threadID, blockedByThreadID)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_thread_dependencies WHERE thread_id=$1 AND blocked_by_thread_id=$2", threadID, blockedByThreadID)
+	if err != nil {
+		return false, err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return nrows > 0, nil
+}
@@ -0,0 +1,8 @@
+package db
+
+import "context"
+
+type MockDiscussionThreadDependencies struct {
+	Add    func(ctx context.Context, threadID, blockedByThreadID int64) (bool, error)
+	Remove func(ctx context.Context, threadID, blockedByThreadID int64) (bool, error)
+}
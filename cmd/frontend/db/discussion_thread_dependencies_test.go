@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadDependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, a := createTestDiscussionThread(ctx, t)
+	createThread := func(title string) *types.DiscussionThread {
+		t.Helper()
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        title,
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return thread
+	}
+	b := createThread("b")
+	c := createThread("c")
+
+	threadIDs := func(opt *DiscussionThreadsListOptions) []int64 {
+		t.Helper()
+		threads, err := DiscussionThreads.List(ctx, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []int64{}
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		return ids
+	}
+
+	// a is blocked by b, which is blocked by c.
+	for _, d := range [][2]int64{{a.ID, b.ID}, {b.ID, c.ID}} {
+		if added, err := DiscussionThreadDependencies.Add(ctx, d[0], d[1]); err != nil {
+			t.Fatal(err)
+		} else if !added {
+			t.Errorf("dependency %v: got added false, want true", d)
+		}
+	}
+	if added, err := DiscussionThreadDependencies.Add(ctx, a.ID, b.ID); err != nil {
+		t.Fatal(err)
+	} else if added {
+		t.Error("existing dependency: got added true, want false")
+	}
+	if got, want := threadIDs(&DiscussionThreadsListOptions{BlocksThreadID: a.ID}), []int64{b.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got blocking threads %v, want %v", got, want)
+	}
+	if got, want := threadIDs(&DiscussionThreadsListOptions{BlockedByThreadID: c.ID}), []int64{b.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got blocked threads %v, want %v", got, want)
+	}
+
+	// Dependencies must not form cycles.
+	for _, d := range [][2]int64{{c.ID, a.ID}, {b.ID, a.ID}, {a.ID, a.ID}} {
+		if _, err := DiscussionThreadDependencies.Add(ctx, d[0], d[1]); err != ErrThreadDependencyCycle {
+			t.Errorf("dependency %v: got error %v, want %v", d, err, ErrThreadDependencyCycle)
+		}
+	}
+
+	if removed, err := DiscussionThreadDependencies.Remove(ctx, b.ID, c.ID); err != nil {
+		t.Fatal(err)
+	} else if !removed {
+		t.Error("got removed false, want true")
+	}
+	if _, err := DiscussionThreadDependencies.Add(ctx, c.ID, a.ID); err != nil {
+		t.Errorf("got error %v after removing the dependency that formed a cycle", err)
+	}
+}
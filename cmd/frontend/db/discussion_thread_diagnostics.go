@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// discussionThreadDiagnostics provides access to the
+// `discussion_thread_diagnostics` table, which records the diagnostics that
+// tools and extensions have reported on each thread.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadDiagnostics struct{}
+
+// Set replaces the thread's diagnostics from the source with the given
+// diagnostics, so that each source can report its current results without
+// affecting the diagnostics of the other sources. An empty list removes the
+// source's diagnostics.
+func (*discussionThreadDiagnostics) Set(ctx context.Context, threadID int64, source string, diagnostics []*types.DiscussionThreadDiagnostic) error {
+	if Mocks.DiscussionThreadDiagnostics.Set != nil {
+		return Mocks.DiscussionThreadDiagnostics.Set(ctx, threadID, source, diagnostics)
+	}
+
+	// Validate the input diagnostics.
+	if source == "" {
+		return errors.New("source must be specified")
+	}
+	for _, d := range diagnostics {
+		if d.ID != 0 || d.ThreadID != 0 || d.Source != "" || !d.CreatedAt.IsZero() {
+			return errors.New("diagnostic ID, ThreadID, Source and CreatedAt must not be specified")
+		}
+		if d.Path == "" {
+			return errors.New("diagnostic Path must be specified")
+		}
+		if d.StartLine < 0 || d.StartCharacter < 0 || d.EndLine < d.StartLine || (d.EndLine == d.StartLine && d.EndCharacter < d.StartCharacter) {
+			return errors.New("diagnostic range is invalid")
+		}
+		switch d.Severity {
+		case types.DiscussionDiagnosticError, types.DiscussionDiagnosticWarning, types.DiscussionDiagnosticInfo, types.DiscussionDiagnosticHint:
+		default:
+			return errors.New("diagnostic Severity is invalid")
+		}
+	}
+
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM discussion_thread_diagnostics WHERE thread_id=$1 AND source=$2", threadID, source); err != nil {
+			return err
+		}
+		for _, d := range diagnostics {
+			_, err := tx.ExecContext(ctx, `INSERT INTO discussion_thread_diagnostics(
+				thread_id,
+				source,
+				path,
+				start_line,
+				start_character,
+				end_line,
+				end_character,
+				message,
+				severity
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+				threadID,
+				source,
+				d.Path,
+				d.StartLine,
+				d.StartCharacter,
+				d.EndLine,
+				d.EndCharacter,
+				d.Message,
+				d.Severity,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type DiscussionThreadDiagnosticsListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// ThreadID, when non-zero, specifies that only diagnostics on this thread
+	// should be returned.
+	ThreadID int64
+
+	// Severity, when non-empty, specifies that only diagnostics with this
+	// severity should be returned.
+	Severity types.DiscussionDiagnosticSeverity
+}
+
+// List returns the diagnostics matching the options, ordered by path and
+// position.
+func (s *discussionThreadDiagnostics) List(ctx context.Context, opts *DiscussionThreadDiagnosticsListOptions) ([]*types.DiscussionThreadDiagnostic, error) {
+	if Mocks.DiscussionThreadDiagnostics.List != nil {
+		return Mocks.DiscussionThreadDiagnostics.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := s.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY path ASC, start_line ASC, start_character ASC, id ASC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			id,
+			thread_id,
+			source,
+			path,
+			start_line,
+			start_character,
+			end_line,
+			end_character,
+			message,
+			severity,
+			created_at
+		FROM discussion_thread_diagnostics `+q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	diagnostics := []*types.DiscussionThreadDiagnostic{}
+	defer rows.Close()
+	for rows.Next() {
+		d := &types.DiscussionThreadDiagnostic{}
+		err := rows.Scan(
+			&d.ID,
+			&d.ThreadID,
+			&d.Source,
+			&d.Path,
+			&d.StartLine,
+			&d.StartCharacter,
+			&d.EndLine,
+			&d.EndCharacter,
+			&d.Message,
+			&d.Severity,
+			&d.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return diagnostics, nil
+}
+
+// CountBySeverity returns the number of diagnostics matching the options
+// (ignoring LimitOffset) with each severity. Severities without diagnostics
+// are omitted.
+func (s *discussionThreadDiagnostics) CountBySeverity(ctx context.Context, opts *DiscussionThreadDiagnosticsListOptions) (map[types.DiscussionDiagnosticSeverity]int, error) {
+	if Mocks.DiscussionThreadDiagnostics.CountBySeverity != nil {
+		return Mocks.DiscussionThreadDiagnostics.CountBySeverity(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := s.getListSQL(opts)
+	q := sqlf.Sprintf("SELECT severity, count(id) FROM discussion_thread_diagnostics WHERE %s GROUP BY severity", sqlf.Join(conds, "AND"))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[types.DiscussionDiagnosticSeverity]int{}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			severity types.DiscussionDiagnosticSeverity
+			count    int
+		)
+		if err := rows.Scan(&severity, &count); err != nil {
+			return nil, err
+		}
+		counts[severity] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (*discussionThreadDiagnostics) getListSQL(opts *DiscussionThreadDiagnosticsListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.ThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("thread_id=%v", opts.ThreadID))
+	}
+	if opts.Severity != "" {
+		conds = append(conds, sqlf.Sprintf("severity=%v", opts.Severity))
+	}
+	return conds
+}
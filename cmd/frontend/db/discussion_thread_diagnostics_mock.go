@@ -0,0 +1,13 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionThreadDiagnostics struct {
+	Set             func(ctx context.Context, threadID int64, source string, diagnostics []*types.DiscussionThreadDiagnostic) error
+	List            func(ctx context.Context, opts *DiscussionThreadDiagnosticsListOptions) ([]*types.DiscussionThreadDiagnostic, error)
+	CountBySeverity func(ctx context.Context, opts *DiscussionThreadDiagnosticsListOptions) (map[types.DiscussionDiagnosticSeverity]int, error)
+}
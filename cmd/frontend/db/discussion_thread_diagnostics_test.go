@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadDiagnostics(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	_, _, thread := createTestDiscussionThread(ctx, t)
+
+	messages := func(opts *DiscussionThreadDiagnosticsListOptions) []string {
+		t.Helper()
+		diagnostics, err := DiscussionThreadDiagnostics.List(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages := []string{}
+		for _, d := range diagnostics {
+			messages = append(messages, d.Source+": "+d.Message)
+		}
+		return messages
+	}
+
+	if err := DiscussionThreadDiagnostics.Set(ctx, thread.ID, "lint", []*types.DiscussionThreadDiagnostic{
+		{Path: "b.go", StartLine: 1, EndLine: 1, EndCharacter: 3, Message: "unused", Severity: types.DiscussionDiagnosticWarning},
+		{Path: "a.go", StartLine: 2, EndLine: 2, EndCharacter: 3, Message: "undefined", Severity: types.DiscussionDiagnosticError},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscussionThreadDiagnostics.Set(ctx, thread.ID, "build", []*types.DiscussionThreadDiagnostic{
+		{Path: "a.go", StartLine: 1, EndLine: 1, Message: "syntax error", Severity: types.DiscussionDiagnosticError},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := messages(&DiscussionThreadDiagnosticsListOptions{ThreadID: thread.ID}), []string{"build: syntax error", "lint: undefined", "lint: unused"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := messages(&DiscussionThreadDiagnosticsListOptions{ThreadID: thread.ID, Severity: types.DiscussionDiagnosticWarning}), []string{"lint: unused"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got warnings %v, want %v", got, want)
+	}
+	counts, err := DiscussionThreadDiagnostics.CountBySeverity(ctx, &DiscussionThreadDiagnosticsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[types.DiscussionDiagnosticSeverity]int{types.DiscussionDiagnosticError: 2, types.DiscussionDiagnosticWarning: 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("got counts %v, want %v", counts, want)
+	}
+
+	// Setting a source's diagnostics replaces only that source's diagnostics.
+	if err := DiscussionThreadDiagnostics.Set(ctx, thread.ID, "lint", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := messages(&DiscussionThreadDiagnosticsListOptions{ThreadID: thread.ID}), []string{"build: syntax error"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after clearing lint: got %v, want %v", got, want)
+	}
+
+	// Invalid diagnostics are rejected.
+	for _, d := range []*types.DiscussionThreadDiagnostic{
+		{Path: "", Message: "m", Severity: types.DiscussionDiagnosticError},
+		{Path: "a.go", StartLine: 2, EndLine: 1, Message: "m", Severity: types.DiscussionDiagnosticError},
+		{Path: "a.go", Message: "m", Severity: "fatal"},
+	} {
+		if err := DiscussionThreadDiagnostics.Set(ctx, thread.ID, "build", []*types.DiscussionThreadDiagnostic{d}); err == nil {
+			t.Errorf("diagnostic %+v: got nil error, want error", d)
+		}
+	}
+	if got, want := messages(&DiscussionThreadDiagnosticsListOptions{ThreadID: thread.ID}), []string{"build: syntax error"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after invalid diagnostics: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,290 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionThreadEvents provides access to the `discussion_thread_events`
+// table, which is an append-only log of the activity on each discussion
+// thread.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadEvents struct{}
+
+// Create appends an event to its thread's timeline.
+func (e *discussionThreadEvents) Create(ctx context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+	if Mocks.DiscussionThreadEvents.Create != nil {
+		return Mocks.DiscussionThreadEvents.Create(ctx, newEvent)
+	}
+	return e.create(ctx, dbconn.Global, newEvent)
+}
+
+func (*discussionThreadEvents) create(ctx context.Context, dbh dbHandle, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+	// Validate the input event.
+	if newEvent == nil {
+		return nil, errors.New("newEvent is nil")
+	}
+	if newEvent.ID != 0 {
+		return nil, errors.New("newEvent.ID must be zero")
+	}
+	if newEvent.ThreadID == 0 {
+		return nil, errors.New("newEvent.ThreadID must be specified")
+	}
+	if newEvent.ActorUserID == 0 {
+		return nil, errors.New("newEvent.ActorUserID must be specified")
+	}
+	if newEvent.Kind == "" {
+		return nil, errors.New("newEvent.Kind must be specified")
+	}
+	if !newEvent.CreatedAt.IsZero() {
+		return nil, errors.New("newEvent.CreatedAt must not be specified")
+	}
+
+	data, err := json.Marshal(newEvent.Data)
+	if err != nil {
+		return nil, err
+	}
+	newEvent.CreatedAt = time.Now()
+	err = dbh.QueryRowContext(ctx, `INSERT INTO discussion_thread_events(
+		thread_id,
+		actor_user_id,
+		kind,
+		data,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		newEvent.ThreadID,
+		newEvent.ActorUserID,
+		newEvent.Kind,
+		data,
+		newEvent.CreatedAt,
+	).Scan(&newEvent.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newEvent, nil
+}
+
+type DiscussionThreadEventsListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// EventIDs, when len() > 0, specifies that only events with one of these
+	// IDs should be returned.
+	EventIDs []int64
+
+	// ThreadID, when non-zero, specifies that only events on this thread
+	// should be returned.
+	ThreadID int64
+
+	// RepoID, when non-zero, specifies that only events on threads that target
+	// this repository should be returned.
+	RepoID api.RepoID
+
+	// CreatedAfter, when non-nil, specifies that only events that were created
+	// after this time should be returned.
+	CreatedAfter *time.Time
+
+	// AfterID, when non-nil, specifies that only events with an ID greater
+	// than this should be returned. The events are then ordered by ID, so that
+	// the ID of the last event can be used as the cursor for the next page.
+	AfterID *int64
+}
+
+// List returns the events matching the options, oldest first.
+func (e *discussionThreadEvents) List(ctx context.Context, opts *DiscussionThreadEventsListOptions) ([]*types.DiscussionThreadEvent, error) {
+	if Mocks.DiscussionThreadEvents.List != nil {
+		return Mocks.DiscussionThreadEvents.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := e.getListSQL(opts)
+	order := sqlf.Sprintf("created_at ASC, id ASC")
+	if opts.AfterID != nil {
+		order = sqlf.Sprintf("id ASC")
+	}
+	q := sqlf.Sprintf("WHERE %s ORDER BY %s %s", sqlf.Join(conds, "AND"), order, opts.LimitOffset.SQL())
+	return e.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (e *discussionThreadEvents) Count(ctx context.Context, opts *DiscussionThreadEventsListOptions) (int, error) {
+	if Mocks.DiscussionThreadEvents.Count != nil {
+		return Mocks.DiscussionThreadEvents.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := e.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
+	return e.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (*discussionThreadEvents) getListSQL(opts *DiscussionThreadEventsListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.EventIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id = ANY(%v)", pq.Array(opts.EventIDs)))
+	}
+	if opts.ThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("thread_id=%v", opts.ThreadID))
+	}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("thread_id IN (SELECT thread_id FROM discussion_threads_target_repo WHERE repo_id=%v)", opts.RepoID))
+	}
+	if opts.CreatedAfter != nil {
+		conds = append(conds, sqlf.Sprintf("created_at > %v", *opts.CreatedAfter))
+	}
+	if opts.AfterID != nil {
+		conds = append(conds, sqlf.Sprintf("id > %v", *opts.AfterID))
+	}
+	return conds
+}
+
+func (*discussionThreadEvents) getCountBySQL(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+	rows := dbconn.Global.QueryRowContext(ctx, "SELECT count(id) FROM discussion_thread_events e "+query, args...)
+	err := rows.Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
+
+// getBySQL returns events matching the SQL query, if any exist.
+func (*discussionThreadEvents) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionThreadEvent, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			e.id,
+			e.thread_id,
+			e.actor_user_id,
+			e.kind,
+			e.data,
+			e.created_at
+		FROM discussion_thread_events e `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*types.DiscussionThreadEvent{}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			event = &types.DiscussionThreadEvent{}
+			data  []byte
+		)
+		err := rows.Scan(
+			&event.ID,
+			&event.ThreadID,
+			&event.ActorUserID,
+			&event.Kind,
+			&data,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &event.Data); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Statistics summarizes the threads that target the repository. The weekly
+// comment counts cover the weeks since the week that contains the given time,
+// including weeks without comments.
+func (*discussionThreadEvents) Statistics(ctx context.Context, repoID api.RepoID, since time.Time) (*types.DiscussionThreadStatistics, error) {
+	if Mocks.DiscussionThreadEvents.Statistics != nil {
+		return Mocks.DiscussionThreadEvents.Statistics(ctx, repoID, since)
+	}
+
+	stats := &types.DiscussionThreadStatistics{}
+	if err := dbconn.Global.QueryRowContext(ctx, `SELECT
+			COUNT(*) FILTER (WHERE t.archived_at IS NULL),
+			COUNT(*) FILTER (WHERE t.archived_at IS NOT NULL)
+		FROM discussion_threads t
+		JOIN discussion_threads_target_repo tr ON tr.id=t.target_repo_id
+		WHERE tr.repo_id=$1 AND t.deleted_at IS NULL`, repoID,
+	).Scan(&stats.OpenCount, &stats.ArchivedCount); err != nil {
+		return nil, err
+	}
+
+	var medianSeconds sql.NullFloat64
+	if err := dbconn.Global.QueryRowContext(ctx, `SELECT
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.archived_at - t.created_at))
+		FROM discussion_threads t
+		JOIN discussion_threads_target_repo tr ON tr.id=t.target_repo_id
+		JOIN (
+			SELECT thread_id, MIN(created_at) AS archived_at FROM discussion_thread_events
+			WHERE kind=$2 GROUP BY thread_id
+		) a ON a.thread_id=t.id
+		WHERE tr.repo_id=$1 AND t.deleted_at IS NULL AND t.archived_at IS NOT NULL`, repoID, types.DiscussionThreadEventArchived,
+	).Scan(&medianSeconds); err != nil {
+		return nil, err
+	}
+	if medianSeconds.Valid {
+		median := time.Duration(medianSeconds.Float64 * float64(time.Second))
+		stats.MedianTimeToArchive = &median
+	}
+
+	weekStart := startOfWeek(since)
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT
+			date_trunc('week', e.created_at AT TIME ZONE 'UTC'),
+			COUNT(*)
+		FROM discussion_thread_events e
+		JOIN discussion_threads t ON t.id=e.thread_id
+		JOIN discussion_threads_target_repo tr ON tr.id=t.target_repo_id
+		WHERE tr.repo_id=$1 AND t.deleted_at IS NULL AND e.kind=$2 AND e.created_at >= $3
+		GROUP BY 1`, repoID, types.DiscussionThreadEventCommented, weekStart,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := map[time.Time]int{}
+	for rows.Next() {
+		var (
+			week  time.Time
+			count int
+		)
+		if err := rows.Scan(&week, &count); err != nil {
+			return nil, err
+		}
+		counts[startOfWeek(week)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	stats.WeeklyComments = weeklyCounts(weekStart, time.Now(), counts)
+	return stats, nil
+}
+
+// startOfWeek returns the start of the week (Monday, UTC) that contains t.
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// weeklyCounts returns the counts of the weeks from the week that contains
+// since until the week that contains until, with zero for missing weeks.
+func weeklyCounts(since, until time.Time, counts map[time.Time]int) []types.DiscussionWeeklyCount {
+	l := []types.DiscussionWeeklyCount{}
+	for week := startOfWeek(since); !week.After(until); week = week.AddDate(0, 0, 7) {
+		l = append(l, types.DiscussionWeeklyCount{Week: week, Count: counts[week]})
+	}
+	return l
+}
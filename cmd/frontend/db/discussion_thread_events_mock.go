@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type MockDiscussionThreadEvents struct {
+	Create     func(ctx context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error)
+	List       func(ctx context.Context, opts *DiscussionThreadEventsListOptions) ([]*types.DiscussionThreadEvent, error)
+	Count      func(ctx context.Context, opts *DiscussionThreadEventsListOptions) (int, error)
+	Statistics func(ctx context.Context, repoID api.RepoID, since time.Time) (*types.DiscussionThreadStatistics, error)
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+
+	previousTitle, title := "old", "new"
+	want := []*types.DiscussionThreadEvent{
+		{ThreadID: thread.ID, ActorUserID: user.ID, Kind: types.DiscussionThreadEventCreated},
+		{ThreadID: thread.ID, ActorUserID: user.ID, Kind: types.DiscussionThreadEventTitleEdited, Data: types.DiscussionThreadEventData{PreviousTitle: &previousTitle, Title: &title}},
+		{ThreadID: thread.ID, ActorUserID: user.ID, Kind: types.DiscussionThreadEventArchived},
+	}
+	for _, event := range want {
+		if _, err := DiscussionThreadEvents.Create(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := DiscussionThreadEvents.List(ctx, &DiscussionThreadEventsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		// Postgres truncates timestamps to microseconds, so don't compare
+		// them.
+		event.CreatedAt = want[i].CreatedAt
+		if !reflect.DeepEqual(event, want[i]) {
+			t.Errorf("%d: got event %+v, want %+v", i, event, want[i])
+		}
+	}
+
+	// Listing after an event ID returns the next events by ID.
+	events, err = DiscussionThreadEvents.List(ctx, &DiscussionThreadEventsListOptions{
+		LimitOffset: &LimitOffset{Limit: 1},
+		ThreadID:    thread.ID,
+		AfterID:     &want[0].ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != want[1].ID {
+		t.Errorf("got events %+v after ID %d, want only event %d", events, want[0].ID, want[1].ID)
+	}
+
+	count, err := DiscussionThreadEvents.Count(ctx, &DiscussionThreadEventsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != len(want) {
+		t.Errorf("got count %d, want %d", count, len(want))
+	}
+
+	if _, err := DiscussionThreadEvents.Create(ctx, &types.DiscussionThreadEvent{ThreadID: thread.ID, Kind: types.DiscussionThreadEventArchived}); err == nil {
+		t.Error("expected error creating event without an actor")
+	}
+}
+
+func TestDiscussionThreadEvents_Statistics(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, thread := createTestDiscussionThread(ctx, t)
+	archive := true
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Archive: &archive}); err != nil {
+		t.Fatal(err)
+	}
+	for _, kind := range []types.DiscussionThreadEventKind{types.DiscussionThreadEventCommented, types.DiscussionThreadEventCommented, types.DiscussionThreadEventArchived} {
+		if _, err := DiscussionThreadEvents.Create(ctx, &types.DiscussionThreadEvent{ThreadID: thread.ID, ActorUserID: user.ID, Kind: kind}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := DiscussionThreadEvents.Statistics(ctx, repo.ID, time.Now().AddDate(0, 0, -14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.OpenCount != 0 || stats.ArchivedCount != 1 {
+		t.Errorf("got %d open and %d archived threads, want 0 and 1", stats.OpenCount, stats.ArchivedCount)
+	}
+	if stats.MedianTimeToArchive == nil {
+		t.Error("got nil MedianTimeToArchive")
+	}
+	if len(stats.WeeklyComments) < 3 {
+		t.Fatalf("got %d weeks, want at least 3", len(stats.WeeklyComments))
+	}
+	if last := stats.WeeklyComments[len(stats.WeeklyComments)-1]; last.Count != 2 {
+		t.Errorf("got %d comments this week, want 2", last.Count)
+	}
+}
+
+func TestWeeklyCounts(t *testing.T) {
+	// 2019-12-04 is a Wednesday.
+	since := time.Date(2019, 12, 4, 15, 0, 0, 0, time.UTC)
+	until := time.Date(2019, 12, 17, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2019, 12, 2, 0, 0, 0, 0, time.UTC)
+	if got := startOfWeek(since); !got.Equal(monday) {
+		t.Errorf("got start of week %s, want %s", got, monday)
+	}
+	got := weeklyCounts(since, until, map[time.Time]int{monday.AddDate(0, 0, 7): 3})
+	want := []types.DiscussionWeeklyCount{
+		{Week: monday, Count: 0},
+		{Week: monday.AddDate(0, 0, 7), Count: 3},
+		{Week: monday.AddDate(0, 0, 14), Count: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionThreadReads provides access to the `discussion_thread_reads`
+// table, which records when each user last read each thread.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadReads struct{}
+
+// MarkAsRead records that the user has read the thread at the given time. The
+// recorded time never moves backwards.
+func (*discussionThreadReads) MarkAsRead(ctx context.Context, threadID int64, userID int32, readAt time.Time) error {
+	if Mocks.DiscussionThreadReads.MarkAsRead != nil {
+		return Mocks.DiscussionThreadReads.MarkAsRead(ctx, threadID, userID, readAt)
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_thread_reads(thread_id, user_id, last_read_at) VALUES($1, $2, $3)
+		ON CONFLICT (thread_id, user_id) DO UPDATE SET last_read_at=GREATEST(discussion_thread_reads.last_read_at, excluded.last_read_at)`,
+		threadID, userID, readAt,
+	)
+	return err
+}
+
+// GetLastReadAt returns when the user last read the thread, or nil if they
+// have never read it.
+func (*discussionThreadReads) GetLastReadAt(ctx context.Context, threadID int64, userID int32) (*time.Time, error) {
+	if Mocks.DiscussionThreadReads.GetLastReadAt != nil {
+		return Mocks.DiscussionThreadReads.GetLastReadAt(ctx, threadID, userID)
+	}
+	var lastReadAt time.Time
+	err := dbconn.Global.QueryRowContext(ctx, "SELECT last_read_at FROM discussion_thread_reads WHERE thread_id=$1 AND user_id=$2", threadID, userID).Scan(&lastReadAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lastReadAt, nil
+}
+
+// CountUnreadComments counts the comments in the thread that were added since
+// the user last read it (or all of them, if the user has never read it). The
+// user's own comments are never unread.
+func (*discussionThreadReads) CountUnreadComments(ctx context.Context, threadID int64, userID int32) (int, error) {
+	if Mocks.DiscussionThreadReads.CountUnreadComments != nil {
+		return Mocks.DiscussionThreadReads.CountUnreadComments(ctx, threadID, userID)
+	}
+	var count int
+	err := dbconn.Global.QueryRowContext(ctx, `SELECT count(c.id) FROM discussion_comments c
+		LEFT JOIN discussion_thread_reads r ON r.thread_id=c.thread_id AND r.user_id=$2
+		WHERE c.thread_id=$1 AND c.deleted_at IS NULL AND c.author_user_id != $2
+		AND (r.last_read_at IS NULL OR c.created_at > r.last_read_at)`,
+		threadID, userID,
+	).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,12 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type MockDiscussionThreadReads struct {
+	MarkAsRead          func(ctx context.Context, threadID int64, userID int32, readAt time.Time) error
+	GetLastReadAt       func(ctx context.Context, threadID int64, userID int32) (*time.Time, error)
+	CountUnreadComments func(ctx context.Context, threadID int64, userID int32) (int, error)
+}
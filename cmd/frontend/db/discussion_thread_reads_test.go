@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadReads(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	user2, err := Users.Create(ctx, NewUser{
+		Email:                 "b@b.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addComment := func(authorUserID int32) {
+		t.Helper()
+		if _, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: authorUserID, Contents: "c"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	unread := func() int {
+		t.Helper()
+		count, err := DiscussionThreadReads.CountUnreadComments(ctx, thread.ID, user2.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	// All comments by others are unread until the user reads the thread.
+	addComment(user.ID)
+	addComment(user2.ID)
+	if lastReadAt, err := DiscussionThreadReads.GetLastReadAt(ctx, thread.ID, user2.ID); err != nil {
+		t.Fatal(err)
+	} else if lastReadAt != nil {
+		t.Errorf("got last read at %v, want nil", lastReadAt)
+	}
+	if got := unread(); got != 1 {
+		t.Errorf("got %d unread comments, want 1", got)
+	}
+
+	readAt := time.Now()
+	if err := DiscussionThreadReads.MarkAsRead(ctx, thread.ID, user2.ID, readAt); err != nil {
+		t.Fatal(err)
+	}
+	if got := unread(); got != 0 {
+		t.Errorf("got %d unread comments after reading, want 0", got)
+	}
+	addComment(user.ID)
+	if got := unread(); got != 1 {
+		t.Errorf("got %d unread comments after new comment, want 1", got)
+	}
+
+	// Marking the thread as read at an earlier time has no effect.
+	if err := DiscussionThreadReads.MarkAsRead(ctx, thread.ID, user2.ID, readAt.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	lastReadAt, err := DiscussionThreadReads.GetLastReadAt(ctx, thread.ID, user2.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastReadAt == nil || lastReadAt.Before(readAt.Add(-time.Second)) {
+		t.Errorf("got last read at %v, want %v", lastReadAt, readAt)
+	}
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// discussionThreadReferences provides access to the
+// `discussion_thread_references` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadReferences struct{}
+
+// Set replaces the threads referenced by the comment (which was made in the
+// given thread) with the given threads. References from a thread to itself are
+// ignored. It returns the IDs of the threads that the comment did not
+// reference before.
+//
+// To list the threads that a thread references (or is referenced by), use
+// DiscussionThreads.List with the ReferencedByThreadID (or ReferencesThreadID)
+// option.
+func (*discussionThreadReferences) Set(ctx context.Context, commentID, threadID int64, referencedThreadIDs []int64) (added []int64, err error) {
+	if Mocks.DiscussionThreadReferences.Set != nil {
+		return Mocks.DiscussionThreadReferences.Set(ctx, commentID, threadID, referencedThreadIDs)
+	}
+	err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM discussion_thread_references WHERE comment_id=$1 AND NOT (referenced_thread_id = ANY($2))", commentID, pq.Array(referencedThreadIDs)); err != nil {
+			return err
+		}
+		if len(referencedThreadIDs) == 0 {
+			return nil
+		}
+		rows, err := tx.QueryContext(ctx, `INSERT INTO discussion_thread_references(comment_id, thread_id, referenced_thread_id)
+			SELECT DISTINCT $1::bigint, $2::bigint, id FROM unnest($3::bigint[]) AS id WHERE id <> $2
+			ON CONFLICT DO NOTHING
+			RETURNING referenced_thread_id`, commentID, threadID, pq.Array(referencedThreadIDs))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			added = append(added, id)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "context"
+
+type MockDiscussionThreadReferences struct {
+	Set func(ctx context.Context, commentID, threadID int64, referencedThreadIDs []int64) ([]int64, error)
+}
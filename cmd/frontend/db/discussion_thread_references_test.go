@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadReferences(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, thread := createTestDiscussionThread(ctx, t)
+	other, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "Other",
+		TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "see #2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	threadIDs := func(opt *DiscussionThreadsListOptions) []int64 {
+		t.Helper()
+		threads, err := DiscussionThreads.List(ctx, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []int64{}
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		return ids
+	}
+
+	// References to the comment's own thread are ignored.
+	added, err := DiscussionThreadReferences.Set(ctx, comment.ID, thread.ID, []int64{other.ID, other.ID, thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{other.ID}; !reflect.DeepEqual(added, want) {
+		t.Errorf("got added %v, want %v", added, want)
+	}
+	if got, want := threadIDs(&DiscussionThreadsListOptions{ReferencedByThreadID: thread.ID}), []int64{other.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got referenced threads %v, want %v", got, want)
+	}
+	if got, want := threadIDs(&DiscussionThreadsListOptions{ReferencesThreadID: other.ID}), []int64{thread.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got referencing threads %v, want %v", got, want)
+	}
+
+	// Existing references are not reported as added again.
+	added, err = DiscussionThreadReferences.Set(ctx, comment.ID, thread.ID, []int64{other.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 0 {
+		t.Errorf("got added %v, want none", added)
+	}
+
+	// References from deleted comments are not listed.
+	if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{Delete: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := threadIDs(&DiscussionThreadsListOptions{ReferencesThreadID: other.ID}); len(got) != 0 {
+		t.Errorf("got referencing threads %v, want none", got)
+	}
+
+	if _, err := DiscussionThreadReferences.Set(ctx, comment.ID, thread.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := threadIDs(&DiscussionThreadsListOptions{ReferencedByThreadID: thread.ID}); len(got) != 0 {
+		t.Errorf("got referenced threads %v, want none", got)
+	}
+}
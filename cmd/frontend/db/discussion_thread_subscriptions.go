@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionThreadSubscriptions provides access to the
+// `discussion_thread_subscriptions` table.
+//
+// A row with subscribed=false records that the user explicitly unsubscribed
+// from the thread, which prevents them from being automatically subscribed
+// again.
+//
+// Each subscription has a notification level, which specifies the kinds of
+// thread activity (comments, state changes, or both) that the subscriber is
+// notified of.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadSubscriptions struct{}
+
+// Set explicitly subscribes the user to, or unsubscribes the user from, the
+// thread. An existing subscription's notification level is kept.
+func (*discussionThreadSubscriptions) Set(ctx context.Context, threadID int64, userID int32, subscribed bool) error {
+	if Mocks.DiscussionThreadSubscriptions.Set != nil {
+		return Mocks.DiscussionThreadSubscriptions.Set(ctx, threadID, userID, subscribed)
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_thread_subscriptions(thread_id, user_id, subscribed) VALUES($1, $2, $3)
+		ON CONFLICT (thread_id, user_id) DO UPDATE SET subscribed=excluded.subscribed, updated_at=now()`,
+		threadID, userID, subscribed,
+	)
+	return err
+}
+
+// AutoSubscribe subscribes the users to the thread, unless they have
+// previously subscribed to or unsubscribed from it.
+func (*discussionThreadSubscriptions) AutoSubscribe(ctx context.Context, threadID int64, userIDs []int32) error {
+	if Mocks.DiscussionThreadSubscriptions.AutoSubscribe != nil {
+		return Mocks.DiscussionThreadSubscriptions.AutoSubscribe(ctx, threadID, userIDs)
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_thread_subscriptions(thread_id, user_id)
+		SELECT $1, unnest($2::integer[])
+		ON CONFLICT DO NOTHING`,
+		threadID, pq.Array(userIDs),
+	)
+	return err
+}
+
+// IsSubscribed reports whether the user is subscribed to the thread.
+func (*discussionThreadSubscriptions) IsSubscribed(ctx context.Context, threadID int64, userID int32) (bool, error) {
+	if Mocks.DiscussionThreadSubscriptions.IsSubscribed != nil {
+		return Mocks.DiscussionThreadSubscriptions.IsSubscribed(ctx, threadID, userID)
+	}
+	var subscribed bool
+	err := dbconn.Global.QueryRowContext(ctx, "SELECT subscribed FROM discussion_thread_subscriptions WHERE thread_id=$1 AND user_id=$2", threadID, userID).Scan(&subscribed)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return subscribed, err
+}
+
+// SetNotificationLevel explicitly subscribes the user to the thread with the
+// given notification level.
+func (*discussionThreadSubscriptions) SetNotificationLevel(ctx context.Context, threadID int64, userID int32, level types.DiscussionThreadNotificationLevel) error {
+	if Mocks.DiscussionThreadSubscriptions.SetNotificationLevel != nil {
+		return Mocks.DiscussionThreadSubscriptions.SetNotificationLevel(ctx, threadID, userID, level)
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `INSERT INTO discussion_thread_subscriptions(thread_id, user_id, subscribed, notification_level) VALUES($1, $2, true, $3)
+		ON CONFLICT (thread_id, user_id) DO UPDATE SET subscribed=true, notification_level=excluded.notification_level, updated_at=now()`,
+		threadID, userID, level,
+	)
+	return err
+}
+
+// GetNotificationLevel returns the notification level of the user's
+// subscription to the thread, or the empty string if the user is not
+// subscribed to it.
+func (*discussionThreadSubscriptions) GetNotificationLevel(ctx context.Context, threadID int64, userID int32) (types.DiscussionThreadNotificationLevel, error) {
+	if Mocks.DiscussionThreadSubscriptions.GetNotificationLevel != nil {
+		return Mocks.DiscussionThreadSubscriptions.GetNotificationLevel(ctx, threadID, userID)
+	}
+	var level types.DiscussionThreadNotificationLevel
+	err := dbconn.Global.QueryRowContext(ctx, "SELECT notification_level FROM discussion_thread_subscriptions WHERE thread_id=$1 AND user_id=$2 AND subscribed", threadID, userID).Scan(&level)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return level, err
+}
+
+// ListSubscribedUserIDs returns the IDs of the users subscribed to the thread.
+func (*discussionThreadSubscriptions) ListSubscribedUserIDs(ctx context.Context, threadID int64) ([]int32, error) {
+	if Mocks.DiscussionThreadSubscriptions.ListSubscribedUserIDs != nil {
+		return Mocks.DiscussionThreadSubscriptions.ListSubscribedUserIDs(ctx, threadID)
+	}
+	return scanSubscribedUserIDs(dbconn.Global.QueryContext(ctx, "SELECT user_id FROM discussion_thread_subscriptions WHERE thread_id=$1 AND subscribed ORDER BY created_at ASC, user_id ASC", threadID))
+}
+
+// ListNotifiedUserIDs returns the IDs of the users subscribed to the thread
+// whose notification level is "all" or the given level (i.e., who want to be
+// notified of that kind of activity).
+func (*discussionThreadSubscriptions) ListNotifiedUserIDs(ctx context.Context, threadID int64, level types.DiscussionThreadNotificationLevel) ([]int32, error) {
+	if Mocks.DiscussionThreadSubscriptions.ListNotifiedUserIDs != nil {
+		return Mocks.DiscussionThreadSubscriptions.ListNotifiedUserIDs(ctx, threadID, level)
+	}
+	return scanSubscribedUserIDs(dbconn.Global.QueryContext(ctx, "SELECT user_id FROM discussion_thread_subscriptions WHERE thread_id=$1 AND subscribed AND notification_level IN ('all', $2) ORDER BY created_at ASC, user_id ASC", threadID, level))
+}
+
+func scanSubscribedUserIDs(rows *sql.Rows, err error) ([]int32, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	userIDs := []int32{}
+	for rows.Next() {
+		var userID int32
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionThreadSubscriptions struct {
+	Set                   func(ctx context.Context, threadID int64, userID int32, subscribed bool) error
+	AutoSubscribe         func(ctx context.Context, threadID int64, userIDs []int32) error
+	IsSubscribed          func(ctx context.Context, threadID int64, userID int32) (bool, error)
+	SetNotificationLevel  func(ctx context.Context, threadID int64, userID int32, level types.DiscussionThreadNotificationLevel) error
+	GetNotificationLevel  func(ctx context.Context, threadID int64, userID int32) (types.DiscussionThreadNotificationLevel, error)
+	ListSubscribedUserIDs func(ctx context.Context, threadID int64) ([]int32, error)
+	ListNotifiedUserIDs   func(ctx context.Context, threadID int64, level types.DiscussionThreadNotificationLevel) ([]int32, error)
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadSubscriptions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	user2, err := Users.Create(ctx, NewUser{
+		Email:                 "b@b.com",
+		Username:              "u2",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscribers := func() []int32 {
+		userIDs, err := DiscussionThreadSubscriptions.ListSubscribedUserIDs(ctx, thread.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return userIDs
+	}
+
+	if err := DiscussionThreadSubscriptions.AutoSubscribe(ctx, thread.ID, []int32{user.ID, user2.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := subscribers(), []int32{user.ID, user2.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got subscribers %v, want %v", got, want)
+	}
+
+	// Users who explicitly unsubscribed are not automatically subscribed again.
+	if err := DiscussionThreadSubscriptions.Set(ctx, thread.ID, user2.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscussionThreadSubscriptions.AutoSubscribe(ctx, thread.ID, []int32{user2.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := subscribers(), []int32{user.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got subscribers %v, want %v", got, want)
+	}
+	subscribed, err := DiscussionThreadSubscriptions.IsSubscribed(ctx, thread.ID, user2.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscribed {
+		t.Error("got subscribed true, want false")
+	}
+
+	if err := DiscussionThreadSubscriptions.Set(ctx, thread.ID, user2.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	subscribed, err = DiscussionThreadSubscriptions.IsSubscribed(ctx, thread.ID, user2.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !subscribed {
+		t.Error("got subscribed false, want true")
+	}
+
+	// Notification levels filter the subscribers who are notified of each kind
+	// of activity.
+	if err := DiscussionThreadSubscriptions.SetNotificationLevel(ctx, thread.ID, user2.ID, types.DiscussionThreadNotifyStateChanges); err != nil {
+		t.Fatal(err)
+	}
+	level, err := DiscussionThreadSubscriptions.GetNotificationLevel(ctx, thread.ID, user2.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := types.DiscussionThreadNotifyStateChanges; level != want {
+		t.Errorf("got notification level %q, want %q", level, want)
+	}
+	for level, want := range map[types.DiscussionThreadNotificationLevel][]int32{
+		types.DiscussionThreadNotifyComments:     {user.ID},
+		types.DiscussionThreadNotifyStateChanges: {user.ID, user2.ID},
+	} {
+		got, err := DiscussionThreadSubscriptions.ListNotifiedUserIDs(ctx, thread.ID, level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got notified users %v, want %v", level, got, want)
+		}
+	}
+
+	// Unsubscribing and subscribing again keeps the notification level.
+	if err := DiscussionThreadSubscriptions.Set(ctx, thread.ID, user2.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if level, err := DiscussionThreadSubscriptions.GetNotificationLevel(ctx, thread.ID, user2.ID); err != nil {
+		t.Fatal(err)
+	} else if level != "" {
+		t.Errorf("got notification level %q for unsubscribed user, want none", level)
+	}
+	if err := DiscussionThreadSubscriptions.Set(ctx, thread.ID, user2.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if level, err := DiscussionThreadSubscriptions.GetNotificationLevel(ctx, thread.ID, user2.ID); err != nil {
+		t.Fatal(err)
+	} else if want := types.DiscussionThreadNotifyStateChanges; level != want {
+		t.Errorf("got notification level %q, want %q", level, want)
+	}
+}
@@ -0,0 +1,232 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionThreadTemplates provides access to the
+// `discussion_thread_templates` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionThreadTemplates struct{}
+
+// ErrThreadTemplateNotFound is the error returned by Discussions methods to
+// indicate that the thread template could not be found.
+type ErrThreadTemplateNotFound struct {
+	// TemplateID is the template that was not found.
+	TemplateID int64
+}
+
+func (e *ErrThreadTemplateNotFound) Error() string {
+	return fmt.Sprintf("thread template %d not found", e.TemplateID)
+}
+
+func (e *ErrThreadTemplateNotFound) NotFound() bool { return true }
+
+func (t *discussionThreadTemplates) Create(ctx context.Context, newTemplate *types.DiscussionThreadTemplate) (*types.DiscussionThreadTemplate, error) {
+	if Mocks.DiscussionThreadTemplates.Create != nil {
+		return Mocks.DiscussionThreadTemplates.Create(ctx, newTemplate)
+	}
+
+	// Validate the input template.
+	if newTemplate == nil {
+		return nil, errors.New("newTemplate is nil")
+	}
+	if newTemplate.ID != 0 {
+		return nil, errors.New("newTemplate.ID must be zero")
+	}
+	if newTemplate.RepoID == 0 {
+		return nil, errors.New("newTemplate.RepoID must be specified")
+	}
+	if strings.TrimSpace(newTemplate.Name) == "" {
+		return nil, errors.New("newTemplate.Name must be present (and not whitespace)")
+	}
+	if len([]rune(newTemplate.Name)) > 100 {
+		return nil, errors.New("newTemplate.Name too long (must be less than 100 UTF-8 characters)")
+	}
+	if newTemplate.Title != nil && len([]rune(*newTemplate.Title)) > 500 {
+		return nil, errors.New("newTemplate.Title too long (must be less than 500 UTF-8 characters)")
+	}
+	if strings.TrimSpace(newTemplate.Contents) == "" {
+		return nil, errors.New("newTemplate.Contents must be present (and not whitespace)")
+	}
+	if !newTemplate.CreatedAt.IsZero() {
+		return nil, errors.New("newTemplate.CreatedAt must not be specified")
+	}
+	if !newTemplate.UpdatedAt.IsZero() {
+		return nil, errors.New("newTemplate.UpdatedAt must not be specified")
+	}
+
+	newTemplate.CreatedAt = time.Now()
+	newTemplate.UpdatedAt = newTemplate.CreatedAt
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_thread_templates(
+		repo_id,
+		name,
+		description,
+		title,
+		contents,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		newTemplate.RepoID,
+		newTemplate.Name,
+		newTemplate.Description,
+		newTemplate.Title,
+		newTemplate.Contents,
+		newTemplate.CreatedAt,
+		newTemplate.UpdatedAt,
+	).Scan(&newTemplate.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "discussion_thread_templates_repo_id_name_idx" {
+			return nil, &ErrNameAlreadyExists{Message: fmt.Sprintf("a thread template named %q already exists in this repository", newTemplate.Name)}
+		}
+		return nil, err
+	}
+	return newTemplate, nil
+}
+
+func (t *discussionThreadTemplates) Get(ctx context.Context, templateID int64) (*types.DiscussionThreadTemplate, error) {
+	if Mocks.DiscussionThreadTemplates.Get != nil {
+		return Mocks.DiscussionThreadTemplates.Get(templateID)
+	}
+
+	templates, err := t.List(ctx, &DiscussionThreadTemplatesListOptions{
+		TemplateIDs: []int64{templateID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, &ErrThreadTemplateNotFound{TemplateID: templateID}
+	}
+	return templates[0], nil
+}
+
+func (t *discussionThreadTemplates) Delete(ctx context.Context, templateID int64) error {
+	if Mocks.DiscussionThreadTemplates.Delete != nil {
+		return Mocks.DiscussionThreadTemplates.Delete(ctx, templateID)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_thread_templates WHERE id=$1", templateID)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return &ErrThreadTemplateNotFound{TemplateID: templateID}
+	}
+	return nil
+}
+
+type DiscussionThreadTemplatesListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// TemplateIDs, when len() > 0, specifies that only templates with one of
+	// these IDs should be returned.
+	TemplateIDs []int64
+
+	// RepoID, when non-zero, specifies that only templates in this repository
+	// should be returned.
+	RepoID api.RepoID
+}
+
+func (t *discussionThreadTemplates) List(ctx context.Context, opts *DiscussionThreadTemplatesListOptions) ([]*types.DiscussionThreadTemplate, error) {
+	if Mocks.DiscussionThreadTemplates.List != nil {
+		return Mocks.DiscussionThreadTemplates.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := t.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY name ASC, id ASC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+	return t.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (t *discussionThreadTemplates) Count(ctx context.Context, opts *DiscussionThreadTemplatesListOptions) (int, error) {
+	if Mocks.DiscussionThreadTemplates.Count != nil {
+		return Mocks.DiscussionThreadTemplates.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := t.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
+	return t.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+}
+
+func (*discussionThreadTemplates) getListSQL(opts *DiscussionThreadTemplatesListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.TemplateIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id = ANY(%v)", pq.Array(opts.TemplateIDs)))
+	}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id=%v", opts.RepoID))
+	}
+	return conds
+}
+
+func (*discussionThreadTemplates) getCountBySQL(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+	rows := dbconn.Global.QueryRowContext(ctx, "SELECT count(id) FROM discussion_thread_templates t "+query, args...)
+	err := rows.Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
+
+// getBySQL returns thread templates matching the SQL query, if any exist.
+func (*discussionThreadTemplates) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionThreadTemplate, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
+		SELECT
+			t.id,
+			t.repo_id,
+			t.name,
+			t.description,
+			t.title,
+			t.contents,
+			t.created_at,
+			t.updated_at
+		FROM discussion_thread_templates t `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []*types.DiscussionThreadTemplate{}
+	defer rows.Close()
+	for rows.Next() {
+		template := &types.DiscussionThreadTemplate{}
+		err := rows.Scan(
+			&template.ID,
+			&template.RepoID,
+			&template.Name,
+			&template.Description,
+			&template.Title,
+			&template.Contents,
+			&template.CreatedAt,
+			&template.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
@@ -0,0 +1,15 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionThreadTemplates struct {
+	Create func(ctx context.Context, newTemplate *types.DiscussionThreadTemplate) (*types.DiscussionThreadTemplate, error)
+	Get    func(templateID int64) (*types.DiscussionThreadTemplate, error)
+	Delete func(ctx context.Context, templateID int64) error
+	List   func(ctx context.Context, opts *DiscussionThreadTemplatesListOptions) ([]*types.DiscussionThreadTemplate, error)
+	Count  func(ctx context.Context, opts *DiscussionThreadTemplatesListOptions) (int, error)
+}
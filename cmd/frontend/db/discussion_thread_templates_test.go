@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionThreadTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	_, repo, _ := createTestDiscussionThread(ctx, t)
+
+	template, err := DiscussionThreadTemplates.Create(ctx, &types.DiscussionThreadTemplate{
+		RepoID:   repo.ID,
+		Name:     "Bug report",
+		Title:    strPtr("Bug: "),
+		Contents: "## Steps to reproduce",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotTemplate, err := DiscussionThreadTemplates.Get(ctx, template.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotTemplate.Name != template.Name || gotTemplate.Contents != template.Contents || *gotTemplate.Title != *template.Title {
+		t.Errorf("got template %+v, want %+v", gotTemplate, template)
+	}
+
+	// Template names are unique per repository.
+	if _, err := DiscussionThreadTemplates.Create(ctx, &types.DiscussionThreadTemplate{RepoID: repo.ID, Name: "Bug report", Contents: "x"}); err == nil {
+		t.Error("expected error creating duplicate template")
+	}
+
+	count, err := DiscussionThreadTemplates.Count(ctx, &DiscussionThreadTemplatesListOptions{RepoID: repo.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %d templates, want 1", count)
+	}
+
+	if err := DiscussionThreadTemplates.Delete(ctx, template.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreadTemplates.Get(ctx, template.ID); err == nil {
+		t.Error("expected error getting deleted template")
+	}
+}
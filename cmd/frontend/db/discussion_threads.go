@@ -14,10 +14,13 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"github.com/segmentio/fasthash/fnv1"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/searchquery"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -40,11 +43,274 @@ func (e *ErrThreadNotFound) Error() string {
 	return fmt.Sprintf("thread %d not found", e.ThreadID)
 }
 
-func (t *discussionThreads) Create(ctx context.Context, newThread *types.DiscussionThread) (*types.DiscussionThread, error) {
+func (e *ErrThreadNotFound) NotFound() bool { return true }
+
+func (t *discussionThreads) Create(ctx context.Context, newThread *types.DiscussionThread) (_ *types.DiscussionThread, err error) {
 	if Mocks.DiscussionThreads.Create != nil {
 		return Mocks.DiscussionThreads.Create(ctx, newThread)
 	}
 
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.Create", &err)
+	defer done()
+
+	return t.create(ctx, dbconn.Global, newThread)
+}
+
+// dbHandle is the DB handle that store methods execute queries with: either
+// dbconn.Global or a transaction. It allows us to reuse the same logic both on
+// its own and as part of a larger transaction.
+type dbHandle interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// CreateWithComment creates a thread along with its first comment and the
+// event that records the thread's creation, in a single transaction. Either
+// all of them are created or (on error) none of them are.
+//
+// The comment's and event's ThreadID is set to the new thread's ID.
+func (t *discussionThreads) CreateWithComment(ctx context.Context, newThread *types.DiscussionThread, newComment *types.DiscussionComment, newEvent *types.DiscussionThreadEvent) (_ *types.DiscussionThread, err error) {
+	if Mocks.DiscussionThreads.CreateWithComment != nil {
+		return Mocks.DiscussionThreads.CreateWithComment(ctx, newThread, newComment, newEvent)
+	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.CreateWithComment", &err)
+	defer done()
+
+	if newComment == nil || newEvent == nil {
+		return nil, errors.New("newComment and newEvent must not be nil")
+	}
+	if newComment.ThreadID != 0 || newEvent.ThreadID != 0 {
+		return nil, errors.New("newComment.ThreadID and newEvent.ThreadID must not be specified")
+	}
+
+	var thread *types.DiscussionThread
+	err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		var err error
+		thread, err = t.create(ctx, tx, newThread)
+		if err != nil {
+			return err
+		}
+		newComment.ThreadID = thread.ID
+		if _, err := DiscussionComments.create(ctx, tx, newComment); err != nil {
+			return errors.Wrap(err, "create comment")
+		}
+		newEvent.ThreadID = thread.ID
+		if _, err := DiscussionThreadEvents.create(ctx, tx, newEvent); err != nil {
+			return errors.Wrap(err, "create event")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The comment is counted by the database, but the thread was read before
+	// the comment was created.
+	thread.CommentCount++
+	return thread, nil
+}
+
+// DiscussionThreadAction is a single change that ApplyActions makes to a
+// thread. Exactly one of its fields must be set.
+type DiscussionThreadAction struct {
+	// AddLabelIDs are labels to add to the thread. The caller is responsible
+	// for ensuring the labels belong to the thread's repository.
+	AddLabelIDs []int64
+
+	// RemoveLabelIDs are labels to remove from the thread.
+	RemoveLabelIDs []int64
+
+	// AddAssigneeUserID is a user to assign to the thread.
+	AddAssigneeUserID int32
+
+	// RemoveAssigneeUserID is a user to unassign from the thread.
+	RemoveAssigneeUserID int32
+
+	// Comment is a comment to add to the thread. Its ThreadID must be the
+	// thread's ID.
+	Comment *types.DiscussionComment
+
+	// Archive specifies whether the thread is archived or not.
+	Archive *bool
+}
+
+func (a *DiscussionThreadAction) validate() error {
+	n := 0
+	if len(a.AddLabelIDs) > 0 {
+		n++
+	}
+	if len(a.RemoveLabelIDs) > 0 {
+		n++
+	}
+	if a.AddAssigneeUserID != 0 {
+		n++
+	}
+	if a.RemoveAssigneeUserID != 0 {
+		n++
+	}
+	if a.Comment != nil {
+		n++
+	}
+	if a.Archive != nil {
+		n++
+	}
+	if n != 1 {
+		return errors.New("exactly one field of each action must be set")
+	}
+	return nil
+}
+
+// ApplyActions applies the actions to the thread, in order, in a single
+// transaction. Either all of them are applied or (on error) none of them are.
+//
+// Each change is recorded on the thread's timeline (actions that change
+// nothing, such as adding a label the thread already has, are not). The
+// recorded events are returned along with the updated thread, so that the
+// caller can deliver them once the transaction is committed.
+func (t *discussionThreads) ApplyActions(ctx context.Context, threadID int64, actorUserID int32, actions []*DiscussionThreadAction) (_ *types.DiscussionThread, _ []*types.DiscussionThreadEvent, err error) {
+	if Mocks.DiscussionThreads.ApplyActions != nil {
+		return Mocks.DiscussionThreads.ApplyActions(ctx, threadID, actorUserID, actions)
+	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.ApplyActions", &err)
+	defer done()
+
+	for _, a := range actions {
+		if err := a.validate(); err != nil {
+			return nil, nil, err
+		}
+		if a.Comment != nil && a.Comment.ThreadID != threadID {
+			return nil, nil, errors.New("Comment.ThreadID must be the thread's ID")
+		}
+	}
+
+	var events []*types.DiscussionThreadEvent
+	err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		// Lock the thread so that concurrent changes to its archived state
+		// are serialized.
+		var archived bool
+		if err := tx.QueryRowContext(ctx, "SELECT archived_at IS NOT NULL FROM discussion_threads WHERE id=$1 AND deleted_at IS NULL FOR UPDATE", threadID).Scan(&archived); err != nil {
+			if err == sql.ErrNoRows {
+				return &ErrThreadNotFound{ThreadID: threadID}
+			}
+			return err
+		}
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, threadID)
+		if err != nil {
+			return err
+		}
+		wasArchived := archived
+
+		logEvent := func(kind types.DiscussionThreadEventKind, data types.DiscussionThreadEventData) error {
+			event, err := DiscussionThreadEvents.create(ctx, tx, &types.DiscussionThreadEvent{
+				ThreadID:    threadID,
+				ActorUserID: actorUserID,
+				Kind:        kind,
+				Data:        data,
+			})
+			if err != nil {
+				return errors.Wrap(err, "create event")
+			}
+			events = append(events, event)
+			return nil
+		}
+		logLabelEvents := func(kind types.DiscussionThreadEventKind, labelIDs []int64) error {
+			for i := range labelIDs {
+				if err := logEvent(kind, types.DiscussionThreadEventData{LabelID: &labelIDs[i]}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for _, a := range actions {
+			switch {
+			case len(a.AddLabelIDs) > 0:
+				added, err := DiscussionLabels.addToThread(ctx, tx, threadID, a.AddLabelIDs)
+				if err != nil {
+					return errors.Wrap(err, "add labels")
+				}
+				if err := logLabelEvents(types.DiscussionThreadEventLabelAdded, added); err != nil {
+					return err
+				}
+
+			case len(a.RemoveLabelIDs) > 0:
+				removed, err := DiscussionLabels.removeFromThread(ctx, tx, threadID, a.RemoveLabelIDs)
+				if err != nil {
+					return errors.Wrap(err, "remove labels")
+				}
+				if err := logLabelEvents(types.DiscussionThreadEventLabelRemoved, removed); err != nil {
+					return err
+				}
+
+			case a.AddAssigneeUserID != 0:
+				added, err := DiscussionThreadAssignees.add(ctx, tx, threadID, a.AddAssigneeUserID)
+				if err != nil {
+					return errors.Wrap(err, "add assignee")
+				}
+				if added {
+					if err := logEvent(types.DiscussionThreadEventAssigned, types.DiscussionThreadEventData{AssigneeUserID: &a.AddAssigneeUserID}); err != nil {
+						return err
+					}
+				}
+
+			case a.RemoveAssigneeUserID != 0:
+				removed, err := DiscussionThreadAssignees.remove(ctx, tx, threadID, a.RemoveAssigneeUserID)
+				if err != nil {
+					return errors.Wrap(err, "remove assignee")
+				}
+				if removed {
+					if err := logEvent(types.DiscussionThreadEventUnassigned, types.DiscussionThreadEventData{AssigneeUserID: &a.RemoveAssigneeUserID}); err != nil {
+						return err
+					}
+				}
+
+			case a.Comment != nil:
+				if _, err := DiscussionComments.create(ctx, tx, a.Comment); err != nil {
+					return err // Intentionally not wrapping the error here for cleaner error messages.
+				}
+				if err := logEvent(types.DiscussionThreadEventCommented, types.DiscussionThreadEventData{CommentID: &a.Comment.ID}); err != nil {
+					return err
+				}
+
+			case a.Archive != nil:
+				if *a.Archive == archived {
+					continue
+				}
+				archived = *a.Archive
+				var archivedAt *time.Time
+				kind := types.DiscussionThreadEventUnarchived
+				if archived {
+					now := time.Now()
+					archivedAt = &now
+					kind = types.DiscussionThreadEventArchived
+				}
+				if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET archived_at=$1, stale_at=NULL, updated_at=GREATEST(updated_at, $2) WHERE id=$3", archivedAt, time.Now(), threadID); err != nil {
+					return err
+				}
+				if err := logEvent(kind, types.DiscussionThreadEventData{}); err != nil {
+					return err
+				}
+			}
+		}
+		if archived != wasArchived {
+			return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, "update", before)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	invalidateDiscussionThreads(threadID)
+	thread, err := t.get(ctx, threadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return thread, events, nil
+}
+
+func (t *discussionThreads) create(ctx context.Context, dbh dbHandle, newThread *types.DiscussionThread) (*types.DiscussionThread, error) {
 	// Validate the input thread.
 	if newThread == nil {
 		return nil, errors.New("newThread is nil")
@@ -64,6 +330,24 @@ func (t *discussionThreads) Create(ctx context.Context, newThread *types.Discuss
 	if newThread.ArchivedAt != nil {
 		return nil, errors.New("newThread.ArchivedAt must not be specified")
 	}
+	if newThread.LockedAt != nil {
+		return nil, errors.New("newThread.LockedAt must not be specified")
+	}
+	if newThread.StaleAt != nil {
+		return nil, errors.New("newThread.StaleAt must not be specified")
+	}
+	if newThread.PinnedAt != nil {
+		return nil, errors.New("newThread.PinnedAt must not be specified")
+	}
+	if len(newThread.Reports) > 0 {
+		return nil, errors.New("newThread.Reports must not be specified")
+	}
+	if newThread.MilestoneID != nil {
+		return nil, errors.New("newThread.MilestoneID must not be specified")
+	}
+	if newThread.DuplicateOfThreadID != nil {
+		return nil, errors.New("newThread.DuplicateOfThreadID must not be specified")
+	}
 	if !newThread.UpdatedAt.IsZero() {
 		return nil, errors.New("newThread.UpdatedAt must not be specified")
 	}
@@ -80,23 +364,32 @@ func (t *discussionThreads) Create(ctx context.Context, newThread *types.Discuss
 		return nil, errors.New("newThread must have a target")
 	}
 
-	// TODO(slimsag:discussions): should be in a transaction
-
 	// First, create the thread itself. Initially it will have no target.
 	newThread.CreatedAt = time.Now()
 	newThread.UpdatedAt = newThread.CreatedAt
-	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_threads(
+	if newThread.IdempotencyKey != nil {
+		// Expired keys (and keys of deleted threads) may be reused.
+		if _, err := dbh.ExecContext(ctx, "UPDATE discussion_threads SET idempotency_key=NULL WHERE author_user_id=$1 AND idempotency_key=$2 AND (created_at < $3 OR deleted_at IS NOT NULL)", newThread.AuthorUserID, *newThread.IdempotencyKey, newThread.CreatedAt.Add(-DiscussionThreadIdempotencyWindow)); err != nil {
+			return nil, errors.Wrap(err, "expire idempotency key")
+		}
+	}
+	err := dbh.QueryRowContext(ctx, `INSERT INTO discussion_threads(
 		author_user_id,
 		title,
+		idempotency_key,
 		created_at,
 		updated_at
-	) VALUES ($1, $2, $3, $4) RETURNING id`,
+	) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
 		newThread.AuthorUserID,
 		newThread.Title,
+		newThread.IdempotencyKey,
 		newThread.CreatedAt,
 		newThread.UpdatedAt,
 	).Scan(&newThread.ID)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "discussion_threads_author_user_id_idempotency_key_idx" {
+			return nil, ErrDuplicateIdempotencyKey
+		}
 		return nil, errors.Wrap(err, "create thread")
 	}
 
@@ -108,7 +401,7 @@ func (t *discussionThreads) Create(ctx context.Context, newThread *types.Discuss
 	switch {
 	case newThread.TargetRepo != nil:
 		var err error
-		newThread.TargetRepo, err = t.createTargetRepo(ctx, newThread.TargetRepo, newThread.ID)
+		newThread.TargetRepo, err = t.createTargetRepo(ctx, dbh, newThread.TargetRepo, newThread.ID)
 		if err != nil {
 			return nil, errors.Wrap(err, "createTargetRepo")
 		}
@@ -119,18 +412,112 @@ func (t *discussionThreads) Create(ctx context.Context, newThread *types.Discuss
 	}
 
 	// Update the thread to reference the target we just created.
-	_, err = dbconn.Global.ExecContext(ctx, `UPDATE discussion_threads SET `+targetName+`=$1 WHERE id=$2`, targetID, newThread.ID)
+	_, err = dbh.ExecContext(ctx, `UPDATE discussion_threads SET `+targetName+`=$1 WHERE id=$2`, targetID, newThread.ID)
 	if err != nil {
 		return nil, errors.Wrap(err, "update thread target")
 	}
+	if err := recordDiscussionAudit(ctx, dbh, types.DiscussionAuditLogObjectThread, newThread.ID, "create", nil); err != nil {
+		return nil, err
+	}
 	return newThread, nil
 }
 
-func (t *discussionThreads) Get(ctx context.Context, threadID int64) (*types.DiscussionThread, error) {
+// GetByNumber returns the thread with the number in the repository. This
+// includes a thread that had the number before it was transferred to another
+// repository, which is then returned with its new repository and number. If
+// there is none, it returns an *ErrThreadNotFound error.
+func (t *discussionThreads) GetByNumber(ctx context.Context, repoID api.RepoID, number int32) (*types.DiscussionThread, error) {
+	if Mocks.DiscussionThreads.GetByNumber != nil {
+		return Mocks.DiscussionThreads.GetByNumber(repoID, number)
+	}
+
+	// 🚨 SECURITY: List checks that the current user can access the
+	// repository.
+	threads, err := t.List(ctx, &DiscussionThreadsListOptions{
+		TargetRepoID:     &repoID,
+		TargetRepoNumber: &number,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(threads) == 0 {
+		// The thread may have had the number before it was transferred to
+		// another repository. Numbers are never reused, so there is at most
+		// one such thread.
+		var threadID int64
+		err := dbconn.Global.QueryRowContext(ctx, "SELECT thread_id FROM discussion_thread_previous_numbers WHERE repo_id=$1 AND number=$2", repoID, number).Scan(&threadID)
+		if err == sql.ErrNoRows {
+			return nil, &ErrThreadNotFound{}
+		}
+		if err != nil {
+			return nil, err
+		}
+		// 🚨 SECURITY: Get checks that the current user can access the
+		// repository that the thread is in now.
+		return t.Get(ctx, threadID)
+	}
+	return threads[0], nil
+}
+
+// DiscussionThreadIdempotencyWindow is how long after a thread is created that
+// creating a thread with the same idempotency key (by the same author) returns
+// the existing thread instead (see GetByIdempotencyKey).
+const DiscussionThreadIdempotencyWindow = 24 * time.Hour
+
+// ErrDuplicateIdempotencyKey is returned by DiscussionThreads.Create and
+// CreateWithComment when the author already created a thread with the
+// thread's idempotency key within DiscussionThreadIdempotencyWindow (e.g. in
+// a concurrent request).
+var ErrDuplicateIdempotencyKey = errors.New("a thread with the idempotency key already exists")
+
+// GetByIdempotencyKey returns the thread with the idempotency key that the
+// author created within DiscussionThreadIdempotencyWindow. If there is none,
+// it returns an *ErrThreadNotFound error.
+func (t *discussionThreads) GetByIdempotencyKey(ctx context.Context, authorUserID int32, key string) (*types.DiscussionThread, error) {
+	if Mocks.DiscussionThreads.GetByIdempotencyKey != nil {
+		return Mocks.DiscussionThreads.GetByIdempotencyKey(authorUserID, key)
+	}
+
+	var threadID int64
+	err := dbconn.Global.QueryRowContext(ctx, "SELECT id FROM discussion_threads WHERE author_user_id=$1 AND idempotency_key=$2 AND created_at >= $3 AND deleted_at IS NULL", authorUserID, key, time.Now().Add(-DiscussionThreadIdempotencyWindow)).Scan(&threadID)
+	if err == sql.ErrNoRows {
+		return nil, &ErrThreadNotFound{}
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Get checks that the current user can (still) access the
+	// thread's repository.
+	return t.Get(ctx, threadID)
+}
+
+func (t *discussionThreads) Get(ctx context.Context, threadID int64) (_ *types.DiscussionThread, err error) {
 	if Mocks.DiscussionThreads.Get != nil {
 		return Mocks.DiscussionThreads.Get(threadID)
 	}
 
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.Get", &err)
+	defer done()
+
+	ttl := discussionsCacheTTL()
+	if ttl == 0 {
+		return t.get(ctx, threadID)
+	}
+	if thread, err := t.getCached(ctx, threadID); thread != nil || err != nil {
+		return thread, err
+	}
+	thread, err := t.get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.RepoDeletedAt == nil {
+		discussionsCacheSet(discussionThreadsCacheName, ttl, threadID, thread)
+	}
+	return thread, nil
+}
+
+// get is like Get, except that it always reads the thread from the database.
+func (t *discussionThreads) get(ctx context.Context, threadID int64) (*types.DiscussionThread, error) {
 	threads, err := t.List(ctx, &DiscussionThreadsListOptions{
 		ThreadIDs: []int64{threadID},
 	})
@@ -148,70 +535,823 @@ type DiscussionThreadsUpdateOptions struct {
 	Title *string
 
 	// Archive, when non-nil, specifies whether the thread is archived or not.
+	// Archiving or unarchiving a thread also makes it no longer stale.
 	Archive *bool
 
-	// Delete, when true, specifies that the thread should be deleted. This
-	// operation cannot be undone.
+	// Stale, when non-nil, specifies whether the thread is marked as stale
+	// (inactive and due to be archived) or not. A stale thread also stops
+	// being stale when a comment is added to it.
+	Stale *bool
+
+	// Contents, when non-nil, specifies the new contents of the thread (i.e.
+	// of its first comment). The previous contents are recorded in the
+	// comment's edit history.
+	Contents *string
+
+	// EditorUserID is the user who is editing the contents. It is ignored
+	// unless Contents is set.
+	EditorUserID int32
+
+	// DuplicateOfThreadID, when non-nil, specifies the ID of the thread that
+	// the thread is a duplicate of. Marking a thread as a duplicate does not
+	// archive it; set Archive to do so.
+	DuplicateOfThreadID *int64
+
+	// Lock, when non-nil, specifies whether the thread is locked or not. Only
+	// site admins can add comments to a locked thread.
+	Lock *bool
+
+	// Report, when non-nil, specifies that the report message string should be
+	// added to the list of reports on this thread.
+	Report *string
+
+	// ClearReports, when true, specifies that the thread's reports should be
+	// cleared (e.g. after review by an admin).
+	ClearReports bool
+
+	// Delete, when true, specifies that the thread should be deleted. The
+	// thread can be restored with DiscussionThreads.Restore until
+	// DiscussionThreadRestoreWindow has elapsed.
 	Delete bool
+
+	// ExpectedUpdatedAt, when non-nil, specifies that the thread must not have
+	// been updated since this time, or else ErrConcurrentUpdate is returned
+	// and nothing is updated. It is compared with a precision of one second,
+	// because that is the precision of the timestamps in the GraphQL API.
+	ExpectedUpdatedAt *time.Time
 }
 
-func (t *discussionThreads) Update(ctx context.Context, threadID int64, opts *DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error) {
+// ErrConcurrentUpdate is returned by DiscussionThreads.Update and
+// DiscussionComments.Update when the thread or comment was updated after the
+// given ExpectedUpdatedAt time (e.g. by another user editing it concurrently).
+var ErrConcurrentUpdate = errors.New("the thread or comment has been changed since it was last loaded; reload it and try again")
+
+// DiscussionThreadRestoreWindow is how long after a thread is deleted that it
+// can still be restored.
+const DiscussionThreadRestoreWindow = 30 * 24 * time.Hour
+
+func (t *discussionThreads) Update(ctx context.Context, threadID int64, opts *DiscussionThreadsUpdateOptions) (_ *types.DiscussionThread, err error) {
 	if Mocks.DiscussionThreads.Update != nil {
 		return Mocks.DiscussionThreads.Update(ctx, threadID, opts)
 	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.Update", &err)
+	defer done()
+
+	thread, _, err := t.update(ctx, threadID, 0, opts)
+	return thread, err
+}
+
+// UpdateAndRecord is like Update, except that it also records the changes that
+// the actor made to the thread's title, archived, locked and deleted states on
+// the thread's timeline, in the same transaction as the update. The recorded
+// events are returned along with the updated thread (which is nil if it was
+// deleted), so that the caller can deliver them once the transaction is
+// committed.
+func (t *discussionThreads) UpdateAndRecord(ctx context.Context, threadID int64, actorUserID int32, opts *DiscussionThreadsUpdateOptions) (_ *types.DiscussionThread, _ []*types.DiscussionThreadEvent, err error) {
+	if Mocks.DiscussionThreads.UpdateAndRecord != nil {
+		return Mocks.DiscussionThreads.UpdateAndRecord(ctx, threadID, actorUserID, opts)
+	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.UpdateAndRecord", &err)
+	defer done()
+
+	if actorUserID == 0 {
+		return nil, nil, errors.New("actorUserID must be specified")
+	}
+	return t.update(ctx, threadID, actorUserID, opts)
+}
+
+// update implements Update and UpdateAndRecord. Events are only recorded if
+// actorUserID is non-zero.
+func (t *discussionThreads) update(ctx context.Context, threadID int64, actorUserID int32, opts *DiscussionThreadsUpdateOptions) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
+	defer invalidateDiscussionThreads(threadID)
+
 	if opts == nil {
-		return nil, errors.New("options must not be nil")
+		return nil, nil, errors.New("options must not be nil")
 	}
+	if opts.DuplicateOfThreadID != nil && *opts.DuplicateOfThreadID == threadID {
+		return nil, nil, errors.New("a thread cannot be a duplicate of itself")
+	}
+
 	now := time.Now()
+	var events []*types.DiscussionThreadEvent
+	err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		// Lock the thread so that the expected updated_at time is checked
+		// against (and the audit log snapshot is of) the state that this
+		// update changes.
+		if err := lockAndCheckUpdatedAt(ctx, tx, "discussion_threads", threadID, opts.ExpectedUpdatedAt, &ErrThreadNotFound{ThreadID: threadID}); err != nil {
+			return err
+		}
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, threadID)
+		if err != nil {
+			return err
+		}
+		beforeState, err := getDiscussionThreadState(ctx, tx, threadID)
+		if err != nil {
+			return err
+		}
+
+		anyUpdate := false
+		if opts.Title != nil {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET title=$1 WHERE id=$2 AND deleted_at IS NULL", opts.Title, threadID); err != nil {
+				return err
+			}
+		}
+		if opts.Archive != nil {
+			anyUpdate = true
+			var archivedAt *time.Time
+			if *opts.Archive {
+				archivedAt = &now
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET archived_at=$1, stale_at=NULL WHERE id=$2 AND deleted_at IS NULL", archivedAt, threadID); err != nil {
+				return err
+			}
+		}
+		if opts.Stale != nil {
+			anyUpdate = true
+			var staleAt *time.Time
+			if *opts.Stale {
+				staleAt = &now
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET stale_at=$1 WHERE id=$2 AND deleted_at IS NULL", staleAt, threadID); err != nil {
+				return err
+			}
+		}
+		if opts.DuplicateOfThreadID != nil {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET duplicate_of_thread_id=$1 WHERE id=$2 AND deleted_at IS NULL", *opts.DuplicateOfThreadID, threadID); err != nil {
+				return err
+			}
+		}
+		if opts.Lock != nil {
+			anyUpdate = true
+			var lockedAt *time.Time
+			if *opts.Lock {
+				lockedAt = &now
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET locked_at=$1 WHERE id=$2 AND deleted_at IS NULL", lockedAt, threadID); err != nil {
+				return err
+			}
+		}
+		if opts.Report != nil {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET reports=ARRAY_APPEND(reports,$1) WHERE id=$2 AND deleted_at IS NULL", *opts.Report, threadID); err != nil {
+				return err
+			}
+		}
+		if opts.ClearReports {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET reports='{}' WHERE id=$1 AND deleted_at IS NULL", threadID); err != nil {
+				return err
+			}
+		}
+		if opts.Contents != nil {
+			anyUpdate = true
+			var firstCommentID int64
+			if err := tx.QueryRowContext(ctx, "SELECT id FROM discussion_comments WHERE thread_id=$1 AND deleted_at IS NULL ORDER BY id ASC LIMIT 1 FOR UPDATE", threadID).Scan(&firstCommentID); err != nil {
+				return err
+			}
+			commentBefore, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectComment, firstCommentID)
+			if err != nil {
+				return err
+			}
+			if err := updateDiscussionCommentContents(ctx, tx, firstCommentID, opts.EditorUserID, *opts.Contents, now); err != nil {
+				return err
+			}
+			if err := bumpUpdatedAt(ctx, tx, "discussion_comments", firstCommentID, now, false); err != nil {
+				return err
+			}
+			if err := recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectComment, firstCommentID, "update", commentBefore); err != nil {
+				return err
+			}
+		}
+		if opts.Delete {
+			anyUpdate = true
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL", now, threadID); err != nil {
+				return err
+			}
+
+			// Mark all comments in the thread as deleted.
+			if err := deleteDiscussionThreadComments(ctx, tx, threadID, now); err != nil {
+				return err
+			}
+		}
+		if !anyUpdate {
+			return nil
+		}
+		if err := bumpUpdatedAt(ctx, tx, "discussion_threads", threadID, now, opts.ExpectedUpdatedAt != nil); err != nil {
+			return err
+		}
+		action := "update"
+		if opts.Delete {
+			action = "delete"
+		}
+		if err := recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, action, before); err != nil {
+			return err
+		}
+		if actorUserID == 0 {
+			return nil
+		}
+		events, err = recordDiscussionThreadStateEvents(ctx, tx, threadID, actorUserID, beforeState)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.Delete {
+		return nil, events, nil
+	}
+	thread, err := t.get(ctx, threadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return thread, events, nil
+}
 
-	// TODO(slimsag:discussions): should be in a transaction
+// discussionThreadState is the part of a thread's state whose changes
+// recordDiscussionThreadStateEvents records on the thread's timeline.
+type discussionThreadState struct {
+	title                     string
+	archived, locked, deleted bool
+}
 
-	anyUpdate := false
-	if opts.Title != nil {
-		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET title=$1 WHERE id=$2 AND deleted_at IS NULL", opts.Title, threadID); err != nil {
+func getDiscussionThreadState(ctx context.Context, dbh dbHandle, threadID int64) (state discussionThreadState, err error) {
+	err = dbh.QueryRowContext(ctx, "SELECT title, archived_at IS NOT NULL, locked_at IS NOT NULL, deleted_at IS NOT NULL FROM discussion_threads WHERE id=$1", threadID).Scan(&state.title, &state.archived, &state.locked, &state.deleted)
+	return state, err
+}
+
+// recordDiscussionThreadStateEvents records the changes that the actor made
+// to the thread's state since it was in the before state on the thread's
+// timeline, and returns the recorded events. Deleting a thread is recorded as
+// a single DELETED event.
+func recordDiscussionThreadStateEvents(ctx context.Context, tx *sql.Tx, threadID int64, actorUserID int32, before discussionThreadState) ([]*types.DiscussionThreadEvent, error) {
+	after, err := getDiscussionThreadState(ctx, tx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	var events []*types.DiscussionThreadEvent
+	record := func(kind types.DiscussionThreadEventKind, data types.DiscussionThreadEventData) error {
+		event, err := DiscussionThreadEvents.create(ctx, tx, &types.DiscussionThreadEvent{
+			ThreadID:    threadID,
+			ActorUserID: actorUserID,
+			Kind:        kind,
+			Data:        data,
+		})
+		if err != nil {
+			return errors.Wrap(err, "create event")
+		}
+		events = append(events, event)
+		return nil
+	}
+	if after.deleted {
+		if !before.deleted {
+			if err := record(types.DiscussionThreadEventDeleted, types.DiscussionThreadEventData{}); err != nil {
+				return nil, err
+			}
+		}
+		return events, nil
+	}
+	if after.title != before.title {
+		if err := record(types.DiscussionThreadEventTitleEdited, types.DiscussionThreadEventData{
+			PreviousTitle: &before.title,
+			Title:         &after.title,
+		}); err != nil {
 			return nil, err
 		}
 	}
-	if opts.Archive != nil {
-		anyUpdate = true
-		var archivedAt *time.Time
-		if *opts.Archive {
-			archivedAt = &now
+	if after.archived != before.archived {
+		kind := types.DiscussionThreadEventUnarchived
+		if after.archived {
+			kind = types.DiscussionThreadEventArchived
 		}
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET archived_at=$1 WHERE id=$2 AND deleted_at IS NULL", archivedAt, threadID); err != nil {
+		if err := record(kind, types.DiscussionThreadEventData{}); err != nil {
 			return nil, err
 		}
 	}
-	if opts.Delete {
-		anyUpdate = true
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL", now, threadID); err != nil {
+	if after.locked != before.locked {
+		kind := types.DiscussionThreadEventUnlocked
+		if after.locked {
+			kind = types.DiscussionThreadEventLocked
+		}
+		if err := record(kind, types.DiscussionThreadEventData{}); err != nil {
 			return nil, err
 		}
+	}
+	return events, nil
+}
 
-		// Mark all comments in the thread as deleted.
-		comments, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{
-			ThreadID: &threadID,
-		})
+// deleteDiscussionThread marks the thread and its comments as deleted,
+// recording the deletions in the audit log. The caller must have locked the
+// thread.
+func deleteDiscussionThread(ctx context.Context, tx *sql.Tx, threadID int64, now time.Time) error {
+	before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, threadID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET deleted_at=$1, updated_at=GREATEST(updated_at, $1) WHERE id=$2 AND deleted_at IS NULL", now, threadID); err != nil {
+		return err
+	}
+	if err := deleteDiscussionThreadComments(ctx, tx, threadID, now); err != nil {
+		return err
+	}
+	return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, "delete", before)
+}
+
+// deleteDiscussionThreadComments marks the comments in the thread that are not
+// yet deleted as deleted, recording each deletion in the audit log.
+func deleteDiscussionThreadComments(ctx context.Context, tx *sql.Tx, threadID int64, now time.Time) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM discussion_comments WHERE thread_id=$1 AND deleted_at IS NULL ORDER BY id FOR UPDATE", threadID)
+	if err != nil {
+		return err
+	}
+	var commentIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		commentIDs = append(commentIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, id := range commentIDs {
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectComment, id)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		for _, comment := range comments {
-			_, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{Delete: true, noThreadDelete: true})
-			if err != nil {
-				return nil, err
+		if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET deleted_at=$1 WHERE id=$2", now, id); err != nil {
+			return err
+		}
+		if err := recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectComment, id, "delete", before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockAndCheckUpdatedAt locks the row with the given ID in the table (which
+// must have updated_at and deleted_at columns) for the rest of the transaction.
+// It returns notFound if the row does not exist or is deleted and, if expected
+// is non-nil, ErrConcurrentUpdate if the row was updated after the expected
+// time. Because the row stays locked, no other update can happen between the
+// check and the caller's update.
+func lockAndCheckUpdatedAt(ctx context.Context, tx *sql.Tx, table string, id int64, expected *time.Time, notFound error) error {
+	var sameSecond bool
+	err := tx.QueryRowContext(ctx, "SELECT $2::timestamptz IS NULL OR date_trunc('second', updated_at)=date_trunc('second', $2::timestamptz) FROM "+table+" WHERE id=$1 AND deleted_at IS NULL FOR UPDATE", id, expected).Scan(&sameSecond)
+	if err == sql.ErrNoRows {
+		return notFound
+	}
+	if err != nil {
+		return err
+	}
+	if !sameSecond {
+		return ErrConcurrentUpdate
+	}
+	return nil
+}
+
+// bumpUpdatedAt sets the updated_at time of the row with the given ID in the
+// table to now. If expected is true (i.e. the update was checked with
+// lockAndCheckUpdatedAt), it is bumped to at least the next second, so that
+// the change is visible to other updates that expect the previous time at a
+// precision of one second.
+func bumpUpdatedAt(ctx context.Context, tx *sql.Tx, table string, id int64, now time.Time, expected bool) error {
+	q := "UPDATE " + table + " SET updated_at=GREATEST(updated_at, $1) WHERE id=$2"
+	if expected {
+		q = "UPDATE " + table + " SET updated_at=GREATEST($1, date_trunc('second', updated_at) + interval '1 second') WHERE id=$2"
+	}
+	_, err := tx.ExecContext(ctx, q, now, id)
+	return err
+}
+
+// UpdateMany applies the same update to each of the given threads inside a
+// single transaction, so that either all of the threads are updated or none
+// are. It returns the IDs of the threads that were updated; threads that do
+// not exist or were already deleted are skipped.
+//
+// If actorUserID is non-zero, the changes are recorded on the threads'
+// timelines in the same transaction (see UpdateAndRecord), and the recorded
+// events are returned so that the caller can deliver them once the transaction
+// is committed.
+func (t *discussionThreads) UpdateMany(ctx context.Context, threadIDs []int64, actorUserID int32, opts *DiscussionThreadsUpdateOptions) (updated []int64, events []*types.DiscussionThreadEvent, err error) {
+	if Mocks.DiscussionThreads.UpdateMany != nil {
+		return Mocks.DiscussionThreads.UpdateMany(ctx, threadIDs, actorUserID, opts)
+	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.UpdateMany", &err)
+	defer done()
+	defer invalidateDiscussionThreads(threadIDs...)
+
+	if opts == nil {
+		return nil, nil, errors.New("options must not be nil")
+	}
+	if opts.Contents != nil {
+		return nil, nil, errors.New("the contents of many threads cannot be updated at once")
+	}
+	if len(threadIDs) == 0 {
+		return nil, nil, nil
+	}
+	now := time.Now()
+	err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		// Lock the threads so that they cannot be deleted concurrently.
+		rows, err := tx.QueryContext(ctx, "SELECT id FROM discussion_threads WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE", pq.Array(threadIDs))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			updated = append(updated, id)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(updated) == 0 {
+			return nil
+		}
+		before := make(map[int64][]byte, len(updated))
+		beforeState := make(map[int64]discussionThreadState, len(updated))
+		for _, id := range updated {
+			if before[id], err = discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, id); err != nil {
+				return err
+			}
+			if beforeState[id], err = getDiscussionThreadState(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+
+		ids := pq.Array(updated)
+		if opts.Title != nil {
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET title=$1 WHERE id = ANY($2)", opts.Title, ids); err != nil {
+				return err
+			}
+		}
+		if opts.Archive != nil {
+			var archivedAt *time.Time
+			if *opts.Archive {
+				archivedAt = &now
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET archived_at=$1, stale_at=NULL WHERE id = ANY($2)", archivedAt, ids); err != nil {
+				return err
+			}
+		}
+		if opts.Stale != nil {
+			var staleAt *time.Time
+			if *opts.Stale {
+				staleAt = &now
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET stale_at=$1 WHERE id = ANY($2)", staleAt, ids); err != nil {
+				return err
+			}
+		}
+		if opts.DuplicateOfThreadID != nil {
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET duplicate_of_thread_id=$1 WHERE id = ANY($2) AND id != $1", *opts.DuplicateOfThreadID, ids); err != nil {
+				return err
+			}
+		}
+		if opts.Lock != nil {
+			var lockedAt *time.Time
+			if *opts.Lock {
+				lockedAt = &now
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET locked_at=$1 WHERE id = ANY($2)", lockedAt, ids); err != nil {
+				return err
+			}
+		}
+		if opts.Report != nil {
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET reports=ARRAY_APPEND(reports,$1) WHERE id = ANY($2)", *opts.Report, ids); err != nil {
+				return err
+			}
+		}
+		if opts.ClearReports {
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET reports='{}' WHERE id = ANY($1)", ids); err != nil {
+				return err
+			}
+		}
+		if opts.Delete {
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET deleted_at=$1 WHERE id = ANY($2)", now, ids); err != nil {
+				return err
+			}
+			// Mark all comments in the threads as deleted.
+			for _, id := range updated {
+				if err := deleteDiscussionThreadComments(ctx, tx, id, now); err != nil {
+					return err
+				}
 			}
 		}
-	}
-	if anyUpdate {
-		if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET updated_at=$1 WHERE id=$2 AND deleted_at IS NULL", now, threadID); err != nil {
-			return nil, err
+		if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET updated_at=GREATEST(updated_at, $1) WHERE id = ANY($2)", now, ids); err != nil {
+			return err
+		}
+		action := "update"
+		if opts.Delete {
+			action = "delete"
+		}
+		for _, id := range updated {
+			if err := recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, id, action, before[id]); err != nil {
+				return err
+			}
+			if actorUserID != 0 {
+				threadEvents, err := recordDiscussionThreadStateEvents(ctx, tx, id, actorUserID, beforeState[id])
+				if err != nil {
+					return err
+				}
+				events = append(events, threadEvents...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, events, nil
+}
+
+// Restore undeletes a thread (and the comments that were deleted along with
+// it) that was deleted less than DiscussionThreadRestoreWindow ago. The event
+// that records the restoration is created in the same transaction, so the
+// thread is not restored if the event cannot be recorded.
+//
+// The event's ThreadID is set to the thread's ID.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (t *discussionThreads) Restore(ctx context.Context, threadID int64, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThread, error) {
+	if Mocks.DiscussionThreads.Restore != nil {
+		return Mocks.DiscussionThreads.Restore(ctx, threadID, newEvent)
+	}
+	if newEvent == nil {
+		return nil, errors.New("newEvent must not be nil")
+	}
+	if newEvent.ThreadID != 0 {
+		return nil, errors.New("newEvent.ThreadID must not be specified")
+	}
+	defer invalidateDiscussionThreads(threadID)
+	err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		var deletedAt time.Time
+		err := tx.QueryRowContext(ctx, "SELECT deleted_at FROM discussion_threads WHERE id=$1 AND deleted_at IS NOT NULL FOR UPDATE", threadID).Scan(&deletedAt)
+		if err == sql.ErrNoRows {
+			return &ErrThreadNotFound{ThreadID: threadID}
+		}
+		if err != nil {
+			return err
+		}
+		if time.Since(deletedAt) > DiscussionThreadRestoreWindow {
+			return fmt.Errorf("thread %d was deleted more than %v ago and can no longer be restored", threadID, DiscussionThreadRestoreWindow)
+		}
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, threadID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET deleted_at=NULL, updated_at=now() WHERE id=$1", threadID); err != nil {
+			return err
+		}
+		// Comments cannot be deleted once their thread is deleted, so the
+		// comments deleted at or after the thread's deletion time are exactly
+		// those that were deleted along with it.
+		if _, err := tx.ExecContext(ctx, "UPDATE discussion_comments SET deleted_at=NULL WHERE thread_id=$1 AND deleted_at >= $2", threadID, deletedAt); err != nil {
+			return err
+		}
+		newEvent.ThreadID = threadID
+		if _, err := DiscussionThreadEvents.create(ctx, tx, newEvent); err != nil {
+			return errors.Wrap(err, "create event")
+		}
+		return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, "restore", before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.get(ctx, threadID)
+}
+
+// ErrThreadAlreadyClosed and ErrThreadNotClosed are returned by
+// DiscussionThreads.SetClosed when closing a thread that is already closed, or
+// reopening a thread that is not closed.
+var (
+	ErrThreadAlreadyClosed = errors.New("the thread is already closed")
+	ErrThreadNotClosed     = errors.New("the thread is not closed")
+)
+
+// SetClosed closes or reopens a thread and records newEvent (the CLOSED or
+// REOPENED event, with the actor who closed or reopened it) on its timeline,
+// in a single transaction. Its ThreadID is set by SetClosed.
+//
+// Closing a thread does not archive it, so it can still be changed and
+// commented on.
+func (t *discussionThreads) SetClosed(ctx context.Context, threadID int64, closed bool, newEvent *types.DiscussionThreadEvent) (_ *types.DiscussionThread, err error) {
+	if Mocks.DiscussionThreads.SetClosed != nil {
+		return Mocks.DiscussionThreads.SetClosed(ctx, threadID, closed, newEvent)
+	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.SetClosed", &err)
+	defer done()
+
+	if newEvent == nil {
+		return nil, errors.New("newEvent must not be nil")
+	}
+	if newEvent.ThreadID != 0 {
+		return nil, errors.New("newEvent.ThreadID must not be specified")
+	}
+	defer invalidateDiscussionThreads(threadID)
+	err = dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		// Lock the thread so that concurrent attempts to close (or reopen) it
+		// are serialized, and only the first one succeeds.
+		var wasClosed bool
+		if err := tx.QueryRowContext(ctx, "SELECT closed_at IS NOT NULL FROM discussion_threads WHERE id=$1 AND deleted_at IS NULL FOR UPDATE", threadID).Scan(&wasClosed); err != nil {
+			if err == sql.ErrNoRows {
+				return &ErrThreadNotFound{ThreadID: threadID}
+			}
+			return err
+		}
+		if closed && wasClosed {
+			return ErrThreadAlreadyClosed
+		}
+		if !closed && !wasClosed {
+			return ErrThreadNotClosed
+		}
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, threadID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var closedAt *time.Time
+		if closed {
+			closedAt = &now
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET closed_at=$1, updated_at=GREATEST(updated_at, $2) WHERE id=$3", closedAt, now, threadID); err != nil {
+			return err
+		}
+		newEvent.ThreadID = threadID
+		if _, err := DiscussionThreadEvents.create(ctx, tx, newEvent); err != nil {
+			return errors.Wrap(err, "create event")
+		}
+		return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, "update", before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.get(ctx, threadID)
+}
+
+// Transfer moves a thread to another repository. The thread keeps its ID, so
+// its comments, timeline, and references to it are preserved and its URLs
+// continue to resolve. It gets a new number in the new repository, but
+// GetByNumber still finds it by its number in the previous repository.
+//
+// The thread's branch and revision refer to the Git objects of its previous
+// repository, so they are removed. So are its labels and milestone if they
+// belong to its previous repository, except that each label is replaced by
+// the label of the same name in the new repository (if there is one). The
+// thread is also unpinned, because threads are pinned in a repository.
+//
+// 🚨 SECURITY: The caller must ensure that the actor can transfer the thread
+// and can access the new repository.
+func (t *discussionThreads) Transfer(ctx context.Context, threadID int64, repoID api.RepoID) (*types.DiscussionThread, error) {
+	if Mocks.DiscussionThreads.Transfer != nil {
+		return Mocks.DiscussionThreads.Transfer(ctx, threadID, repoID)
+	}
+	defer invalidateDiscussionThreads(threadID)
+	err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		var (
+			previousRepoID api.RepoID
+			previousNumber int32
+		)
+		err := tx.QueryRowContext(ctx, `SELECT tr.repo_id, tr.number FROM discussion_threads t
+			JOIN discussion_threads_target_repo tr ON tr.id = t.target_repo_id
+			WHERE t.id=$1 AND t.deleted_at IS NULL FOR UPDATE OF t`, threadID).Scan(&previousRepoID, &previousNumber)
+		if err == sql.ErrNoRows {
+			return &ErrThreadNotFound{ThreadID: threadID}
+		}
+		if err != nil {
+			return err
+		}
+		if previousRepoID == repoID {
+			return errors.New("the thread is already in the repository")
+		}
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, threadID)
+		if err != nil {
+			return err
+		}
+
+		// The thread gets a new number in the repository it is transferred to.
+		// Its previous number is kept so that GetByNumber still finds it.
+		if _, err := tx.ExecContext(ctx, "INSERT INTO discussion_thread_previous_numbers(repo_id, number, thread_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", previousRepoID, previousNumber, threadID); err != nil {
+			return err
+		}
+		number, err := nextDiscussionThreadNumber(ctx, tx, repoID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads_target_repo SET repo_id=$1, number=$2, branch=NULL, revision=NULL WHERE thread_id=$3", repoID, number, threadID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE discussion_threads_labels tl SET label_id=l2.id
+			FROM discussion_labels l, discussion_labels l2
+			WHERE tl.thread_id=$1 AND l.id = tl.label_id AND l2.repo_id=$2 AND l2.name = l.name`, threadID, repoID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM discussion_threads_labels tl USING discussion_labels l
+			WHERE tl.thread_id=$1 AND l.id = tl.label_id AND l.repo_id != $2`, threadID, repoID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE discussion_threads SET
+			milestone_id=(CASE WHEN milestone_id IN (SELECT id FROM discussion_milestones WHERE repo_id IS NOT NULL) THEN NULL ELSE milestone_id END),
+			pinned_at=NULL,
+			updated_at=now()
+			WHERE id=$1`, threadID); err != nil {
+			return err
+		}
+		return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, "transfer", before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.get(ctx, threadID)
+}
+
+// MaxPinnedDiscussionThreads is the maximum number of threads that can be
+// pinned in a repository.
+const MaxPinnedDiscussionThreads = 3
+
+// ErrTooManyPinnedThreads is returned by DiscussionThreads.SetPinned when the
+// thread's repository already has MaxPinnedDiscussionThreads pinned threads.
+var ErrTooManyPinnedThreads = fmt.Errorf("a repository can have at most %d pinned threads", MaxPinnedDiscussionThreads)
+
+// discussionPinsLockNamespace is the Postgres advisory lock namespace that
+// SetPinned locks repositories in. Advisory lock IDs are global within the
+// database, so the namespace separates its locks from other ones.
+var discussionPinsLockNamespace = int32(fnv1.HashString32("discussion_pins"))
+
+// SetPinned pins the thread to the top of its repository's thread list, or
+// unpins it. Pinning an already pinned thread (or unpinning a thread that is
+// not pinned) is a no-op.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (t *discussionThreads) SetPinned(ctx context.Context, threadID int64, pinned bool) (*types.DiscussionThread, error) {
+	if Mocks.DiscussionThreads.SetPinned != nil {
+		return Mocks.DiscussionThreads.SetPinned(ctx, threadID, pinned)
+	}
+	defer invalidateDiscussionThreads(threadID)
+	err := dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		var (
+			repoID   api.RepoID
+			pinnedAt *time.Time
+		)
+		err := tx.QueryRowContext(ctx, `SELECT tr.repo_id, t.pinned_at FROM discussion_threads t
+			JOIN discussion_threads_target_repo tr ON tr.id = t.target_repo_id
+			WHERE t.id=$1 AND t.deleted_at IS NULL FOR UPDATE OF t`, threadID).Scan(&repoID, &pinnedAt)
+		if err == sql.ErrNoRows {
+			return &ErrThreadNotFound{ThreadID: threadID}
+		}
+		if err != nil {
+			return err
+		}
+		if (pinnedAt != nil) == pinned {
+			return nil
+		}
+		before, err := discussionAuditSnapshot(ctx, tx, types.DiscussionAuditLogObjectThread, threadID)
+		if err != nil {
+			return err
+		}
+		if !pinned {
+			if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET pinned_at=NULL, updated_at=now() WHERE id=$1", threadID); err != nil {
+				return err
+			}
+			return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, "unpin", before)
+		}
+
+		// Serialize the pins in the repository so that concurrent pins cannot
+		// exceed the maximum. An advisory lock is used so that the repo row,
+		// which other services update, is not locked.
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1, $2)", discussionPinsLockNamespace, int32(repoID)); err != nil {
+			return err
+		}
+		var count int
+		if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM discussion_threads t
+			JOIN discussion_threads_target_repo tr ON tr.id = t.target_repo_id
+			WHERE tr.repo_id=$1 AND t.pinned_at IS NOT NULL AND t.deleted_at IS NULL`, repoID).Scan(&count); err != nil {
+			return err
+		}
+		if count >= MaxPinnedDiscussionThreads {
+			return ErrTooManyPinnedThreads
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE discussion_threads SET pinned_at=now(), updated_at=now() WHERE id=$1", threadID); err != nil {
+			return err
 		}
+		return recordDiscussionAudit(ctx, tx, types.DiscussionAuditLogObjectThread, threadID, "pin", before)
+	})
+	if err != nil {
+		return nil, err
 	}
-	if opts.Delete {
-		return nil, nil
-	}
-	return t.Get(ctx, threadID)
+	return t.get(ctx, threadID)
 }
 
 type DiscussionThreadsListOptions struct {
@@ -219,10 +1359,18 @@ type DiscussionThreadsListOptions struct {
 	*LimitOffset
 
 	// TitleQuery, when non-nil, specifies that only threads whose title
-	// matches this string should be returned.
+	// matches this string should be returned. The threads are ranked by how
+	// well their titles match, which is computed after the query, so only
+	// the first maxTitleQueryCandidates threads (in the list order) that may
+	// match are ranked; the others are never returned (or counted).
 	TitleQuery    *string
 	NotTitleQuery *string
 
+	// TextQuery, when non-nil, specifies that only threads whose title or
+	// first comment matches this Postgres full-text search query (in
+	// plainto_tsquery syntax) should be returned.
+	TextQuery *string
+
 	// ThreadIDs, when len() > 0, specifies that only the thread with one of
 	// these IDs should be returned. See also DiscussionThreads.Get.
 	ThreadIDs    []int64
@@ -238,6 +1386,11 @@ type DiscussionThreadsListOptions struct {
 	TargetRepoID    *api.RepoID
 	NotTargetRepoID *api.RepoID
 
+	// TargetRepoNumber, when non-nil, specifies that only threads with this
+	// number in their target repository should be returned. It is typically
+	// used together with TargetRepoID.
+	TargetRepoNumber *int32
+
 	// TargetRepoPath, when non-nil, specifies that only threads that have a repo target
 	// and this path should be returned.
 	TargetRepoPath    *string
@@ -248,13 +1401,229 @@ type DiscussionThreadsListOptions struct {
 	CreatedBefore *time.Time
 	CreatedAfter  *time.Time
 
-	// Whether or not to return results in ascending (oldest first) order. When
-	// false, descending (latest first) order is used.
+	// OrderBy is the column to order threads by, with ties broken by ID. When
+	// empty, threads are ordered by the time they were last updated.
+	OrderBy DiscussionThreadsListColumn
+
+	// Whether or not to return results in ascending (e.g. least recently
+	// updated first) order. When false, descending (e.g. most recently updated
+	// first) order is used.
 	AscendingOrder bool
 
-	// Reported, when true, specifies that only threads with at least one
-	// reported comment should be returned.
+	// PinnedFirst, when true, specifies that pinned threads are returned
+	// before all other threads (in either order).
+	PinnedFirst bool
+
+	// After, when non-nil, specifies that only threads that come after this
+	// cursor in the result order should be returned. It is used for keyset
+	// pagination.
+	After *DiscussionThreadsCursor
+
+	// Reported, when true, specifies that only threads that were reported, or
+	// that have at least one reported comment, should be returned.
 	Reported bool
+
+	// Archived, when non-nil, specifies whether only archived (true) or only
+	// unarchived (false) threads should be returned.
+	Archived *bool
+
+	// Closed, when non-nil, specifies whether only closed (true) or only
+	// unclosed (false) threads should be returned. Open threads are neither
+	// closed nor archived.
+	Closed *bool
+
+	// Stale, when non-nil, specifies whether only stale (true) or only
+	// non-stale (false) threads should be returned. Archived threads are never
+	// stale.
+	Stale *bool
+
+	// StaleBefore, when non-nil, specifies that only threads that were marked
+	// as stale before this time should be returned.
+	StaleBefore *time.Time
+
+	// InactiveBefore, when non-nil, specifies that only threads that have not
+	// been updated or commented on since this time should be returned.
+	InactiveBefore *time.Time
+
+	// RepoDeleted, when non-nil, specifies whether only threads whose target
+	// repository was deleted (true) or only other threads (false) should be
+	// returned.
+	RepoDeleted *bool
+
+	// NotTargetRepoIDs, when len() > 0, specifies that threads in these repos
+	// should not be returned.
+	NotTargetRepoIDs []api.RepoID
+
+	// LabelNames, when len() > 0, specifies that only threads with a label
+	// named one of these should be returned.
+	LabelNames    []string
+	NotLabelNames []string
+
+	// MilestoneID, when non-zero, specifies that only threads in this
+	// milestone should be returned.
+	MilestoneID int64
+
+	// DuplicateOfThreadID, when non-zero, specifies that only threads marked
+	// as duplicates of this thread should be returned.
+	DuplicateOfThreadID int64
+
+	// ReferencedByThreadID, when non-zero, specifies that only threads
+	// referenced by a (non-deleted) comment in this thread should be
+	// returned. ReferencesThreadID is the inverse: only threads with a comment
+	// that references this thread are returned.
+	ReferencedByThreadID int64
+	ReferencesThreadID   int64
+
+	// BlockedByThreadID, when non-zero, specifies that only threads blocked by
+	// this thread should be returned. BlocksThreadID is the inverse: only
+	// threads that block this thread are returned.
+	BlockedByThreadID int64
+	BlocksThreadID    int64
+
+	// AssigneeUserID, when non-zero, specifies that only threads assigned to
+	// this user should be returned.
+	AssigneeUserID int32
+
+	// MentionedUserID, when non-zero, specifies that only threads with a
+	// (non-deleted) comment that @mentions this user should be returned.
+	MentionedUserID int32
+
+	// InvolvedUserID, when non-zero, specifies that only threads that this
+	// user authored, is assigned to, or is mentioned in should be returned.
+	InvolvedUserID int32
+
+	// OrgID, when non-zero, specifies that only threads that @mention this
+	// organization, or that a member of it authored or is assigned to, should
+	// be returned.
+	OrgID int32
+}
+
+// DiscussionThreadsListColumn is a column by which threads can be ordered.
+// These correspond to columns in the database, each of which has an index on
+// (column, id).
+type DiscussionThreadsListColumn string
+
+const (
+	DiscussionThreadsListUpdatedAt    DiscussionThreadsListColumn = "updated_at"
+	DiscussionThreadsListCreatedAt    DiscussionThreadsListColumn = "created_at"
+	DiscussionThreadsListCommentCount DiscussionThreadsListColumn = "comment_count"
+	DiscussionThreadsListTitle        DiscussionThreadsListColumn = "title"
+)
+
+// orderBy returns the column that threads are ordered by.
+func (opts *DiscussionThreadsListOptions) orderBy() (DiscussionThreadsListColumn, error) {
+	switch opts.OrderBy {
+	case "":
+		return DiscussionThreadsListUpdatedAt, nil
+	case DiscussionThreadsListUpdatedAt, DiscussionThreadsListCreatedAt, DiscussionThreadsListCommentCount, DiscussionThreadsListTitle:
+		return opts.OrderBy, nil
+	default:
+		return "", fmt.Errorf("invalid discussion threads order column %q", opts.OrderBy)
+	}
+}
+
+// DiscussionThreadsCursor identifies a thread's position in the stable
+// (column, id) order that DiscussionThreads.List returns threads in.
+type DiscussionThreadsCursor struct {
+	UpdatedAt time.Time
+	ID        int64
+
+	// CreatedAt, CommentCount and Title are the thread's values of the
+	// OrderBy column, when listing threads ordered by one of them.
+	CreatedAt    *time.Time `json:",omitempty"`
+	CommentCount *int32     `json:",omitempty"`
+	Title        *string    `json:",omitempty"`
+
+	// Pinned is whether the thread is pinned. It is only used when listing
+	// with PinnedFirst.
+	Pinned bool `json:",omitempty"`
+
+	// Score is the thread's title match score, when listing threads matching
+	// a TitleQuery (which are ordered by best match, then by ID).
+	Score *int `json:",omitempty"`
+}
+
+// NewDiscussionThreadsCursor returns the cursor for listing the threads that
+// come after thread with opts.
+func NewDiscussionThreadsCursor(thread *types.DiscussionThread, opts *DiscussionThreadsListOptions) *DiscussionThreadsCursor {
+	c := &DiscussionThreadsCursor{
+		UpdatedAt: thread.UpdatedAt,
+		ID:        thread.ID,
+		Pinned:    opts.PinnedFirst && thread.PinnedAt != nil,
+	}
+	switch opts.OrderBy {
+	case DiscussionThreadsListCreatedAt:
+		c.CreatedAt = &thread.CreatedAt
+	case DiscussionThreadsListCommentCount:
+		c.CommentCount = &thread.CommentCount
+	case DiscussionThreadsListTitle:
+		c.Title = &thread.Title
+	}
+	if query, ok := opts.fuzzyTitleQuery(); ok {
+		score := stringscore.Score(thread.Title, query)
+		c.Score = &score
+	}
+	return c
+}
+
+// value returns the cursor's value of the column, or an error if the cursor
+// was not created for listing threads ordered by the column.
+func (c *DiscussionThreadsCursor) value(column DiscussionThreadsListColumn) (interface{}, error) {
+	var value interface{}
+	switch column {
+	case DiscussionThreadsListUpdatedAt:
+		return c.UpdatedAt, nil
+	case DiscussionThreadsListCreatedAt:
+		if c.CreatedAt != nil {
+			value = *c.CreatedAt
+		}
+	case DiscussionThreadsListCommentCount:
+		if c.CommentCount != nil {
+			value = *c.CommentCount
+		}
+	case DiscussionThreadsListTitle:
+		if c.Title != nil {
+			value = *c.Title
+		}
+	}
+	if value == nil {
+		return nil, fmt.Errorf("discussion threads cursor is not for threads ordered by %s", column)
+	}
+	return value, nil
+}
+
+// validate returns an error if the order or the cursor (if any) is invalid.
+func (opts *DiscussionThreadsListOptions) validate() error {
+	column, err := opts.orderBy()
+	if err != nil {
+		return err
+	}
+	if opts.After != nil {
+		if _, ok := opts.fuzzyTitleQuery(); ok {
+			if opts.After.Score == nil {
+				return errors.New("discussion threads cursor is not for threads matching a title query")
+			}
+			return nil
+		}
+		if _, err := opts.After.value(column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxTitleQueryCandidates is the maximum number of threads that List ranks by
+// how well their titles match a TitleQuery. It is a variable so that tests can
+// change it.
+var maxTitleQueryCandidates = 5000
+
+// fuzzyTitleQuery returns the TitleQuery and true if the threads are filtered
+// and ranked by how well their titles match it.
+func (opts *DiscussionThreadsListOptions) fuzzyTitleQuery() (string, bool) {
+	if opts.TitleQuery == nil || strings.TrimSpace(*opts.TitleQuery) == "" {
+		return "", false
+	}
+	return *opts.TitleQuery, true
 }
 
 // SetFromQuery sets the options based on the search query string.
@@ -296,6 +1665,43 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 		return
 	}
 
+	findAssignedThreadIDs := func(value string) []int64 {
+		userIDs := userIDsList(value)
+		if len(userIDs) == 0 {
+			return nil
+		}
+		threadIDs, err := DiscussionThreadAssignees.ListThreadIDs(ctx, userIDs)
+		if err != nil {
+			return nil
+		}
+		return threadIDs
+	}
+
+	// restrictThreadIDs restricts the threads to those with the given IDs (in
+	// addition to any previous restriction, so that "involves:a assignee:b"
+	// matches the threads that both qualifiers match). If no thread matches,
+	// the ID -1 (which no thread has) is used, so that no thread is matched
+	// even if a later qualifier matches threads.
+	restrictThreadIDs := func(threadIDs []int64) {
+		if len(opts.ThreadIDs) > 0 {
+			allowed := make(map[int64]bool, len(opts.ThreadIDs))
+			for _, id := range opts.ThreadIDs {
+				allowed[id] = true
+			}
+			var intersection []int64
+			for _, id := range threadIDs {
+				if allowed[id] {
+					intersection = append(intersection, id)
+				}
+			}
+			threadIDs = intersection
+		}
+		if len(threadIDs) == 0 {
+			threadIDs = []int64{-1}
+		}
+		opts.ThreadIDs = threadIDs
+	}
+
 	parseTimeOrDuration := func(value string) *time.Time {
 		// Try parsing as RFC3339 / ISO 8601 first.
 		t, err := time.Parse(time.RFC3339, value)
@@ -312,6 +1718,38 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 		return &t
 	}
 
+	// setState sets the options for the "is:" (or, if negated, "-is:")
+	// qualifier, and reports whether the value is a state. A thread is open if
+	// it is neither closed nor archived.
+	setState := func(value string, negated bool) bool {
+		yes, no := true, false
+		switch strings.ToLower(value) {
+		case "open":
+			if negated {
+				// Archived threads are excluded by default, so "-is:open"
+				// matches the closed threads.
+				opts.Closed = &yes
+			} else {
+				opts.Archived, opts.Closed = &no, &no
+			}
+		case "closed":
+			if negated {
+				opts.Closed = &no
+			} else {
+				opts.Archived, opts.Closed = &no, &yes
+			}
+		case "archived":
+			if negated {
+				opts.Archived = &no
+			} else {
+				opts.Archived = &yes
+			}
+		default:
+			return false
+		}
+		return true
+	}
+
 	var reported bool
 	operators := map[string]func(value string){
 		// syntax: `title:"some title"` or "title:sometitle"
@@ -325,10 +1763,7 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 
 		// syntax: "involves:slimsag" or "involves:@slimsag" or "involves:slimsag @jack"
 		"involves": func(value string) {
-			opts.ThreadIDs = append(opts.ThreadIDs, findInvolvedThreadIDs(value)...)
-			if len(opts.ThreadIDs) == 0 {
-				opts.ThreadIDs = []int64{-1}
-			}
+			restrictThreadIDs(findInvolvedThreadIDs(value))
 		},
 		"-involves": func(value string) {
 			opts.NotThreadIDs = append(opts.NotThreadIDs, findInvolvedThreadIDs(value)...)
@@ -345,6 +1780,14 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 			opts.NotAuthorUserIDs = userIDsList(value)
 		},
 
+		// syntax: "assignee:slimsag" or "assignee:@slimsag" or `assignee:"slimsag @jack"`
+		"assignee": func(value string) {
+			restrictThreadIDs(findAssignedThreadIDs(value))
+		},
+		"-assignee": func(value string) {
+			opts.NotThreadIDs = append(opts.NotThreadIDs, findAssignedThreadIDs(value)...)
+		},
+
 		// syntax: "repo:github.com/gorilla/mux" or "repo:some/repo"
 		// TODO(slimsag:discussions): support list syntax here.
 		"repo": func(value string) {
@@ -364,6 +1807,28 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 			opts.NotTargetRepoID = &repo.ID
 		},
 
+		// syntax: "label:bug" or `label:"help wanted"`
+		"label": func(value string) {
+			opts.LabelNames = append(opts.LabelNames, value)
+		},
+		"-label": func(value string) {
+			opts.NotLabelNames = append(opts.NotLabelNames, value)
+		},
+
+		// syntax: "is:open", "is:closed", "is:archived", or "is:stale"
+		"is": func(value string) {
+			if !setState(value, false) && strings.EqualFold(value, "stale") {
+				stale := true
+				opts.Stale = &stale
+			}
+		},
+		"-is": func(value string) {
+			if !setState(value, true) && strings.EqualFold(value, "stale") {
+				notStale := false
+				opts.Stale = &notStale
+			}
+		},
+
 		// syntax: "file:dir/file.go" or "file:something.go"
 		// TODO(slimsag:discussions): support list syntax here.
 		"file": func(value string) {
@@ -402,65 +1867,54 @@ func (opts *DiscussionThreadsListOptions) SetFromQuery(ctx context.Context, quer
 		// the remaining search query.
 		remaining = strings.Join([]string{remaining, operation + ":" + value}, " ")
 	}
-	opts.TitleQuery = &remaining
+	if strings.TrimSpace(remaining) != "" {
+		opts.TextQuery = &remaining
+	}
 
 	if reported {
-		// Searching only for reported threads.
-		if len(opts.ThreadIDs) > 0 {
-			// Already have a list of threads we're interested in, e.g. from `involves:slimsag`.
-			// Narrow the list down.
-			var newThreads []int64
-			for _, threadID := range opts.ThreadIDs {
-				reportedComments, err := DiscussionComments.Count(ctx, &DiscussionCommentsListOptions{
-					ThreadID: &threadID,
-					Reported: true,
-				})
-				if err != nil {
-					continue
-				}
-				if reportedComments == 0 {
-					continue
-				}
-				newThreads = append(newThreads, threadID)
-			}
-			opts.ThreadIDs = newThreads
-			if len(opts.ThreadIDs) == 0 {
-				opts.ThreadIDs = []int64{-1}
-			}
-		} else {
-			// We don't have an existing list of threads we're interested in.
-			// Compile it now.
-			comments, _ := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{
-				Reported: true,
-			})
-			set := map[int64]struct{}{}
-			for _, comment := range comments {
-				if _, ok := set[comment.ThreadID]; ok {
-					continue
-				}
-				set[comment.ThreadID] = struct{}{}
-				opts.ThreadIDs = append(opts.ThreadIDs, comment.ThreadID)
-			}
-			if len(opts.ThreadIDs) == 0 {
-				opts.ThreadIDs = []int64{-1}
-			}
-		}
+		// Searching only for reported threads (or threads with reported
+		// comments).
+		opts.Reported = true
 	}
 }
 
-func (t *discussionThreads) List(ctx context.Context, opts *DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+func (t *discussionThreads) List(ctx context.Context, opts *DiscussionThreadsListOptions) (_ []*types.DiscussionThread, err error) {
 	if Mocks.DiscussionThreads.List != nil {
 		return Mocks.DiscussionThreads.List(ctx, opts)
 	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.List", &err)
+	defer done()
+
 	if opts == nil {
 		return nil, errors.New("options must not be nil")
 	}
-	conds := t.getListSQL(opts)
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	conds, err := t.authzConds(ctx, t.getListSQL(opts))
+	if err != nil {
+		return nil, err
+	}
 	order := "DESC"
 	if opts.AscendingOrder {
 		order = "ASC"
 	}
-	q := sqlf.Sprintf("WHERE %s ORDER BY id "+order+" %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+	column, _ := opts.orderBy()
+	orderBy := string(column) + " " + order + ", id " + order
+	if opts.PinnedFirst {
+		orderBy = "(pinned_at IS NOT NULL) DESC, " + orderBy
+	}
+	limitOffset := opts.LimitOffset.SQL()
+	if _, ok := opts.fuzzyTitleQuery(); ok {
+		// The threads are ranked by title match score, which is computed
+		// after the query, so the cursor and limit are applied afterward (the
+		// title conditions of the query exclude threads that cannot match).
+		// The candidates are bounded so that a query matching many threads
+		// does not load all of them.
+		limitOffset = (&LimitOffset{Limit: maxTitleQueryCandidates}).SQL()
+	}
+	q := sqlf.Sprintf("WHERE %s ORDER BY "+orderBy+" %s", sqlf.Join(conds, "AND"), limitOffset)
 
 	threads, err := t.getBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	if err != nil {
@@ -469,10 +1923,14 @@ func (t *discussionThreads) List(ctx context.Context, opts *DiscussionThreadsLis
 	return t.fuzzyFilterThreads(opts, threads), nil
 }
 
-func (t *discussionThreads) Count(ctx context.Context, opts *DiscussionThreadsListOptions) (int, error) {
+func (t *discussionThreads) Count(ctx context.Context, opts *DiscussionThreadsListOptions) (_ int, err error) {
 	if Mocks.DiscussionThreads.Count != nil {
 		return Mocks.DiscussionThreads.Count(ctx, opts)
 	}
+
+	ctx, done := observeDiscussionsQuery(ctx, "DiscussionThreads.Count", &err)
+	defer done()
+
 	if opts == nil {
 		return 0, errors.New("options must not be nil")
 	}
@@ -483,43 +1941,158 @@ func (t *discussionThreads) Count(ctx context.Context, opts *DiscussionThreadsLi
 		threads, err := t.List(ctx, opts)
 		return len(threads), err
 	}
-	conds := t.getListSQL(opts)
+	if err := opts.validate(); err != nil {
+		return 0, err
+	}
+	conds, err := t.authzConds(ctx, t.getListSQL(opts))
+	if err != nil {
+		return 0, err
+	}
 	q := sqlf.Sprintf("WHERE %s", sqlf.Join(conds, "AND"))
 	return t.getCountBySQL(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 }
 
+// ListParticipantUserIDs returns the IDs of the users who participate in the
+// thread: its author, the authors of its (non-deleted) comments, and its
+// assignees. Each user is returned once, in the order in which they began
+// participating.
+func (t *discussionThreads) ListParticipantUserIDs(ctx context.Context, threadID int64) ([]int32, error) {
+	if Mocks.DiscussionThreads.ListParticipantUserIDs != nil {
+		return Mocks.DiscussionThreads.ListParticipantUserIDs(ctx, threadID)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT user_id FROM (
+			SELECT author_user_id AS user_id, created_at FROM discussion_threads WHERE id=$1
+			UNION ALL
+			SELECT author_user_id, created_at FROM discussion_comments WHERE thread_id=$1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT user_id, created_at FROM discussion_threads_assignees WHERE thread_id=$1
+		) AS participants
+		GROUP BY user_id
+		ORDER BY MIN(created_at) ASC, user_id ASC`, threadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	userIDs := []int32{}
+	for rows.Next() {
+		var userID int32
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// fuzzyFilterThreads removes the threads whose titles do not match the
+// TitleQuery (if any), and orders the rest by best match, then by ID. It then
+// applies the cursor and limit, which the query did not (see List).
 func (t *discussionThreads) fuzzyFilterThreads(opts *DiscussionThreadsListOptions, threads []*types.DiscussionThread) []*types.DiscussionThread {
-	if opts.TitleQuery != nil && strings.TrimSpace(*opts.TitleQuery) != "" {
-		var (
-			scoresByThread  = make(map[*types.DiscussionThread]int, len(threads))
-			threadsToRemove []*types.DiscussionThread
-		)
-		for _, t := range threads {
-			score := stringscore.Score(t.Title, *opts.TitleQuery)
-			if score > 0 {
-				scoresByThread[t] = score
-			} else {
-				threadsToRemove = append(threadsToRemove, t)
-			}
+	query, ok := opts.fuzzyTitleQuery()
+	if !ok {
+		return threads
+	}
+	scores := make(map[int64]int, len(threads))
+	matches := threads[:0]
+	for _, t := range threads {
+		if score := stringscore.Score(t.Title, query); score > 0 {
+			scores[t.ID] = score
+			matches = append(matches, t)
 		}
-		for _, rm := range threadsToRemove {
-			for i, t := range threads {
-				if t == rm {
-					threads = append(threads[:i], threads[i+1:]...)
-					break
-				}
-			}
+	}
+	threads = matches
+
+	// TODO(slimsag:discussions): future: whether or not to sort based on
+	// best match here should be optional.
+	sort.Slice(threads, func(i, j int) bool {
+		if si, sj := scores[threads[i].ID], scores[threads[j].ID]; si != sj {
+			return si > sj
 		}
+		return threads[i].ID > threads[j].ID
+	})
 
-		// TODO(slimsag:discussions): future: whether or not to sort based on
-		// best match here should be optional.
-		sort.Slice(threads, func(i, j int) bool {
-			return scoresByThread[threads[i]] > scoresByThread[threads[j]]
+	if opts.After != nil && opts.After.Score != nil {
+		afterScore, afterID := *opts.After.Score, opts.After.ID
+		i := sort.Search(len(threads), func(i int) bool {
+			score := scores[threads[i].ID]
+			return score < afterScore || (score == afterScore && threads[i].ID < afterID)
 		})
+		threads = threads[i:]
+	}
+	if opts.LimitOffset != nil {
+		if opts.Offset >= len(threads) {
+			return []*types.DiscussionThread{}
+		}
+		threads = threads[opts.Offset:]
+		if opts.Limit < len(threads) {
+			threads = threads[:opts.Limit]
+		}
 	}
 	return threads
 }
 
+// authzConds returns conds with an additional condition that restricts the
+// threads to those without a repository target or whose target repository the
+// current user can read. The target repositories of all matching threads are
+// checked in a single batch.
+//
+// Threads whose target repository was deleted are restricted to site admins,
+// because the permissions of the repository can no longer be checked.
+//
+// 🚨 SECURITY: This enforces repository permissions for discussion threads.
+func (*discussionThreads) authzConds(ctx context.Context, conds []*sqlf.Query) ([]*sqlf.Query, error) {
+	if !isInternalActor(ctx) {
+		siteAdmin := false
+		if actor.FromContext(ctx).IsAuthenticated() {
+			currentUser, err := Users.GetByCurrentAuthUser(ctx)
+			if err != nil {
+				return nil, err
+			}
+			siteAdmin = currentUser.SiteAdmin
+		}
+		if !siteAdmin {
+			conds = append(conds, sqlf.Sprintf("repo_deleted_at IS NULL"))
+		}
+	}
+
+	q := sqlf.Sprintf(`SELECT DISTINCT (SELECT tr.repo_id FROM discussion_threads_target_repo tr WHERE tr.id=t.target_repo_id)
+		FROM discussion_threads t WHERE %s AND t.target_repo_id IS NOT NULL`, sqlf.Join(conds, "AND"))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var repoIDs []int32
+	for rows.Next() {
+		var repoID int32
+		if err := rows.Scan(&repoID); err != nil {
+			return nil, err
+		}
+		repoIDs = append(repoIDs, repoID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	readable := []int32{}
+	if len(repoIDs) > 0 {
+		repos, err := Repos.getReposBySQL(ctx, true, sqlf.Sprintf("id = ANY(%v)", pq.Array(repoIDs)))
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			readable = append(readable, int32(repo.ID))
+		}
+	}
+	return append(conds, sqlf.Sprintf(
+		"(target_repo_id IS NULL OR target_repo_id IN (SELECT id FROM discussion_threads_target_repo WHERE repo_id = ANY(%v)))",
+		pq.Array(readable),
+	)), nil
+}
+
 func (*discussionThreads) getListSQL(opts *DiscussionThreadsListOptions) (conds []*sqlf.Query) {
 	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
 	conds = append(conds, sqlf.Sprintf("deleted_at IS NULL"))
@@ -535,13 +2108,53 @@ func (*discussionThreads) getListSQL(opts *DiscussionThreadsListOptions) (conds
 		conds = append(conds, sqlf.Sprintf("id = ANY(%v)", pq.Array(opts.ThreadIDs)))
 	}
 	if len(opts.NotThreadIDs) > 0 {
-		conds = append(conds, sqlf.Sprintf("id != ANY(%v)", pq.Array(opts.NotThreadIDs)))
+		conds = append(conds, sqlf.Sprintf("NOT (id = ANY(%v))", pq.Array(opts.NotThreadIDs)))
+	}
+	if opts.MilestoneID != 0 {
+		conds = append(conds, sqlf.Sprintf("milestone_id=%v", opts.MilestoneID))
+	}
+	if opts.DuplicateOfThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("duplicate_of_thread_id=%v", opts.DuplicateOfThreadID))
+	}
+	if opts.ReferencedByThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT r.referenced_thread_id FROM discussion_thread_references r JOIN discussion_comments c ON c.id = r.comment_id WHERE r.thread_id=%v AND c.deleted_at IS NULL)", opts.ReferencedByThreadID))
+	}
+	if opts.BlockedByThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT d.thread_id FROM discussion_thread_dependencies d WHERE d.blocked_by_thread_id=%v)", opts.BlockedByThreadID))
+	}
+	if opts.BlocksThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT d.blocked_by_thread_id FROM discussion_thread_dependencies d WHERE d.thread_id=%v)", opts.BlocksThreadID))
+	}
+	if opts.AssigneeUserID != 0 {
+		conds = append(conds, threadsAssignedToSQL(sqlf.Sprintf("%v", opts.AssigneeUserID)))
+	}
+	if opts.MentionedUserID != 0 {
+		conds = append(conds, threadsMentioningSQL(sqlf.Sprintf("m.user_id=%v", opts.MentionedUserID)))
+	}
+	if opts.InvolvedUserID != 0 {
+		userID := sqlf.Sprintf("%v", opts.InvolvedUserID)
+		conds = append(conds, sqlf.Sprintf("(author_user_id=%s OR %s OR %s)",
+			userID,
+			threadsAssignedToSQL(userID),
+			threadsMentioningSQL(sqlf.Sprintf("m.user_id=%s", userID)),
+		))
+	}
+	if opts.OrgID != 0 {
+		members := sqlf.Sprintf("SELECT om.user_id FROM org_members om WHERE om.org_id=%v", opts.OrgID)
+		conds = append(conds, sqlf.Sprintf("(author_user_id IN (%s) OR %s OR %s)",
+			members,
+			threadsAssignedToSQL(members),
+			threadsMentioningSQL(sqlf.Sprintf("m.org_id=%v", opts.OrgID)),
+		))
+	}
+	if opts.ReferencesThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT r.thread_id FROM discussion_thread_references r JOIN discussion_comments c ON c.id = r.comment_id WHERE r.referenced_thread_id=%v AND c.deleted_at IS NULL)", opts.ReferencesThreadID))
 	}
 	if len(opts.AuthorUserIDs) > 0 {
 		conds = append(conds, sqlf.Sprintf("author_user_id = ANY(%v)", pq.Array(opts.AuthorUserIDs)))
 	}
 	if len(opts.NotAuthorUserIDs) > 0 {
-		conds = append(conds, sqlf.Sprintf("author_user_id != ANY(%v)", pq.Array(opts.NotAuthorUserIDs)))
+		conds = append(conds, sqlf.Sprintf("NOT (author_user_id = ANY(%v))", pq.Array(opts.NotAuthorUserIDs)))
 	}
 	if opts.CreatedBefore != nil {
 		conds = append(conds, sqlf.Sprintf("created_at < %v", *opts.CreatedBefore))
@@ -549,12 +2162,93 @@ func (*discussionThreads) getListSQL(opts *DiscussionThreadsListOptions) (conds
 	if opts.CreatedAfter != nil {
 		conds = append(conds, sqlf.Sprintf("created_at > %v", *opts.CreatedAfter))
 	}
+	if opts.TextQuery != nil && strings.TrimSpace(*opts.TextQuery) != "" {
+		// Match against the title and the first comment, which is the body of
+		// the thread.
+		conds = append(conds, sqlf.Sprintf(`(
+			to_tsvector('english', title) @@ plainto_tsquery('english', %v)
+			OR id IN (
+				SELECT c.thread_id FROM discussion_comments c
+				WHERE to_tsvector('english', c.contents) @@ plainto_tsquery('english', %v)
+				AND c.id = (SELECT min(c2.id) FROM discussion_comments c2 WHERE c2.thread_id = c.thread_id AND c2.deleted_at IS NULL)
+			)
+		)`, *opts.TextQuery, *opts.TextQuery))
+	}
+	if opts.Reported {
+		conds = append(conds, sqlf.Sprintf(`(array_length(reports,1) > 0 OR EXISTS (
+			SELECT 1 FROM discussion_comments c WHERE c.thread_id=t.id AND c.deleted_at IS NULL AND array_length(c.reports,1) > 0
+		))`))
+	}
+	if opts.Archived != nil {
+		if *opts.Archived {
+			conds = append(conds, sqlf.Sprintf("archived_at IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("archived_at IS NULL"))
+		}
+	}
+	if opts.Closed != nil {
+		if *opts.Closed {
+			conds = append(conds, sqlf.Sprintf("closed_at IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("closed_at IS NULL"))
+		}
+	}
+	if opts.Stale != nil {
+		if *opts.Stale {
+			conds = append(conds, sqlf.Sprintf("stale_at IS NOT NULL AND archived_at IS NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("(stale_at IS NULL OR archived_at IS NOT NULL)"))
+		}
+	}
+	if opts.StaleBefore != nil {
+		conds = append(conds, sqlf.Sprintf("stale_at < %v", *opts.StaleBefore))
+	}
+	if opts.RepoDeleted != nil {
+		if *opts.RepoDeleted {
+			conds = append(conds, sqlf.Sprintf("repo_deleted_at IS NOT NULL"))
+		} else {
+			conds = append(conds, sqlf.Sprintf("repo_deleted_at IS NULL"))
+		}
+	}
+	if opts.InactiveBefore != nil {
+		conds = append(conds, sqlf.Sprintf("updated_at < %v", *opts.InactiveBefore))
+		conds = append(conds, sqlf.Sprintf("NOT EXISTS (SELECT 1 FROM discussion_comments c WHERE c.thread_id = t.id AND c.created_at >= %v AND c.deleted_at IS NULL)", *opts.InactiveBefore))
+	}
+	if len(opts.NotTargetRepoIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id NOT IN (SELECT thread_id FROM discussion_threads_target_repo WHERE repo_id = ANY(%v))", pq.Array(opts.NotTargetRepoIDs)))
+	}
+	if len(opts.LabelNames) > 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT tl.thread_id FROM discussion_threads_labels tl JOIN discussion_labels l ON l.id = tl.label_id WHERE l.name = ANY(%v))", pq.Array(opts.LabelNames)))
+	}
+	if len(opts.NotLabelNames) > 0 {
+		conds = append(conds, sqlf.Sprintf("id NOT IN (SELECT tl.thread_id FROM discussion_threads_labels tl JOIN discussion_labels l ON l.id = tl.label_id WHERE l.name = ANY(%v))", pq.Array(opts.NotLabelNames)))
+	}
+	if _, fuzzy := opts.fuzzyTitleQuery(); opts.After != nil && !fuzzy {
+		// The order and cursor were checked by validate. The cursor of threads
+		// matching a title query is applied by fuzzyFilterThreads.
+		column, _ := opts.orderBy()
+		value, _ := opts.After.value(column)
+		op := "<"
+		if opts.AscendingOrder {
+			op = ">"
+		}
+		after := sqlf.Sprintf("("+string(column)+", id) "+op+" (%v, %v)", value, opts.After.ID)
+		if opts.PinnedFirst {
+			// Pinned threads come first, so the threads after a pinned thread
+			// are the rest of the pinned threads followed by the others.
+			after = sqlf.Sprintf("((pinned_at IS NOT NULL) < %v OR ((pinned_at IS NOT NULL) = %v AND %s))", opts.After.Pinned, opts.After.Pinned, after)
+		}
+		conds = append(conds, after)
+	}
 
-	if opts.TargetRepoID != nil || opts.TargetRepoPath != nil || opts.NotTargetRepoID != nil || opts.NotTargetRepoPath != nil {
+	if opts.TargetRepoID != nil || opts.TargetRepoNumber != nil || opts.TargetRepoPath != nil || opts.NotTargetRepoID != nil || opts.NotTargetRepoPath != nil {
 		targetRepoConds := []*sqlf.Query{}
 		if opts.TargetRepoID != nil {
 			targetRepoConds = append(targetRepoConds, sqlf.Sprintf("repo_id = %v", *opts.TargetRepoID))
 		}
+		if opts.TargetRepoNumber != nil {
+			targetRepoConds = append(targetRepoConds, sqlf.Sprintf("number = %v", *opts.TargetRepoNumber))
+		}
 		if opts.NotTargetRepoID != nil {
 			targetRepoConds = append(targetRepoConds, sqlf.Sprintf("repo_id != %v", *opts.NotTargetRepoID))
 		}
@@ -579,6 +2273,18 @@ func (*discussionThreads) getListSQL(opts *DiscussionThreadsListOptions) (conds
 	return conds
 }
 
+// threadsAssignedToSQL returns a condition matching threads assigned to any
+// of the users selected by userIDs (a user ID or an SQL subquery).
+func threadsAssignedToSQL(userIDs *sqlf.Query) *sqlf.Query {
+	return sqlf.Sprintf("id IN (SELECT a.thread_id FROM discussion_threads_assignees a WHERE a.user_id IN (%s))", userIDs)
+}
+
+// threadsMentioningSQL returns a condition matching threads with a
+// non-deleted comment that has a mention (m) matching the given condition.
+func threadsMentioningSQL(mentionCond *sqlf.Query) *sqlf.Query {
+	return sqlf.Sprintf("id IN (SELECT c.thread_id FROM discussion_comment_mentions m JOIN discussion_comments c ON c.id = m.comment_id WHERE %s AND c.deleted_at IS NULL)", mentionCond)
+}
+
 func (*discussionThreads) getCountBySQL(ctx context.Context, query string, args ...interface{}) (int, error) {
 	var count int
 	rows := dbconn.Global.QueryRowContext(ctx, "SELECT count(id) FROM discussion_threads t "+query, args...)
@@ -590,15 +2296,21 @@ func (*discussionThreads) getCountBySQL(ctx context.Context, query string, args
 }
 
 // createTargetRepo handles the creation of a repo-based discussion thread target.
-func (t *discussionThreads) createTargetRepo(ctx context.Context, tr *types.DiscussionThreadTargetRepo, threadID int64) (*types.DiscussionThreadTargetRepo, error) {
+func (t *discussionThreads) createTargetRepo(ctx context.Context, dbh dbHandle, tr *types.DiscussionThreadTargetRepo, threadID int64) (*types.DiscussionThreadTargetRepo, error) {
 	var fields []*sqlf.Query
 	var values []*sqlf.Query
 	field := func(name string, arg interface{}) {
 		fields = append(fields, sqlf.Sprintf("%s", sqlf.Sprintf(name)))
 		values = append(values, sqlf.Sprintf("%v", arg))
 	}
+	var err error
+	tr.Number, err = nextDiscussionThreadNumber(ctx, dbh, tr.RepoID)
+	if err != nil {
+		return nil, err
+	}
 	field("thread_id", threadID)
 	field("repo_id", tr.RepoID)
+	field("number", tr.Number)
 	if tr.Path != nil {
 		field("path", *tr.Path)
 	}
@@ -623,13 +2335,27 @@ func (t *discussionThreads) createTargetRepo(ctx context.Context, tr *types.Disc
 	// fmt.Println(q.Query(sqlf.PostgresBindVar))
 	// fmt.Println(q.Args())
 
-	err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&tr.ID)
+	err = dbh.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&tr.ID)
 	if err != nil {
 		return nil, err
 	}
 	return tr, err
 }
 
+// nextDiscussionThreadNumber returns the next thread number in the repository.
+// The row lock taken on the repository's counter serializes concurrent
+// callers, so numbers are assigned in increasing order and never reused.
+func nextDiscussionThreadNumber(ctx context.Context, dbh dbHandle, repoID api.RepoID) (int32, error) {
+	var number int32
+	err := dbh.QueryRowContext(ctx, `INSERT INTO discussion_repo_thread_numbers(repo_id, last_number) VALUES ($1, 1)
+		ON CONFLICT (repo_id) DO UPDATE SET last_number=discussion_repo_thread_numbers.last_number+1
+		RETURNING last_number`, repoID).Scan(&number)
+	if err != nil {
+		return 0, errors.Wrap(err, "next thread number")
+	}
+	return number, nil
+}
+
 // getBySQL returns threads matching the SQL query, if any exist.
 func (t *discussionThreads) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.DiscussionThread, error) {
 	rows, err := dbconn.Global.QueryContext(ctx, `
@@ -640,13 +2366,25 @@ func (t *discussionThreads) getBySQL(ctx context.Context, query string, args ...
 			t.target_repo_id,
 			t.created_at,
 			t.archived_at,
-			t.updated_at
+			t.locked_at,
+			t.stale_at,
+			t.pinned_at,
+			t.reports,
+			t.duplicate_of_thread_id,
+			t.milestone_id,
+			t.comment_count,
+			t.reaction_count,
+			t.idempotency_key,
+			t.updated_at,
+			t.repo_deleted_at,
+			t.closed_at
 		FROM discussion_threads t `+query, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	threads := []*types.DiscussionThread{}
+	targetRepoIDs := map[*types.DiscussionThread]int64{}
 	defer rows.Close()
 	for rows.Next() {
 		var (
@@ -660,33 +2398,57 @@ func (t *discussionThreads) getBySQL(ctx context.Context, query string, args ...
 			&targetRepoID,
 			&thread.CreatedAt,
 			&thread.ArchivedAt,
+			&thread.LockedAt,
+			&thread.StaleAt,
+			&thread.PinnedAt,
+			pq.Array(&thread.Reports),
+			&thread.DuplicateOfThreadID,
+			&thread.MilestoneID,
+			&thread.CommentCount,
+			&thread.ReactionCount,
+			&thread.IdempotencyKey,
 			&thread.UpdatedAt,
+			&thread.RepoDeletedAt,
+			&thread.ClosedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 		if targetRepoID != nil {
-			thread.TargetRepo, err = t.getTargetRepo(ctx, *targetRepoID)
-			if err != nil {
-				return nil, errors.Wrap(err, "getTargetRepo")
-			}
+			targetRepoIDs[&thread] = *targetRepoID
 		}
 		threads = append(threads, &thread)
 	}
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+
+	// Get the target repos of all threads with a single query, instead of one
+	// query per thread.
+	if len(targetRepoIDs) > 0 {
+		ids := make([]int64, 0, len(targetRepoIDs))
+		for _, id := range targetRepoIDs {
+			ids = append(ids, id)
+		}
+		targetRepos, err := t.getTargetRepos(ctx, ids)
+		if err != nil {
+			return nil, errors.Wrap(err, "getTargetRepos")
+		}
+		for thread, id := range targetRepoIDs {
+			thread.TargetRepo = targetRepos[id]
+		}
+	}
 	return threads, nil
 }
 
-func (t *discussionThreads) getTargetRepo(ctx context.Context, targetRepoID int64) (*types.DiscussionThreadTargetRepo, error) {
-	tr := &types.DiscussionThreadTargetRepo{}
-	var linesBefore, lines, linesAfter *string
-	err := dbconn.Global.QueryRowContext(ctx, `
+// getTargetRepos returns the target repos with the given IDs, keyed by ID.
+func (t *discussionThreads) getTargetRepos(ctx context.Context, targetRepoIDs []int64) (map[int64]*types.DiscussionThreadTargetRepo, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, `
 		SELECT
 			t.id,
 			t.thread_id,
 			t.repo_id,
+			t.number,
 			t.path,
 			t.branch,
 			t.revision,
@@ -697,38 +2459,59 @@ func (t *discussionThreads) getTargetRepo(ctx context.Context, targetRepoID int6
 			t.lines_before,
 			t.lines,
 			t.lines_after
-		FROM discussion_threads_target_repo t WHERE id=$1
-	`, targetRepoID).Scan(
-		&tr.ID,
-		&tr.ThreadID,
-		&tr.RepoID,
-		&tr.Path,
-		&tr.Branch,
-		&tr.Revision,
-		&tr.StartLine,
-		&tr.EndLine,
-		&tr.StartCharacter,
-		&tr.EndCharacter,
-		&linesBefore,
-		&lines,
-		&linesAfter,
-	)
+		FROM discussion_threads_target_repo t WHERE id = ANY($1)
+	`, pq.Array(targetRepoIDs))
 	if err != nil {
 		return nil, err
 	}
-	if linesBefore != nil {
-		linesBeforeSplit := strings.Split(*linesBefore, "\n")
-		tr.LinesBefore = &linesBeforeSplit
+	defer rows.Close()
+
+	targetRepos := make(map[int64]*types.DiscussionThreadTargetRepo, len(targetRepoIDs))
+	for rows.Next() {
+		tr := &types.DiscussionThreadTargetRepo{}
+		var linesBefore, lines, linesAfter *string
+		err := rows.Scan(
+			&tr.ID,
+			&tr.ThreadID,
+			&tr.RepoID,
+			&tr.Number,
+			&tr.Path,
+			&tr.Branch,
+			&tr.Revision,
+			&tr.StartLine,
+			&tr.EndLine,
+			&tr.StartCharacter,
+			&tr.EndCharacter,
+			&linesBefore,
+			&lines,
+			&linesAfter,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if linesBefore != nil {
+			linesBeforeSplit := strings.Split(*linesBefore, "\n")
+			tr.LinesBefore = &linesBeforeSplit
+		}
+		if lines != nil {
+			linesSplit := strings.Split(*lines, "\n")
+			tr.Lines = &linesSplit
+		}
+		if linesAfter != nil {
+			linesAfterSplit := strings.Split(*linesAfter, "\n")
+			tr.LinesAfter = &linesAfterSplit
+		}
+		targetRepos[tr.ID] = tr
 	}
-	if lines != nil {
-		linesSplit := strings.Split(*lines, "\n")
-		tr.Lines = &linesSplit
+	if err = rows.Err(); err != nil {
+		return nil, err
 	}
-	if linesAfter != nil {
-		linesAfterSplit := strings.Split(*linesAfter, "\n")
-		tr.LinesAfter = &linesAfterSplit
+	for _, id := range targetRepoIDs {
+		if targetRepos[id] == nil {
+			return nil, sql.ErrNoRows
+		}
 	}
-	return tr, nil
+	return targetRepos, nil
 }
 
 // extraFuzzy turns a string like "cat" into "%c%a%t%". It can be used with a
@@ -5,14 +5,27 @@ import (
 	"testing"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
 type MockDiscussionThreads struct {
-	Get    func(int64) (*types.DiscussionThread, error)
-	Create func(ctx context.Context, newThread *types.DiscussionThread) (*types.DiscussionThread, error)
-	Update func(ctx context.Context, threadID int64, opts *DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error)
-	List   func(ctx context.Context, opt *DiscussionThreadsListOptions) ([]*types.DiscussionThread, error)
-	Count  func(ctx context.Context, opt *DiscussionThreadsListOptions) (int, error)
+	Get             func(int64) (*types.DiscussionThread, error)
+	Create          func(ctx context.Context, newThread *types.DiscussionThread) (*types.DiscussionThread, error)
+	Update          func(ctx context.Context, threadID int64, opts *DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error)
+	UpdateAndRecord func(ctx context.Context, threadID int64, actorUserID int32, opts *DiscussionThreadsUpdateOptions) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error)
+	UpdateMany      func(ctx context.Context, threadIDs []int64, actorUserID int32, opts *DiscussionThreadsUpdateOptions) ([]int64, []*types.DiscussionThreadEvent, error)
+	Restore         func(ctx context.Context, threadID int64, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThread, error)
+	SetClosed       func(ctx context.Context, threadID int64, closed bool, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThread, error)
+	Transfer        func(ctx context.Context, threadID int64, repoID api.RepoID) (*types.DiscussionThread, error)
+	SetPinned       func(ctx context.Context, threadID int64, pinned bool) (*types.DiscussionThread, error)
+	List            func(ctx context.Context, opt *DiscussionThreadsListOptions) ([]*types.DiscussionThread, error)
+	Count           func(ctx context.Context, opt *DiscussionThreadsListOptions) (int, error)
+
+	CreateWithComment      func(ctx context.Context, newThread *types.DiscussionThread, newComment *types.DiscussionComment, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThread, error)
+	ApplyActions           func(ctx context.Context, threadID int64, actorUserID int32, actions []*DiscussionThreadAction) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error)
+	GetByNumber            func(repoID api.RepoID, number int32) (*types.DiscussionThread, error)
+	GetByIdempotencyKey    func(authorUserID int32, key string) (*types.DiscussionThread, error)
+	ListParticipantUserIDs func(ctx context.Context, threadID int64) ([]int32, error)
 }
 
 func (s *MockDiscussionThreads) MockCreate_Return(t *testing.T, returns *types.DiscussionThread, returnsErr error) (called *bool, calledWith *types.DiscussionThread) {
@@ -3,12 +3,18 @@ package db
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 )
 
 // TODO(slimsag:discussions): future: test that DiscussionThreadsListOptions.AuthorUserID works
@@ -130,6 +136,124 @@ func TestDiscussionThreads_Update(t *testing.T) {
 	if gotThread.ArchivedAt == nil {
 		t.Fatal("expected thread to be archived")
 	}
+
+	// Lock and unlock the thread.
+	gotThread, err = DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Lock: boolPtr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotThread.LockedAt == nil {
+		t.Fatal("expected thread to be locked")
+	}
+	gotThread, err = DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Lock: boolPtr(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotThread.LockedAt != nil {
+		t.Fatal("expected thread to be unlocked")
+	}
+}
+
+func TestDiscussionThreads_UpdateExpectedUpdatedAt(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first update with the loaded updatedAt succeeds, and the second one
+	// (e.g. by another user who loaded the thread at the same time) fails.
+	updated, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Title: strPtr("x"), ExpectedUpdatedAt: &thread.UpdatedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Title: strPtr("y"), ExpectedUpdatedAt: &thread.UpdatedAt}); err != ErrConcurrentUpdate {
+		t.Errorf("got error %v, want %v", err, ErrConcurrentUpdate)
+	}
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Title: strPtr("y"), ExpectedUpdatedAt: &updated.UpdatedAt}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.Update(ctx, thread.ID+1, &DiscussionThreadsUpdateOptions{Title: strPtr("y"), ExpectedUpdatedAt: &updated.UpdatedAt}); err == nil || err == ErrConcurrentUpdate {
+		t.Errorf("got error %v, want thread not found", err)
+	}
+
+	// A rejected update does not change the thread, so it can still be
+	// updated with the time it was last loaded at.
+	current, err := DiscussionThreads.Get(ctx, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{DuplicateOfThreadID: &thread.ID, ExpectedUpdatedAt: &current.UpdatedAt}); err == nil {
+		t.Error("expected error marking a thread as a duplicate of itself")
+	}
+	updated, err = DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Contents: strPtr("new contents"), EditorUserID: user.ID, ExpectedUpdatedAt: &current.UpdatedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Contents: strPtr("other contents"), ExpectedUpdatedAt: &current.UpdatedAt}); err != ErrConcurrentUpdate {
+		t.Errorf("got error %v, want %v", err, ErrConcurrentUpdate)
+	}
+	comments, err := DiscussionComments.List(ctx, &DiscussionCommentsListOptions{ThreadID: &thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) == 0 || comments[0].Contents != "new contents" {
+		t.Errorf("got comments %+v, want the first comment to have the new contents", comments)
+	}
+
+	if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{Contents: strPtr("b"), ExpectedUpdatedAt: &comment.UpdatedAt}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{Contents: strPtr("c"), ExpectedUpdatedAt: &comment.UpdatedAt}); err != ErrConcurrentUpdate {
+		t.Errorf("got error %v, want %v", err, ErrConcurrentUpdate)
+	}
+}
+
+func TestDiscussionThreads_UpdateAndRecord(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	updated, events, err := DiscussionThreads.UpdateAndRecord(ctx, thread.ID, user.ID, &DiscussionThreadsUpdateOptions{Title: strPtr("x"), Archive: boolPtr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "x" {
+		t.Errorf("got title %q, want %q", updated.Title, "x")
+	}
+	if len(events) != 2 || events[0].Kind != types.DiscussionThreadEventTitleEdited || events[1].Kind != types.DiscussionThreadEventArchived {
+		t.Fatalf("got events %+v, want TITLE_EDITED and ARCHIVED events", events)
+	}
+	if data := events[0].Data; data.PreviousTitle == nil || *data.PreviousTitle != thread.Title || data.Title == nil || *data.Title != "x" {
+		t.Errorf("got event data %+v, want the previous and new titles", data)
+	}
+
+	// A failed update records nothing.
+	stale := thread.UpdatedAt.Add(-time.Hour)
+	if _, _, err := DiscussionThreads.UpdateAndRecord(ctx, thread.ID, user.ID, &DiscussionThreadsUpdateOptions{Archive: boolPtr(false), ExpectedUpdatedAt: &stale}); err != ErrConcurrentUpdate {
+		t.Errorf("got error %v, want %v", err, ErrConcurrentUpdate)
+	}
+	deleted, events, err := DiscussionThreads.UpdateAndRecord(ctx, thread.ID, user.ID, &DiscussionThreadsUpdateOptions{Delete: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != nil || len(events) != 1 || events[0].Kind != types.DiscussionThreadEventDeleted {
+		t.Errorf("got thread %+v and events %+v, want nil thread and a DELETED event", deleted, events)
+	}
+	if count, err := DiscussionThreadEvents.Count(ctx, &DiscussionThreadEventsListOptions{ThreadID: thread.ID}); err != nil {
+		t.Fatal(err)
+	} else if count != 3 {
+		t.Errorf("got %d events, want 3", count)
+	}
 }
 
 func TestDiscussionThreads_Count(t *testing.T) {
@@ -262,78 +386,1433 @@ func TestDiscussionThreads_List(t *testing.T) {
 	}
 }
 
-func TestDiscussionThreads_Delete(t *testing.T) {
+func TestDiscussionThreads_ListNegated(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
 	dbtesting.SetupGlobalTestDB(t)
 	ctx := context.Background()
 
-	user, err := Users.Create(ctx, NewUser{
-		Email:                 "a@a.com",
-		Username:              "u",
+	user, repo, thread1 := createTestDiscussionThread(ctx, t)
+	user2, err := Users.Create(ctx, NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(authorUserID int32) *types.DiscussionThread {
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: authorUserID,
+			Title:        "t",
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return thread
+	}
+	thread2 := create(user.ID)
+	thread3 := create(user2.ID)
+
+	listIDs := func(opts *DiscussionThreadsListOptions) []int64 {
+		threads, err := DiscussionThreads.List(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []int64{}
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		return ids
+	}
+
+	// Excluding two or more threads (or authors) excludes all of them.
+	if got, want := listIDs(&DiscussionThreadsListOptions{NotThreadIDs: []int64{thread1.ID, thread2.ID}}), []int64{thread3.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NotThreadIDs: got threads %v, want %v", got, want)
+	}
+	if got, want := listIDs(&DiscussionThreadsListOptions{NotAuthorUserIDs: []int32{user.ID, user2.ID + 1}}), []int64{thread3.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NotAuthorUserIDs: got threads %v, want %v", got, want)
+	}
+}
+
+func TestDiscussionThreads_ListAfter(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread1 := createTestDiscussionThread(ctx, t)
+	var want []int64
+	for _, title := range []string{"a", "b"} {
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{AuthorUserID: user.ID, Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append([]int64{thread.ID}, want...)
+	}
+	want = append(want, thread1.ID)
+
+	// Page through the threads (most recently updated first) two at a time.
+	var (
+		got   []int64
+		after *DiscussionThreadsCursor
+	)
+	for page := 0; ; page++ {
+		if page > len(want) {
+			t.Fatal("pagination did not terminate")
+		}
+		threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{
+			LimitOffset: &LimitOffset{Limit: 2},
+			After:       after,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(threads) == 0 {
+			break
+		}
+		for _, thread := range threads {
+			got = append(got, thread.ID)
+		}
+		last := threads[len(threads)-1]
+		after = &DiscussionThreadsCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got threads %v, want %v", got, want)
+	}
+}
+
+func TestDiscussionThreads_ListOrderBy(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, _ := createTestDiscussionThread(ctx, t)
+	for _, title := range []string{"c", "a", "b"} {
+		if _, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{AuthorUserID: user.ID, Title: title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Page through the threads with a title (in title order) two at a time.
+	var (
+		got  []string
+		opts = &DiscussionThreadsListOptions{
+			LimitOffset:    &LimitOffset{Limit: 2},
+			OrderBy:        DiscussionThreadsListTitle,
+			AscendingOrder: true,
+		}
+	)
+	for page := 0; ; page++ {
+		if page > 4 {
+			t.Fatal("pagination did not terminate")
+		}
+		threads, err := DiscussionThreads.List(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(threads) == 0 {
+			break
+		}
+		for _, thread := range threads {
+			if len(thread.Title) == 1 {
+				got = append(got, thread.Title)
+			}
+		}
+		opts.After = NewDiscussionThreadsCursor(threads[len(threads)-1], opts)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got threads %v, want %v", got, want)
+	}
+}
+
+func TestDiscussionThreads_fuzzyFilterThreadsPagination(t *testing.T) {
+	titles := []string{"foo", "bar", "foo bar", "foo", "a foo", "baz", "foo", "food"}
+	var all []*types.DiscussionThread
+	for i, title := range titles {
+		all = append(all, &types.DiscussionThread{ID: int64(i + 1), Title: title})
+	}
+	list := func(opts *DiscussionThreadsListOptions) []*types.DiscussionThread {
+		// Like List, the query returns all candidate threads.
+		threads := append([]*types.DiscussionThread(nil), all...)
+		return DiscussionThreads.fuzzyFilterThreads(opts, threads)
+	}
+	query := "foo"
+	want := list(&DiscussionThreadsListOptions{TitleQuery: &query})
+	if len(want) != 6 {
+		t.Fatalf("got %d matching threads, want 6", len(want))
+	}
+
+	var got []*types.DiscussionThread
+	opts := &DiscussionThreadsListOptions{TitleQuery: &query, LimitOffset: &LimitOffset{Limit: 2}}
+	for page := 0; page < len(want); page++ {
+		threads := list(opts)
+		if len(threads) == 0 {
+			break
+		}
+		got = append(got, threads...)
+		opts.After = NewDiscussionThreadsCursor(threads[len(threads)-1], opts)
+		if err := opts.validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got pages of threads %+v, want %+v", got, want)
+	}
+
+	// A cursor for another order cannot be used with a title query.
+	opts.After.Score = nil
+	if err := opts.validate(); err == nil {
+		t.Error("expected error for a cursor without a score")
+	}
+}
+
+func TestDiscussionThreads_ListTitleQueryCandidates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+	defer func(n int) { maxTitleQueryCandidates = n }(maxTitleQueryCandidates)
+	maxTitleQueryCandidates = 2
+
+	user, _, _ := createTestDiscussionThread(ctx, t)
+	for i := 0; i < 3; i++ {
+		if _, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{AuthorUserID: user.ID, Title: "foo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the candidates that the query loaded are ranked.
+	query := "foo"
+	threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{TitleQuery: &query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != maxTitleQueryCandidates {
+		t.Errorf("got %d threads, want %d", len(threads), maxTitleQueryCandidates)
+	}
+}
+
+func TestDiscussionThreadsListOptions_validate(t *testing.T) {
+	thread := &types.DiscussionThread{ID: 1, Title: "t"}
+	titleCursor := NewDiscussionThreadsCursor(thread, &DiscussionThreadsListOptions{OrderBy: DiscussionThreadsListTitle})
+	tests := map[string]struct {
+		opts    DiscussionThreadsListOptions
+		wantErr bool
+	}{
+		"default order":          {opts: DiscussionThreadsListOptions{}},
+		"title cursor":           {opts: DiscussionThreadsListOptions{OrderBy: DiscussionThreadsListTitle, After: titleCursor}},
+		"updated_at cursor":      {opts: DiscussionThreadsListOptions{After: titleCursor}},
+		"cursor for other order": {opts: DiscussionThreadsListOptions{OrderBy: DiscussionThreadsListCommentCount, After: titleCursor}, wantErr: true},
+		"invalid column":         {opts: DiscussionThreadsListOptions{OrderBy: "id; DROP TABLE users"}, wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.opts.validate(); (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiscussionThreads_ListParticipantUserIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	author, _, thread := createTestDiscussionThread(ctx, t)
+	newUser := func(username string) *types.User {
+		user, err := Users.Create(ctx, NewUser{
+			Email:                 username + "@example.com",
+			Username:              username,
+			Password:              "p",
+			EmailVerificationCode: "c",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return user
+	}
+	commenter, assignee, deletedCommenter := newUser("commenter"), newUser("assignee"), newUser("deleted")
+
+	for _, userID := range []int32{author.ID, commenter.ID, author.ID} {
+		if _, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: userID, Contents: "c"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deleted, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: deletedCommenter.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionComments.Update(ctx, deleted.ID, &DiscussionCommentsUpdateOptions{Delete: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, userID := range []int32{assignee.ID, commenter.ID} {
+		if _, err := DiscussionThreadAssignees.Add(ctx, thread.ID, userID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userIDs, err := DiscussionThreads.ListParticipantUserIDs(ctx, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int32{author.ID, commenter.ID, assignee.ID}; !reflect.DeepEqual(userIDs, want) {
+		t.Errorf("got participants %v, want %v", userIDs, want)
+	}
+}
+
+func TestDiscussionThreads_ListInvolvement(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	author, repo, authored := createTestDiscussionThread(ctx, t)
+	other, err := Users.Create(ctx, NewUser{
+		Email:                 "b@b.com",
+		Username:              "u2",
 		Password:              "p",
 		EmailVerificationCode: "c",
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	// Create a repository to comply with the postgres repo constraint.
-	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+	org, err := Orgs.Create(ctx, "o", nil)
+	if err != nil {
 		t.Fatal(err)
 	}
-	repo, err := Repos.GetByName(ctx, "myrepo")
+	if _, err := OrgMembers.Create(ctx, org.ID, other.ID); err != nil {
+		t.Fatal(err)
+	}
+	newThread := func(title string) *types.DiscussionThread {
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: other.ID,
+			Title:        title,
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return thread
+	}
+	assigned, mentioned, orgMentioned := newThread("assigned"), newThread("mentioned"), newThread("org mentioned")
+	if _, err := DiscussionThreadAssignees.Add(ctx, assigned.ID, author.ID); err != nil {
+		t.Fatal(err)
+	}
+	for thread, mention := range map[*types.DiscussionThread][2][]int32{
+		mentioned:    {{author.ID}, nil},
+		orgMentioned: {nil, {org.ID}},
+	} {
+		comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: other.ID, Contents: "c"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := DiscussionCommentMentions.Set(ctx, comment.ID, mention[0], mention[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		opt  DiscussionThreadsListOptions
+		want []int64
+	}{
+		"assignee":  {DiscussionThreadsListOptions{AssigneeUserID: author.ID}, []int64{assigned.ID}},
+		"mentioned": {DiscussionThreadsListOptions{MentionedUserID: author.ID}, []int64{mentioned.ID}},
+		"involved":  {DiscussionThreadsListOptions{InvolvedUserID: author.ID, AscendingOrder: true}, []int64{authored.ID, assigned.ID, mentioned.ID}},
+		"org":       {DiscussionThreadsListOptions{OrgID: org.ID, AscendingOrder: true}, []int64{assigned.ID, mentioned.ID, orgMentioned.ID}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			threads, err := DiscussionThreads.List(ctx, &test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int64{}
+			for _, thread := range threads {
+				got = append(got, thread.ID)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got threads %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiscussionThreads_Stale(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, thread := createTestDiscussionThread(ctx, t)
+	listIDs := func(opt *DiscussionThreadsListOptions) []int64 {
+		t.Helper()
+		threads, err := DiscussionThreads.List(ctx, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []int64{}
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		return ids
+	}
+
+	future := time.Now().Add(time.Hour)
+	if got := listIDs(&DiscussionThreadsListOptions{InactiveBefore: &future}); !reflect.DeepEqual(got, []int64{thread.ID}) {
+		t.Errorf("got inactive threads %v, want %v", got, []int64{thread.ID})
+	}
+	if got := listIDs(&DiscussionThreadsListOptions{InactiveBefore: &future, NotTargetRepoIDs: []api.RepoID{repo.ID}}); len(got) != 0 {
+		t.Errorf("got inactive threads %v in excluded repo, want none", got)
+	}
+
+	updated, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Stale: boolPtr(true)})
 	if err != nil {
 		t.Fatal(err)
 	}
+	if updated.StaleAt == nil {
+		t.Fatal("expected thread to be stale")
+	}
+	if got := listIDs(&DiscussionThreadsListOptions{Stale: boolPtr(true), StaleBefore: &future}); !reflect.DeepEqual(got, []int64{thread.ID}) {
+		t.Errorf("got stale threads %v, want %v", got, []int64{thread.ID})
+	}
+	if got := listIDs(&DiscussionThreadsListOptions{Stale: boolPtr(false)}); len(got) != 0 {
+		t.Errorf("got non-stale threads %v, want none", got)
+	}
 
-	// Create the thread.
-	thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
-		AuthorUserID: user.ID,
-		Title:        "Hello world!",
-		TargetRepo: &types.DiscussionThreadTargetRepo{
-			RepoID:   repo.ID,
-			Path:     strPtr("foo/bar/mux.go"),
-			Branch:   strPtr("master"),
-			Revision: strPtr("0c1a96370c1a96370c1a96370c1a96370c1a9637"),
-		},
-	})
+	// Commenting on a stale thread makes it no longer stale.
+	if _, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DiscussionThreads.Get(ctx, thread.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if got.StaleAt != nil {
+		t.Error("expected commented thread to no longer be stale")
+	}
+	past := time.Now().Add(-time.Hour)
+	if got := listIDs(&DiscussionThreadsListOptions{InactiveBefore: &past}); len(got) != 0 {
+		t.Errorf("got inactive threads %v, want none", got)
+	}
 
-	// Delete thread.
-	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Delete: true}); err != nil {
+	// Archiving a stale thread makes it no longer stale.
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Stale: boolPtr(true)}); err != nil {
+		t.Fatal(err)
+	}
+	got, err = DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Archive: boolPtr(true)})
+	if err != nil {
 		t.Fatal(err)
 	}
+	if got.StaleAt != nil {
+		t.Error("expected archived thread to no longer be stale")
+	}
+}
 
-	// Thread no longer exists.
-	_, err = DiscussionThreads.Get(ctx, thread.ID)
-	if _, ok := err.(*ErrThreadNotFound); !ok {
-		t.Errorf("got error %v, want thread not found", err)
+func TestDiscussionThreads_Transfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
 	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
 
-	// List threads.
-	threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{})
+	user, repo, thread := createTestDiscussionThread(ctx, t)
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "otherrepo", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	otherRepo, err := Repos.GetByName(ctx, "otherrepo")
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(threads) != 0 {
-		t.Fatalf("got %d threads, want 0", len(threads))
+	newLabel := func(repoID api.RepoID, name string) *types.DiscussionLabel {
+		label, err := DiscussionLabels.Create(ctx, &types.DiscussionLabel{RepoID: repoID, Name: name, Color: "ff0000"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return label
+	}
+	bug, other, otherBug := newLabel(repo.ID, "bug"), newLabel(repo.ID, "other"), newLabel(otherRepo.ID, "bug")
+	if _, err := DiscussionLabels.AddToThread(ctx, thread.ID, []int64{bug.ID, other.ID}); err != nil {
+		t.Fatal(err)
+	}
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	// Deleting an already-deleted thread should be no-op.
-	updatedThread, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Delete: true})
-	if updatedThread != nil || err != nil {
-		t.Errorf("got updatedThread=%v err=%v, want nil thread nil error", updatedThread, err)
+	transferred, err := DiscussionThreads.Transfer(ctx, thread.ID, otherRepo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transferred.ID != thread.ID || transferred.TargetRepo.RepoID != otherRepo.ID {
+		t.Errorf("got thread %d in repo %d, want thread %d in repo %d", transferred.ID, transferred.TargetRepo.RepoID, thread.ID, otherRepo.ID)
+	}
+	if path := transferred.TargetRepo.Path; path == nil || *path != "foo/bar/mux.go" {
+		t.Errorf("got path %v, want it to be preserved", path)
+	}
+	labels, err := DiscussionLabels.List(ctx, &DiscussionLabelsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 1 || labels[0].ID != otherBug.ID {
+		t.Errorf("got labels %+v, want only the target repository's bug label", labels)
+	}
+	if _, err := DiscussionComments.Get(ctx, comment.ID); err != nil {
+		t.Errorf("expected comment to be preserved: %v", err)
 	}
-}
 
-func boolPtr(b bool) *bool {
-	return &b
+	if _, err := DiscussionThreads.Transfer(ctx, thread.ID, otherRepo.ID); err == nil {
+		t.Error("expected error transferring thread to the repository it is in")
+	}
 }
 
-func strPtr(s string) *string {
-	return &s
+func TestDiscussionThreads_Duplicates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, original := createTestDiscussionThread(ctx, t)
+	duplicate, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "duplicate",
+		TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.Update(ctx, original.ID, &DiscussionThreadsUpdateOptions{DuplicateOfThreadID: &original.ID}); err == nil {
+		t.Error("expected error marking a thread as a duplicate of itself")
+	}
+	updated, err := DiscussionThreads.Update(ctx, duplicate.ID, &DiscussionThreadsUpdateOptions{DuplicateOfThreadID: &original.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.DuplicateOfThreadID == nil || *updated.DuplicateOfThreadID != original.ID {
+		t.Errorf("got duplicate of %v, want %d", updated.DuplicateOfThreadID, original.ID)
+	}
+	threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{DuplicateOfThreadID: original.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 || threads[0].ID != duplicate.ID {
+		t.Errorf("got duplicates %+v, want only thread %d", threads, duplicate.ID)
+	}
+}
+
+func TestDiscussionThreads_Pinned(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, first := createTestDiscussionThread(ctx, t)
+	threads := []*types.DiscussionThread{first}
+	for i := 0; i < MaxPinnedDiscussionThreads; i++ {
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        "t",
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		threads = append(threads, thread)
+	}
+
+	// Pin the oldest thread, which is then listed first.
+	pinned, err := DiscussionThreads.SetPinned(ctx, first.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pinned.PinnedAt == nil {
+		t.Fatal("expected thread to be pinned")
+	}
+	if _, err := DiscussionThreads.Update(ctx, threads[1].ID, &DiscussionThreadsUpdateOptions{Title: strPtr("u")}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{TargetRepoID: &repo.ID, PinnedFirst: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(threads) || list[0].ID != first.ID || list[1].ID != threads[1].ID {
+		t.Errorf("got threads %+v, want pinned thread %d first", list, first.ID)
+	}
+
+	// Pagination continues past the pinned threads.
+	list, err = DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{
+		TargetRepoID: &repo.ID,
+		PinnedFirst:  true,
+		After:        &DiscussionThreadsCursor{UpdatedAt: pinned.UpdatedAt, ID: pinned.ID, Pinned: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(threads)-1 || list[0].ID != threads[1].ID {
+		t.Errorf("got threads %+v after the pinned thread, want %d first", list, threads[1].ID)
+	}
+
+	for _, thread := range threads[1:MaxPinnedDiscussionThreads] {
+		if _, err := DiscussionThreads.SetPinned(ctx, thread.ID, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := DiscussionThreads.SetPinned(ctx, threads[MaxPinnedDiscussionThreads].ID, true); err != ErrTooManyPinnedThreads {
+		t.Errorf("got error %v, want %v", err, ErrTooManyPinnedThreads)
+	}
+	unpinned, err := DiscussionThreads.SetPinned(ctx, first.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpinned.PinnedAt != nil {
+		t.Error("expected thread to be unpinned")
+	}
+}
+
+func TestDiscussionThreads_ListTextQuery(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, titleMatch := createTestDiscussionThread(ctx, t)
+	if _, err := DiscussionThreads.Update(ctx, titleMatch.ID, &DiscussionThreadsUpdateOptions{Title: strPtr("Panic when parsing routes")}); err != nil {
+		t.Fatal(err)
+	}
+	bodyMatch, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{AuthorUserID: user.ID, Title: "Crash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		threadID int64
+		contents string
+	}{
+		{titleMatch.ID, "It happens on every request."},
+		{bodyMatch.ID, "The router panics on startup."},
+		{titleMatch.ID, "See the stack trace."},
+	} {
+		if _, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: c.threadID, AuthorUserID: user.ID, Contents: c.contents}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	label, err := DiscussionLabels.Create(ctx, &types.DiscussionLabel{RepoID: repo.ID, Name: "bug", Color: "#ff0000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionLabels.AddToThread(ctx, titleMatch.ID, []int64{label.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.Update(ctx, bodyMatch.ID, &DiscussionThreadsUpdateOptions{Archive: boolPtr(true)}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]int64{
+		"panic":             {bodyMatch.ID, titleMatch.ID},
+		"panic label:bug":   {titleMatch.ID},
+		"panic -label:bug":  {bodyMatch.ID},
+		"panic is:archived": {bodyMatch.ID},
+		"panic is:open":     {titleMatch.ID},
+		"stack trace":       {}, // only the first comment is searched
+	}
+	for query, want := range tests {
+		t.Run(query, func(t *testing.T) {
+			opts := &DiscussionThreadsListOptions{}
+			opts.SetFromQuery(ctx, query)
+			threads, err := DiscussionThreads.List(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int64{}
+			for _, thread := range threads {
+				got = append(got, thread.ID)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got threads %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDiscussionThreads_Delete(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create the thread.
+	thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "Hello world!",
+		TargetRepo: &types.DiscussionThreadTargetRepo{
+			RepoID:   repo.ID,
+			Path:     strPtr("foo/bar/mux.go"),
+			Branch:   strPtr("master"),
+			Revision: strPtr("0c1a96370c1a96370c1a96370c1a96370c1a9637"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Delete thread.
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Delete: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Thread no longer exists.
+	_, err = DiscussionThreads.Get(ctx, thread.ID)
+	if _, ok := err.(*ErrThreadNotFound); !ok {
+		t.Errorf("got error %v, want thread not found", err)
+	}
+
+	// List threads.
+	threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 0 {
+		t.Fatalf("got %d threads, want 0", len(threads))
+	}
+
+	// Deleting an already-deleted thread should be no-op.
+	updatedThread, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Delete: true})
+	if updatedThread != nil || err != nil {
+		t.Errorf("got updatedThread=%v err=%v, want nil thread nil error", updatedThread, err)
+	}
+
+	// Restore the thread.
+	restoreEvent := func() *types.DiscussionThreadEvent {
+		return &types.DiscussionThreadEvent{ActorUserID: user.ID, Kind: types.DiscussionThreadEventRestored}
+	}
+	restoredThread, err := DiscussionThreads.Restore(ctx, thread.ID, restoreEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restoredThread.ID != thread.ID {
+		t.Errorf("got restored thread %d, want %d", restoredThread.ID, thread.ID)
+	}
+	events, err := DiscussionThreadEvents.List(ctx, &DiscussionThreadEventsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 || events[len(events)-1].Kind != types.DiscussionThreadEventRestored {
+		t.Errorf("got events %+v, want the last to be RESTORED", events)
+	}
+
+	// Restoring a thread that is not deleted is an error.
+	if _, err := DiscussionThreads.Restore(ctx, thread.ID, restoreEvent()); err == nil {
+		t.Error("expected error restoring a thread that is not deleted")
+	}
+}
+
+func TestDiscussionThreads_UpdateMany(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread1 := createTestDiscussionThread(ctx, t)
+	thread2, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "Goodbye world!",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Archive both threads. Nonexistent threads are skipped.
+	updated, events, err := DiscussionThreads.UpdateMany(ctx, []int64{thread1.ID, thread2.ID, thread2.ID + 1}, user.ID, &DiscussionThreadsUpdateOptions{Archive: boolPtr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{thread1.ID, thread2.ID}; !reflect.DeepEqual(updated, want) {
+		t.Errorf("got updated %v, want %v", updated, want)
+	}
+	// The changes are recorded on the threads' timelines.
+	if len(events) != 2 || events[0].ThreadID != thread1.ID || events[1].ThreadID != thread2.ID || events[0].Kind != types.DiscussionThreadEventArchived || events[0].ID == 0 {
+		t.Errorf("got events %+v, want an ARCHIVED event for each thread", events)
+	}
+	for _, id := range updated {
+		thread, err := DiscussionThreads.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thread.ArchivedAt == nil {
+			t.Errorf("thread %d: got ArchivedAt nil, want non-nil", id)
+		}
+	}
+
+	// Delete one thread. Deleted threads are skipped afterwards.
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread1.ID, AuthorUserID: user.ID, Contents: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := DiscussionThreads.UpdateMany(ctx, []int64{thread1.ID}, 0, &DiscussionThreadsUpdateOptions{Delete: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.Get(ctx, thread1.ID); err == nil {
+		t.Error("expected thread to be deleted")
+	}
+	// The deletion of each comment in the thread is audited.
+	entries, err := DiscussionAuditLog.List(ctx, &DiscussionAuditLogListOptions{ObjectKind: types.DiscussionAuditLogObjectComment, ObjectID: comment.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 || entries[0].Action != "delete" {
+		t.Errorf("got comment audit log entries %+v, want a delete entry", entries)
+	}
+	updated, _, err = DiscussionThreads.UpdateMany(ctx, []int64{thread1.ID, thread2.ID}, 0, &DiscussionThreadsUpdateOptions{Archive: boolPtr(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{thread2.ID}; !reflect.DeepEqual(updated, want) {
+		t.Errorf("got updated %v, want %v", updated, want)
+	}
+}
+
+// createTestDiscussionThread creates a user, a repository, and a thread (by
+// that user, targeting that repository) for use in tests.
+func createTestDiscussionThread(ctx context.Context, t *testing.T) (*types.User, *types.Repo, *types.DiscussionThread) {
+	t.Helper()
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a repository to comply with the postgres repo constraint.
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "myrepo", Description: "", Fork: false, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Repos.GetByName(ctx, "myrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "Hello world!",
+		TargetRepo: &types.DiscussionThreadTargetRepo{
+			RepoID: repo.ID,
+			Path:   strPtr("foo/bar/mux.go"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, repo, thread
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestDiscussionThreads_ListAuthzFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, err := Users.Create(ctx, NewUser{
+		Email:                 "a@a.com",
+		Username:              "u",
+		Password:              "p",
+		EmailVerificationCode: "c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a thread in a public and a private repository.
+	var threadIDs []int64
+	for _, name := range []api.RepoName{"public", "private"} {
+		if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: name, Enabled: true}); err != nil {
+			t.Fatal(err)
+		}
+		repo, err := Repos.GetByName(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        string(name),
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID, Path: strPtr("a")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		threadIDs = append(threadIDs, thread.ID)
+	}
+	publicThreadID, privateThreadID := threadIDs[0], threadIDs[1]
+
+	MockAuthzFilter = func(ctx context.Context, repos []*types.Repo, p authz.Perms) ([]*types.Repo, error) {
+		var filtered []*types.Repo
+		for _, repo := range repos {
+			if repo.Name != "private" {
+				filtered = append(filtered, repo)
+			}
+		}
+		return filtered, nil
+	}
+	defer func() { MockAuthzFilter = nil }()
+
+	threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{AscendingOrder: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotIDs []int64
+	for _, thread := range threads {
+		gotIDs = append(gotIDs, thread.ID)
+	}
+	if want := []int64{publicThreadID}; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("got thread IDs %v, want %v", gotIDs, want)
+	}
+	count, err := DiscussionThreads.Count(ctx, &DiscussionThreadsListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got count %d, want 1", count)
+	}
+	if _, err := DiscussionThreads.Get(ctx, privateThreadID); err == nil {
+		t.Error("expected error getting thread in private repository")
+	}
+}
+
+func TestDiscussionThreads_RepoDeleted(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	// The first user is a site admin.
+	admin, repo, thread := createTestDiscussionThread(ctx, t)
+	if _, err := dbconn.Global.ExecContext(ctx, "DELETE FROM repo WHERE id=$1", repo.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The thread is detached from the deleted repository instead of being
+	// deleted along with it.
+	internalCtx := actor.WithActor(ctx, &actor.Actor{Internal: true})
+	got, err := DiscussionThreads.Get(internalCtx, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TargetRepo != nil || got.RepoDeletedAt == nil {
+		t.Errorf("got target repo %+v and repo deleted at %v, want no target repo and a repo deleted time", got.TargetRepo, got.RepoDeletedAt)
+	}
+	threads, err := DiscussionThreads.List(internalCtx, &DiscussionThreadsListOptions{RepoDeleted: boolPtr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 {
+		t.Errorf("got %d threads with a deleted repository, want 1", len(threads))
+	}
+
+	// Only site admins can see it.
+	if _, err := DiscussionThreads.Get(actor.WithActor(ctx, &actor.Actor{UID: admin.ID}), thread.ID); err != nil {
+		t.Errorf("site admin: %v", err)
+	}
+	if _, err := DiscussionThreads.Get(ctx, thread.ID); err == nil {
+		t.Error("expected error getting thread of a deleted repository as a non-admin")
+	}
+}
+
+func TestDiscussionThreads_Reported(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, thread := createTestDiscussionThread(ctx, t)
+	thread2, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+		AuthorUserID: user.ID,
+		Title:        "t2",
+		TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{ThreadID: thread2.ID, AuthorUserID: user.ID, Contents: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reported := func() []int64 {
+		t.Helper()
+		threads, err := DiscussionThreads.List(ctx, &DiscussionThreadsListOptions{Reported: true, AscendingOrder: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []int64
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		return ids
+	}
+
+	gotThread, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{Report: strPtr("spam")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"spam"}; !reflect.DeepEqual(gotThread.Reports, want) {
+		t.Errorf("got reports %v, want %v", gotThread.Reports, want)
+	}
+	if _, err := DiscussionComments.Update(ctx, comment.ID, &DiscussionCommentsUpdateOptions{Report: strPtr("abuse")}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reported(), []int64{thread.ID, thread2.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got reported threads %v, want %v", got, want)
+	}
+
+	if _, err := DiscussionThreads.Update(ctx, thread.ID, &DiscussionThreadsUpdateOptions{ClearReports: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reported(), []int64{thread2.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got reported threads %v, want %v", got, want)
+	}
+}
+
+func TestDiscussionThreads_CommentAndReactionCounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	var comments []*types.DiscussionComment
+	for i := 0; i < 2; i++ {
+		comment, err := DiscussionComments.Create(ctx, &types.DiscussionComment{
+			ThreadID:     thread.ID,
+			AuthorUserID: user.ID,
+			Contents:     "c",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		comments = append(comments, comment)
+	}
+	for _, content := range []types.DiscussionReactionContent{types.DiscussionReactionThumbsUp, types.DiscussionReactionHeart} {
+		if err := DiscussionCommentReactions.Add(ctx, comments[1].ID, user.ID, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Adding an existing reaction does not count it twice.
+	if err := DiscussionCommentReactions.Add(ctx, comments[1].ID, user.ID, types.DiscussionReactionHeart); err != nil {
+		t.Fatal(err)
+	}
+	wantCounts := func(wantComments, wantReactions int32) {
+		t.Helper()
+		thread, err := DiscussionThreads.Get(ctx, thread.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thread.CommentCount != wantComments || thread.ReactionCount != wantReactions {
+			t.Errorf("got %d comments and %d reactions, want %d and %d", thread.CommentCount, thread.ReactionCount, wantComments, wantReactions)
+		}
+	}
+	wantCounts(2, 2)
+
+	if err := DiscussionCommentReactions.Remove(ctx, comments[1].ID, user.ID, types.DiscussionReactionThumbsUp); err != nil {
+		t.Fatal(err)
+	}
+	wantCounts(2, 1)
+
+	// Reactions to a deleted comment are not counted.
+	if _, err := DiscussionComments.Update(ctx, comments[1].ID, &DiscussionCommentsUpdateOptions{Delete: true}); err != nil {
+		t.Fatal(err)
+	}
+	wantCounts(1, 0)
+}
+
+func TestDiscussionThreads_CreateWithComment(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, _ := createTestDiscussionThread(ctx, t)
+	create := func(contents string) (*types.DiscussionThread, *types.DiscussionComment, error) {
+		newComment := &types.DiscussionComment{AuthorUserID: user.ID, Contents: contents}
+		thread, err := DiscussionThreads.CreateWithComment(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        "t",
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+		}, newComment, &types.DiscussionThreadEvent{ActorUserID: user.ID, Kind: types.DiscussionThreadEventCreated})
+		return thread, newComment, err
+	}
+
+	thread, comment, err := create("c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.ThreadID != thread.ID || thread.CommentCount != 1 {
+		t.Errorf("got comment on thread %d and comment count %d, want thread %d and count 1", comment.ThreadID, thread.CommentCount, thread.ID)
+	}
+	events, err := DiscussionThreadEvents.List(ctx, &DiscussionThreadEventsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Kind != types.DiscussionThreadEventCreated {
+		t.Errorf("got events %+v, want only the CREATED event", events)
+	}
+
+	// When the comment cannot be created, neither is the thread.
+	if _, _, err := create(strings.Repeat("x", 100001)); err == nil {
+		t.Fatal("expected error creating a comment that is too long")
+	}
+	count, err := DiscussionThreads.Count(ctx, &DiscussionThreadsListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("got %d threads, want 2", count)
+	}
+}
+
+func TestDiscussionThreads_ApplyActions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, thread := createTestDiscussionThread(ctx, t)
+	label, err := DiscussionLabels.Create(ctx, &types.DiscussionLabel{RepoID: repo.ID, Name: "bug", Color: "#ffffff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := true
+	updated, events, err := DiscussionThreads.ApplyActions(ctx, thread.ID, user.ID, []*DiscussionThreadAction{
+		{AddLabelIDs: []int64{label.ID}},
+		{AddAssigneeUserID: user.ID},
+		{Comment: &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: "c"}},
+		{Archive: &archive},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ArchivedAt == nil || updated.CommentCount != 1 {
+		t.Errorf("got archived at %v and comment count %d, want archived thread with 1 comment", updated.ArchivedAt, updated.CommentCount)
+	}
+	var kinds []types.DiscussionThreadEventKind
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+	}
+	wantKinds := []types.DiscussionThreadEventKind{
+		types.DiscussionThreadEventLabelAdded,
+		types.DiscussionThreadEventAssigned,
+		types.DiscussionThreadEventCommented,
+		types.DiscussionThreadEventArchived,
+	}
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Errorf("got event kinds %v, want %v", kinds, wantKinds)
+	}
+
+	// When an action fails, none of the actions are applied.
+	archive = false
+	if _, _, err := DiscussionThreads.ApplyActions(ctx, thread.ID, user.ID, []*DiscussionThreadAction{
+		{Archive: &archive},
+		{RemoveLabelIDs: []int64{label.ID}},
+		{Comment: &types.DiscussionComment{ThreadID: thread.ID, AuthorUserID: user.ID, Contents: strings.Repeat("x", 100001)}},
+	}); err == nil {
+		t.Fatal("expected error creating a comment that is too long")
+	}
+	got, err := DiscussionThreads.Get(ctx, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ArchivedAt == nil {
+		t.Error("got unarchived thread, want the failed actions to be rolled back")
+	}
+	labels, err := DiscussionLabels.List(ctx, &DiscussionLabelsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 1 {
+		t.Errorf("got %d labels, want the failed actions to be rolled back", len(labels))
+	}
+
+	// Each action must set exactly one field.
+	if _, _, err := DiscussionThreads.ApplyActions(ctx, thread.ID, user.ID, []*DiscussionThreadAction{
+		{AddAssigneeUserID: user.ID, Archive: &archive},
+	}); err == nil {
+		t.Fatal("expected error for an action with more than one field set")
+	}
+}
+
+func TestDiscussionThreads_IdempotencyKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, _ := createTestDiscussionThread(ctx, t)
+	create := func(key string) (*types.DiscussionThread, error) {
+		return DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID:   user.ID,
+			Title:          "t",
+			TargetRepo:     &types.DiscussionThreadTargetRepo{RepoID: repo.ID},
+			IdempotencyKey: &key,
+		})
+	}
+
+	thread, err := create("k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := create("k"); err != ErrDuplicateIdempotencyKey {
+		t.Errorf("got error %v, want %v", err, ErrDuplicateIdempotencyKey)
+	}
+	if got, err := DiscussionThreads.GetByIdempotencyKey(ctx, user.ID, "k"); err != nil {
+		t.Fatal(err)
+	} else if got.ID != thread.ID {
+		t.Errorf("got thread %d, want %d", got.ID, thread.ID)
+	}
+	if _, err := DiscussionThreads.GetByIdempotencyKey(ctx, user.ID, "other"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	// The key can be reused after the idempotency window.
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_threads SET created_at=$1 WHERE id=$2", time.Now().Add(-DiscussionThreadIdempotencyWindow-time.Minute), thread.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiscussionThreads.GetByIdempotencyKey(ctx, user.ID, "k"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+	if _, err := create("k"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscussionThreads_Numbers(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, first := createTestDiscussionThread(ctx, t)
+	if err := Repos.Upsert(ctx, api.InsertRepoOp{Name: "otherrepo", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	otherRepo, err := Repos.GetByName(ctx, "otherrepo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(repoID api.RepoID) *types.DiscussionThread {
+		t.Helper()
+		thread, err := DiscussionThreads.Create(ctx, &types.DiscussionThread{
+			AuthorUserID: user.ID,
+			Title:        "t",
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: repoID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return thread
+	}
+
+	second, otherFirst := create(repo.ID), create(otherRepo.ID)
+	for _, c := range []struct {
+		thread *types.DiscussionThread
+		want   int32
+	}{{first, 1}, {second, 2}, {otherFirst, 1}} {
+		if c.thread.TargetRepo.Number != c.want {
+			t.Errorf("thread %d: got number %d, want %d", c.thread.ID, c.thread.TargetRepo.Number, c.want)
+		}
+	}
+	if got, err := DiscussionThreads.GetByNumber(ctx, repo.ID, 2); err != nil {
+		t.Fatal(err)
+	} else if got.ID != second.ID {
+		t.Errorf("got thread %d, want %d", got.ID, second.ID)
+	}
+	if _, err := DiscussionThreads.GetByNumber(ctx, repo.ID, 3); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	// A transferred thread gets the next number in its new repository, and its
+	// old number is not reused.
+	transferred, err := DiscussionThreads.Transfer(ctx, second.ID, otherRepo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transferred.TargetRepo.Number != 2 {
+		t.Errorf("got number %d after transfer, want 2", transferred.TargetRepo.Number)
+	}
+	if third := create(repo.ID); third.TargetRepo.Number != 3 {
+		t.Errorf("got number %d, want 3", third.TargetRepo.Number)
+	}
+
+	// The transferred thread is still found by its old number.
+	if got, err := DiscussionThreads.GetByNumber(ctx, repo.ID, 2); err != nil {
+		t.Fatal(err)
+	} else if got.ID != second.ID || got.TargetRepo.RepoID != otherRepo.ID || got.TargetRepo.Number != 2 {
+		t.Errorf("got thread %d (number %d in repo %d), want thread %d (number 2 in repo %d)", got.ID, got.TargetRepo.Number, got.TargetRepo.RepoID, second.ID, otherRepo.ID)
+	}
+}
+
+func TestDiscussionThreadsListOptions_SetFromQuery_state(t *testing.T) {
+	tests := map[string]struct {
+		wantArchived, wantClosed *bool
+	}{
+		"is:open":      {wantArchived: boolPtr(false), wantClosed: boolPtr(false)},
+		"is:closed":    {wantArchived: boolPtr(false), wantClosed: boolPtr(true)},
+		"is:archived":  {wantArchived: boolPtr(true)},
+		"-is:open":     {wantClosed: boolPtr(true)},
+		"-is:closed":   {wantClosed: boolPtr(false)},
+		"-is:archived": {wantArchived: boolPtr(false)},
+	}
+	for query, test := range tests {
+		t.Run(query, func(t *testing.T) {
+			opts := &DiscussionThreadsListOptions{}
+			opts.SetFromQuery(context.Background(), query)
+			if !reflect.DeepEqual(opts.Archived, test.wantArchived) {
+				t.Errorf("got Archived %v, want %v", opts.Archived, test.wantArchived)
+			}
+			if !reflect.DeepEqual(opts.Closed, test.wantClosed) {
+				t.Errorf("got Closed %v, want %v", opts.Closed, test.wantClosed)
+			}
+		})
+	}
+}
+
+func TestDiscussionThreadsListOptions_SetFromQuery_threadIDs(t *testing.T) {
+	defer func() { Mocks = MockStores{} }()
+	users := map[string]int32{"a": 1, "b": 2, "c": 3}
+	Mocks.Users.GetByUsername = func(_ context.Context, username string) (*types.User, error) {
+		if id, ok := users[username]; ok {
+			return &types.User{ID: id, Username: username}, nil
+		}
+		return nil, &userNotFoundErr{}
+	}
+	// User a is involved in threads 1 and 2, and user b in thread 3.
+	Mocks.DiscussionComments.List = func(_ context.Context, opts *DiscussionCommentsListOptions) ([]*types.DiscussionComment, error) {
+		switch *opts.AuthorUserID {
+		case 1:
+			return []*types.DiscussionComment{{ThreadID: 1}, {ThreadID: 2}}, nil
+		case 2:
+			return []*types.DiscussionComment{{ThreadID: 3}}, nil
+		}
+		return nil, nil
+	}
+	// User b is assigned to threads 2 and 3, and user c to thread 1.
+	Mocks.DiscussionThreadAssignees.ListThreadIDs = func(_ context.Context, userIDs []int32) ([]int64, error) {
+		switch userIDs[0] {
+		case 2:
+			return []int64{2, 3}, nil
+		case 3:
+			return []int64{1}, nil
+		}
+		return nil, nil
+	}
+
+	tests := map[string][]int64{
+		"involves:a":                       {1, 2},
+		"involves:a assignee:b":            {2},
+		"assignee:b involves:a":            {2},
+		"involves:b assignee:c":            {-1},
+		"involves:nobody assignee:b":       {-1},
+		"involves:b assignee:c assignee:b": {-1},
+	}
+	for query, want := range tests {
+		t.Run(query, func(t *testing.T) {
+			opts := &DiscussionThreadsListOptions{}
+			opts.SetFromQuery(context.Background(), query)
+			if !reflect.DeepEqual(opts.ThreadIDs, want) {
+				t.Errorf("got ThreadIDs %v, want %v", opts.ThreadIDs, want)
+			}
+		})
+	}
+}
+
+func TestDiscussionThreads_SetClosed(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, _, thread := createTestDiscussionThread(ctx, t)
+	closed, err := DiscussionThreads.SetClosed(ctx, thread.ID, true, &types.DiscussionThreadEvent{ActorUserID: user.ID, Kind: types.DiscussionThreadEventClosed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.ClosedAt == nil || closed.ArchivedAt != nil {
+		t.Errorf("got closedAt %v and archivedAt %v, want only closedAt", closed.ClosedAt, closed.ArchivedAt)
+	}
+	if _, err := DiscussionThreads.SetClosed(ctx, thread.ID, true, &types.DiscussionThreadEvent{ActorUserID: user.ID, Kind: types.DiscussionThreadEventClosed}); err != ErrThreadAlreadyClosed {
+		t.Errorf("close closed thread: got error %v, want %v", err, ErrThreadAlreadyClosed)
+	}
+
+	reopened, err := DiscussionThreads.SetClosed(ctx, thread.ID, false, &types.DiscussionThreadEvent{ActorUserID: user.ID, Kind: types.DiscussionThreadEventReopened})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.ClosedAt != nil {
+		t.Errorf("got closedAt %v, want nil", reopened.ClosedAt)
+	}
+	if _, err := DiscussionThreads.SetClosed(ctx, thread.ID, false, &types.DiscussionThreadEvent{ActorUserID: user.ID, Kind: types.DiscussionThreadEventReopened}); err != ErrThreadNotClosed {
+		t.Errorf("reopen open thread: got error %v, want %v", err, ErrThreadNotClosed)
+	}
+
+	events, err := DiscussionThreadEvents.List(ctx, &DiscussionThreadEventsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []types.DiscussionThreadEventKind
+	for _, event := range events {
+		if event.ActorUserID != user.ID {
+			t.Errorf("got actor %d, want %d", event.ActorUserID, user.ID)
+		}
+		kinds = append(kinds, event.Kind)
+	}
+	if want := []types.DiscussionThreadEventKind{types.DiscussionThreadEventClosed, types.DiscussionThreadEventReopened}; !reflect.DeepEqual(kinds, want) {
+		t.Errorf("got events %v, want %v", kinds, want)
+	}
 }
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"io"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionUnsubscribeTokens provides access to the
+// `discussion_unsubscribe_tokens` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionUnsubscribeTokens struct{}
+
+// Generate gets the existing token, or generates a new one, for unsubscribing
+// the specified user from the specified thread through only the token.
+//
+// 🚨 SECURITY: The caller must ensure the token is ONLY given to the user that
+// is passed to this method. Anyone with the token can unsubscribe the
+// specified user from the specified thread, at ANY point in the future.
+func (*discussionUnsubscribeTokens) Generate(ctx context.Context, userID int32, threadID int64) (string, error) {
+	if Mocks.DiscussionUnsubscribeTokens.Generate != nil {
+		return Mocks.DiscussionUnsubscribeTokens.Generate(ctx, userID, threadID)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, io.LimitReader(cryptorand.Reader, 128)); err != nil {
+		return "", err
+	}
+	newToken := fmt.Sprintf("%x", h.Sum(nil))
+
+	// Reuse the existing token for this userID + threadID pair, if any, so
+	// that every email about the thread has the same unsubscribe link.
+	var token string
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_unsubscribe_tokens(token, user_id, thread_id) VALUES($1, $2, $3)
+		ON CONFLICT (user_id, thread_id) DO UPDATE SET token=discussion_unsubscribe_tokens.token
+		RETURNING token`, newToken, userID, threadID).Scan(&token)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Get returns the user and thread ID found for the given token. If there is
+// none, the token is invalid and ErrInvalidToken is returned.
+func (*discussionUnsubscribeTokens) Get(ctx context.Context, token string) (userID int32, threadID int64, err error) {
+	if Mocks.DiscussionUnsubscribeTokens.Get != nil {
+		return Mocks.DiscussionUnsubscribeTokens.Get(ctx, token)
+	}
+	err = dbconn.Global.QueryRowContext(ctx, "SELECT user_id, thread_id FROM discussion_unsubscribe_tokens WHERE token=$1", token).Scan(
+		&userID,
+		&threadID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, 0, ErrInvalidToken
+		}
+		return 0, 0, err
+	}
+	return userID, threadID, nil
+}
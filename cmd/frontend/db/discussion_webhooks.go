@@ -0,0 +1,295 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// discussionWebhooks provides access to the `discussion_webhooks` and
+// `discussion_webhook_deliveries` tables.
+//
+// For a detailed overview of the schema, see schema.md.
+type discussionWebhooks struct{}
+
+// ErrWebhookNotFound is the error returned by Discussions methods to indicate
+// that the webhook could not be found.
+type ErrWebhookNotFound struct {
+	// WebhookID is the webhook that was not found.
+	WebhookID int64
+}
+
+func (e *ErrWebhookNotFound) Error() string {
+	return fmt.Sprintf("webhook %d not found", e.WebhookID)
+}
+
+func (e *ErrWebhookNotFound) NotFound() bool { return true }
+
+func (w *discussionWebhooks) Create(ctx context.Context, newWebhook *types.DiscussionWebhook) (*types.DiscussionWebhook, error) {
+	if Mocks.DiscussionWebhooks.Create != nil {
+		return Mocks.DiscussionWebhooks.Create(ctx, newWebhook)
+	}
+
+	// Validate the input webhook.
+	if newWebhook == nil {
+		return nil, errors.New("newWebhook is nil")
+	}
+	if newWebhook.ID != 0 {
+		return nil, errors.New("newWebhook.ID must be zero")
+	}
+	if u, err := url.Parse(newWebhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("newWebhook.URL must be an absolute http or https URL")
+	}
+	if strings.TrimSpace(newWebhook.Secret) == "" {
+		return nil, errors.New("newWebhook.Secret must be present (and not whitespace)")
+	}
+	if !newWebhook.CreatedAt.IsZero() {
+		return nil, errors.New("newWebhook.CreatedAt must not be specified")
+	}
+
+	newWebhook.CreatedAt = time.Now()
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_webhooks(
+		repo_id,
+		url,
+		secret,
+		created_at
+	) VALUES ($1, $2, $3, $4) RETURNING id`,
+		newWebhook.RepoID,
+		newWebhook.URL,
+		newWebhook.Secret,
+		newWebhook.CreatedAt,
+	).Scan(&newWebhook.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newWebhook, nil
+}
+
+func (w *discussionWebhooks) Get(ctx context.Context, webhookID int64) (*types.DiscussionWebhook, error) {
+	if Mocks.DiscussionWebhooks.Get != nil {
+		return Mocks.DiscussionWebhooks.Get(webhookID)
+	}
+
+	webhooks, err := w.List(ctx, &DiscussionWebhooksListOptions{
+		WebhookIDs: []int64{webhookID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, &ErrWebhookNotFound{WebhookID: webhookID}
+	}
+	return webhooks[0], nil
+}
+
+func (w *discussionWebhooks) Delete(ctx context.Context, webhookID int64) error {
+	if Mocks.DiscussionWebhooks.Delete != nil {
+		return Mocks.DiscussionWebhooks.Delete(ctx, webhookID)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, "DELETE FROM discussion_webhooks WHERE id=$1", webhookID)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return &ErrWebhookNotFound{WebhookID: webhookID}
+	}
+	return nil
+}
+
+type DiscussionWebhooksListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// WebhookIDs, when len() > 0, specifies that only webhooks with one of
+	// these IDs should be returned.
+	WebhookIDs []int64
+
+	// RepoID, when non-zero, specifies that only webhooks configured for this
+	// repository should be returned.
+	RepoID api.RepoID
+
+	// ForThreadID, when non-zero, specifies that only webhooks that fire for
+	// events on this thread (i.e., the site-wide webhooks and the webhooks of
+	// the thread's target repository) should be returned.
+	ForThreadID int64
+}
+
+func (w *discussionWebhooks) List(ctx context.Context, opts *DiscussionWebhooksListOptions) ([]*types.DiscussionWebhook, error) {
+	if Mocks.DiscussionWebhooks.List != nil {
+		return Mocks.DiscussionWebhooks.List(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := w.getListSQL(opts)
+	q := sqlf.Sprintf("WHERE %s ORDER BY id ASC %s", sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT id, repo_id, url, secret, created_at FROM discussion_webhooks "+q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := []*types.DiscussionWebhook{}
+	defer rows.Close()
+	for rows.Next() {
+		webhook := &types.DiscussionWebhook{}
+		if err := rows.Scan(&webhook.ID, &webhook.RepoID, &webhook.URL, &webhook.Secret, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (w *discussionWebhooks) Count(ctx context.Context, opts *DiscussionWebhooksListOptions) (int, error) {
+	if Mocks.DiscussionWebhooks.Count != nil {
+		return Mocks.DiscussionWebhooks.Count(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := w.getListSQL(opts)
+	q := sqlf.Sprintf("SELECT count(id) FROM discussion_webhooks WHERE %s", sqlf.Join(conds, "AND"))
+	return w.getCountBySQL(ctx, q)
+}
+
+func (*discussionWebhooks) getListSQL(opts *DiscussionWebhooksListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.WebhookIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("id = ANY(%v)", pq.Array(opts.WebhookIDs)))
+	}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id=%v", opts.RepoID))
+	}
+	if opts.ForThreadID != 0 {
+		conds = append(conds, sqlf.Sprintf("(repo_id IS NULL OR repo_id IN (SELECT repo_id FROM discussion_threads_target_repo WHERE thread_id=%v))", opts.ForThreadID))
+	}
+	return conds
+}
+
+// CreateDelivery records that the event is about to be delivered to the
+// webhook. The delivery starts with no attempts.
+func (w *discussionWebhooks) CreateDelivery(ctx context.Context, webhookID, eventID int64) (*types.DiscussionWebhookDelivery, error) {
+	if Mocks.DiscussionWebhooks.CreateDelivery != nil {
+		return Mocks.DiscussionWebhooks.CreateDelivery(ctx, webhookID, eventID)
+	}
+	delivery := &types.DiscussionWebhookDelivery{
+		WebhookID: webhookID,
+		EventID:   eventID,
+		CreatedAt: time.Now(),
+	}
+	err := dbconn.Global.QueryRowContext(ctx, `INSERT INTO discussion_webhook_deliveries(
+		webhook_id,
+		event_id,
+		created_at
+	) VALUES ($1, $2, $3) RETURNING id`,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.CreatedAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// UpdateDelivery records the outcome of the latest attempt of the delivery.
+func (w *discussionWebhooks) UpdateDelivery(ctx context.Context, delivery *types.DiscussionWebhookDelivery) error {
+	if Mocks.DiscussionWebhooks.UpdateDelivery != nil {
+		return Mocks.DiscussionWebhooks.UpdateDelivery(ctx, delivery)
+	}
+	_, err := dbconn.Global.ExecContext(ctx, "UPDATE discussion_webhook_deliveries SET attempts=$1, status_code=$2, error=$3, delivered_at=$4 WHERE id=$5",
+		delivery.Attempts,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.DeliveredAt,
+		delivery.ID,
+	)
+	return err
+}
+
+type DiscussionWebhookDeliveriesListOptions struct {
+	// LimitOffset specifies SQL LIMIT and OFFSET counts. It may be nil (no limit / offset).
+	*LimitOffset
+
+	// WebhookID, when non-zero, specifies that only deliveries to this
+	// webhook should be returned.
+	WebhookID int64
+}
+
+// ListDeliveries returns the deliveries matching the options, most recent
+// first.
+func (w *discussionWebhooks) ListDeliveries(ctx context.Context, opts *DiscussionWebhookDeliveriesListOptions) ([]*types.DiscussionWebhookDelivery, error) {
+	if Mocks.DiscussionWebhooks.ListDeliveries != nil {
+		return Mocks.DiscussionWebhooks.ListDeliveries(ctx, opts)
+	}
+	if opts == nil {
+		return nil, errors.New("options must not be nil")
+	}
+	conds := w.getDeliveriesListSQL(opts)
+	q := sqlf.Sprintf(`SELECT id, webhook_id, event_id, attempts, status_code, error, created_at, delivered_at
+		FROM discussion_webhook_deliveries WHERE %s ORDER BY id DESC %s`, sqlf.Join(conds, "AND"), opts.LimitOffset.SQL())
+
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	deliveries := []*types.DiscussionWebhookDelivery{}
+	defer rows.Close()
+	for rows.Next() {
+		d := &types.DiscussionWebhookDelivery{}
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.Attempts, &d.StatusCode, &d.Error, &d.CreatedAt, &d.DeliveredAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (w *discussionWebhooks) CountDeliveries(ctx context.Context, opts *DiscussionWebhookDeliveriesListOptions) (int, error) {
+	if Mocks.DiscussionWebhooks.CountDeliveries != nil {
+		return Mocks.DiscussionWebhooks.CountDeliveries(ctx, opts)
+	}
+	if opts == nil {
+		return 0, errors.New("options must not be nil")
+	}
+	conds := w.getDeliveriesListSQL(opts)
+	q := sqlf.Sprintf("SELECT count(id) FROM discussion_webhook_deliveries WHERE %s", sqlf.Join(conds, "AND"))
+	return w.getCountBySQL(ctx, q)
+}
+
+func (*discussionWebhooks) getDeliveriesListSQL(opts *DiscussionWebhookDeliveriesListOptions) (conds []*sqlf.Query) {
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.WebhookID != 0 {
+		conds = append(conds, sqlf.Sprintf("webhook_id=%v", opts.WebhookID))
+	}
+	return conds
+}
+
+func (*discussionWebhooks) getCountBySQL(ctx context.Context, q *sqlf.Query) (int, error) {
+	var count int
+	err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
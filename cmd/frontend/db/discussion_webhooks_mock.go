@@ -0,0 +1,19 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockDiscussionWebhooks struct {
+	Create          func(ctx context.Context, newWebhook *types.DiscussionWebhook) (*types.DiscussionWebhook, error)
+	Get             func(webhookID int64) (*types.DiscussionWebhook, error)
+	Delete          func(ctx context.Context, webhookID int64) error
+	List            func(ctx context.Context, opts *DiscussionWebhooksListOptions) ([]*types.DiscussionWebhook, error)
+	Count           func(ctx context.Context, opts *DiscussionWebhooksListOptions) (int, error)
+	CreateDelivery  func(ctx context.Context, webhookID, eventID int64) (*types.DiscussionWebhookDelivery, error)
+	UpdateDelivery  func(ctx context.Context, delivery *types.DiscussionWebhookDelivery) error
+	ListDeliveries  func(ctx context.Context, opts *DiscussionWebhookDeliveriesListOptions) ([]*types.DiscussionWebhookDelivery, error)
+	CountDeliveries func(ctx context.Context, opts *DiscussionWebhookDeliveriesListOptions) (int, error)
+}
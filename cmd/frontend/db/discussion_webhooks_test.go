@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestDiscussionWebhooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, repo, thread := createTestDiscussionThread(ctx, t)
+
+	siteWide, err := DiscussionWebhooks.Create(ctx, &types.DiscussionWebhook{URL: "https://example.com/a", Secret: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forRepo, err := DiscussionWebhooks.Create(ctx, &types.DiscussionWebhook{RepoID: &repo.ID, URL: "https://example.com/b", Secret: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRepoID := repo.ID + 1
+	if _, err := DiscussionWebhooks.Create(ctx, &types.DiscussionWebhook{RepoID: &otherRepoID, URL: "https://example.com/c", Secret: "s"}); err == nil {
+		t.Error("expected error creating webhook for nonexistent repository")
+	}
+	if _, err := DiscussionWebhooks.Create(ctx, &types.DiscussionWebhook{URL: "ftp://example.com", Secret: "s"}); err == nil {
+		t.Error("expected error creating webhook with non-HTTP URL")
+	}
+	if _, err := DiscussionWebhooks.Create(ctx, &types.DiscussionWebhook{URL: "https://example.com"}); err == nil {
+		t.Error("expected error creating webhook without secret")
+	}
+
+	webhooks, err := DiscussionWebhooks.List(ctx, &DiscussionWebhooksListOptions{ForThreadID: thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks) != 2 || webhooks[0].ID != siteWide.ID || webhooks[1].ID != forRepo.ID {
+		t.Errorf("got webhooks %+v, want site-wide and repository webhooks", webhooks)
+	}
+
+	event, err := DiscussionThreadEvents.Create(ctx, &types.DiscussionThreadEvent{
+		ThreadID:    thread.ID,
+		ActorUserID: user.ID,
+		Kind:        types.DiscussionThreadEventArchived,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delivery, err := DiscussionWebhooks.CreateDelivery(ctx, forRepo.ID, event.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statusCode := int32(200)
+	delivery.Attempts = 1
+	delivery.StatusCode = &statusCode
+	if err := DiscussionWebhooks.UpdateDelivery(ctx, delivery); err != nil {
+		t.Fatal(err)
+	}
+	deliveries, err := DiscussionWebhooks.ListDeliveries(ctx, &DiscussionWebhookDeliveriesListOptions{WebhookID: forRepo.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Attempts != 1 || deliveries[0].StatusCode == nil || *deliveries[0].StatusCode != 200 {
+		t.Errorf("got deliveries %+v, want 1 successful delivery", deliveries)
+	}
+
+	if err := DiscussionWebhooks.Delete(ctx, forRepo.ID); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := DiscussionWebhooks.CountDeliveries(ctx, &DiscussionWebhookDeliveriesListOptions{WebhookID: forRepo.ID}); err != nil {
+		t.Fatal(err)
+	} else if count != 0 {
+		t.Errorf("got %d deliveries after deleting webhook, want 0", count)
+	}
+	if _, err := DiscussionWebhooks.Get(ctx, forRepo.ID); err == nil {
+		t.Error("expected webhook not found error")
+	} else if _, ok := err.(*ErrWebhookNotFound); !ok {
+		t.Errorf("got error %v, want webhook not found", err)
+	}
+}
@@ -6,9 +6,31 @@ var Mocks MockStores
 type MockStores struct {
 	AccessTokens MockAccessTokens
 
-	DiscussionThreads         MockDiscussionThreads
-	DiscussionComments        MockDiscussionComments
-	DiscussionMailReplyTokens MockDiscussionMailReplyTokens
+	DiscussionThreads             MockDiscussionThreads
+	DiscussionComments            MockDiscussionComments
+	DiscussionMailReplyTokens     MockDiscussionMailReplyTokens
+	DiscussionLabels              MockDiscussionLabels
+	DiscussionThreadAssignees     MockDiscussionThreadAssignees
+	DiscussionThreadEvents        MockDiscussionThreadEvents
+	DiscussionThreadSubscriptions MockDiscussionThreadSubscriptions
+	DiscussionThreadReads         MockDiscussionThreadReads
+	DiscussionMilestones          MockDiscussionMilestones
+	DiscussionThreadTemplates     MockDiscussionThreadTemplates
+	DiscussionSavedReplies        MockDiscussionSavedReplies
+	DiscussionWebhooks            MockDiscussionWebhooks
+	DiscussionFeedTokens          MockDiscussionFeedTokens
+	DiscussionUnsubscribeTokens   MockDiscussionUnsubscribeTokens
+	DiscussionCommentReactions    MockDiscussionCommentReactions
+	DiscussionCommentMentions     MockDiscussionCommentMentions
+	DiscussionCommentEdits        MockDiscussionCommentEdits
+	DiscussionCommentSuggestions  MockDiscussionCommentSuggestions
+	DiscussionCommentAttachments  MockDiscussionCommentAttachments
+	DiscussionThreadReferences    MockDiscussionThreadReferences
+	DiscussionThreadDependencies  MockDiscussionThreadDependencies
+	DiscussionThreadDiagnostics   MockDiscussionThreadDiagnostics
+	DiscussionAuditLog            MockDiscussionAuditLog
+	DiscussionRoles               MockDiscussionRoles
+	DiscussionRules               MockDiscussionRules
 
 	Repos         MockRepos
 	Orgs          MockOrgs
@@ -62,6 +62,24 @@ func (s *repos) Get(ctx context.Context, id api.RepoID) (*types.Repo, error) {
 	return repos[0], nil
 }
 
+// GetByIDs returns the repositories with the given IDs, in no particular
+// order. Repositories that do not exist (or that the current user cannot
+// access) are omitted.
+func (s *repos) GetByIDs(ctx context.Context, ids ...api.RepoID) ([]*types.Repo, error) {
+	if Mocks.Repos.GetByIDs != nil {
+		return Mocks.Repos.GetByIDs(ctx, ids...)
+	}
+	if len(ids) == 0 {
+		return []*types.Repo{}, nil
+	}
+
+	items := make([]*sqlf.Query, len(ids))
+	for i, id := range ids {
+		items[i] = sqlf.Sprintf("%d", id)
+	}
+	return s.getBySQL(ctx, sqlf.Sprintf("id IN (%s)", sqlf.Join(items, ",")))
+}
+
 // GetByName returns the repository with the given nameOrUri from the
 // database, or an error. If we have a match on name and uri, we prefer the
 // match on name.
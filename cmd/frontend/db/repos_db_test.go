@@ -203,6 +203,25 @@ func TestRepos_Get(t *testing.T) {
 	}
 }
 
+func TestRepos_GetByIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	want := mustCreate(ctx, t, &types.Repo{Name: "r1"}, &types.Repo{Name: "r2"})
+
+	repos, err := Repos.GetByIDs(ctx, want[1].ID, want[1].ID+100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 1 || repos[0].ID != want[1].ID {
+		t.Errorf("got %v, want only %v", repos, want[1])
+	}
+}
+
 func TestRepos_List(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -1,22 +1,44 @@
 package db
 
 var (
-	AccessTokens              = &accessTokens{}
-	ExternalServices          = &ExternalServicesStore{}
-	DefaultRepos              = &defaultRepos{}
-	DiscussionThreads         = &discussionThreads{}
-	DiscussionComments        = &discussionComments{}
-	DiscussionMailReplyTokens = &discussionMailReplyTokens{}
-	Repos                     = &repos{}
-	Phabricator               = &phabricator{}
-	QueryRunnerState          = &queryRunnerState{}
-	Orgs                      = &orgs{}
-	OrgMembers                = &orgMembers{}
-	SavedSearches             = &savedSearches{}
-	Settings                  = &settings{}
-	Users                     = &users{}
-	UserEmails                = &userEmails{}
-	EventLogs                 = &eventLogs{}
+	AccessTokens                  = &accessTokens{}
+	ExternalServices              = &ExternalServicesStore{}
+	DefaultRepos                  = &defaultRepos{}
+	DiscussionThreads             = &discussionThreads{}
+	DiscussionComments            = &discussionComments{}
+	DiscussionMailReplyTokens     = &discussionMailReplyTokens{}
+	DiscussionLabels              = &discussionLabels{}
+	DiscussionThreadAssignees     = &discussionThreadAssignees{}
+	DiscussionThreadEvents        = &discussionThreadEvents{}
+	DiscussionThreadSubscriptions = &discussionThreadSubscriptions{}
+	DiscussionThreadReads         = &discussionThreadReads{}
+	DiscussionMilestones          = &discussionMilestones{}
+	DiscussionThreadTemplates     = &discussionThreadTemplates{}
+	DiscussionSavedReplies        = &discussionSavedReplies{}
+	DiscussionWebhooks            = &discussionWebhooks{}
+	DiscussionFeedTokens          = &discussionFeedTokens{}
+	DiscussionUnsubscribeTokens   = &discussionUnsubscribeTokens{}
+	DiscussionCommentReactions    = &discussionCommentReactions{}
+	DiscussionCommentMentions     = &discussionCommentMentions{}
+	DiscussionCommentEdits        = &discussionCommentEdits{}
+	DiscussionCommentSuggestions  = &discussionCommentSuggestions{}
+	DiscussionCommentAttachments  = &discussionCommentAttachments{}
+	DiscussionThreadReferences    = &discussionThreadReferences{}
+	DiscussionThreadDependencies  = &discussionThreadDependencies{}
+	DiscussionThreadDiagnostics   = &discussionThreadDiagnostics{}
+	DiscussionAuditLog            = &discussionAuditLog{}
+	DiscussionRoles               = &discussionRoles{}
+	DiscussionRules               = &discussionRules{}
+	Repos                         = &repos{}
+	Phabricator                   = &phabricator{}
+	QueryRunnerState              = &queryRunnerState{}
+	Orgs                          = &orgs{}
+	OrgMembers                    = &orgMembers{}
+	SavedSearches                 = &savedSearches{}
+	Settings                      = &settings{}
+	Users                         = &users{}
+	UserEmails                    = &userEmails{}
+	EventLogs                     = &eventLogs{}
 
 	SurveyResponses = &surveyResponses{}
 
@@ -376,6 +376,7 @@ func (u *users) Delete(ctx context.Context, id int32) error {
 	if err != nil {
 		return err
 	}
+	var discussionThreadIDs []int64
 	defer func() {
 		if err != nil {
 			rollErr := tx.Rollback()
@@ -385,6 +386,9 @@ func (u *users) Delete(ctx context.Context, id int32) error {
 			return
 		}
 		err = tx.Commit()
+		if err == nil {
+			invalidateDiscussionThreads(discussionThreadIDs...)
+		}
 	}()
 
 	res, err := tx.ExecContext(ctx, "UPDATE users SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL", id)
@@ -421,6 +425,9 @@ func (u *users) Delete(ctx context.Context, id int32) error {
 	}
 
 	// Soft-delete discussions data.
+	if discussionThreadIDs, err = discussionThreadIDsByAuthor(ctx, tx, id); err != nil {
+		return err
+	}
 	if _, err := tx.ExecContext(ctx, "UPDATE discussion_mail_reply_tokens SET deleted_at=now() WHERE deleted_at IS NULL AND user_id=$1", id); err != nil {
 		return err
 	}
@@ -440,6 +447,7 @@ func (u *users) HardDelete(ctx context.Context, id int32) error {
 	if err != nil {
 		return err
 	}
+	var discussionThreadIDs []int64
 	defer func() {
 		if err != nil {
 			rollErr := tx.Rollback()
@@ -449,6 +457,9 @@ func (u *users) HardDelete(ctx context.Context, id int32) error {
 			return
 		}
 		err = tx.Commit()
+		if err == nil {
+			invalidateDiscussionThreads(discussionThreadIDs...)
+		}
 	}()
 
 	if _, err := tx.ExecContext(ctx, "DELETE FROM names WHERE user_id=$1", id); err != nil {
@@ -490,6 +501,9 @@ func (u *users) HardDelete(ctx context.Context, id int32) error {
 	}
 
 	// Hard-delete discussions data.
+	if discussionThreadIDs, err = discussionThreadIDsByAuthor(ctx, tx, id); err != nil {
+		return err
+	}
 	if _, err := tx.ExecContext(ctx, "DELETE FROM discussion_mail_reply_tokens WHERE user_id=$1", id); err != nil {
 		return err
 	}
@@ -0,0 +1,204 @@
+package graphqlbackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func (schemaResolver) AuditLog(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+	Actor         *graphql.ID
+	Object        *graphql.ID
+	CreatedAfter  *DateTime
+	CreatedBefore *DateTime
+}) (*discussionAuditLogConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins can access the audit log.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	opt := &db.DiscussionAuditLogListOptions{}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	if args.Actor != nil {
+		userID, err := UnmarshalUserID(*args.Actor)
+		if err != nil {
+			return nil, err
+		}
+		opt.ActorUserID = userID
+	}
+	if args.Object != nil {
+		var err error
+		switch relay.UnmarshalKind(*args.Object) {
+		case "DiscussionThread":
+			opt.ObjectKind = types.DiscussionAuditLogObjectThread
+			opt.ObjectID, err = unmarshalDiscussionThreadID(*args.Object)
+		case "DiscussionComment":
+			opt.ObjectKind = types.DiscussionAuditLogObjectComment
+			opt.ObjectID, err = unmarshalDiscussionCommentID(*args.Object)
+		default:
+			err = fmt.Errorf("the audit log object must be a discussion thread or comment (got %q)", relay.UnmarshalKind(*args.Object))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if args.CreatedAfter != nil {
+		opt.CreatedAfter = &args.CreatedAfter.Time
+	}
+	if args.CreatedBefore != nil {
+		opt.CreatedBefore = &args.CreatedBefore.Time
+	}
+	return &discussionAuditLogConnectionResolver{opt: opt}, nil
+}
+
+type discussionAuditLogConnectionResolver struct {
+	opt *db.DiscussionAuditLogListOptions
+
+	// cache results because they are used by multiple fields
+	once    sync.Once
+	entries []*types.DiscussionAuditLogEntry
+	err     error
+}
+
+func (r *discussionAuditLogConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionAuditLogEntry, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+		r.entries, r.err = db.DiscussionAuditLog.List(ctx, &opt2)
+	})
+	return r.entries, r.err
+}
+
+func (r *discussionAuditLogConnectionResolver) Nodes(ctx context.Context) ([]*discussionAuditLogEntryResolver, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(entries) > r.opt.Limit {
+		entries = entries[:r.opt.Limit]
+	}
+	l := make([]*discussionAuditLogEntryResolver, len(entries))
+	for i, entry := range entries {
+		l[i] = &discussionAuditLogEntryResolver{e: entry}
+	}
+	return l, nil
+}
+
+func (r *discussionAuditLogConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionAuditLog.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionAuditLogConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(entries) > r.opt.Limit), nil
+}
+
+type discussionAuditLogEntryResolver struct {
+	e *types.DiscussionAuditLogEntry
+}
+
+func (r *discussionAuditLogEntryResolver) ID() graphql.ID {
+	return relay.MarshalID("DiscussionAuditLogEntry", strconv.FormatInt(r.e.ID, 36))
+}
+
+func (r *discussionAuditLogEntryResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	if r.e.ActorUserID == nil {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, *r.e.ActorUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *discussionAuditLogEntryResolver) ActorIP() *string { return r.e.ActorIP }
+
+func (r *discussionAuditLogEntryResolver) ActorForwardedFor() *string { return r.e.ActorForwardedFor }
+
+func (r *discussionAuditLogEntryResolver) ObjectKind() string {
+	return strings.ToUpper(string(r.e.ObjectKind))
+}
+
+func (r *discussionAuditLogEntryResolver) ObjectID() graphql.ID {
+	if r.e.ObjectKind == types.DiscussionAuditLogObjectComment {
+		return marshalDiscussionCommentID(r.e.ObjectID)
+	}
+	return marshalDiscussionThreadID(r.e.ObjectID)
+}
+
+func (r *discussionAuditLogEntryResolver) Action() string { return r.e.Action }
+
+func (r *discussionAuditLogEntryResolver) Before() (*JSONValue, error) {
+	return auditLogJSON(r.e.Before)
+}
+
+func (r *discussionAuditLogEntryResolver) After() (*JSONValue, error) {
+	return auditLogJSON(r.e.After)
+}
+
+func auditLogJSON(b []byte) (*JSONValue, error) {
+	if b == nil {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return &JSONValue{v}, nil
+}
+
+// ChangedFields returns the (sorted) names of the top-level fields whose
+// values differ between the before and after snapshots.
+func (r *discussionAuditLogEntryResolver) ChangedFields() ([]string, error) {
+	var before, after map[string]json.RawMessage
+	if r.e.Before != nil {
+		if err := json.Unmarshal(r.e.Before, &before); err != nil {
+			return nil, err
+		}
+	}
+	if r.e.After != nil {
+		if err := json.Unmarshal(r.e.After, &after); err != nil {
+			return nil, err
+		}
+	}
+	changed := []string{}
+	for name, value := range after {
+		if !bytes.Equal(before[name], value) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+func (r *discussionAuditLogEntryResolver) CreatedAt() DateTime { return DateTime{Time: r.e.CreatedAt} }
@@ -0,0 +1,68 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestAuditLog(t *testing.T) {
+	resetMocks()
+	object := marshalDiscussionThreadID(3)
+	args := &struct {
+		graphqlutil.ConnectionArgs
+		Actor         *graphql.ID
+		Object        *graphql.ID
+		CreatedAfter  *DateTime
+		CreatedBefore *DateTime
+	}{Object: &object}
+
+	t.Run("non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		if _, err := (schemaResolver{}).AuditLog(context.Background(), args); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		db.Mocks.DiscussionAuditLog.List = func(_ context.Context, opts *db.DiscussionAuditLogListOptions) ([]*types.DiscussionAuditLogEntry, error) {
+			if opts.ObjectKind != types.DiscussionAuditLogObjectThread || opts.ObjectID != 3 {
+				t.Errorf("got options %+v, want entries for thread 3", opts)
+			}
+			return []*types.DiscussionAuditLogEntry{{
+				ID:         1,
+				ObjectKind: types.DiscussionAuditLogObjectThread,
+				ObjectID:   3,
+				Action:     "update",
+				Before:     []byte(`{"id": 3, "title": "a", "locked_at": null}`),
+				After:      []byte(`{"id": 3, "title": "b", "archived_at": "2019-06-01T00:00:00Z"}`),
+			}}, nil
+		}
+		r, err := (schemaResolver{}).AuditLog(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries, err := r.Nodes(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].ObjectID() != object {
+			t.Fatalf("got entries %+v, want one entry for thread 3", entries)
+		}
+		changed, err := entries[0].ChangedFields()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"archived_at", "locked_at", "title"}; !reflect.DeepEqual(changed, want) {
+			t.Errorf("got changed fields %v, want %v", changed, want)
+		}
+	})
+}
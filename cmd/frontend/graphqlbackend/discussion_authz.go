@@ -0,0 +1,63 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+)
+
+// discussionViewerCan returns the value of a viewerCan* field, given the result
+// of the discussions.CheckCan* function for the action. Errors that mean that
+// the viewer may not perform the action are not returned.
+func discussionViewerCan(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	switch discussions.Code(err) {
+	case discussions.ErrorCodeForbidden, discussions.ErrorCodeInvalidState:
+		return false, nil
+	}
+	return false, err
+}
+
+func (r *discussionThreadResolver) ViewerCanUpdate(ctx context.Context) (bool, error) {
+	return discussionViewerCan(discussions.CheckCanUpdateThread(ctx, r.t))
+}
+
+func (r *discussionThreadResolver) ViewerCanTriage(ctx context.Context) (bool, error) {
+	return discussionViewerCan(discussions.CheckCanTriageThread(ctx, r.t))
+}
+
+func (r *discussionThreadResolver) ViewerCanArchive(ctx context.Context) (bool, error) {
+	return discussionViewerCan(discussions.CheckCanArchiveThread(ctx, r.t))
+}
+
+func (r *discussionThreadResolver) ViewerCanLock(ctx context.Context) (bool, error) {
+	return discussionViewerCan(discussions.CheckCanLockThread(ctx, r.t))
+}
+
+func (r *discussionThreadResolver) ViewerCanDelete(ctx context.Context) (bool, error) {
+	return discussionViewerCan(discussions.CheckCanDeleteThread(ctx, r.t))
+}
+
+func (r *discussionThreadResolver) ViewerCanComment(ctx context.Context) (bool, error) {
+	// Keep in sync with the checks in the addCommentToThread mutation.
+	if _, err := checkSignedInAndEmailVerified(ctx); err != nil {
+		return discussionViewerCan(err)
+	}
+	return discussionViewerCan(discussions.CheckCanComment(ctx, r.t))
+}
+
+func (r *discussionCommentResolver) ViewerCanUpdate(ctx context.Context) (bool, error) {
+	return discussionViewerCan(discussions.CheckCanUpdateComment(ctx, r.c))
+}
+
+func (r *discussionCommentResolver) ViewerCanDelete(ctx context.Context) (bool, error) {
+	thread, err := db.DiscussionThreads.Get(ctx, r.c.ThreadID)
+	if err != nil {
+		return false, errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	return discussionViewerCan(discussions.CheckCanDeleteComment(ctx, thread, r.c))
+}
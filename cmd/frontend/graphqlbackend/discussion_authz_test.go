@@ -0,0 +1,101 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionThread_ViewerCan(t *testing.T) {
+	resetMocks()
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	users := map[int32]*types.User{
+		1: {ID: 1, Username: "author"},
+		2: {ID: 2, Username: "admin", SiteAdmin: true},
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		if user, ok := users[actor.FromContext(ctx).UID]; ok {
+			return user, nil
+		}
+		return nil, db.ErrNoCurrentUser
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return users[id], nil }
+	verifiedAt := time.Now()
+	db.Mocks.UserEmails.ListByUser = func(userID int32) ([]*db.UserEmail, error) {
+		return []*db.UserEmail{{UserID: userID, Email: "a@example.com", VerifiedAt: &verifiedAt}}, nil
+	}
+	lockedAt := time.Now()
+	db.Mocks.DiscussionThreads.Get = func(int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: 123, AuthorUserID: 1, LockedAt: &lockedAt}, nil
+	}
+
+	query := `
+		{
+			node(id: "RGlzY3Vzc2lvblRocmVhZDoiM2Yi") {
+				... on DiscussionThread {
+					viewerCanUpdate
+					viewerCanTriage
+					viewerCanLock
+					viewerCanDelete
+					viewerCanComment
+				}
+			}
+		}
+	`
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: context.Background(),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query:   query,
+			ExpectedResult: `
+				{
+					"node": {
+						"viewerCanUpdate": false,
+						"viewerCanTriage": false,
+						"viewerCanLock": false,
+						"viewerCanDelete": false,
+						"viewerCanComment": false
+					}
+				}
+			`,
+		},
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query:   query,
+			ExpectedResult: `
+				{
+					"node": {
+						"viewerCanUpdate": true,
+						"viewerCanTriage": true,
+						"viewerCanLock": false,
+						"viewerCanDelete": false,
+						"viewerCanComment": false
+					}
+				}
+			`,
+		},
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 2}),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query:   query,
+			ExpectedResult: `
+				{
+					"node": {
+						"viewerCanUpdate": true,
+						"viewerCanTriage": true,
+						"viewerCanLock": true,
+						"viewerCanDelete": true,
+						"viewerCanComment": true
+					}
+				}
+			`,
+		},
+	})
+}
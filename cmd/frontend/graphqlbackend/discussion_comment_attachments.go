@@ -0,0 +1,117 @@
+package graphqlbackend
+
+import (
+	"context"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/attachments"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type discussionCommentAttachmentResolver struct {
+	a *types.DiscussionCommentAttachment
+}
+
+func (r *discussionCommentAttachmentResolver) Filename() string    { return r.a.Filename }
+func (r *discussionCommentAttachmentResolver) ContentType() string { return r.a.ContentType }
+func (r *discussionCommentAttachmentResolver) Size() int32         { return int32(r.a.Size) }
+
+func (r *discussionCommentAttachmentResolver) URL() (string, error) {
+	u, err := discussions.URLToAttachment(r.a)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (r *discussionCommentAttachmentResolver) IsImage() bool {
+	return attachments.IsImage(r.a.ContentType)
+}
+
+func (r *discussionCommentAttachmentResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.a.CreatedAt}
+}
+
+func (r *discussionCommentResolver) Attachments(ctx context.Context) ([]*discussionCommentAttachmentResolver, error) {
+	// 🚨 SECURITY: Anyone with access to the comment also has access to its
+	// attachments.
+	list, err := db.DiscussionCommentAttachments.List(ctx, r.c.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentAttachments.List")
+	}
+	resolvers := make([]*discussionCommentAttachmentResolver, len(list))
+	for i, a := range list {
+		resolvers[i] = &discussionCommentAttachmentResolver{a: a}
+	}
+	return resolvers, nil
+}
+
+type discussionCommentAttachmentUploadResolver struct {
+	attachment *types.DiscussionCommentAttachment
+	uploadURL  string
+	expiresAt  time.Time
+}
+
+func (r *discussionCommentAttachmentUploadResolver) Attachment() *discussionCommentAttachmentResolver {
+	return &discussionCommentAttachmentResolver{a: r.attachment}
+}
+
+func (r *discussionCommentAttachmentUploadResolver) UploadURL() string { return r.uploadURL }
+
+func (r *discussionCommentAttachmentUploadResolver) UploadURLExpiresAt() DateTime {
+	return DateTime{Time: r.expiresAt}
+}
+
+func (r *discussionsMutationResolver) CreateCommentAttachment(ctx context.Context, args *struct {
+	CommentID   graphql.ID
+	Filename    string
+	ContentType string
+	Size        int32
+}) (*discussionCommentAttachmentUploadResolver, error) {
+	// 🚨 SECURITY: Only signed in users with a verified email may attach
+	// files (see AddCommentToThread).
+	currentUser, err := checkSignedInAndEmailVerified(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := unmarshalDiscussionCommentID(args.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := db.DiscussionComments.Get(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the comment's author may attach files to it.
+	if comment.AuthorUserID != currentUser.user.ID {
+		return nil, discussions.NewError(discussions.ErrorCodeForbidden, "only the author of a comment can attach files to it")
+	}
+	if err := attachments.CheckAllowed(args.ContentType, int64(args.Size)); err != nil {
+		return nil, err
+	}
+
+	attachment, token, expiresAt, err := db.DiscussionCommentAttachments.Create(ctx, &types.DiscussionCommentAttachment{
+		CommentID:   comment.ID,
+		Filename:    args.Filename,
+		ContentType: args.ContentType,
+		Size:        int64(args.Size),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentAttachments.Create")
+	}
+	// 🚨 SECURITY: The upload URL is only returned to the comment's author.
+	uploadURL, err := discussions.URLToUploadAttachment(token)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionCommentAttachmentUploadResolver{
+		attachment: attachment,
+		uploadURL:  uploadURL.String(),
+		expiresAt:  expiresAt,
+	}, nil
+}
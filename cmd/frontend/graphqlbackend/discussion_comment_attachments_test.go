@@ -0,0 +1,78 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestDiscussionsMutations_CreateCommentAttachment(t *testing.T) {
+	resetMocks()
+	defer conf.Mock(nil)
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{Attachments: &schema.DiscussionsAttachments{
+			Storage: schema.DiscussionsAttachmentsStorage{Type: "filesystem", Directory: "/tmp"},
+		}},
+	}})
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.UserEmails.ListByUser = func(int32) ([]*db.UserEmail, error) {
+		now := time.Now()
+		return []*db.UserEmail{{Email: "alice@example.com", VerifiedAt: &now}}, nil
+	}
+	db.Mocks.DiscussionComments.Get = func(commentID int64) (*types.DiscussionComment, error) {
+		return &types.DiscussionComment{ID: commentID, AuthorUserID: int32(commentID)}, nil
+	}
+	var created bool
+	db.Mocks.DiscussionCommentAttachments.Create = func(_ context.Context, a *types.DiscussionCommentAttachment) (*types.DiscussionCommentAttachment, string, time.Time, error) {
+		created = true
+		a.ID = 3
+		return a, "t", time.Now(), nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	args := func(commentID int64, contentType string) *struct {
+		CommentID   graphql.ID
+		Filename    string
+		ContentType string
+		Size        int32
+	} {
+		return &struct {
+			CommentID   graphql.ID
+			Filename    string
+			ContentType string
+			Size        int32
+		}{CommentID: marshalDiscussionCommentID(commentID), Filename: "a.png", ContentType: contentType, Size: 10}
+	}
+	r := &discussionsMutationResolver{}
+
+	if _, err := r.CreateCommentAttachment(ctx, args(2, "image/png")); err == nil {
+		t.Error("expected error attaching to another user's comment")
+	}
+	if _, err := r.CreateCommentAttachment(ctx, args(1, "application/zip")); err == nil {
+		t.Error("expected error attaching a disallowed content type")
+	}
+	if created {
+		t.Fatal("unexpected attachment created")
+	}
+
+	upload, err := r.CreateCommentAttachment(ctx, args(1, "image/png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(upload.UploadURL(), "token=t") {
+		t.Errorf("got upload URL %q, want it to contain the token", upload.UploadURL())
+	}
+	if !upload.Attachment().IsImage() {
+		t.Error("expected attachment to be an image")
+	}
+	if url, err := upload.Attachment().URL(); err != nil || url != "/-/discussions/attachments/3" {
+		t.Errorf("got URL %q (error %v), want %q", url, err, "/-/discussions/attachments/3")
+	}
+}
@@ -0,0 +1,130 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type discussionCommentEditResolver struct {
+	e *types.DiscussionCommentEdit
+}
+
+func (r *discussionCommentEditResolver) PreviousContents() string { return r.e.PreviousContents }
+
+func (r *discussionCommentEditResolver) Editor(ctx context.Context) (*UserResolver, error) {
+	return UserByIDInt32(ctx, r.e.EditorUserID)
+}
+
+func (r *discussionCommentEditResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.e.CreatedAt}
+}
+
+// lastEdit returns the most recent edit of the comment, or nil if it has never
+// been edited.
+func (r *discussionCommentResolver) lastEdit(ctx context.Context) (*types.DiscussionCommentEdit, error) {
+	edits, err := db.DiscussionCommentEdits.List(ctx, &db.DiscussionCommentEditsListOptions{
+		LimitOffset: &db.LimitOffset{Limit: 1},
+		CommentID:   r.c.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentEdits.List")
+	}
+	if len(edits) == 0 {
+		return nil, nil
+	}
+	return edits[0], nil
+}
+
+func (r *discussionCommentResolver) LastEditedAt(ctx context.Context) (*DateTime, error) {
+	edit, err := r.lastEdit(ctx)
+	if edit == nil || err != nil {
+		return nil, err
+	}
+	return &DateTime{Time: edit.CreatedAt}, nil
+}
+
+func (r *discussionCommentResolver) Editor(ctx context.Context) (*UserResolver, error) {
+	edit, err := r.lastEdit(ctx)
+	if edit == nil || err != nil {
+		return nil, err
+	}
+	return UserByIDInt32(ctx, edit.EditorUserID)
+}
+
+func (r *discussionCommentResolver) EditHistory(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) (*discussionCommentEditsConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins and the comment author can view the prior
+	// contents of a comment.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.c.AuthorUserID); err != nil {
+		return nil, err
+	}
+	opt := &db.DiscussionCommentEditsListOptions{CommentID: r.c.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionCommentEditsConnectionResolver{opt: opt}, nil
+}
+
+// discussionCommentEditsConnectionResolver resolves a list of discussion
+// comment edits.
+//
+// 🚨 SECURITY: When instantiating an discussionCommentEditsConnectionResolver
+// value, the caller MUST check permissions.
+type discussionCommentEditsConnectionResolver struct {
+	opt *db.DiscussionCommentEditsListOptions
+
+	// cache results because they are used by multiple fields
+	once  sync.Once
+	edits []*types.DiscussionCommentEdit
+	err   error
+}
+
+func (r *discussionCommentEditsConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionCommentEdit, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.edits, r.err = db.DiscussionCommentEdits.List(ctx, &opt2)
+	})
+	return r.edits, r.err
+}
+
+func (r *discussionCommentEditsConnectionResolver) Nodes(ctx context.Context) ([]*discussionCommentEditResolver, error) {
+	edits, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(edits) > r.opt.Limit {
+		edits = edits[:r.opt.Limit]
+	}
+
+	var l []*discussionCommentEditResolver
+	for _, edit := range edits {
+		l = append(l, &discussionCommentEditResolver{e: edit})
+	}
+	return l, nil
+}
+
+func (r *discussionCommentEditsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionCommentEdits.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionCommentEditsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	edits, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(edits) > r.opt.Limit), nil
+}
@@ -0,0 +1,70 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionComment_EditHistory(t *testing.T) {
+	resetMocks()
+	editedAt := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	db.Mocks.DiscussionCommentEdits.List = func(_ context.Context, opts *db.DiscussionCommentEditsListOptions) ([]*types.DiscussionCommentEdit, error) {
+		if opts.CommentID != 5 {
+			t.Errorf("got comment ID %d, want 5", opts.CommentID)
+		}
+		return []*types.DiscussionCommentEdit{{CommentID: 5, EditorUserID: 2, PreviousContents: "a", CreatedAt: editedAt}}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	comment := &discussionCommentResolver{c: &types.DiscussionComment{ID: 5, AuthorUserID: 1}}
+
+	lastEditedAt, err := comment.LastEditedAt(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEditedAt == nil || !lastEditedAt.Time.Equal(editedAt) {
+		t.Errorf("got lastEditedAt %v, want %v", lastEditedAt, editedAt)
+	}
+	editor, err := comment.Editor(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if editor == nil || editor.user.ID != 2 {
+		t.Errorf("got editor %+v, want user 2", editor)
+	}
+
+	t.Run("other user", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 3}, nil
+		}
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 3})
+		if _, err := comment.EditHistory(ctx, &struct{ graphqlutil.ConnectionArgs }{}); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("author", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1}, nil
+		}
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		history, err := comment.EditHistory(ctx, &struct{ graphqlutil.ConnectionArgs }{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes, err := history.Nodes(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(nodes) != 1 || nodes[0].PreviousContents() != "a" {
+			t.Errorf("got edit history %+v, want 1 edit with previous contents %q", nodes, "a")
+		}
+	})
+}
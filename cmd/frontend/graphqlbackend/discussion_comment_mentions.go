@@ -0,0 +1,33 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+)
+
+func (r *discussionCommentResolver) Mentions(ctx context.Context) ([]*NamespaceResolver, error) {
+	mentions, err := db.DiscussionCommentMentions.List(ctx, r.c.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentMentions.List")
+	}
+	l := make([]*NamespaceResolver, 0, len(mentions))
+	for _, mention := range mentions {
+		switch {
+		case mention.UserID != nil:
+			user, err := UserByIDInt32(ctx, *mention.UserID)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, &NamespaceResolver{user})
+		case mention.OrgID != nil:
+			org, err := OrgByIDInt32(ctx, *mention.OrgID)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, &NamespaceResolver{org})
+		}
+	}
+	return l, nil
+}
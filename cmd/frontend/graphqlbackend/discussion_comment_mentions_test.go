@@ -0,0 +1,40 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionComment_Mentions(t *testing.T) {
+	resetMocks()
+	userID, orgID := int32(1), int32(2)
+	db.Mocks.DiscussionCommentMentions.List = func(_ context.Context, commentID int64) ([]*types.DiscussionCommentMention, error) {
+		return []*types.DiscussionCommentMention{
+			{CommentID: commentID, UserID: &userID},
+			{CommentID: commentID, OrgID: &orgID},
+		}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "alice"}, nil
+	}
+	db.Mocks.Orgs.GetByID = func(_ context.Context, id int32) (*types.Org, error) {
+		return &types.Org{ID: id, Name: "acme"}, nil
+	}
+
+	mentions, err := (&discussionCommentResolver{c: &types.DiscussionComment{ID: 5}}).Mentions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mentions) != 2 {
+		t.Fatalf("got %d mentions, want 2", len(mentions))
+	}
+	if user, ok := mentions[0].ToUser(); !ok || user.Username() != "alice" {
+		t.Errorf("got first mention %+v, want user alice", mentions[0])
+	}
+	if org, ok := mentions[1].ToOrg(); !ok || org.Name() != "acme" {
+		t.Errorf("got second mention %+v, want org acme", mentions[1])
+	}
+}
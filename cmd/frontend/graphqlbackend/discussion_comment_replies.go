@@ -0,0 +1,77 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/ratelimit"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func (r *discussionCommentResolver) ParentComment(ctx context.Context) (*discussionCommentResolver, error) {
+	if r.c.ParentCommentID == nil {
+		return nil, nil
+	}
+	parent, err := db.DiscussionComments.Get(ctx, *r.c.ParentCommentID)
+	if err != nil {
+		if _, ok := err.(*db.ErrCommentNotFound); ok {
+			return nil, nil // the parent comment was deleted
+		}
+		return nil, errors.Wrap(err, "DiscussionComments.Get")
+	}
+	return &discussionCommentResolver{c: parent}, nil
+}
+
+func (r *discussionCommentResolver) Replies(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionCommentsConnectionResolver {
+	// 🚨 SECURITY: Anyone with access to the comment also has access to its
+	// replies, which are in the same thread.
+	opt := &db.DiscussionCommentsListOptions{ParentCommentID: &r.c.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionCommentsConnectionResolver{opt: opt}
+}
+
+func (r *discussionsMutationResolver) ReplyToComment(ctx context.Context, args *struct {
+	CommentID graphql.ID
+	Contents  string
+}) (*discussionCommentResolver, error) {
+	// 🚨 SECURITY: Only signed in users with a verified email may reply to
+	// comments (see AddCommentToThread).
+	currentUser, err := checkSignedInAndEmailVerified(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(args.Contents) == "" {
+		return nil, errors.New("cannot add empty replies to comments")
+	}
+
+	parentID, err := unmarshalDiscussionCommentID(args.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := db.DiscussionComments.Get(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ratelimit.CheckCanAddComment(currentUser.user.ID); err != nil {
+		return nil, err
+	}
+	reply := &types.DiscussionComment{
+		ThreadID:        parent.ThreadID,
+		ParentCommentID: &parent.ID,
+		AuthorUserID:    currentUser.user.ID,
+		Contents:        args.Contents,
+	}
+	if _, err := discussions.InsecureAddCommentToThread(ctx, reply); err != nil {
+		return nil, errors.Wrap(err, "ReplyToComment")
+	}
+	return &discussionCommentResolver{c: reply}, nil
+}
@@ -0,0 +1,54 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionComment_Replies(t *testing.T) {
+	resetMocks()
+	parentID := int64(1)
+	db.Mocks.DiscussionComments.Get = func(commentID int64) (*types.DiscussionComment, error) {
+		if commentID != parentID {
+			return nil, &db.ErrCommentNotFound{CommentID: commentID}
+		}
+		return &types.DiscussionComment{ID: commentID}, nil
+	}
+	db.Mocks.DiscussionComments.List = func(_ context.Context, opts *db.DiscussionCommentsListOptions) ([]*types.DiscussionComment, error) {
+		if opts.ParentCommentID == nil || *opts.ParentCommentID != parentID {
+			t.Errorf("got parent comment ID %v, want %d", opts.ParentCommentID, parentID)
+		}
+		return []*types.DiscussionComment{{ID: 2, ParentCommentID: &parentID}}, nil
+	}
+	ctx := context.Background()
+
+	parent := &discussionCommentResolver{c: &types.DiscussionComment{ID: parentID}}
+	if got, err := parent.ParentComment(ctx); err != nil || got != nil {
+		t.Errorf("got parent comment %v (error %v) of a top-level comment, want nil", got, err)
+	}
+	replies, err := parent.Replies(ctx, &struct{ graphqlutil.ConnectionArgs }{}).Nodes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replies) != 1 {
+		t.Fatalf("got %d replies, want 1", len(replies))
+	}
+	got, err := replies[0].ParentComment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.c.ID != parentID {
+		t.Errorf("got parent comment %v, want comment %d", got, parentID)
+	}
+
+	// Replies to deleted comments have no parent.
+	deletedID := int64(3)
+	orphan := &discussionCommentResolver{c: &types.DiscussionComment{ID: 4, ParentCommentID: &deletedID}}
+	if got, err := orphan.ParentComment(ctx); err != nil || got != nil {
+		t.Errorf("got parent comment %v (error %v) of a reply to a deleted comment, want nil", got, err)
+	}
+}
@@ -0,0 +1,95 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func (r *discussionCommentResolver) IsResolved() bool { return r.c.ResolvedAt != nil }
+
+func (r *discussionCommentResolver) ResolvedAt() *DateTime {
+	if r.c.ResolvedAt == nil {
+		return nil
+	}
+	return &DateTime{Time: *r.c.ResolvedAt}
+}
+
+func (r *discussionCommentResolver) ResolvedBy(ctx context.Context) (*UserResolver, error) {
+	if r.c.ResolvedAt == nil || r.c.ResolvedByUserID == nil {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, *r.c.ResolvedByUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (d *discussionThreadResolver) ResolvedThreadCount(ctx context.Context) (int32, error) {
+	// 🚨 SECURITY: Anyone with access to the thread also has access to its
+	// comments.
+	resolved := true
+	count, err := db.DiscussionComments.Count(ctx, &db.DiscussionCommentsListOptions{
+		ThreadID: &d.t.ID,
+		TopLevel: true,
+		Resolved: &resolved,
+	})
+	return int32(count), err
+}
+
+func (r *discussionsMutationResolver) ResolveCommentThread(ctx context.Context, args *struct {
+	CommentID graphql.ID
+}) (*discussionCommentResolver, error) {
+	return r.setCommentResolved(ctx, args.CommentID, true)
+}
+
+func (r *discussionsMutationResolver) UnresolveCommentThread(ctx context.Context, args *struct {
+	CommentID graphql.ID
+}) (*discussionCommentResolver, error) {
+	return r.setCommentResolved(ctx, args.CommentID, false)
+}
+
+func (r *discussionsMutationResolver) setCommentResolved(ctx context.Context, id graphql.ID, resolve bool) (*discussionCommentResolver, error) {
+	// 🚨 SECURITY: Only signed in users with a verified email may resolve and
+	// unresolve comments, as with adding comments (see AddCommentToThread).
+	currentUser, err := checkSignedInAndEmailVerified(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := unmarshalDiscussionCommentID(id)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := db.DiscussionComments.Get(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if previous.ParentCommentID != nil {
+		return nil, discussions.NewError(discussions.ErrorCodeInvalidState, "only top-level comments can be resolved")
+	}
+
+	comment, err := db.DiscussionComments.Update(ctx, commentID, &db.DiscussionCommentsUpdateOptions{
+		Resolve:        &resolve,
+		ResolverUserID: currentUser.user.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionComments.Update")
+	}
+	if wasResolved := previous.ResolvedAt != nil; wasResolved != resolve {
+		kind := types.DiscussionThreadEventCommentUnresolved
+		if resolve {
+			kind = types.DiscussionThreadEventCommentResolved
+		}
+		discussions.LogThreadEvent(ctx, comment.ThreadID, currentUser.user.ID, kind, types.DiscussionThreadEventData{
+			CommentID: &comment.ID,
+		})
+	}
+	return &discussionCommentResolver{c: comment}, nil
+}
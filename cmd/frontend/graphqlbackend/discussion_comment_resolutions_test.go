@@ -0,0 +1,84 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionsMutations_ResolveCommentThread(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.UserEmails.ListByUser = func(int32) ([]*db.UserEmail, error) {
+		now := time.Now()
+		return []*db.UserEmail{{Email: "alice@example.com", VerifiedAt: &now}}, nil
+	}
+	parentID := int64(1)
+	comments := map[int64]*types.DiscussionComment{
+		1: {ID: 1, ThreadID: 2},
+		3: {ID: 3, ThreadID: 2, ParentCommentID: &parentID},
+	}
+	db.Mocks.DiscussionComments.Get = func(commentID int64) (*types.DiscussionComment, error) {
+		c, ok := comments[commentID]
+		if !ok {
+			return nil, &db.ErrCommentNotFound{CommentID: commentID}
+		}
+		copy := *c
+		return &copy, nil
+	}
+	db.Mocks.DiscussionComments.Update = func(_ context.Context, commentID int64, opts *db.DiscussionCommentsUpdateOptions) (*types.DiscussionComment, error) {
+		c := comments[commentID]
+		if opts.Resolve == nil {
+			t.Fatal("expected Resolve to be set")
+		}
+		if *opts.Resolve && c.ResolvedAt == nil {
+			now := time.Now()
+			c.ResolvedAt, c.ResolvedByUserID = &now, &opts.ResolverUserID
+		} else if !*opts.Resolve {
+			c.ResolvedAt, c.ResolvedByUserID = nil, nil
+		}
+		return c, nil
+	}
+	var events []types.DiscussionThreadEventKind
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		events = append(events, newEvent.Kind)
+		return newEvent, nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	r := &discussionsMutationResolver{}
+	args := func(commentID int64) *struct{ CommentID graphql.ID } {
+		return &struct{ CommentID graphql.ID }{CommentID: marshalDiscussionCommentID(commentID)}
+	}
+
+	comment, err := r.ResolveCommentThread(ctx, args(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !comment.IsResolved() {
+		t.Error("expected comment to be resolved")
+	}
+	// Resolving a resolved comment is not recorded again.
+	if _, err := r.ResolveCommentThread(ctx, args(1)); err != nil {
+		t.Fatal(err)
+	}
+	comment, err = r.UnresolveCommentThread(ctx, args(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.IsResolved() {
+		t.Error("expected comment to be unresolved")
+	}
+	want := []types.DiscussionThreadEventKind{types.DiscussionThreadEventCommentResolved, types.DiscussionThreadEventCommentUnresolved}
+	if len(events) != len(want) || events[0] != want[0] || events[1] != want[1] {
+		t.Errorf("got events %v, want %v", events, want)
+	}
+
+	if _, err := r.ResolveCommentThread(ctx, args(3)); err == nil {
+		t.Error("expected error resolving a reply")
+	}
+}
@@ -0,0 +1,91 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+type discussionCommentSuggestionResolver struct {
+	s *types.DiscussionCommentSuggestion
+}
+
+func (r *discussionCommentSuggestionResolver) Contents() string { return r.s.Contents }
+
+func (r *discussionCommentSuggestionResolver) AppliedAt() *DateTime {
+	if r.s.AppliedAt == nil {
+		return nil
+	}
+	return &DateTime{Time: *r.s.AppliedAt}
+}
+
+func (r *discussionCommentSuggestionResolver) AppliedBy(ctx context.Context) (*UserResolver, error) {
+	if r.s.AppliedByUserID == nil {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, *r.s.AppliedByUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *discussionCommentSuggestionResolver) AppliedCommitOID() *GitObjectID {
+	if r.s.AppliedCommitID == nil {
+		return nil
+	}
+	oid := GitObjectID(*r.s.AppliedCommitID)
+	return &oid
+}
+
+func (r *discussionCommentResolver) Suggestion(ctx context.Context) (*discussionCommentSuggestionResolver, error) {
+	suggestion, err := db.DiscussionCommentSuggestions.Get(ctx, r.c.ID)
+	if err != nil {
+		if _, ok := err.(*db.ErrSuggestionNotFound); ok {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "DiscussionCommentSuggestions.Get")
+	}
+	return &discussionCommentSuggestionResolver{s: suggestion}, nil
+}
+
+func (r *discussionsMutationResolver) ApplyCommentSuggestion(ctx context.Context, args *struct {
+	CommentID graphql.ID
+}) (*discussionCommentResolver, error) {
+	// 🚨 SECURITY: Only site admins can apply suggestions, because the commit
+	// is pushed to the code host with the site's credentials rather than the
+	// user's.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	commentID, err := unmarshalDiscussionCommentID(args.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := db.DiscussionComments.Get(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, comment.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := discussions.ApplySuggestion(ctx, thread, comment, currentUser.user); err != nil {
+		return nil, err
+	}
+	return &discussionCommentResolver{c: comment}, nil
+}
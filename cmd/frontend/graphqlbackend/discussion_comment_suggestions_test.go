@@ -0,0 +1,58 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionComment_Suggestion(t *testing.T) {
+	resetMocks()
+	db.Mocks.DiscussionCommentSuggestions.Get = func(_ context.Context, commentID int64) (*types.DiscussionCommentSuggestion, error) {
+		if commentID != 1 {
+			return nil, &db.ErrSuggestionNotFound{CommentID: commentID}
+		}
+		commitID := "c"
+		return &types.DiscussionCommentSuggestion{CommentID: commentID, Contents: "x", AppliedCommitID: &commitID}, nil
+	}
+	ctx := context.Background()
+
+	suggestion, err := (&discussionCommentResolver{c: &types.DiscussionComment{ID: 1}}).Suggestion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suggestion == nil || suggestion.Contents() != "x" {
+		t.Fatalf("got suggestion %+v, want contents %q", suggestion, "x")
+	}
+	if oid := suggestion.AppliedCommitOID(); oid == nil || *oid != "c" {
+		t.Errorf("got applied commit %v, want %q", oid, "c")
+	}
+
+	suggestion, err = (&discussionCommentResolver{c: &types.DiscussionComment{ID: 2}}).Suggestion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suggestion != nil {
+		t.Errorf("got suggestion %+v, want nil", suggestion)
+	}
+}
+
+func TestDiscussionsMutations_ApplyCommentSuggestion(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1}, nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	_, err := (&discussionsMutationResolver{}).ApplyCommentSuggestion(ctx, &struct{ CommentID graphql.ID }{
+		CommentID: marshalDiscussionCommentID(1),
+	})
+	if err != backend.ErrMustBeSiteAdmin {
+		t.Errorf("got error %v, want %v", err, backend.ErrMustBeSiteAdmin)
+	}
+}
@@ -14,9 +14,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/ratelimit"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
-	"github.com/sourcegraph/sourcegraph/internal/markdown"
 )
 
 func marshalDiscussionCommentID(dbID int64) graphql.ID {
@@ -50,6 +50,8 @@ func discussionCommentByID(ctx context.Context, id graphql.ID) (*discussionComme
 
 type discussionCommentResolver struct {
 	c *types.DiscussionComment
+
+	authors *discussionUsersLoader // may be nil (see newDiscussionCommentResolvers)
 }
 
 func (r *discussionCommentResolver) ID() graphql.ID {
@@ -69,10 +71,14 @@ func (r *discussionCommentResolver) Thread(ctx context.Context) (*discussionThre
 }
 
 func (r *discussionCommentResolver) Author(ctx context.Context) (*UserResolver, error) {
-	return UserByIDInt32(ctx, r.c.AuthorUserID)
+	return discussionUserByID(ctx, r.authors, r.c.AuthorUserID)
 }
 
 func (r *discussionCommentResolver) Contents(ctx context.Context) (string, error) {
+	// 🚨 SECURITY: Only site admins can read the contents of hidden comments.
+	if r.c.HiddenAt != nil && backend.CheckCurrentUserIsSiteAdmin(ctx) != nil {
+		return hiddenCommentContents, nil
+	}
 	if strings.TrimSpace(r.c.Contents) != "" {
 		return r.c.Contents, nil
 	}
@@ -83,12 +89,44 @@ func (r *discussionCommentResolver) Contents(ctx context.Context) (string, error
 	return thread.Title, nil
 }
 
-func (r *discussionCommentResolver) HTML(ctx context.Context, args *struct{ Options *markdownOptions }) (string, error) {
+func (r *discussionCommentResolver) HTML(ctx context.Context, args *struct {
+	Options      *markdownOptions
+	IsLightTheme bool
+}) (string, error) {
+	contents, err := r.Contents(ctx)
+	if err != nil {
+		return "", err
+	}
+	contents, _ = discussions.TruncateCommentContents(contents)
+	return r.renderHTML(ctx, contents, args.IsLightTheme)
+}
+
+func (r *discussionCommentResolver) IsTruncated(ctx context.Context) (bool, error) {
+	contents, err := r.Contents(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, truncated := discussions.TruncateCommentContents(contents)
+	return truncated, nil
+}
+
+func (r *discussionCommentResolver) FullHTML(ctx context.Context, args *struct {
+	Options      *markdownOptions
+	IsLightTheme bool
+}) (string, error) {
 	contents, err := r.Contents(ctx)
 	if err != nil {
 		return "", err
 	}
-	return markdown.Render(contents), nil
+	return r.renderHTML(ctx, contents, args.IsLightTheme)
+}
+
+func (r *discussionCommentResolver) renderHTML(ctx context.Context, contents string, isLightTheme bool) (string, error) {
+	thread, err := db.DiscussionThreads.Get(ctx, r.c.ThreadID)
+	if err != nil {
+		return "", errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	return discussions.RenderCommentHTML(ctx, thread, contents, isLightTheme)
 }
 
 func (r *discussionCommentResolver) InlineURL(ctx context.Context) (*string, error) {
@@ -149,11 +187,8 @@ func (r *discussionCommentResolver) CanClearReports(ctx context.Context) bool {
 }
 
 func (r *discussionCommentResolver) CanDelete(ctx context.Context) bool {
-	// Only site admins can delete discussion comments.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
-		return false
-	}
-	return true
+	can, _ := r.ViewerCanDelete(ctx)
+	return can
 }
 
 func (*schemaResolver) DiscussionComments(ctx context.Context, args *struct {
@@ -169,7 +204,7 @@ func (*schemaResolver) DiscussionComments(ctx context.Context, args *struct {
 	// inherently, the GraphQL API) is private.
 
 	opt := &db.DiscussionCommentsListOptions{}
-	args.ConnectionArgs.Set(&opt.LimitOffset)
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
 	if args.AuthorUserID != nil {
 		userID, err := UnmarshalUserID(*args.AuthorUserID)
 		if err != nil {
@@ -188,7 +223,7 @@ func checkSignedInAndEmailVerified(ctx context.Context) (*UserResolver, error) {
 		return nil, err
 	}
 	if currentUser == nil {
-		return nil, errors.New("no current user")
+		return nil, discussions.ErrNoCurrentUser
 	}
 	emails, err := currentUser.Emails(ctx)
 	if err != nil {
@@ -199,12 +234,15 @@ func checkSignedInAndEmailVerified(ctx context.Context) (*UserResolver, error) {
 			return currentUser, nil
 		}
 	}
-	return nil, errors.New("account email must be verified to perform this action")
+	return nil, errEmailNotVerified
 }
 
+var errEmailNotVerified = discussions.NewError(discussions.ErrorCodeForbidden, "account email must be verified to perform this action")
+
 func (r *discussionsMutationResolver) AddCommentToThread(ctx context.Context, args *struct {
-	ThreadID graphql.ID
-	Contents string
+	ThreadID   graphql.ID
+	Contents   string
+	Suggestion *string
 }) (*discussionThreadResolver, error) {
 	// 🚨 SECURITY: Only signed in users with a verified email may add comments
 	// to a discussion thread.
@@ -228,24 +266,50 @@ func (r *discussionsMutationResolver) AddCommentToThread(ctx context.Context, ar
 		return nil, err
 	}
 
-	updatedThread, err := discussions.InsecureAddCommentToThread(ctx, &types.DiscussionComment{
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := discussions.CheckCanComment(ctx, thread); err != nil {
+		return nil, err
+	}
+	if args.Suggestion != nil {
+		if err := discussions.CheckCanSuggestChanges(thread); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ratelimit.CheckCanAddComment(currentUser.user.ID); err != nil {
+		return nil, err
+	}
+	newComment := &types.DiscussionComment{
 		ThreadID:     threadID,
 		AuthorUserID: currentUser.user.ID,
 		Contents:     args.Contents,
-	})
+	}
+	updatedThread, err := discussions.InsecureAddCommentToThread(ctx, newComment)
 	if err != nil {
 		return nil, errors.Wrap(err, "AddCommentToThread")
 	}
+	if args.Suggestion != nil {
+		if _, err := db.DiscussionCommentSuggestions.Create(ctx, &types.DiscussionCommentSuggestion{
+			CommentID: newComment.ID,
+			Contents:  *args.Suggestion,
+		}); err != nil {
+			return nil, errors.Wrap(err, "DiscussionCommentSuggestions.Create")
+		}
+	}
 	return &discussionThreadResolver{t: updatedThread}, nil
 }
 
 func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *struct {
 	Input *struct {
-		CommentID    graphql.ID
-		Contents     *string
-		Delete       *bool
-		Report       *string
-		ClearReports *bool
+		CommentID         graphql.ID
+		Contents          *string
+		Delete            *bool
+		Report            *string
+		ClearReports      *bool
+		ExpectedUpdatedAt *DateTime
 	}
 }) (*discussionThreadResolver, error) {
 	commentID, err := unmarshalDiscussionThreadID(args.Input.CommentID)
@@ -259,13 +323,27 @@ func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *s
 		return nil, err
 	}
 	if currentUser == nil {
-		return nil, errors.New("no current user")
+		return nil, discussions.ErrNoCurrentUser
+	}
+
+	// Resolve the thread ID of the comment first so we can return the updated
+	// thread later. We must do this now because the comment may be deleted
+	// below (Update may return nil).
+	comment, err := db.DiscussionComments.Get(ctx, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionComments.Get")
 	}
+	threadID := comment.ThreadID
 
 	var delete bool
 	if args.Input.Delete != nil && *args.Input.Delete {
-		// 🚨 SECURITY: Only site admins can delete discussion comments.
-		if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		// 🚨 SECURITY: Only site admins and maintainers can delete discussion
+		// comments.
+		thread, err := db.DiscussionThreads.Get(ctx, threadID)
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionThreads.Get")
+		}
+		if err := discussions.CheckCanDeleteComment(ctx, thread, comment); err != nil {
 			return nil, err
 		}
 		delete = *args.Input.Delete
@@ -293,31 +371,25 @@ func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *s
 
 	if args.Input.Contents != nil {
 		// 🚨 SECURITY: Only site admins and the comment author can update the contents.
-		comment, err := db.DiscussionComments.Get(ctx, commentID)
-		if err != nil {
+		if err := discussions.CheckCanUpdateComment(ctx, comment); err != nil {
 			return nil, err
 		}
-		err = backend.CheckSiteAdminOrSameUser(ctx, comment.AuthorUserID)
-		if err != nil {
+		if err := discussions.ValidateCommentLength("contents", *args.Input.Contents); err != nil {
 			return nil, err
 		}
 	}
 
-	// Resolve the thread ID of the comment first so we can return the updated
-	// thread later. We must do this now because the comment may be deleted
-	// below (Update may return nil).
-	comment, err := db.DiscussionComments.Get(ctx, commentID)
-	if err != nil {
-		return nil, errors.Wrap(err, "DiscussionComments.Get")
-	}
-	threadID := comment.ThreadID
-
-	updatedComment, err := db.DiscussionComments.Update(ctx, commentID, &db.DiscussionCommentsUpdateOptions{
+	opts := &db.DiscussionCommentsUpdateOptions{
 		Contents:     args.Input.Contents,
+		EditorUserID: currentUser.user.ID,
 		Delete:       delete,
 		Report:       args.Input.Report,
 		ClearReports: clearReports,
-	})
+	}
+	if args.Input.ExpectedUpdatedAt != nil {
+		opts.ExpectedUpdatedAt = &args.Input.ExpectedUpdatedAt.Time
+	}
+	updatedComment, err := db.DiscussionComments.Update(ctx, commentID, opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "DiscussionComments.Update")
 	}
@@ -332,6 +404,10 @@ func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *s
 		}
 		discussions.NotifyCommentReported(currentUser.user, thread, c)
 	}
+	if args.Input.Contents != nil && updatedComment != nil {
+		discussions.UpdateCommentMentions(ctx, thread, updatedComment)
+		discussions.StoreCommentReferences(ctx, updatedComment)
+	}
 	return &discussionThreadResolver{t: thread}, nil
 }
 
@@ -342,6 +418,10 @@ func (r *discussionsMutationResolver) UpdateComment(ctx context.Context, args *s
 type discussionCommentsConnectionResolver struct {
 	opt *db.DiscussionCommentsListOptions
 
+	// totalCount, if non-nil, is the already known total count of comments
+	// matching opt.
+	totalCount *int32
+
 	// cache results because they are used by multiple fields
 	once     sync.Once
 	comments []*types.DiscussionComment
@@ -367,15 +447,13 @@ func (r *discussionCommentsConnectionResolver) Nodes(ctx context.Context) ([]*di
 	if err != nil {
 		return nil, err
 	}
-
-	var l []*discussionCommentResolver
-	for _, comment := range comments {
-		l = append(l, &discussionCommentResolver{c: comment})
-	}
-	return l, nil
+	return newDiscussionCommentResolvers(comments), nil
 }
 
 func (r *discussionCommentsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	if r.totalCount != nil {
+		return *r.totalCount, nil
+	}
 	withoutLimit := *r.opt
 	withoutLimit.LimitOffset = nil
 	count, err := db.DiscussionComments.Count(ctx, &withoutLimit)
@@ -8,6 +8,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestDiscussionComment_Get(t *testing.T) {
@@ -83,6 +85,17 @@ func TestDiscussionsMutations_UpdateComment(t *testing.T) {
 		}
 		return &types.DiscussionComment{ThreadID: wantThreadID, Contents: wantContents}, nil
 	}
+	db.Mocks.DiscussionCommentMentions.List = func(context.Context, int64) ([]*types.DiscussionCommentMention, error) {
+		return nil, nil
+	}
+	var mentionsStored bool
+	db.Mocks.DiscussionCommentMentions.Set = func(context.Context, int64, []int32, []int32) error {
+		mentionsStored = true
+		return nil
+	}
+	db.Mocks.DiscussionThreadReferences.Set = func(context.Context, int64, int64, []int64) ([]int64, error) {
+		return nil, nil
+	}
 
 	gqltesting.RunTests(t, []*gqltesting.Test{
 		{
@@ -109,4 +122,46 @@ func TestDiscussionsMutations_UpdateComment(t *testing.T) {
                         `,
 		},
 	})
+	if !mentionsStored {
+		t.Error("expected the comment mentions to be stored")
+	}
+}
+
+func TestDiscussionComment_Truncated(t *testing.T) {
+	resetMocks()
+	defer conf.Mock(nil)
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{MaxCommentLength: 8},
+	}})
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID}, nil
+	}
+	ctx := context.Background()
+	r := &discussionCommentResolver{c: &types.DiscussionComment{ID: 1, ThreadID: 2, Contents: "first\nsecond"}}
+	htmlArgs := &struct {
+		Options      *markdownOptions
+		IsLightTheme bool
+	}{}
+
+	truncated, err := r.IsTruncated(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !truncated {
+		t.Error("got isTruncated false, want true")
+	}
+	html, err := r.HTML(ctx, htmlArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p>first</p>\n"; html != want {
+		t.Errorf("got html %q, want %q", html, want)
+	}
+	fullHTML, err := r.FullHTML(ctx, htmlArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p>first\nsecond</p>\n"; fullHTML != want {
+		t.Errorf("got fullHTML %q, want %q", fullHTML, want)
+	}
 }
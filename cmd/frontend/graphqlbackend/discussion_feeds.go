@@ -0,0 +1,163 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func marshalDiscussionFeedID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionFeed", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionFeedID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+// discussionFeedByID looks up a DiscussionFeed by its GraphQL ID.
+func discussionFeedByID(ctx context.Context, id graphql.ID) (*discussionFeedResolver, error) {
+	dbID, err := unmarshalDiscussionFeedID(id)
+	if err != nil {
+		return nil, err
+	}
+	token, err := db.DiscussionFeedTokens.GetByID(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only site admins and the feed's user can view a feed.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, token.UserID); err != nil {
+		return nil, err
+	}
+	return &discussionFeedResolver{t: token}, nil
+}
+
+// discussionFeedResolver resolves a discussion feed.
+//
+// 🚨 SECURITY: The feed's URL embeds its token's value, which is only known
+// when the feed is created (see CreateFeed).
+type discussionFeedResolver struct {
+	t *types.DiscussionFeedToken
+}
+
+func (r *discussionFeedResolver) ID() graphql.ID {
+	return marshalDiscussionFeedID(r.t.ID)
+}
+
+func (r *discussionFeedResolver) Kind() string {
+	return strings.ToUpper(string(r.t.Kind))
+}
+
+func (r *discussionFeedResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	if r.t.RepoID == nil {
+		return nil, nil
+	}
+	return RepositoryByIDInt32(ctx, *r.t.RepoID)
+}
+
+func (r *discussionFeedResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.t.CreatedAt}
+}
+
+func (r *discussionFeedResolver) LastUsedAt() *DateTime {
+	return DateTimeOrNil(r.t.LastUsedAt)
+}
+
+func (r *UserResolver) DiscussionFeeds(ctx context.Context) ([]*discussionFeedResolver, error) {
+	// 🚨 SECURITY: Only site admins and the user can list the user's feeds.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return nil, err
+	}
+	tokens, err := db.DiscussionFeedTokens.List(ctx, r.user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionFeedTokens.List")
+	}
+	feeds := make([]*discussionFeedResolver, len(tokens))
+	for i, token := range tokens {
+		feeds[i] = &discussionFeedResolver{t: token}
+	}
+	return feeds, nil
+}
+
+// createDiscussionFeedResultResolver resolves the result of the createFeed
+// mutation.
+type createDiscussionFeedResultResolver struct {
+	feed *discussionFeedResolver
+	url  string
+}
+
+func (r *createDiscussionFeedResultResolver) Feed() *discussionFeedResolver { return r.feed }
+
+func (r *createDiscussionFeedResultResolver) URL() string { return r.url }
+
+func (r *discussionsMutationResolver) CreateFeed(ctx context.Context, args *struct {
+	Kind       string
+	Repository *graphql.ID
+}) (*createDiscussionFeedResultResolver, error) {
+	// 🚨 SECURITY: Only signed in users may create feeds, and only for
+	// themselves.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+
+	token := &types.DiscussionFeedToken{
+		UserID: currentUser.user.ID,
+		Kind:   types.DiscussionFeedKind(strings.ToLower(args.Kind)),
+	}
+	if (token.Kind == types.DiscussionFeedRepository) != (args.Repository != nil) {
+		return nil, fmt.Errorf("repository must be given if and only if kind is %s", args.Kind)
+	}
+	if args.Repository != nil {
+		// 🚨 SECURITY: repositoryByID checks that the user can view the
+		// repository.
+		repo, err := repositoryByID(ctx, *args.Repository)
+		if err != nil {
+			return nil, err
+		}
+		token.RepoID = &repo.repo.ID
+	}
+	token, value, err := db.DiscussionFeedTokens.Create(ctx, token)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionFeedTokens.Create")
+	}
+	url, err := discussions.URLToFeed(value)
+	if err != nil {
+		return nil, err
+	}
+	return &createDiscussionFeedResultResolver{
+		feed: &discussionFeedResolver{t: token},
+		url:  url.String(),
+	}, nil
+}
+
+func (r *discussionsMutationResolver) RevokeFeed(ctx context.Context, args *struct {
+	Feed graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: discussionFeedByID checks that the current user is a site
+	// admin or the feed's user.
+	feed, err := discussionFeedByID(ctx, args.Feed)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.DiscussionFeedTokens.Revoke(ctx, feed.t.ID); err != nil {
+		return nil, errors.Wrap(err, "DiscussionFeedTokens.Revoke")
+	}
+	return &EmptyResponse{}, nil
+}
@@ -0,0 +1,74 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionsMutations_CreateFeed(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.DiscussionFeedTokens.Create = func(_ context.Context, newToken *types.DiscussionFeedToken) (*types.DiscussionFeedToken, string, error) {
+		if newToken.UserID != 1 {
+			t.Errorf("got UserID %d, want 1", newToken.UserID)
+		}
+		newToken.ID = 2
+		return newToken, "s3cr3t", nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	type createFeedArgs = struct {
+		Kind       string
+		Repository *graphql.ID
+	}
+
+	result, err := (&discussionsMutationResolver{}).CreateFeed(ctx, &createFeedArgs{Kind: "ASSIGNED"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := result.Feed().Kind(), "ASSIGNED"; got != want {
+		t.Errorf("got kind %q, want %q", got, want)
+	}
+	if want := "/-/discussions/feed?token=s3cr3t"; !strings.HasSuffix(result.URL(), want) {
+		t.Errorf("got URL %q, want suffix %q", result.URL(), want)
+	}
+
+	if _, err := (&discussionsMutationResolver{}).CreateFeed(ctx, &createFeedArgs{Kind: "REPOSITORY"}); err == nil {
+		t.Error("expected error creating repository feed without repository")
+	}
+	repo := graphql.ID("r")
+	if _, err := (&discussionsMutationResolver{}).CreateFeed(ctx, &createFeedArgs{Kind: "MENTIONED", Repository: &repo}); err == nil {
+		t.Error("expected error creating mentioned feed with repository")
+	}
+}
+
+func TestDiscussionsMutations_RevokeFeed(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return &types.User{ID: id}, nil }
+	db.Mocks.DiscussionFeedTokens.GetByID = func(_ context.Context, id int64) (*types.DiscussionFeedToken, error) {
+		// Feed 3 belongs to another user.
+		return &types.DiscussionFeedToken{ID: id, UserID: map[int64]int32{2: 1, 3: 5}[id], Kind: types.DiscussionFeedAssigned}, nil
+	}
+	var revoked []int64
+	db.Mocks.DiscussionFeedTokens.Revoke = func(_ context.Context, id int64) error {
+		revoked = append(revoked, id)
+		return nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	if _, err := (&discussionsMutationResolver{}).RevokeFeed(ctx, &struct{ Feed graphql.ID }{Feed: marshalDiscussionFeedID(3)}); err == nil {
+		t.Error("expected error revoking another user's feed")
+	}
+	if _, err := (&discussionsMutationResolver{}).RevokeFeed(ctx, &struct{ Feed graphql.ID }{Feed: marshalDiscussionFeedID(2)}); err != nil {
+		t.Fatal(err)
+	}
+	if len(revoked) != 1 || revoked[0] != 2 {
+		t.Errorf("got revoked %v, want [2]", revoked)
+	}
+}
@@ -0,0 +1,280 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func marshalDiscussionLabelID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionLabel", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionLabelID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+// discussionLabelByID looks up a DiscussionLabel by its GraphQL ID.
+func discussionLabelByID(ctx context.Context, id graphql.ID) (*discussionLabelResolver, error) {
+	dbID, err := unmarshalDiscussionLabelID(id)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: No authentication is required to get a discussion label. Discussion labels are
+	// public unless the Sourcegraph instance itself (and inherently, the GraphQL API) is private.
+	label, err := db.DiscussionLabels.Get(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionLabelResolver{l: label}, nil
+}
+
+type discussionLabelResolver struct {
+	l *types.DiscussionLabel
+}
+
+func (r *discussionLabelResolver) ID() graphql.ID {
+	return marshalDiscussionLabelID(r.l.ID)
+}
+
+func (r *discussionLabelResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	return RepositoryByIDInt32(ctx, r.l.RepoID)
+}
+
+func (r *discussionLabelResolver) Name() string { return r.l.Name }
+
+func (r *discussionLabelResolver) Color() string { return r.l.Color }
+
+func (r *discussionLabelResolver) Description() *string { return r.l.Description }
+
+func (r *discussionLabelResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.l.CreatedAt}
+}
+
+func (r *discussionLabelResolver) UpdatedAt() DateTime {
+	return DateTime{Time: r.l.UpdatedAt}
+}
+
+func (r *discussionsMutationResolver) CreateLabel(ctx context.Context, args *struct {
+	Input *struct {
+		Repository  graphql.ID
+		Name        string
+		Color       string
+		Description *string
+	}
+}) (*discussionLabelResolver, error) {
+	// 🚨 SECURITY: Only site admins can create discussion labels.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	repo, err := repositoryByID(ctx, args.Input.Repository)
+	if err != nil {
+		return nil, err
+	}
+	label, err := db.DiscussionLabels.Create(ctx, &types.DiscussionLabel{
+		RepoID:      repo.repo.ID,
+		Name:        args.Input.Name,
+		Color:       args.Input.Color,
+		Description: args.Input.Description,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionLabels.Create")
+	}
+	return &discussionLabelResolver{l: label}, nil
+}
+
+func (r *discussionsMutationResolver) AddLabelsToThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+	Labels   []graphql.ID
+}) (*discussionThreadResolver, error) {
+	thread, labelIDs, err := discussionThreadLabelsMutationArgs(ctx, args.ThreadID, args.Labels)
+	if err != nil {
+		return nil, err
+	}
+	added, err := db.DiscussionLabels.AddToThread(ctx, thread.ID, labelIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionLabels.AddToThread")
+	}
+	logDiscussionThreadLabelEvents(ctx, thread.ID, types.DiscussionThreadEventLabelAdded, added)
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) RemoveLabelsFromThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+	Labels   []graphql.ID
+}) (*discussionThreadResolver, error) {
+	thread, labelIDs, err := discussionThreadLabelsMutationArgs(ctx, args.ThreadID, args.Labels)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := db.DiscussionLabels.RemoveFromThread(ctx, thread.ID, labelIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionLabels.RemoveFromThread")
+	}
+	logDiscussionThreadLabelEvents(ctx, thread.ID, types.DiscussionThreadEventLabelRemoved, removed)
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+// discussionThreadLabelsMutationArgs resolves the thread and label IDs passed
+// to the addLabelsToThread and removeLabelsFromThread mutations, checking that
+// the current user may change the thread's labels and that all labels belong
+// to the thread's target repository.
+func discussionThreadLabelsMutationArgs(ctx context.Context, threadGQLID graphql.ID, labelGQLIDs []graphql.ID) (*types.DiscussionThread, []int64, error) {
+	threadID, err := unmarshalDiscussionThreadID(threadGQLID)
+	if err != nil {
+		return nil, nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins, the thread author and triagers can
+	// change the labels on a thread.
+	if err := discussions.CheckCanTriageThread(ctx, thread); err != nil {
+		return nil, nil, err
+	}
+
+	labelIDs, err := discussionThreadLabelIDs(ctx, thread, labelGQLIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return thread, labelIDs, nil
+}
+
+// discussionThreadLabelIDs resolves the label IDs, checking that all labels
+// belong to the thread's target repository.
+func discussionThreadLabelIDs(ctx context.Context, thread *types.DiscussionThread, labelGQLIDs []graphql.ID) ([]int64, error) {
+	labelIDs := make([]int64, 0, len(labelGQLIDs))
+	for _, id := range labelGQLIDs {
+		labelID, err := unmarshalDiscussionLabelID(id)
+		if err != nil {
+			return nil, err
+		}
+		labelIDs = append(labelIDs, labelID)
+	}
+	if len(labelIDs) == 0 {
+		return labelIDs, nil
+	}
+	if thread.TargetRepo == nil {
+		return nil, errors.New("labels can only be added to threads with a repository target")
+	}
+	labels, err := db.DiscussionLabels.List(ctx, &db.DiscussionLabelsListOptions{
+		LabelIDs: labelIDs,
+		RepoID:   thread.TargetRepo.RepoID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionLabels.List")
+	}
+	found := make(map[int64]bool, len(labels))
+	for _, label := range labels {
+		found[label.ID] = true
+	}
+	for i, labelID := range labelIDs {
+		if !found[labelID] {
+			return nil, fmt.Errorf("label %s not found in the thread's repository", labelGQLIDs[i])
+		}
+	}
+	return labelIDs, nil
+}
+
+// logDiscussionThreadLabelEvents records on the thread's timeline that the
+// current user added or removed the labels.
+func logDiscussionThreadLabelEvents(ctx context.Context, threadID int64, kind types.DiscussionThreadEventKind, labelIDs []int64) {
+	actorUserID := actor.FromContext(ctx).UID
+	for i := range labelIDs {
+		discussions.LogThreadEvent(ctx, threadID, actorUserID, kind, types.DiscussionThreadEventData{
+			LabelID: &labelIDs[i],
+		})
+	}
+}
+
+func (d *discussionThreadResolver) Labels(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionLabelsConnectionResolver {
+	opt := &db.DiscussionLabelsListOptions{ThreadID: d.t.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionLabelsConnectionResolver{opt: opt}
+}
+
+func (r *RepositoryResolver) DiscussionLabels(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionLabelsConnectionResolver {
+	opt := &db.DiscussionLabelsListOptions{RepoID: r.repo.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionLabelsConnectionResolver{opt: opt}
+}
+
+// discussionLabelsConnectionResolver resolves a list of discussion labels.
+//
+// 🚨 SECURITY: When instantiating an discussionLabelsConnectionResolver
+// value, the caller MUST check permissions.
+type discussionLabelsConnectionResolver struct {
+	opt *db.DiscussionLabelsListOptions
+
+	// cache results because they are used by multiple fields
+	once   sync.Once
+	labels []*types.DiscussionLabel
+	err    error
+}
+
+func (r *discussionLabelsConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionLabel, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.labels, r.err = db.DiscussionLabels.List(ctx, &opt2)
+	})
+	return r.labels, r.err
+}
+
+func (r *discussionLabelsConnectionResolver) Nodes(ctx context.Context) ([]*discussionLabelResolver, error) {
+	labels, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(labels) > r.opt.Limit {
+		labels = labels[:r.opt.Limit]
+	}
+
+	var l []*discussionLabelResolver
+	for _, label := range labels {
+		l = append(l, &discussionLabelResolver{l: label})
+	}
+	return l, nil
+}
+
+func (r *discussionLabelsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionLabels.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionLabelsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	labels, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(labels) > r.opt.Limit), nil
+}
@@ -0,0 +1,103 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionsMutations_AddLabelsToThread(t *testing.T) {
+	const (
+		wantThreadID = 123
+		wantRepoID   = api.RepoID(1)
+	)
+	var events []*types.DiscussionThreadEvent
+	setup := func() *[]int64 {
+		resetMocks()
+		db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+			if threadID != wantThreadID {
+				t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+			}
+			return &types.DiscussionThread{
+				ID:           wantThreadID,
+				AuthorUserID: 1,
+				TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: wantRepoID},
+			}, nil
+		}
+		db.Mocks.DiscussionLabels.List = func(_ context.Context, opts *db.DiscussionLabelsListOptions) ([]*types.DiscussionLabel, error) {
+			if opts.RepoID != wantRepoID {
+				t.Errorf("got RepoID %v, want %v", opts.RepoID, wantRepoID)
+			}
+			// Only label 1 belongs to the thread's repository.
+			var labels []*types.DiscussionLabel
+			for _, id := range opts.LabelIDs {
+				if id == 1 {
+					labels = append(labels, &types.DiscussionLabel{ID: id, RepoID: wantRepoID})
+				}
+			}
+			return labels, nil
+		}
+		added := new([]int64)
+		db.Mocks.DiscussionLabels.AddToThread = func(_ context.Context, threadID int64, labelIDs []int64) ([]int64, error) {
+			*added = labelIDs
+			return labelIDs, nil
+		}
+		events = nil
+		db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+			events = append(events, newEvent)
+			return newEvent, nil
+		}
+		return added
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("label in thread repository", func(t *testing.T) {
+		added := setup()
+		_, err := (&discussionsMutationResolver{}).AddLabelsToThread(ctx, &struct {
+			ThreadID graphql.ID
+			Labels   []graphql.ID
+		}{
+			ThreadID: marshalDiscussionThreadID(wantThreadID),
+			Labels:   []graphql.ID{marshalDiscussionLabelID(1)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []int64{1}; !reflect.DeepEqual(*added, want) {
+			t.Errorf("got added labels %v, want %v", *added, want)
+		}
+		labelID := int64(1)
+		wantEvents := []*types.DiscussionThreadEvent{{
+			ThreadID:    wantThreadID,
+			ActorUserID: 1,
+			Kind:        types.DiscussionThreadEventLabelAdded,
+			Data:        types.DiscussionThreadEventData{LabelID: &labelID},
+		}}
+		if !reflect.DeepEqual(events, wantEvents) {
+			t.Errorf("got events %+v, want %+v", events, wantEvents)
+		}
+	})
+
+	t.Run("label in other repository", func(t *testing.T) {
+		added := setup()
+		_, err := (&discussionsMutationResolver{}).AddLabelsToThread(ctx, &struct {
+			ThreadID graphql.ID
+			Labels   []graphql.ID
+		}{
+			ThreadID: marshalDiscussionThreadID(wantThreadID),
+			Labels:   []graphql.ID{marshalDiscussionLabelID(1), marshalDiscussionLabelID(2)},
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if *added != nil {
+			t.Errorf("got added labels %v, want none", *added)
+		}
+	})
+}
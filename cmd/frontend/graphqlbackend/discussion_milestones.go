@@ -0,0 +1,392 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func marshalDiscussionMilestoneID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionMilestone", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionMilestoneID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+// discussionMilestoneByID looks up a DiscussionMilestone by its GraphQL ID.
+func discussionMilestoneByID(ctx context.Context, id graphql.ID) (*discussionMilestoneResolver, error) {
+	dbID, err := unmarshalDiscussionMilestoneID(id)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: No authentication is required to get a discussion milestone. Discussion
+	// milestones are public unless the Sourcegraph instance itself (and inherently, the GraphQL
+	// API) is private.
+	milestone, err := db.DiscussionMilestones.Get(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionMilestoneResolver{m: milestone}, nil
+}
+
+type discussionMilestoneResolver struct {
+	m *types.DiscussionMilestone
+}
+
+func (r *discussionMilestoneResolver) ID() graphql.ID {
+	return marshalDiscussionMilestoneID(r.m.ID)
+}
+
+func (r *discussionMilestoneResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	if r.m.RepoID == nil {
+		return nil, nil
+	}
+	return RepositoryByIDInt32(ctx, *r.m.RepoID)
+}
+
+func (r *discussionMilestoneResolver) Organization(ctx context.Context) (*OrgResolver, error) {
+	if r.m.OrgID == nil {
+		return nil, nil
+	}
+	return OrgByIDInt32(ctx, *r.m.OrgID)
+}
+
+func (r *discussionMilestoneResolver) Title() string { return r.m.Title }
+
+func (r *discussionMilestoneResolver) Description() *string { return r.m.Description }
+
+func (r *discussionMilestoneResolver) DueDate() *DateTime { return DateTimeOrNil(r.m.DueDate) }
+
+func (r *discussionMilestoneResolver) ClosedAt() *DateTime { return DateTimeOrNil(r.m.ClosedAt) }
+
+func (r *discussionMilestoneResolver) IsClosed() bool { return r.m.ClosedAt != nil }
+
+func (r *discussionMilestoneResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.m.CreatedAt}
+}
+
+func (r *discussionMilestoneResolver) UpdatedAt() DateTime {
+	return DateTime{Time: r.m.UpdatedAt}
+}
+
+func (r *discussionMilestoneResolver) Threads(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadsConnectionResolver {
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt := &db.DiscussionThreadsListOptions{MilestoneID: r.m.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadsConnectionResolver{opt: opt}
+}
+
+// checkCanAdministerDiscussionMilestone returns an error if the current user
+// may not change the milestone or use it on threads.
+func checkCanAdministerDiscussionMilestone(ctx context.Context, m *types.DiscussionMilestone) error {
+	// 🚨 SECURITY: Only site admins can administer repository milestones, and
+	// only organization members can administer organization milestones.
+	if m.OrgID != nil {
+		return backend.CheckOrgAccess(ctx, *m.OrgID)
+	}
+	return backend.CheckCurrentUserIsSiteAdmin(ctx)
+}
+
+func (r *discussionsMutationResolver) CreateMilestone(ctx context.Context, args *struct {
+	Input *struct {
+		Repository   *graphql.ID
+		Organization *graphql.ID
+		Title        string
+		Description  *string
+		DueDate      *DateTime
+	}
+}) (*discussionMilestoneResolver, error) {
+	milestone := &types.DiscussionMilestone{
+		Title:       args.Input.Title,
+		Description: args.Input.Description,
+	}
+	switch {
+	case (args.Input.Repository == nil) == (args.Input.Organization == nil):
+		return nil, errors.New("exactly one of repository and organization must be specified")
+	case args.Input.Repository != nil:
+		repo, err := repositoryByID(ctx, *args.Input.Repository)
+		if err != nil {
+			return nil, err
+		}
+		milestone.RepoID = &repo.repo.ID
+	default:
+		orgID, err := UnmarshalOrgID(*args.Input.Organization)
+		if err != nil {
+			return nil, err
+		}
+		milestone.OrgID = &orgID
+	}
+	if args.Input.DueDate != nil {
+		milestone.DueDate = &args.Input.DueDate.Time
+	}
+
+	// 🚨 SECURITY: Check that the current user may create milestones in the
+	// repository or organization.
+	if err := checkCanAdministerDiscussionMilestone(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	milestone, err := db.DiscussionMilestones.Create(ctx, milestone)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionMilestones.Create")
+	}
+	return &discussionMilestoneResolver{m: milestone}, nil
+}
+
+func (r *discussionsMutationResolver) UpdateMilestone(ctx context.Context, args *struct {
+	Input *struct {
+		MilestoneID  graphql.ID
+		Title        *string
+		Description  *string
+		DueDate      *DateTime
+		ClearDueDate *bool
+	}
+}) (*discussionMilestoneResolver, error) {
+	opts := &db.DiscussionMilestonesUpdateOptions{
+		Title:        args.Input.Title,
+		Description:  args.Input.Description,
+		ClearDueDate: args.Input.ClearDueDate != nil && *args.Input.ClearDueDate,
+	}
+	if args.Input.DueDate != nil {
+		opts.DueDate = &args.Input.DueDate.Time
+	}
+	return updateDiscussionMilestone(ctx, args.Input.MilestoneID, opts)
+}
+
+func (r *discussionsMutationResolver) CloseMilestone(ctx context.Context, args *struct {
+	MilestoneID graphql.ID
+}) (*discussionMilestoneResolver, error) {
+	close := true
+	return updateDiscussionMilestone(ctx, args.MilestoneID, &db.DiscussionMilestonesUpdateOptions{Close: &close})
+}
+
+func (r *discussionsMutationResolver) ReopenMilestone(ctx context.Context, args *struct {
+	MilestoneID graphql.ID
+}) (*discussionMilestoneResolver, error) {
+	close := false
+	return updateDiscussionMilestone(ctx, args.MilestoneID, &db.DiscussionMilestonesUpdateOptions{Close: &close})
+}
+
+func updateDiscussionMilestone(ctx context.Context, id graphql.ID, opts *db.DiscussionMilestonesUpdateOptions) (*discussionMilestoneResolver, error) {
+	milestoneID, err := unmarshalDiscussionMilestoneID(id)
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := db.DiscussionMilestones.Get(ctx, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check that the current user may update the milestone.
+	if err := checkCanAdministerDiscussionMilestone(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	milestone, err = db.DiscussionMilestones.Update(ctx, milestoneID, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionMilestones.Update")
+	}
+	return &discussionMilestoneResolver{m: milestone}, nil
+}
+
+func (r *discussionsMutationResolver) SetThreadMilestone(ctx context.Context, args *struct {
+	ThreadID  graphql.ID
+	Milestone *graphql.ID
+}) (*discussionThreadResolver, error) {
+	threadID, err := unmarshalDiscussionThreadID(args.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins, the thread author and triagers can
+	// change the milestone of a thread.
+	if err := discussions.CheckCanTriageThread(ctx, thread); err != nil {
+		return nil, err
+	}
+
+	var milestoneID *int64
+	if args.Milestone != nil {
+		id, err := unmarshalDiscussionMilestoneID(*args.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		milestone, err := db.DiscussionMilestones.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if milestone.RepoID != nil && (thread.TargetRepo == nil || thread.TargetRepo.RepoID != *milestone.RepoID) {
+			return nil, errors.New("milestone does not belong to the thread's repository")
+		}
+		// 🚨 SECURITY: Only organization members can add threads to an
+		// organization milestone.
+		if milestone.OrgID != nil {
+			if err := backend.CheckOrgAccess(ctx, *milestone.OrgID); err != nil {
+				return nil, err
+			}
+		}
+		milestoneID = &milestone.ID
+	}
+
+	if err := db.DiscussionMilestones.SetOnThread(ctx, thread.ID, milestoneID); err != nil {
+		return nil, errors.Wrap(err, "DiscussionMilestones.SetOnThread")
+	}
+	logDiscussionThreadMilestoneEvents(ctx, thread.ID, thread.MilestoneID, milestoneID)
+	thread, err = db.DiscussionThreads.Get(ctx, thread.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+// logDiscussionThreadMilestoneEvents records on the thread's timeline that the
+// current user moved the thread from the previous milestone to the new one.
+func logDiscussionThreadMilestoneEvents(ctx context.Context, threadID int64, previous, milestoneID *int64) {
+	if previous != nil && milestoneID != nil && *previous == *milestoneID {
+		return
+	}
+	actorUserID := actor.FromContext(ctx).UID
+	if previous != nil {
+		discussions.LogThreadEvent(ctx, threadID, actorUserID, types.DiscussionThreadEventDemilestoned, types.DiscussionThreadEventData{
+			MilestoneID: previous,
+		})
+	}
+	if milestoneID != nil {
+		discussions.LogThreadEvent(ctx, threadID, actorUserID, types.DiscussionThreadEventMilestoned, types.DiscussionThreadEventData{
+			MilestoneID: milestoneID,
+		})
+	}
+}
+
+func (d *discussionThreadResolver) Milestone(ctx context.Context) (*discussionMilestoneResolver, error) {
+	if d.t.MilestoneID == nil {
+		return nil, nil
+	}
+	milestone, err := db.DiscussionMilestones.Get(ctx, *d.t.MilestoneID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionMilestoneResolver{m: milestone}, nil
+}
+
+func (r *discussionThreadTimelineItemResolver) Milestone(ctx context.Context) (*discussionMilestoneResolver, error) {
+	if r.e.Data.MilestoneID == nil {
+		return nil, nil
+	}
+	milestone, err := db.DiscussionMilestones.Get(ctx, *r.e.Data.MilestoneID)
+	if err != nil {
+		if _, ok := err.(*db.ErrMilestoneNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &discussionMilestoneResolver{m: milestone}, nil
+}
+
+type discussionMilestonesArgs struct {
+	graphqlutil.ConnectionArgs
+	IncludeClosed bool
+}
+
+func (a *discussionMilestonesArgs) listOptions() *db.DiscussionMilestonesListOptions {
+	opt := &db.DiscussionMilestonesListOptions{}
+	if !a.IncludeClosed {
+		closed := false
+		opt.Closed = &closed
+	}
+	a.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return opt
+}
+
+func (r *RepositoryResolver) DiscussionMilestones(ctx context.Context, args *discussionMilestonesArgs) *discussionMilestonesConnectionResolver {
+	opt := args.listOptions()
+	opt.RepoID = r.repo.ID
+	return &discussionMilestonesConnectionResolver{opt: opt}
+}
+
+func (o *OrgResolver) DiscussionMilestones(ctx context.Context, args *discussionMilestonesArgs) *discussionMilestonesConnectionResolver {
+	opt := args.listOptions()
+	opt.OrgID = o.org.ID
+	return &discussionMilestonesConnectionResolver{opt: opt}
+}
+
+// discussionMilestonesConnectionResolver resolves a list of discussion
+// milestones.
+//
+// 🚨 SECURITY: When instantiating an discussionMilestonesConnectionResolver
+// value, the caller MUST check permissions.
+type discussionMilestonesConnectionResolver struct {
+	opt *db.DiscussionMilestonesListOptions
+
+	// cache results because they are used by multiple fields
+	once       sync.Once
+	milestones []*types.DiscussionMilestone
+	err        error
+}
+
+func (r *discussionMilestonesConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionMilestone, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.milestones, r.err = db.DiscussionMilestones.List(ctx, &opt2)
+	})
+	return r.milestones, r.err
+}
+
+func (r *discussionMilestonesConnectionResolver) Nodes(ctx context.Context) ([]*discussionMilestoneResolver, error) {
+	milestones, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(milestones) > r.opt.Limit {
+		milestones = milestones[:r.opt.Limit]
+	}
+
+	var l []*discussionMilestoneResolver
+	for _, milestone := range milestones {
+		l = append(l, &discussionMilestoneResolver{m: milestone})
+	}
+	return l, nil
+}
+
+func (r *discussionMilestonesConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionMilestones.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionMilestonesConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	milestones, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(milestones) > r.opt.Limit), nil
+}
@@ -0,0 +1,107 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionsMutations_SetThreadMilestone(t *testing.T) {
+	const (
+		wantThreadID = 123
+		wantRepoID   = api.RepoID(1)
+		otherRepoID  = api.RepoID(2)
+	)
+	var (
+		threadMilestoneID *int64
+		events            []*types.DiscussionThreadEvent
+	)
+	setup := func() {
+		resetMocks()
+		threadMilestoneID = nil
+		events = nil
+		db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+			return &types.DiscussionThread{
+				ID:           threadID,
+				AuthorUserID: 1,
+				TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: wantRepoID},
+				MilestoneID:  threadMilestoneID,
+			}, nil
+		}
+		db.Mocks.DiscussionMilestones.Get = func(milestoneID int64) (*types.DiscussionMilestone, error) {
+			// Milestone 1 belongs to the thread's repository, milestone 2
+			// to another repository.
+			repoID := wantRepoID
+			if milestoneID == 2 {
+				repoID = otherRepoID
+			}
+			return &types.DiscussionMilestone{ID: milestoneID, RepoID: &repoID}, nil
+		}
+		db.Mocks.DiscussionMilestones.SetOnThread = func(_ context.Context, threadID int64, milestoneID *int64) error {
+			if threadID != wantThreadID {
+				t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+			}
+			threadMilestoneID = milestoneID
+			return nil
+		}
+		db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+			events = append(events, newEvent)
+			return newEvent, nil
+		}
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	milestoneArg := func(id int64) *graphql.ID {
+		gqlID := marshalDiscussionMilestoneID(id)
+		return &gqlID
+	}
+
+	t.Run("milestone in thread repository", func(t *testing.T) {
+		setup()
+		thread, err := (&discussionsMutationResolver{}).SetThreadMilestone(ctx, &struct {
+			ThreadID  graphql.ID
+			Milestone *graphql.ID
+		}{ThreadID: marshalDiscussionThreadID(wantThreadID), Milestone: milestoneArg(1)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thread.t.MilestoneID == nil || *thread.t.MilestoneID != 1 {
+			t.Errorf("got thread milestone %v, want 1", thread.t.MilestoneID)
+		}
+		if len(events) != 1 || events[0].Kind != types.DiscussionThreadEventMilestoned {
+			t.Errorf("got events %+v, want one MILESTONED event", events)
+		}
+
+		// Clearing the milestone records that the thread was removed from it.
+		events = nil
+		if _, err := (&discussionsMutationResolver{}).SetThreadMilestone(ctx, &struct {
+			ThreadID  graphql.ID
+			Milestone *graphql.ID
+		}{ThreadID: marshalDiscussionThreadID(wantThreadID)}); err != nil {
+			t.Fatal(err)
+		}
+		if threadMilestoneID != nil {
+			t.Errorf("got thread milestone %v, want nil", *threadMilestoneID)
+		}
+		if len(events) != 1 || events[0].Kind != types.DiscussionThreadEventDemilestoned {
+			t.Errorf("got events %+v, want one DEMILESTONED event", events)
+		}
+	})
+
+	t.Run("milestone in other repository", func(t *testing.T) {
+		setup()
+		if _, err := (&discussionsMutationResolver{}).SetThreadMilestone(ctx, &struct {
+			ThreadID  graphql.ID
+			Milestone *graphql.ID
+		}{ThreadID: marshalDiscussionThreadID(wantThreadID), Milestone: milestoneArg(2)}); err == nil {
+			t.Fatal("expected error")
+		}
+		if threadMilestoneID != nil {
+			t.Error("milestone was set on thread")
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// hiddenCommentContents is shown instead of the contents of a hidden comment
+// to users who are not site admins.
+const hiddenCommentContents = "_This comment has been hidden by a moderator._"
+
+// formatReport returns the report message that is stored for a report by the
+// user.
+func formatReport(reason string, reportedBy *UserResolver) string {
+	return fmt.Sprintf(`"%s"\n\nreported by @%s`, reason, reportedBy.user.Username)
+}
+
+// checkCanReport returns an error if the current user cannot file reports.
+func checkCanReport(ctx context.Context) (*UserResolver, error) {
+	if dc := conf.Get().Discussions; dc != nil && !dc.AbuseProtection {
+		return nil, errors.New("cannot report; discussions.abuseProtection is disabled")
+	}
+	// 🚨 SECURITY: Only signed in users may file reports.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+	return currentUser, nil
+}
+
+func (r *discussionsMutationResolver) ReportComment(ctx context.Context, args *struct {
+	CommentID graphql.ID
+	Reason    string
+}) (*discussionCommentResolver, error) {
+	currentUser, err := checkCanReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commentID, err := unmarshalDiscussionCommentID(args.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	report := formatReport(args.Reason, currentUser)
+	comment, err := db.DiscussionComments.Update(ctx, commentID, &db.DiscussionCommentsUpdateOptions{Report: &report})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionComments.Update")
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, comment.ThreadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	discussions.NotifyCommentReported(currentUser.user, thread, comment)
+	return &discussionCommentResolver{c: comment}, nil
+}
+
+func (r *discussionsMutationResolver) ReportThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+	Reason   string
+}) (*discussionThreadResolver, error) {
+	currentUser, err := checkCanReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	threadID, err := unmarshalDiscussionThreadID(args.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	report := formatReport(args.Reason, currentUser)
+	thread, err := db.DiscussionThreads.Update(ctx, threadID, &db.DiscussionThreadsUpdateOptions{Report: &report})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Update")
+	}
+	discussions.NotifyThreadReported(currentUser.user, thread)
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) ClearThreadReports(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	// 🚨 SECURITY: Only site admins can clear reports.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	threadID, err := unmarshalDiscussionThreadID(args.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Update(ctx, threadID, &db.DiscussionThreadsUpdateOptions{ClearReports: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Update")
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) HideComment(ctx context.Context, args *struct {
+	CommentID graphql.ID
+}) (*discussionCommentResolver, error) {
+	return setDiscussionCommentHidden(ctx, args.CommentID, true)
+}
+
+func (r *discussionsMutationResolver) UnhideComment(ctx context.Context, args *struct {
+	CommentID graphql.ID
+}) (*discussionCommentResolver, error) {
+	return setDiscussionCommentHidden(ctx, args.CommentID, false)
+}
+
+func setDiscussionCommentHidden(ctx context.Context, commentGQLID graphql.ID, hide bool) (*discussionCommentResolver, error) {
+	// 🚨 SECURITY: Only site admins can hide and unhide comments.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commentID, err := unmarshalDiscussionCommentID(commentGQLID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := db.DiscussionComments.Update(ctx, commentID, &db.DiscussionCommentsUpdateOptions{
+		Hide:        &hide,
+		HiderUserID: currentUser.user.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionComments.Update")
+	}
+	return &discussionCommentResolver{c: comment}, nil
+}
+
+func (r *discussionCommentResolver) IsHidden() bool { return r.c.HiddenAt != nil }
+
+func (r *discussionCommentResolver) HiddenAt() *DateTime {
+	if r.c.HiddenAt == nil {
+		return nil
+	}
+	return &DateTime{Time: *r.c.HiddenAt}
+}
+
+func (r *discussionCommentResolver) HiddenBy(ctx context.Context) (*UserResolver, error) {
+	// 🚨 SECURITY: Only site admins can see who hid a comment.
+	if r.c.HiddenAt == nil || r.c.HiddenByUserID == nil || backend.CheckCurrentUserIsSiteAdmin(ctx) != nil {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, *r.c.HiddenByUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (d *discussionThreadResolver) Reports(ctx context.Context) []string {
+	// 🚨 SECURITY: Only site admins can read reports.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return []string{}
+	}
+	if dc := conf.Get().Discussions; dc != nil && !dc.AbuseProtection {
+		return []string{}
+	}
+	return d.t.Reports
+}
+
+func (d *discussionThreadResolver) CanReport(ctx context.Context) bool {
+	_, err := checkCanReport(ctx)
+	return err == nil
+}
+
+func (schemaResolver) DiscussionModerationQueue(ctx context.Context) (*discussionModerationQueueResolver, error) {
+	// 🚨 SECURITY: Only site admins can access the moderation queue.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return &discussionModerationQueueResolver{}, nil
+}
+
+// discussionModerationQueueResolver resolves the threads and comments that
+// need the attention of moderators.
+//
+// 🚨 SECURITY: Only site admins may access it.
+type discussionModerationQueueResolver struct{}
+
+func (discussionModerationQueueResolver) ReportedThreads(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadsConnectionResolver {
+	opt := &db.DiscussionThreadsListOptions{Reported: true}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadsConnectionResolver{opt: opt}
+}
+
+func (discussionModerationQueueResolver) ReportedComments(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionCommentsConnectionResolver {
+	opt := &db.DiscussionCommentsListOptions{Reported: true}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionCommentsConnectionResolver{opt: opt}
+}
+
+func (discussionModerationQueueResolver) HiddenComments(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionCommentsConnectionResolver {
+	hidden := true
+	opt := &db.DiscussionCommentsListOptions{Hidden: &hidden}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionCommentsConnectionResolver{opt: opt}
+}
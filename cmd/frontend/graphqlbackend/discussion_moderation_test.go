@@ -0,0 +1,105 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionsMutations_HideComment(t *testing.T) {
+	resetMocks()
+	const wantCommentID = 123
+	var hiddenAt *time.Time
+	var hiddenBy *int32
+	db.Mocks.DiscussionComments.Update = func(_ context.Context, commentID int64, opts *db.DiscussionCommentsUpdateOptions) (*types.DiscussionComment, error) {
+		if commentID != wantCommentID {
+			t.Errorf("got commentID %v, want %v", commentID, wantCommentID)
+		}
+		hiddenAt, hiddenBy = nil, nil
+		if *opts.Hide {
+			now := time.Now()
+			hiddenAt, hiddenBy = &now, &opts.HiderUserID
+		}
+		return &types.DiscussionComment{ID: commentID, Contents: "spam", HiddenAt: hiddenAt, HiddenByUserID: hiddenBy}, nil
+	}
+	args := &struct{ CommentID graphql.ID }{CommentID: marshalDiscussionCommentID(wantCommentID)}
+
+	t.Run("non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		if _, err := (&discussionsMutationResolver{}).HideComment(context.Background(), args); err == nil {
+			t.Error("expected error")
+		}
+		if hiddenAt != nil {
+			t.Error("comment was hidden by non-admin")
+		}
+
+		// The contents of hidden comments are not shown to non-admins.
+		now := time.Now()
+		comment := &discussionCommentResolver{c: &types.DiscussionComment{Contents: "spam", HiddenAt: &now}}
+		if contents, err := comment.Contents(context.Background()); err != nil {
+			t.Fatal(err)
+		} else if contents != hiddenCommentContents {
+			t.Errorf("got contents %q, want %q", contents, hiddenCommentContents)
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		comment, err := (&discussionsMutationResolver{}).HideComment(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !comment.IsHidden() || *comment.c.HiddenByUserID != 1 {
+			t.Errorf("got hidden %v by %v, want hidden by user 1", comment.IsHidden(), comment.c.HiddenByUserID)
+		}
+		if contents, err := comment.Contents(context.Background()); err != nil {
+			t.Fatal(err)
+		} else if contents != "spam" {
+			t.Errorf("got contents %q, want site admins to see the original contents", contents)
+		}
+		comment, err = (&discussionsMutationResolver{}).UnhideComment(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if comment.IsHidden() {
+			t.Error("expected comment to be unhidden")
+		}
+	})
+}
+
+func TestDiscussionsMutations_ReportThread(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1, Username: "alice"}, nil
+	}
+	var gotReport string
+	db.Mocks.DiscussionThreads.Update = func(_ context.Context, threadID int64, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error) {
+		gotReport = *opts.Report
+		return &types.DiscussionThread{ID: threadID, Reports: []string{gotReport}}, nil
+	}
+
+	thread, err := (&discussionsMutationResolver{}).ReportThread(context.Background(), &struct {
+		ThreadID graphql.ID
+		Reason   string
+	}{ThreadID: marshalDiscussionThreadID(123), Reason: "spam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"spam"\n\nreported by @alice`; gotReport != want {
+		t.Errorf("got report %q, want %q", gotReport, want)
+	}
+	// Only site admins can read reports.
+	if reports := thread.Reports(context.Background()); len(reports) != 0 {
+		t.Errorf("got reports %v, want none for non-admin", reports)
+	}
+
+	if _, err := (schemaResolver{}).DiscussionModerationQueue(context.Background()); err == nil {
+		t.Error("expected error for non-admin accessing the moderation queue")
+	}
+}
@@ -0,0 +1,127 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// discussionThreadsLoader loads the data that the resolvers of a list of
+// threads (e.g. a page of a connection) need, with one query for all threads
+// on first use instead of one query per thread.
+//
+// The threads' comment counts need no loading, because they are stored on the
+// threads (see DiscussionThread.CommentCount).
+type discussionThreadsLoader struct {
+	threads []*types.DiscussionThread
+	authors *discussionUsersLoader
+
+	reposOnce sync.Once
+	repos     map[api.RepoID]*types.Repo
+	reposErr  error
+}
+
+// newDiscussionThreadResolvers returns resolvers for the threads that share a
+// discussionThreadsLoader.
+func newDiscussionThreadResolvers(threads []*types.DiscussionThread) []*discussionThreadResolver {
+	authorIDs := make([]int32, len(threads))
+	for i, thread := range threads {
+		authorIDs[i] = thread.AuthorUserID
+	}
+	loader := &discussionThreadsLoader{threads: threads, authors: &discussionUsersLoader{userIDs: authorIDs}}
+
+	var l []*discussionThreadResolver
+	for _, thread := range threads {
+		l = append(l, &discussionThreadResolver{t: thread, loader: loader})
+	}
+	return l
+}
+
+// repo returns the repository, or nil if it was not found.
+//
+// 🚨 SECURITY: Repositories that the current user cannot access are not found
+// (see Repos.GetByIDs). Callers fall back to getting the repository as usual,
+// which fails in the same way as without the loader.
+func (l *discussionThreadsLoader) repo(ctx context.Context, repoID api.RepoID) (*types.Repo, error) {
+	l.reposOnce.Do(func() {
+		var repoIDs []api.RepoID
+		seen := map[api.RepoID]bool{}
+		for _, thread := range l.threads {
+			if thread.TargetRepo != nil && !seen[thread.TargetRepo.RepoID] {
+				seen[thread.TargetRepo.RepoID] = true
+				repoIDs = append(repoIDs, thread.TargetRepo.RepoID)
+			}
+		}
+		var repos []*types.Repo
+		repos, l.reposErr = db.Repos.GetByIDs(ctx, repoIDs...)
+		l.repos = make(map[api.RepoID]*types.Repo, len(repos))
+		for _, repo := range repos {
+			l.repos[repo.ID] = repo
+		}
+	})
+	if l.reposErr != nil {
+		return nil, errors.Wrap(l.reposErr, "Repos.GetByIDs")
+	}
+	return l.repos[repoID], nil
+}
+
+// newDiscussionCommentResolvers returns resolvers for the comments that share
+// a loader for the comments' authors.
+func newDiscussionCommentResolvers(comments []*types.DiscussionComment) []*discussionCommentResolver {
+	authorIDs := make([]int32, len(comments))
+	for i, comment := range comments {
+		authorIDs[i] = comment.AuthorUserID
+	}
+	authors := &discussionUsersLoader{userIDs: authorIDs}
+
+	var l []*discussionCommentResolver
+	for _, comment := range comments {
+		l = append(l, &discussionCommentResolver{c: comment, authors: authors})
+	}
+	return l
+}
+
+// discussionUsersLoader loads a set of users with a single query on first
+// use.
+type discussionUsersLoader struct {
+	userIDs []int32
+
+	once  sync.Once
+	users map[int32]*types.User
+	err   error
+}
+
+// get returns the user, or nil if it was not found (e.g. because the user has
+// been deleted).
+func (l *discussionUsersLoader) get(ctx context.Context, userID int32) (*types.User, error) {
+	l.once.Do(func() {
+		var users []*types.User
+		users, l.err = db.Users.List(ctx, &db.UsersListOptions{UserIDs: l.userIDs})
+		l.users = make(map[int32]*types.User, len(users))
+		for _, user := range users {
+			l.users[user.ID] = user
+		}
+	})
+	if l.err != nil {
+		return nil, errors.Wrap(l.err, "Users.List")
+	}
+	return l.users[userID], nil
+}
+
+// discussionUserByID returns the user, using the loader if it is non-nil.
+func discussionUserByID(ctx context.Context, loader *discussionUsersLoader, userID int32) (*UserResolver, error) {
+	if loader != nil {
+		user, err := loader.get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			return &UserResolver{user: user}, nil
+		}
+	}
+	return UserByIDInt32(ctx, userID)
+}
@@ -0,0 +1,72 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionThreadsLoader(t *testing.T) {
+	resetMocks()
+	var usersListCalls, reposGetByIDsCalls int
+	db.Mocks.Users.List = func(_ context.Context, opt *db.UsersListOptions) ([]*types.User, error) {
+		usersListCalls++
+		var users []*types.User
+		for _, id := range opt.UserIDs {
+			users = append(users, &types.User{ID: id})
+		}
+		return users, nil
+	}
+	db.Mocks.Repos.GetByIDs = func(_ context.Context, ids ...api.RepoID) ([]*types.Repo, error) {
+		reposGetByIDsCalls++
+		var repos []*types.Repo
+		for _, id := range ids {
+			repos = append(repos, &types.Repo{ID: id, Name: "r"})
+		}
+		return repos, nil
+	}
+	ctx := context.Background()
+
+	threads := []*types.DiscussionThread{
+		{ID: 1, AuthorUserID: 1, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1}, CommentCount: 10},
+		{ID: 2, AuthorUserID: 2, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1}, CommentCount: 20},
+		{ID: 3, AuthorUserID: 1, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 2}, CommentCount: 30},
+	}
+	for _, r := range newDiscussionThreadResolvers(threads) {
+		author, err := r.Author(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if author.user.ID != r.t.AuthorUserID {
+			t.Errorf("thread %d: got author %d, want %d", r.t.ID, author.user.ID, r.t.AuthorUserID)
+		}
+
+		target, _ := r.Target(ctx).ToDiscussionThreadTargetRepo()
+		repo, err := target.Repository(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repo.repo.ID != r.t.TargetRepo.RepoID {
+			t.Errorf("thread %d: got repository %d, want %d", r.t.ID, repo.repo.ID, r.t.TargetRepo.RepoID)
+		}
+
+		// The comment count is stored on the thread, so it needs no query.
+		totalCount, err := r.Comments(ctx, &struct {
+			graphqlutil.ConnectionArgs
+			TopLevelOnly bool
+		}{}).TotalCount(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int32(r.t.ID) * 10; totalCount != want {
+			t.Errorf("thread %d: got comment count %d, want %d", r.t.ID, totalCount, want)
+		}
+	}
+	if usersListCalls != 1 || reposGetByIDsCalls != 1 {
+		t.Errorf("got %d Users.List and %d Repos.GetByIDs calls, want 1 of each", usersListCalls, reposGetByIDsCalls)
+	}
+}
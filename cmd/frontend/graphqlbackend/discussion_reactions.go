@@ -0,0 +1,138 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+type discussionReactionGroupResolver struct {
+	g *types.DiscussionReactionGroup
+}
+
+func (r *discussionReactionGroupResolver) Content() string { return string(r.g.Content) }
+
+func (r *discussionReactionGroupResolver) Count() int32 { return int32(r.g.Count) }
+
+func (r *discussionReactionGroupResolver) ViewerHasReacted() bool { return r.g.ViewerHasReacted }
+
+// discussionReactionGroups returns the reaction groups of the comment, as seen
+// by the current user.
+func discussionReactionGroups(ctx context.Context, commentID int64) ([]*discussionReactionGroupResolver, error) {
+	groups, err := db.DiscussionCommentReactions.Groups(ctx, commentID, actor.FromContext(ctx).UID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentReactions.Groups")
+	}
+	l := make([]*discussionReactionGroupResolver, len(groups))
+	for i, group := range groups {
+		l[i] = &discussionReactionGroupResolver{g: group}
+	}
+	return l, nil
+}
+
+func (r *discussionCommentResolver) ReactionGroups(ctx context.Context) ([]*discussionReactionGroupResolver, error) {
+	return discussionReactionGroups(ctx, r.c.ID)
+}
+
+func (r *discussionThreadResolver) ReactionGroups(ctx context.Context) ([]*discussionReactionGroupResolver, error) {
+	commentID, err := discussionThreadFirstCommentID(ctx, r.t.ID)
+	if err != nil {
+		return nil, err
+	}
+	return discussionReactionGroups(ctx, commentID)
+}
+
+// discussionThreadFirstCommentID returns the ID of the thread's first comment,
+// which is what reactions to the thread itself are stored on.
+func discussionThreadFirstCommentID(ctx context.Context, threadID int64) (int64, error) {
+	comments, err := db.DiscussionComments.List(ctx, &db.DiscussionCommentsListOptions{
+		LimitOffset: &db.LimitOffset{Limit: 1},
+		ThreadID:    &threadID,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "DiscussionComments.List")
+	}
+	if len(comments) == 0 {
+		return 0, fmt.Errorf("thread %d has no comments", threadID)
+	}
+	return comments[0].ID, nil
+}
+
+// discussionReactionSubjectCommentID returns the ID of the comment that holds
+// the reactions of the thread or comment with the given GraphQL ID.
+func discussionReactionSubjectCommentID(ctx context.Context, id graphql.ID) (int64, error) {
+	switch relay.UnmarshalKind(id) {
+	case "DiscussionThread":
+		threadID, err := unmarshalDiscussionThreadID(id)
+		if err != nil {
+			return 0, err
+		}
+		// Ensure the thread exists and is visible to the current user.
+		if _, err := db.DiscussionThreads.Get(ctx, threadID); err != nil {
+			return 0, err
+		}
+		return discussionThreadFirstCommentID(ctx, threadID)
+	case "DiscussionComment":
+		commentID, err := unmarshalDiscussionCommentID(id)
+		if err != nil {
+			return 0, err
+		}
+		comment, err := db.DiscussionComments.Get(ctx, commentID)
+		if err != nil {
+			return 0, err
+		}
+		// Ensure the comment's thread is visible to the current user.
+		if _, err := db.DiscussionThreads.Get(ctx, comment.ThreadID); err != nil {
+			return 0, err
+		}
+		return comment.ID, nil
+	default:
+		return 0, fmt.Errorf("reactions are only supported on discussion threads and comments, not %q", relay.UnmarshalKind(id))
+	}
+}
+
+func (r *discussionsMutationResolver) AddReaction(ctx context.Context, args *struct {
+	Subject graphql.ID
+	Content string
+}) ([]*discussionReactionGroupResolver, error) {
+	return r.setReaction(ctx, args.Subject, types.DiscussionReactionContent(args.Content), true)
+}
+
+func (r *discussionsMutationResolver) RemoveReaction(ctx context.Context, args *struct {
+	Subject graphql.ID
+	Content string
+}) ([]*discussionReactionGroupResolver, error) {
+	return r.setReaction(ctx, args.Subject, types.DiscussionReactionContent(args.Content), false)
+}
+
+func (r *discussionsMutationResolver) setReaction(ctx context.Context, subject graphql.ID, content types.DiscussionReactionContent, add bool) ([]*discussionReactionGroupResolver, error) {
+	// 🚨 SECURITY: Only signed in users may react, and only as themselves.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	commentID, err := discussionReactionSubjectCommentID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if add {
+		err = db.DiscussionCommentReactions.Add(ctx, commentID, currentUser.user.ID, content)
+	} else {
+		err = db.DiscussionCommentReactions.Remove(ctx, commentID, currentUser.user.ID, content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return discussionReactionGroups(ctx, commentID)
+}
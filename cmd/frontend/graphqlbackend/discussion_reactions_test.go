@@ -0,0 +1,95 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionsMutations_AddReaction(t *testing.T) {
+	type reaction struct {
+		commentID int64
+		userID    int32
+		content   types.DiscussionReactionContent
+	}
+	var added []reaction
+	setup := func() {
+		resetMocks()
+		added = nil
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1}, nil
+		}
+		db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+			return &types.DiscussionThread{ID: threadID}, nil
+		}
+		db.Mocks.DiscussionComments.Get = func(commentID int64) (*types.DiscussionComment, error) {
+			return &types.DiscussionComment{ID: commentID, ThreadID: 10}, nil
+		}
+		db.Mocks.DiscussionComments.List = func(_ context.Context, opts *db.DiscussionCommentsListOptions) ([]*types.DiscussionComment, error) {
+			// The first comment of thread N is comment N*100.
+			return []*types.DiscussionComment{{ID: *opts.ThreadID * 100, ThreadID: *opts.ThreadID}}, nil
+		}
+		db.Mocks.DiscussionCommentReactions.Add = func(_ context.Context, commentID int64, userID int32, content types.DiscussionReactionContent) error {
+			added = append(added, reaction{commentID, userID, content})
+			return nil
+		}
+		db.Mocks.DiscussionCommentReactions.Groups = func(_ context.Context, commentID int64, viewerUserID int32) ([]*types.DiscussionReactionGroup, error) {
+			return []*types.DiscussionReactionGroup{{Content: types.DiscussionReactionHeart, Count: 1, ViewerHasReacted: viewerUserID == 1}}, nil
+		}
+	}
+	addReaction := func(ctx context.Context, subject graphql.ID) ([]*discussionReactionGroupResolver, error) {
+		return (&discussionsMutationResolver{}).AddReaction(ctx, &struct {
+			Subject graphql.ID
+			Content string
+		}{Subject: subject, Content: "HEART"})
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("comment", func(t *testing.T) {
+		setup()
+		groups, err := addReaction(ctx, marshalDiscussionCommentID(5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := (reaction{5, 1, types.DiscussionReactionHeart}); len(added) != 1 || added[0] != want {
+			t.Errorf("got reactions added %+v, want %+v", added, want)
+		}
+		if len(groups) != 1 || !groups[0].ViewerHasReacted() || groups[0].Count() != 1 {
+			t.Errorf("got groups %+v, want 1 group that the viewer reacted in", groups)
+		}
+	})
+
+	t.Run("thread", func(t *testing.T) {
+		setup()
+		if _, err := addReaction(ctx, marshalDiscussionThreadID(3)); err != nil {
+			t.Fatal(err)
+		}
+		if want := (reaction{300, 1, types.DiscussionReactionHeart}); len(added) != 1 || added[0] != want {
+			t.Errorf("got reactions added %+v, want %+v", added, want)
+		}
+	})
+
+	t.Run("unsupported subject", func(t *testing.T) {
+		setup()
+		if _, err := addReaction(ctx, marshalDiscussionLabelID(3)); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		setup()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return nil, db.ErrNoCurrentUser
+		}
+		if _, err := addReaction(context.Background(), marshalDiscussionCommentID(5)); err == nil {
+			t.Error("expected error")
+		}
+		if len(added) != 0 {
+			t.Errorf("got reactions added %+v, want none", added)
+		}
+	})
+}
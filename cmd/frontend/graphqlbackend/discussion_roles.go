@@ -0,0 +1,141 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+// discussionRoleArgs are the arguments that identify a user and the repository
+// or organization of a role.
+type discussionRoleArgs struct {
+	User         graphql.ID
+	Repository   *graphql.ID
+	Organization *graphql.ID
+}
+
+// role returns a role for the arguments, without its Role field set.
+func (args *discussionRoleArgs) role(ctx context.Context) (*types.DiscussionRole, error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	role := &types.DiscussionRole{UserID: userID}
+	switch {
+	case (args.Repository == nil) == (args.Organization == nil):
+		return nil, errors.New("exactly one of repository and organization must be specified")
+	case args.Repository != nil:
+		repo, err := repositoryByID(ctx, *args.Repository)
+		if err != nil {
+			return nil, err
+		}
+		role.RepoID = &repo.repo.ID
+	default:
+		orgID, err := UnmarshalOrgID(*args.Organization)
+		if err != nil {
+			return nil, err
+		}
+		role.OrgID = &orgID
+	}
+	return role, nil
+}
+
+// checkCanAdministerDiscussionRoles returns an error if the current user may
+// not change the roles in the repository or organization of the role.
+func checkCanAdministerDiscussionRoles(ctx context.Context, role *types.DiscussionRole) error {
+	// 🚨 SECURITY: Only site admins can administer roles in repositories, and
+	// only organization members can administer roles in organizations (like
+	// milestones).
+	if role.OrgID != nil {
+		return backend.CheckOrgAccess(ctx, *role.OrgID)
+	}
+	return backend.CheckCurrentUserIsSiteAdmin(ctx)
+}
+
+func (r *discussionsMutationResolver) SetDiscussionRole(ctx context.Context, args *struct {
+	discussionRoleArgs
+	Role string
+}) (*EmptyResponse, error) {
+	role, err := args.role(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role.Role = types.DiscussionRoleKind(args.Role)
+	if err := checkCanAdministerDiscussionRoles(ctx, role); err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Any user can create an organization, so organization
+	// members may not give themselves roles (only site admins may).
+	if role.OrgID != nil && role.UserID == actor.FromContext(ctx).UID {
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if err := db.DiscussionRoles.Set(ctx, role); err != nil {
+		return nil, errors.Wrap(err, "DiscussionRoles.Set")
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *discussionsMutationResolver) RemoveDiscussionRole(ctx context.Context, args *discussionRoleArgs) (*EmptyResponse, error) {
+	role, err := args.role(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCanAdministerDiscussionRoles(ctx, role); err != nil {
+		return nil, err
+	}
+	if _, err := db.DiscussionRoles.Remove(ctx, role.UserID, role.RepoID, role.OrgID); err != nil {
+		return nil, errors.Wrap(err, "DiscussionRoles.Remove")
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *RepositoryResolver) DiscussionRoles(ctx context.Context) ([]*discussionRoleResolver, error) {
+	// 🚨 SECURITY: The roles in a repository are public to those who can view
+	// the repository (like its milestones).
+	return listDiscussionRoles(ctx, &db.DiscussionRolesListOptions{RepoID: r.repo.ID})
+}
+
+func (o *OrgResolver) DiscussionRoles(ctx context.Context) ([]*discussionRoleResolver, error) {
+	// 🚨 SECURITY: The roles in an organization are public (like its
+	// milestones).
+	return listDiscussionRoles(ctx, &db.DiscussionRolesListOptions{OrgID: o.org.ID})
+}
+
+func listDiscussionRoles(ctx context.Context, opts *db.DiscussionRolesListOptions) ([]*discussionRoleResolver, error) {
+	roles, err := db.DiscussionRoles.List(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionRoles.List")
+	}
+	userIDs := make([]int32, len(roles))
+	for i, role := range roles {
+		userIDs[i] = role.UserID
+	}
+	users := &discussionUsersLoader{userIDs: userIDs}
+	l := make([]*discussionRoleResolver, len(roles))
+	for i, role := range roles {
+		l[i] = &discussionRoleResolver{r: role, users: users}
+	}
+	return l, nil
+}
+
+// discussionRoleResolver resolves a user's role in a repository or
+// organization.
+type discussionRoleResolver struct {
+	r     *types.DiscussionRole
+	users *discussionUsersLoader
+}
+
+func (r *discussionRoleResolver) User(ctx context.Context) (*UserResolver, error) {
+	return discussionUserByID(ctx, r.users, r.r.UserID)
+}
+
+func (r *discussionRoleResolver) Role() string { return string(r.r.Role) }
+
+func (r *discussionRoleResolver) CreatedAt() DateTime { return DateTime{Time: r.r.CreatedAt} }
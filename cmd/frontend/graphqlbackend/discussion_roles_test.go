@@ -0,0 +1,145 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionsMutations_SetDiscussionRole(t *testing.T) {
+	resetMocks()
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "r"}, nil
+	}
+	var set *types.DiscussionRole
+	db.Mocks.DiscussionRoles.Set = func(_ context.Context, role *types.DiscussionRole) error {
+		set = role
+		return nil
+	}
+
+	mutation := `
+		mutation($user: ID!, $repository: ID!) {
+			discussions {
+				setDiscussionRole(user: $user, repository: $repository, role: TRIAGER) {
+					alwaysNil
+				}
+			}
+		}
+	`
+	variables := map[string]interface{}{
+		"user":       string(marshalUserID(2)),
+		"repository": string(MarshalRepositoryID(3)),
+	}
+
+	t.Run("site admin", func(t *testing.T) {
+		gqltesting.RunTests(t, []*gqltesting.Test{
+			{
+				Context:   backend.WithAuthzBypass(context.Background()),
+				Schema:    mustParseGraphQLSchema(t, nil),
+				Query:     mutation,
+				Variables: variables,
+				ExpectedResult: `
+					{
+						"discussions": {
+							"setDiscussionRole": {
+								"alwaysNil": null
+							}
+						}
+					}
+				`,
+			},
+		})
+		repoID := api.RepoID(3)
+		if want := (&types.DiscussionRole{UserID: 2, RepoID: &repoID, Role: types.DiscussionRoleTriager}); !reflect.DeepEqual(set, want) {
+			t.Errorf("got role %+v, want %+v", set, want)
+		}
+	})
+
+	t.Run("non-admin", func(t *testing.T) {
+		set = nil
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		userID := marshalUserID(2)
+		repoID := MarshalRepositoryID(3)
+		_, err := (&discussionsMutationResolver{}).SetDiscussionRole(ctx, &struct {
+			discussionRoleArgs
+			Role string
+		}{discussionRoleArgs: discussionRoleArgs{User: userID, Repository: &repoID}, Role: "TRIAGER"})
+		if err != backend.ErrMustBeSiteAdmin {
+			t.Errorf("got error %v, want %v", err, backend.ErrMustBeSiteAdmin)
+		}
+		if set != nil {
+			t.Error("expected no role to be set")
+		}
+	})
+
+	t.Run("org member", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		db.Mocks.OrgMembers.GetByOrgIDAndUserID = func(_ context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+			return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+		}
+		defer func() { db.Mocks.OrgMembers = db.MockOrgMembers{} }()
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		orgID := marshalOrgID(4)
+		setOrgRole := func(userID int32) error {
+			_, err := (&discussionsMutationResolver{}).SetDiscussionRole(ctx, &struct {
+				discussionRoleArgs
+				Role string
+			}{discussionRoleArgs: discussionRoleArgs{User: marshalUserID(userID), Organization: &orgID}, Role: "MAINTAINER"})
+			return err
+		}
+
+		set = nil
+		if err := setOrgRole(2); err != nil {
+			t.Fatal(err)
+		}
+		if set == nil || set.UserID != 2 {
+			t.Errorf("got role %+v, want a role for user 2", set)
+		}
+
+		// Organization members may not give themselves roles.
+		set = nil
+		if err := setOrgRole(1); err != backend.ErrMustBeSiteAdmin {
+			t.Errorf("got error %v, want %v", err, backend.ErrMustBeSiteAdmin)
+		}
+		if set != nil {
+			t.Error("expected no role to be set")
+		}
+	})
+}
+
+func TestRepository_DiscussionRoles(t *testing.T) {
+	resetMocks()
+	db.Mocks.DiscussionRoles.List = func(_ context.Context, opts *db.DiscussionRolesListOptions) ([]*types.DiscussionRole, error) {
+		if opts.RepoID != 3 {
+			t.Errorf("got repository %d, want 3", opts.RepoID)
+		}
+		return []*types.DiscussionRole{{UserID: 2, RepoID: &opts.RepoID, Role: types.DiscussionRoleMaintainer}}, nil
+	}
+	db.Mocks.Users.List = func(context.Context, *db.UsersListOptions) ([]*types.User, error) {
+		return []*types.User{{ID: 2, Username: "u"}}, nil
+	}
+	roles, err := (&RepositoryResolver{repo: &types.Repo{ID: 3}}).DiscussionRoles(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || roles[0].Role() != "MAINTAINER" {
+		t.Fatalf("got roles %+v, want one MAINTAINER role", roles)
+	}
+	user, err := roles[0].User(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Username() != "u" {
+		t.Errorf("got user %q, want %q", user.Username(), "u")
+	}
+}
@@ -0,0 +1,258 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func marshalDiscussionRuleID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionRule", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionRuleID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+// discussionRuleByID looks up a DiscussionRule by its GraphQL ID.
+func discussionRuleByID(ctx context.Context, id graphql.ID) (*discussionRuleResolver, error) {
+	dbID, err := unmarshalDiscussionRuleID(id)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: No authentication is required to get a discussion rule, for
+	// the same reason as discussion labels.
+	rule, err := db.DiscussionRules.Get(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionRuleResolver{r: rule}, nil
+}
+
+type discussionRuleResolver struct {
+	r *types.DiscussionRule
+}
+
+func (r *discussionRuleResolver) ID() graphql.ID {
+	return marshalDiscussionRuleID(r.r.ID)
+}
+
+func (r *discussionRuleResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	return RepositoryByIDInt32(ctx, r.r.RepoID)
+}
+
+func (r *discussionRuleResolver) Name() string { return r.r.Name }
+
+func (r *discussionRuleResolver) Query() string { return r.r.Query }
+
+func (r *discussionRuleResolver) Labels(ctx context.Context) ([]*discussionLabelResolver, error) {
+	if len(r.r.LabelIDs) == 0 {
+		return []*discussionLabelResolver{}, nil
+	}
+	// Labels that were deleted since the rule was created are omitted.
+	labels, err := db.DiscussionLabels.List(ctx, &db.DiscussionLabelsListOptions{LabelIDs: r.r.LabelIDs, RepoID: r.r.RepoID})
+	if err != nil {
+		return nil, err
+	}
+	l := make([]*discussionLabelResolver, len(labels))
+	for i, label := range labels {
+		l[i] = &discussionLabelResolver{l: label}
+	}
+	return l, nil
+}
+
+func (r *discussionRuleResolver) Assignees(ctx context.Context) ([]*UserResolver, error) {
+	l := []*UserResolver{}
+	for _, userID := range r.r.AssigneeUserIDs {
+		user, err := UserByIDInt32(ctx, userID)
+		if errcode.IsNotFound(err) {
+			// The user was deleted since the rule was created.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, user)
+	}
+	return l, nil
+}
+
+func (r *discussionRuleResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.r.CreatedAt}
+}
+
+func (r *discussionRuleResolver) UpdatedAt() DateTime {
+	return DateTime{Time: r.r.UpdatedAt}
+}
+
+func (r *RepositoryResolver) DiscussionRules(ctx context.Context) ([]*discussionRuleResolver, error) {
+	// 🚨 SECURITY: Anyone with access to the repository can list its rules,
+	// for the same reason as its labels.
+	rules, err := db.DiscussionRules.List(ctx, r.repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	l := make([]*discussionRuleResolver, len(rules))
+	for i, rule := range rules {
+		l[i] = &discussionRuleResolver{r: rule}
+	}
+	return l, nil
+}
+
+func (r *discussionsMutationResolver) CreateRule(ctx context.Context, args *struct {
+	Input *struct {
+		Repository graphql.ID
+		Name       string
+		Query      string
+		Labels     *[]graphql.ID
+		Assignees  *[]graphql.ID
+	}
+}) (*discussionRuleResolver, error) {
+	// 🚨 SECURITY: Only site admins can create discussion rules.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	repo, err := repositoryByID(ctx, args.Input.Repository)
+	if err != nil {
+		return nil, err
+	}
+	rule := &types.DiscussionRule{
+		RepoID: repo.repo.ID,
+		Name:   args.Input.Name,
+		Query:  args.Input.Query,
+	}
+	if args.Input.Labels != nil {
+		if rule.LabelIDs, err = discussionRuleLabelIDs(ctx, repo.repo.ID, *args.Input.Labels); err != nil {
+			return nil, err
+		}
+	}
+	if args.Input.Assignees != nil {
+		if rule.AssigneeUserIDs, err = discussionRuleAssigneeUserIDs(*args.Input.Assignees); err != nil {
+			return nil, err
+		}
+	}
+	rule, err = db.DiscussionRules.Create(ctx, rule)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionRules.Create")
+	}
+	return &discussionRuleResolver{r: rule}, nil
+}
+
+func (r *discussionsMutationResolver) UpdateRule(ctx context.Context, args *struct {
+	Input *struct {
+		Rule      graphql.ID
+		Name      *string
+		Query     *string
+		Labels    *[]graphql.ID
+		Assignees *[]graphql.ID
+	}
+}) (*discussionRuleResolver, error) {
+	// 🚨 SECURITY: Only site admins can update discussion rules.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ruleID, err := unmarshalDiscussionRuleID(args.Input.Rule)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := db.DiscussionRules.Get(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	opts := &db.DiscussionRulesUpdateOptions{Name: args.Input.Name, Query: args.Input.Query}
+	if args.Input.Labels != nil {
+		labelIDs, err := discussionRuleLabelIDs(ctx, rule.RepoID, *args.Input.Labels)
+		if err != nil {
+			return nil, err
+		}
+		opts.LabelIDs = &labelIDs
+	}
+	if args.Input.Assignees != nil {
+		userIDs, err := discussionRuleAssigneeUserIDs(*args.Input.Assignees)
+		if err != nil {
+			return nil, err
+		}
+		opts.AssigneeUserIDs = &userIDs
+	}
+	rule, err = db.DiscussionRules.Update(ctx, rule.ID, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionRules.Update")
+	}
+	return &discussionRuleResolver{r: rule}, nil
+}
+
+func (r *discussionsMutationResolver) DeleteRule(ctx context.Context, args *struct {
+	Rule graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can delete discussion rules.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ruleID, err := unmarshalDiscussionRuleID(args.Rule)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.DiscussionRules.Delete(ctx, ruleID); err != nil {
+		return nil, errors.Wrap(err, "DiscussionRules.Delete")
+	}
+	return &EmptyResponse{}, nil
+}
+
+// discussionRuleLabelIDs unmarshals the label IDs of a rule, checking that
+// all labels belong to the rule's repository.
+func discussionRuleLabelIDs(ctx context.Context, repoID api.RepoID, labelGQLIDs []graphql.ID) ([]int64, error) {
+	labelIDs := make([]int64, 0, len(labelGQLIDs))
+	for _, id := range labelGQLIDs {
+		labelID, err := unmarshalDiscussionLabelID(id)
+		if err != nil {
+			return nil, err
+		}
+		labelIDs = append(labelIDs, labelID)
+	}
+	if len(labelIDs) == 0 {
+		return labelIDs, nil
+	}
+	labels, err := db.DiscussionLabels.List(ctx, &db.DiscussionLabelsListOptions{
+		LabelIDs: labelIDs,
+		RepoID:   repoID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionLabels.List")
+	}
+	found := make(map[int64]bool, len(labels))
+	for _, label := range labels {
+		found[label.ID] = true
+	}
+	for i, labelID := range labelIDs {
+		if !found[labelID] {
+			return nil, fmt.Errorf("label %s not found in the rule's repository", labelGQLIDs[i])
+		}
+	}
+	return labelIDs, nil
+}
+
+func discussionRuleAssigneeUserIDs(userGQLIDs []graphql.ID) ([]int32, error) {
+	userIDs := make([]int32, 0, len(userGQLIDs))
+	for _, id := range userGQLIDs {
+		userID, err := UnmarshalUserID(id)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,118 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionsMutations_CreateRule(t *testing.T) {
+	var (
+		siteAdmin bool
+		created   *types.DiscussionRule
+	)
+	setup := func() {
+		resetMocks()
+		created = nil
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: siteAdmin}, nil
+		}
+		db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+			return &types.Repo{ID: id, Name: "r"}, nil
+		}
+		db.Mocks.DiscussionLabels.List = func(_ context.Context, opts *db.DiscussionLabelsListOptions) ([]*types.DiscussionLabel, error) {
+			// Label 5 belongs to another repository.
+			var labels []*types.DiscussionLabel
+			for _, labelID := range opts.LabelIDs {
+				if labelID != 5 {
+					labels = append(labels, &types.DiscussionLabel{ID: labelID, RepoID: opts.RepoID})
+				}
+			}
+			return labels, nil
+		}
+		db.Mocks.DiscussionRules.Create = func(_ context.Context, newRule *types.DiscussionRule) (*types.DiscussionRule, error) {
+			newRule.ID = 2
+			created = newRule
+			return newRule, nil
+		}
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	type createRuleInput = struct {
+		Repository graphql.ID
+		Name       string
+		Query      string
+		Labels     *[]graphql.ID
+		Assignees  *[]graphql.ID
+	}
+	labels := []graphql.ID{marshalDiscussionLabelID(4)}
+	assignees := []graphql.ID{marshalUserID(7)}
+	input := &createRuleInput{Repository: MarshalRepositoryID(3), Name: "Crashes", Query: "title:crash", Labels: &labels, Assignees: &assignees}
+
+	t.Run("non-site admin", func(t *testing.T) {
+		setup()
+		siteAdmin = false
+		if _, err := (&discussionsMutationResolver{}).CreateRule(ctx, &struct{ Input *createRuleInput }{Input: input}); err == nil {
+			t.Error("expected error")
+		}
+		if created != nil {
+			t.Error("rule was created")
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		setup()
+		siteAdmin = true
+		rule, err := (&discussionsMutationResolver{}).CreateRule(ctx, &struct{ Input *createRuleInput }{Input: input})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := marshalDiscussionRuleID(2); rule.ID() != want {
+			t.Errorf("got rule ID %q, want %q", rule.ID(), want)
+		}
+		want := &types.DiscussionRule{ID: 2, RepoID: 3, Name: "Crashes", Query: "title:crash", LabelIDs: []int64{4}, AssigneeUserIDs: []int32{7}}
+		if !reflect.DeepEqual(created, want) {
+			t.Errorf("got rule %+v, want %+v", created, want)
+		}
+	})
+
+	t.Run("label in another repository", func(t *testing.T) {
+		setup()
+		siteAdmin = true
+		labels := []graphql.ID{marshalDiscussionLabelID(4), marshalDiscussionLabelID(5)}
+		input := *input
+		input.Labels = &labels
+		if _, err := (&discussionsMutationResolver{}).CreateRule(ctx, &struct{ Input *createRuleInput }{Input: &input}); err == nil {
+			t.Error("expected error")
+		}
+		if created != nil {
+			t.Error("rule was created")
+		}
+	})
+}
+
+func TestDiscussionThreadTimelineItem_Rule(t *testing.T) {
+	resetMocks()
+	db.Mocks.DiscussionRules.Get = func(_ context.Context, ruleID int64) (*types.DiscussionRule, error) {
+		if ruleID == 2 {
+			return &types.DiscussionRule{ID: ruleID}, nil
+		}
+		return nil, &db.ErrRuleNotFound{RuleID: ruleID}
+	}
+	for ruleID, wantRule := range map[int64]bool{2: true, 3: false} {
+		ruleID := ruleID
+		item := &discussionThreadTimelineItemResolver{e: &types.DiscussionThreadEvent{Data: types.DiscussionThreadEventData{RuleID: &ruleID}}}
+		rule, err := item.Rule(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (rule != nil) != wantRule {
+			t.Errorf("rule %d: got %v, want present %v", ruleID, rule, wantRule)
+		}
+	}
+}
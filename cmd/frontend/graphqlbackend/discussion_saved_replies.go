@@ -0,0 +1,217 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func marshalDiscussionSavedReplyID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionSavedReply", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionSavedReplyID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+// discussionSavedReplyByID looks up a DiscussionSavedReply by its GraphQL ID.
+func discussionSavedReplyByID(ctx context.Context, id graphql.ID) (*discussionSavedReplyResolver, error) {
+	dbID, err := unmarshalDiscussionSavedReplyID(id)
+	if err != nil {
+		return nil, err
+	}
+	return getDiscussionSavedReply(ctx, dbID)
+}
+
+// getDiscussionSavedReply gets the saved reply, returning an error if the
+// current user may not access it.
+func getDiscussionSavedReply(ctx context.Context, savedReplyID int64) (*discussionSavedReplyResolver, error) {
+	reply, err := db.DiscussionSavedReplies.Get(ctx, savedReplyID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the user who saved a reply (and site admins) can
+	// access it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, reply.UserID); err != nil {
+		return nil, err
+	}
+	return &discussionSavedReplyResolver{r: reply}, nil
+}
+
+type discussionSavedReplyResolver struct {
+	r *types.DiscussionSavedReply
+}
+
+func (r *discussionSavedReplyResolver) ID() graphql.ID {
+	return marshalDiscussionSavedReplyID(r.r.ID)
+}
+
+func (r *discussionSavedReplyResolver) User(ctx context.Context) (*UserResolver, error) {
+	return UserByIDInt32(ctx, r.r.UserID)
+}
+
+func (r *discussionSavedReplyResolver) Name() string { return r.r.Name }
+
+func (r *discussionSavedReplyResolver) Contents() string { return r.r.Contents }
+
+func (r *discussionSavedReplyResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.r.CreatedAt}
+}
+
+func (r *discussionSavedReplyResolver) UpdatedAt() DateTime {
+	return DateTime{Time: r.r.UpdatedAt}
+}
+
+func (r *discussionsMutationResolver) CreateSavedReply(ctx context.Context, args *struct {
+	Name     string
+	Contents string
+}) (*discussionSavedReplyResolver, error) {
+	// 🚨 SECURITY: Only signed in users can save replies, and only for
+	// themselves.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+	reply, err := db.DiscussionSavedReplies.Create(ctx, &types.DiscussionSavedReply{
+		UserID:   currentUser.user.ID,
+		Name:     args.Name,
+		Contents: args.Contents,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionSavedReplies.Create")
+	}
+	return &discussionSavedReplyResolver{r: reply}, nil
+}
+
+func (r *discussionsMutationResolver) UpdateSavedReply(ctx context.Context, args *struct {
+	SavedReply graphql.ID
+	Name       *string
+	Contents   *string
+}) (*discussionSavedReplyResolver, error) {
+	savedReplyID, err := unmarshalDiscussionSavedReplyID(args.SavedReply)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: getDiscussionSavedReply checks that the current user may
+	// access (and therefore modify) the saved reply.
+	if _, err := getDiscussionSavedReply(ctx, savedReplyID); err != nil {
+		return nil, err
+	}
+	reply, err := db.DiscussionSavedReplies.Update(ctx, savedReplyID, &db.DiscussionSavedRepliesUpdateOptions{
+		Name:     args.Name,
+		Contents: args.Contents,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionSavedReplies.Update")
+	}
+	return &discussionSavedReplyResolver{r: reply}, nil
+}
+
+func (r *discussionsMutationResolver) DeleteSavedReply(ctx context.Context, args *struct {
+	SavedReply graphql.ID
+}) (*EmptyResponse, error) {
+	savedReplyID, err := unmarshalDiscussionSavedReplyID(args.SavedReply)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: getDiscussionSavedReply checks that the current user may
+	// access (and therefore delete) the saved reply.
+	if _, err := getDiscussionSavedReply(ctx, savedReplyID); err != nil {
+		return nil, err
+	}
+	if err := db.DiscussionSavedReplies.Delete(ctx, savedReplyID); err != nil {
+		return nil, errors.Wrap(err, "DiscussionSavedReplies.Delete")
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (schemaResolver) SavedReplies(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) (*discussionSavedRepliesConnectionResolver, error) {
+	// 🚨 SECURITY: Users can only list their own saved replies.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+	opt := &db.DiscussionSavedRepliesListOptions{UserID: currentUser.user.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionSavedRepliesConnectionResolver{opt: opt}, nil
+}
+
+// discussionSavedRepliesConnectionResolver resolves a list of saved replies.
+//
+// 🚨 SECURITY: When instantiating an discussionSavedRepliesConnectionResolver
+// value, the caller MUST check permissions.
+type discussionSavedRepliesConnectionResolver struct {
+	opt *db.DiscussionSavedRepliesListOptions
+
+	// cache results because they are used by multiple fields
+	once    sync.Once
+	replies []*types.DiscussionSavedReply
+	err     error
+}
+
+func (r *discussionSavedRepliesConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionSavedReply, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.replies, r.err = db.DiscussionSavedReplies.List(ctx, &opt2)
+	})
+	return r.replies, r.err
+}
+
+func (r *discussionSavedRepliesConnectionResolver) Nodes(ctx context.Context) ([]*discussionSavedReplyResolver, error) {
+	replies, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(replies) > r.opt.Limit {
+		replies = replies[:r.opt.Limit]
+	}
+
+	var l []*discussionSavedReplyResolver
+	for _, reply := range replies {
+		l = append(l, &discussionSavedReplyResolver{r: reply})
+	}
+	return l, nil
+}
+
+func (r *discussionSavedRepliesConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionSavedReplies.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionSavedRepliesConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	replies, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(replies) > r.opt.Limit), nil
+}
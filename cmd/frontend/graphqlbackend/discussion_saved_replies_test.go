@@ -0,0 +1,71 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionsMutations_SavedReplies(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return &types.User{ID: id}, nil }
+	db.Mocks.DiscussionSavedReplies.Create = func(_ context.Context, newReply *types.DiscussionSavedReply) (*types.DiscussionSavedReply, error) {
+		if newReply.UserID != 1 {
+			t.Errorf("got UserID %d, want 1", newReply.UserID)
+		}
+		newReply.ID = 1
+		return newReply, nil
+	}
+	db.Mocks.DiscussionSavedReplies.Get = func(savedReplyID int64) (*types.DiscussionSavedReply, error) {
+		// Saved reply 2 belongs to another user.
+		return &types.DiscussionSavedReply{ID: savedReplyID, UserID: int32(savedReplyID), Name: "Thanks", Contents: "Thanks!"}, nil
+	}
+	var updated bool
+	db.Mocks.DiscussionSavedReplies.Update = func(_ context.Context, savedReplyID int64, opts *db.DiscussionSavedRepliesUpdateOptions) (*types.DiscussionSavedReply, error) {
+		updated = true
+		return &types.DiscussionSavedReply{ID: savedReplyID, UserID: 1, Name: "Thanks", Contents: *opts.Contents}, nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	r := &discussionsMutationResolver{}
+
+	reply, err := r.CreateSavedReply(ctx, &struct {
+		Name     string
+		Contents string
+	}{Name: "Thanks", Contents: "Thanks!"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Name() != "Thanks" {
+		t.Errorf("got name %q, want %q", reply.Name(), "Thanks")
+	}
+
+	type updateArgs = struct {
+		SavedReply graphql.ID
+		Name       *string
+		Contents   *string
+	}
+	reply, err = r.UpdateSavedReply(ctx, &updateArgs{SavedReply: marshalDiscussionSavedReplyID(1), Contents: strptr("Thank you!")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Contents() != "Thank you!" {
+		t.Errorf("got contents %q, want %q", reply.Contents(), "Thank you!")
+	}
+
+	// Users cannot access or modify other users' saved replies.
+	updated = false
+	if _, err := r.UpdateSavedReply(ctx, &updateArgs{SavedReply: marshalDiscussionSavedReplyID(2), Contents: strptr("x")}); err == nil {
+		t.Error("expected error updating another user's saved reply")
+	}
+	if updated {
+		t.Error("another user's saved reply was updated")
+	}
+	if _, err := discussionSavedReplyByID(ctx, marshalDiscussionSavedReplyID(2)); err == nil {
+		t.Error("expected error getting another user's saved reply")
+	}
+}
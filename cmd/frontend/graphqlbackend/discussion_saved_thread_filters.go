@@ -0,0 +1,124 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/jsonx"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type discussionSavedThreadFilterResolver struct {
+	f *schema.DiscussionsSavedThreadFilter
+}
+
+func (r *discussionSavedThreadFilterResolver) Name() string { return r.f.Name }
+
+func (r *discussionSavedThreadFilterResolver) Query() string { return r.f.Query }
+
+func (r *discussionSavedThreadFilterResolver) IsDefault() bool { return r.f.Default }
+
+func (r *UserResolver) SavedThreadFilters(ctx context.Context) ([]*discussionSavedThreadFilterResolver, error) {
+	// 🚨 SECURITY: Only the user and site admins are allowed to access the
+	// user's settings.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return nil, err
+	}
+	settings, err := backend.Configuration.GetForSubject(ctx, api.SettingsSubject{User: &r.user.ID})
+	if err != nil {
+		return nil, err
+	}
+	filters := make([]*discussionSavedThreadFilterResolver, 0, len(settings.DiscussionsSavedThreadFilters))
+	for _, f := range settings.DiscussionsSavedThreadFilters {
+		filters = append(filters, &discussionSavedThreadFilterResolver{f: f})
+	}
+	return filters, nil
+}
+
+func (r *discussionsMutationResolver) CreateSavedThreadFilter(ctx context.Context, args *struct {
+	Name      string
+	Query     string
+	IsDefault bool
+}) (*discussionSavedThreadFilterResolver, error) {
+	if args.Name == "" {
+		return nil, errors.New("saved thread filter name must not be empty")
+	}
+	newFilter := &schema.DiscussionsSavedThreadFilter{Name: args.Name, Query: args.Query, Default: args.IsDefault}
+	err := editViewerSavedThreadFilters(ctx, func(filters []*schema.DiscussionsSavedThreadFilter) ([]*schema.DiscussionsSavedThreadFilter, error) {
+		for _, f := range filters {
+			if f.Name == args.Name {
+				return nil, discussions.NewError(discussions.ErrorCodeConflict, fmt.Sprintf("a saved thread filter named %q already exists", args.Name))
+			}
+			if args.IsDefault {
+				f.Default = false
+			}
+		}
+		return append(filters, newFilter), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &discussionSavedThreadFilterResolver{f: newFilter}, nil
+}
+
+func (r *discussionsMutationResolver) DeleteSavedThreadFilter(ctx context.Context, args *struct {
+	Name string
+}) (*EmptyResponse, error) {
+	err := editViewerSavedThreadFilters(ctx, func(filters []*schema.DiscussionsSavedThreadFilter) ([]*schema.DiscussionsSavedThreadFilter, error) {
+		for i, f := range filters {
+			if f.Name == args.Name {
+				return append(filters[:i], filters[i+1:]...), nil
+			}
+		}
+		return nil, discussions.NewError(discussions.ErrorCodeNotFound, fmt.Sprintf("no saved thread filter named %q", args.Name))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// editViewerSavedThreadFilters replaces the discussions.savedThreadFilters
+// setting in the viewer's user settings with the result of edit.
+func editViewerSavedThreadFilters(ctx context.Context, edit func([]*schema.DiscussionsSavedThreadFilter) ([]*schema.DiscussionsSavedThreadFilter, error)) error {
+	// 🚨 SECURITY: Users may only edit their own settings.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if currentUser == nil {
+		return discussions.ErrNoCurrentUser
+	}
+	subject := &settingsSubject{user: currentUser}
+
+	latest, err := db.Settings.GetLatest(ctx, subject.toSubject())
+	if err != nil {
+		return err
+	}
+	var (
+		lastID   *int32
+		settings schema.Settings
+	)
+	if latest != nil {
+		lastID = &latest.ID
+		if err := jsonc.Unmarshal(latest.Contents, &settings); err != nil {
+			return err
+		}
+	}
+	filters, err := edit(settings.DiscussionsSavedThreadFilters)
+	if err != nil {
+		return err
+	}
+
+	// The edit is based on the settings with ID lastID, so it fails if the
+	// settings were changed concurrently.
+	m := &settingsMutation{input: &settingsMutationGroupInput{LastID: lastID}, subject: subject}
+	_, err = m.editSettings(ctx, jsonx.PropertyPath("discussions.savedThreadFilters"), filters, false)
+	return err
+}
@@ -0,0 +1,81 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionsMutations_SavedThreadFilters(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return &types.User{ID: id}, nil }
+	var latest *api.Settings
+	db.Mocks.Settings.GetLatest = func(_ context.Context, subject api.SettingsSubject) (*api.Settings, error) {
+		if subject.User == nil || *subject.User != 1 {
+			t.Errorf("got settings subject %+v, want user 1", subject)
+		}
+		return latest, nil
+	}
+	db.Mocks.Settings.CreateIfUpToDate = func(_ context.Context, _ api.SettingsSubject, lastID, _ *int32, contents string) (*api.Settings, error) {
+		if (latest == nil) != (lastID == nil) || (latest != nil && *lastID != latest.ID) {
+			t.Fatalf("got lastID %v, want ID of %+v", lastID, latest)
+		}
+		id := int32(1)
+		if latest != nil {
+			id = latest.ID + 1
+		}
+		latest = &api.Settings{ID: id, Contents: contents}
+		return latest, nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	r := &discussionsMutationResolver{}
+	type createArgs = struct {
+		Name      string
+		Query     string
+		IsDefault bool
+	}
+
+	if _, err := r.CreateSavedThreadFilter(ctx, &createArgs{Name: "mine", Query: "assignee:alice is:open", IsDefault: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.CreateSavedThreadFilter(ctx, &createArgs{Name: "bugs", Query: "label:bug", IsDefault: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.CreateSavedThreadFilter(ctx, &createArgs{Name: "bugs", Query: "label:bug"}); err == nil {
+		t.Error("expected error creating a filter with a duplicate name")
+	}
+
+	list := func() (names []string, defaults []string) {
+		t.Helper()
+		filters, err := (&UserResolver{user: &types.User{ID: 1}}).SavedThreadFilters(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range filters {
+			names = append(names, f.Name())
+			if f.IsDefault() {
+				defaults = append(defaults, f.Name())
+			}
+		}
+		return names, defaults
+	}
+	// Only the most recently created default filter is the default.
+	if names, defaults := list(); len(names) != 2 || names[0] != "mine" || names[1] != "bugs" || len(defaults) != 1 || defaults[0] != "bugs" {
+		t.Errorf("got filters %v with defaults %v, want [mine bugs] with default bugs", names, defaults)
+	}
+
+	if _, err := r.DeleteSavedThreadFilter(ctx, &struct{ Name string }{Name: "mine"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.DeleteSavedThreadFilter(ctx, &struct{ Name string }{Name: "mine"}); err == nil {
+		t.Error("expected error deleting a nonexistent filter")
+	}
+	if names, _ := list(); len(names) != 1 || names[0] != "bugs" {
+		t.Errorf("got filters %v, want [bugs]", names)
+	}
+}
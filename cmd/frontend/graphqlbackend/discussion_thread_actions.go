@@ -0,0 +1,192 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/ratelimit"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+// maxDiscussionThreadActions is the maximum number of actions that a single
+// applyThreadActions mutation can apply.
+const maxDiscussionThreadActions = 100
+
+type discussionThreadActionInput struct {
+	AddLabels      *[]graphql.ID
+	RemoveLabels   *[]graphql.ID
+	AddAssignee    *graphql.ID
+	RemoveAssignee *graphql.ID
+	Comment        *string
+	Archive        *bool
+}
+
+func (r *discussionsMutationResolver) ApplyThreadActions(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+	Actions  []*discussionThreadActionInput
+}) (*discussionThreadResolver, error) {
+	if len(args.Actions) == 0 {
+		return nil, errors.New("at least one action must be specified")
+	}
+	if len(args.Actions) > maxDiscussionThreadActions {
+		return nil, fmt.Errorf("at most %d actions can be applied at once", maxDiscussionThreadActions)
+	}
+
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+	threadID, err := unmarshalDiscussionThreadID(args.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: All actions are checked before any of them are applied.
+	// Each action requires the same permissions as the equivalent mutation.
+	var checkedTriage, checkedComment bool
+	checkCanTriage := func() error {
+		if checkedTriage {
+			return nil
+		}
+		// 🚨 SECURITY: Only site admins, the thread author and triagers can
+		// change the labels and assignees of a thread, and archive it.
+		//
+		// Whether the thread is archived (and read-only) when the action is
+		// applied depends on the preceding actions, which
+		// InsecureApplyThreadActions checks.
+		if err := discussions.CheckCanArchiveThread(ctx, thread); err != nil {
+			return err
+		}
+		checkedTriage = true
+		return nil
+	}
+	checkCanComment := func() error {
+		if checkedComment {
+			return nil
+		}
+		// 🚨 SECURITY: Only signed in users with a verified email may add
+		// comments to a discussion thread (see AddCommentToThread).
+		if _, err := checkSignedInAndEmailVerified(ctx); err != nil {
+			return err
+		}
+		// Whether the thread is archived when the comment is added depends on
+		// the preceding actions, which InsecureApplyThreadActions checks.
+		commentable := *thread
+		commentable.ArchivedAt = nil
+		if err := discussions.CheckCanComment(ctx, &commentable); err != nil {
+			return err
+		}
+		checkedComment = true
+		return nil
+	}
+
+	actions := make([]*db.DiscussionThreadAction, 0, len(args.Actions))
+	for _, input := range args.Actions {
+		action, err := discussionThreadActionFromInput(ctx, thread, currentUser.user.ID, input, checkCanTriage, checkCanComment)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+
+	updatedThread, err := discussions.InsecureApplyThreadActions(ctx, thread, currentUser.user.ID, actions)
+	if err != nil {
+		return nil, errors.Wrap(err, "ApplyThreadActions")
+	}
+	return &discussionThreadResolver{t: updatedThread}, nil
+}
+
+// discussionThreadActionFromInput resolves an action of the applyThreadActions
+// mutation, checking that the current user may perform it.
+func discussionThreadActionFromInput(ctx context.Context, thread *types.DiscussionThread, currentUserID int32, input *discussionThreadActionInput, checkCanTriage, checkCanComment func() error) (*db.DiscussionThreadAction, error) {
+	n := 0
+	for _, set := range []bool{input.AddLabels != nil, input.RemoveLabels != nil, input.AddAssignee != nil, input.RemoveAssignee != nil, input.Comment != nil, input.Archive != nil} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, errors.New("exactly one field of each action must be set")
+	}
+
+	action := &db.DiscussionThreadAction{}
+	switch {
+	case input.AddLabels != nil || input.RemoveLabels != nil:
+		if err := checkCanTriage(); err != nil {
+			return nil, err
+		}
+		if input.AddLabels != nil {
+			labelIDs, err := discussionThreadLabelIDs(ctx, thread, *input.AddLabels)
+			if err != nil {
+				return nil, err
+			}
+			action.AddLabelIDs = labelIDs
+		} else {
+			labelIDs, err := discussionThreadLabelIDs(ctx, thread, *input.RemoveLabels)
+			if err != nil {
+				return nil, err
+			}
+			action.RemoveLabelIDs = labelIDs
+		}
+		if len(action.AddLabelIDs) == 0 && len(action.RemoveLabelIDs) == 0 {
+			return nil, errors.New("at least one label must be specified")
+		}
+
+	case input.AddAssignee != nil || input.RemoveAssignee != nil:
+		if err := checkCanTriage(); err != nil {
+			return nil, err
+		}
+		assigneeGQLID := input.AddAssignee
+		if assigneeGQLID == nil {
+			assigneeGQLID = input.RemoveAssignee
+		}
+		assigneeID, err := UnmarshalUserID(*assigneeGQLID)
+		if err != nil {
+			return nil, err
+		}
+		assignee, err := db.Users.GetByID(ctx, assigneeID)
+		if err != nil {
+			return nil, err
+		}
+		if input.AddAssignee != nil {
+			action.AddAssigneeUserID = assignee.ID
+		} else {
+			action.RemoveAssigneeUserID = assignee.ID
+		}
+
+	case input.Comment != nil:
+		if err := checkCanComment(); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(*input.Comment) == "" {
+			return nil, errors.New("cannot add empty comments to threads")
+		}
+		if err := ratelimit.CheckCanAddComment(currentUserID); err != nil {
+			return nil, err
+		}
+		action.Comment = &types.DiscussionComment{
+			ThreadID:     thread.ID,
+			AuthorUserID: currentUserID,
+			Contents:     *input.Comment,
+		}
+
+	case input.Archive != nil:
+		if err := checkCanTriage(); err != nil {
+			return nil, err
+		}
+		action.Archive = input.Archive
+	}
+	return action, nil
+}
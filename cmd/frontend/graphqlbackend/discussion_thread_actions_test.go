@@ -0,0 +1,161 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionsMutations_ApplyThreadActions(t *testing.T) {
+	const (
+		wantThreadID = 123
+		wantRepoID   = api.RepoID(1)
+	)
+	users := map[int32]*types.User{
+		1: {ID: 1, Username: "author"},
+		2: {ID: 2, Username: "assignee"},
+		3: {ID: 3, Username: "other"},
+	}
+	setup := func() *[]*db.DiscussionThreadAction {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+			if user, ok := users[actor.FromContext(ctx).UID]; ok {
+				return user, nil
+			}
+			return nil, db.ErrNoCurrentUser
+		}
+		db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return users[id], nil }
+		verifiedAt := time.Now()
+		db.Mocks.UserEmails.ListByUser = func(userID int32) ([]*db.UserEmail, error) {
+			return []*db.UserEmail{{UserID: userID, Email: "a@example.com", VerifiedAt: &verifiedAt}}, nil
+		}
+		db.Mocks.DiscussionRoles.List = func(context.Context, *db.DiscussionRolesListOptions) ([]*types.DiscussionRole, error) {
+			return nil, nil
+		}
+		db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+			if threadID != wantThreadID {
+				t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+			}
+			return &types.DiscussionThread{
+				ID:           wantThreadID,
+				AuthorUserID: 1,
+				TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: wantRepoID},
+			}, nil
+		}
+		db.Mocks.DiscussionLabels.List = func(_ context.Context, opts *db.DiscussionLabelsListOptions) ([]*types.DiscussionLabel, error) {
+			// Only label 1 belongs to the thread's repository.
+			var labels []*types.DiscussionLabel
+			for _, id := range opts.LabelIDs {
+				if id == 1 {
+					labels = append(labels, &types.DiscussionLabel{ID: id, RepoID: wantRepoID})
+				}
+			}
+			return labels, nil
+		}
+		applied := new([]*db.DiscussionThreadAction)
+		db.Mocks.DiscussionThreads.ApplyActions = func(_ context.Context, threadID int64, actorUserID int32, actions []*db.DiscussionThreadAction) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
+			if threadID != wantThreadID || actorUserID != 1 {
+				t.Errorf("got thread %d and actor %d, want %d and 1", threadID, actorUserID, wantThreadID)
+			}
+			*applied = actions
+			return &types.DiscussionThread{ID: threadID}, nil, nil
+		}
+		return applied
+	}
+	apply := func(ctx context.Context, actions ...*discussionThreadActionInput) error {
+		_, err := (&discussionsMutationResolver{}).ApplyThreadActions(ctx, &struct {
+			ThreadID graphql.ID
+			Actions  []*discussionThreadActionInput
+		}{
+			ThreadID: marshalDiscussionThreadID(wantThreadID),
+			Actions:  actions,
+		})
+		return err
+	}
+	labels := func(ids ...int64) *[]graphql.ID {
+		gqlIDs := []graphql.ID{}
+		for _, id := range ids {
+			gqlIDs = append(gqlIDs, marshalDiscussionLabelID(id))
+		}
+		return &gqlIDs
+	}
+	assignee := marshalUserID(2)
+	archive := true
+	comment := "c"
+	authorCtx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("triage", func(t *testing.T) {
+		applied := setup()
+		if err := apply(authorCtx,
+			&discussionThreadActionInput{AddLabels: labels(1)},
+			&discussionThreadActionInput{AddAssignee: &assignee},
+			&discussionThreadActionInput{Archive: &archive},
+		); err != nil {
+			t.Fatal(err)
+		}
+		want := []*db.DiscussionThreadAction{
+			{AddLabelIDs: []int64{1}},
+			{AddAssigneeUserID: 2},
+			{Archive: &archive},
+		}
+		if !reflect.DeepEqual(*applied, want) {
+			t.Errorf("got actions %+v, want %+v", *applied, want)
+		}
+	})
+
+	tests := map[string]struct {
+		ctx     context.Context
+		actions []*discussionThreadActionInput
+		wantErr error
+	}{
+		"no actions": {
+			ctx: authorCtx,
+		},
+		"label in other repository": {
+			ctx:     authorCtx,
+			actions: []*discussionThreadActionInput{{AddLabels: labels(1)}, {AddLabels: labels(2)}},
+		},
+		"more than one field": {
+			ctx:     authorCtx,
+			actions: []*discussionThreadActionInput{{AddLabels: labels(1), Archive: &archive}},
+		},
+		"comment after archive": {
+			ctx:     authorCtx,
+			actions: []*discussionThreadActionInput{{Archive: &archive}, {Comment: &comment}},
+			wantErr: discussions.ErrThreadArchived,
+		},
+		"not triager": {
+			ctx:     actor.WithActor(context.Background(), &actor.Actor{UID: 3}),
+			actions: []*discussionThreadActionInput{{AddAssignee: &assignee}},
+		},
+		"not signed in": {
+			ctx:     context.Background(),
+			actions: []*discussionThreadActionInput{{Comment: &comment}},
+			wantErr: discussions.ErrNoCurrentUser,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			applied := setup()
+			err := apply(test.ctx, test.actions...)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if test.wantErr != nil && errors.Cause(err) != errors.Cause(test.wantErr) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+			if *applied != nil {
+				t.Errorf("got applied actions %+v, want none", *applied)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func (r *discussionsMutationResolver) AddThreadAssignee(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+	Assignee graphql.ID
+}) (*discussionThreadResolver, error) {
+	thread, assignee, err := discussionThreadAssigneeMutationArgs(ctx, args.ThreadID, args.Assignee)
+	if err != nil {
+		return nil, err
+	}
+	added, err := db.DiscussionThreadAssignees.Add(ctx, thread.ID, assignee.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadAssignees.Add")
+	}
+	if added {
+		discussions.LogThreadEvent(ctx, thread.ID, actor.FromContext(ctx).UID, types.DiscussionThreadEventAssigned, types.DiscussionThreadEventData{
+			AssigneeUserID: &assignee.ID,
+		})
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) RemoveThreadAssignee(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+	Assignee graphql.ID
+}) (*discussionThreadResolver, error) {
+	thread, assignee, err := discussionThreadAssigneeMutationArgs(ctx, args.ThreadID, args.Assignee)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := db.DiscussionThreadAssignees.Remove(ctx, thread.ID, assignee.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadAssignees.Remove")
+	}
+	if removed {
+		discussions.LogThreadEvent(ctx, thread.ID, actor.FromContext(ctx).UID, types.DiscussionThreadEventUnassigned, types.DiscussionThreadEventData{
+			AssigneeUserID: &assignee.ID,
+		})
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+// discussionThreadAssigneeMutationArgs resolves the thread and user passed to
+// the addThreadAssignee and removeThreadAssignee mutations, checking that the
+// current user may change the thread's assignees.
+func discussionThreadAssigneeMutationArgs(ctx context.Context, threadGQLID, assigneeGQLID graphql.ID) (*types.DiscussionThread, *types.User, error) {
+	threadID, err := unmarshalDiscussionThreadID(threadGQLID)
+	if err != nil {
+		return nil, nil, err
+	}
+	assigneeID, err := UnmarshalUserID(assigneeGQLID)
+	if err != nil {
+		return nil, nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins, the thread author and triagers can
+	// change the assignees of a thread.
+	if err := discussions.CheckCanTriageThread(ctx, thread); err != nil {
+		return nil, nil, err
+	}
+
+	assignee, err := db.Users.GetByID(ctx, assigneeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return thread, assignee, nil
+}
+
+func (d *discussionThreadResolver) Assignees(ctx context.Context) (*staticUserConnectionResolver, error) {
+	userIDs, err := db.DiscussionThreadAssignees.ListUserIDs(ctx, d.t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return &staticUserConnectionResolver{}, nil
+	}
+	users, err := db.Users.List(ctx, &db.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	// Preserve the order in which the users were assigned.
+	byID := make(map[int32]*types.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	assignees := make([]*types.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if user, ok := byID[userID]; ok {
+			assignees = append(assignees, user)
+		}
+	}
+	return &staticUserConnectionResolver{users: assignees}, nil
+}
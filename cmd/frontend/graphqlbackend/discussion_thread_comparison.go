@@ -0,0 +1,40 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+)
+
+// RepositoryComparison returns the comparison of the thread's repository's
+// default branch (the base) with the branch the thread is about (the head),
+// or nil if the thread is not about a branch.
+func (d *discussionThreadResolver) RepositoryComparison(ctx context.Context) (*RepositoryComparisonResolver, error) {
+	// 🚨 SECURITY: RepositoryByIDInt32 checks that the viewer has access to
+	// the repository.
+	t := d.t.TargetRepo
+	if t == nil || t.Branch == nil {
+		return nil, nil
+	}
+	repo, err := RepositoryByIDInt32(ctx, t.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return repo.Comparison(ctx, &RepositoryComparisonInput{Head: t.Branch})
+}
+
+func (d *discussionThreadResolver) Commits(ctx context.Context, args *graphqlutil.ConnectionArgs) (*gitCommitConnectionResolver, error) {
+	comparison, err := d.RepositoryComparison(ctx)
+	if err != nil || comparison == nil {
+		return nil, err
+	}
+	return comparison.Commits(args), nil
+}
+
+func (d *discussionThreadResolver) ChangedFiles(ctx context.Context, args *graphqlutil.ConnectionArgs) (*fileDiffConnectionResolver, error) {
+	comparison, err := d.RepositoryComparison(ctx)
+	if err != nil || comparison == nil {
+		return nil, err
+	}
+	return comparison.FileDiffs(args), nil
+}
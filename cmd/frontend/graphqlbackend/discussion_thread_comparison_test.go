@@ -0,0 +1,67 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestDiscussionThread_RepositoryComparison(t *testing.T) {
+	resetMocks()
+	defer git.ResetMocks()
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "r"}, nil
+	}
+	git.Mocks.ResolveRevision = func(rev string, _ *git.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID("commit-" + rev), nil
+	}
+	git.Mocks.GetCommit = func(id api.CommitID) (*git.Commit, error) {
+		return &git.Commit{ID: id}, nil
+	}
+	ctx := context.Background()
+
+	t.Run("thread without branch", func(t *testing.T) {
+		r := &discussionThreadResolver{t: &types.DiscussionThread{TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1}}}
+		comparison, err := r.RepositoryComparison(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if comparison != nil {
+			t.Error("expected no comparison")
+		}
+		commits, err := r.Commits(ctx, &graphqlutil.ConnectionArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if commits != nil {
+			t.Error("expected no commits")
+		}
+	})
+
+	t.Run("thread about a branch", func(t *testing.T) {
+		branch := "b"
+		r := &discussionThreadResolver{t: &types.DiscussionThread{TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1, Branch: &branch}}}
+		comparison, err := r.RepositoryComparison(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := comparison.Range().Expr(), "HEAD...b"; got != want {
+			t.Errorf("got range %q, want %q", got, want)
+		}
+		if comparison.head.OID() != "commit-b" {
+			t.Errorf("got head commit %q, want %q", comparison.head.OID(), "commit-b")
+		}
+		commits, err := r.Commits(ctx, &graphqlutil.ConnectionArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := commits.revisionRange, "HEAD..b"; got != want {
+			t.Errorf("got commits range %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+)
+
+// discussionThreadsFilterArgs are the arguments of the discussionThreads
+// connections on users and organizations, which list threads across all
+// repositories.
+type discussionThreadsFilterArgs struct {
+	graphqlutil.ConnectionArgs
+	After      *string
+	OrderBy    *discussionThreadOrder
+	State      *string
+	Repository *graphql.ID
+	Labels     *[]string
+}
+
+func (a *discussionThreadsFilterArgs) listOptions() (*db.DiscussionThreadsListOptions, error) {
+	opt := &db.DiscussionThreadsListOptions{}
+	a.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	if a.After != nil {
+		cursor, err := unmarshalDiscussionThreadsCursor(*a.After)
+		if err != nil {
+			return nil, err
+		}
+		opt.After = cursor
+	}
+	if a.OrderBy != nil {
+		if err := a.OrderBy.set(opt); err != nil {
+			return nil, err
+		}
+	}
+	if a.Repository != nil {
+		repoID, err := UnmarshalRepositoryID(*a.Repository)
+		if err != nil {
+			return nil, err
+		}
+		opt.TargetRepoID = &repoID
+	}
+	if err := setDiscussionThreadsStateAndLabels(opt, a.State, a.Labels); err != nil {
+		return nil, err
+	}
+	return opt, nil
+}
+
+// setDiscussionThreadsStateAndLabels sets the options for the state and
+// labels arguments of the discussionThreads connections.
+//
+// Archived threads are excluded unless the state argument or an "is:"
+// qualifier in the query says otherwise, or specific threads are requested.
+func setDiscussionThreadsStateAndLabels(opt *db.DiscussionThreadsListOptions, state *string, labels *[]string) error {
+	archived, closed := false, false
+	switch {
+	case state == nil:
+		if opt.Archived == nil && len(opt.ThreadIDs) == 0 {
+			opt.Archived = &archived
+		}
+	case *state == "OPEN":
+		opt.Archived = &archived
+		opt.Closed = &closed
+	case *state == "CLOSED":
+		closed = true
+		opt.Archived = &archived
+		opt.Closed = &closed
+	case *state == "ARCHIVED":
+		archived = true
+		opt.Archived = &archived
+		opt.Closed = nil
+	case *state == "STALE":
+		stale := true
+		opt.Stale = &stale
+		opt.Archived = &archived
+		opt.Closed = &closed
+	case *state == "ALL":
+		opt.Archived = nil
+		opt.Closed = nil
+	default:
+		return fmt.Errorf("invalid discussion thread state %q", *state)
+	}
+	if labels != nil {
+		opt.LabelNames = append(opt.LabelNames, *labels...)
+	}
+	return nil
+}
+
+func (r *UserResolver) DiscussionThreads(ctx context.Context, args *struct {
+	discussionThreadsFilterArgs
+	Relation *string
+}) (*discussionThreadsConnectionResolver, error) {
+	if err := viewerCanUseDiscussions(ctx); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt, err := args.listOptions()
+	if err != nil {
+		return nil, err
+	}
+	if args.Relation == nil {
+		opt.InvolvedUserID = r.user.ID
+	} else {
+		switch *args.Relation {
+		case "AUTHORED":
+			opt.AuthorUserIDs = []int32{r.user.ID}
+		case "ASSIGNED":
+			opt.AssigneeUserID = r.user.ID
+		case "MENTIONED":
+			opt.MentionedUserID = r.user.ID
+		default:
+			return nil, fmt.Errorf("invalid discussion thread relation %q", *args.Relation)
+		}
+	}
+	return &discussionThreadsConnectionResolver{opt: opt}, nil
+}
+
+func (o *OrgResolver) DiscussionThreads(ctx context.Context, args *discussionThreadsFilterArgs) (*discussionThreadsConnectionResolver, error) {
+	if err := viewerCanUseDiscussions(ctx); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt, err := args.listOptions()
+	if err != nil {
+		return nil, err
+	}
+	opt.OrgID = o.org.ID
+	return &discussionThreadsConnectionResolver{opt: opt}, nil
+}
@@ -0,0 +1,144 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestUser_DiscussionThreads(t *testing.T) {
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	ctx := context.Background()
+	r := &UserResolver{user: &types.User{ID: 1}}
+
+	strPtr := func(s string) *string { return &s }
+	labels := []string{"bug"}
+	tests := map[string]struct {
+		relation *string
+		check    func(t *testing.T, c *discussionThreadsConnectionResolver)
+	}{
+		"involved": {nil, func(t *testing.T, c *discussionThreadsConnectionResolver) {
+			if c.opt.InvolvedUserID != 1 {
+				t.Errorf("got InvolvedUserID %d, want 1", c.opt.InvolvedUserID)
+			}
+		}},
+		"authored": {strPtr("AUTHORED"), func(t *testing.T, c *discussionThreadsConnectionResolver) {
+			if !reflect.DeepEqual(c.opt.AuthorUserIDs, []int32{1}) {
+				t.Errorf("got AuthorUserIDs %v, want [1]", c.opt.AuthorUserIDs)
+			}
+		}},
+		"assigned": {strPtr("ASSIGNED"), func(t *testing.T, c *discussionThreadsConnectionResolver) {
+			if c.opt.AssigneeUserID != 1 {
+				t.Errorf("got AssigneeUserID %d, want 1", c.opt.AssigneeUserID)
+			}
+		}},
+		"mentioned": {strPtr("MENTIONED"), func(t *testing.T, c *discussionThreadsConnectionResolver) {
+			if c.opt.MentionedUserID != 1 {
+				t.Errorf("got MentionedUserID %d, want 1", c.opt.MentionedUserID)
+			}
+		}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			args := &struct {
+				discussionThreadsFilterArgs
+				Relation *string
+			}{Relation: test.relation}
+			args.State = strPtr("ARCHIVED")
+			args.Labels = &labels
+			c, err := r.DiscussionThreads(ctx, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.check(t, c)
+			if c.opt.Archived == nil || !*c.opt.Archived {
+				t.Errorf("got Archived %v, want true", c.opt.Archived)
+			}
+			if !reflect.DeepEqual(c.opt.LabelNames, labels) {
+				t.Errorf("got LabelNames %v, want %v", c.opt.LabelNames, labels)
+			}
+		})
+	}
+
+	if _, err := r.DiscussionThreads(ctx, &struct {
+		discussionThreadsFilterArgs
+		Relation *string
+	}{Relation: strPtr("WATCHING")}); err == nil {
+		t.Error("expected error for invalid relation")
+	}
+}
+
+func TestOrg_DiscussionThreads(t *testing.T) {
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	state, repoID := "OPEN", MarshalRepositoryID(3)
+	c, err := (&OrgResolver{org: &types.Org{ID: 2}}).DiscussionThreads(context.Background(), &discussionThreadsFilterArgs{
+		State:      &state,
+		Repository: &repoID,
+		OrderBy:    &discussionThreadOrder{Field: "COMMENT_COUNT", Direction: "ASC"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.opt.OrgID != 2 {
+		t.Errorf("got OrgID %d, want 2", c.opt.OrgID)
+	}
+	if c.opt.Archived == nil || *c.opt.Archived {
+		t.Errorf("got Archived %v, want false", c.opt.Archived)
+	}
+	if c.opt.TargetRepoID == nil || *c.opt.TargetRepoID != 3 {
+		t.Errorf("got TargetRepoID %v, want 3", c.opt.TargetRepoID)
+	}
+	if c.opt.OrderBy != db.DiscussionThreadsListCommentCount || !c.opt.AscendingOrder {
+		t.Errorf("got OrderBy %q (ascending %v), want comment_count ascending", c.opt.OrderBy, c.opt.AscendingOrder)
+	}
+
+	if _, err := (&OrgResolver{org: &types.Org{ID: 2}}).DiscussionThreads(context.Background(), &discussionThreadsFilterArgs{
+		OrderBy: &discussionThreadOrder{Field: "AUTHOR", Direction: "ASC"},
+	}); err == nil {
+		t.Error("expected error for invalid order field")
+	}
+}
+
+func TestSetDiscussionThreadsStateAndLabels(t *testing.T) {
+	archived, notArchived := true, false
+	closed, notClosed := true, false
+	tests := map[string]struct {
+		opt          db.DiscussionThreadsListOptions
+		state        string
+		wantArchived *bool
+		wantClosed   *bool
+	}{
+		"default":               {wantArchived: &notArchived},
+		"default, is:archived":  {opt: db.DiscussionThreadsListOptions{Archived: &archived}, wantArchived: &archived},
+		"default, thread IDs":   {opt: db.DiscussionThreadsListOptions{ThreadIDs: []int64{1}}},
+		"OPEN":                  {state: "OPEN", wantArchived: &notArchived, wantClosed: &notClosed},
+		"CLOSED":                {state: "CLOSED", wantArchived: &notArchived, wantClosed: &closed},
+		"ARCHIVED":              {state: "ARCHIVED", wantArchived: &archived},
+		"ALL":                   {state: "ALL"},
+		"ALL, overrides query":  {opt: db.DiscussionThreadsListOptions{Archived: &archived}, state: "ALL"},
+		"OPEN, overrides query": {opt: db.DiscussionThreadsListOptions{Archived: &archived, Closed: &closed}, state: "OPEN", wantArchived: &notArchived, wantClosed: &notClosed},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var state *string
+			if test.state != "" {
+				state = &test.state
+			}
+			opt := test.opt
+			if err := setDiscussionThreadsStateAndLabels(&opt, state, nil); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(opt.Archived, test.wantArchived) {
+				t.Errorf("got Archived %v, want %v", opt.Archived, test.wantArchived)
+			}
+			if !reflect.DeepEqual(opt.Closed, test.wantClosed) {
+				t.Errorf("got Closed %v, want %v", opt.Closed, test.wantClosed)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func (r *discussionsMutationResolver) AddThreadDependency(ctx context.Context, args *struct {
+	Thread    graphql.ID
+	BlockedBy graphql.ID
+}) (*discussionThreadResolver, error) {
+	return updateDiscussionThreadDependency(ctx, args.Thread, args.BlockedBy, true)
+}
+
+func (r *discussionsMutationResolver) RemoveThreadDependency(ctx context.Context, args *struct {
+	Thread    graphql.ID
+	BlockedBy graphql.ID
+}) (*discussionThreadResolver, error) {
+	return updateDiscussionThreadDependency(ctx, args.Thread, args.BlockedBy, false)
+}
+
+func updateDiscussionThreadDependency(ctx context.Context, threadGQLID, blockedByGQLID graphql.ID, add bool) (*discussionThreadResolver, error) {
+	threadID, err := unmarshalDiscussionThreadID(threadGQLID)
+	if err != nil {
+		return nil, err
+	}
+	blockedByThreadID, err := unmarshalDiscussionThreadID(blockedByGQLID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins, the thread author and triagers can
+	// change the thread's dependencies, and only on threads they can access
+	// (which DiscussionThreads.Get checks).
+	if err := discussions.CheckCanTriageThread(ctx, thread); err != nil {
+		return nil, err
+	}
+	blockedBy, err := db.DiscussionThreads.Get(ctx, blockedByThreadID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed bool
+	kind := types.DiscussionThreadEventDependencyRemoved
+	if add {
+		kind = types.DiscussionThreadEventDependencyAdded
+		changed, err = db.DiscussionThreadDependencies.Add(ctx, thread.ID, blockedBy.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionThreadDependencies.Add")
+		}
+	} else {
+		changed, err = db.DiscussionThreadDependencies.Remove(ctx, thread.ID, blockedBy.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionThreadDependencies.Remove")
+		}
+	}
+	if changed {
+		discussions.LogThreadEvent(ctx, thread.ID, actor.FromContext(ctx).UID, kind, types.DiscussionThreadEventData{
+			DependencyThreadID: &blockedBy.ID,
+		})
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (d *discussionThreadResolver) Dependencies(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadsConnectionResolver {
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt := &db.DiscussionThreadsListOptions{BlocksThreadID: d.t.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadsConnectionResolver{opt: opt}
+}
+
+func (d *discussionThreadResolver) Dependents(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadsConnectionResolver {
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt := &db.DiscussionThreadsListOptions{BlockedByThreadID: d.t.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadsConnectionResolver{opt: opt}
+}
+
+func (r *discussionThreadTimelineItemResolver) DependencyThread(ctx context.Context) (*discussionThreadResolver, error) {
+	if r.e.Data.DependencyThreadID == nil {
+		return nil, nil
+	}
+	return discussionThreadByIDOrNil(ctx, *r.e.Data.DependencyThreadID)
+}
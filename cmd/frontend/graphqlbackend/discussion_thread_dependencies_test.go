@@ -0,0 +1,85 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionsMutations_ThreadDependencies(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return &types.User{ID: id}, nil }
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		// Thread 3 is authored by another user.
+		authorUserID := int32(1)
+		if threadID == 3 {
+			authorUserID = 2
+		}
+		return &types.DiscussionThread{ID: threadID, AuthorUserID: authorUserID}, nil
+	}
+	dependencies := map[[2]int64]bool{}
+	db.Mocks.DiscussionThreadDependencies.Add = func(_ context.Context, threadID, blockedByThreadID int64) (bool, error) {
+		if threadID == blockedByThreadID {
+			return false, db.ErrThreadDependencyCycle
+		}
+		added := !dependencies[[2]int64{threadID, blockedByThreadID}]
+		dependencies[[2]int64{threadID, blockedByThreadID}] = true
+		return added, nil
+	}
+	db.Mocks.DiscussionThreadDependencies.Remove = func(_ context.Context, threadID, blockedByThreadID int64) (bool, error) {
+		removed := dependencies[[2]int64{threadID, blockedByThreadID}]
+		delete(dependencies, [2]int64{threadID, blockedByThreadID})
+		return removed, nil
+	}
+	var events []types.DiscussionThreadEventKind
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		if *newEvent.Data.DependencyThreadID != 2 {
+			t.Errorf("got dependency thread %d, want 2", *newEvent.Data.DependencyThreadID)
+		}
+		events = append(events, newEvent.Kind)
+		return newEvent, nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	r := &discussionsMutationResolver{}
+	args := func(thread, blockedBy int64) *struct {
+		Thread    graphql.ID
+		BlockedBy graphql.ID
+	} {
+		return &struct {
+			Thread    graphql.ID
+			BlockedBy graphql.ID
+		}{Thread: marshalDiscussionThreadID(thread), BlockedBy: marshalDiscussionThreadID(blockedBy)}
+	}
+
+	// Adding an existing dependency (or removing a nonexistent one) does not
+	// add a timeline event.
+	for i := 0; i < 2; i++ {
+		if _, err := r.AddThreadDependency(ctx, args(1, 2)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.RemoveThreadDependency(ctx, args(1, 2)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []types.DiscussionThreadEventKind{types.DiscussionThreadEventDependencyAdded, types.DiscussionThreadEventDependencyRemoved}; !reflect.DeepEqual(events, want) {
+		t.Errorf("got events %v, want %v", events, want)
+	}
+
+	if _, err := r.AddThreadDependency(ctx, args(1, 1)); err == nil {
+		t.Error("expected error adding a dependency on the thread itself")
+	}
+	if _, err := r.AddThreadDependency(ctx, args(3, 2)); err == nil {
+		t.Error("expected error adding a dependency to another user's thread")
+	}
+	if len(dependencies) != 0 {
+		t.Errorf("got dependencies %v, want none", dependencies)
+	}
+}
@@ -0,0 +1,211 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+// discussionDiagnosticSeverities lists the diagnostic severities, most severe
+// first.
+var discussionDiagnosticSeverities = []types.DiscussionDiagnosticSeverity{
+	types.DiscussionDiagnosticError,
+	types.DiscussionDiagnosticWarning,
+	types.DiscussionDiagnosticInfo,
+	types.DiscussionDiagnosticHint,
+}
+
+type discussionThreadDiagnosticInput struct {
+	Path           string
+	StartLine      int32
+	StartCharacter int32
+	EndLine        int32
+	EndCharacter   int32
+	Message        string
+	Severity       string
+}
+
+func (r *discussionsMutationResolver) SetThreadDiagnostics(ctx context.Context, args *struct {
+	Thread      graphql.ID
+	Source      string
+	Diagnostics []*discussionThreadDiagnosticInput
+}) (*discussionThreadResolver, error) {
+	threadID, err := unmarshalDiscussionThreadID(args.Thread)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins, the thread author and triagers can set
+	// the thread's diagnostics, and only on threads they can access (which
+	// DiscussionThreads.Get checks).
+	if err := discussions.CheckCanTriageThread(ctx, thread); err != nil {
+		return nil, err
+	}
+
+	diagnostics := make([]*types.DiscussionThreadDiagnostic, len(args.Diagnostics))
+	for i, d := range args.Diagnostics {
+		diagnostics[i] = &types.DiscussionThreadDiagnostic{
+			Path:           d.Path,
+			StartLine:      d.StartLine,
+			StartCharacter: d.StartCharacter,
+			EndLine:        d.EndLine,
+			EndCharacter:   d.EndCharacter,
+			Message:        d.Message,
+			Severity:       types.DiscussionDiagnosticSeverity(strings.ToLower(d.Severity)),
+		}
+	}
+	if err := db.DiscussionThreadDiagnostics.Set(ctx, thread.ID, args.Source, diagnostics); err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadDiagnostics.Set")
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (d *discussionThreadResolver) Diagnostics(args *struct {
+	graphqlutil.ConnectionArgs
+	Severity *string
+}) *discussionThreadDiagnosticsConnectionResolver {
+	// 🚨 SECURITY: Anyone with access to the thread also has access to its
+	// diagnostics, for the same reason as the thread's comments.
+	opt := &db.DiscussionThreadDiagnosticsListOptions{ThreadID: d.t.ID}
+	if args.Severity != nil {
+		opt.Severity = types.DiscussionDiagnosticSeverity(strings.ToLower(*args.Severity))
+	}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadDiagnosticsConnectionResolver{opt: opt}
+}
+
+type discussionThreadDiagnosticResolver struct {
+	d *types.DiscussionThreadDiagnostic
+}
+
+func (r *discussionThreadDiagnosticResolver) Source() string { return r.d.Source }
+
+func (r *discussionThreadDiagnosticResolver) Path() string { return r.d.Path }
+
+func (r *discussionThreadDiagnosticResolver) Range() RangeResolver {
+	return NewRangeResolver(lsp.Range{
+		Start: lsp.Position{Line: int(r.d.StartLine), Character: int(r.d.StartCharacter)},
+		End:   lsp.Position{Line: int(r.d.EndLine), Character: int(r.d.EndCharacter)},
+	})
+}
+
+func (r *discussionThreadDiagnosticResolver) Message() string { return r.d.Message }
+
+func (r *discussionThreadDiagnosticResolver) Severity() string {
+	return strings.ToUpper(string(r.d.Severity))
+}
+
+type discussionThreadDiagnosticSeverityCountResolver struct {
+	severity types.DiscussionDiagnosticSeverity
+	count    int
+}
+
+func (r *discussionThreadDiagnosticSeverityCountResolver) Severity() string {
+	return strings.ToUpper(string(r.severity))
+}
+
+func (r *discussionThreadDiagnosticSeverityCountResolver) Count() int32 { return int32(r.count) }
+
+// discussionThreadDiagnosticsConnectionResolver resolves a list of discussion
+// thread diagnostics.
+//
+// 🚨 SECURITY: When instantiating a
+// discussionThreadDiagnosticsConnectionResolver value, the caller MUST check
+// permissions.
+type discussionThreadDiagnosticsConnectionResolver struct {
+	opt *db.DiscussionThreadDiagnosticsListOptions
+
+	// cache results because they are used by multiple fields
+	once        sync.Once
+	diagnostics []*types.DiscussionThreadDiagnostic
+	err         error
+
+	countsOnce sync.Once
+	counts     map[types.DiscussionDiagnosticSeverity]int
+	countsErr  error
+}
+
+func (r *discussionThreadDiagnosticsConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionThreadDiagnostic, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.diagnostics, r.err = db.DiscussionThreadDiagnostics.List(ctx, &opt2)
+	})
+	return r.diagnostics, r.err
+}
+
+func (r *discussionThreadDiagnosticsConnectionResolver) computeCounts(ctx context.Context) (map[types.DiscussionDiagnosticSeverity]int, error) {
+	r.countsOnce.Do(func() {
+		withoutLimit := *r.opt
+		withoutLimit.LimitOffset = nil
+		r.counts, r.countsErr = db.DiscussionThreadDiagnostics.CountBySeverity(ctx, &withoutLimit)
+	})
+	return r.counts, r.countsErr
+}
+
+func (r *discussionThreadDiagnosticsConnectionResolver) Nodes(ctx context.Context) ([]*discussionThreadDiagnosticResolver, error) {
+	diagnostics, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(diagnostics) > r.opt.Limit {
+		diagnostics = diagnostics[:r.opt.Limit]
+	}
+
+	l := make([]*discussionThreadDiagnosticResolver, len(diagnostics))
+	for i, d := range diagnostics {
+		l[i] = &discussionThreadDiagnosticResolver{d: d}
+	}
+	return l, nil
+}
+
+func (r *discussionThreadDiagnosticsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	counts, err := r.computeCounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var total int
+	for _, count := range counts {
+		total += count
+	}
+	return int32(total), nil
+}
+
+func (r *discussionThreadDiagnosticsConnectionResolver) CountsBySeverity(ctx context.Context) ([]*discussionThreadDiagnosticSeverityCountResolver, error) {
+	counts, err := r.computeCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l := []*discussionThreadDiagnosticSeverityCountResolver{}
+	for _, severity := range discussionDiagnosticSeverities {
+		if counts[severity] > 0 {
+			l = append(l, &discussionThreadDiagnosticSeverityCountResolver{severity: severity, count: counts[severity]})
+		}
+	}
+	return l, nil
+}
+
+func (r *discussionThreadDiagnosticsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	diagnostics, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(diagnostics) > r.opt.Limit), nil
+}
@@ -0,0 +1,159 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionsMutations_SetThreadDiagnostics(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return &types.User{ID: id}, nil }
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	const wantThreadID = 123
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		// Thread 3 is authored by another user.
+		authorUserID := int32(1)
+		if threadID == 3 {
+			authorUserID = 2
+		}
+		return &types.DiscussionThread{ID: threadID, AuthorUserID: authorUserID}, nil
+	}
+	var stored []*types.DiscussionThreadDiagnostic
+	db.Mocks.DiscussionThreadDiagnostics.Set = func(_ context.Context, threadID int64, source string, diagnostics []*types.DiscussionThreadDiagnostic) error {
+		if threadID != wantThreadID {
+			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+		}
+		for _, d := range diagnostics {
+			d2 := *d
+			d2.ThreadID = threadID
+			d2.Source = source
+			stored = append(stored, &d2)
+		}
+		return nil
+	}
+	db.Mocks.DiscussionThreadDiagnostics.List = func(_ context.Context, opts *db.DiscussionThreadDiagnosticsListOptions) ([]*types.DiscussionThreadDiagnostic, error) {
+		if opts.ThreadID != wantThreadID {
+			t.Errorf("got ThreadID %v, want %v", opts.ThreadID, wantThreadID)
+		}
+		return stored, nil
+	}
+	db.Mocks.DiscussionThreadDiagnostics.CountBySeverity = func(context.Context, *db.DiscussionThreadDiagnosticsListOptions) (map[types.DiscussionDiagnosticSeverity]int, error) {
+		counts := map[types.DiscussionDiagnosticSeverity]int{}
+		for _, d := range stored {
+			counts[d.Severity]++
+		}
+		return counts, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation {
+					discussions {
+						setThreadDiagnostics(
+							thread: "RGlzY3Vzc2lvblRocmVhZDoiM2Yi",
+							source: "lint",
+							diagnostics: [
+								{path: "a.go", startLine: 1, startCharacter: 2, endLine: 1, endCharacter: 5, message: "unused", severity: WARNING},
+								{path: "a.go", startLine: 3, startCharacter: 0, endLine: 4, endCharacter: 0, message: "undefined", severity: ERROR},
+								{path: "b.go", startLine: 0, startCharacter: 0, endLine: 0, endCharacter: 0, message: "shadowed", severity: WARNING}
+							]
+						) {
+							diagnostics(first: 1) {
+								nodes {
+									source
+									path
+									range {
+										start { line character }
+										end { line character }
+									}
+									message
+									severity
+								}
+								totalCount
+								countsBySeverity {
+									severity
+									count
+								}
+								pageInfo {
+									hasNextPage
+								}
+							}
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussions": {
+						"setThreadDiagnostics": {
+							"diagnostics": {
+								"nodes": [
+									{
+										"source": "lint",
+										"path": "a.go",
+										"range": {
+											"start": {"line": 1, "character": 2},
+											"end": {"line": 1, "character": 5}
+										},
+										"message": "unused",
+										"severity": "WARNING"
+									}
+								],
+								"totalCount": 3,
+								"countsBySeverity": [
+									{"severity": "ERROR", "count": 1},
+									{"severity": "WARNING", "count": 2}
+								],
+								"pageInfo": {
+									"hasNextPage": true
+								}
+							}
+						}
+					}
+				}
+			`,
+		},
+	})
+
+	t.Run("severity filter", func(t *testing.T) {
+		severity := "ERROR"
+		r := (&discussionThreadResolver{t: &types.DiscussionThread{ID: wantThreadID}}).Diagnostics(&struct {
+			graphqlutil.ConnectionArgs
+			Severity *string
+		}{Severity: &severity})
+		if r.opt.Severity != types.DiscussionDiagnosticError {
+			t.Errorf("got severity %q, want %q", r.opt.Severity, types.DiscussionDiagnosticError)
+		}
+	})
+
+	t.Run("non-triager", func(t *testing.T) {
+		stored = nil
+		_, err := (&discussionsMutationResolver{}).SetThreadDiagnostics(context.Background(), &struct {
+			Thread      graphql.ID
+			Source      string
+			Diagnostics []*discussionThreadDiagnosticInput
+		}{
+			Thread:      marshalDiscussionThreadID(3),
+			Source:      "lint",
+			Diagnostics: []*discussionThreadDiagnosticInput{{Path: "a.go", Message: "m", Severity: "ERROR"}},
+		})
+		if err == nil {
+			t.Error("expected error setting diagnostics on another user's thread")
+		}
+		if len(stored) != 0 {
+			t.Errorf("got %d stored diagnostics, want none", len(stored))
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func (r *discussionsMutationResolver) MarkThreadAsDuplicate(ctx context.Context, args *struct {
+	Thread   graphql.ID
+	Original graphql.ID
+}) (*discussionThreadResolver, error) {
+	threadID, err := unmarshalDiscussionThreadID(args.Thread)
+	if err != nil {
+		return nil, err
+	}
+	originalID, err := unmarshalDiscussionThreadID(args.Original)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins, the thread author and triagers can mark
+	// a thread as a duplicate, and only of a thread they can access (which
+	// DiscussionThreads.Get checks).
+	if err := discussions.CheckCanTriageThread(ctx, thread); err != nil {
+		return nil, err
+	}
+	original, err := db.DiscussionThreads.Get(ctx, originalID)
+	if err != nil {
+		return nil, err
+	}
+	if original.ID == thread.ID {
+		return nil, errors.New("a thread cannot be a duplicate of itself")
+	}
+	if original.DuplicateOfThreadID != nil {
+		return nil, discussions.NewError(discussions.ErrorCodeInvalidState, "the original thread is itself marked as a duplicate")
+	}
+
+	archive := true
+	updated, err := db.DiscussionThreads.Update(ctx, thread.ID, &db.DiscussionThreadsUpdateOptions{
+		Archive:             &archive,
+		DuplicateOfThreadID: &original.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Update")
+	}
+
+	// Subscribe the duplicate's subscribers to the original, so that
+	// discussion continues in one place. Users who explicitly unsubscribed
+	// from the original stay unsubscribed.
+	userIDs, err := db.DiscussionThreadSubscriptions.ListSubscribedUserIDs(ctx, thread.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadSubscriptions.ListSubscribedUserIDs")
+	}
+	discussions.AutoSubscribe(ctx, original.ID, userIDs...)
+
+	actorUserID := actor.FromContext(ctx).UID
+	discussions.LogThreadEvent(ctx, thread.ID, actorUserID, types.DiscussionThreadEventMarkedAsDuplicate, types.DiscussionThreadEventData{
+		OriginalThreadID: &original.ID,
+	})
+	if thread.ArchivedAt == nil {
+		discussions.LogThreadEvent(ctx, thread.ID, actorUserID, types.DiscussionThreadEventArchived, types.DiscussionThreadEventData{})
+	}
+	return &discussionThreadResolver{t: updated}, nil
+}
+
+func (d *discussionThreadResolver) DuplicateOf(ctx context.Context) (*discussionThreadResolver, error) {
+	if d.t.DuplicateOfThreadID == nil {
+		return nil, nil
+	}
+	return discussionThreadByIDOrNil(ctx, *d.t.DuplicateOfThreadID)
+}
+
+func (d *discussionThreadResolver) Duplicates(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadsConnectionResolver {
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt := &db.DiscussionThreadsListOptions{DuplicateOfThreadID: d.t.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadsConnectionResolver{opt: opt}
+}
+
+func (r *discussionThreadTimelineItemResolver) OriginalThread(ctx context.Context) (*discussionThreadResolver, error) {
+	if r.e.Data.OriginalThreadID == nil {
+		return nil, nil
+	}
+	return discussionThreadByIDOrNil(ctx, *r.e.Data.OriginalThreadID)
+}
+
+// discussionThreadByIDOrNil returns the thread, or nil if it does not exist
+// (e.g. because it has since been deleted).
+func discussionThreadByIDOrNil(ctx context.Context, threadID int64) (*discussionThreadResolver, error) {
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		if _, ok := err.(*db.ErrThreadNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
@@ -0,0 +1,99 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionsMutations_MarkThreadAsDuplicate(t *testing.T) {
+	const (
+		duplicateID = 1
+		originalID  = 2
+	)
+	resetMocks()
+	var originalDuplicateOf *int64
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		thread := &types.DiscussionThread{ID: threadID, AuthorUserID: 1}
+		if threadID == originalID {
+			thread.DuplicateOfThreadID = originalDuplicateOf
+		}
+		return thread, nil
+	}
+	var update *db.DiscussionThreadsUpdateOptions
+	db.Mocks.DiscussionThreads.Update = func(_ context.Context, threadID int64, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error) {
+		update = opts
+		return &types.DiscussionThread{ID: threadID, DuplicateOfThreadID: opts.DuplicateOfThreadID}, nil
+	}
+	db.Mocks.DiscussionThreadSubscriptions.ListSubscribedUserIDs = func(_ context.Context, threadID int64) ([]int32, error) {
+		if threadID != duplicateID {
+			t.Errorf("got subscribers of thread %d, want %d", threadID, duplicateID)
+		}
+		return []int32{1, 3}, nil
+	}
+	var subscribed []int32
+	db.Mocks.DiscussionThreadSubscriptions.AutoSubscribe = func(_ context.Context, threadID int64, userIDs []int32) error {
+		if threadID != originalID {
+			t.Errorf("got subscribers added to thread %d, want %d", threadID, originalID)
+		}
+		subscribed = userIDs
+		return nil
+	}
+	var events []types.DiscussionThreadEventKind
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		events = append(events, newEvent.Kind)
+		return newEvent, nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	markAsDuplicate := func(thread, original int64) (*discussionThreadResolver, error) {
+		return (&discussionsMutationResolver{}).MarkThreadAsDuplicate(ctx, &struct {
+			Thread   graphql.ID
+			Original graphql.ID
+		}{Thread: marshalDiscussionThreadID(thread), Original: marshalDiscussionThreadID(original)})
+	}
+
+	thread, err := markAsDuplicate(duplicateID, originalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.Archive == nil || !*update.Archive || update.DuplicateOfThreadID == nil || *update.DuplicateOfThreadID != originalID {
+		t.Errorf("got update %+v, want the thread to be archived and marked as a duplicate of %d", update, originalID)
+	}
+	if thread.t.DuplicateOfThreadID == nil || *thread.t.DuplicateOfThreadID != originalID {
+		t.Errorf("got duplicate of %v, want %d", thread.t.DuplicateOfThreadID, originalID)
+	}
+	if want := []int32{1, 3}; !reflect.DeepEqual(subscribed, want) {
+		t.Errorf("got subscribed %v, want %v", subscribed, want)
+	}
+	if want := []types.DiscussionThreadEventKind{types.DiscussionThreadEventMarkedAsDuplicate, types.DiscussionThreadEventArchived}; !reflect.DeepEqual(events, want) {
+		t.Errorf("got events %v, want %v", events, want)
+	}
+
+	t.Run("duplicate of itself", func(t *testing.T) {
+		if _, err := markAsDuplicate(duplicateID, duplicateID); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("original is a duplicate", func(t *testing.T) {
+		id := int64(3)
+		originalDuplicateOf = &id
+		defer func() { originalDuplicateOf = nil }()
+		if _, err := markAsDuplicate(duplicateID, originalID); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestDiscussionThread_Duplicates(t *testing.T) {
+	r := &discussionThreadResolver{t: &types.DiscussionThread{ID: 1}}
+	if opt := r.Duplicates(&struct{ graphqlutil.ConnectionArgs }{}).opt; opt.DuplicateOfThreadID != 1 {
+		t.Errorf("got duplicates options %+v, want duplicates of thread 1", opt)
+	}
+}
@@ -0,0 +1,172 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func marshalDiscussionThreadEventID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionThreadTimelineItem", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionThreadEventID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+func (d *discussionThreadResolver) TimelineItems(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadTimelineItemsConnectionResolver {
+	// 🚨 SECURITY: Anyone with access to the thread also has access to its
+	// timeline, for the same reason as the thread's comments.
+	opt := &db.DiscussionThreadEventsListOptions{ThreadID: d.t.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadTimelineItemsConnectionResolver{opt: opt}
+}
+
+type discussionThreadTimelineItemResolver struct {
+	e *types.DiscussionThreadEvent
+}
+
+func (r *discussionThreadTimelineItemResolver) ID() graphql.ID {
+	return marshalDiscussionThreadEventID(r.e.ID)
+}
+
+func (r *discussionThreadTimelineItemResolver) Kind() string { return string(r.e.Kind) }
+
+func (r *discussionThreadTimelineItemResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	return UserByIDInt32(ctx, r.e.ActorUserID)
+}
+
+func (r *discussionThreadTimelineItemResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.e.CreatedAt}
+}
+
+func (r *discussionThreadTimelineItemResolver) PreviousTitle() *string { return r.e.Data.PreviousTitle }
+
+func (r *discussionThreadTimelineItemResolver) Title() *string { return r.e.Data.Title }
+
+func (r *discussionThreadTimelineItemResolver) Label(ctx context.Context) (*discussionLabelResolver, error) {
+	if r.e.Data.LabelID == nil {
+		return nil, nil
+	}
+	label, err := db.DiscussionLabels.Get(ctx, *r.e.Data.LabelID)
+	if err != nil {
+		if _, ok := err.(*db.ErrLabelNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &discussionLabelResolver{l: label}, nil
+}
+
+func (r *discussionThreadTimelineItemResolver) Assignee(ctx context.Context) (*UserResolver, error) {
+	if r.e.Data.AssigneeUserID == nil {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, *r.e.Data.AssigneeUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *discussionThreadTimelineItemResolver) Rule(ctx context.Context) (*discussionRuleResolver, error) {
+	if r.e.Data.RuleID == nil {
+		return nil, nil
+	}
+	rule, err := db.DiscussionRules.Get(ctx, *r.e.Data.RuleID)
+	if err != nil {
+		if _, ok := err.(*db.ErrRuleNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &discussionRuleResolver{r: rule}, nil
+}
+
+func (r *discussionThreadTimelineItemResolver) Comment(ctx context.Context) (*discussionCommentResolver, error) {
+	if r.e.Data.CommentID == nil {
+		return nil, nil
+	}
+	comment, err := db.DiscussionComments.Get(ctx, *r.e.Data.CommentID)
+	if err != nil {
+		if _, ok := err.(*db.ErrCommentNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &discussionCommentResolver{c: comment}, nil
+}
+
+// discussionThreadTimelineItemsConnectionResolver resolves a list of
+// discussion thread timeline items.
+//
+// 🚨 SECURITY: When instantiating an
+// discussionThreadTimelineItemsConnectionResolver value, the caller MUST check
+// permissions.
+type discussionThreadTimelineItemsConnectionResolver struct {
+	opt *db.DiscussionThreadEventsListOptions
+
+	// cache results because they are used by multiple fields
+	once   sync.Once
+	events []*types.DiscussionThreadEvent
+	err    error
+}
+
+func (r *discussionThreadTimelineItemsConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionThreadEvent, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.events, r.err = db.DiscussionThreadEvents.List(ctx, &opt2)
+	})
+	return r.events, r.err
+}
+
+func (r *discussionThreadTimelineItemsConnectionResolver) Nodes(ctx context.Context) ([]*discussionThreadTimelineItemResolver, error) {
+	events, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(events) > r.opt.Limit {
+		events = events[:r.opt.Limit]
+	}
+
+	var l []*discussionThreadTimelineItemResolver
+	for _, event := range events {
+		l = append(l, &discussionThreadTimelineItemResolver{e: event})
+	}
+	return l, nil
+}
+
+func (r *discussionThreadTimelineItemsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionThreadEvents.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionThreadTimelineItemsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	events, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(events) > r.opt.Limit), nil
+}
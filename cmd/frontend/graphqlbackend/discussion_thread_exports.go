@@ -0,0 +1,52 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+)
+
+func (r *discussionsMutationResolver) ExportThreads(ctx context.Context, args *struct {
+	Repository   *graphql.ID
+	Organization *graphql.ID
+	Format       string
+}) (string, error) {
+	format, err := discussions.ParseExportFormat(strings.ToLower(args.Format))
+	if err != nil {
+		return "", err
+	}
+	var target discussions.ExportTarget
+	if args.Repository != nil {
+		repoID, err := UnmarshalRepositoryID(*args.Repository)
+		if err != nil {
+			return "", err
+		}
+		repo, err := db.Repos.Get(ctx, repoID)
+		if err != nil {
+			return "", err
+		}
+		target.RepoName = repo.Name
+	}
+	if args.Organization != nil {
+		orgID, err := UnmarshalOrgID(*args.Organization)
+		if err != nil {
+			return "", err
+		}
+		org, err := db.Orgs.GetByID(ctx, orgID)
+		if err != nil {
+			return "", err
+		}
+		target.OrgName = org.Name
+	}
+
+	// 🚨 SECURITY: Check that the user can export the threads now, so that
+	// errors are reported here instead of when the URL is requested (where
+	// access is checked again).
+	if _, err := discussions.ExportListOptions(ctx, target); err != nil {
+		return "", err
+	}
+	return discussions.ExportURL(target, format).String(), nil
+}
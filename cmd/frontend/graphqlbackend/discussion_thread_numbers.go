@@ -0,0 +1,29 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+)
+
+func (d *discussionThreadResolver) Number() *int32 {
+	if d.t.TargetRepo == nil {
+		return nil
+	}
+	return &d.t.TargetRepo.Number
+}
+
+func (r *RepositoryResolver) DiscussionThread(ctx context.Context, args *struct {
+	Number int32
+}) (*discussionThreadResolver, error) {
+	// 🚨 SECURITY: The viewer can access the repository (because they
+	// resolved it), and GetByNumber checks the repository permissions anyway.
+	thread, err := db.DiscussionThreads.GetByNumber(ctx, r.repo.ID, args.Number)
+	if err != nil {
+		if _, ok := err.(*db.ErrThreadNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
@@ -0,0 +1,35 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestRepository_DiscussionThread(t *testing.T) {
+	resetMocks()
+	db.Mocks.DiscussionThreads.GetByNumber = func(repoID api.RepoID, number int32) (*types.DiscussionThread, error) {
+		if repoID != 1 {
+			t.Errorf("got repo %d, want 1", repoID)
+		}
+		if number != 2 {
+			return nil, &db.ErrThreadNotFound{}
+		}
+		return &types.DiscussionThread{ID: 3, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: repoID, Number: number}}, nil
+	}
+	r := &RepositoryResolver{repo: &types.Repo{ID: 1}}
+
+	thread, err := r.DiscussionThread(context.Background(), &struct{ Number int32 }{Number: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := thread.Number(); got == nil || *got != 2 {
+		t.Errorf("got number %v, want 2", got)
+	}
+	if thread, err := r.DiscussionThread(context.Background(), &struct{ Number int32 }{Number: 4}); err != nil || thread != nil {
+		t.Errorf("got thread %v and error %v, want nil", thread, err)
+	}
+}
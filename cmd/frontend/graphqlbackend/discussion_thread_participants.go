@@ -0,0 +1,90 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func (d *discussionThreadResolver) Participants(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadParticipantsConnectionResolver {
+	// 🚨 SECURITY: Anyone with access to the thread also has access to its
+	// participants, who are shown on its comments and assignees anyway.
+	first := args.First
+	if first == nil {
+		defaultFirst := int32(graphqlutil.DefaultFirst)
+		first = &defaultFirst
+	}
+	return &discussionThreadParticipantsConnectionResolver{threadID: d.t.ID, first: first}
+}
+
+// discussionThreadParticipantsConnectionResolver resolves the participants of
+// a discussion thread.
+//
+// 🚨 SECURITY: When instantiating a
+// discussionThreadParticipantsConnectionResolver value, the caller MUST check
+// permissions.
+type discussionThreadParticipantsConnectionResolver struct {
+	threadID int64
+	first    *int32
+
+	// cache results because they are used by multiple fields
+	once    sync.Once
+	userIDs []int32
+	err     error
+}
+
+func (r *discussionThreadParticipantsConnectionResolver) compute(ctx context.Context) ([]int32, error) {
+	r.once.Do(func() {
+		r.userIDs, r.err = db.DiscussionThreads.ListParticipantUserIDs(ctx, r.threadID)
+	})
+	return r.userIDs, r.err
+}
+
+func (r *discussionThreadParticipantsConnectionResolver) Nodes(ctx context.Context) ([]*UserResolver, error) {
+	userIDs, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.first != nil && len(userIDs) > int(*r.first) {
+		userIDs = userIDs[:*r.first]
+	}
+	if len(userIDs) == 0 {
+		return []*UserResolver{}, nil
+	}
+
+	// Fetch all of the users in a single query, preserving the order in which
+	// they began participating.
+	users, err := db.Users.List(ctx, &db.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int32]*types.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	l := make([]*UserResolver, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if user, ok := byID[userID]; ok {
+			l = append(l, &UserResolver{user: user})
+		}
+	}
+	return l, nil
+}
+
+func (r *discussionThreadParticipantsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	userIDs, err := r.compute(ctx)
+	return int32(len(userIDs)), err
+}
+
+func (r *discussionThreadParticipantsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	userIDs, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.first != nil && len(userIDs) > int(*r.first)), nil
+}
@@ -0,0 +1,60 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionThread_Participants(t *testing.T) {
+	resetMocks()
+	db.Mocks.DiscussionThreads.ListParticipantUserIDs = func(_ context.Context, threadID int64) ([]int32, error) {
+		if threadID != 1 {
+			t.Errorf("got threadID %d, want 1", threadID)
+		}
+		return []int32{3, 1, 2}, nil
+	}
+	var listCalls int
+	db.Mocks.Users.List = func(_ context.Context, opt *db.UsersListOptions) ([]*types.User, error) {
+		listCalls++
+		var users []*types.User
+		for _, id := range opt.UserIDs {
+			users = append(users, &types.User{ID: id})
+		}
+		// The users are not returned in participation order.
+		for i, j := 0, len(users)-1; i < j; i, j = i+1, j-1 {
+			users[i], users[j] = users[j], users[i]
+		}
+		return users, nil
+	}
+	ctx := context.Background()
+
+	first := int32(2)
+	r := (&discussionThreadResolver{t: &types.DiscussionThread{ID: 1}}).Participants(&struct{ graphqlutil.ConnectionArgs }{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+	})
+	nodes, err := r.Nodes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int32
+	for _, node := range nodes {
+		ids = append(ids, node.user.ID)
+	}
+	if want := []int32{3, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got participants %v, want %v", ids, want)
+	}
+	if listCalls != 1 {
+		t.Errorf("got %d Users.List calls, want 1", listCalls)
+	}
+	if totalCount, err := r.TotalCount(ctx); err != nil || totalCount != 3 {
+		t.Errorf("got total count %d (error %v), want 3", totalCount, err)
+	}
+	if pageInfo, err := r.PageInfo(ctx); err != nil || !pageInfo.HasNextPage() {
+		t.Errorf("got hasNextPage false (error %v), want true", err)
+	}
+}
@@ -0,0 +1,57 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func (r *discussionsMutationResolver) PinThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadPinned(ctx, args.ThreadID, true)
+}
+
+func (r *discussionsMutationResolver) UnpinThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadPinned(ctx, args.ThreadID, false)
+}
+
+func (r *discussionsMutationResolver) setThreadPinned(ctx context.Context, id graphql.ID, pin bool) (*discussionThreadResolver, error) {
+	// 🚨 SECURITY: Only site admins can pin and unpin discussion threads.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	threadID, err := unmarshalDiscussionThreadID(id)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	if pin && previous.TargetRepo == nil {
+		return nil, discussions.NewError(discussions.ErrorCodeInvalidState, "only threads in a repository can be pinned")
+	}
+	thread, err := db.DiscussionThreads.SetPinned(ctx, threadID, pin)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.SetPinned")
+	}
+	if wasPinned := previous.PinnedAt != nil; wasPinned != pin {
+		kind := types.DiscussionThreadEventUnpinned
+		if pin {
+			kind = types.DiscussionThreadEventPinned
+		}
+		discussions.LogThreadEvent(ctx, thread.ID, actor.FromContext(ctx).UID, kind, types.DiscussionThreadEventData{})
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (d *discussionThreadResolver) IsPinned() bool { return d.t.PinnedAt != nil }
@@ -0,0 +1,71 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionsMutations_PinThread(t *testing.T) {
+	resetMocks()
+	const wantThreadID = 123
+	var pinned *time.Time
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1}, PinnedAt: pinned}, nil
+	}
+	db.Mocks.DiscussionThreads.SetPinned = func(_ context.Context, threadID int64, pin bool) (*types.DiscussionThread, error) {
+		if threadID != wantThreadID {
+			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+		}
+		pinned = nil
+		if pin {
+			now := time.Now()
+			pinned = &now
+		}
+		return &types.DiscussionThread{ID: threadID, PinnedAt: pinned}, nil
+	}
+	var events []types.DiscussionThreadEventKind
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, event *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		events = append(events, event.Kind)
+		return event, nil
+	}
+	args := &struct{ ThreadID graphql.ID }{ThreadID: marshalDiscussionThreadID(wantThreadID)}
+
+	t.Run("non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		if _, err := (&discussionsMutationResolver{}).PinThread(context.Background(), args); err == nil {
+			t.Error("expected error")
+		}
+		if pinned != nil {
+			t.Error("thread was pinned by non-admin")
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		thread, err := (&discussionsMutationResolver{}).PinThread(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !thread.IsPinned() {
+			t.Error("expected thread to be pinned")
+		}
+		thread, err = (&discussionsMutationResolver{}).UnpinThread(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thread.IsPinned() {
+			t.Error("expected thread to be unpinned")
+		}
+		if want := []types.DiscussionThreadEventKind{types.DiscussionThreadEventPinned, types.DiscussionThreadEventUnpinned}; !reflect.DeepEqual(events, want) {
+			t.Errorf("got events %v, want %v", events, want)
+		}
+	})
+}
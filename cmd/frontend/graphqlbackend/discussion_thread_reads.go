@@ -0,0 +1,55 @@
+package graphqlbackend
+
+import (
+	"context"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+)
+
+func (r *discussionsMutationResolver) MarkThreadAsRead(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	// 🚨 SECURITY: Only signed in users may mark a discussion thread as read,
+	// and only on their own behalf.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+
+	threadID, err := unmarshalDiscussionThreadID(args.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.DiscussionThreadReads.MarkAsRead(ctx, thread.ID, currentUser.user.ID, time.Now()); err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadReads.MarkAsRead")
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (d *discussionThreadResolver) UnreadCommentCount(ctx context.Context) (int32, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if currentUser == nil {
+		return 0, nil
+	}
+	count, err := db.DiscussionThreadReads.CountUnreadComments(ctx, d.t.ID, currentUser.user.ID)
+	return int32(count), err
+}
+
+func (d *discussionThreadResolver) ViewerHasUnread(ctx context.Context) (bool, error) {
+	count, err := d.UnreadCommentCount(ctx)
+	return count > 0, err
+}
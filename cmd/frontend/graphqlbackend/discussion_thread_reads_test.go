@@ -0,0 +1,84 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionsMutations_MarkThreadAsRead(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 2}, nil }
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	const wantThreadID = 123
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID}, nil
+	}
+	read := map[int32]bool{}
+	db.Mocks.DiscussionThreadReads.MarkAsRead = func(_ context.Context, threadID int64, userID int32, readAt time.Time) error {
+		if threadID != wantThreadID {
+			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+		}
+		read[userID] = true
+		return nil
+	}
+	db.Mocks.DiscussionThreadReads.CountUnreadComments = func(_ context.Context, threadID int64, userID int32) (int, error) {
+		if read[userID] {
+			return 0, nil
+		}
+		return 3, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				{
+					discussionThread(idWithoutKind: "123") {
+						viewerHasUnread
+						unreadCommentCount
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussionThread": {
+						"viewerHasUnread": true,
+						"unreadCommentCount": 3
+					}
+				}
+			`,
+		},
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation {
+					discussions {
+						markThreadAsRead(threadID: "RGlzY3Vzc2lvblRocmVhZDoiM2Yi") {
+							viewerHasUnread
+							unreadCommentCount
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussions": {
+						"markThreadAsRead": {
+							"viewerHasUnread": false,
+							"unreadCommentCount": 0
+						}
+					}
+				}
+			`,
+		},
+	})
+}
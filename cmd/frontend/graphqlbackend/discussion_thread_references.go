@@ -0,0 +1,26 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+)
+
+func (d *discussionThreadResolver) References(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadsConnectionResolver {
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt := &db.DiscussionThreadsListOptions{ReferencedByThreadID: d.t.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadsConnectionResolver{opt: opt}
+}
+
+func (d *discussionThreadResolver) ReferencedBy(args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadsConnectionResolver {
+	// 🚨 SECURITY: Threads are filtered by repository permissions in
+	// DiscussionThreads.List, so no additional check is needed here.
+	opt := &db.DiscussionThreadsListOptions{ReferencesThreadID: d.t.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadsConnectionResolver{opt: opt}
+}
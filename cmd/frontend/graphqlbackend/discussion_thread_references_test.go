@@ -0,0 +1,18 @@
+package graphqlbackend
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionThread_References(t *testing.T) {
+	r := &discussionThreadResolver{t: &types.DiscussionThread{ID: 1}}
+	if opt := r.References(&struct{ graphqlutil.ConnectionArgs }{}).opt; opt.ReferencedByThreadID != 1 || opt.ReferencesThreadID != 0 {
+		t.Errorf("got references options %+v, want threads referenced by thread 1", opt)
+	}
+	if opt := r.ReferencedBy(&struct{ graphqlutil.ConnectionArgs }{}).opt; opt.ReferencesThreadID != 1 || opt.ReferencedByThreadID != 0 {
+		t.Errorf("got referencedBy options %+v, want threads referencing thread 1", opt)
+	}
+}
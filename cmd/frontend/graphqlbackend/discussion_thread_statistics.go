@@ -0,0 +1,51 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func (r *RepositoryResolver) DiscussionThreadStatistics(ctx context.Context) (*discussionThreadStatisticsResolver, error) {
+	// 🚨 SECURITY: Anyone with access to the repository can view the
+	// statistics of its threads, for the same reason as the threads
+	// themselves.
+	stats, err := discussions.ThreadStatistics(ctx, r.repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionThreadStatisticsResolver{s: stats}, nil
+}
+
+type discussionThreadStatisticsResolver struct {
+	s *types.DiscussionThreadStatistics
+}
+
+func (r *discussionThreadStatisticsResolver) OpenCount() int32 { return int32(r.s.OpenCount) }
+
+func (r *discussionThreadStatisticsResolver) ArchivedCount() int32 { return int32(r.s.ArchivedCount) }
+
+func (r *discussionThreadStatisticsResolver) MedianSecondsToArchive() *float64 {
+	if r.s.MedianTimeToArchive == nil {
+		return nil
+	}
+	seconds := r.s.MedianTimeToArchive.Seconds()
+	return &seconds
+}
+
+func (r *discussionThreadStatisticsResolver) WeeklyComments() []*discussionWeeklyCountResolver {
+	l := make([]*discussionWeeklyCountResolver, len(r.s.WeeklyComments))
+	for i, c := range r.s.WeeklyComments {
+		l[i] = &discussionWeeklyCountResolver{c: c}
+	}
+	return l
+}
+
+type discussionWeeklyCountResolver struct {
+	c types.DiscussionWeeklyCount
+}
+
+func (r *discussionWeeklyCountResolver) Week() DateTime { return DateTime{Time: r.c.Week} }
+
+func (r *discussionWeeklyCountResolver) Count() int32 { return int32(r.c.Count) }
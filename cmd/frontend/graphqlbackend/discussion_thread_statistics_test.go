@@ -0,0 +1,68 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestRepository_DiscussionThreadStatistics(t *testing.T) {
+	resetMocks()
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	db.Mocks.DiscussionThreadEvents.Statistics = func(_ context.Context, repoID api.RepoID, since time.Time) (*types.DiscussionThreadStatistics, error) {
+		if repoID != 2 {
+			t.Errorf("got repoID %d, want 2", repoID)
+		}
+		median := 90 * time.Minute
+		return &types.DiscussionThreadStatistics{
+			OpenCount:           3,
+			ArchivedCount:       2,
+			MedianTimeToArchive: &median,
+			WeeklyComments: []types.DiscussionWeeklyCount{
+				{Week: time.Date(2019, 12, 2, 0, 0, 0, 0, time.UTC), Count: 4},
+				{Week: time.Date(2019, 12, 9, 0, 0, 0, 0, time.UTC), Count: 0},
+			},
+		}, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t, nil),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						discussionThreadStatistics {
+							openCount
+							archivedCount
+							medianSecondsToArchive
+							weeklyComments {
+								week
+								count
+							}
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repository": {
+						"discussionThreadStatistics": {
+							"openCount": 3,
+							"archivedCount": 2,
+							"medianSecondsToArchive": 5400,
+							"weeklyComments": [
+								{"week": "2019-12-02T00:00:00Z", "count": 4},
+								{"week": "2019-12-09T00:00:00Z", "count": 0}
+							]
+						}
+					}
+				}
+			`,
+		},
+	})
+}
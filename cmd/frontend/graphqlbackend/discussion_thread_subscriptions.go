@@ -0,0 +1,100 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func (r *discussionsMutationResolver) SubscribeToThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return setDiscussionThreadSubscription(ctx, args.ThreadID, true)
+}
+
+func (r *discussionsMutationResolver) UnsubscribeFromThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return setDiscussionThreadSubscription(ctx, args.ThreadID, false)
+}
+
+func (r *discussionsMutationResolver) SetThreadNotificationLevel(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+	Level    string
+}) (*discussionThreadResolver, error) {
+	level := types.DiscussionThreadNotificationLevel(strings.ToLower(args.Level))
+	return updateDiscussionThreadSubscription(ctx, args.ThreadID, func(threadID int64, userID int32) error {
+		if err := db.DiscussionThreadSubscriptions.SetNotificationLevel(ctx, threadID, userID, level); err != nil {
+			return errors.Wrap(err, "DiscussionThreadSubscriptions.SetNotificationLevel")
+		}
+		return nil
+	})
+}
+
+func setDiscussionThreadSubscription(ctx context.Context, threadGQLID graphql.ID, subscribed bool) (*discussionThreadResolver, error) {
+	return updateDiscussionThreadSubscription(ctx, threadGQLID, func(threadID int64, userID int32) error {
+		if err := db.DiscussionThreadSubscriptions.Set(ctx, threadID, userID, subscribed); err != nil {
+			return errors.Wrap(err, "DiscussionThreadSubscriptions.Set")
+		}
+		return nil
+	})
+}
+
+// updateDiscussionThreadSubscription calls update with the thread and the
+// current user, whose subscription to the thread it should update.
+func updateDiscussionThreadSubscription(ctx context.Context, threadGQLID graphql.ID, update func(threadID int64, userID int32) error) (*discussionThreadResolver, error) {
+	// 🚨 SECURITY: Only signed in users may subscribe to a discussion thread,
+	// and only on their own behalf.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+
+	threadID, err := unmarshalDiscussionThreadID(threadGQLID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(thread.ID, currentUser.user.ID); err != nil {
+		return nil, err
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (d *discussionThreadResolver) ViewerIsSubscribed(ctx context.Context) (bool, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return false, err
+	}
+	if currentUser == nil {
+		return false, nil
+	}
+	return db.DiscussionThreadSubscriptions.IsSubscribed(ctx, d.t.ID, currentUser.user.ID)
+}
+
+func (d *discussionThreadResolver) ViewerNotificationLevel(ctx context.Context) (*string, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, nil
+	}
+	level, err := db.DiscussionThreadSubscriptions.GetNotificationLevel(ctx, d.t.ID, currentUser.user.ID)
+	if err != nil || level == "" {
+		return nil, err
+	}
+	s := strings.ToUpper(string(level))
+	return &s, nil
+}
@@ -0,0 +1,126 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDiscussionsMutations_SubscribeToThread(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 2}, nil }
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	const wantThreadID = 123
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID}, nil
+	}
+	subscriptions := map[int32]bool{}
+	db.Mocks.DiscussionThreadSubscriptions.Set = func(_ context.Context, threadID int64, userID int32, subscribed bool) error {
+		if threadID != wantThreadID {
+			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+		}
+		subscriptions[userID] = subscribed
+		return nil
+	}
+	db.Mocks.DiscussionThreadSubscriptions.IsSubscribed = func(_ context.Context, threadID int64, userID int32) (bool, error) {
+		return subscriptions[userID], nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation {
+					discussions {
+						subscribeToThread(threadID: "RGlzY3Vzc2lvblRocmVhZDoiM2Yi") {
+							viewerIsSubscribed
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussions": {
+						"subscribeToThread": {
+							"viewerIsSubscribed": true
+						}
+					}
+				}
+			`,
+		},
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation {
+					discussions {
+						unsubscribeFromThread(threadID: "RGlzY3Vzc2lvblRocmVhZDoiM2Yi") {
+							viewerIsSubscribed
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussions": {
+						"unsubscribeFromThread": {
+							"viewerIsSubscribed": false
+						}
+					}
+				}
+			`,
+		},
+	})
+}
+
+func TestDiscussionsMutations_SetThreadNotificationLevel(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 2}, nil }
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID}, nil
+	}
+	levels := map[int32]types.DiscussionThreadNotificationLevel{}
+	db.Mocks.DiscussionThreadSubscriptions.SetNotificationLevel = func(_ context.Context, threadID int64, userID int32, level types.DiscussionThreadNotificationLevel) error {
+		levels[userID] = level
+		return nil
+	}
+	db.Mocks.DiscussionThreadSubscriptions.GetNotificationLevel = func(_ context.Context, threadID int64, userID int32) (types.DiscussionThreadNotificationLevel, error) {
+		return levels[userID], nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation {
+					discussions {
+						setThreadNotificationLevel(threadID: "RGlzY3Vzc2lvblRocmVhZDoiM2Yi", level: STATE_CHANGES) {
+							viewerNotificationLevel
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussions": {
+						"setThreadNotificationLevel": {
+							"viewerNotificationLevel": "STATE_CHANGES"
+						}
+					}
+				}
+			`,
+		},
+	})
+	if want := types.DiscussionThreadNotifyStateChanges; levels[2] != want {
+		t.Errorf("got notification level %q, want %q", levels[2], want)
+	}
+}
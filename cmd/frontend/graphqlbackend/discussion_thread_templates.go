@@ -0,0 +1,211 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func marshalDiscussionThreadTemplateID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionThreadTemplate", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionThreadTemplateID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+// discussionThreadTemplateByID looks up a DiscussionThreadTemplate by its
+// GraphQL ID.
+func discussionThreadTemplateByID(ctx context.Context, id graphql.ID) (*discussionThreadTemplateResolver, error) {
+	dbID, err := unmarshalDiscussionThreadTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: No authentication is required to get a discussion thread template. Discussion
+	// thread templates are public unless the Sourcegraph instance itself (and inherently, the
+	// GraphQL API) is private.
+	template, err := db.DiscussionThreadTemplates.Get(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionThreadTemplateResolver{t: template}, nil
+}
+
+type discussionThreadTemplateResolver struct {
+	t *types.DiscussionThreadTemplate
+}
+
+func (r *discussionThreadTemplateResolver) ID() graphql.ID {
+	return marshalDiscussionThreadTemplateID(r.t.ID)
+}
+
+func (r *discussionThreadTemplateResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	return RepositoryByIDInt32(ctx, r.t.RepoID)
+}
+
+func (r *discussionThreadTemplateResolver) Name() string { return r.t.Name }
+
+func (r *discussionThreadTemplateResolver) Description() *string { return r.t.Description }
+
+func (r *discussionThreadTemplateResolver) Title() *string { return r.t.Title }
+
+func (r *discussionThreadTemplateResolver) Contents() string { return r.t.Contents }
+
+func (r *discussionThreadTemplateResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.t.CreatedAt}
+}
+
+func (r *discussionThreadTemplateResolver) UpdatedAt() DateTime {
+	return DateTime{Time: r.t.UpdatedAt}
+}
+
+func (r *discussionsMutationResolver) CreateThreadTemplate(ctx context.Context, args *struct {
+	Input *struct {
+		Repository  graphql.ID
+		Name        string
+		Description *string
+		Title       *string
+		Contents    string
+	}
+}) (*discussionThreadTemplateResolver, error) {
+	// 🚨 SECURITY: Only site admins can create discussion thread templates.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	repo, err := repositoryByID(ctx, args.Input.Repository)
+	if err != nil {
+		return nil, err
+	}
+	template, err := db.DiscussionThreadTemplates.Create(ctx, &types.DiscussionThreadTemplate{
+		RepoID:      repo.repo.ID,
+		Name:        args.Input.Name,
+		Description: args.Input.Description,
+		Title:       args.Input.Title,
+		Contents:    args.Input.Contents,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadTemplates.Create")
+	}
+	return &discussionThreadTemplateResolver{t: template}, nil
+}
+
+func (r *discussionsMutationResolver) DeleteThreadTemplate(ctx context.Context, args *struct {
+	Template graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can delete discussion thread templates.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	templateID, err := unmarshalDiscussionThreadTemplateID(args.Template)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.DiscussionThreadTemplates.Delete(ctx, templateID); err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadTemplates.Delete")
+	}
+	return &EmptyResponse{}, nil
+}
+
+// applyDiscussionThreadTemplate resolves the template passed to the
+// createThread mutation and returns the title and contents for the new thread.
+// An explicitly given title or contents takes precedence over the template's.
+func applyDiscussionThreadTemplate(ctx context.Context, id graphql.ID, targetRepo *types.DiscussionThreadTargetRepo, title, contents *string) (*string, *string, error) {
+	templateID, err := unmarshalDiscussionThreadTemplateID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := db.DiscussionThreadTemplates.Get(ctx, templateID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if targetRepo == nil || targetRepo.RepoID != template.RepoID {
+		return nil, nil, errors.New("thread template does not belong to the thread's target repository")
+	}
+	if title == nil {
+		title = template.Title
+	}
+	if contents == nil {
+		contents = &template.Contents
+	}
+	return title, contents, nil
+}
+
+func (r *RepositoryResolver) DiscussionThreadTemplates(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionThreadTemplatesConnectionResolver {
+	opt := &db.DiscussionThreadTemplatesListOptions{RepoID: r.repo.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionThreadTemplatesConnectionResolver{opt: opt}
+}
+
+// discussionThreadTemplatesConnectionResolver resolves a list of discussion
+// thread templates.
+//
+// 🚨 SECURITY: When instantiating an discussionThreadTemplatesConnectionResolver
+// value, the caller MUST check permissions.
+type discussionThreadTemplatesConnectionResolver struct {
+	opt *db.DiscussionThreadTemplatesListOptions
+
+	// cache results because they are used by multiple fields
+	once      sync.Once
+	templates []*types.DiscussionThreadTemplate
+	err       error
+}
+
+func (r *discussionThreadTemplatesConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionThreadTemplate, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.templates, r.err = db.DiscussionThreadTemplates.List(ctx, &opt2)
+	})
+	return r.templates, r.err
+}
+
+func (r *discussionThreadTemplatesConnectionResolver) Nodes(ctx context.Context) ([]*discussionThreadTemplateResolver, error) {
+	templates, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(templates) > r.opt.Limit {
+		templates = templates[:r.opt.Limit]
+	}
+
+	var l []*discussionThreadTemplateResolver
+	for _, template := range templates {
+		l = append(l, &discussionThreadTemplateResolver{t: template})
+	}
+	return l, nil
+}
+
+func (r *discussionThreadTemplatesConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionThreadTemplates.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionThreadTemplatesConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	templates, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(templates) > r.opt.Limit), nil
+}
@@ -0,0 +1,52 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestApplyDiscussionThreadTemplate(t *testing.T) {
+	resetMocks()
+	const repoID = api.RepoID(1)
+	db.Mocks.DiscussionThreadTemplates.Get = func(templateID int64) (*types.DiscussionThreadTemplate, error) {
+		return &types.DiscussionThreadTemplate{
+			ID:       templateID,
+			RepoID:   repoID,
+			Title:    strptr("Bug: "),
+			Contents: "## Steps to reproduce",
+		}, nil
+	}
+	templateID := marshalDiscussionThreadTemplateID(1)
+	targetRepo := &types.DiscussionThreadTargetRepo{RepoID: repoID}
+
+	t.Run("template values", func(t *testing.T) {
+		title, contents, err := applyDiscussionThreadTemplate(context.Background(), templateID, targetRepo, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *title != "Bug: " || *contents != "## Steps to reproduce" {
+			t.Errorf("got title %q and contents %q, want the template's", *title, *contents)
+		}
+	})
+
+	t.Run("explicit values take precedence", func(t *testing.T) {
+		title, contents, err := applyDiscussionThreadTemplate(context.Background(), templateID, targetRepo, strptr("t"), strptr("c"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *title != "t" || *contents != "c" {
+			t.Errorf("got title %q and contents %q, want %q and %q", *title, *contents, "t", "c")
+		}
+	})
+
+	t.Run("template in other repository", func(t *testing.T) {
+		otherRepo := &types.DiscussionThreadTargetRepo{RepoID: repoID + 1}
+		if _, _, err := applyDiscussionThreadTemplate(context.Background(), templateID, otherRepo, nil, nil); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
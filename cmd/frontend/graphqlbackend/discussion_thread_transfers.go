@@ -0,0 +1,79 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func (r *discussionsMutationResolver) TransferThread(ctx context.Context, args *struct {
+	Thread           graphql.ID
+	TargetRepository graphql.ID
+}) (*discussionThreadResolver, error) {
+	threadID, err := unmarshalDiscussionThreadID(args.Thread)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.TargetRepo == nil {
+		return nil, discussions.NewError(discussions.ErrorCodeInvalidState, "only threads in a repository can be transferred")
+	}
+
+	// 🚨 SECURITY: Only site admins and the thread author can transfer a
+	// thread, and only to a repository they can access (which db.Repos.Get
+	// checks).
+	if err := discussions.CheckCanUpdateThread(ctx, thread); err != nil {
+		return nil, err
+	}
+	repoID, err := UnmarshalRepositoryID(args.TargetRepository)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := db.Repos.Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	previousRepoID := thread.TargetRepo.RepoID
+	thread, err = db.DiscussionThreads.Transfer(ctx, thread.ID, repo.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Transfer")
+	}
+	discussions.LogThreadEvent(ctx, thread.ID, currentUser.user.ID, types.DiscussionThreadEventTransferred, types.DiscussionThreadEventData{
+		PreviousRepoID: &previousRepoID,
+		RepoID:         &repo.ID,
+	})
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionThreadTimelineItemResolver) PreviousRepository(ctx context.Context) (*RepositoryResolver, error) {
+	return timelineItemRepository(ctx, r.e.Data.PreviousRepoID)
+}
+
+func (r *discussionThreadTimelineItemResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	return timelineItemRepository(ctx, r.e.Data.RepoID)
+}
+
+func timelineItemRepository(ctx context.Context, repoID *api.RepoID) (*RepositoryResolver, error) {
+	if repoID == nil {
+		return nil, nil
+	}
+	repo, err := RepositoryByIDInt32(ctx, *repoID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return repo, err
+}
@@ -0,0 +1,81 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDiscussionsMutations_TransferThread(t *testing.T) {
+	const (
+		wantThreadID = 123
+		fromRepoID   = api.RepoID(1)
+		toRepoID     = api.RepoID(2)
+	)
+	resetMocks()
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{
+			ID:           threadID,
+			AuthorUserID: 1,
+			TargetRepo:   &types.DiscussionThreadTargetRepo{RepoID: fromRepoID},
+		}, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "r"}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	var transferred bool
+	db.Mocks.DiscussionThreads.Transfer = func(_ context.Context, threadID int64, repoID api.RepoID) (*types.DiscussionThread, error) {
+		if threadID != wantThreadID || repoID != toRepoID {
+			t.Errorf("got Transfer(%d, %d), want Transfer(%d, %d)", threadID, repoID, wantThreadID, toRepoID)
+		}
+		transferred = true
+		return &types.DiscussionThread{ID: threadID, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: repoID}}, nil
+	}
+	var events []*types.DiscussionThreadEvent
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		events = append(events, newEvent)
+		return newEvent, nil
+	}
+	args := &struct {
+		Thread           graphql.ID
+		TargetRepository graphql.ID
+	}{Thread: marshalDiscussionThreadID(wantThreadID), TargetRepository: MarshalRepositoryID(toRepoID)}
+
+	t.Run("not author", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 2}, nil
+		}
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 2})
+		if _, err := (&discussionsMutationResolver{}).TransferThread(ctx, args); err == nil {
+			t.Fatal("expected error")
+		}
+		if transferred {
+			t.Error("expected thread not to be transferred")
+		}
+	})
+
+	t.Run("author", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		thread, err := (&discussionsMutationResolver{}).TransferThread(ctx, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thread.t.TargetRepo.RepoID != toRepoID {
+			t.Errorf("got thread repository %d, want %d", thread.t.TargetRepo.RepoID, toRepoID)
+		}
+		if len(events) != 1 || events[0].Kind != types.DiscussionThreadEventTransferred {
+			t.Fatalf("got events %+v, want one TRANSFERRED event", events)
+		}
+		if data := events[0].Data; data.PreviousRepoID == nil || *data.PreviousRepoID != fromRepoID || data.RepoID == nil || *data.RepoID != toRepoID {
+			t.Errorf("got event data %+v, want transfer from %d to %d", data, fromRepoID, toRepoID)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package graphqlbackend
+
+import (
+	"context"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+)
+
+// discussionThreadUpdatesPollInterval is how often discussionThreadUpdates
+// checks for new events while it waits for them.
+var discussionThreadUpdatesPollInterval = time.Second
+
+// maxDiscussionThreadUpdatesWait bounds how long a single
+// discussionThreadUpdates request may wait for new events.
+const maxDiscussionThreadUpdatesWait = 30 * time.Second
+
+// maxDiscussionThreadUpdatesFirst bounds how many events a single
+// discussionThreadUpdates request may return.
+const maxDiscussionThreadUpdatesFirst = 100
+
+func (r *RepositoryResolver) DiscussionThreadUpdates(ctx context.Context, args *struct {
+	Since       *DateTime
+	After       *graphql.ID
+	First       int32
+	WaitSeconds int32
+}) ([]*discussionThreadTimelineItemResolver, error) {
+	// 🚨 SECURITY: Anyone who can view the repository can view the activity
+	// on its threads, for the same reason as the thread timelines.
+
+	if (args.Since == nil) == (args.After == nil) {
+		return nil, errors.New("exactly one of since and after must be specified")
+	}
+	if args.First < 1 {
+		return nil, errors.New("first must be positive")
+	}
+	first := int(args.First)
+	if first > maxDiscussionThreadUpdatesFirst {
+		first = maxDiscussionThreadUpdatesFirst
+	}
+
+	// Events are paged by ID rather than by creation time, because several
+	// events can be created in the same instant.
+	afterID := int64(0)
+	opt := &db.DiscussionThreadEventsListOptions{
+		LimitOffset: &db.LimitOffset{Limit: first},
+		RepoID:      r.repo.ID,
+		AfterID:     &afterID,
+	}
+	if args.Since != nil {
+		opt.CreatedAfter = &args.Since.Time
+	} else {
+		var err error
+		afterID, err = unmarshalDiscussionThreadEventID(*args.After)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wait := time.Duration(args.WaitSeconds) * time.Second
+	if wait > maxDiscussionThreadUpdatesWait {
+		wait = maxDiscussionThreadUpdatesWait
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		events, err := db.DiscussionThreadEvents.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 || !time.Now().Before(deadline) {
+			l := make([]*discussionThreadTimelineItemResolver, 0, len(events))
+			for _, event := range events {
+				l = append(l, &discussionThreadTimelineItemResolver{e: event})
+			}
+			return l, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(discussionThreadUpdatesPollInterval):
+		}
+	}
+}
+
+func (r *discussionThreadTimelineItemResolver) Thread(ctx context.Context) (*discussionThreadResolver, error) {
+	thread, err := db.DiscussionThreads.Get(ctx, r.e.ThreadID)
+	if err != nil {
+		if _, ok := err.(*db.ErrThreadNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
@@ -0,0 +1,120 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type discussionThreadUpdatesArgs struct {
+	Since       *DateTime
+	After       *graphql.ID
+	First       int32
+	WaitSeconds int32
+}
+
+func discussionThreadUpdates(repo *RepositoryResolver, args discussionThreadUpdatesArgs) ([]*discussionThreadTimelineItemResolver, error) {
+	return repo.DiscussionThreadUpdates(context.Background(), (*struct {
+		Since       *DateTime
+		After       *graphql.ID
+		First       int32
+		WaitSeconds int32
+	})(&args))
+}
+
+func TestRepositoryResolver_DiscussionThreadUpdates(t *testing.T) {
+	resetMocks()
+	defer func(d time.Duration) { discussionThreadUpdatesPollInterval = d }(discussionThreadUpdatesPollInterval)
+	discussionThreadUpdatesPollInterval = time.Millisecond
+
+	const repoID = api.RepoID(1)
+	since := time.Now()
+	calls := 0
+	db.Mocks.DiscussionThreadEvents.List = func(_ context.Context, opts *db.DiscussionThreadEventsListOptions) ([]*types.DiscussionThreadEvent, error) {
+		if opts.RepoID != repoID {
+			t.Errorf("got RepoID %v, want %v", opts.RepoID, repoID)
+		}
+		if opts.CreatedAfter == nil || !opts.CreatedAfter.Equal(since) {
+			t.Errorf("got CreatedAfter %v, want %v", opts.CreatedAfter, since)
+		}
+		if opts.AfterID == nil || *opts.AfterID != 0 {
+			t.Errorf("got AfterID %v, want 0", opts.AfterID)
+		}
+		// The third poll finds an event.
+		calls++
+		if calls < 3 {
+			return nil, nil
+		}
+		return []*types.DiscussionThreadEvent{{ID: 1, Kind: types.DiscussionThreadEventCommented}}, nil
+	}
+
+	repo := &RepositoryResolver{repo: &types.Repo{ID: repoID}}
+	items, err := discussionThreadUpdates(repo, discussionThreadUpdatesArgs{Since: &DateTime{Time: since}, First: 10, WaitSeconds: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Kind() != "COMMENTED" {
+		t.Errorf("got %d items, want 1 COMMENTED item", len(items))
+	}
+	if calls != 3 {
+		t.Errorf("got %d polls, want 3", calls)
+	}
+}
+
+func TestRepositoryResolver_DiscussionThreadUpdates_after(t *testing.T) {
+	resetMocks()
+	const wantAfterID = 7
+	db.Mocks.DiscussionThreadEvents.List = func(_ context.Context, opts *db.DiscussionThreadEventsListOptions) ([]*types.DiscussionThreadEvent, error) {
+		if opts.AfterID == nil || *opts.AfterID != wantAfterID {
+			t.Errorf("got AfterID %v, want %d", opts.AfterID, wantAfterID)
+		}
+		if opts.CreatedAfter != nil {
+			t.Errorf("got CreatedAfter %v, want nil", opts.CreatedAfter)
+		}
+		// The limit is clamped.
+		if opts.Limit != maxDiscussionThreadUpdatesFirst {
+			t.Errorf("got limit %d, want %d", opts.Limit, maxDiscussionThreadUpdatesFirst)
+		}
+		return []*types.DiscussionThreadEvent{{ID: wantAfterID + 1, Kind: types.DiscussionThreadEventCommented}}, nil
+	}
+
+	repo := &RepositoryResolver{repo: &types.Repo{ID: 1}}
+	after := marshalDiscussionThreadEventID(wantAfterID)
+	items, err := discussionThreadUpdates(repo, discussionThreadUpdatesArgs{After: &after, First: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].ID() != marshalDiscussionThreadEventID(wantAfterID+1) {
+		t.Errorf("got items %+v, want the item after %d", items, wantAfterID)
+	}
+}
+
+func TestRepositoryResolver_DiscussionThreadUpdates_invalidArgs(t *testing.T) {
+	resetMocks()
+	db.Mocks.DiscussionThreadEvents.List = func(context.Context, *db.DiscussionThreadEventsListOptions) ([]*types.DiscussionThreadEvent, error) {
+		t.Error("unexpected List call")
+		return nil, nil
+	}
+
+	since := &DateTime{Time: time.Now()}
+	after := marshalDiscussionThreadEventID(1)
+	tests := map[string]discussionThreadUpdatesArgs{
+		"zero first":           {Since: since, First: 0, WaitSeconds: 30},
+		"negative first":       {Since: since, First: -1},
+		"no since or after":    {First: 10},
+		"both since and after": {Since: since, After: &after, First: 10},
+	}
+	repo := &RepositoryResolver{repo: &types.Repo{ID: 1}}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := discussionThreadUpdates(repo, args); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/ratelimit"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -119,6 +121,11 @@ func (d *discussionThreadTargetRepoInput) convert(ctx context.Context) (*types.D
 
 // validate checks the validity of the input and returns an error, if any.
 func (d *discussionThreadTargetRepoInput) validate() error {
+	if d.Branch != nil {
+		if err := discussions.ValidateRefName("targetRepo.branch", *d.Branch); err != nil {
+			return err
+		}
+	}
 	if d.Selection != nil {
 		// Check that the caller either specified all line fields or didn't specify
 		// any at all (specifying some but not others makes no sense, see the
@@ -191,17 +198,13 @@ func (d *discussionThreadTargetRepoInput) populateLinesFromRepository(ctx contex
 
 func (r *discussionsMutationResolver) CreateThread(ctx context.Context, args *struct {
 	Input *struct {
-		Title      *string
-		Contents   string
-		TargetRepo *discussionThreadTargetRepoInput
+		Title          *string
+		Contents       *string
+		TargetRepo     *discussionThreadTargetRepoInput
+		Template       *graphql.ID
+		IdempotencyKey *string
 	}
 }) (*discussionThreadResolver, error) {
-	if args.Input.Title == nil {
-		// Title defaults to first line of contents.
-		title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(args.Input.Contents), "\n", 2)[0])
-		args.Input.Title = &title
-	}
-
 	// 🚨 SECURITY: Only signed in users with a verified email may add comments
 	// to a discussion thread.
 	//
@@ -214,18 +217,26 @@ func (r *discussionsMutationResolver) CreateThread(ctx context.Context, args *st
 		return nil, err
 	}
 	if currentUser == nil {
-		return nil, errors.New("no current user")
+		return nil, discussions.ErrNoCurrentUser
 	}
-	if dc := conf.Get().Discussions; dc != nil && dc.AbuseProtection {
-		if mustWait := ratelimit.TimeUntilUserCanCreateThread(ctx, currentUser.user.ID, *args.Input.Title, args.Input.Contents); mustWait != 0 {
-			return nil, fmt.Errorf("You are creating threads too quickly. You may create a new one after %v", mustWait.Round(time.Second))
+
+	// A retried request returns the thread created by the original request.
+	if key := args.Input.IdempotencyKey; key != nil {
+		if *key == "" || len(*key) > 255 {
+			return nil, &discussions.ValidationError{Field: "idempotencyKey", Message: "must be between 1 and 255 bytes long"}
+		}
+		thread, err := db.DiscussionThreads.GetByIdempotencyKey(ctx, currentUser.user.ID, *key)
+		if err == nil {
+			return &discussionThreadResolver{t: thread}, nil
+		}
+		if !errcode.IsNotFound(err) {
+			return nil, errors.Wrap(err, "DiscussionThreads.GetByIdempotencyKey")
 		}
 	}
 
-	// Create the thread.
 	newThread := &types.DiscussionThread{
-		AuthorUserID: currentUser.user.ID,
-		Title:        *args.Input.Title,
+		AuthorUserID:   currentUser.user.ID,
+		IdempotencyKey: args.Input.IdempotencyKey,
 	}
 	if args.Input.TargetRepo != nil {
 		if err := args.Input.TargetRepo.validate(); err != nil {
@@ -236,31 +247,72 @@ func (r *discussionsMutationResolver) CreateThread(ctx context.Context, args *st
 			return nil, err
 		}
 	}
-	thread, err := db.DiscussionThreads.Create(ctx, newThread)
-	if err != nil {
-		return nil, errors.Wrap(err, "DiscussionThreads.Create")
+
+	// Pre-populate the title and contents from the template, if any.
+	if args.Input.Template != nil {
+		args.Input.Title, args.Input.Contents, err = applyDiscussionThreadTemplate(ctx, *args.Input.Template, newThread.TargetRepo, args.Input.Title, args.Input.Contents)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if args.Input.Contents == nil {
+		return nil, errors.New("contents must be specified when no template is given")
+	}
+	contents := *args.Input.Contents
+	if args.Input.Title == nil {
+		// Title defaults to first line of contents.
+		title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(contents), "\n", 2)[0])
+		args.Input.Title = &title
+	}
+	newThread.Title = *args.Input.Title
+	if err := discussions.ValidateThreadTitle("title", newThread.Title); err != nil {
+		return nil, err
+	}
+	if err := discussions.ValidateCommentContents("contents", contents); err != nil {
+		return nil, err
+	}
+
+	if err := ratelimit.CheckCanCreateThread(currentUser.user.ID); err != nil {
+		return nil, err
+	}
+	if dc := conf.Get().Discussions; dc != nil && dc.AbuseProtection {
+		if mustWait := ratelimit.TimeUntilUserCanCreateThread(ctx, currentUser.user.ID, newThread.Title, contents); mustWait != 0 {
+			return nil, fmt.Errorf("You are creating threads too quickly. You may create a new one after %v", mustWait.Round(time.Second))
+		}
 	}
 
-	// Create the first comment in the thread.
+	// Create the thread and its first comment.
 	newComment := &types.DiscussionComment{
-		ThreadID:     newThread.ID,
 		AuthorUserID: currentUser.user.ID,
-		Contents:     args.Input.Contents,
+		Contents:     contents,
+	}
+	thread, err := discussions.InsecureCreateThread(ctx, newThread, newComment)
+	if errors.Cause(err) == db.ErrDuplicateIdempotencyKey {
+		// A concurrent request with the same idempotency key created the thread.
+		thread, err := db.DiscussionThreads.GetByIdempotencyKey(ctx, currentUser.user.ID, *newThread.IdempotencyKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionThreads.GetByIdempotencyKey")
+		}
+		return &discussionThreadResolver{t: thread}, nil
 	}
-	_, err = db.DiscussionComments.Create(ctx, newComment)
 	if err != nil {
-		return nil, errors.Wrap(err, "DiscussionComments.Create")
+		return nil, err
 	}
+	discussions.StoreCommentMentions(ctx, newComment)
+	discussions.StoreCommentReferences(ctx, newComment)
+	discussions.AutoSubscribe(ctx, thread.ID, currentUser.user.ID)
 	discussions.NotifyNewThread(newThread, newComment)
 	return &discussionThreadResolver{t: thread}, nil
 }
 
 func (r *discussionsMutationResolver) UpdateThread(ctx context.Context, args *struct {
 	Input *struct {
-		ThreadID graphql.ID
-		Title    *string
-		Archive  *bool
-		Delete   *bool
+		ThreadID          graphql.ID
+		Title             *string
+		Contents          *string
+		Archive           *bool
+		Delete            *bool
+		ExpectedUpdatedAt *DateTime
 	}
 }) (*discussionThreadResolver, error) {
 	// 🚨 SECURITY: Only signed in users may update a discussion thread.
@@ -269,37 +321,375 @@ func (r *discussionsMutationResolver) UpdateThread(ctx context.Context, args *st
 		return nil, err
 	}
 	if currentUser == nil {
-		return nil, errors.New("no current user")
+		return nil, discussions.ErrNoCurrentUser
+	}
+
+	threadID, err := unmarshalDiscussionThreadID(args.Input.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	if args.Input.Title != nil {
+		if err := discussions.ValidateThreadTitle("title", *args.Input.Title); err != nil {
+			return nil, err
+		}
+	}
+	if args.Input.Contents != nil {
+		if err := discussions.ValidateCommentContents("contents", *args.Input.Contents); err != nil {
+			return nil, err
+		}
+	}
+
+	// Resolve the thread before updating it so that we can record the changes
+	// on its timeline.
+	previous, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
 	}
 
 	var delete bool
 	if args.Input.Delete != nil && *args.Input.Delete {
-		// 🚨 SECURITY: Only site admins can delete discussion threads.
-		if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		// 🚨 SECURITY: Only site admins and maintainers can delete discussion
+		// threads.
+		if err := discussions.CheckCanDeleteThread(ctx, previous); err != nil {
 			return nil, err
 		}
 		delete = *args.Input.Delete
 	}
+	if args.Input.Title != nil || args.Input.Contents != nil {
+		// 🚨 SECURITY: Only site admins and the thread author can update the
+		// title and contents of a discussion thread.
+		if err := discussions.CheckCanUpdateThread(ctx, previous); err != nil {
+			return nil, err
+		}
+	}
+	if args.Input.Archive != nil {
+		// 🚨 SECURITY: Only site admins, the thread author and triagers can
+		// archive and unarchive a discussion thread.
+		if err := discussions.CheckCanArchiveThread(ctx, previous); err != nil {
+			return nil, err
+		}
+	}
 
-	threadID, err := unmarshalDiscussionThreadID(args.Input.ThreadID)
-	if err != nil {
-		return nil, err
+	// The thread's contents are the contents of its first comment.
+	var firstComment *types.DiscussionComment
+	if args.Input.Contents != nil {
+		firstCommentID, err := discussionThreadFirstCommentID(ctx, threadID)
+		if err != nil {
+			return nil, err
+		}
+		firstComment, err = db.DiscussionComments.Get(ctx, firstCommentID)
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionComments.Get")
+		}
 	}
-	thread, err := db.DiscussionThreads.Update(ctx, threadID, &db.DiscussionThreadsUpdateOptions{
+
+	opts := &db.DiscussionThreadsUpdateOptions{
 		Archive: args.Input.Archive,
 		Delete:  delete,
 		Title:   args.Input.Title,
-	})
+	}
+	contentsChanged := firstComment != nil && *args.Input.Contents != firstComment.Contents
+	if contentsChanged {
+		// The previous contents are recorded in the comment's edit history.
+		opts.Contents = args.Input.Contents
+		opts.EditorUserID = currentUser.user.ID
+	}
+	if args.Input.ExpectedUpdatedAt != nil {
+		opts.ExpectedUpdatedAt = &args.Input.ExpectedUpdatedAt.Time
+	}
+	thread, err := discussions.InsecureUpdateThread(ctx, threadID, currentUser.user.ID, opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "DiscussionThreads.Update")
+		return nil, err
 	}
 	if thread == nil {
 		// deleted
 		return nil, nil
 	}
+	if contentsChanged {
+		updatedComment, err := db.DiscussionComments.Get(ctx, firstComment.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionComments.Get")
+		}
+		discussions.UpdateCommentMentions(ctx, thread, updatedComment)
+		discussions.StoreCommentReferences(ctx, updatedComment)
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) RestoreThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	// 🚨 SECURITY: Only site admins can restore discussion threads.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	threadID, err := unmarshalDiscussionThreadID(args.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := discussions.InsecureRestoreThread(ctx, threadID, actor.FromContext(ctx).UID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) CloseThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadClosed(ctx, args.ThreadID, true)
+}
+
+func (r *discussionsMutationResolver) ReopenThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadClosed(ctx, args.ThreadID, false)
+}
+
+func (r *discussionsMutationResolver) setThreadClosed(ctx context.Context, id graphql.ID, close bool) (*discussionThreadResolver, error) {
+	threadID, err := unmarshalDiscussionThreadID(id)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	// 🚨 SECURITY: Only site admins, the thread author and triagers can close
+	// and reopen discussion threads (and not while they are archived).
+	if err := discussions.CheckCanTriageThread(ctx, thread); err != nil {
+		return nil, err
+	}
+	thread, err = discussions.InsecureSetThreadClosed(ctx, threadID, actor.FromContext(ctx).UID, close)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionThreadResolver{t: thread}, nil
+}
+
+func (r *discussionsMutationResolver) LockThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadLocked(ctx, args.ThreadID, true)
+}
+
+func (r *discussionsMutationResolver) UnlockThread(ctx context.Context, args *struct {
+	ThreadID graphql.ID
+}) (*discussionThreadResolver, error) {
+	return r.setThreadLocked(ctx, args.ThreadID, false)
+}
+
+func (r *discussionsMutationResolver) setThreadLocked(ctx context.Context, id graphql.ID, lock bool) (*discussionThreadResolver, error) {
+	threadID, err := unmarshalDiscussionThreadID(id)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	// 🚨 SECURITY: Only site admins and maintainers can lock and unlock
+	// discussion threads.
+	if err := discussions.CheckCanLockThread(ctx, previous); err != nil {
+		return nil, err
+	}
+	thread, err := discussions.InsecureUpdateThread(ctx, threadID, actor.FromContext(ctx).UID, &db.DiscussionThreadsUpdateOptions{Lock: &lock})
+	if err != nil {
+		return nil, err
+	}
 	return &discussionThreadResolver{t: thread}, nil
 }
 
+// discussionThreadsUpdateInput is the GraphQL DiscussionThreadsUpdateInput
+// type.
+type discussionThreadsUpdateInput struct {
+	ThreadIDs []graphql.ID
+	Archive   *bool
+	Delete    *bool
+}
+
+func (r *discussionsMutationResolver) UpdateThreads(ctx context.Context, args *struct {
+	Input *discussionThreadsUpdateInput
+}) ([]*discussionThreadUpdateResultResolver, error) {
+	// 🚨 SECURITY: Only signed in users may update discussion threads.
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, discussions.ErrNoCurrentUser
+	}
+
+	delete := args.Input.Delete != nil && *args.Input.Delete
+
+	results := make([]*discussionThreadUpdateResultResolver, len(args.Input.ThreadIDs))
+	threadIDs := make([]int64, 0, len(args.Input.ThreadIDs))
+	for i, id := range args.Input.ThreadIDs {
+		results[i] = &discussionThreadUpdateResultResolver{threadID: id}
+		threadID, err := unmarshalDiscussionThreadID(id)
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+		results[i].dbID = threadID
+		threadIDs = append(threadIDs, threadID)
+	}
+
+	// Resolve the threads before updating them so that we can record the
+	// changes on their timelines.
+	previous := map[int64]*types.DiscussionThread{}
+	if len(threadIDs) > 0 {
+		threads, err := db.DiscussionThreads.List(ctx, &db.DiscussionThreadsListOptions{ThreadIDs: threadIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionThreads.List")
+		}
+		for _, thread := range threads {
+			previous[thread.ID] = thread
+		}
+	}
+
+	// 🚨 SECURITY: Only update the threads that the current user can view (which
+	// DiscussionThreads.List checks) and update. Only site admins and
+	// maintainers can delete threads, and only site admins, the thread author
+	// and triagers can archive and unarchive them.
+	var updatableIDs []int64
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		thread, ok := previous[result.dbID]
+		if !ok {
+			result.err = &db.ErrThreadNotFound{ThreadID: result.dbID}
+			continue
+		}
+		if delete {
+			if err := discussions.CheckCanDeleteThread(ctx, thread); err != nil {
+				result.err = err
+				continue
+			}
+		}
+		if args.Input.Archive != nil {
+			if err := discussions.CheckCanArchiveThread(ctx, thread); err != nil {
+				result.err = err
+				continue
+			}
+		}
+		updatableIDs = append(updatableIDs, result.dbID)
+	}
+
+	updatedIDs, err := discussions.InsecureUpdateThreads(ctx, updatableIDs, currentUser.user.ID, &db.DiscussionThreadsUpdateOptions{
+		Archive: args.Input.Archive,
+		Delete:  delete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	updated := map[int64]*types.DiscussionThread{}
+	if !delete && len(updatedIDs) > 0 {
+		threads, err := db.DiscussionThreads.List(ctx, &db.DiscussionThreadsListOptions{ThreadIDs: updatedIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "DiscussionThreads.List")
+		}
+		for _, thread := range threads {
+			updated[thread.ID] = thread
+		}
+	}
+	wasUpdated := make(map[int64]bool, len(updatedIDs))
+	for _, id := range updatedIDs {
+		wasUpdated[id] = true
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		if !wasUpdated[result.dbID] {
+			result.err = &db.ErrThreadNotFound{ThreadID: result.dbID}
+			continue
+		}
+		if thread := updated[result.dbID]; thread != nil {
+			result.thread = &discussionThreadResolver{t: thread}
+		}
+	}
+	return results, nil
+}
+
+// archiveThreadsLimit is the maximum number of threads that a single
+// archiveThreads mutation archives.
+const archiveThreadsLimit = 1000
+
+// discussionThreadsArchiveInput is the GraphQL DiscussionThreadsArchiveInput
+// type.
+type discussionThreadsArchiveInput struct {
+	Repository    *graphql.ID
+	Labels        *[]string
+	InactiveSince *DateTime
+}
+
+func (r *discussionsMutationResolver) ArchiveThreads(ctx context.Context, args *struct {
+	Input *discussionThreadsArchiveInput
+}) ([]*discussionThreadUpdateResultResolver, error) {
+	if (args.Input.Labels == nil || len(*args.Input.Labels) == 0) && args.Input.InactiveSince == nil {
+		return nil, errors.New("at least one of labels or inactiveSince must be specified")
+	}
+
+	notArchived := false
+	opt := &db.DiscussionThreadsListOptions{
+		LimitOffset: &db.LimitOffset{Limit: archiveThreadsLimit},
+		Archived:    &notArchived,
+	}
+	if args.Input.Repository != nil {
+		repoID, err := UnmarshalRepositoryID(*args.Input.Repository)
+		if err != nil {
+			return nil, err
+		}
+		opt.TargetRepoID = &repoID
+	}
+	if args.Input.Labels != nil {
+		opt.LabelNames = *args.Input.Labels
+	}
+	if args.Input.InactiveSince != nil {
+		opt.InactiveBefore = &args.Input.InactiveSince.Time
+	}
+
+	// 🚨 SECURITY: The matching threads are filtered by repository permissions
+	// in DiscussionThreads.List, and UpdateThreads checks that the current user
+	// can archive each of them.
+	threads, err := db.DiscussionThreads.List(ctx, opt)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.List")
+	}
+	threadIDs := make([]graphql.ID, len(threads))
+	for i, thread := range threads {
+		threadIDs[i] = marshalDiscussionThreadID(thread.ID)
+	}
+	archive := true
+	return r.UpdateThreads(ctx, &struct{ Input *discussionThreadsUpdateInput }{
+		Input: &discussionThreadsUpdateInput{ThreadIDs: threadIDs, Archive: &archive},
+	})
+}
+
+// discussionThreadUpdateResultResolver resolves the outcome of updating a
+// single thread with the updateThreads mutation.
+type discussionThreadUpdateResultResolver struct {
+	threadID graphql.ID
+	dbID     int64
+	thread   *discussionThreadResolver
+	err      error
+}
+
+func (r *discussionThreadUpdateResultResolver) ThreadID() graphql.ID { return r.threadID }
+
+func (r *discussionThreadUpdateResultResolver) Thread() *discussionThreadResolver { return r.thread }
+
+func (r *discussionThreadUpdateResultResolver) Error() *string {
+	if r.err == nil {
+		return nil
+	}
+	msg := r.err.Error()
+	return &msg
+}
+
 func (*schemaResolver) Discussions(ctx context.Context) (*discussionsMutationResolver, error) {
 	if err := viewerCanUseDiscussions(ctx); err != nil {
 		return nil, err
@@ -309,6 +699,8 @@ func (*schemaResolver) Discussions(ctx context.Context) (*discussionsMutationRes
 
 func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
+	After                       *string
+	OrderBy                     *discussionThreadOrder
 	Query                       *string
 	ThreadID                    *graphql.ID
 	AuthorUserID                *graphql.ID
@@ -316,6 +708,9 @@ func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 	TargetRepositoryName        *string
 	TargetRepositoryGitCloneURL *string
 	TargetRepositoryPath        *string
+	State                       *string
+	Labels                      *[]string
+	BlockedBy                   *graphql.ID
 }) (*discussionThreadsConnectionResolver, error) {
 	if err := viewerCanUseDiscussions(ctx); err != nil {
 		return nil, err
@@ -331,7 +726,20 @@ func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 	if args.Query != nil {
 		opt.SetFromQuery(ctx, *args.Query)
 	}
-	args.ConnectionArgs.Set(&opt.LimitOffset)
+	if args.OrderBy != nil {
+		// Overrides the "order:" qualifier in the query, if any.
+		if err := args.OrderBy.set(opt); err != nil {
+			return nil, err
+		}
+	}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	if args.After != nil {
+		cursor, err := unmarshalDiscussionThreadsCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opt.After = cursor
+	}
 
 	if args.ThreadID != nil {
 		// BACKCOMPAT DEPRECATED: For backcompat, this value is treated as
@@ -343,6 +751,9 @@ func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 		}
 		opt.ThreadIDs = []int64{dbID}
 	}
+	if err := setDiscussionThreadsStateAndLabels(opt, args.State, args.Labels); err != nil {
+		return nil, err
+	}
 	if args.AuthorUserID != nil {
 		authorUserID, err := UnmarshalUserID(*args.AuthorUserID)
 		if err != nil {
@@ -350,6 +761,13 @@ func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 		}
 		opt.AuthorUserIDs = []int32{authorUserID}
 	}
+	if args.BlockedBy != nil {
+		blockedByThreadID, err := unmarshalDiscussionThreadID(*args.BlockedBy)
+		if err != nil {
+			return nil, err
+		}
+		opt.BlockedByThreadID = blockedByThreadID
+	}
 
 	count := 0
 	if args.TargetRepositoryID != nil {
@@ -367,12 +785,37 @@ func (*schemaResolver) DiscussionThreads(ctx context.Context, args *struct {
 			return nil, err
 		}
 		opt.TargetRepoID = &repo.repo.ID
+		opt.PinnedFirst = true
 	} else if count > 1 {
 		return nil, errors.New("only one of targetRepositoryID, targetRepositoryName, or targetRepositoryGitCloneURL can be specified")
 	}
 	return &discussionThreadsConnectionResolver{opt: opt}, nil
 }
 
+// discussionThreadOrder is the GraphQL DiscussionThreadOrder input type.
+type discussionThreadOrder struct {
+	Field     string
+	Direction string
+}
+
+// set sets the order of the list options.
+func (o *discussionThreadOrder) set(opt *db.DiscussionThreadsListOptions) error {
+	switch o.Field {
+	case "CREATED_AT":
+		opt.OrderBy = db.DiscussionThreadsListCreatedAt
+	case "UPDATED_AT":
+		opt.OrderBy = db.DiscussionThreadsListUpdatedAt
+	case "COMMENT_COUNT":
+		opt.OrderBy = db.DiscussionThreadsListCommentCount
+	case "TITLE":
+		opt.OrderBy = db.DiscussionThreadsListTitle
+	default:
+		return fmt.Errorf("invalid discussion thread order field %q", o.Field)
+	}
+	opt.AscendingOrder = o.Direction == "ASC"
+	return nil
+}
+
 func (schemaResolver) DiscussionThread(ctx context.Context, args *struct {
 	IDWithoutKind string
 }) (*discussionThreadResolver, error) {
@@ -399,9 +842,20 @@ func (r *discussionThreadTargetRepoSelectionResolver) LinesAfter() []string  { r
 
 type discussionThreadTargetRepoResolver struct {
 	t *types.DiscussionThreadTargetRepo
+
+	loader *discussionThreadsLoader // may be nil
 }
 
 func (r *discussionThreadTargetRepoResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	if r.loader != nil {
+		repo, err := r.loader.repo(ctx, r.t.RepoID)
+		if err != nil {
+			return nil, err
+		}
+		if repo != nil {
+			return &RepositoryResolver{repo: repo}, nil
+		}
+	}
 	return RepositoryByIDInt32(ctx, r.t.RepoID)
 }
 
@@ -617,14 +1071,15 @@ func (r *discussionThreadTargetRepoResolver) RelativeSelection(ctx context.Conte
 }
 
 type discussionThreadTargetResolver struct {
-	t *types.DiscussionThread
+	t      *types.DiscussionThread
+	loader *discussionThreadsLoader // may be nil
 }
 
 func (r *discussionThreadTargetResolver) ToDiscussionThreadTargetRepo() (*discussionThreadTargetRepoResolver, bool) {
 	if r.t.TargetRepo == nil {
 		return nil, false
 	}
-	return &discussionThreadTargetRepoResolver{t: r.t.TargetRepo}, true
+	return &discussionThreadTargetRepoResolver{t: r.t.TargetRepo, loader: r.loader}, true
 }
 
 func marshalDiscussionThreadID(dbID int64) graphql.ID {
@@ -659,6 +1114,10 @@ func discussionThreadByID(ctx context.Context, id graphql.ID) (*discussionThread
 // caller MUST check permissions.
 type discussionThreadResolver struct {
 	t *types.DiscussionThread
+
+	// loader, if non-nil, loads data for all threads in the list that this
+	// thread was resolved in (see newDiscussionThreadResolvers).
+	loader *discussionThreadsLoader
 }
 
 func (d *discussionThreadResolver) ID() graphql.ID {
@@ -670,13 +1129,17 @@ func (d *discussionThreadResolver) IDWithoutKind() string {
 }
 
 func (d *discussionThreadResolver) Author(ctx context.Context) (*UserResolver, error) {
-	return UserByIDInt32(ctx, d.t.AuthorUserID)
+	var authors *discussionUsersLoader
+	if d.loader != nil {
+		authors = d.loader.authors
+	}
+	return discussionUserByID(ctx, authors, d.t.AuthorUserID)
 }
 
 func (d *discussionThreadResolver) Title() string { return d.t.Title }
 
 func (d *discussionThreadResolver) Target(ctx context.Context) *discussionThreadTargetResolver {
-	return &discussionThreadTargetResolver{t: d.t}
+	return &discussionThreadTargetResolver{t: d.t, loader: d.loader}
 }
 
 func (d *discussionThreadResolver) InlineURL(ctx context.Context) (*string, error) {
@@ -695,23 +1158,46 @@ func (d *discussionThreadResolver) UpdatedAt() DateTime {
 	return DateTime{Time: d.t.UpdatedAt}
 }
 
+func (d *discussionThreadResolver) ClosedAt() *DateTime {
+	return DateTimeOrNil(d.t.ClosedAt)
+}
+
 func (d *discussionThreadResolver) ArchivedAt() *DateTime {
 	return DateTimeOrNil(d.t.ArchivedAt)
 }
 
+func (d *discussionThreadResolver) StaleAt() *DateTime {
+	return DateTimeOrNil(d.t.StaleAt)
+}
+
+func (d *discussionThreadResolver) RepositoryDeletedAt() *DateTime {
+	return DateTimeOrNil(d.t.RepoDeletedAt)
+}
+
+func (d *discussionThreadResolver) IsLocked() bool { return d.t.LockedAt != nil }
+
 func (d *discussionThreadResolver) Comments(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
+	TopLevelOnly bool
 }) *discussionCommentsConnectionResolver {
 	// 🚨 SECURITY: Anyone with access to the thread also has access to its
 	// comments. Hence, since we are only accessing the threads comments here
 	// (and not other thread's comments) we are covered security-wise here
 	// implicitly.
 
-	opt := &db.DiscussionCommentsListOptions{ThreadID: &d.t.ID}
-	args.ConnectionArgs.Set(&opt.LimitOffset)
-	return &discussionCommentsConnectionResolver{opt: opt}
+	opt := &db.DiscussionCommentsListOptions{ThreadID: &d.t.ID, TopLevel: args.TopLevelOnly}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	r := &discussionCommentsConnectionResolver{opt: opt}
+	if !args.TopLevelOnly {
+		r.totalCount = &d.t.CommentCount
+	}
+	return r
 }
 
+func (d *discussionThreadResolver) CommentCount() int32 { return d.t.CommentCount }
+
+func (d *discussionThreadResolver) ReactionCount() int32 { return d.t.ReactionCount }
+
 // discussionThreadsConnectionResolver resolves a list of discussion comments.
 //
 // 🚨 SECURITY: When instantiating an discussionThreadsConnectionResolver
@@ -744,16 +1230,17 @@ func (r *discussionThreadsConnectionResolver) Nodes(ctx context.Context) ([]*dis
 	if err != nil {
 		return nil, err
 	}
-	var l []*discussionThreadResolver
-	for _, thread := range threads {
-		l = append(l, &discussionThreadResolver{t: thread})
+	if r.opt.LimitOffset != nil && len(threads) > r.opt.Limit {
+		threads = threads[:r.opt.Limit]
 	}
-	return l, nil
+
+	return newDiscussionThreadResolvers(threads), nil
 }
 
 func (r *discussionThreadsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
 	withoutLimit := *r.opt
 	withoutLimit.LimitOffset = nil
+	withoutLimit.After = nil
 	count, err := db.DiscussionThreads.Count(ctx, &withoutLimit)
 	return int32(count), err
 }
@@ -763,7 +1250,32 @@ func (r *discussionThreadsConnectionResolver) PageInfo(ctx context.Context) (*gr
 	if err != nil {
 		return nil, err
 	}
-	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(threads) > r.opt.Limit), nil
+	if r.opt.LimitOffset == nil || len(threads) <= r.opt.Limit || r.opt.Limit == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	last := threads[r.opt.Limit-1]
+	return graphqlutil.NextPageCursor(marshalDiscussionThreadsCursor(db.NewDiscussionThreadsCursor(last, r.opt))), nil
+}
+
+const discussionThreadsCursorKind = "DiscussionThreadsCursor"
+
+// marshalDiscussionThreadsCursor marshals a discussion threads pagination
+// cursor. From an API consumer standpoint, it is an opaque string.
+func marshalDiscussionThreadsCursor(c *db.DiscussionThreadsCursor) string {
+	return string(relay.MarshalID(discussionThreadsCursorKind, c))
+}
+
+// unmarshalDiscussionThreadsCursor unmarshals a discussion threads pagination
+// cursor.
+func unmarshalDiscussionThreadsCursor(cursor string) (*db.DiscussionThreadsCursor, error) {
+	if kind := relay.UnmarshalKind(graphql.ID(cursor)); kind != discussionThreadsCursorKind {
+		return nil, fmt.Errorf("cannot unmarshal discussion threads cursor type: %q", kind)
+	}
+	var c *db.DiscussionThreadsCursor
+	if err := relay.UnmarshalSpec(graphql.ID(cursor), &c); err != nil {
+		return nil, err
+	}
+	return c, nil
 }
 
 var mockViewerCanUseDiscussions func() error
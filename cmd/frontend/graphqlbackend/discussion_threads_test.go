@@ -4,11 +4,16 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
+	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 )
 
 func TestDiscussionThread_Get(t *testing.T) {
@@ -137,9 +142,55 @@ func TestDiscussionSelectionRelativeTo(t *testing.T) {
 	}
 }
 
+func TestDiscussionsMutations_CreateThread_idempotencyKey(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	verifiedAt := time.Now()
+	db.Mocks.UserEmails.ListByUser = func(int32) ([]*db.UserEmail, error) {
+		return []*db.UserEmail{{UserID: 1, Email: "a@example.com", VerifiedAt: &verifiedAt}}, nil
+	}
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	db.Mocks.DiscussionThreads.GetByIdempotencyKey = func(authorUserID int32, key string) (*types.DiscussionThread, error) {
+		if authorUserID != 1 || key != "k" {
+			t.Errorf("got author %d and key %q, want 1 and %q", authorUserID, key, "k")
+		}
+		return &types.DiscussionThread{ID: 2, Title: "existing"}, nil
+	}
+	db.Mocks.DiscussionThreads.CreateWithComment = func(context.Context, *types.DiscussionThread, *types.DiscussionComment, *types.DiscussionThreadEvent) (*types.DiscussionThread, error) {
+		t.Fatal("expected no thread to be created")
+		return nil, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation {
+					discussions {
+						createThread(input: {contents: "x", idempotencyKey: "k"}) {
+							title
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussions": {
+						"createThread": {
+							"title": "existing"
+						}
+					}
+				}
+			`,
+		},
+	})
+}
+
 func TestDiscussionsMutations_UpdateThread(t *testing.T) {
 	resetMocks()
-	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{}, nil }
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
 	mockViewerCanUseDiscussions = func() error { return nil }
 	defer func() { mockViewerCanUseDiscussions = nil }()
 	const (
@@ -150,9 +201,10 @@ func TestDiscussionsMutations_UpdateThread(t *testing.T) {
 		if threadID != wantThreadID {
 			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
 		}
-		return &types.DiscussionThread{}, nil
+		return &types.DiscussionThread{ID: wantThreadID, Title: "a"}, nil
 	}
-	db.Mocks.DiscussionThreads.Update = func(_ context.Context, threadID int64, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error) {
+	var actor int32
+	db.Mocks.DiscussionThreads.UpdateAndRecord = func(_ context.Context, threadID int64, actorUserID int32, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
 		if threadID != wantThreadID {
 			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
 		}
@@ -163,7 +215,8 @@ func TestDiscussionsMutations_UpdateThread(t *testing.T) {
 			}
 			t.Errorf("got title %v, want %v", title, wantTitle)
 		}
-		return &types.DiscussionThread{ID: wantThreadID, Title: wantTitle}, nil
+		actor = actorUserID
+		return &types.DiscussionThread{ID: wantThreadID, Title: wantTitle}, nil, nil
 	}
 
 	gqltesting.RunTests(t, []*gqltesting.Test{
@@ -191,4 +244,581 @@ func TestDiscussionsMutations_UpdateThread(t *testing.T) {
                         `,
 		},
 	})
+
+	// The change is recorded on the timeline as made by the current user.
+	if actor != 1 {
+		t.Errorf("got actor user ID %d, want 1", actor)
+	}
+}
+
+func TestDiscussionsMutations_UpdateThread_invalidTitle(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	db.Mocks.DiscussionThreads.UpdateAndRecord = func(context.Context, int64, int32, *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
+		t.Fatal("expected thread not to be updated")
+		return nil, nil, nil
+	}
+	title := " "
+	_, err := (&discussionsMutationResolver{}).UpdateThread(context.Background(), &struct {
+		Input *struct {
+			ThreadID          graphql.ID
+			Title             *string
+			Contents          *string
+			Archive           *bool
+			Delete            *bool
+			ExpectedUpdatedAt *DateTime
+		}
+	}{Input: &struct {
+		ThreadID          graphql.ID
+		Title             *string
+		Contents          *string
+		Archive           *bool
+		Delete            *bool
+		ExpectedUpdatedAt *DateTime
+	}{ThreadID: marshalDiscussionThreadID(1), Title: &title}})
+	if e, ok := err.(*discussions.ValidationError); !ok || e.Field != "title" {
+		t.Errorf("got error %v, want validation error for field title", err)
+	}
+}
+
+func TestDiscussionsMutations_UpdateThread_archived(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1, SiteAdmin: true}, nil }
+	archivedAt := time.Now()
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID, AuthorUserID: 1, Title: "a", ArchivedAt: &archivedAt}, nil
+	}
+	db.Mocks.DiscussionThreads.UpdateAndRecord = func(context.Context, int64, int32, *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
+		t.Fatal("expected thread not to be updated")
+		return nil, nil, nil
+	}
+	title := "b"
+	_, err := (&discussionsMutationResolver{}).UpdateThread(context.Background(), &struct {
+		Input *struct {
+			ThreadID          graphql.ID
+			Title             *string
+			Contents          *string
+			Archive           *bool
+			Delete            *bool
+			ExpectedUpdatedAt *DateTime
+		}
+	}{Input: &struct {
+		ThreadID          graphql.ID
+		Title             *string
+		Contents          *string
+		Archive           *bool
+		Delete            *bool
+		ExpectedUpdatedAt *DateTime
+	}{ThreadID: marshalDiscussionThreadID(1), Title: &title}})
+	if err != discussions.ErrThreadArchived {
+		t.Errorf("got error %v, want %v", err, discussions.ErrThreadArchived)
+	}
+}
+
+func TestDiscussionsMutations_UpdateThread_contents(t *testing.T) {
+	resetMocks()
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	const (
+		wantThreadID  = 123
+		wantCommentID = 456
+		wantContents  = "b"
+	)
+	db.Mocks.DiscussionThreads.Get = func(int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: wantThreadID, AuthorUserID: 1, Title: "t"}, nil
+	}
+	var updatedContents *string
+	db.Mocks.DiscussionThreads.UpdateAndRecord = func(_ context.Context, threadID int64, _ int32, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
+		// The first comment's contents are updated along with the thread.
+		updatedContents = opts.Contents
+		if opts.EditorUserID != 1 {
+			t.Errorf("got editor user ID %d, want 1", opts.EditorUserID)
+		}
+		return &types.DiscussionThread{ID: threadID, AuthorUserID: 1, Title: "t"}, nil, nil
+	}
+	db.Mocks.DiscussionComments.List = func(_ context.Context, opts *db.DiscussionCommentsListOptions) ([]*types.DiscussionComment, error) {
+		if opts.ThreadID == nil || *opts.ThreadID != wantThreadID {
+			t.Errorf("got options %+v, want thread ID %d", opts, wantThreadID)
+		}
+		return []*types.DiscussionComment{{ID: wantCommentID, ThreadID: wantThreadID, AuthorUserID: 1, Contents: "a"}}, nil
+	}
+	db.Mocks.DiscussionComments.Get = func(int64) (*types.DiscussionComment, error) {
+		return &types.DiscussionComment{ID: wantCommentID, ThreadID: wantThreadID, AuthorUserID: 1, Contents: "a"}, nil
+	}
+	db.Mocks.DiscussionCommentMentions.List = func(context.Context, int64) ([]*types.DiscussionCommentMention, error) {
+		return nil, nil
+	}
+	var mentionsStored bool
+	db.Mocks.DiscussionCommentMentions.Set = func(context.Context, int64, []int32, []int32) error {
+		mentionsStored = true
+		return nil
+	}
+	db.Mocks.DiscussionThreadReferences.Set = func(context.Context, int64, int64, []int64) ([]int64, error) {
+		return nil, nil
+	}
+
+	t.Run("author", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		gqltesting.RunTests(t, []*gqltesting.Test{
+			{
+				Context: backend.WithAuthzBypass(context.Background()),
+				Schema:  mustParseGraphQLSchema(t, nil),
+				Query: `
+					mutation($contents: String!) {
+						discussions {
+							updateThread(input: {threadID: "RGlzY3Vzc2lvblRocmVhZDoiM2Yi", contents: $contents}) {
+								title
+							}
+						}
+					}
+				`,
+				Variables: map[string]interface{}{"contents": wantContents},
+				ExpectedResult: `
+					{
+						"discussions": {
+							"updateThread": {
+								"title": "t"
+							}
+						}
+					}
+				`,
+			},
+		})
+		if updatedContents == nil || *updatedContents != wantContents {
+			t.Errorf("got contents %v, want %q", updatedContents, wantContents)
+		}
+		if !mentionsStored {
+			t.Error("expected the comment mentions to be stored")
+		}
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		updatedContents = nil
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 2}, nil }
+		db.Mocks.Users.GetByID = func(context.Context, int32) (*types.User, error) { return &types.User{ID: 1, Username: "u"}, nil }
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 2})
+		contents := wantContents
+		_, err := (&discussionsMutationResolver{}).UpdateThread(ctx, &struct {
+			Input *struct {
+				ThreadID          graphql.ID
+				Title             *string
+				Contents          *string
+				Archive           *bool
+				Delete            *bool
+				ExpectedUpdatedAt *DateTime
+			}
+		}{Input: &struct {
+			ThreadID          graphql.ID
+			Title             *string
+			Contents          *string
+			Archive           *bool
+			Delete            *bool
+			ExpectedUpdatedAt *DateTime
+		}{ThreadID: marshalDiscussionThreadID(wantThreadID), Contents: &contents}})
+		if _, ok := err.(*backend.InsufficientAuthorizationError); !ok {
+			t.Errorf("got error %v, want *backend.InsufficientAuthorizationError", err)
+		}
+		if updatedContents != nil {
+			t.Error("expected the contents not to be updated")
+		}
+	})
+}
+
+func TestDiscussionsMutations_UpdateThreads(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	archivedAt := time.Now()
+	db.Mocks.DiscussionThreads.List = func(_ context.Context, opt *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		// Thread 2 does not exist.
+		var threads []*types.DiscussionThread
+		for _, id := range opt.ThreadIDs {
+			if id != 2 {
+				threads = append(threads, &types.DiscussionThread{ID: id, Title: "t", ArchivedAt: &archivedAt})
+			}
+		}
+		return threads, nil
+	}
+	db.Mocks.DiscussionThreads.UpdateMany = func(_ context.Context, threadIDs []int64, actorUserID int32, opts *db.DiscussionThreadsUpdateOptions) ([]int64, []*types.DiscussionThreadEvent, error) {
+		// Thread 2 is not updated because it does not exist.
+		if want := []int64{1}; !reflect.DeepEqual(threadIDs, want) {
+			t.Errorf("got threadIDs %v, want %v", threadIDs, want)
+		}
+		// The changes are recorded as made by the current user.
+		if actorUserID != 1 {
+			t.Errorf("got actor user ID %d, want 1", actorUserID)
+		}
+		if opts.Archive == nil || !*opts.Archive {
+			t.Error("want Archive true")
+		}
+		return []int64{1}, []*types.DiscussionThreadEvent{{ID: 1, ThreadID: 1, ActorUserID: actorUserID, Kind: types.DiscussionThreadEventArchived}}, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation($threadIDs: [ID!]!) {
+					discussions {
+						updateThreads(input: {threadIDs: $threadIDs, archive: true}) {
+							threadID
+							thread {
+								title
+							}
+							error
+						}
+					}
+				}
+			`,
+			Variables: map[string]interface{}{"threadIDs": []interface{}{
+				string(marshalDiscussionThreadID(1)),
+				string(marshalDiscussionThreadID(2)),
+				"invalid",
+			}},
+			ExpectedResult: `
+				{
+					"discussions": {
+						"updateThreads": [
+							{
+								"threadID": "` + string(marshalDiscussionThreadID(1)) + `",
+								"thread": {"title": "t"},
+								"error": null
+							},
+							{
+								"threadID": "` + string(marshalDiscussionThreadID(2)) + `",
+								"thread": null,
+								"error": "thread 2 not found"
+							},
+							{
+								"threadID": "invalid",
+								"thread": null,
+								"error": "illegal base64 data at input byte 4"
+							}
+						]
+					}
+				}
+			`,
+		},
+	})
+}
+
+func TestDiscussionsMutations_ArchiveThreads(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	inactiveSince := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	db.Mocks.DiscussionThreads.List = func(_ context.Context, opt *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if len(opt.ThreadIDs) > 0 {
+			var threads []*types.DiscussionThread
+			for _, id := range opt.ThreadIDs {
+				threads = append(threads, &types.DiscussionThread{ID: id, Title: "t"})
+			}
+			return threads, nil
+		}
+		if opt.Archived == nil || *opt.Archived {
+			t.Errorf("got Archived %v, want false", opt.Archived)
+		}
+		if want := []string{"wontfix"}; !reflect.DeepEqual(opt.LabelNames, want) {
+			t.Errorf("got LabelNames %v, want %v", opt.LabelNames, want)
+		}
+		if opt.InactiveBefore == nil || !opt.InactiveBefore.Equal(inactiveSince) {
+			t.Errorf("got InactiveBefore %v, want %v", opt.InactiveBefore, inactiveSince)
+		}
+		return []*types.DiscussionThread{{ID: 1}, {ID: 3}}, nil
+	}
+	db.Mocks.DiscussionThreads.UpdateMany = func(_ context.Context, threadIDs []int64, _ int32, opts *db.DiscussionThreadsUpdateOptions) ([]int64, []*types.DiscussionThreadEvent, error) {
+		if want := []int64{1, 3}; !reflect.DeepEqual(threadIDs, want) {
+			t.Errorf("got threadIDs %v, want %v", threadIDs, want)
+		}
+		if opts.Archive == nil || !*opts.Archive {
+			t.Error("want Archive true")
+		}
+		return threadIDs, nil, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: backend.WithAuthzBypass(context.Background()),
+			Schema:  mustParseGraphQLSchema(t, nil),
+			Query: `
+				mutation {
+					discussions {
+						archiveThreads(input: {labels: ["wontfix"], inactiveSince: "2019-01-02T03:04:05Z"}) {
+							threadID
+							error
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussions": {
+						"archiveThreads": [
+							{"threadID": "` + string(marshalDiscussionThreadID(1)) + `", "error": null},
+							{"threadID": "` + string(marshalDiscussionThreadID(3)) + `", "error": null}
+						]
+					}
+				}
+			`,
+		},
+	})
+
+	t.Run("no criteria", func(t *testing.T) {
+		_, err := (&discussionsMutationResolver{}).ArchiveThreads(context.Background(), &struct {
+			Input *discussionThreadsArchiveInput
+		}{
+			Input: &discussionThreadsArchiveInput{Labels: &[]string{}},
+		})
+		if want := "at least one of labels or inactiveSince must be specified"; err == nil || err.Error() != want {
+			t.Errorf("got error %v, want %q", err, want)
+		}
+	})
+}
+
+func TestDiscussionThreads_Pagination(t *testing.T) {
+	resetMocks()
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	updatedAt := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	cursor := marshalDiscussionThreadsCursor(&db.DiscussionThreadsCursor{UpdatedAt: updatedAt, ID: 3})
+	db.Mocks.DiscussionThreads.List = func(_ context.Context, opt *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if opt.After == nil {
+			return []*types.DiscussionThread{
+				{ID: 4, UpdatedAt: updatedAt},
+				{ID: 3, UpdatedAt: updatedAt},
+				{ID: 2, UpdatedAt: updatedAt},
+			}, nil
+		}
+		if want := (&db.DiscussionThreadsCursor{UpdatedAt: updatedAt, ID: 3}); !reflect.DeepEqual(opt.After, want) {
+			t.Errorf("got After %+v, want %+v", opt.After, want)
+		}
+		return []*types.DiscussionThread{{ID: 2, UpdatedAt: updatedAt}}, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t, nil),
+			Query: `
+				{
+					discussionThreads(first: 2) {
+						nodes {
+							idWithoutKind
+						}
+						pageInfo {
+							endCursor
+							hasNextPage
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"discussionThreads": {
+						"nodes": [{"idWithoutKind": "4"}, {"idWithoutKind": "3"}],
+						"pageInfo": {
+							"endCursor": "` + cursor + `",
+							"hasNextPage": true
+						}
+					}
+				}
+			`,
+		},
+		{
+			Schema: mustParseGraphQLSchema(t, nil),
+			Query: `
+				query($after: String) {
+					discussionThreads(first: 2, after: $after) {
+						nodes {
+							idWithoutKind
+						}
+						pageInfo {
+							endCursor
+							hasNextPage
+						}
+					}
+				}
+			`,
+			Variables: map[string]interface{}{"after": cursor},
+			ExpectedResult: `
+				{
+					"discussionThreads": {
+						"nodes": [{"idWithoutKind": "2"}],
+						"pageInfo": {
+							"endCursor": null,
+							"hasNextPage": false
+						}
+					}
+				}
+			`,
+		},
+	})
+}
+
+func TestDiscussionsMutations_RestoreThread(t *testing.T) {
+	resetMocks()
+	mockViewerCanUseDiscussions = func() error { return nil }
+	defer func() { mockViewerCanUseDiscussions = nil }()
+	const wantThreadID = 123
+	var restoreEvent *types.DiscussionThreadEvent
+	db.Mocks.DiscussionThreads.Restore = func(_ context.Context, threadID int64, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThread, error) {
+		if threadID != wantThreadID {
+			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+		}
+		if newEvent.ActorUserID == 0 {
+			// Like DiscussionThreadEvents.create, which fails the restore.
+			return nil, errors.New("newEvent.ActorUserID must be specified")
+		}
+		restoreEvent = newEvent
+		return &types.DiscussionThread{ID: threadID}, nil
+	}
+	args := &struct{ ThreadID graphql.ID }{ThreadID: marshalDiscussionThreadID(wantThreadID)}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		if _, err := (&discussionsMutationResolver{}).RestoreThread(ctx, args); err == nil {
+			t.Error("expected error")
+		}
+		if restoreEvent != nil {
+			t.Error("thread was restored by non-admin")
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		if _, err := (&discussionsMutationResolver{}).RestoreThread(ctx, args); err != nil {
+			t.Fatal(err)
+		}
+		want := &types.DiscussionThreadEvent{ActorUserID: 1, Kind: types.DiscussionThreadEventRestored}
+		if !reflect.DeepEqual(restoreEvent, want) {
+			t.Errorf("got restore event %+v, want %+v", restoreEvent, want)
+		}
+	})
+}
+
+func TestDiscussionsMutations_LockThread(t *testing.T) {
+	resetMocks()
+	const wantThreadID = 123
+	var locked *time.Time
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID, LockedAt: locked}, nil
+	}
+	db.Mocks.DiscussionThreads.UpdateAndRecord = func(_ context.Context, threadID int64, _ int32, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
+		if threadID != wantThreadID {
+			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+		}
+		if opts.Lock == nil {
+			t.Fatal("expected opts.Lock to be set")
+		}
+		locked = nil
+		if *opts.Lock {
+			now := time.Now()
+			locked = &now
+		}
+		return &types.DiscussionThread{ID: threadID, LockedAt: locked}, nil, nil
+	}
+	args := &struct{ ThreadID graphql.ID }{ThreadID: marshalDiscussionThreadID(wantThreadID)}
+
+	t.Run("non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+		if _, err := (&discussionsMutationResolver{}).LockThread(context.Background(), args); err == nil {
+			t.Error("expected error")
+		}
+		if locked != nil {
+			t.Error("thread was locked by non-admin")
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		thread, err := (&discussionsMutationResolver{}).LockThread(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !thread.IsLocked() {
+			t.Error("expected thread to be locked")
+		}
+		thread, err = (&discussionsMutationResolver{}).UnlockThread(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thread.IsLocked() {
+			t.Error("expected thread to be unlocked")
+		}
+	})
+}
+
+func TestDiscussionsMutations_CloseThread(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) { return &types.User{ID: 1}, nil }
+	const wantThreadID = 123
+	var closedAt, archivedAt *time.Time
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return &types.DiscussionThread{ID: threadID, AuthorUserID: 1, ClosedAt: closedAt, ArchivedAt: archivedAt}, nil
+	}
+	var events []*types.DiscussionThreadEvent
+	db.Mocks.DiscussionThreads.SetClosed = func(_ context.Context, threadID int64, closed bool, newEvent *types.DiscussionThreadEvent) (*types.DiscussionThread, error) {
+		if threadID != wantThreadID {
+			t.Errorf("got threadID %v, want %v", threadID, wantThreadID)
+		}
+		if closed == (closedAt != nil) {
+			if closed {
+				return nil, db.ErrThreadAlreadyClosed
+			}
+			return nil, db.ErrThreadNotClosed
+		}
+		closedAt = nil
+		if closed {
+			now := time.Now()
+			closedAt = &now
+		}
+		events = append(events, newEvent)
+		return &types.DiscussionThread{ID: threadID, AuthorUserID: 1, ClosedAt: closedAt}, nil
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	args := &struct{ ThreadID graphql.ID }{ThreadID: marshalDiscussionThreadID(wantThreadID)}
+
+	thread, err := (&discussionsMutationResolver{}).CloseThread(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thread.ClosedAt() == nil {
+		t.Error("expected thread to be closed")
+	}
+	if _, err := (&discussionsMutationResolver{}).CloseThread(ctx, args); discussions.Code(err) != discussions.ErrorCodeInvalidState {
+		t.Errorf("close closed thread: got error %v, want an INVALID_STATE error", err)
+	}
+
+	thread, err = (&discussionsMutationResolver{}).ReopenThread(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thread.ClosedAt() != nil {
+		t.Error("expected thread to be reopened")
+	}
+	if _, err := (&discussionsMutationResolver{}).ReopenThread(ctx, args); discussions.Code(err) != discussions.ErrorCodeInvalidState {
+		t.Errorf("reopen open thread: got error %v, want an INVALID_STATE error", err)
+	}
+
+	wantEvents := []*types.DiscussionThreadEvent{
+		{ActorUserID: 1, Kind: types.DiscussionThreadEventClosed},
+		{ActorUserID: 1, Kind: types.DiscussionThreadEventReopened},
+	}
+	if !reflect.DeepEqual(events, wantEvents) {
+		t.Errorf("got events %+v, want %+v", events, wantEvents)
+	}
+
+	// Archived threads are read-only, so they can't be closed.
+	now := time.Now()
+	archivedAt = &now
+	if _, err := (&discussionsMutationResolver{}).CloseThread(ctx, args); err != discussions.ErrThreadArchived {
+		t.Errorf("close archived thread: got error %v, want %v", err, discussions.ErrThreadArchived)
+	}
 }
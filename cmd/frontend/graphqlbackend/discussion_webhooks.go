@@ -0,0 +1,294 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func marshalDiscussionWebhookID(dbID int64) graphql.ID {
+	return relay.MarshalID("DiscussionWebhook", strconv.FormatInt(dbID, 36))
+}
+
+func unmarshalDiscussionWebhookID(id graphql.ID) (dbID int64, err error) {
+	var dbIDStr string
+	err = relay.UnmarshalSpec(id, &dbIDStr)
+	if err == nil {
+		dbID, err = strconv.ParseInt(dbIDStr, 36, 64)
+	}
+	return
+}
+
+// discussionWebhookByID looks up a DiscussionWebhook by its GraphQL ID.
+func discussionWebhookByID(ctx context.Context, id graphql.ID) (*discussionWebhookResolver, error) {
+	// 🚨 SECURITY: Only site admins can view discussion webhooks, because their
+	// delivery logs reveal activity on every thread they apply to.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	dbID, err := unmarshalDiscussionWebhookID(id)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := db.DiscussionWebhooks.Get(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	return &discussionWebhookResolver{w: webhook}, nil
+}
+
+// discussionWebhookResolver resolves a discussion webhook.
+//
+// 🚨 SECURITY: The webhook's secret MUST NOT be exposed.
+type discussionWebhookResolver struct {
+	w *types.DiscussionWebhook
+}
+
+func (r *discussionWebhookResolver) ID() graphql.ID {
+	return marshalDiscussionWebhookID(r.w.ID)
+}
+
+func (r *discussionWebhookResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	if r.w.RepoID == nil {
+		return nil, nil
+	}
+	return RepositoryByIDInt32(ctx, *r.w.RepoID)
+}
+
+func (r *discussionWebhookResolver) URL() string { return r.w.URL }
+
+func (r *discussionWebhookResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.w.CreatedAt}
+}
+
+func (r *discussionWebhookResolver) Deliveries(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) *discussionWebhookDeliveriesConnectionResolver {
+	opt := &db.DiscussionWebhookDeliveriesListOptions{WebhookID: r.w.ID}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionWebhookDeliveriesConnectionResolver{opt: opt}
+}
+
+func (r *discussionsMutationResolver) CreateWebhook(ctx context.Context, args *struct {
+	Input *struct {
+		Repository *graphql.ID
+		URL        string
+		Secret     string
+	}
+}) (*discussionWebhookResolver, error) {
+	// 🚨 SECURITY: Only site admins can create discussion webhooks.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	webhook := &types.DiscussionWebhook{
+		URL:    args.Input.URL,
+		Secret: args.Input.Secret,
+	}
+	if args.Input.Repository != nil {
+		repo, err := repositoryByID(ctx, *args.Input.Repository)
+		if err != nil {
+			return nil, err
+		}
+		webhook.RepoID = &repo.repo.ID
+	}
+	webhook, err := db.DiscussionWebhooks.Create(ctx, webhook)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionWebhooks.Create")
+	}
+	return &discussionWebhookResolver{w: webhook}, nil
+}
+
+func (r *discussionsMutationResolver) DeleteWebhook(ctx context.Context, args *struct {
+	Webhook graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can delete discussion webhooks.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	webhookID, err := unmarshalDiscussionWebhookID(args.Webhook)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.DiscussionWebhooks.Delete(ctx, webhookID); err != nil {
+		return nil, errors.Wrap(err, "DiscussionWebhooks.Delete")
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (*schemaResolver) DiscussionWebhooks(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+	Repository *graphql.ID
+}) (*discussionWebhooksConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins can list discussion webhooks.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	opt := &db.DiscussionWebhooksListOptions{}
+	if args.Repository != nil {
+		repo, err := repositoryByID(ctx, *args.Repository)
+		if err != nil {
+			return nil, err
+		}
+		opt.RepoID = repo.repo.ID
+	}
+	args.ConnectionArgs.SetWithDefault(&opt.LimitOffset)
+	return &discussionWebhooksConnectionResolver{opt: opt}, nil
+}
+
+// discussionWebhooksConnectionResolver resolves a list of discussion webhooks.
+//
+// 🚨 SECURITY: When instantiating an discussionWebhooksConnectionResolver
+// value, the caller MUST check permissions.
+type discussionWebhooksConnectionResolver struct {
+	opt *db.DiscussionWebhooksListOptions
+
+	// cache results because they are used by multiple fields
+	once     sync.Once
+	webhooks []*types.DiscussionWebhook
+	err      error
+}
+
+func (r *discussionWebhooksConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionWebhook, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.webhooks, r.err = db.DiscussionWebhooks.List(ctx, &opt2)
+	})
+	return r.webhooks, r.err
+}
+
+func (r *discussionWebhooksConnectionResolver) Nodes(ctx context.Context) ([]*discussionWebhookResolver, error) {
+	webhooks, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(webhooks) > r.opt.Limit {
+		webhooks = webhooks[:r.opt.Limit]
+	}
+
+	var l []*discussionWebhookResolver
+	for _, webhook := range webhooks {
+		l = append(l, &discussionWebhookResolver{w: webhook})
+	}
+	return l, nil
+}
+
+func (r *discussionWebhooksConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionWebhooks.Count(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionWebhooksConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	webhooks, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(webhooks) > r.opt.Limit), nil
+}
+
+type discussionWebhookDeliveryResolver struct {
+	d *types.DiscussionWebhookDelivery
+}
+
+func (r *discussionWebhookDeliveryResolver) Event(ctx context.Context) (*discussionThreadTimelineItemResolver, error) {
+	events, err := db.DiscussionThreadEvents.List(ctx, &db.DiscussionThreadEventsListOptions{
+		EventIDs: []int64{r.d.EventID},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadEvents.List")
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("event %d not found", r.d.EventID)
+	}
+	return &discussionThreadTimelineItemResolver{e: events[0]}, nil
+}
+
+func (r *discussionWebhookDeliveryResolver) Attempts() int32 { return r.d.Attempts }
+
+func (r *discussionWebhookDeliveryResolver) StatusCode() *int32 { return r.d.StatusCode }
+
+func (r *discussionWebhookDeliveryResolver) Error() *string { return r.d.Error }
+
+func (r *discussionWebhookDeliveryResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.d.CreatedAt}
+}
+
+func (r *discussionWebhookDeliveryResolver) DeliveredAt() *DateTime {
+	return DateTimeOrNil(r.d.DeliveredAt)
+}
+
+// discussionWebhookDeliveriesConnectionResolver resolves a list of discussion
+// webhook deliveries.
+//
+// 🚨 SECURITY: When instantiating an
+// discussionWebhookDeliveriesConnectionResolver value, the caller MUST check
+// permissions.
+type discussionWebhookDeliveriesConnectionResolver struct {
+	opt *db.DiscussionWebhookDeliveriesListOptions
+
+	// cache results because they are used by multiple fields
+	once       sync.Once
+	deliveries []*types.DiscussionWebhookDelivery
+	err        error
+}
+
+func (r *discussionWebhookDeliveriesConnectionResolver) compute(ctx context.Context) ([]*types.DiscussionWebhookDelivery, error) {
+	r.once.Do(func() {
+		opt2 := *r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.deliveries, r.err = db.DiscussionWebhooks.ListDeliveries(ctx, &opt2)
+	})
+	return r.deliveries, r.err
+}
+
+func (r *discussionWebhookDeliveriesConnectionResolver) Nodes(ctx context.Context) ([]*discussionWebhookDeliveryResolver, error) {
+	deliveries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(deliveries) > r.opt.Limit {
+		deliveries = deliveries[:r.opt.Limit]
+	}
+
+	var l []*discussionWebhookDeliveryResolver
+	for _, delivery := range deliveries {
+		l = append(l, &discussionWebhookDeliveryResolver{d: delivery})
+	}
+	return l, nil
+}
+
+func (r *discussionWebhookDeliveriesConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	withoutLimit := *r.opt
+	withoutLimit.LimitOffset = nil
+	count, err := db.DiscussionWebhooks.CountDeliveries(ctx, &withoutLimit)
+	return int32(count), err
+}
+
+func (r *discussionWebhookDeliveriesConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	deliveries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(deliveries) > r.opt.Limit), nil
+}
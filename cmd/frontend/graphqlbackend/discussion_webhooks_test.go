@@ -0,0 +1,62 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestDiscussionsMutations_CreateWebhook(t *testing.T) {
+	var siteAdmin bool
+	setup := func() {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: siteAdmin}, nil
+		}
+		db.Mocks.DiscussionWebhooks.Create = func(_ context.Context, newWebhook *types.DiscussionWebhook) (*types.DiscussionWebhook, error) {
+			newWebhook.ID = 2
+			return newWebhook, nil
+		}
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	type createWebhookInput = struct {
+		Repository *graphql.ID
+		URL        string
+		Secret     string
+	}
+	input := &createWebhookInput{URL: "https://example.com", Secret: "s"}
+
+	t.Run("non-site admin", func(t *testing.T) {
+		setup()
+		siteAdmin = false
+		if _, err := (&discussionsMutationResolver{}).CreateWebhook(ctx, &struct{ Input *createWebhookInput }{Input: input}); err == nil {
+			t.Error("expected error")
+		}
+		if _, err := (&schemaResolver{}).DiscussionWebhooks(ctx, &struct {
+			graphqlutil.ConnectionArgs
+			Repository *graphql.ID
+		}{}); err == nil {
+			t.Error("expected error listing webhooks")
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		setup()
+		siteAdmin = true
+		webhook, err := (&discussionsMutationResolver{}).CreateWebhook(ctx, &struct{ Input *createWebhookInput }{Input: input})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := marshalDiscussionWebhookID(2); webhook.ID() != want {
+			t.Errorf("got webhook ID %q, want %q", webhook.ID(), want)
+		}
+		if webhook.w.RepoID != nil {
+			t.Errorf("got repository %v, want site-wide webhook", *webhook.w.RepoID)
+		}
+	})
+}
@@ -149,6 +149,41 @@ func (r *NodeResolver) ToDiscussionThread() (*discussionThreadResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToDiscussionLabel() (*discussionLabelResolver, bool) {
+	n, ok := r.Node.(*discussionLabelResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToDiscussionMilestone() (*discussionMilestoneResolver, bool) {
+	n, ok := r.Node.(*discussionMilestoneResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToDiscussionThreadTemplate() (*discussionThreadTemplateResolver, bool) {
+	n, ok := r.Node.(*discussionThreadTemplateResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToDiscussionSavedReply() (*discussionSavedReplyResolver, bool) {
+	n, ok := r.Node.(*discussionSavedReplyResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToDiscussionWebhook() (*discussionWebhookResolver, bool) {
+	n, ok := r.Node.(*discussionWebhookResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToDiscussionRule() (*discussionRuleResolver, bool) {
+	n, ok := r.Node.(*discussionRuleResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToDiscussionFeed() (*discussionFeedResolver, bool) {
+	n, ok := r.Node.(*discussionFeedResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToProductLicense() (ProductLicense, bool) {
 	n, ok := r.Node.(ProductLicense)
 	return n, ok
@@ -284,6 +319,20 @@ func (r *schemaResolver) nodeByID(ctx context.Context, id graphql.ID) (Node, err
 		return discussionCommentByID(ctx, id)
 	case "DiscussionThread":
 		return discussionThreadByID(ctx, id)
+	case "DiscussionLabel":
+		return discussionLabelByID(ctx, id)
+	case "DiscussionMilestone":
+		return discussionMilestoneByID(ctx, id)
+	case "DiscussionThreadTemplate":
+		return discussionThreadTemplateByID(ctx, id)
+	case "DiscussionSavedReply":
+		return discussionSavedReplyByID(ctx, id)
+	case "DiscussionWebhook":
+		return discussionWebhookByID(ctx, id)
+	case "DiscussionRule":
+		return discussionRuleByID(ctx, id)
+	case "DiscussionFeed":
+		return discussionFeedByID(ctx, id)
 	case "ProductLicense":
 		if f := ProductLicenseByID; f != nil {
 			return f(ctx, id)
@@ -2,6 +2,11 @@ package graphqlutil
 
 import "github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 
+// DefaultFirst is the number of items that a connection returns when its first
+// argument is not given. The estimated cost of a query counts such connections
+// as returning DefaultFirst nodes (see graphqlbackend.EstimateQueryCost).
+const DefaultFirst = 1000
+
 // ConnectionArgs is the common set of arguments to GraphQL fields that return connections (lists).
 type ConnectionArgs struct {
 	First *int32 // return the first n items
@@ -14,6 +19,17 @@ func (a ConnectionArgs) Set(o **db.LimitOffset) {
 	}
 }
 
+// SetWithDefault is like Set, except that the limit is DefaultFirst if First is
+// nil. Connections that can return many nodes should use it, so that queries
+// that omit first can't load all of them.
+func (a ConnectionArgs) SetWithDefault(o **db.LimitOffset) {
+	if a.First != nil {
+		*o = &db.LimitOffset{Limit: int(*a.First)}
+	} else {
+		*o = &db.LimitOffset{Limit: DefaultFirst}
+	}
+}
+
 // GetFirst is a convenience method returning the value of First, defaulting to
 // the type's zero value if nil.
 func (a ConnectionArgs) GetFirst() int32 {
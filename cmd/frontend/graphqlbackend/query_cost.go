@@ -0,0 +1,713 @@
+package graphqlbackend
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+)
+
+// QueryCost is the estimated cost of executing a GraphQL query.
+type QueryCost struct {
+	// FieldCount is the number of fields the query can resolve. The fields
+	// inside a field with a first (or last) argument of N are counted N
+	// times, because the field is a connection that can return N nodes. The
+	// fields inside a connection without a first (or last) argument are
+	// counted graphqlutil.DefaultFirst times.
+	FieldCount int
+
+	// MaxDepth is the nesting depth of the most deeply nested field. The
+	// top-level fields have a depth of 1.
+	MaxDepth int
+}
+
+// EstimateQueryCost estimates the cost of executing the operation of the query
+// with the given name (or the only operation, if operationName is empty). It
+// does not validate the query against the schema, so it is meant to be called
+// before the query is executed to cheaply reject expensive queries.
+//
+// The page sizes of connections are read from variables (and variable
+// defaults) when they are not literals. An error is returned if the query
+// cannot be parsed, in which case its cost is unknown and the caller should
+// reject it.
+func EstimateQueryCost(query, operationName string, variables map[string]interface{}) (*QueryCost, error) {
+	doc, err := parseQueryDocument(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var op *queryOperation
+	for _, o := range doc.operations {
+		if operationName == "" || o.name == operationName {
+			if op != nil {
+				return nil, fmt.Errorf("more than one operation in query (operationName must be specified)")
+			}
+			op = o
+		}
+	}
+	if op == nil {
+		return nil, fmt.Errorf("no operation named %q in query", operationName)
+	}
+
+	vars := make(map[string]interface{}, len(op.variableDefaults)+len(variables))
+	for name, value := range op.variableDefaults {
+		vars[name] = value
+	}
+	for name, value := range variables {
+		vars[name] = value
+	}
+
+	c := &queryCostEstimator{
+		fragments: doc.fragments,
+		vars:      vars,
+		costs:     map[string]selectionSetCost{},
+		visiting:  map[string]bool{},
+
+		connectionFragments: map[string]bool{},
+	}
+	cost, err := c.selectionSetCost(op.selections)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryCost{FieldCount: cost.fieldCount, MaxDepth: cost.depth}, nil
+}
+
+// selectionSetCost is the cost of a selection set that is resolved once.
+type selectionSetCost struct {
+	fieldCount int
+	depth      int
+}
+
+type queryCostEstimator struct {
+	fragments map[string][]*querySelection
+	vars      map[string]interface{}
+
+	costs               map[string]selectionSetCost // the costs of the fragments, by name
+	visiting            map[string]bool             // fragments being expanded (to detect cycles)
+	connectionFragments map[string]bool             // whether the fragments select nodes, by name
+}
+
+// selectionSetCost returns the cost of resolving the selection set once. The
+// cost of resolving it N times (e.g. for each node of a connection) is N times
+// its field count, at the same depth.
+func (c *queryCostEstimator) selectionSetCost(selections []*querySelection) (selectionSetCost, error) {
+	var total selectionSetCost
+	for _, sel := range selections {
+		var cost selectionSetCost
+		switch {
+		case sel.fragmentSpread != "":
+			var err error
+			if cost, err = c.fragmentCost(sel.fragmentSpread); err != nil {
+				return selectionSetCost{}, err
+			}
+
+		case sel.field == "":
+			// Inline fragment.
+			var err error
+			if cost, err = c.selectionSetCost(sel.selections); err != nil {
+				return selectionSetCost{}, err
+			}
+
+		default:
+			children, err := c.selectionSetCost(sel.selections)
+			if err != nil {
+				return selectionSetCost{}, err
+			}
+			cost = selectionSetCost{
+				fieldCount: saturatingAdd(1, saturatingMul(c.pageSize(sel), children.fieldCount)),
+				depth:      children.depth + 1,
+			}
+		}
+		total.fieldCount = saturatingAdd(total.fieldCount, cost.fieldCount)
+		if cost.depth > total.depth {
+			total.depth = cost.depth
+		}
+	}
+	return total, nil
+}
+
+// fragmentCost returns the cost of the named fragment. It is computed only
+// once per query, so that fragments that spread other fragments many times
+// can't make the estimation itself expensive.
+func (c *queryCostEstimator) fragmentCost(name string) (selectionSetCost, error) {
+	if cost, ok := c.costs[name]; ok {
+		return cost, nil
+	}
+	fragment, ok := c.fragments[name]
+	if !ok {
+		return selectionSetCost{}, fmt.Errorf("unknown fragment %q", name)
+	}
+	if c.visiting[name] {
+		return selectionSetCost{}, fmt.Errorf("fragment %q spreads itself", name)
+	}
+	c.visiting[name] = true
+	cost, err := c.selectionSetCost(fragment)
+	delete(c.visiting, name)
+	if err != nil {
+		return selectionSetCost{}, err
+	}
+	c.costs[name] = cost
+	return cost, nil
+}
+
+// pageSize returns the number of nodes that the field can return, based on its
+// first or last argument. A connection with neither returns up to
+// graphqlutil.DefaultFirst nodes, and other fields return 1.
+func (c *queryCostEstimator) pageSize(sel *querySelection) int {
+	size := 1
+	hasPageSize := false
+	for _, name := range []string{"first", "last"} {
+		value, ok := sel.arguments[name]
+		if !ok {
+			continue
+		}
+		hasPageSize = true
+		if v, ok := value.(queryVariable); ok {
+			value = c.vars[string(v)]
+		}
+		var n int
+		switch v := value.(type) {
+		case int:
+			n = v
+		case float64: // JSON numbers in variables
+			if v > math.MaxInt32 {
+				n = math.MaxInt32
+			} else {
+				n = int(v)
+			}
+		}
+		if n > size {
+			size = n
+		}
+	}
+	if !hasPageSize && c.isConnection(sel.selections) {
+		return graphqlutil.DefaultFirst
+	}
+	return size
+}
+
+// isConnection reports whether the field is a connection, i.e. selects the
+// nodes (or edges) of a list. The query is not validated against the schema, so
+// this is judged by the field's selections.
+func (c *queryCostEstimator) isConnection(selections []*querySelection) bool {
+	for _, sel := range selections {
+		switch {
+		case sel.fragmentSpread != "":
+			if isConnection, ok := c.connectionFragments[sel.fragmentSpread]; ok {
+				if isConnection {
+					return true
+				}
+				continue
+			}
+			// The fragment's cost is computed before this is called, so
+			// fragments that spread themselves have already been rejected.
+			isConnection := c.isConnection(c.fragments[sel.fragmentSpread])
+			c.connectionFragments[sel.fragmentSpread] = isConnection
+			if isConnection {
+				return true
+			}
+
+		case sel.field == "":
+			if c.isConnection(sel.selections) {
+				return true
+			}
+
+		case sel.field == "nodes" || sel.field == "edges":
+			return true
+		}
+	}
+	return false
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int) int {
+	if b != 0 && a > math.MaxInt32/b {
+		return math.MaxInt32
+	}
+	return a * b
+}
+
+// queryDocument is the subset of a parsed GraphQL query document that is
+// needed to estimate its cost.
+type queryDocument struct {
+	operations []*queryOperation
+	fragments  map[string][]*querySelection
+}
+
+type queryOperation struct {
+	name             string
+	variableDefaults map[string]interface{}
+	selections       []*querySelection
+}
+
+// querySelection is a field, a fragment spread (if fragmentSpread is set) or
+// an inline fragment (if neither field nor fragmentSpread is set).
+type querySelection struct {
+	field          string
+	arguments      map[string]interface{}
+	fragmentSpread string
+	selections     []*querySelection
+}
+
+// queryVariable is a reference to a variable in an argument value.
+type queryVariable string
+
+func parseQueryDocument(query string) (*queryDocument, error) {
+	p := &queryParser{lexer: queryLexer{src: query}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	doc := &queryDocument{fragments: map[string][]*querySelection{}}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.tok.is(tokPunct, "{"):
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &queryOperation{selections: selections})
+
+		case p.tok.is(tokName, "query"), p.tok.is(tokName, "mutation"), p.tok.is(tokName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+
+		case p.tok.is(tokName, "fragment"):
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if !p.tok.is(tokName, "on") {
+				return nil, p.unexpected()
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if _, err := p.expectName(); err != nil {
+				return nil, err
+			}
+			if err := p.skipDirectives(); err != nil {
+				return nil, err
+			}
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[name] = selections
+
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	return doc, nil
+}
+
+type queryParser struct {
+	lexer queryLexer
+	tok   queryToken
+}
+
+func (p *queryParser) next() (err error) {
+	p.tok, err = p.lexer.next()
+	return err
+}
+
+func (p *queryParser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return fmt.Errorf("unexpected end of query")
+	}
+	return fmt.Errorf("unexpected %q at offset %d", p.tok.text, p.tok.offset)
+}
+
+func (p *queryParser) expectName() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected()
+	}
+	name := p.tok.text
+	return name, p.next()
+}
+
+func (p *queryParser) expectPunct(punct string) error {
+	if !p.tok.is(tokPunct, punct) {
+		return p.unexpected()
+	}
+	return p.next()
+}
+
+func (p *queryParser) parseOperation() (*queryOperation, error) {
+	if err := p.next(); err != nil { // query, mutation or subscription
+		return nil, err
+	}
+	op := &queryOperation{variableDefaults: map[string]interface{}{}}
+	if p.tok.kind == tokName {
+		op.name = p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.tok.is(tokPunct, "(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.tok.is(tokPunct, ")") {
+			if err := p.expectPunct("$"); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, err
+			}
+			if err := p.skipType(); err != nil {
+				return nil, err
+			}
+			if p.tok.is(tokPunct, "=") {
+				if err := p.next(); err != nil {
+					return nil, err
+				}
+				value, err := p.parseValue()
+				if err != nil {
+					return nil, err
+				}
+				op.variableDefaults[name] = value
+			}
+			if err := p.skipDirectives(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *queryParser) skipType() error {
+	if p.tok.is(tokPunct, "[") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+	if p.tok.is(tokPunct, "!") {
+		return p.next()
+	}
+	return nil
+}
+
+func (p *queryParser) skipDirectives() error {
+	for p.tok.is(tokPunct, "@") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if _, err := p.expectName(); err != nil {
+			return err
+		}
+		if p.tok.is(tokPunct, "(") {
+			if _, err := p.parseArguments(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *queryParser) parseSelectionSet() ([]*querySelection, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var selections []*querySelection
+	for !p.tok.is(tokPunct, "}") {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	return selections, p.next()
+}
+
+func (p *queryParser) parseSelection() (*querySelection, error) {
+	if p.tok.is(tokPunct, "...") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokName && p.tok.text != "on" {
+			// Fragment spread.
+			sel := &querySelection{fragmentSpread: p.tok.text}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			return sel, p.skipDirectives()
+		}
+		// Inline fragment.
+		if p.tok.is(tokName, "on") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if _, err := p.expectName(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+		selections, err := p.parseSelectionSet()
+		if err != nil {
+			return nil, err
+		}
+		return &querySelection{selections: selections}, nil
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.is(tokPunct, ":") { // alias
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	sel := &querySelection{field: name}
+	if p.tok.is(tokPunct, "(") {
+		if sel.arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+	if p.tok.is(tokPunct, "{") {
+		if sel.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
+
+func (p *queryParser) parseArguments() (map[string]interface{}, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	args := map[string]interface{}{}
+	for !p.tok.is(tokPunct, ")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		if args[name], err = p.parseValue(); err != nil {
+			return nil, err
+		}
+	}
+	return args, p.next()
+}
+
+// parseValue parses a value. Only int values and variable references are
+// needed to estimate costs, so other values are returned as nil.
+func (p *queryParser) parseValue() (interface{}, error) {
+	switch {
+	case p.tok.is(tokPunct, "$"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		return queryVariable(name), err
+
+	case p.tok.kind == tokInt:
+		n, err := strconv.Atoi(p.tok.text)
+		if err != nil {
+			n = math.MaxInt32 // overflow
+		}
+		return n, p.next()
+
+	case p.tok.kind == tokFloat, p.tok.kind == tokString, p.tok.kind == tokName:
+		return nil, p.next()
+
+	case p.tok.is(tokPunct, "["):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.tok.is(tokPunct, "]") {
+			if _, err := p.parseValue(); err != nil {
+				return nil, err
+			}
+		}
+		return nil, p.next()
+
+	case p.tok.is(tokPunct, "{"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.tok.is(tokPunct, "}") {
+			if _, err := p.expectName(); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, err
+			}
+			if _, err := p.parseValue(); err != nil {
+				return nil, err
+			}
+		}
+		return nil, p.next()
+	}
+	return nil, p.unexpected()
+}
+
+type queryTokenKind int
+
+const (
+	tokEOF queryTokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type queryToken struct {
+	kind   queryTokenKind
+	text   string
+	offset int
+}
+
+func (t queryToken) is(kind queryTokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+// queryLexer splits a GraphQL query document into tokens, skipping
+// whitespace, commas and comments.
+type queryLexer struct {
+	src string
+	pos int
+}
+
+func (l *queryLexer) next() (queryToken, error) {
+	// Skip ignored tokens.
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			l.pos++
+		} else if c == '#' {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		} else if l.pos == 0 && len(l.src) >= 3 && l.src[:3] == "\ufeff" {
+			l.pos += 3
+		} else {
+			break
+		}
+	}
+	start := l.pos
+	if l.pos == len(l.src) {
+		return queryToken{kind: tokEOF, offset: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '.':
+		if len(l.src)-l.pos >= 3 && l.src[l.pos:l.pos+3] == "..." {
+			l.pos += 3
+			return queryToken{kind: tokPunct, text: "...", offset: start}, nil
+		}
+
+	case c == '!' || c == '$' || c == '&' || c == '(' || c == ')' || c == ':' || c == '=' || c == '@' || c == '[' || c == ']' || c == '{' || c == '|' || c == '}':
+		l.pos++
+		return queryToken{kind: tokPunct, text: string(c), offset: start}, nil
+
+	case c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+		for l.pos < len(l.src) && isQueryNameChar(l.src[l.pos]) {
+			l.pos++
+		}
+		return queryToken{kind: tokName, text: l.src[start:l.pos], offset: start}, nil
+
+	case c == '-' || ('0' <= c && c <= '9'):
+		kind := tokInt
+		l.pos++
+		for l.pos < len(l.src) {
+			c := l.src[l.pos]
+			if c == '.' || c == 'e' || c == 'E' || ((c == '+' || c == '-') && (l.src[l.pos-1] == 'e' || l.src[l.pos-1] == 'E')) {
+				kind = tokFloat
+			} else if !('0' <= c && c <= '9') {
+				break
+			}
+			l.pos++
+		}
+		return queryToken{kind: kind, text: l.src[start:l.pos], offset: start}, nil
+
+	case c == '"':
+		if len(l.src)-l.pos >= 3 && l.src[l.pos:l.pos+3] == `"""` {
+			// Block string.
+			l.pos += 3
+			for l.pos < len(l.src) {
+				if l.src[l.pos] == '\\' && len(l.src)-l.pos >= 4 && l.src[l.pos+1:l.pos+4] == `"""` {
+					l.pos += 4
+				} else if len(l.src)-l.pos >= 3 && l.src[l.pos:l.pos+3] == `"""` {
+					l.pos += 3
+					return queryToken{kind: tokString, text: l.src[start:l.pos], offset: start}, nil
+				} else {
+					l.pos++
+				}
+			}
+			return queryToken{}, fmt.Errorf("unterminated string at offset %d", start)
+		}
+		l.pos++
+		for l.pos < len(l.src) {
+			switch l.src[l.pos] {
+			case '\\':
+				l.pos += 2
+			case '"':
+				l.pos++
+				return queryToken{kind: tokString, text: l.src[start:l.pos], offset: start}, nil
+			case '\n', '\r':
+				return queryToken{}, fmt.Errorf("unterminated string at offset %d", start)
+			default:
+				l.pos++
+			}
+		}
+		return queryToken{}, fmt.Errorf("unterminated string at offset %d", start)
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return queryToken{}, fmt.Errorf("unexpected character %q at offset %d", r, start)
+}
+
+func isQueryNameChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,132 @@
+package graphqlbackend
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+)
+
+func TestEstimateQueryCost(t *testing.T) {
+	tests := map[string]struct {
+		query         string
+		operationName string
+		variables     map[string]interface{}
+		want          QueryCost
+	}{
+		"shorthand": {
+			query: `{ currentUser { username } }`,
+			want:  QueryCost{FieldCount: 2, MaxDepth: 2},
+		},
+		"connection": {
+			query: `
+				query {
+					discussionThreads(first: 10) {
+						nodes {
+							title
+							comments(first: 5) { nodes { contents } }
+						}
+						totalCount
+					}
+				}`,
+			// discussionThreads + 10*(nodes + title + comments + 5*(nodes + contents) + totalCount)
+			want: QueryCost{FieldCount: 1 + 10*(1+1+(1+5*2)+1), MaxDepth: 5},
+		},
+		"connection without first": {
+			query: `
+				query {
+					users { nodes { username } totalCount }
+					orgs { ...Orgs }
+				}
+				fragment Orgs on OrgConnection { ... on OrgConnection { nodes { name } } }`,
+			// Connections without a first argument return up to DefaultFirst nodes.
+			want: QueryCost{FieldCount: (1 + graphqlutil.DefaultFirst*(1+1+1)) + (1 + graphqlutil.DefaultFirst*(1+1)), MaxDepth: 3},
+		},
+		"variables and defaults": {
+			query: `
+				query Threads($first: Int!, $comments: Int = 3) {
+					discussionThreads(first: $first) {
+						nodes { comments(first: $comments) { totalCount } }
+					}
+				}`,
+			variables: map[string]interface{}{"first": float64(20)},
+			want:      QueryCost{FieldCount: 1 + 20*(2+3*1), MaxDepth: 4},
+		},
+		"fragments": {
+			query: `
+				query {
+					a: repository(name: "a") { ...Repo }
+					b: repository(name: "b") { ... on Repository { ...Repo } }
+				}
+				fragment Repo on Repository {
+					name
+					discussionLabels(first: 2) @include(if: true) { nodes { name } }
+				}`,
+			want: QueryCost{FieldCount: 2 * (1 + 1 + 1 + 2*2), MaxDepth: 4},
+		},
+		"operation name": {
+			query: `
+				query A { currentUser { username } }
+				query B { site { id } currentUser { username } }`,
+			operationName: "B",
+			want:          QueryCost{FieldCount: 4, MaxDepth: 2},
+		},
+		"strings and comments": {
+			query: `
+				# { ignored(first: 100) { x } }
+				mutation {
+					discussions {
+						addCommentToThread(threadID: "x", contents: "} { \" (first: 1000)", extra: """ { "" } """, list: [1, 2.5e3, {a: null}]) { id }
+					}
+				}`,
+			want: QueryCost{FieldCount: 3, MaxDepth: 3},
+		},
+		"saturates": {
+			query: `{ a(first: 100000) { b(first: 100000) { c(first: 100000) { d } } } }`,
+			want:  QueryCost{FieldCount: math.MaxInt32, MaxDepth: 4},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cost, err := EstimateQueryCost(test.query, test.operationName, test.variables)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *cost != test.want {
+				t.Errorf("got %+v, want %+v", *cost, test.want)
+			}
+		})
+	}
+
+	t.Run("many fragment spreads", func(t *testing.T) {
+		// Each fragment spreads the next one twice, so expanding the
+		// fragments naively would take 2^30 steps.
+		query := `{ ...F0 }`
+		for i := 0; i < 30; i++ {
+			query += " fragment F" + strconv.Itoa(i) + " on Query { a ...F" + strconv.Itoa(i+1) + " ...F" + strconv.Itoa(i+1) + " }"
+		}
+		query += " fragment F30 on Query { a }"
+		cost, err := EstimateQueryCost(query, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := math.MaxInt32; cost.FieldCount != want {
+			t.Errorf("got field count %d, want %d", cost.FieldCount, want)
+		}
+	})
+
+	for name, query := range map[string]string{
+		"syntax error":       `{ currentUser { username }`,
+		"unterminated":       `{ a(b: "c) }`,
+		"unknown fragment":   `{ ...F }`,
+		"fragment cycle":     `{ ...A } fragment A on Query { ...B } fragment B on Query { ...A }`,
+		"multiple anonymous": `{ a } { b }`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := EstimateQueryCost(query, "", nil); err == nil {
+				t.Error("got nil error, want error")
+			}
+		})
+	}
+}
@@ -879,7 +879,8 @@ input DiscussionThreadTargetRepoInput {
     path: String
 
     # The branch or other human-readable Git ref (e.g. "HEAD~2", but not exact
-    # Git revision), that the thread was referencing, if any.
+    # Git revision), that the thread was referencing, if any. It must be a
+    # valid Git ref name (see the git-check-ref-format documentation).
     branch: String
 
     # The exact Git object ID (OID / 40-character SHA-1 hash) which the thread
@@ -893,15 +894,34 @@ input DiscussionThreadTargetRepoInput {
 # Describes the creation of a new thread around some target (e.g. a file in a repo).
 input DiscussionThreadCreateInput {
     # An explicitly chosen title for the discussion thread. Otherwise, the title
-    # will be chosen based on the 'contents' (e.g. the first line).
+    # will be the template's title (if any) or chosen based on the 'contents'
+    # (e.g. the first line).
+    #
+    # The title must not be empty, be longer than 500 characters, or contain
+    # control characters. An invalid title (or contents) fails with an
+    # INVALID_INPUT error whose "field" extension names the input field.
     title: String
 
     # The contents of the thread's first comment (i.e. the threads comment).
-    contents: String!
+    # This may only be omitted when a template is given, in which case the
+    # template's contents are used.
+    #
+    # The contents must not be empty, be longer than 100,000 characters, or
+    # contain control characters other than newlines and tabs.
+    contents: String
 
     # The target repo of this discussion thread. This is nullable so that in
     # the future more target types may be added.
     targetRepo: DiscussionThreadTargetRepoInput
+
+    # The ID of a thread template to pre-populate the title and contents from.
+    # The template must belong to the thread's target repository.
+    template: ID
+    # A key chosen by the client (e.g. a random UUID) that identifies this
+    # request. If the viewer already created a thread with the same key in
+    # the last 24 hours, that thread is returned instead of creating a new
+    # one, so that clients can safely retry requests that timed out.
+    idempotencyKey: String
 }
 
 # Describes an update mutation to an existing thread.
@@ -910,16 +930,97 @@ input DiscussionThreadUpdateInput {
     threadID: ID!
 
     # When non-null, indicates that the thread's title should be updated to the specified value.
+    # The same rules as for DiscussionThreadCreateInput.title apply.
     title: String
 
-    # When non-null, indicates that the thread should be archived.
+    # When non-null, indicates that the thread's contents (i.e. its first
+    # comment) should be updated to the specified value. Only the thread's
+    # author and site admins can update the contents. The previous contents are
+    # kept in the comment's edit history, and mentions and references are
+    # updated. The same rules as for DiscussionThreadCreateInput.contents apply.
+    contents: String
+
+    # When non-null, indicates that the thread should be archived. Only site
+    # admins, the thread's author and triagers can perform this action.
+    archive: Boolean
+
+    # When non-null, indicates that the thread should be deleted. Only site
+    # admins and maintainers can perform this action. Deleted threads can be
+    # restored for 30 days with the restoreThread mutation.
+    delete: Boolean
+
+    # When non-null, the update fails with a CONFLICT error (and nothing is
+    # updated) if the thread's updatedAt is no longer this value, i.e. the
+    # thread was changed since the client loaded it.
+    expectedUpdatedAt: DateTime
+}
+
+# Describes an action of the applyThreadActions mutation. Exactly one of the
+# fields must be set.
+input DiscussionThreadActionInput {
+    # Adds the labels to the thread (see addLabelsToThread).
+    addLabels: [ID!]
+
+    # Removes the labels from the thread (see removeLabelsFromThread).
+    removeLabels: [ID!]
+
+    # Assigns the user to the thread (see addThreadAssignee).
+    addAssignee: ID
+
+    # Unassigns the user from the thread (see removeThreadAssignee).
+    removeAssignee: ID
+
+    # Adds a comment with these contents to the thread (see addCommentToThread).
+    comment: String
+
+    # Archives (true) or unarchives (false) the thread.
+    archive: Boolean
+}
+
+# Describes an update mutation to many existing threads.
+input DiscussionThreadsUpdateInput {
+    # The IDs of the threads to update.
+    threadIDs: [ID!]!
+
+    # When non-null, indicates whether the threads should be archived or
+    # unarchived. Only site admins, the threads' authors and triagers can
+    # perform this action.
     archive: Boolean
 
-    # When non-null, indicates that the thread should be deleted. Only admins
-    # can perform this action.
+    # When non-null, indicates that the threads should be deleted. Only site
+    # admins and maintainers can perform this action.
     delete: Boolean
 }
 
+# Describes which open threads the archiveThreads mutation archives.
+input DiscussionThreadsArchiveInput {
+    # When non-null, only the threads whose target is this repository are
+    # archived.
+    repository: ID
+
+    # When non-null, only the threads with a label named one of these are
+    # archived.
+    labels: [String!]
+
+    # When non-null, only the threads that have had no activity since this
+    # time are archived.
+    inactiveSince: DateTime
+}
+
+# The result of updating a single thread with the updateThreads mutation.
+type DiscussionThreadUpdateResult {
+    # The ID of the thread, as given in the input.
+    threadID: ID!
+
+    # The updated thread. Null if the thread was deleted or could not be
+    # updated.
+    thread: DiscussionThread
+
+    # A description of why the thread could not be updated, or null if it was
+    # updated successfully.
+    error: String
+}
+
 # Describes an update mutation to an existing comment in a thread.
 input DiscussionCommentUpdateInput {
     # The ID of the comment to update.
@@ -929,8 +1030,8 @@ input DiscussionCommentUpdateInput {
     # original author can perform this action.
     contents: String
 
-    # When non-null, indicates that the thread should be deleted. Only admins
-    # can perform this action.
+    # When non-null, indicates that the comment should be deleted. Only site
+    # admins and maintainers can perform this action.
     delete: Boolean
 
     # When non-null, reports the comment with the specified reason.
@@ -943,6 +1044,166 @@ input DiscussionCommentUpdateInput {
     #
     # An error will be returned if the comment's canClearReports field is false.
     clearReports: Boolean
+    # When non-null, the update fails with a CONFLICT error (and nothing is
+    # updated) if the comment's updatedAt is no longer this value, i.e. the
+    # comment was changed since the client loaded it.
+    expectedUpdatedAt: DateTime
+}
+
+# Describes the creation of a new label in a repository.
+input DiscussionLabelCreateInput {
+    # The ID of the repository in which to create the label.
+    repository: ID!
+
+    # The name of the label, which must be unique within the repository.
+    name: String!
+
+    # The color of the label as a hex color string (e.g. "#ff0000").
+    color: String!
+
+    # An optional description of the label.
+    description: String
+}
+
+# Describes the creation of a new thread template in a repository.
+# Describes the creation of a new discussion webhook.
+input DiscussionWebhookCreateInput {
+    # The ID of the repository whose threads' events are delivered to the
+    # webhook. If null, events on threads in all repositories are delivered.
+    repository: ID
+
+    # The absolute http or https URL that events are POSTed to.
+    url: String!
+
+    # The secret used to sign each delivery. The hex-encoded HMAC-SHA256 of
+    # the request body keyed with the secret is sent in the
+    # X-Sourcegraph-Signature header as "sha256=<signature>".
+    secret: String!
+}
+
+# Describes the creation of a new discussion rule in a repository.
+input DiscussionRuleCreateInput {
+    # The ID of the repository whose threads the rule applies to.
+    repository: ID!
+
+    # The name of the rule.
+    name: String!
+
+    # The thread filter query that threads must match for the rule to apply,
+    # in the syntax of Query#discussionThreads's query argument (e.g.
+    # "title:crash -label:triaged").
+    query: String!
+
+    # The labels that the rule adds to matching threads. They must belong to
+    # the rule's repository.
+    labels: [ID!]
+
+    # The users that the rule assigns to matching threads.
+    assignees: [ID!]
+}
+
+# Describes an update to a discussion rule. Fields that are null are left
+# unchanged.
+input DiscussionRuleUpdateInput {
+    # The ID of the rule to update.
+    rule: ID!
+
+    # The new name of the rule.
+    name: String
+
+    # The new thread filter query of the rule.
+    query: String
+
+    # The new labels that the rule adds to matching threads, replacing the
+    # previous labels.
+    labels: [ID!]
+
+    # The new users that the rule assigns to matching threads, replacing the
+    # previous assignees.
+    assignees: [ID!]
+}
+
+input DiscussionThreadTemplateCreateInput {
+    # The ID of the repository in which to create the template.
+    repository: ID!
+
+    # The name of the template, which must be unique within the repository.
+    name: String!
+
+    # An optional description of when to use the template.
+    description: String
+
+    # An optional title for threads created from the template.
+    title: String
+
+    # The contents of the first comment of threads created from the template.
+    contents: String!
+}
+
+# Describes the creation of a new milestone in a repository or organization.
+input DiscussionMilestoneCreateInput {
+    # The ID of the repository in which to create the milestone. Exactly one
+    # of repository and organization must be specified.
+    repository: ID
+
+    # The ID of the organization in which to create the milestone. Exactly one
+    # of repository and organization must be specified.
+    organization: ID
+
+    # The title of the milestone.
+    title: String!
+
+    # An optional description of the milestone.
+    description: String
+
+    # An optional date by which the milestone is due.
+    dueDate: DateTime
+}
+
+# Describes an update to an existing milestone.
+input DiscussionMilestoneUpdateInput {
+    # The ID of the milestone to update.
+    milestoneID: ID!
+
+    # When non-null, updates the title of the milestone.
+    title: String
+
+    # When non-null, updates the description of the milestone. An empty
+    # string removes the description.
+    description: String
+
+    # When non-null, updates the due date of the milestone.
+    dueDate: DateTime
+
+    # When true, removes the due date of the milestone.
+    clearDueDate: Boolean
+}
+
+# A diagnostic reported on a discussion thread.
+input DiscussionThreadDiagnosticInput {
+    # The path (relative to the repository root) of the file that the
+    # diagnostic applies to.
+    path: String!
+
+    # The line that the diagnostic's range starts on (zero-based, inclusive).
+    startLine: Int!
+
+    # The character of the start line that the diagnostic's range starts on
+    # (zero-based, inclusive).
+    startCharacter: Int!
+
+    # The line that the diagnostic's range ends on (zero-based, exclusive).
+    endLine: Int!
+
+    # The character of the end line that the diagnostic's range ends on
+    # (zero-based, exclusive).
+    endCharacter: Int!
+
+    # The diagnostic's message.
+    message: String!
+
+    # The diagnostic's severity.
+    severity: DiagnosticSeverity!
 }
 
 # Mutations for discussions.
@@ -955,11 +1216,363 @@ type DiscussionsMutation {
     # Returns null if the thread was deleted.
     updateThread(input: DiscussionThreadUpdateInput!): DiscussionThread
 
+    # Restores a thread (and the comments that were deleted along with it) that
+    # was deleted within the last 30 days. Only site admins can perform this
+    # action. Returns the restored thread.
+    restoreThread(threadID: ID!): DiscussionThread!
+
+    # Creates a new thread template in a repository. Only site admins can
+    # perform this action. Returns the new template.
+    createThreadTemplate(input: DiscussionThreadTemplateCreateInput!): DiscussionThreadTemplate!
+
+    # Deletes a thread template. Only site admins can perform this action.
+    deleteThreadTemplate(template: ID!): EmptyResponse
+
+    # Saves a reply (a named canned response) for the viewer, which they can
+    # insert when commenting. Returns the new saved reply.
+    createSavedReply(name: String!, contents: String!): DiscussionSavedReply!
+
+    # Updates a saved reply. Only the user who saved the reply (and site
+    # admins) can perform this action. Returns the updated saved reply.
+    updateSavedReply(savedReply: ID!, name: String, contents: String): DiscussionSavedReply!
+
+    # Deletes a saved reply. Only the user who saved the reply (and site
+    # admins) can perform this action.
+    deleteSavedReply(savedReply: ID!): EmptyResponse
+
+    # Creates a new webhook that is notified of every event on discussion
+    # threads in a repository (or, if no repository is given, in all
+    # repositories). Only site admins can perform this action. Returns the new
+    # webhook.
+    createWebhook(input: DiscussionWebhookCreateInput!): DiscussionWebhook!
+
+    # Deletes a webhook and its delivery log. Only site admins can perform
+    # this action.
+    deleteWebhook(webhook: ID!): EmptyResponse
+
+    # Creates a new rule that adds labels and assignees to the threads of a
+    # repository that match its query when they are created or their title is
+    # edited. Only site admins can perform this action. Returns the new rule.
+    createRule(input: DiscussionRuleCreateInput!): DiscussionRule!
+
+    # Updates a rule. Only site admins can perform this action. Returns the
+    # updated rule.
+    updateRule(input: DiscussionRuleUpdateInput!): DiscussionRule!
+
+    # Deletes a rule. Labels and assignees that the rule already added are not
+    # removed. Only site admins can perform this action.
+    deleteRule(rule: ID!): EmptyResponse
+
+    # Creates an Atom feed of discussion threads for the current user, such as
+    # the threads of a repository or the threads assigned to the user. Feed
+    # readers can fetch the feed's URL without signing in; the feed lists only
+    # the threads that the user can view.
+    #
+    # The URL is only returned here. Anyone with it can read the feed until it
+    # is revoked with revokeFeed.
+    createFeed(
+        kind: DiscussionFeedKind!
+        # The repository whose threads the feed lists. Required if (and only
+        # if) kind is REPOSITORY.
+        repository: ID
+    ): CreateDiscussionFeedResult!
+
+    # Revokes a feed, so that its URL no longer works. Only site admins and the
+    # feed's user can perform this action.
+    revokeFeed(feed: ID!): EmptyResponse
+
+    # Creates a new milestone in a repository or organization. Only site admins
+    # can create repository milestones, and only organization members can
+    # create organization milestones. Returns the new milestone.
+    createMilestone(input: DiscussionMilestoneCreateInput!): DiscussionMilestone!
+
+    # Updates an existing milestone. The same permissions as for
+    # createMilestone apply. Returns the updated milestone.
+    updateMilestone(input: DiscussionMilestoneUpdateInput!): DiscussionMilestone!
+
+    # Closes a milestone. The same permissions as for createMilestone apply.
+    # Returns the updated milestone.
+    closeMilestone(milestoneID: ID!): DiscussionMilestone!
+
+    # Reopens a closed milestone. The same permissions as for createMilestone
+    # apply. Returns the updated milestone.
+    reopenMilestone(milestoneID: ID!): DiscussionMilestone!
+
+    # Sets the milestone of a thread, or removes it if milestone is null. A
+    # repository milestone can only be used on threads in that repository.
+    # Only site admins, the thread author and triagers can perform this
+    # action, and only organization members can use an organization milestone.
+    # Returns the updated thread.
+    setThreadMilestone(threadID: ID!, milestone: ID): DiscussionThread!
+
+    # Gives a user a role in the discussions of a repository or organization
+    # (exactly one of which must be specified), replacing the role that the
+    # user previously had there. Only a role in a thread's repository grants
+    # permissions on the thread; roles in an organization do not apply to the
+    # threads in its milestones. Only site admins can give roles in
+    # repositories, and only organization members can give roles in their
+    # organization (to users other than themselves, unless they are site
+    # admins).
+    setDiscussionRole(user: ID!, repository: ID, organization: ID, role: DiscussionRole!): EmptyResponse
+
+    # Removes a user's role in the discussions of a repository or organization
+    # (exactly one of which must be specified). Removing a role that the user
+    # does not have is a no-op. The same permissions as for setDiscussionRole
+    # apply.
+    removeDiscussionRole(user: ID!, repository: ID, organization: ID): EmptyResponse
+
+    # Closes a thread, recording who closed it on its timeline. Closed threads
+    # can still be changed and commented on (unlike archived threads). Only
+    # site admins, the thread's author and triagers can perform this action.
+    # Fails with an INVALID_STATE error if the thread is already closed.
+    # Returns the updated thread.
+    closeThread(threadID: ID!): DiscussionThread!
+
+    # Reopens a closed thread, recording who reopened it on its timeline. The
+    # same permissions as for closeThread are required. Fails with an
+    # INVALID_STATE error if the thread is not closed. Returns the updated
+    # thread.
+    reopenThread(threadID: ID!): DiscussionThread!
+
+    # Locks a thread so that only site admins and contributors can add new
+    # comments to it. Only site admins and maintainers can perform this action.
+    # Returns the updated thread.
+    lockThread(threadID: ID!): DiscussionThread!
+
+    # Unlocks a previously locked thread. Only site admins and maintainers can
+    # perform this action. Returns the updated thread.
+    unlockThread(threadID: ID!): DiscussionThread!
+
+    # Pins a thread to the top of its repository's thread list. At most 3
+    # threads can be pinned in a repository. Only site admins can perform this
+    # action. Returns the updated thread.
+    pinThread(threadID: ID!): DiscussionThread!
+
+    # Unpins a previously pinned thread. Only site admins can perform this
+    # action. Returns the updated thread.
+    unpinThread(threadID: ID!): DiscussionThread!
+
+    # Reports a comment to the site admins (for example, as spam or abuse),
+    # who can review it in Query#discussionModerationQueue. An error is
+    # returned if the comment's canReport field is false. Returns the updated
+    # comment.
+    reportComment(commentID: ID!, reason: String!): DiscussionComment!
+
+    # Reports a thread to the site admins (for example, as spam or abuse), who
+    # can review it in Query#discussionModerationQueue. An error is returned if
+    # the thread's canReport field is false. Returns the updated thread.
+    reportThread(threadID: ID!, reason: String!): DiscussionThread!
+
+    # Clears the reports on a thread (for example, after they have been
+    # reviewed). Only site admins can perform this action. Returns the updated
+    # thread.
+    clearThreadReports(threadID: ID!): DiscussionThread!
+
+    # Hides a comment. The contents of a hidden comment are kept, but only site
+    # admins can read them. Only site admins can perform this action. Returns
+    # the updated comment.
+    hideComment(commentID: ID!): DiscussionComment!
+
+    # Unhides a previously hidden comment. Only site admins can perform this
+    # action. Returns the updated comment.
+    unhideComment(commentID: ID!): DiscussionComment!
+
+    # Moves a thread to another repository. The thread's comments and timeline
+    # are preserved, and its URLs and references to it continue to resolve.
+    # The thread's branch and revision are removed, as are its labels and
+    # milestone from its previous repository (except that labels are replaced
+    # by the labels of the same name in the target repository). Only site
+    # admins and the thread author can perform this action. Returns the
+    # updated thread.
+    transferThread(thread: ID!, targetRepository: ID!): DiscussionThread!
+
+    # Marks a thread as a duplicate of the original thread and archives it.
+    # The duplicate's subscribers are subscribed to the original (unless they
+    # have unsubscribed from it), so that they are notified of updates on it.
+    # Only site admins, the thread author and triagers can perform this
+    # action. Returns the updated (duplicate) thread.
+    markThreadAsDuplicate(thread: ID!, original: ID!): DiscussionThread!
+
+    # Records that a thread is blocked by another thread (that the other
+    # thread must be resolved first). An error is returned if the other thread
+    # is already (transitively) blocked by the thread. Only site admins, the
+    # thread author and triagers can perform this action. Returns the updated
+    # thread.
+    addThreadDependency(thread: ID!, blockedBy: ID!): DiscussionThread!
+
+    # Removes a thread's dependency on another thread. Only site admins, the
+    # thread author and triagers can perform this action. Returns the updated
+    # thread.
+    removeThreadDependency(thread: ID!, blockedBy: ID!): DiscussionThread!
+
+    # Replaces the diagnostics (such as compiler errors or lint warnings) that
+    # a source reported on a thread. Each tool or extension should report under
+    # its own source name, so that it does not affect the diagnostics of other
+    # sources. An empty list removes the source's diagnostics. Only site admins,
+    # the thread author and triagers can perform this action. Returns the
+    # updated thread.
+    setThreadDiagnostics(
+        thread: ID!
+        # The name of the tool or extension that reported the diagnostics (for
+        # example, "eslint").
+        source: String!
+        diagnostics: [DiscussionThreadDiagnosticInput!]!
+    ): DiscussionThread!
+
+    # Exports all threads in a repository or organization, with their comments
+    # and events, for compliance and migration. Exactly one of repository or
+    # organization must be specified. A repository's threads can be exported by
+    # users who can access the repository, and an organization's threads by its
+    # members and site admins. Returns the URL of the HTTP API endpoint that
+    # streams the export; it must be requested with the same authentication.
+    exportThreads(
+        repository: ID
+        organization: ID
+        format: DiscussionThreadsExportFormat!
+    ): String!
+
+    # Adds the viewer's reaction to a discussion thread or comment. Adding a
+    # reaction that the viewer already added has no effect. Returns the updated
+    # reaction groups of the thread or comment.
+    addReaction(subject: ID!, content: DiscussionReactionContent!): [DiscussionReactionGroup!]!
+
+    # Removes the viewer's reaction from a discussion thread or comment.
+    # Returns the updated reaction groups of the thread or comment.
+    removeReaction(subject: ID!, content: DiscussionReactionContent!): [DiscussionReactionGroup!]!
+
+    # Updates many existing threads at once. The changes to all of the threads
+    # that can be updated are made in a single transaction. Threads that cannot
+    # be updated (for example, because they do not exist) are reported in the
+    # results and do not prevent the other threads from being updated.
+    #
+    # Returns one result per thread ID in the input, in the same order.
+    updateThreads(input: DiscussionThreadsUpdateInput!): [DiscussionThreadUpdateResult!]!
+
+    # Archives the open threads that match all of the given criteria, such as
+    # every thread labeled "wontfix" or every thread that has been inactive for
+    # a year. At least one of labels or inactiveSince must be specified. The
+    # current user must be able to archive each thread (see
+    # DiscussionThreadsUpdateInput#archive).
+    #
+    # At most 1000 threads are archived at once; run the mutation again to
+    # archive the rest. Returns one result per matching thread.
+    archiveThreads(input: DiscussionThreadsArchiveInput!): [DiscussionThreadUpdateResult!]!
+
     # Adds a new comment to a thread. Returns the updated thread.
-    addCommentToThread(threadID: ID!, contents: String!): DiscussionThread!
+    addCommentToThread(
+        threadID: ID!
+        contents: String!
+        # A suggested replacement for the lines that the thread selects. Only
+        # threads about a selection in a file on a branch accept suggestions.
+        suggestion: String
+    ): DiscussionThread!
 
     # Updates an existing comment. Returns the updated thread.
     updateComment(input: DiscussionCommentUpdateInput!): DiscussionThread!
+
+    # Adds a reply to an existing comment, in the same thread as the comment.
+    # Returns the new reply.
+    replyToComment(commentID: ID!, contents: String!): DiscussionComment!
+
+    # Commits the comment's suggested change to the thread's branch and pushes
+    # it to the code host. The push fails if the branch has changed since the
+    # suggestion's lines were last seen. Only site admins can perform this
+    # action. Returns the updated comment.
+    applyCommentSuggestion(commentID: ID!): DiscussionComment!
+
+    # Attaches a file to a comment. Only the comment's author may attach
+    # files to it, within the size and content type limits in the
+    # discussions.attachments site configuration.
+    #
+    # The attachment is not shown on the comment until its contents have been
+    # uploaded to the returned upload URL.
+    createCommentAttachment(
+        commentID: ID!
+        # The name of the file.
+        filename: String!
+        # The MIME type of the file, such as "image/png".
+        contentType: String!
+        # The size of the file, in bytes. The uploaded contents must be exactly
+        # this size.
+        size: Int!
+    ): DiscussionCommentAttachmentUpload!
+
+    # Marks the discussion formed by a top-level comment and its replies as
+    # resolved. Returns the updated comment.
+    resolveCommentThread(commentID: ID!): DiscussionComment!
+
+    # Marks the discussion formed by a top-level comment and its replies as
+    # unresolved. Returns the updated comment.
+    unresolveCommentThread(commentID: ID!): DiscussionComment!
+
+    # Saves a named thread filter query in the viewer's
+    # discussions.savedThreadFilters user settings. Returns the new filter.
+    createSavedThreadFilter(
+        # The name of the filter. It must not be the name of another of the
+        # viewer's saved filters.
+        name: String!
+        # The thread filter query, in the syntax of Query#discussionThreads's
+        # query argument.
+        query: String!
+        # Whether the filter becomes the viewer's default view in the threads
+        # UI, replacing any previous default.
+        isDefault: Boolean = false
+    ): DiscussionSavedThreadFilter!
+
+    # Deletes the viewer's saved thread filter with the given name.
+    deleteSavedThreadFilter(name: String!): EmptyResponse
+
+    # Creates a new label in a repository. Only site admins can perform this
+    # action. Returns the new label.
+    createLabel(input: DiscussionLabelCreateInput!): DiscussionLabel!
+
+    # Adds labels to a thread. The labels must belong to the thread's target
+    # repository. Labels already on the thread are ignored. Only site admins,
+    # the thread author and triagers can perform this action. Returns the
+    # updated thread.
+    addLabelsToThread(threadID: ID!, labels: [ID!]!): DiscussionThread!
+
+    # Removes labels from a thread. Labels not on the thread are ignored. Only
+    # site admins, the thread author and triagers can perform this action.
+    # Returns the updated thread.
+    removeLabelsFromThread(threadID: ID!, labels: [ID!]!): DiscussionThread!
+
+    # Assigns a user to a thread. Assigning a user who is already assigned is a
+    # no-op. Only site admins, the thread author and triagers can perform this
+    # action. Returns the updated thread.
+    addThreadAssignee(threadID: ID!, assignee: ID!): DiscussionThread!
+
+    # Unassigns a user from a thread. Unassigning a user who is not assigned is
+    # a no-op. Only site admins, the thread author and triagers can perform
+    # this action. Returns the updated thread.
+    removeThreadAssignee(threadID: ID!, assignee: ID!): DiscussionThread!
+
+    # Applies a list of actions to a thread, in order, in a single transaction:
+    # either all of them are applied or (on error) none of them are. This lets
+    # clients such as keyboard-driven triage UIs label, assign, comment on and
+    # archive a thread in one round trip. Each action requires the same
+    # permissions as the equivalent mutation, and a comment cannot follow an
+    # action that archives the thread. At most 100 actions can be applied at
+    # once. Returns the updated thread.
+    applyThreadActions(threadID: ID!, actions: [DiscussionThreadActionInput!]!): DiscussionThread!
+
+    # Subscribes the viewer to notifications about new activity on a thread.
+    # Returns the updated thread.
+    subscribeToThread(threadID: ID!): DiscussionThread!
+
+    # Unsubscribes the viewer from notifications about new activity on a
+    # thread. The viewer will not be automatically subscribed again when they
+    # comment, but will still be notified when they are mentioned. Returns the
+    # updated thread.
+    unsubscribeFromThread(threadID: ID!): DiscussionThread!
+
+    # Subscribes the viewer to a thread, and sets which kinds of activity on
+    # the thread the viewer is notified of. Returns the updated thread.
+    setThreadNotificationLevel(threadID: ID!, level: DiscussionThreadNotificationLevel!): DiscussionThread!
+
+    # Marks a thread as read by the viewer, so that its current comments are no
+    # longer unread. Returns the updated thread.
+    markThreadAsRead(threadID: ID!): DiscussionThread!
 }
 
 # Describes options for rendering Markdown.
@@ -1204,7 +1817,21 @@ type Query {
     discussionThreads(
         # Returns the first n threads from the list.
         first: Int
+        # When present, returns only the threads that come after this cursor
+        # (the DiscussionThreadConnection#pageInfo.endCursor value of the
+        # previous page).
+        after: String
+        # The order to return threads in. Defaults to the most recently
+        # updated threads first.
+        orderBy: DiscussionThreadOrder
         # Return discussion threads matching the query.
+        #
+        # Free text in the query is matched using full-text search against the
+        # thread titles and bodies (the first comment in each thread). The
+        # query may also contain qualifiers such as "author:alice",
+        # "assignee:bob", "repo:github.com/gorilla/mux", "label:bug",
+        # "is:open", "is:closed", "is:archived", and "is:stale". Prefix a
+        # qualifier with "-" to negate it.
         query: String
         # When present, lists only the thread with this ID.
         #
@@ -1228,11 +1855,45 @@ type Query {
         #
         # If the path ends with "/**", any path below that is matched.
         targetRepositoryPath: String
+        # When present, lists only the threads in this state. Otherwise,
+        # archived threads are excluded unless the query contains an "is:"
+        # qualifier.
+        state: DiscussionThreadState
+        # When present, lists only the threads with a label named one of
+        # these.
+        labels: [String!]
+        # When present, lists only the threads that are blocked by the thread
+        # with this ID.
+        blockedBy: ID
     ): DiscussionThreadConnection!
     # Looks up a discussion thread by its DiscussionThread#idWithoutKind value.
     #
     # To get a discussion thread by its globally unique GraphQL ID, use Query#node.
     discussionThread(idWithoutKind: String!): DiscussionThread
+    # Lists the audit log of every mutation of discussion threads and comments,
+    # newest first. Only site admins can access the audit log.
+    auditLog(
+        # Returns the first n entries from the list.
+        first: Int
+        # When present, lists only the mutations performed by this user.
+        actor: ID
+        # When present, lists only the mutations of this discussion thread or
+        # comment.
+        object: ID
+        # When present, lists only the mutations performed after this time.
+        createdAfter: DateTime
+        # When present, lists only the mutations performed before this time.
+        createdBefore: DateTime
+    ): DiscussionAuditLogEntryConnection!
+    # The reported and hidden discussion threads and comments that need the
+    # attention of moderators. Only site admins can access the moderation
+    # queue.
+    discussionModerationQueue: DiscussionModerationQueue!
+    # Lists the viewer's saved replies, ordered by name.
+    savedReplies(
+        # Returns the first n saved replies from the list.
+        first: Int
+    ): DiscussionSavedReplyConnection!
     # Lists discussion comments.
     discussionComments(
         # Returns the first n comments from the list.
@@ -1240,6 +1901,13 @@ type Query {
         # When present, lists only the comments created by this author.
         authorUserID: ID
     ): DiscussionCommentConnection!
+    # Lists discussion webhooks. Only site admins can perform this action.
+    discussionWebhooks(
+        # Returns the first n webhooks from the list.
+        first: Int
+        # When present, lists only the webhooks of this repository.
+        repository: ID
+    ): DiscussionWebhookConnection!
     # Renders Markdown to HTML. The returned HTML is already sanitized and
     # escaped and thus is always safe to render.
     renderMarkdown(markdown: String!, options: MarkdownOptions): String!
@@ -1889,6 +2557,68 @@ type Repository implements Node & GenericSearchResultInterface {
         # 'LSIFDumpConnection.pageInfo.endCursor' that is returned.
         after: String
     ): LSIFDumpConnection!
+
+    # The labels that can be added to discussion threads in this repository.
+    discussionLabels(
+        # Returns the first n labels from the list.
+        first: Int
+    ): DiscussionLabelConnection!
+
+    # The rules that apply to discussion threads in this repository, in the
+    # order in which they are applied.
+    discussionRules: [DiscussionRule!]!
+
+    # Statistics about the discussion threads in this repository, for
+    # dashboards. They may be up to 10 minutes out of date.
+    discussionThreadStatistics: DiscussionThreadStatistics!
+
+    # The activity (creations, updates, comments, and deletions) on discussion
+    # threads in this repository after the given time or item, oldest first.
+    # To follow the activity, pass the id of the last returned item as after in
+    # the next request. Exactly one of since and after must be given.
+    #
+    # When waitSeconds is positive and there is no activity yet, the request
+    # waits up to that many seconds (at most 30) for activity before returning
+    # an empty list. This lets clients long-poll for changes instead of
+    # repeatedly fetching the full thread list.
+    discussionThreadUpdates(
+        # Only activity after this time is returned. Use it for the first
+        # request, and after for the following ones.
+        since: DateTime
+        # Only activity after the item with this ID is returned.
+        after: ID
+        # Returns at most the first n items (and never more than 100). It must
+        # be positive.
+        first: Int = 100
+        # How long to wait for activity if there is none yet.
+        waitSeconds: Int = 0
+    ): [DiscussionThreadTimelineItem!]!
+
+    # The discussion thread with the given number in this repository (see
+    # DiscussionThread.number), or null if there is none. If the thread had
+    # the number before it was transferred to another repository, the thread
+    # is returned (with its new repository and number).
+    discussionThread(number: Int!): DiscussionThread
+
+    # The templates that discussion threads in this repository can be created
+    # from.
+    discussionThreadTemplates(
+        # Returns the first n templates from the list.
+        first: Int
+    ): DiscussionThreadTemplateConnection!
+
+    # The milestones that discussion threads in this repository can be
+    # grouped into, ordered by due date.
+    discussionMilestones(
+        # Returns the first n milestones from the list.
+        first: Int
+        # Whether to include closed milestones.
+        includeClosed: Boolean = false
+    ): DiscussionMilestoneConnection!
+
+    # The users who have a role in the discussions of this repository, in the
+    # order in which they were given the role.
+    discussionRoles: [DiscussionRoleAssignment!]!
 }
 
 # A URL to a resource on an external service, such as the URL to a repository on its external (origin) code host.
@@ -2938,6 +3668,10 @@ type User implements Node & SettingsSubject & Namespace {
     #
     # Only the user and site admins can access this field.
     emails: [UserEmail!]!
+    # The user's discussion feeds that have not been revoked, oldest first.
+    #
+    # Only the user and site admins can access this field.
+    discussionFeeds: [DiscussionFeed!]!
     # The user's access tokens (which grant to the holder the privileges of the user). This consists
     # of all access tokens whose subject is this user.
     #
@@ -2968,6 +3702,11 @@ type User implements Node & SettingsSubject & Namespace {
     #
     # Only the user and site admins can access this field.
     surveyResponses: [SurveyResponse!]!
+    # The user's saved discussion thread filters, from their
+    # discussions.savedThreadFilters settings.
+    #
+    # Only the user and site admins can access this field.
+    savedThreadFilters: [DiscussionSavedThreadFilter!]!
     # The URL to view this user's customer information (for Sourcegraph.com site admins).
     #
     # Only Sourcegraph.com site admins may query this field.
@@ -2981,6 +3720,32 @@ type User implements Node & SettingsSubject & Namespace {
 
     # The name of this user namespace's component. For users, this is the username.
     namespaceName: String!
+    # The discussion threads across all repositories that the user is
+    # involved in, most recently updated first.
+    discussionThreads(
+        # Returns the first n threads from the list.
+        first: Int
+        # When present, returns only the threads that come after this cursor
+        # (the DiscussionThreadConnection#pageInfo.endCursor value of the
+        # previous page).
+        after: String
+        # The order to return threads in. Defaults to the most recently
+        # updated threads first.
+        orderBy: DiscussionThreadOrder
+        # When present, lists only the threads that the user is involved in
+        # this way. Otherwise, lists the threads that the user authored, is
+        # assigned to, or is mentioned in.
+        relation: DiscussionThreadUserRelation
+        # When present, lists only the threads in this state. Otherwise,
+        # archived threads are excluded unless the query contains an "is:"
+        # qualifier.
+        state: DiscussionThreadState
+        # When present, lists only the threads whose target is this repository.
+        repository: ID
+        # When present, lists only the threads with a label named one of
+        # these.
+        labels: [String!]
+    ): DiscussionThreadConnection!
 }
 
 # An access token that grants to the holder the privileges of the user who created it.
@@ -3173,6 +3938,41 @@ type Org implements Node & SettingsSubject & Namespace {
 
     # The name of this user namespace's component. For organizations, this is the organization's name.
     namespaceName: String!
+    # The milestones that discussion threads can be grouped into across this
+    # organization's repositories, ordered by due date.
+    discussionMilestones(
+        # Returns the first n milestones from the list.
+        first: Int
+        # Whether to include closed milestones.
+        includeClosed: Boolean = false
+    ): DiscussionMilestoneConnection!
+    # The users who have a role in the discussions of this organization (which
+    # applies to the threads in its milestones), in the order in which they
+    # were given the role.
+    discussionRoles: [DiscussionRoleAssignment!]!
+    # The discussion threads across all repositories that @mention this
+    # organization, or that a member of it authored or is assigned to, most
+    # recently updated first.
+    discussionThreads(
+        # Returns the first n threads from the list.
+        first: Int
+        # When present, returns only the threads that come after this cursor
+        # (the DiscussionThreadConnection#pageInfo.endCursor value of the
+        # previous page).
+        after: String
+        # The order to return threads in. Defaults to the most recently
+        # updated threads first.
+        orderBy: DiscussionThreadOrder
+        # When present, lists only the threads in this state. Otherwise,
+        # archived threads are excluded unless the query contains an "is:"
+        # qualifier.
+        state: DiscussionThreadState
+        # When present, lists only the threads whose target is this repository.
+        repository: ID
+        # When present, lists only the threads with a label named one of
+        # these.
+        labels: [String!]
+    ): DiscussionThreadConnection!
 }
 
 # The result of Mutation.inviteUserToOrganization.
@@ -3340,6 +4140,12 @@ type DiscussionThread implements Node {
     # The target of this discussion thread.
     target: DiscussionThreadTarget!
 
+    # The thread's number in its target repository (like an issue number),
+    # which is unique within the repository. Threads get a new number when
+    # transferred to another repository. Null if the thread's target is not a
+    # repository.
+    number: Int
+
     # The URL at which this thread can be viewed inline (i.e. in the file blob view).
     #
     # This will be null if the thread target is not DiscussionThreadTargetRepo
@@ -3352,14 +4158,235 @@ type DiscussionThread implements Node {
     # The date when the discussion thread was last updated.
     updatedAt: DateTime!
 
+    # The date when the discussion thread was closed (or null if it is not
+    # closed).
+    closedAt: DateTime
+
     # The date when the discussion thread was archived (or null if it has not).
     archivedAt: DateTime
 
+    # The date when the discussion thread was marked as stale, or null if it is
+    # not stale. A stale thread is archived if there is no new activity on it
+    # within the grace period configured in the site configuration.
+    staleAt: DateTime
+
+    # The date when the thread's target repository was deleted, or null if it
+    # was not. The thread no longer has a target, and it is visible only to
+    # site admins. It is archived or deleted as configured by the
+    # discussions.deletedRepositoryThreads site configuration.
+    repositoryDeletedAt: DateTime
+
+    # The thread that this thread was marked as a duplicate of, if any.
+    duplicateOf: DiscussionThread
+
+    # The threads that have been marked as duplicates of this thread.
+    duplicates(
+        # Returns the first n threads from the list.
+        first: Int
+    ): DiscussionThreadConnection!
+
+    # The threads that block this thread (that must be resolved first).
+    dependencies(
+        # Returns the first n threads from the list.
+        first: Int
+    ): DiscussionThreadConnection!
+
+    # The threads that are blocked by this thread.
+    dependents(
+        # Returns the first n threads from the list.
+        first: Int
+    ): DiscussionThreadConnection!
+
+    # Whether the discussion thread is locked. Only site admins can add
+    # comments to a locked thread.
+    isLocked: Boolean!
+
+    # Whether the discussion thread is pinned to the top of its repository's
+    # thread list.
+    isPinned: Boolean!
+
+    # Reports filed by users about this thread. Only admins will receive a non
+    # empty list of reports.
+    #
+    # When discussions.abuseProtection in the site config is set to false, this
+    # will always be an empty list.
+    reports: [String!]!
+
+    # Whether or not the thread can be reported.
+    #
+    # This is always false when discussions.abuseProtection in the site config is set to false.
+    canReport: Boolean!
+
+    # Whether the viewer can update the thread's title and contents. Only site
+    # admins and the thread's author can update them, and only while the thread
+    # is not archived.
+    viewerCanUpdate: Boolean!
+
+    # Whether the viewer can triage the thread, i.e. label, assign, or set the
+    # milestone of the thread, or mark it as a duplicate or dependency of
+    # another thread. Only site admins, the thread's author and triagers can
+    # triage it, and only while the thread is not archived.
+    viewerCanTriage: Boolean!
+
+    # Whether the viewer can archive and unarchive the thread. The same users
+    # who can triage the thread can archive it.
+    viewerCanArchive: Boolean!
+
+    # Whether the viewer can lock and unlock the thread. Only site admins and
+    # maintainers can lock threads.
+    viewerCanLock: Boolean!
+
+    # Whether the viewer can delete the thread. Only site admins and
+    # maintainers can delete threads.
+    viewerCanDelete: Boolean!
+
+    # Whether the viewer can add comments to the thread. Signed-in users with a
+    # verified email address can comment on threads that are not locked. Site
+    # admins and contributors can also comment on locked threads.
+    viewerCanComment: Boolean!
+
+    # The milestone that the discussion thread belongs to, if any.
+    milestone: DiscussionMilestone
+
     # The comments in the discussion thread.
     comments(
         # Returns the first n comments from the list.
         first: Int
+        # Only return comments that are not replies to another comment. Replies
+        # can be fetched with DiscussionComment.replies.
+        topLevelOnly: Boolean = false
     ): DiscussionCommentConnection!
+
+    # The number of comments in the discussion thread. It is equal to
+    # comments.totalCount, but cheaper to get.
+    commentCount: Int!
+
+    # The number of reactions to all comments in the discussion thread.
+    reactionCount: Int!
+
+    # The number of top-level comments in the discussion thread whose
+    # discussions (the comment and its replies) have been resolved.
+    resolvedThreadCount: Int!
+
+    # The labels that have been added to the discussion thread.
+    labels(
+        # Returns the first n labels from the list.
+        first: Int
+    ): DiscussionLabelConnection!
+
+    # The users assigned to the discussion thread, in the order in which they
+    # were assigned.
+    assignees: UserConnection!
+
+    # The users who participate in the discussion thread: its author, the
+    # authors of its comments, and its assignees. Each user is listed once, in
+    # the order in which they began participating.
+    participants(
+        # Returns the first n participants from the list.
+        first: Int
+    ): UserConnection!
+
+    # Whether the viewer is subscribed to notifications about new activity on
+    # the discussion thread. Users are automatically subscribed to threads they
+    # create, comment on, or are mentioned in.
+    viewerIsSubscribed: Boolean!
+
+    # The kinds of activity on the discussion thread that the viewer is
+    # notified of, or null if the viewer is not subscribed to the thread.
+    viewerNotificationLevel: DiscussionThreadNotificationLevel
+
+    # Whether the discussion thread has comments that the viewer has not read
+    # (see unreadCommentCount).
+    viewerHasUnread: Boolean!
+
+    # The number of comments on the discussion thread that were added since the
+    # viewer last marked it as read (or all comments, if the viewer has never
+    # read it). The viewer's own comments are never unread. Always 0 if there
+    # is no viewer.
+    unreadCommentCount: Int!
+
+    # The activity on the discussion thread (such as comments, title edits,
+    # and label changes), oldest first.
+    timelineItems(
+        # Returns the first n timeline items from the list.
+        first: Int
+    ): DiscussionThreadTimelineItemConnection!
+
+    # For threads about a branch, the comparison of the repository's default
+    # branch (the base) with the thread's branch (the head). Null for other
+    # threads.
+    repositoryComparison: RepositoryComparison
+
+    # For threads about a branch, the commits on the thread's branch that are
+    # not on the repository's default branch. Null for other threads.
+    commits(
+        # Return the first n commits from the list.
+        first: Int
+    ): GitCommitConnection
+
+    # For threads about a branch, the diffs of the files changed on the
+    # thread's branch relative to the repository's default branch. Null for
+    # other threads.
+    changedFiles(
+        # Return the first n file diffs from the list.
+        first: Int
+    ): FileDiffConnection
+
+    # The threads referenced (like #123, or by URL) in the comments of the
+    # discussion thread.
+    references(
+        # Returns the first n threads from the list.
+        first: Int
+    ): DiscussionThreadConnection!
+
+    # The threads whose comments reference the discussion thread.
+    referencedBy(
+        # Returns the first n threads from the list.
+        first: Int
+    ): DiscussionThreadConnection!
+
+    # The diagnostics (such as compiler errors or lint warnings) that tools and
+    # extensions reported on the discussion thread, ordered by path and
+    # position.
+    diagnostics(
+        # Returns the first n diagnostics from the list.
+        first: Int
+        # Only return diagnostics with this severity.
+        severity: DiagnosticSeverity
+    ): DiscussionThreadDiagnosticConnection!
+
+    # The reactions to the discussion thread (i.e., to its first comment),
+    # grouped by content.
+    reactionGroups: [DiscussionReactionGroup!]!
+}
+
+# The order of a list of discussion threads.
+input DiscussionThreadOrder {
+    # The field to order threads by. Threads with the same value are ordered by
+    # their IDs (in the same direction).
+    field: DiscussionThreadOrderField!
+    # The direction to order threads in.
+    direction: OrderDirection!
+}
+
+# A field to order discussion threads by.
+enum DiscussionThreadOrderField {
+    # The time the thread was created.
+    CREATED_AT
+    # The time the thread was last updated.
+    UPDATED_AT
+    # The number of comments in the thread.
+    COMMENT_COUNT
+    # The thread's title.
+    TITLE
+}
+
+# The direction to order a list in.
+enum OrderDirection {
+    # Ascending order (e.g. oldest first).
+    ASC
+    # Descending order (e.g. newest first).
+    DESC
 }
 
 # A comment made within a discussion thread.
@@ -3387,9 +4414,33 @@ type DiscussionComment implements Node {
     # The markdown contents rendered as an HTML string. It is already sanitized
     # and escaped and thus is always safe to render.
     #
+    # Fenced code blocks that specify a language are syntax highlighted. Thread
+    # references (like #123), commit SHAs, and file paths in the thread's
+    # repository are linked to the thread, commit, or file.
+    #
     # If the comment was created without any contents (after trimming whitespace)
     # then the title of the thread will be returned.
-    html(options: MarkdownOptions): String!
+    #
+    # If the comment is longer than the discussions.maxCommentLength site configuration (e.g.
+    # because it was created before the limit was lowered), only its beginning is rendered (see
+    # isTruncated and fullHTML).
+    html(
+        options: MarkdownOptions
+        # Whether to highlight code blocks for a light theme.
+        isLightTheme: Boolean = false
+    ): String!
+
+    # Whether the comment is longer than the discussions.maxCommentLength site configuration,
+    # in which case html renders only its beginning.
+    isTruncated: Boolean!
+
+    # Like html, except that the whole comment is rendered even if it is truncated in html. It
+    # should only be requested when the user asks to see the full comment.
+    fullHTML(
+        options: MarkdownOptions
+        # Whether to highlight code blocks for a light theme.
+        isLightTheme: Boolean = false
+    ): String!
 
     # The URL at which this thread can be viewed inline (i.e. in the file blob view).
     #
@@ -3415,35 +4466,434 @@ type DiscussionComment implements Node {
     canReport: Boolean!
 
     # Whether or not the comment can be deleted.
-    canDelete: Boolean!
+    canDelete: Boolean! @deprecated(reason: "use viewerCanDelete instead")
+
+    # Whether the viewer can update the contents of the comment. Only site
+    # admins and the comment's author can update it.
+    viewerCanUpdate: Boolean!
+
+    # Whether the viewer can delete the comment. Only site admins and
+    # maintainers can delete comments.
+    viewerCanDelete: Boolean!
 
     # Whether or not the comment can have its reports be cleared.
     #
     # This is always false when discussions.abuseProtection in the site config is set to false.
     canClearReports: Boolean!
-}
 
-# A list of discussion threads.
-type DiscussionThreadConnection {
-    # A list of discussion threads.
-    nodes: [DiscussionThread!]!
+    # The reactions to the comment, grouped by content.
+    reactionGroups: [DiscussionReactionGroup!]!
 
-    # The total count of discussion threads in the connection. This total
-    # count may be larger than the number of nodes in this object when the
-    # result is paginated.
-    totalCount: Int!
+    # The comment that this comment is a reply to, or null if it is a
+    # top-level comment in the thread.
+    parentComment: DiscussionComment
 
-    # Pagination information.
-    pageInfo: PageInfo!
+    # Whether the discussion formed by the comment and its replies has been
+    # resolved. Always false for replies.
+    isResolved: Boolean!
+
+    # The date when the comment was resolved, or null if it is not resolved.
+    resolvedAt: DateTime
+
+    # The user who resolved the comment. Null if the comment is not resolved
+    # or the user has since been deleted.
+    resolvedBy: User
+
+    # Whether the comment has been hidden by a site admin. The contents and
+    # html of a hidden comment are replaced with a notice for users who are not
+    # site admins.
+    isHidden: Boolean!
+
+    # The date when the comment was hidden, or null if it is not hidden.
+    hiddenAt: DateTime
+
+    # The site admin who hid the comment. Only site admins will receive a
+    # non-null value.
+    hiddenBy: User
+
+    # The direct replies to the comment, oldest first.
+    replies(
+        # Returns the first n replies from the list.
+        first: Int
+    ): DiscussionCommentConnection!
+
+    # The users and organizations @mentioned in the comment's contents.
+    mentions: [Namespace!]!
+
+    # The files attached to the comment, oldest first.
+    attachments: [DiscussionCommentAttachment!]!
+
+    # The change suggested by the comment, or null if it does not suggest one.
+    suggestion: DiscussionCommentSuggestion
+
+    # The date when the comment's contents were last edited, or null if they
+    # have never been edited.
+    lastEditedAt: DateTime
+
+    # The user who last edited the comment's contents, or null if they have
+    # never been edited.
+    editor: User
+
+    # The prior versions of the comment's contents, most recent first.
+    #
+    # Only site admins and the comment author may view the edit history.
+    editHistory(
+        # Returns the first n edits from the list.
+        first: Int
+    ): DiscussionCommentEditConnection!
 }
 
-# A list of discussion comments.
-type DiscussionCommentConnection {
-    # A list of discussion comments.
-    nodes: [DiscussionComment!]!
+# A file attached to a discussion comment.
+type DiscussionCommentAttachment {
+    # The name of the file.
+    filename: String!
 
-    # The total count of discussion comments in the connection. This total
-    # count may be larger than the number of nodes in this object when the
+    # The MIME type of the file, such as "image/png".
+    contentType: String!
+
+    # The size of the file, in bytes.
+    size: Int!
+
+    # The URL at which the file's contents can be downloaded.
+    url: String!
+
+    # Whether the file is an image that can be displayed inline (e.g., with an
+    # <img> tag pointing to the url).
+    isImage: Boolean!
+
+    # The date when the file was attached.
+    createdAt: DateTime!
+}
+
+# A new discussion comment attachment, whose contents have yet to be uploaded.
+type DiscussionCommentAttachmentUpload {
+    # The new attachment.
+    attachment: DiscussionCommentAttachment!
+
+    # The URL to which the file's contents must be uploaded with a PUT request
+    # (including the X-Csrf-Token header, as with other requests to the site).
+    # It can only be used once.
+    uploadURL: String!
+
+    # The date when the upload URL expires.
+    uploadURLExpiresAt: DateTime!
+}
+
+# A change suggested by a discussion comment: a replacement for the lines that
+# the comment's thread selects.
+type DiscussionCommentSuggestion {
+    # The suggested replacement for the selected lines.
+    contents: String!
+
+    # The date when the suggestion was applied, or null if it has not been
+    # applied.
+    appliedAt: DateTime
+
+    # The user who applied the suggestion. Null if the suggestion has not been
+    # applied or the user has since been deleted.
+    appliedBy: User
+
+    # The commit that applied the suggestion, or null if it has not been
+    # applied.
+    appliedCommitOID: GitObjectID
+}
+
+# A prior version of a discussion comment's contents.
+type DiscussionCommentEdit {
+    # The contents of the comment before the edit.
+    previousContents: String!
+
+    # The user who made the edit.
+    editor: User!
+
+    # The date when the edit was made.
+    createdAt: DateTime!
+}
+
+# A list of discussion comment edits.
+type DiscussionCommentEditConnection {
+    # A list of discussion comment edits.
+    nodes: [DiscussionCommentEdit!]!
+
+    # The total count of edits in the connection. This total count may be
+    # larger than the number of nodes in this object when the result is
+    # paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# The emoji of a reaction to a discussion thread or comment.
+enum DiscussionReactionContent {
+    # 👍
+    THUMBS_UP
+    # 👎
+    THUMBS_DOWN
+    # 😄
+    LAUGH
+    # 🎉
+    HOORAY
+    # 😕
+    CONFUSED
+    # ❤️
+    HEART
+    # 🚀
+    ROCKET
+    # 👀
+    EYES
+}
+
+# The reactions with the same content to a discussion thread or comment.
+type DiscussionReactionGroup {
+    # The emoji of the reactions.
+    content: DiscussionReactionContent!
+
+    # The number of users who reacted with this content.
+    count: Int!
+
+    # Whether the viewer is one of the users who reacted with this content.
+    viewerHasReacted: Boolean!
+}
+
+# A list of audit log entries.
+type DiscussionAuditLogEntryConnection {
+    # A list of audit log entries.
+    nodes: [DiscussionAuditLogEntry!]!
+
+    # The total count of audit log entries in the connection. This total count
+    # may be larger than the number of nodes in this object when the result is
+    # paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# An entry in the audit log, which records a mutation of a discussion thread
+# or comment.
+type DiscussionAuditLogEntry {
+    # The unique ID of the entry.
+    id: ID!
+
+    # The user who performed the mutation, or null if it was performed by
+    # Sourcegraph itself (or the user has since been deleted).
+    actor: User
+
+    # The IP address that the mutation was requested from, if it was requested
+    # over HTTP. This may be the address of a proxy.
+    actorIP: String
+
+    # The X-Forwarded-For header of the request that performed the mutation,
+    # if any.
+    actorForwardedFor: String
+
+    # The kind of object that was mutated.
+    objectKind: DiscussionAuditLogObjectKind!
+
+    # The ID of the discussion thread or comment that was mutated. The object
+    # may since have been deleted.
+    objectID: ID!
+
+    # The mutation that was performed, such as "create", "update", or
+    # "delete".
+    action: String!
+
+    # The object's database record before the mutation, or null if it was
+    # created by the mutation.
+    before: JSONValue
+
+    # The object's database record after the mutation.
+    after: JSONValue
+
+    # The names of the fields of the record that were changed by the mutation.
+    changedFields: [String!]!
+
+    # The date when the mutation was performed.
+    createdAt: DateTime!
+}
+
+# The kind of object that an audit log entry records a mutation of.
+enum DiscussionAuditLogObjectKind {
+    # A discussion thread.
+    THREAD
+    # A discussion comment.
+    COMMENT
+}
+
+# A list of discussion threads.
+type DiscussionThreadConnection {
+    # A list of discussion threads.
+    nodes: [DiscussionThread!]!
+
+    # The total count of discussion threads in the connection. This total
+    # count may be larger than the number of nodes in this object when the
+    # result is paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# A list of discussion comments.
+type DiscussionCommentConnection {
+    # A list of discussion comments.
+    nodes: [DiscussionComment!]!
+
+    # The total count of discussion comments in the connection. This total
+    # count may be larger than the number of nodes in this object when the
+    # result is paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# A label that can be added to discussion threads in a repository.
+type DiscussionLabel implements Node {
+    # The discussion label ID (globally unique).
+    id: ID!
+
+    # The repository that the label belongs to.
+    repository: Repository!
+
+    # The name of the label.
+    name: String!
+
+    # The color of the label as a hex color string (e.g. "#ff0000").
+    color: String!
+
+    # The description of the label, if any.
+    description: String
+
+    # The date when the label was created.
+    createdAt: DateTime!
+
+    # The date when the label was last updated.
+    updatedAt: DateTime!
+}
+
+# The kind of threads that a discussion feed lists.
+enum DiscussionFeedKind {
+    # The threads of a repository.
+    REPOSITORY
+    # The threads assigned to the feed's user.
+    ASSIGNED
+    # The threads that mention the feed's user.
+    MENTIONED
+}
+
+# An Atom feed of discussion threads, read with the permissions of the user
+# who created it.
+type DiscussionFeed implements Node {
+    # The discussion feed ID (globally unique).
+    id: ID!
+    # The kind of threads that the feed lists.
+    kind: DiscussionFeedKind!
+    # The repository whose threads the feed lists, for REPOSITORY feeds.
+    repository: Repository
+    # The date when the feed was created.
+    createdAt: DateTime!
+    # The date when the feed was last fetched, if it has been.
+    lastUsedAt: DateTime
+}
+
+# The result of the createFeed mutation.
+type CreateDiscussionFeedResult {
+    # The new feed.
+    feed: DiscussionFeed!
+    # The URL of the feed, which embeds a secret token. The caller is
+    # responsible for storing this value; it cannot be retrieved later.
+    url: String!
+}
+
+# A webhook that is notified of events on discussion threads.
+type DiscussionWebhook implements Node {
+    # The discussion webhook ID (globally unique).
+    id: ID!
+
+    # The repository whose threads' events are delivered to the webhook, or
+    # null if events on threads in all repositories are delivered.
+    repository: Repository
+
+    # The URL that events are POSTed to.
+    url: String!
+
+    # The date when the webhook was created.
+    createdAt: DateTime!
+
+    # The log of deliveries to the webhook, most recent first.
+    deliveries(
+        # Returns the first n deliveries from the list.
+        first: Int
+    ): DiscussionWebhookDeliveryConnection!
+}
+
+# Statistics about the discussion threads in a repository.
+type DiscussionThreadStatistics {
+    # The number of open threads.
+    openCount: Int!
+
+    # The number of archived threads.
+    archivedCount: Int!
+
+    # The median number of seconds from the creation of an archived thread
+    # until it was first archived. Null if no thread is archived.
+    medianSecondsToArchive: Float
+
+    # The number of comments made on threads in each of the last 12 weeks
+    # (including the current week), oldest first. Weeks begin on Monday (UTC).
+    weeklyComments: [DiscussionWeeklyCount!]!
+}
+
+# The number of occurrences of something in a week.
+type DiscussionWeeklyCount {
+    # The start of the week.
+    week: DateTime!
+
+    # The number of occurrences in the week.
+    count: Int!
+}
+
+# A rule that adds labels and assignees to the discussion threads of a
+# repository that match its query. Rules are applied when a thread is created
+# and when its title is edited.
+type DiscussionRule implements Node {
+    # The discussion rule ID (globally unique).
+    id: ID!
+
+    # The repository whose threads the rule applies to.
+    repository: Repository!
+
+    # The name of the rule.
+    name: String!
+
+    # The thread filter query that threads must match for the rule to apply,
+    # in the syntax of Query#discussionThreads's query argument.
+    query: String!
+
+    # The labels that the rule adds to matching threads. Labels that have
+    # since been deleted are omitted.
+    labels: [DiscussionLabel!]!
+
+    # The users that the rule assigns to matching threads. Users who have
+    # since been deleted are omitted.
+    assignees: [User!]!
+
+    # The date when the rule was created.
+    createdAt: DateTime!
+
+    # The date when the rule was last updated.
+    updatedAt: DateTime!
+}
+
+# A list of discussion webhooks.
+type DiscussionWebhookConnection {
+    # A list of discussion webhooks.
+    nodes: [DiscussionWebhook!]!
+
+    # The total count of discussion webhooks in the connection. This total
+    # count may be larger than the number of nodes in this object when the
     # result is paginated.
     totalCount: Int!
 
@@ -3451,6 +4901,490 @@ type DiscussionCommentConnection {
     pageInfo: PageInfo!
 }
 
+# The delivery of a discussion thread event to a webhook. Failed deliveries
+# are retried with exponential backoff.
+type DiscussionWebhookDelivery {
+    # The event that was delivered.
+    event: DiscussionThreadTimelineItem!
+
+    # The number of delivery attempts made so far.
+    attempts: Int!
+
+    # The HTTP status code of the webhook's response to the latest attempt, if
+    # a response was received.
+    statusCode: Int
+
+    # The error of the latest attempt, or null if it succeeded.
+    error: String
+
+    # The date when the delivery was created.
+    createdAt: DateTime!
+
+    # The date when the delivery succeeded, or null if it has not (yet).
+    deliveredAt: DateTime
+}
+
+# A list of discussion webhook deliveries.
+type DiscussionWebhookDeliveryConnection {
+    # A list of discussion webhook deliveries.
+    nodes: [DiscussionWebhookDelivery!]!
+
+    # The total count of discussion webhook deliveries in the connection. This
+    # total count may be larger than the number of nodes in this object when
+    # the result is paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# A template that pre-populates new discussion threads in a repository.
+type DiscussionThreadTemplate implements Node {
+    # The discussion thread template ID (globally unique).
+    id: ID!
+
+    # The repository that the template belongs to.
+    repository: Repository!
+
+    # The name of the template.
+    name: String!
+
+    # The description of when to use the template, if any.
+    description: String
+
+    # The title of threads created from the template, if any.
+    title: String
+
+    # The contents of the first comment of threads created from the template.
+    contents: String!
+
+    # The date when the template was created.
+    createdAt: DateTime!
+
+    # The date when the template was last updated.
+    updatedAt: DateTime!
+}
+
+# A list of discussion thread templates.
+type DiscussionThreadTemplateConnection {
+    # A list of discussion thread templates.
+    nodes: [DiscussionThreadTemplate!]!
+
+    # The total count of discussion thread templates in the connection. This
+    # total count may be larger than the number of nodes in this object when
+    # the result is paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# A named canned response that a user saved to insert when commenting.
+type DiscussionSavedReply implements Node {
+    # The saved reply ID (globally unique).
+    id: ID!
+
+    # The user who saved the reply.
+    user: User!
+
+    # The name of the saved reply, which is unique among the user's saved
+    # replies.
+    name: String!
+
+    # The contents of the saved reply, in Markdown.
+    contents: String!
+
+    # The date when the saved reply was created.
+    createdAt: DateTime!
+
+    # The date when the saved reply was last updated.
+    updatedAt: DateTime!
+}
+
+# A list of saved replies.
+type DiscussionSavedReplyConnection {
+    # A list of saved replies.
+    nodes: [DiscussionSavedReply!]!
+
+    # The total count of saved replies in the connection. This total count may
+    # be larger than the number of nodes in this object when the result is
+    # paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# A milestone that groups discussion threads, e.g. for a release. A milestone
+# belongs to either a repository or an organization.
+type DiscussionMilestone implements Node {
+    # The discussion milestone ID (globally unique).
+    id: ID!
+
+    # The repository that the milestone belongs to, if any.
+    repository: Repository
+
+    # The organization that the milestone belongs to, if any.
+    organization: Org
+
+    # The title of the milestone.
+    title: String!
+
+    # The description of the milestone, if any.
+    description: String
+
+    # The date by which the milestone is due, if any.
+    dueDate: DateTime
+
+    # The date when the milestone was closed (or null if it is open).
+    closedAt: DateTime
+
+    # Whether the milestone is closed.
+    isClosed: Boolean!
+
+    # The threads in the milestone.
+    threads(
+        # Returns the first n threads from the list.
+        first: Int
+    ): DiscussionThreadConnection!
+
+    # The date when the milestone was created.
+    createdAt: DateTime!
+
+    # The date when the milestone was last updated.
+    updatedAt: DateTime!
+}
+
+# A list of discussion milestones.
+type DiscussionMilestoneConnection {
+    # A list of discussion milestones.
+    nodes: [DiscussionMilestone!]!
+
+    # The total count of discussion milestones in the connection. This total
+    # count may be larger than the number of nodes in this object when the
+    # result is paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# A list of discussion labels.
+type DiscussionLabelConnection {
+    # A list of discussion labels.
+    nodes: [DiscussionLabel!]!
+
+    # The total count of discussion labels in the connection. This total
+    # count may be larger than the number of nodes in this object when the
+    # result is paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# The kind of a discussion thread timeline item.
+enum DiscussionThreadTimelineItemKind {
+    # The thread was created.
+    CREATED
+    # The thread's title was changed.
+    TITLE_EDITED
+    # The thread was archived.
+    ARCHIVED
+    # The thread was unarchived.
+    UNARCHIVED
+    # The thread was closed.
+    CLOSED
+    # The thread was reopened.
+    REOPENED
+    # The thread was locked.
+    LOCKED
+    # The thread was unlocked.
+    UNLOCKED
+    # A label was added to the thread.
+    LABEL_ADDED
+    # A label was removed from the thread.
+    LABEL_REMOVED
+    # A user was assigned to the thread.
+    ASSIGNED
+    # A user was unassigned from the thread.
+    UNASSIGNED
+    # The thread was added to a milestone.
+    MILESTONED
+    # The thread was removed from a milestone.
+    DEMILESTONED
+    # A comment was added to the thread.
+    COMMENTED
+    # The thread was deleted.
+    DELETED
+    # The thread was restored after being deleted.
+    RESTORED
+    # A comment's suggested change was applied to the thread's branch.
+    SUGGESTION_APPLIED
+    # A top-level comment and its replies were resolved.
+    COMMENT_RESOLVED
+    # A top-level comment and its replies were unresolved.
+    COMMENT_UNRESOLVED
+    # The thread was referenced by a comment in another thread.
+    REFERENCED
+    # The thread was marked as stale after being inactive.
+    MARKED_STALE
+    # The thread was moved to another repository.
+    TRANSFERRED
+    # The thread was marked as a duplicate of another thread.
+    MARKED_AS_DUPLICATE
+    # The thread was pinned to the top of its repository's thread list.
+    PINNED
+    # The thread was unpinned.
+    UNPINNED
+    # The thread was marked as blocked by another thread.
+    DEPENDENCY_ADDED
+    # The thread was marked as no longer blocked by another thread.
+    DEPENDENCY_REMOVED
+}
+
+# A named discussion thread filter query saved by a user.
+type DiscussionSavedThreadFilter {
+    # The name of the filter, unique among the user's saved filters.
+    name: String!
+    # The thread filter query, in the syntax of Query#discussionThreads's
+    # query argument.
+    query: String!
+    # Whether the threads UI shows this filter's threads by default.
+    isDefault: Boolean!
+}
+
+# The state of a discussion thread.
+#
+# Lists of threads exclude archived threads unless the ARCHIVED or ALL state
+# (or an "is:archived" query qualifier) is given.
+enum DiscussionThreadState {
+    # The thread is open, i.e. neither closed nor archived.
+    OPEN
+    # The thread is closed (and not archived). Closed threads can still be
+    # changed and commented on.
+    CLOSED
+    # The thread is archived (whether or not it is closed). Archived threads are
+    # read-only: they can't be changed or commented on until they are
+    # unarchived.
+    ARCHIVED
+    # The thread is open, but has been inactive for so long that it has been
+    # marked as stale and will be archived unless there is new activity on it.
+    STALE
+    # Any state, including archived. Only valid as a filter when listing
+    # threads.
+    ALL
+}
+
+# The reported and hidden discussion threads and comments that need the
+# attention of moderators.
+type DiscussionModerationQueue {
+    # The threads that were reported, or that have reported comments.
+    reportedThreads(
+        # Returns the first n threads from the list.
+        first: Int
+    ): DiscussionThreadConnection!
+
+    # The comments that were reported, oldest first.
+    reportedComments(
+        # Returns the first n comments from the list.
+        first: Int
+    ): DiscussionCommentConnection!
+
+    # The comments that were hidden, oldest first.
+    hiddenComments(
+        # Returns the first n comments from the list.
+        first: Int
+    ): DiscussionCommentConnection!
+}
+
+# The kinds of activity on a discussion thread that a subscriber of the thread
+# is notified of. Subscribers are always notified when they are mentioned.
+enum DiscussionThreadNotificationLevel {
+    # Notify of new comments and of changes to the thread's state (such as it
+    # being archived or locked).
+    ALL
+    # Only notify of new comments.
+    COMMENTS
+    # Only notify of changes to the thread's state.
+    STATE_CHANGES
+}
+
+# The format of a discussion threads export.
+# A role that a user can have in the discussions of a repository or
+# organization. Each role includes the permissions of the roles before it.
+enum DiscussionRole {
+    # Can comment on locked threads.
+    CONTRIBUTOR
+    # Can also label, archive, assign, and set the milestone of threads, and
+    # mark them as duplicates or dependencies of other threads.
+    TRIAGER
+    # Can also lock, unlock and delete threads, and delete comments.
+    MAINTAINER
+}
+
+# A user's role in the discussions of a repository or organization.
+type DiscussionRoleAssignment {
+    # The user who has the role.
+    user: User!
+    # The role.
+    role: DiscussionRole!
+    # The date when the user was given the role.
+    createdAt: DateTime!
+}
+
+enum DiscussionThreadsExportFormat {
+    # A JSON array with one object per thread, which includes the thread's
+    # comments and events.
+    JSON
+    # A CSV table with one row per thread, comment, and event.
+    CSV
+}
+
+# The ways in which a user can be involved in a discussion thread.
+enum DiscussionThreadUserRelation {
+    # The user authored the thread.
+    AUTHORED
+    # The user is assigned to the thread.
+    ASSIGNED
+    # The user is @mentioned in a comment in the thread.
+    MENTIONED
+}
+
+# An entry in the activity feed of a discussion thread.
+type DiscussionThreadTimelineItem {
+    # The unique ID of the item.
+    id: ID!
+
+    # The kind of activity.
+    kind: DiscussionThreadTimelineItemKind!
+
+    # The thread that the activity occurred on. Null if the thread has since
+    # been deleted.
+    thread: DiscussionThread
+
+    # The user who performed the activity.
+    actor: User!
+
+    # The date when the activity occurred.
+    createdAt: DateTime!
+
+    # For TITLE_EDITED items, the title before the change.
+    previousTitle: String
+
+    # For TITLE_EDITED items, the title after the change.
+    title: String
+
+    # For LABEL_ADDED and LABEL_REMOVED items, the label. Null if the label has
+    # since been deleted.
+    label: DiscussionLabel
+
+    # For ASSIGNED and UNASSIGNED items, the user who was assigned or
+    # unassigned. Null if the user has since been deleted.
+    assignee: User
+
+    # For LABEL_ADDED and ASSIGNED items made by a rule, the rule. Null if the
+    # change was made by a user or the rule has since been deleted.
+    rule: DiscussionRule
+
+    # For MILESTONED and DEMILESTONED items, the milestone. Null if the
+    # milestone has since been deleted.
+    milestone: DiscussionMilestone
+
+    # For COMMENTED, SUGGESTION_APPLIED, COMMENT_RESOLVED, COMMENT_UNRESOLVED
+    # and REFERENCED items, the comment (for REFERENCED items, the comment in
+    # the other thread that references this thread). Null if the comment has
+    # since been deleted.
+    comment: DiscussionComment
+
+    # For TRANSFERRED items, the repository that the thread was moved from.
+    # Null if the repository has since been deleted.
+    previousRepository: Repository
+
+    # For TRANSFERRED items, the repository that the thread was moved to. Null
+    # if the repository has since been deleted.
+    repository: Repository
+
+    # For MARKED_AS_DUPLICATE items, the thread that this thread was marked as
+    # a duplicate of. Null if the thread has since been deleted.
+    originalThread: DiscussionThread
+
+    # For DEPENDENCY_ADDED and DEPENDENCY_REMOVED items, the thread that this
+    # thread was marked as (no longer) blocked by. Null if the thread has since
+    # been deleted.
+    dependencyThread: DiscussionThread
+}
+
+# A list of discussion thread timeline items.
+type DiscussionThreadTimelineItemConnection {
+    # A list of discussion thread timeline items.
+    nodes: [DiscussionThreadTimelineItem!]!
+
+    # The total count of discussion thread timeline items in the connection.
+    # This total count may be larger than the number of nodes in this object
+    # when the result is paginated.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# The severity of a diagnostic. The severities match those of the Language
+# Server Protocol.
+enum DiagnosticSeverity {
+    ERROR
+    WARNING
+    INFO
+    HINT
+}
+
+# A problem (such as a compiler error or lint warning) in a file, reported on
+# a discussion thread by a tool or extension.
+type DiscussionThreadDiagnostic {
+    # The name of the tool or extension that reported the diagnostic.
+    source: String!
+
+    # The path (relative to the repository root) of the file that the
+    # diagnostic applies to.
+    path: String!
+
+    # The range in the file that the diagnostic applies to.
+    range: Range!
+
+    # The diagnostic's message.
+    message: String!
+
+    # The diagnostic's severity.
+    severity: DiagnosticSeverity!
+}
+
+# The number of diagnostics with a severity.
+type DiscussionThreadDiagnosticSeverityCount {
+    # The severity.
+    severity: DiagnosticSeverity!
+
+    # The number of diagnostics with the severity.
+    count: Int!
+}
+
+# A list of diagnostics on a discussion thread.
+type DiscussionThreadDiagnosticConnection {
+    # A list of diagnostics.
+    nodes: [DiscussionThreadDiagnostic!]!
+
+    # The total count of diagnostics in the connection. This total count may be
+    # larger than the number of nodes in this object when the result is
+    # paginated.
+    totalCount: Int!
+
+    # The number of diagnostics in the connection with each severity, most
+    # severe first. Severities without diagnostics are omitted.
+    countsBySeverity: [DiscussionThreadDiagnosticSeverityCount!]!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
 # RepositoryOrderBy enumerates the ways a repositories list can be ordered.
 enum RepositoryOrderBy {
     REPOSITORY_NAME
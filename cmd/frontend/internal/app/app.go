@@ -62,6 +62,11 @@ func NewHandler() http.Handler {
 
 	r.Get(router.RegistryExtensionBundle).Handler(trace.TraceRoute(gziphandler.GzipHandler(http.HandlerFunc(registry.HandleRegistryExtensionBundle))))
 
+	r.Get(router.DiscussionsUnsubscribe).Handler(trace.TraceRoute(http.HandlerFunc(serveDiscussionsUnsubscribe)))
+	r.Get(router.DiscussionsFeed).Handler(trace.TraceRoute(http.HandlerFunc(serveDiscussionsFeed)))
+	r.Get(router.DiscussionsAttachmentUpload).Handler(trace.TraceRoute(http.HandlerFunc(serveDiscussionsAttachmentUpload)))
+	r.Get(router.DiscussionsAttachment).Handler(trace.TraceRoute(http.HandlerFunc(serveDiscussionsAttachment)))
+
 	r.Get(router.GDDORefs).Handler(trace.TraceRoute(errorutil.Handler(serveGDDORefs)))
 	r.Get(router.Editor).Handler(trace.TraceRoute(errorutil.Handler(serveEditor)))
 
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/attachments"
+)
+
+// serveDiscussionsAttachmentUpload handles uploads of the contents of
+// discussion comment attachments (see discussions.URLToUploadAttachment).
+func serveDiscussionsAttachmentUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: The token alone authorizes uploading the contents of the
+	// attachment. It is only given to the author of the attachment's comment,
+	// and can only be used once.
+	attachment, err := db.DiscussionCommentAttachments.GetByUploadToken(ctx, r.URL.Query().Get("token"))
+	if err != nil {
+		if err == db.ErrInvalidToken {
+			http.Error(w, "Invalid or expired upload link.", http.StatusNotFound)
+			return
+		}
+		httpLogAndError(w, "Could not look up upload token", http.StatusInternalServerError, "error", err)
+		return
+	}
+	if r.ContentLength >= 0 && r.ContentLength != attachment.Size {
+		http.Error(w, attachments.ErrSizeMismatch.Error(), http.StatusBadRequest)
+		return
+	}
+
+	store, err := attachments.NewStore(ctx)
+	if err != nil {
+		httpLogAndError(w, "Could not open attachment storage", http.StatusInternalServerError, "error", err)
+		return
+	}
+	if err := store.Put(ctx, attachments.Key(attachment.ID), attachment.ContentType, attachments.NewSizeReader(r.Body, attachment.Size)); err != nil {
+		if err == attachments.ErrSizeMismatch {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		httpLogAndError(w, "Could not store attachment", http.StatusInternalServerError, "attachment", attachment.ID, "error", err)
+		return
+	}
+	if _, err := db.DiscussionCommentAttachments.MarkUploaded(ctx, attachment.ID); err != nil {
+		httpLogAndError(w, "Could not record attachment upload", http.StatusInternalServerError, "attachment", attachment.ID, "error", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveDiscussionsAttachment serves the contents of a discussion comment
+// attachment (see discussions.URLToAttachment).
+func serveDiscussionsAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["ID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Attachment not found.", http.StatusNotFound)
+		return
+	}
+	attachment, err := db.DiscussionCommentAttachments.Get(ctx, id)
+	if err != nil {
+		if _, ok := err.(*db.ErrAttachmentNotFound); ok {
+			http.Error(w, "Attachment not found.", http.StatusNotFound)
+			return
+		}
+		httpLogAndError(w, "Could not look up attachment", http.StatusInternalServerError, "error", err)
+		return
+	}
+	if attachment.UploadedAt == nil {
+		http.Error(w, "Attachment not found.", http.StatusNotFound)
+		return
+	}
+
+	// 🚨 SECURITY: Attachments are visible to anyone who can view their
+	// comment. DiscussionComments.Get returns not found if the comment was
+	// deleted or the user cannot read its thread's repository.
+	if _, err := db.DiscussionComments.Get(ctx, attachment.CommentID); err != nil {
+		if _, ok := err.(*db.ErrCommentNotFound); ok {
+			http.Error(w, "Attachment not found.", http.StatusNotFound)
+			return
+		}
+		httpLogAndError(w, "Could not look up comment", http.StatusInternalServerError, "error", err)
+		return
+	}
+
+	store, err := attachments.NewStore(ctx)
+	if err != nil {
+		httpLogAndError(w, "Could not open attachment storage", http.StatusInternalServerError, "error", err)
+		return
+	}
+	f, err := store.Open(ctx, attachments.Key(attachment.ID))
+	if err != nil {
+		httpLogAndError(w, "Could not read attachment", http.StatusInternalServerError, "attachment", attachment.ID, "error", err)
+		return
+	}
+	defer f.Close()
+
+	// 🚨 SECURITY: Uploaded files are untrusted. Only images that browsers
+	// cannot execute scripts in are displayed inline; everything else is
+	// downloaded. Browsers must also not guess a different content type, or
+	// run anything in the context of this site.
+	disposition := "attachment"
+	if attachments.IsImage(attachment.ContentType) {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	io.Copy(w, f)
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/attachments"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type memAttachmentStore map[string][]byte
+
+func (s memAttachmentStore) Put(_ context.Context, key, _ string, r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s[key] = b
+	return nil
+}
+
+func (s memAttachmentStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(s[key])), nil
+}
+
+func TestServeDiscussionsAttachments(t *testing.T) {
+	defer func() {
+		db.Mocks = db.MockStores{}
+		attachments.MockStore = nil
+	}()
+	store := memAttachmentStore{}
+	attachments.MockStore = store
+
+	attachment := &types.DiscussionCommentAttachment{ID: 1, CommentID: 2, Filename: "a.txt", ContentType: "text/plain", Size: 5}
+	db.Mocks.DiscussionCommentAttachments.GetByUploadToken = func(_ context.Context, token string) (*types.DiscussionCommentAttachment, error) {
+		if token != "t" || attachment.UploadedAt != nil {
+			return nil, db.ErrInvalidToken
+		}
+		return attachment, nil
+	}
+	db.Mocks.DiscussionCommentAttachments.MarkUploaded = func(context.Context, int64) (*types.DiscussionCommentAttachment, error) {
+		now := time.Now()
+		attachment.UploadedAt = &now
+		return attachment, nil
+	}
+	db.Mocks.DiscussionCommentAttachments.Get = func(_ context.Context, id int64) (*types.DiscussionCommentAttachment, error) {
+		if id != attachment.ID {
+			return nil, &db.ErrAttachmentNotFound{AttachmentID: id}
+		}
+		return attachment, nil
+	}
+	db.Mocks.DiscussionComments.Get = func(commentID int64) (*types.DiscussionComment, error) {
+		return &types.DiscussionComment{ID: commentID}, nil
+	}
+
+	upload := func(token, body string) int {
+		rec := httptest.NewRecorder()
+		serveDiscussionsAttachmentUpload(rec, httptest.NewRequest("PUT", "/-/discussions/attachments/upload?token="+token, strings.NewReader(body)))
+		return rec.Code
+	}
+	download := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := mux.SetURLVars(httptest.NewRequest("GET", "/-/discussions/attachments/1", nil), map[string]string{"ID": "1"})
+		serveDiscussionsAttachment(rec, req)
+		return rec
+	}
+
+	if code := download().Code; code != http.StatusNotFound {
+		t.Errorf("got status %d downloading a pending attachment, want %d", code, http.StatusNotFound)
+	}
+	if code := upload("x", "hello"); code != http.StatusNotFound {
+		t.Errorf("got status %d with invalid token, want %d", code, http.StatusNotFound)
+	}
+	if code := upload("t", "hello world"); code != http.StatusBadRequest {
+		t.Errorf("got status %d with mismatched size, want %d", code, http.StatusBadRequest)
+	}
+	if code := upload("t", "hello"); code != http.StatusNoContent {
+		t.Errorf("got status %d, want %d", code, http.StatusNoContent)
+	}
+	if code := upload("t", "hello"); code != http.StatusNotFound {
+		t.Errorf("got status %d reusing token, want %d", code, http.StatusNotFound)
+	}
+
+	rec := download()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != "hello" {
+		t.Errorf("got contents %q, want %q", got, "hello")
+	}
+	if got, want := rec.Header().Get("Content-Disposition"), `attachment; filename=a.txt`; got != want {
+		t.Errorf("got Content-Disposition %q, want %q", got, want)
+	}
+
+	// The comment is not found if the user cannot read its thread's
+	// repository (e.g. a private repository).
+	db.Mocks.DiscussionComments.Get = func(commentID int64) (*types.DiscussionComment, error) {
+		return nil, &db.ErrCommentNotFound{CommentID: commentID}
+	}
+	if code := download().Code; code != http.StatusNotFound {
+		t.Errorf("got status %d downloading an attachment of an unreadable comment, want %d", code, http.StatusNotFound)
+	}
+}
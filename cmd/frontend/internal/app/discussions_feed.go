@@ -0,0 +1,37 @@
+package app
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// serveDiscussionsFeed serves the Atom feeds of discussion threads (see
+// discussions.URLToFeed).
+func serveDiscussionsFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: The token alone identifies the user whose permissions the
+	// feed is read with, so that feed readers can fetch it without signing in.
+	// Revoked tokens are rejected.
+	token, err := db.DiscussionFeedTokens.Lookup(ctx, r.URL.Query().Get("token"))
+	if err != nil {
+		if err == db.ErrInvalidToken {
+			http.Error(w, "Invalid or revoked feed URL.", http.StatusNotFound)
+			return
+		}
+		httpLogAndError(w, "Could not look up feed token", http.StatusInternalServerError, "error", err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := discussions.WriteFeed(ctx, &buf, token); err != nil {
+		log15.Error("Failed to generate discussions feed.", "feedTokenID", token.ID, "error", err)
+		http.Error(w, "Unexpected error when generating the feed.", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	buf.WriteTo(w)
+}
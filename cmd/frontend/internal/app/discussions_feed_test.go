@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestServeDiscussionsFeed(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	db.Mocks.DiscussionFeedTokens.Lookup = func(_ context.Context, token string) (*types.DiscussionFeedToken, error) {
+		if token != "t" {
+			return nil, db.ErrInvalidToken
+		}
+		return &types.DiscussionFeedToken{ID: 1, UserID: 2, Kind: types.DiscussionFeedAssigned}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "u"}, nil
+	}
+	db.Mocks.DiscussionThreads.List = func(_ context.Context, opts *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if opts.AssigneeUserID != 2 {
+			t.Errorf("got AssigneeUserID %d, want 2", opts.AssigneeUserID)
+		}
+		return []*types.DiscussionThread{{ID: 3, AuthorUserID: 2, Title: "t"}}, nil
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		serveDiscussionsFeed(rec, httptest.NewRequest("GET", "/-/discussions/feed?token=x", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		serveDiscussionsFeed(rec, httptest.NewRequest("GET", "/-/discussions/feed?token=t", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+		if got, want := rec.Header().Get("Content-Type"), "application/atom+xml; charset=utf-8"; got != want {
+			t.Errorf("got Content-Type %q, want %q", got, want)
+		}
+		if !strings.Contains(rec.Body.String(), "<title>Discussions assigned to @u</title>") {
+			t.Errorf("unexpected feed %s", rec.Body.String())
+		}
+	})
+}
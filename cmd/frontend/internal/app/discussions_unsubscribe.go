@@ -0,0 +1,70 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/csrf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+var discussionsUnsubscribeConfirmation = template.Must(template.New("").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Unsubscribe from discussion thread</title></head>
+<body>
+<form method="POST">
+<p>Stop receiving emails about this discussion thread? You will still receive emails when you are mentioned.</p>
+<input type="hidden" name="token" value="{{.Token}}">
+{{.CSRFField}}
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`))
+
+// serveDiscussionsUnsubscribe handles the unsubscribe links in discussion
+// notification emails (see discussions.URLToUnsubscribe).
+//
+// A GET request only shows a confirmation form, because mail scanners and link
+// previews fetch the links in emails. The user is unsubscribed when the form
+// is submitted (as a POST request).
+func serveDiscussionsUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: The token alone identifies (and authorizes unsubscribing)
+	// the user, so that the link works without signing in.
+	token := r.FormValue("token")
+	userID, threadID, err := db.DiscussionUnsubscribeTokens.Get(ctx, token)
+	if err != nil {
+		if err == db.ErrInvalidToken {
+			http.Error(w, "Invalid or expired unsubscribe link.", http.StatusNotFound)
+			return
+		}
+		httpLogAndError(w, "Could not look up unsubscribe token", http.StatusInternalServerError, "error", err)
+		return
+	}
+
+	if r.Method != "POST" {
+		var buf bytes.Buffer
+		if err := discussionsUnsubscribeConfirmation.Execute(&buf, map[string]interface{}{
+			"Token":     token,
+			"CSRFField": csrf.TemplateField(r),
+		}); err != nil {
+			httpLogAndError(w, "Could not render unsubscribe confirmation", http.StatusInternalServerError, "error", err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = buf.WriteTo(w)
+		return
+	}
+
+	if err := db.DiscussionThreadSubscriptions.Set(ctx, threadID, userID, false); err != nil {
+		log15.Error("Failed to unsubscribe user from discussion thread.", "userID", userID, "threadID", threadID, "error", err)
+		http.Error(w, "Unexpected error when unsubscribing.", http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintln(w, "You have been unsubscribed from this discussion thread and will no longer receive emails about it, unless you are mentioned.")
+}
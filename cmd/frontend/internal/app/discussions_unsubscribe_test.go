@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+)
+
+func TestServeDiscussionsUnsubscribe(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	db.Mocks.DiscussionUnsubscribeTokens.Get = func(_ context.Context, token string) (int32, int64, error) {
+		if token != "t" {
+			return 0, 0, db.ErrInvalidToken
+		}
+		return 1, 2, nil
+	}
+	var unsubscribed bool
+	db.Mocks.DiscussionThreadSubscriptions.Set = func(_ context.Context, threadID int64, userID int32, subscribed bool) error {
+		if threadID != 2 || userID != 1 || subscribed {
+			t.Errorf("got Set(%d, %d, %v), want Set(2, 1, false)", threadID, userID, subscribed)
+		}
+		unsubscribed = true
+		return nil
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		serveDiscussionsUnsubscribe(rec, httptest.NewRequest("GET", "/-/discussions/unsubscribe?token=x", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusNotFound)
+		}
+		if unsubscribed {
+			t.Error("unexpected unsubscribe")
+		}
+	})
+
+	t.Run("confirmation", func(t *testing.T) {
+		// Following the link (e.g. by a mail scanner) must not unsubscribe.
+		rec := httptest.NewRecorder()
+		serveDiscussionsUnsubscribe(rec, httptest.NewRequest("GET", "/-/discussions/unsubscribe?token=t", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+		if !strings.Contains(rec.Body.String(), `<form method="POST">`) {
+			t.Errorf("got body %q, want a confirmation form", rec.Body.String())
+		}
+		if unsubscribed {
+			t.Error("unexpected unsubscribe")
+		}
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/-/discussions/unsubscribe", strings.NewReader(url.Values{"token": {"t"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		serveDiscussionsUnsubscribe(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+		if !unsubscribed {
+			t.Error("expected unsubscribe")
+		}
+	})
+}
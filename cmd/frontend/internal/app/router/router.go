@@ -30,6 +30,11 @@ const (
 
 	RegistryExtensionBundle = "registry.extension.bundle"
 
+	DiscussionsUnsubscribe      = "discussions.unsubscribe"
+	DiscussionsFeed             = "discussions.feed"
+	DiscussionsAttachment       = "discussions.attachment"
+	DiscussionsAttachmentUpload = "discussions.attachment.upload"
+
 	OldToolsRedirect = "old-tools-redirect"
 	OldTreeRedirect  = "old-tree-redirect"
 
@@ -72,6 +77,11 @@ func newRouter() *mux.Router {
 
 	base.Path("/-/static/extension/{RegistryExtensionReleaseFilename}").Methods("GET").Name(RegistryExtensionBundle)
 
+	base.Path("/-/discussions/unsubscribe").Methods("GET", "POST").Name(DiscussionsUnsubscribe)
+	base.Path("/-/discussions/feed").Methods("GET").Name(DiscussionsFeed)
+	base.Path("/-/discussions/attachments/upload").Methods("PUT").Name(DiscussionsAttachmentUpload)
+	base.Path("/-/discussions/attachments/{ID:[0-9]+}").Methods("GET").Name(DiscussionsAttachment)
+
 	base.Path("/-/godoc/refs").Methods("GET").Name(GDDORefs)
 	base.Path("/-/editor").Methods("GET").Name(Editor)
 
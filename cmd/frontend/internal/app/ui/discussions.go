@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/handlerutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// serveRepoThread redirects the URL of a thread by its number in the
+// repository (/<repo>/-/discussions/<number>) to the thread's inline view. The
+// URLs of a thread by its number in a repository it was transferred out of
+// redirect to the thread in its new repository.
+func serveRepoThread(w http.ResponseWriter, r *http.Request) error {
+	repo, err := handlerutil.GetRepo(r.Context(), mux.Vars(r))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return &errcode.HTTPErr{Status: http.StatusNotFound, Err: err}
+		}
+		return errors.Wrap(err, "GetRepo")
+	}
+	number, err := strconv.ParseInt(mux.Vars(r)["Number"], 10, 32)
+	if err != nil {
+		return &errcode.HTTPErr{Status: http.StatusNotFound, Err: err}
+	}
+	thread, err := db.DiscussionThreads.GetByNumber(r.Context(), repo.ID, int32(number))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return &errcode.HTTPErr{Status: http.StatusNotFound, Err: err}
+		}
+		return errors.Wrap(err, "DiscussionThreads.GetByNumber")
+	}
+	u, err := discussions.URLToInlineThread(r.Context(), thread)
+	if err != nil {
+		return errors.Wrap(err, "URLToInlineThread")
+	}
+	if u == nil {
+		// The thread has no inline view (e.g. because it has no path).
+		return &errcode.HTTPErr{Status: http.StatusNotFound, Err: errors.New("thread has no inline view")}
+	}
+	http.Redirect(w, r, u.String(), http.StatusFound)
+	return nil
+}
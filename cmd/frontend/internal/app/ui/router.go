@@ -39,6 +39,7 @@ const (
 	routeRepoTags       = "repo-tags"
 	routeRepoCompare    = "repo-compare"
 	routeRepoStats      = "repo-stats"
+	routeRepoThread     = "repo-thread"
 	routeCampaigns      = "campaigns"
 	routeThreads        = "threads"
 	routeTree           = "tree"
@@ -158,6 +159,7 @@ func newRouter() *mux.Router {
 	repo.PathPrefix("/tags").Methods("GET").Name(routeRepoTags)
 	repo.PathPrefix("/compare").Methods("GET").Name(routeRepoCompare)
 	repo.PathPrefix("/stats").Methods("GET").Name(routeRepoStats)
+	repo.Path("/discussions/{Number:[0-9]+}").Methods("GET").Name(routeRepoThread)
 
 	// legacy redirects
 	repo.Path("/info").Methods("GET").Name(routeLegacyRepoLanding)
@@ -287,6 +289,7 @@ func initRouter() {
 
 	// raw
 	router.Get(routeRaw).Handler(handler(serveRaw))
+	router.Get(routeRepoThread).Handler(handler(serveRepoThread))
 
 	// All other routes that are not found.
 	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
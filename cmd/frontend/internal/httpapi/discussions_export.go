@@ -0,0 +1,40 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func serveDiscussionThreadsExport(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	format, err := discussions.ParseExportFormat(q.Get("format"))
+	if err != nil {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+	}
+	opts, err := discussions.ExportListOptions(r.Context(), discussions.ExportTarget{
+		RepoName: api.RepoName(q.Get("repository")),
+		OrgName:  q.Get("org"),
+	})
+	switch err {
+	case nil:
+	case backend.ErrNotAuthenticated:
+		return &errcode.HTTPErr{Status: http.StatusUnauthorized, Err: err}
+	case backend.ErrNotAnOrgMember:
+		return &errcode.HTTPErr{Status: http.StatusForbidden, Err: err}
+	case discussions.ErrInvalidExportTarget:
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+	default:
+		return err
+	}
+
+	filename := fmt.Sprintf("threads-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	return discussions.ExportThreads(r.Context(), w, format, *opts)
+}
@@ -1,16 +1,35 @@
 package httpapi
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/graph-gophers/graphql-go"
-	"github.com/graph-gophers/graphql-go/relay"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
+// persistedQueries maps the SHA-256 hashes of persisted GraphQL queries to the
+// queries. Clients that send a query's hash instead of the query itself save
+// the bandwidth of sending large queries (such as the dashboards' thread
+// queries) on every request. Queries that are not used for a week are
+// forgotten, and the client is asked to send the query again.
+var persistedQueries = rcache.NewWithTTL("graphql_persisted_queries", 7*24*60*60)
+
+// maxPersistedQuerySize is the maximum size (in bytes) of a query that can be
+// persisted. It is well above the size of the largest queries the web app
+// sends.
+const maxPersistedQuerySize = 100 * 1024
+
 func serveGraphQL(schema *graphql.Schema) func(w http.ResponseWriter, r *http.Request) (err error) {
-	relayHandler := &relay.Handler{Schema: schema}
 	return func(w http.ResponseWriter, r *http.Request) (err error) {
 		if r.Method != "POST" {
 			// The URL router should not have routed to this handler if method is not POST, but just in
@@ -26,7 +45,119 @@ func serveGraphQL(schema *graphql.Schema) func(w http.ResponseWriter, r *http.Re
 		}
 		r = r.WithContext(trace.WithGraphQLRequestName(r.Context(), requestName))
 
-		relayHandler.ServeHTTP(w, r)
+		// This is equivalent to relay.Handler, except that it adds error extensions (see below).
+		var params struct {
+			Query         string                 `json:"query"`
+			OperationName string                 `json:"operationName"`
+			Variables     map[string]interface{} `json:"variables"`
+			Extensions    struct {
+				PersistedQuery *persistedQuery `json:"persistedQuery"`
+			} `json:"extensions"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return nil
+		}
+
+		var response *graphql.Response
+		if pq := params.Extensions.PersistedQuery; pq != nil {
+			params.Query, response = resolvePersistedQuery(schema, pq, params.Query, params.OperationName, params.Variables)
+		}
+		if response == nil {
+			response = checkQueryCost(params.Query, params.OperationName, params.Variables)
+		}
+		if response == nil {
+			response = schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+		}
+
+		// The GraphQL library only adds the extensions of the exact error returned by a resolver,
+		// so add the error codes of wrapped and known errors (e.g. discussions.ErrorCodeNotFound).
+		for _, err := range response.Errors {
+			if err.ResolverError != nil && err.Extensions == nil {
+				err.Extensions = discussions.ErrorExtensions(err.ResolverError)
+			}
+		}
+
+		responseJSON, err := json.Marshal(response)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(responseJSON)
 		return nil
 	}
 }
+
+// persistedQuery is the persisted query extension of a GraphQL request, in the
+// format of Apollo's automatic persisted queries.
+type persistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// resolvePersistedQuery returns the query of a request that uses the
+// persisted query extension. If the request includes the query, it is
+// persisted for later requests. Otherwise the persisted query with the hash is
+// returned.
+//
+// Only queries that pass checkQueryCost and are valid are persisted, so that
+// clients can't use the cache to store arbitrary data.
+//
+// If the query can't be resolved, the error response is returned instead.
+func resolvePersistedQuery(schema *graphql.Schema, pq *persistedQuery, query, operationName string, variables map[string]interface{}) (string, *graphql.Response) {
+	if pq.Version != 1 {
+		return "", errorResponse("PERSISTED_QUERY_NOT_SUPPORTED", fmt.Sprintf("persisted query version %d is not supported", pq.Version))
+	}
+	if query == "" {
+		b, ok := persistedQueries.Get(pq.Sha256Hash)
+		if !ok {
+			return "", errorResponse("PERSISTED_QUERY_NOT_FOUND", "PersistedQueryNotFound")
+		}
+		return string(b), nil
+	}
+	if len(query) > maxPersistedQuerySize {
+		return "", errorResponse("PERSISTED_QUERY_TOO_LARGE", fmt.Sprintf("persisted query size %d exceeds the maximum of %d bytes", len(query), maxPersistedQuerySize))
+	}
+	hash := sha256.Sum256([]byte(query))
+	if hex.EncodeToString(hash[:]) != pq.Sha256Hash {
+		return "", errorResponse("PERSISTED_QUERY_HASH_MISMATCH", "persisted query hash does not match the query")
+	}
+	if response := checkQueryCost(query, operationName, variables); response != nil {
+		return "", response
+	}
+	if errs := schema.Validate(query); len(errs) > 0 {
+		return "", &graphql.Response{Errors: errs}
+	}
+	persistedQueries.Set(pq.Sha256Hash, []byte(query))
+	return query, nil
+}
+
+// checkQueryCost returns an error response if the estimated cost of the query
+// exceeds the limits in the site configuration. When limits are set, queries
+// whose cost can't be estimated (because they can't be parsed) are rejected.
+func checkQueryCost(query, operationName string, variables map[string]interface{}) *graphql.Response {
+	maxCost, maxDepth := conf.Get().GraphqlMaxQueryCost, conf.Get().GraphqlMaxQueryDepth
+	if maxCost <= 0 && maxDepth <= 0 {
+		return nil
+	}
+	cost, err := graphqlbackend.EstimateQueryCost(query, operationName, variables)
+	if err != nil {
+		return errorResponse("GRAPHQL_PARSE_FAILED", fmt.Sprintf("query cost can't be estimated: %s", err))
+	}
+	if maxDepth > 0 && cost.MaxDepth > maxDepth {
+		return errorResponse("QUERY_TOO_EXPENSIVE", fmt.Sprintf("query depth %d exceeds the maximum of %d (graphql.maxQueryDepth)", cost.MaxDepth, maxDepth))
+	}
+	if maxCost > 0 && cost.FieldCount > maxCost {
+		return errorResponse("QUERY_TOO_EXPENSIVE", fmt.Sprintf("estimated query cost %d exceeds the maximum of %d (graphql.maxQueryCost); request fewer nodes with smaller first arguments", cost.FieldCount, maxCost))
+	}
+	return nil
+}
+
+func errorResponse(code, message string) *graphql.Response {
+	return &graphql.Response{Errors: []*gqlerrors.QueryError{{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": code},
+	}}}
+}
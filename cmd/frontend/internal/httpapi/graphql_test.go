@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCheckQueryCost(t *testing.T) {
+	defer conf.Mock(nil)
+	const query = `{ discussionThreads(first: 100) { nodes { comments(first: 10) { nodes { contents } } } } }`
+
+	errorCode := func() interface{} {
+		t.Helper()
+		response := checkQueryCost(query, "", nil)
+		if response == nil {
+			return nil
+		}
+		return response.Errors[0].Extensions["code"]
+	}
+
+	conf.Mock(&conf.Unified{})
+	if code := errorCode(); code != nil {
+		t.Errorf("without limits: got error %v, want none", code)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{GraphqlMaxQueryCost: 1000}})
+	if code := errorCode(); code != "QUERY_TOO_EXPENSIVE" {
+		t.Errorf("over cost limit: got error %v, want QUERY_TOO_EXPENSIVE", code)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{GraphqlMaxQueryCost: 100000, GraphqlMaxQueryDepth: 4}})
+	if code := errorCode(); code != "QUERY_TOO_EXPENSIVE" {
+		t.Errorf("over depth limit: got error %v, want QUERY_TOO_EXPENSIVE", code)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{GraphqlMaxQueryCost: 100000, GraphqlMaxQueryDepth: 5}})
+	if code := errorCode(); code != nil {
+		t.Errorf("within limits: got error %v, want none", code)
+	}
+
+	// Queries whose cost can't be estimated are rejected.
+	if response := checkQueryCost("{", "", nil); response == nil || response.Errors[0].Extensions["code"] != "GRAPHQL_PARSE_FAILED" {
+		t.Errorf("invalid query: got %v, want GRAPHQL_PARSE_FAILED error", response)
+	}
+	conf.Mock(&conf.Unified{})
+	if response := checkQueryCost("{", "", nil); response != nil {
+		t.Errorf("invalid query without limits: got errors %v, want none", response.Errors)
+	}
+}
+
+func TestResolvePersistedQuery(t *testing.T) {
+	defer conf.Mock(nil)
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{GraphqlMaxQueryCost: 1000}})
+	gqlSchema, err := graphqlbackend.NewSchema(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	persistedQueryFor := func(query string) *persistedQuery {
+		hash := sha256.Sum256([]byte(query))
+		return &persistedQuery{Version: 1, Sha256Hash: hex.EncodeToString(hash[:])}
+	}
+
+	const query = `{ currentUser { username } }`
+	got, response := resolvePersistedQuery(gqlSchema, persistedQueryFor(query), query, "", nil)
+	if response != nil {
+		t.Fatalf("got errors %v, want none", response.Errors)
+	}
+	if got != query {
+		t.Errorf("got query %q, want %q", got, query)
+	}
+
+	tooLarge := `{ currentUser { username } }` + strings.Repeat(" ", maxPersistedQuerySize)
+	for name, test := range map[string]struct {
+		pq    *persistedQuery
+		query string
+	}{
+		"hash mismatch":       {pq: &persistedQuery{Version: 1, Sha256Hash: "0000"}, query: query},
+		"unsupported version": {pq: &persistedQuery{Version: 2, Sha256Hash: persistedQueryFor(query).Sha256Hash}, query: query},
+		"too large":           {pq: persistedQueryFor(tooLarge), query: tooLarge},
+		"too expensive":       {pq: persistedQueryFor(`{ users { nodes { username } } }`), query: `{ users { nodes { username } } }`},
+		"invalid":             {pq: persistedQueryFor(`{ noSuchField }`), query: `{ noSuchField }`},
+	} {
+		if _, response := resolvePersistedQuery(gqlSchema, test.pq, test.query, "", nil); response == nil {
+			t.Errorf("%s: got no errors, want error", name)
+		}
+	}
+}
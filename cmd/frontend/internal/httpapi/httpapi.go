@@ -47,6 +47,7 @@ func NewHandler(m *mux.Router, schema *graphql.Schema, githubWebhook http.Handle
 	m.Get(apirouter.RepoShield).Handler(trace.TraceRoute(handler(serveRepoShield)))
 
 	m.Get(apirouter.RepoRefresh).Handler(trace.TraceRoute(handler(serveRepoRefresh)))
+	m.Get(apirouter.DiscussionThreadsExport).Handler(trace.TraceRoute(handler(serveDiscussionThreadsExport)))
 
 	if githubWebhook != nil {
 		m.Get(apirouter.GitHubWebhooks).Handler(trace.TraceRoute(githubWebhook))
@@ -50,8 +50,52 @@ func NotifyCommentReported(reportedBy *types.User, thread *types.DiscussionThrea
 	})
 }
 
+// NotifyThreadReported should be invoked after a user has reported a thread.
+func NotifyThreadReported(reportedBy *types.User, thread *types.DiscussionThread) {
+	goroutine.Go(func() {
+		conf := conf.Get()
+		if conf.Discussions == nil || len(conf.Discussions.AbuseEmails) == 0 {
+			return
+		}
+
+		ctx := context.Background()
+
+		url, err := URLToInlineThread(ctx, thread)
+		if err != nil {
+			log15.Error("discussions: NotifyThreadReported:", "error", errors.Wrap(err, "URLToInlineThread"))
+			return
+		}
+		if url == nil {
+			return // can't generate a link to this thread target type
+		}
+		q := url.Query()
+		q.Set("utm_source", "abuse-email")
+		url.RawQuery = q.Encode()
+
+		if err := txemail.Send(ctx, txemail.Message{
+			To:       conf.Discussions.AbuseEmails,
+			Template: threadReportedEmailTemplate,
+			Data: struct {
+				ReportedBy string
+				URL        string
+			}{
+				ReportedBy: reportedBy.Username,
+				URL:        url.String(),
+			},
+		}); err != nil {
+			log15.Error("discussions: NotifyThreadReported", "error", err)
+		}
+	})
+}
+
 var commentReportedEmailTemplate = txemail.MustValidate(txtypes.Templates{
 	Subject: "User {{.ReportedBy}} has reported a comment on a discussion thread",
 	Text:    "View the comment and report: {{.URL}}",
 	HTML:    `<a href="{{.URL}}">View the comment and report</a>`,
 })
+
+var threadReportedEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: "User {{.ReportedBy}} has reported a discussion thread",
+	Text:    "View the thread and report: {{.URL}}",
+	HTML:    `<a href="{{.URL}}">View the thread and report</a>`,
+})
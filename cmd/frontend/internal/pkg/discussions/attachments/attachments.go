@@ -0,0 +1,159 @@
+// Package attachments stores the contents of the files attached to discussion
+// comments.
+package attachments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Store stores the contents of attachments, identified by their keys (see
+// Key).
+type Store interface {
+	// Put stores the contents read from r under the key. If reading from r
+	// fails, nothing is stored.
+	Put(ctx context.Context, key, contentType string, r io.Reader) error
+
+	// Open returns the contents stored under the key. The caller must close
+	// the returned reader.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// Key returns the key under which the contents of the attachment are stored.
+func Key(attachmentID int64) string {
+	return "discussions/attachments/" + strconv.FormatInt(attachmentID, 10)
+}
+
+// ErrDisabled is returned when attachments are not configured in the site
+// configuration.
+var ErrDisabled = errors.New("attachments are not enabled on this site (see discussions.attachments in the site configuration)")
+
+// MockStore, when non-nil, is returned by NewStore. It is used in tests.
+var MockStore Store
+
+// NewStore returns the attachment storage configured in the site
+// configuration.
+func NewStore(ctx context.Context) (Store, error) {
+	if MockStore != nil {
+		return MockStore, nil
+	}
+	c := config()
+	if c == nil {
+		return nil, ErrDisabled
+	}
+	switch c.Storage.Type {
+	case "filesystem":
+		if c.Storage.Directory == "" {
+			return nil, errors.New("discussions.attachments.storage.directory must be set for filesystem storage")
+		}
+		return &filesystemStore{dir: c.Storage.Directory}, nil
+	case "s3":
+		return newS3Store(&c.Storage)
+	case "gcs":
+		return newGCSStore(ctx, &c.Storage)
+	default:
+		return nil, fmt.Errorf("unknown attachment storage type %q", c.Storage.Type)
+	}
+}
+
+// Default limits, used if they are not set in the site configuration.
+const defaultMaxSize = 10 * 1024 * 1024
+
+var defaultAllowedContentTypes = []string{"image/png", "image/jpeg", "image/gif", "text/plain"}
+
+func config() *schema.DiscussionsAttachments {
+	if dc := conf.Get().Discussions; dc != nil {
+		return dc.Attachments
+	}
+	return nil
+}
+
+// CheckAllowed returns an error if a file with the content type and size may
+// not be attached, according to the limits in the site configuration.
+func CheckAllowed(contentType string, size int64) error {
+	c := config()
+	if c == nil {
+		return ErrDisabled
+	}
+	if size <= 0 {
+		return errors.New("attachments must not be empty")
+	}
+	maxSize := int64(c.MaxSize)
+	if maxSize == 0 {
+		maxSize = defaultMaxSize
+	}
+	if size > maxSize {
+		return fmt.Errorf("attachments must be at most %d bytes", maxSize)
+	}
+	allowed := c.AllowedContentTypes
+	if len(allowed) == 0 {
+		allowed = defaultAllowedContentTypes
+	}
+	if !contentTypeAllowed(allowed, contentType) {
+		return fmt.Errorf("attachments of type %q are not allowed (allowed types: %s)", contentType, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+func contentTypeAllowed(allowed []string, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, a := range allowed {
+		if a == mediaType {
+			return true
+		}
+		if strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsImage reports whether the content type is one of the image types that
+// clients may display inline.
+//
+// SVG images are intentionally excluded, because they can contain scripts.
+func IsImage(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+		return true
+	}
+	return false
+}
+
+// ErrSizeMismatch is returned by reads from a reader created by
+// NewSizeReader when the underlying reader's size differs from the expected
+// size.
+var ErrSizeMismatch = errors.New("the size of the uploaded file does not match the size of the attachment")
+
+// NewSizeReader returns a reader that reads from r, and fails with
+// ErrSizeMismatch if r does not contain exactly size bytes. Passing it to
+// Store.Put ensures that content with an unexpected size is never stored.
+func NewSizeReader(r io.Reader, size int64) io.Reader {
+	return &sizeReader{r: r, remaining: size}
+}
+
+type sizeReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (r *sizeReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 || (err == io.EOF && r.remaining != 0) {
+		return n, ErrSizeMismatch
+	}
+	return n, err
+}
@@ -0,0 +1,98 @@
+package attachments
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCheckAllowed(t *testing.T) {
+	defer conf.Mock(nil)
+
+	conf.Mock(&conf.Unified{})
+	if err := CheckAllowed("image/png", 1); err != ErrDisabled {
+		t.Errorf("got error %v, want %v", err, ErrDisabled)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{Attachments: &schema.DiscussionsAttachments{
+			MaxSize:             100,
+			AllowedContentTypes: []string{"image/*", "text/plain"},
+		}},
+	}})
+	tests := map[string]struct {
+		contentType string
+		size        int64
+		wantErr     bool
+	}{
+		"allowed":              {contentType: "text/plain", size: 100},
+		"with parameters":      {contentType: "text/plain; charset=utf-8", size: 1},
+		"wildcard":             {contentType: "image/png", size: 1},
+		"disallowed type":      {contentType: "application/zip", size: 1, wantErr: true},
+		"wildcard prefix only": {contentType: "imagex/png", size: 1, wantErr: true},
+		"too large":            {contentType: "text/plain", size: 101, wantErr: true},
+		"empty":                {contentType: "text/plain", size: 0, wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CheckAllowed(test.contentType, test.size)
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSizeReader(t *testing.T) {
+	for _, test := range []struct {
+		contents string
+		size     int64
+		wantErr  error
+	}{
+		{contents: "abc", size: 3},
+		{contents: "abc", size: 2, wantErr: ErrSizeMismatch},
+		{contents: "abc", size: 4, wantErr: ErrSizeMismatch},
+	} {
+		_, err := ioutil.ReadAll(NewSizeReader(strings.NewReader(test.contents), test.size))
+		if err != test.wantErr {
+			t.Errorf("%q with size %d: got error %v, want %v", test.contents, test.size, err, test.wantErr)
+		}
+	}
+}
+
+func TestFilesystemStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "attachments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+	s := &filesystemStore{dir: dir}
+
+	if err := s.Put(ctx, Key(1), "text/plain", strings.NewReader("hello")); err != nil {
+		t.Fatal(err)
+	}
+	f, err := s.Open(ctx, Key(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if b, err := ioutil.ReadAll(f); err != nil {
+		t.Fatal(err)
+	} else if string(b) != "hello" {
+		t.Errorf("got contents %q, want %q", b, "hello")
+	}
+
+	// A failed upload stores nothing.
+	if err := s.Put(ctx, Key(2), "text/plain", NewSizeReader(strings.NewReader("hello"), 1)); err != ErrSizeMismatch {
+		t.Errorf("got error %v, want %v", err, ErrSizeMismatch)
+	}
+	if _, err := s.Open(ctx, Key(2)); !os.IsNotExist(err) {
+		t.Errorf("got error %v, want not exist", err)
+	}
+}
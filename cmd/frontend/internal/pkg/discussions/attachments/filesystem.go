@@ -0,0 +1,45 @@
+package attachments
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// filesystemStore stores attachments as files in a directory.
+type filesystemStore struct {
+	dir string
+}
+
+func (s *filesystemStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+func (s *filesystemStore) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that a failed upload never leaves a
+	// partial file behind.
+	f, err := ioutil.TempFile(filepath.Dir(path), ".upload-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *filesystemStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(s.path(key))
+}
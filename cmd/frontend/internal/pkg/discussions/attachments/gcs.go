@@ -0,0 +1,51 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/sourcegraph/sourcegraph/schema"
+	"google.golang.org/api/option"
+)
+
+// gcsStore stores attachments as objects in a Google Cloud Storage bucket.
+type gcsStore struct {
+	bucket *storage.BucketHandle
+}
+
+func newGCSStore(ctx context.Context, c *schema.DiscussionsAttachmentsStorage) (*gcsStore, error) {
+	if c.Bucket == "" {
+		return nil, errors.New("discussions.attachments.storage.bucket must be set for GCS storage")
+	}
+	var opts []option.ClientOption
+	if c.CredentialsJSON != "" {
+		opts = append(opts, option.WithCredentialsJSON([]byte(c.CredentialsJSON)))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &gcsStore{bucket: client.Bucket(c.Bucket)}, nil
+}
+
+func (s *gcsStore) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	// Canceling the context before closing the writer abandons the upload, so
+	// that no object is created if reading from r fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := s.bucket.Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := io.Copy(w, r); err != nil {
+		cancel()
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (s *gcsStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.bucket.Object(key).NewReader(ctx)
+}
@@ -0,0 +1,61 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/defaults"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/s3manager"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// s3Store stores attachments as objects in an Amazon S3 bucket.
+type s3Store struct {
+	bucket   string
+	client   *s3.Client
+	uploader *s3manager.Uploader
+}
+
+func newS3Store(c *schema.DiscussionsAttachmentsStorage) (*s3Store, error) {
+	if c.Bucket == "" || c.Region == "" {
+		return nil, errors.New("discussions.attachments.storage.bucket and region must be set for S3 storage")
+	}
+	awsConfig := defaults.Config()
+	awsConfig.Region = c.Region
+	awsConfig.Credentials = aws.StaticCredentialsProvider{
+		Value: aws.Credentials{
+			AccessKeyID:     c.AccessKeyID,
+			SecretAccessKey: c.SecretAccessKey,
+			Source:          "sourcegraph-site-configuration",
+		},
+	}
+	return &s3Store{
+		bucket:   c.Bucket,
+		client:   s3.New(awsConfig),
+		uploader: s3manager.NewUploader(awsConfig),
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+		Body:        r,
+	})
+	return err
+}
+
+func (s *s3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}).Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
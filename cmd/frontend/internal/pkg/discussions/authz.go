@@ -0,0 +1,160 @@
+package discussions
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// The Check* functions in this file define who may change threads and
+// comments. Mutations call them to enforce the rules, and the viewerCan*
+// GraphQL fields call them so that clients can show only the actions that the
+// current user is allowed to perform.
+//
+// Besides site admins and authors, users with a role (see
+// types.DiscussionRoleKind) in the thread's repository may perform some
+// actions:
+//
+//  - Contributors may comment on locked threads.
+//  - Triagers may also label, archive, assign, and set the milestone of threads, and
+//    mark them as duplicates or dependencies of other threads.
+//  - Maintainers may also lock, unlock and delete threads, and delete comments.
+//
+// Archived threads are read-only: no one may update, triage or comment on them
+// until they are unarchived.
+//
+// Roles in an organization do not apply to threads. In particular, they are not
+// taken from the organization of the thread's milestone, because triagers and
+// authors can set the milestone to one of their own organization.
+//
+// 🚨 SECURITY: They assume that the current user can view the thread, i.e. that
+// it was obtained with DiscussionThreads.Get or List (which enforce repository
+// permissions).
+
+// CheckCanUpdateThread returns an error if the current user may not update the
+// thread's title or contents. Only site admins and the thread's author may
+// update them, and only while the thread is not archived.
+func CheckCanUpdateThread(ctx context.Context, thread *types.DiscussionThread) error {
+	if thread.ArchivedAt != nil {
+		return ErrThreadArchived
+	}
+	return backend.CheckSiteAdminOrSameUser(ctx, thread.AuthorUserID)
+}
+
+// CheckCanTriageThread returns an error if the current user may not label,
+// assign, or set the milestone of the thread, or mark it as a duplicate or
+// dependency of another thread. Only site admins, the thread's author and
+// triagers may triage it, and only while the thread is not archived.
+func CheckCanTriageThread(ctx context.Context, thread *types.DiscussionThread) error {
+	if thread.ArchivedAt != nil {
+		return ErrThreadArchived
+	}
+	return CheckCanArchiveThread(ctx, thread)
+}
+
+// CheckCanArchiveThread returns an error if the current user may not archive or
+// unarchive the thread. The same users who may triage the thread may archive
+// it, and unarchive it (after which they may triage it again).
+func CheckCanArchiveThread(ctx context.Context, thread *types.DiscussionThread) error {
+	return checkViewerHasRole(ctx, thread, types.DiscussionRoleTriager, backend.CheckSiteAdminOrSameUser(ctx, thread.AuthorUserID))
+}
+
+// CheckCanLockThread returns an error if the current user may not lock or
+// unlock the thread. Only site admins and maintainers may lock threads.
+func CheckCanLockThread(ctx context.Context, thread *types.DiscussionThread) error {
+	return checkViewerHasRole(ctx, thread, types.DiscussionRoleMaintainer, backend.CheckCurrentUserIsSiteAdmin(ctx))
+}
+
+// CheckCanDeleteThread returns an error if the current user may not delete the
+// thread. Only site admins and maintainers may delete threads.
+func CheckCanDeleteThread(ctx context.Context, thread *types.DiscussionThread) error {
+	return checkViewerHasRole(ctx, thread, types.DiscussionRoleMaintainer, backend.CheckCurrentUserIsSiteAdmin(ctx))
+}
+
+// CheckCanComment returns an error if the current user may not add comments to
+// the thread. No one may comment on archived threads. Signed-in users may
+// comment on threads that are not locked, and site admins and contributors may
+// also comment on locked threads.
+//
+// It does not check rate limits, or whether the user has a verified email
+// address.
+func CheckCanComment(ctx context.Context, thread *types.DiscussionThread) error {
+	if thread.ArchivedAt != nil {
+		return ErrThreadArchived
+	}
+	if thread.LockedAt != nil {
+		err := checkViewerHasRole(ctx, thread, types.DiscussionRoleContributor, backend.CheckCurrentUserIsSiteAdmin(ctx))
+		if err == backend.ErrMustBeSiteAdmin {
+			return ErrThreadLocked
+		} else if err == backend.ErrNotAuthenticated {
+			return ErrNoCurrentUser
+		}
+		return err
+	}
+	if _, err := db.Users.GetByCurrentAuthUser(ctx); err == db.ErrNoCurrentUser || errcode.IsNotFound(err) {
+		return ErrNoCurrentUser
+	} else if err != nil {
+		return errors.Wrap(err, "Users.GetByCurrentAuthUser")
+	}
+	return nil
+}
+
+// CheckCanUpdateComment returns an error if the current user may not update
+// the contents of the comment. Only site admins and the comment's author may
+// update it.
+func CheckCanUpdateComment(ctx context.Context, comment *types.DiscussionComment) error {
+	return backend.CheckSiteAdminOrSameUser(ctx, comment.AuthorUserID)
+}
+
+// CheckCanDeleteComment returns an error if the current user may not delete the
+// comment on the thread. Only site admins and maintainers may delete comments.
+func CheckCanDeleteComment(ctx context.Context, thread *types.DiscussionThread, comment *types.DiscussionComment) error {
+	return checkViewerHasRole(ctx, thread, types.DiscussionRoleMaintainer, backend.CheckCurrentUserIsSiteAdmin(ctx))
+}
+
+// checkViewerHasRole returns nil if err is nil or if the current user has (at
+// least) the role for the thread. Otherwise, it returns err.
+//
+// Only authorization errors are overridden by roles. err is returned as-is if it
+// is another kind of error, such as a database error.
+func checkViewerHasRole(ctx context.Context, thread *types.DiscussionThread, role types.DiscussionRoleKind, err error) error {
+	if err == nil || Code(err) != ErrorCodeForbidden {
+		return err
+	}
+	userID := actor.FromContext(ctx).UID
+	if userID == 0 {
+		return err
+	}
+	has, roleErr := discussionRole(ctx, userID, thread)
+	if roleErr != nil {
+		return roleErr
+	}
+	if has.Includes(role) {
+		return nil
+	}
+	return err
+}
+
+// discussionRole returns the highest role that the user has in the thread's
+// repository, or the empty string if the user has no role there.
+func discussionRole(ctx context.Context, userID int32, thread *types.DiscussionThread) (types.DiscussionRoleKind, error) {
+	if thread.TargetRepo == nil {
+		return "", nil
+	}
+	roles, err := db.DiscussionRoles.List(ctx, &db.DiscussionRolesListOptions{UserID: userID, RepoID: thread.TargetRepo.RepoID})
+	if err != nil {
+		return "", errors.Wrap(err, "DiscussionRoles.List")
+	}
+	var highest types.DiscussionRoleKind
+	for _, role := range roles {
+		if !highest.Includes(role.Role) {
+			highest = role.Role
+		}
+	}
+	return highest, nil
+}
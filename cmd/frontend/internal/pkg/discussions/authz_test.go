@@ -0,0 +1,131 @@
+package discussions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestAuthz(t *testing.T) {
+	users := map[int32]*types.User{
+		1: {ID: 1, Username: "author"},
+		2: {ID: 2, Username: "other"},
+		3: {ID: 3, Username: "admin", SiteAdmin: true},
+		4: {ID: 4, Username: "contributor"},
+		5: {ID: 5, Username: "triager"},
+		6: {ID: 6, Username: "maintainer"},
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		if user, ok := users[actor.FromContext(ctx).UID]; ok {
+			return user, nil
+		}
+		return nil, db.ErrNoCurrentUser
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) { return users[id], nil }
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	// Users 4 and 5 have roles in the thread's repository. User 6 has a role
+	// in the organization of the thread's milestone, which does not apply to
+	// the thread.
+	const (
+		repoID      api.RepoID = 10
+		milestoneID int64      = 20
+		orgID       int32      = 30
+	)
+	db.Mocks.DiscussionMilestones.Get = func(id int64) (*types.DiscussionMilestone, error) {
+		org := orgID
+		return &types.DiscussionMilestone{ID: id, OrgID: &org}, nil
+	}
+	db.Mocks.DiscussionRoles.List = func(_ context.Context, opts *db.DiscussionRolesListOptions) ([]*types.DiscussionRole, error) {
+		switch {
+		case opts.RepoID == repoID && opts.UserID == 4:
+			return []*types.DiscussionRole{{UserID: 4, Role: types.DiscussionRoleContributor}}, nil
+		case opts.RepoID == repoID && opts.UserID == 5:
+			return []*types.DiscussionRole{{UserID: 5, Role: types.DiscussionRoleTriager}}, nil
+		case opts.OrgID == orgID && opts.UserID == 6:
+			return []*types.DiscussionRole{{UserID: 6, Role: types.DiscussionRoleMaintainer}}, nil
+		}
+		return nil, nil
+	}
+
+	lockedAt := time.Now()
+	milestone := milestoneID
+	thread := &types.DiscussionThread{ID: 1, AuthorUserID: 1, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: repoID}, MilestoneID: &milestone}
+	lockedThread := &types.DiscussionThread{ID: 2, AuthorUserID: 1, TargetRepo: thread.TargetRepo, MilestoneID: &milestone, LockedAt: &lockedAt}
+	comment := &types.DiscussionComment{ID: 1, ThreadID: 1, AuthorUserID: 1}
+
+	checks := map[string]func(context.Context) error{
+		"update thread":         func(ctx context.Context) error { return CheckCanUpdateThread(ctx, thread) },
+		"triage thread":         func(ctx context.Context) error { return CheckCanTriageThread(ctx, thread) },
+		"archive thread":        func(ctx context.Context) error { return CheckCanArchiveThread(ctx, thread) },
+		"lock thread":           func(ctx context.Context) error { return CheckCanLockThread(ctx, thread) },
+		"delete thread":         func(ctx context.Context) error { return CheckCanDeleteThread(ctx, thread) },
+		"comment":               func(ctx context.Context) error { return CheckCanComment(ctx, thread) },
+		"comment locked thread": func(ctx context.Context) error { return CheckCanComment(ctx, lockedThread) },
+		"update comment":        func(ctx context.Context) error { return CheckCanUpdateComment(ctx, comment) },
+		"delete comment":        func(ctx context.Context) error { return CheckCanDeleteComment(ctx, thread, comment) },
+	}
+	all := map[string]bool{}
+	for name := range checks {
+		all[name] = true
+	}
+	tests := map[int32]map[string]bool{
+		0: {},
+		1: {"update thread": true, "triage thread": true, "archive thread": true, "comment": true, "update comment": true},
+		2: {"comment": true},
+		3: all,
+		4: {"comment": true, "comment locked thread": true},
+		5: {"triage thread": true, "archive thread": true, "comment": true, "comment locked thread": true},
+		6: {"comment": true},
+	}
+	for userID, allowed := range tests {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: userID})
+		for name, check := range checks {
+			err := check(ctx)
+			if (err == nil) != allowed[name] {
+				t.Errorf("user %d: %s: got error %v, want allowed %v", userID, name, err, allowed[name])
+			}
+			if err != nil {
+				if code := Code(err); code != ErrorCodeForbidden && code != ErrorCodeInvalidState {
+					t.Errorf("user %d: %s: got code %q for error %v, want FORBIDDEN or INVALID_STATE", userID, name, code, err)
+				}
+			}
+		}
+	}
+
+	// No one may comment on, update or triage archived threads, not even site
+	// admins, but they may still be unarchived.
+	archivedThread := &types.DiscussionThread{ID: 3, AuthorUserID: 1, TargetRepo: thread.TargetRepo, ArchivedAt: &lockedAt}
+	for userID, allowed := range tests {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: userID})
+		for name, check := range map[string]func(context.Context, *types.DiscussionThread) error{
+			"comment":       CheckCanComment,
+			"update thread": CheckCanUpdateThread,
+			"triage thread": CheckCanTriageThread,
+		} {
+			if err := check(ctx, archivedThread); err != ErrThreadArchived {
+				t.Errorf("user %d: %s archived: got error %v, want %v", userID, name, err, ErrThreadArchived)
+			}
+		}
+		if err := CheckCanArchiveThread(ctx, archivedThread); (err == nil) != allowed["archive thread"] {
+			t.Errorf("user %d: unarchive thread: got error %v, want allowed %v", userID, err, allowed["archive thread"])
+		}
+	}
+}
+
+func TestDiscussionRoleKind_Includes(t *testing.T) {
+	if !types.DiscussionRoleMaintainer.Includes(types.DiscussionRoleTriager) {
+		t.Error("want maintainer to include triager")
+	}
+	if types.DiscussionRoleContributor.Includes(types.DiscussionRoleTriager) {
+		t.Error("want contributor not to include triager")
+	}
+	if types.DiscussionRoleKind("").Includes(types.DiscussionRoleKind("")) {
+		t.Error("want no role not to include itself")
+	}
+}
@@ -0,0 +1,242 @@
+package discussions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/slack"
+	"github.com/sourcegraph/sourcegraph/schema"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// The kinds of thread activity that notification channels can be notified
+// of (see the "discussions.notificationChannels" site configuration).
+const (
+	channelEventThreadCreated  = "threadCreated"
+	channelEventThreadArchived = "threadArchived"
+	channelEventCommented      = "commented"
+)
+
+// defaultChannelTemplates are the message templates used for channels that do
+// not configure their own.
+var defaultChannelTemplates = map[string]*template.Template{
+	channelEventThreadCreated:  template.Must(template.New("").Parse(`@{{.ActorUsername}} started a discussion{{with .RepoName}} in {{.}}{{end}}: {{.ThreadTitle}} {{.URL}}`)),
+	channelEventThreadArchived: template.Must(template.New("").Parse(`@{{.ActorUsername}} archived the discussion{{with .RepoName}} in {{.}}{{end}}: {{.ThreadTitle}} {{.URL}}`)),
+	channelEventCommented:      template.Must(template.New("").Parse(`@{{.ActorUsername}} commented on the discussion{{with .RepoName}} in {{.}}{{end}}: {{.ThreadTitle}} {{.URL}}`)),
+}
+
+// channelMessageData is the data that notification channel message templates
+// are executed with.
+type channelMessageData struct {
+	Event           string
+	ActorUsername   string
+	ThreadTitle     string
+	RepoName        string
+	URL             string
+	CommentContents string
+}
+
+// A notificationChannel is a chat channel that messages about thread
+// activity are posted to.
+type notificationChannel interface {
+	post(ctx context.Context, text string) error
+}
+
+// newNotificationChannel returns the notificationChannel for the channel's
+// configuration.
+func newNotificationChannel(c *schema.DiscussionsNotificationChannel) (notificationChannel, error) {
+	switch c.Type {
+	case "slack":
+		return slackChannel{webhookURL: c.WebhookURL}, nil
+	case "teams":
+		return teamsChannel{webhookURL: c.WebhookURL}, nil
+	default:
+		return nil, fmt.Errorf("unknown discussions notification channel type %q", c.Type)
+	}
+}
+
+// slackChannel posts messages to a Slack channel through an incoming webhook.
+type slackChannel struct{ webhookURL string }
+
+func (c slackChannel) post(ctx context.Context, text string) error {
+	return slack.Post(&slack.Payload{
+		Username:  "discussions-bot",
+		IconEmoji: ":speech_balloon:",
+		Text:      text,
+	}, c.webhookURL)
+}
+
+// teamsChannel posts messages to a Microsoft Teams channel through an incoming
+// webhook.
+type teamsChannel struct{ webhookURL string }
+
+func (c teamsChannel) post(ctx context.Context, text string) error {
+	payload, err := json.Marshal(map[string]string{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"text":     text,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := webhookClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "teams: http request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("teams: unexpected HTTP response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// channelTemplate returns the message template for the event on the channel.
+func channelTemplate(c *schema.DiscussionsNotificationChannel, event string) (*template.Template, error) {
+	if c.Template == "" {
+		return defaultChannelTemplates[event], nil
+	}
+	return template.New("").Parse(c.Template)
+}
+
+// channelWantsEvent reports whether the channel is notified of the event on a
+// thread in the repository (which is empty for threads without a repository
+// target).
+func channelWantsEvent(c *schema.DiscussionsNotificationChannel, event, repoName string) bool {
+	if len(c.Events) > 0 && !stringsContain(c.Events, event) {
+		return false
+	}
+	if len(c.Repositories) > 0 && !stringsContain(c.Repositories, repoName) {
+		return false
+	}
+	return true
+}
+
+func stringsContain(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyChannels posts a message about the event to every configured
+// notification channel that wants it.
+//
+// It returns immediately and does not block.
+func notifyChannels(event string, actorUserID int32, threadID int64, comment *types.DiscussionComment) {
+	dc := conf.Get().Discussions
+	if dc == nil || len(dc.NotificationChannels) == 0 {
+		return
+	}
+	channels := dc.NotificationChannels
+	goroutine.Go(func() {
+		ctx := context.Background()
+		thread, err := db.DiscussionThreads.Get(ctx, threadID)
+		if err != nil {
+			log15.Error("discussions: notifyChannels: DiscussionThreads.Get", "threadID", threadID, "error", err)
+			return
+		}
+		data, repoName, err := newChannelMessageData(ctx, event, actorUserID, thread, comment)
+		if err != nil {
+			log15.Error("discussions: notifyChannels", "threadID", threadID, "event", event, "error", err)
+			return
+		}
+		for _, c := range channels {
+			if !channelWantsEvent(c, event, repoName) {
+				continue
+			}
+			if err := notifyChannel(ctx, c, data); err != nil {
+				log15.Error("discussions: notifyChannel", "type", c.Type, "threadID", threadID, "event", event, "error", err)
+			}
+		}
+	})
+}
+
+func notifyChannel(ctx context.Context, c *schema.DiscussionsNotificationChannel, data *channelMessageData) error {
+	channel, err := newNotificationChannel(c)
+	if err != nil {
+		return err
+	}
+	tmpl, err := channelTemplate(c, data.Event)
+	if err != nil {
+		return errors.Wrap(err, "parsing template")
+	}
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, data); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
+	return channel.post(ctx, strings.TrimSpace(text.String()))
+}
+
+// newChannelMessageData returns the message template data for the event, and
+// the full name of the thread's repository (if any).
+func newChannelMessageData(ctx context.Context, event string, actorUserID int32, thread *types.DiscussionThread, comment *types.DiscussionComment) (*channelMessageData, string, error) {
+	data := &channelMessageData{
+		Event:       event,
+		ThreadTitle: thread.Title,
+	}
+	if comment != nil {
+		data.CommentContents = comment.Contents
+	}
+
+	actor, err := db.Users.GetByID(ctx, actorUserID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Actor: GetByID")
+	}
+	data.ActorUsername = actor.Username
+
+	var repoName string
+	if thread.TargetRepo != nil {
+		repo, err := db.Repos.Get(ctx, thread.TargetRepo.RepoID)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "db.Repos.Get")
+		}
+		repoName = string(repo.Name)
+		data.RepoName = shortenRepoName(repo.Name)
+	}
+
+	url, err := urlToInline(ctx, thread, comment)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "urlToInline")
+	}
+	if url != nil {
+		data.URL = globals.ExternalURL().ResolveReference(url).String()
+	}
+	return data, repoName, nil
+}
+
+func init() {
+	conf.ContributeValidator(validateNotificationChannels)
+}
+
+func validateNotificationChannels(c conf.Unified) (problems conf.Problems) {
+	if c.Discussions == nil {
+		return nil
+	}
+	for i, channel := range c.Discussions.NotificationChannels {
+		if channel.Template == "" {
+			continue
+		}
+		if _, err := template.New("").Parse(channel.Template); err != nil {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("discussions.notificationChannels[%d].template is invalid: %s", i, err)))
+		}
+	}
+	return problems
+}
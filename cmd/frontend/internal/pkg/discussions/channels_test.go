@@ -0,0 +1,93 @@
+package discussions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestChannelWantsEvent(t *testing.T) {
+	tests := map[string]struct {
+		channel  schema.DiscussionsNotificationChannel
+		event    string
+		repoName string
+		want     bool
+	}{
+		"all events":           {event: channelEventCommented, repoName: "r", want: true},
+		"matching event":       {channel: schema.DiscussionsNotificationChannel{Events: []string{channelEventCommented}}, event: channelEventCommented, want: true},
+		"other event":          {channel: schema.DiscussionsNotificationChannel{Events: []string{channelEventThreadCreated}}, event: channelEventCommented, want: false},
+		"matching repository":  {channel: schema.DiscussionsNotificationChannel{Repositories: []string{"r"}}, event: channelEventCommented, repoName: "r", want: true},
+		"other repository":     {channel: schema.DiscussionsNotificationChannel{Repositories: []string{"r"}}, event: channelEventCommented, repoName: "s", want: false},
+		"no target repository": {channel: schema.DiscussionsNotificationChannel{Repositories: []string{"r"}}, event: channelEventCommented, want: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := channelWantsEvent(&test.channel, test.event, test.repoName); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNotifyChannel(t *testing.T) {
+	data := &channelMessageData{
+		Event:         channelEventThreadCreated,
+		ActorUsername: "alice",
+		ThreadTitle:   "Flaky test",
+		RepoName:      "gorilla/mux",
+		URL:           "https://example.com/t",
+	}
+	tests := map[string]struct {
+		channelType string
+		template    string
+		wantText    string
+	}{
+		"slack default template": {
+			channelType: "slack",
+			wantText:    "@alice started a discussion in gorilla/mux: Flaky test https://example.com/t",
+		},
+		"teams custom template": {
+			channelType: "teams",
+			template:    "{{.ThreadTitle}} ({{.Event}})",
+			wantText:    "Flaky test (threadCreated)",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got map[string]interface{}
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			defer ts.Close()
+
+			channel := &schema.DiscussionsNotificationChannel{Type: test.channelType, WebhookURL: ts.URL, Template: test.template}
+			if err := notifyChannel(context.Background(), channel, data); err != nil {
+				t.Fatal(err)
+			}
+			if got["text"] != test.wantText {
+				t.Errorf("got message %v, want text %q", got, test.wantText)
+			}
+		})
+	}
+}
+
+func TestValidateNotificationChannels(t *testing.T) {
+	c := conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{
+			NotificationChannels: []*schema.DiscussionsNotificationChannel{
+				{Type: "slack", WebhookURL: "https://example.com", Template: "{{.URL}}"},
+				{Type: "teams", WebhookURL: "https://example.com", Template: "{{.URL"},
+			},
+		},
+	}}
+	if problems := validateNotificationChannels(c); len(problems) != 1 {
+		t.Errorf("got problems %v, want 1 problem", problems)
+	}
+}
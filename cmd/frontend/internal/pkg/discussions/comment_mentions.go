@@ -0,0 +1,172 @@
+package discussions
+
+import (
+	"context"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/mentions"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// resolveMentions looks up the users and organizations referred to by the
+// given @mention names. Names that refer to neither are ignored.
+func resolveMentions(ctx context.Context, names []string) (users []*types.User, orgs []*types.Org, err error) {
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		user, err := db.Users.GetByUsername(ctx, name)
+		if err == nil {
+			users = append(users, user)
+			continue
+		}
+		if !errcode.IsNotFound(err) {
+			return nil, nil, errors.Wrap(err, "Users.GetByUsername")
+		}
+		org, err := db.Orgs.GetByName(ctx, name)
+		if err == nil {
+			orgs = append(orgs, org)
+			continue
+		}
+		if _, ok := err.(*db.OrgNotFoundError); !ok {
+			return nil, nil, errors.Wrap(err, "Orgs.GetByName")
+		}
+		// Not a mention of an actual user or organization.
+	}
+	return users, orgs, nil
+}
+
+// expandMentions returns the IDs of the users mentioned directly and of the
+// members of the mentioned organizations.
+func expandMentions(ctx context.Context, userIDs, orgIDs []int32) ([]int32, error) {
+	var (
+		ids []int32
+		set = make(map[int32]struct{})
+	)
+	add := func(userID int32) {
+		if _, ok := set[userID]; !ok {
+			set[userID] = struct{}{}
+			ids = append(ids, userID)
+		}
+	}
+	for _, userID := range userIDs {
+		add(userID)
+	}
+	for _, orgID := range orgIDs {
+		members, err := db.OrgMembers.GetByOrgID(ctx, orgID)
+		if err != nil {
+			return nil, errors.Wrap(err, "OrgMembers.GetByOrgID")
+		}
+		for _, member := range members {
+			add(member.UserID)
+		}
+	}
+	return ids, nil
+}
+
+// storeCommentMentions parses the @mentions in the comment's contents and
+// stores them, replacing any previously stored mentions. It returns the IDs of
+// the users who are mentioned by the comment now but were not before (see
+// expandMentions).
+func storeCommentMentions(ctx context.Context, comment *types.DiscussionComment) (_ []int32, err error) {
+	tr, ctx := trace.New(ctx, "discussions.storeCommentMentions", "")
+	tr.LogFields(otlog.Int64("threadID", comment.ThreadID), otlog.Int64("commentID", comment.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	previous, err := db.DiscussionCommentMentions.List(ctx, comment.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentMentions.List")
+	}
+	var previousUserIDs, previousOrgIDs []int32
+	for _, mention := range previous {
+		if mention.UserID != nil {
+			previousUserIDs = append(previousUserIDs, *mention.UserID)
+		}
+		if mention.OrgID != nil {
+			previousOrgIDs = append(previousOrgIDs, *mention.OrgID)
+		}
+	}
+	previouslyMentioned, err := expandMentions(ctx, previousUserIDs, previousOrgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	users, orgs, err := resolveMentions(ctx, mentions.Parse(comment.Contents))
+	if err != nil {
+		return nil, err
+	}
+	var userIDs, orgIDs []int32
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	for _, org := range orgs {
+		orgIDs = append(orgIDs, org.ID)
+	}
+	if err := db.DiscussionCommentMentions.Set(ctx, comment.ID, userIDs, orgIDs); err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentMentions.Set")
+	}
+	mentioned, err := expandMentions(ctx, userIDs, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	wasMentioned := make(map[int32]struct{}, len(previouslyMentioned))
+	for _, userID := range previouslyMentioned {
+		wasMentioned[userID] = struct{}{}
+	}
+	var added []int32
+	for _, userID := range mentioned {
+		if _, ok := wasMentioned[userID]; !ok {
+			added = append(added, userID)
+		}
+	}
+	return added, nil
+}
+
+// StoreCommentMentions should be invoked after a new comment has been created,
+// in order to record the users and organizations it mentions. Notifying them
+// is handled by NotifyNewThread and NotifyNewComment.
+//
+// Errors are logged and not returned, as failing to record the mentions should
+// not fail the creation of the comment.
+func StoreCommentMentions(ctx context.Context, newComment *types.DiscussionComment) {
+	if _, err := storeCommentMentions(ctx, newComment); err != nil {
+		log15.Error("discussions: storing comment mentions", "comment", newComment.ID, "error", err)
+	}
+}
+
+// UpdateCommentMentions should be invoked after the contents of a comment have
+// been updated, in order to record the users and organizations it now
+// mentions and to notify users who are newly mentioned.
+//
+// Errors are logged and not returned, as failing to record the mentions should
+// not fail the update of the comment.
+func UpdateCommentMentions(ctx context.Context, thread *types.DiscussionThread, updatedComment *types.DiscussionComment) {
+	added, err := storeCommentMentions(ctx, updatedComment)
+	if err != nil {
+		log15.Error("discussions: storing comment mentions", "comment", updatedComment.ID, "error", err)
+		return
+	}
+	if len(added) == 0 {
+		return
+	}
+	notifyMentions(&notifier{
+		typ:               newCommentNotification,
+		eventAuthorUserID: updatedComment.AuthorUserID,
+		thread:            thread,
+		comment:           updatedComment,
+		template:          newCommentEmailTemplate,
+		recipients:        added,
+	})
+}
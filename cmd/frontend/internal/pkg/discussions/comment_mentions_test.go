@@ -0,0 +1,82 @@
+package discussions
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestStoreCommentMentions(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	ctx := context.Background()
+
+	users := map[string]int32{"alice": 1, "bob": 2, "carol": 3}
+	db.Mocks.Users.GetByUsername = func(_ context.Context, username string) (*types.User, error) {
+		if id, ok := users[username]; ok {
+			return &types.User{ID: id, Username: username}, nil
+		}
+		return nil, db.NewUserNotFoundError(0)
+	}
+	db.Mocks.Orgs.GetByName = func(_ context.Context, name string) (*types.Org, error) {
+		if name == "acme" {
+			return &types.Org{ID: 10, Name: name}, nil
+		}
+		return nil, &db.OrgNotFoundError{Message: name}
+	}
+	db.Mocks.OrgMembers.GetByOrgID = func(_ context.Context, orgID int32) ([]*types.OrgMembership, error) {
+		return []*types.OrgMembership{{OrgID: orgID, UserID: 2}, {OrgID: orgID, UserID: 3}}, nil
+	}
+
+	var stored []*types.DiscussionCommentMention
+	db.Mocks.DiscussionCommentMentions.List = func(context.Context, int64) ([]*types.DiscussionCommentMention, error) {
+		return stored, nil
+	}
+	db.Mocks.DiscussionCommentMentions.Set = func(_ context.Context, commentID int64, userIDs, orgIDs []int32) error {
+		stored = nil
+		for i := range userIDs {
+			stored = append(stored, &types.DiscussionCommentMention{CommentID: commentID, UserID: &userIDs[i]})
+		}
+		for i := range orgIDs {
+			stored = append(stored, &types.DiscussionCommentMention{CommentID: commentID, OrgID: &orgIDs[i]})
+		}
+		return nil
+	}
+
+	comment := &types.DiscussionComment{ID: 1, Contents: "hey @alice and @alice, see @nobody"}
+	added, err := storeCommentMentions(ctx, comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int32{1}; !reflect.DeepEqual(added, want) {
+		t.Errorf("got added %v, want %v", added, want)
+	}
+	if len(stored) != 1 || stored[0].UserID == nil || *stored[0].UserID != 1 {
+		t.Errorf("unexpected stored mentions %+v", stored)
+	}
+
+	// Editing the comment to mention an organization only reports its members
+	// who were not already mentioned.
+	comment.Contents = "hey @alice and @acme"
+	added, err = storeCommentMentions(ctx, comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int32{2, 3}; !reflect.DeepEqual(added, want) {
+		t.Errorf("got added %v, want %v", added, want)
+	}
+	if len(stored) != 2 || stored[1].OrgID == nil || *stored[1].OrgID != 10 {
+		t.Errorf("unexpected stored mentions %+v", stored)
+	}
+
+	comment.Contents = "hey @bob"
+	added, err = storeCommentMentions(ctx, comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 0 {
+		t.Errorf("got added %v, want none", added)
+	}
+}
@@ -0,0 +1,66 @@
+package discussions
+
+import (
+	"context"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/references"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// storeCommentReferences parses the thread references in the comment's
+// contents and stores those that refer to existing threads, replacing any
+// previously stored references. It returns the IDs of the threads that are
+// referenced by the comment now but were not before.
+func storeCommentReferences(ctx context.Context, comment *types.DiscussionComment) (_ []int64, err error) {
+	tr, ctx := trace.New(ctx, "discussions.storeCommentReferences", "")
+	tr.LogFields(otlog.Int64("threadID", comment.ThreadID), otlog.Int64("commentID", comment.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	var threadIDs []int64
+	for _, threadID := range references.Parse(comment.Contents, globals.ExternalURL()) {
+		if threadID == comment.ThreadID {
+			continue
+		}
+		if _, err := db.DiscussionThreads.Get(ctx, threadID); err != nil {
+			if _, ok := err.(*db.ErrThreadNotFound); ok {
+				continue // Not a reference to an actual thread.
+			}
+			return nil, errors.Wrap(err, "DiscussionThreads.Get")
+		}
+		threadIDs = append(threadIDs, threadID)
+	}
+	added, err := db.DiscussionThreadReferences.Set(ctx, comment.ID, comment.ThreadID, threadIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadReferences.Set")
+	}
+	return added, nil
+}
+
+// StoreCommentReferences should be invoked after a comment has been created or
+// its contents have been updated, in order to record the threads it
+// references. Threads that are newly referenced get a REFERENCED item on
+// their timeline.
+//
+// Errors are logged and not returned, as failing to record the references
+// should not fail the creation or update of the comment.
+func StoreCommentReferences(ctx context.Context, comment *types.DiscussionComment) {
+	added, err := storeCommentReferences(ctx, comment)
+	if err != nil {
+		log15.Error("discussions: storing comment references", "comment", comment.ID, "error", err)
+		return
+	}
+	for _, threadID := range added {
+		LogThreadEvent(ctx, threadID, comment.AuthorUserID, types.DiscussionThreadEventReferenced, types.DiscussionThreadEventData{
+			CommentID: &comment.ID,
+		})
+	}
+}
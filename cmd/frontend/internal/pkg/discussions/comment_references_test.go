@@ -0,0 +1,72 @@
+package discussions
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestStoreCommentReferences(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	ctx := context.Background()
+
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		if threadID > 3 {
+			return nil, &db.ErrThreadNotFound{ThreadID: threadID}
+		}
+		return &types.DiscussionThread{ID: threadID}, nil
+	}
+	stored := map[int64]struct{}{}
+	var setThreadIDs []int64
+	db.Mocks.DiscussionThreadReferences.Set = func(_ context.Context, commentID, threadID int64, referencedThreadIDs []int64) ([]int64, error) {
+		if commentID != 10 || threadID != 1 {
+			t.Errorf("got Set(%d, %d), want Set(10, 1)", commentID, threadID)
+		}
+		setThreadIDs = referencedThreadIDs
+		var added []int64
+		for _, id := range referencedThreadIDs {
+			if _, ok := stored[id]; !ok {
+				added = append(added, id)
+			}
+		}
+		stored = map[int64]struct{}{}
+		for _, id := range referencedThreadIDs {
+			stored[id] = struct{}{}
+		}
+		return added, nil
+	}
+	var referencedThreadIDs []int64
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, event *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		if event.Kind != types.DiscussionThreadEventReferenced || event.Data.CommentID == nil || *event.Data.CommentID != 10 {
+			t.Errorf("unexpected event %+v", event)
+		}
+		referencedThreadIDs = append(referencedThreadIDs, event.ThreadID)
+		return event, nil
+	}
+
+	// References to the comment's own thread and to nonexistent threads are
+	// not stored.
+	comment := &types.DiscussionComment{ID: 10, ThreadID: 1, Contents: "see #1, #2 and #4"}
+	StoreCommentReferences(ctx, comment)
+	if want := []int64{2}; !reflect.DeepEqual(setThreadIDs, want) {
+		t.Errorf("got stored references %v, want %v", setThreadIDs, want)
+	}
+	if want := []int64{2}; !reflect.DeepEqual(referencedThreadIDs, want) {
+		t.Errorf("got REFERENCED events on threads %v, want %v", referencedThreadIDs, want)
+	}
+
+	// Editing the comment only records the newly referenced threads on their
+	// timelines.
+	referencedThreadIDs = nil
+	comment.Contents = "see #2 and #3"
+	StoreCommentReferences(ctx, comment)
+	if want := []int64{2, 3}; !reflect.DeepEqual(setThreadIDs, want) {
+		t.Errorf("got stored references %v, want %v", setThreadIDs, want)
+	}
+	if want := []int64{3}; !reflect.DeepEqual(referencedThreadIDs, want) {
+		t.Errorf("got REFERENCED events on threads %v, want %v", referencedThreadIDs, want)
+	}
+}
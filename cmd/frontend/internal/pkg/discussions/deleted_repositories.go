@@ -0,0 +1,89 @@
+package discussions
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+const (
+	// deletedRepositoryThreadsInterval is how often the deleted repository
+	// threads worker runs.
+	deletedRepositoryThreadsInterval = 10 * time.Minute
+
+	// deletedRepositoryThreadsBatchSize is the maximum number of threads that
+	// are archived or deleted each time the worker runs. Any remaining threads
+	// are handled the next time it runs.
+	deletedRepositoryThreadsBatchSize = 100
+)
+
+// StartDeletedRepositoryThreadsWorker should be invoked only after the DB has
+// been initialized. It starts the background worker which archives or deletes
+// the threads whose target repository was deleted, as configured by the
+// discussions.deletedRepositoryThreads site configuration.
+//
+// It should be invoked in a separate goroutine.
+func StartDeletedRepositoryThreadsWorker() {
+	for {
+		// Only one frontend instance should run this worker at a time, so we
+		// use a distributed lock to guarantee this.
+		ctx, release, ok := rcache.TryAcquireMutex(context.Background(), "discussionsDeletedRepositoryThreadsWorker")
+		if ok {
+			if err := handleDeletedRepositoryThreads(ctx, deletedRepositoryThreadsArchived()); err != nil {
+				log15.Error("discussions: deleted repository threads worker", "error", err)
+			}
+			release()
+		}
+		time.Sleep(deletedRepositoryThreadsInterval)
+	}
+}
+
+// deletedRepositoryThreadsArchived reports whether the threads of deleted
+// repositories are archived (instead of deleted).
+func deletedRepositoryThreadsArchived() bool {
+	dc := conf.Get().Discussions
+	return dc != nil && dc.DeletedRepositoryThreads == "archive"
+}
+
+// handleDeletedRepositoryThreads archives and locks (if archive is true) or
+// deletes the threads whose target repository was deleted.
+//
+// Archived threads are archived again if they are unarchived, because their
+// repository no longer exists.
+func handleDeletedRepositoryThreads(ctx context.Context, archive bool) error {
+	// 🚨 SECURITY: The threads of deleted repositories are visible only to
+	// site admins, and this applies to all of them.
+	ctx = actor.WithActor(ctx, &actor.Actor{Internal: true})
+
+	repoDeleted := true
+	opts := &db.DiscussionThreadsListOptions{
+		LimitOffset:    &db.LimitOffset{Limit: deletedRepositoryThreadsBatchSize},
+		RepoDeleted:    &repoDeleted,
+		AscendingOrder: true,
+	}
+	if archive {
+		notArchived := false
+		opts.Archived = &notArchived
+	}
+	threads, err := db.DiscussionThreads.List(ctx, opts)
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.List")
+	}
+	for _, thread := range threads {
+		update := &db.DiscussionThreadsUpdateOptions{Delete: !archive}
+		if archive {
+			archived, locked := true, true
+			update.Archive, update.Lock = &archived, &locked
+		}
+		if _, err := db.DiscussionThreads.Update(ctx, thread.ID, update); err != nil {
+			return errors.Wrap(err, "DiscussionThreads.Update")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package discussions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestHandleDeletedRepositoryThreads(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	ctx := context.Background()
+
+	for _, archive := range []bool{false, true} {
+		db.Mocks.DiscussionThreads.List = func(_ context.Context, opts *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+			if opts.RepoDeleted == nil || !*opts.RepoDeleted {
+				t.Errorf("got RepoDeleted %v, want true", opts.RepoDeleted)
+			}
+			if archive && (opts.Archived == nil || *opts.Archived) {
+				t.Errorf("got Archived %v, want false", opts.Archived)
+			} else if !archive && opts.Archived != nil {
+				t.Errorf("got Archived %v, want nil", *opts.Archived)
+			}
+			return []*types.DiscussionThread{{ID: 1}, {ID: 2}}, nil
+		}
+		updates := map[int64]db.DiscussionThreadsUpdateOptions{}
+		db.Mocks.DiscussionThreads.Update = func(_ context.Context, threadID int64, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error) {
+			updates[threadID] = *opts
+			return &types.DiscussionThread{ID: threadID}, nil
+		}
+
+		if err := handleDeletedRepositoryThreads(ctx, archive); err != nil {
+			t.Fatal(err)
+		}
+		if len(updates) != 2 {
+			t.Fatalf("archive=%v: got %d updates, want 2", archive, len(updates))
+		}
+		for threadID, update := range updates {
+			if archive {
+				if update.Delete || update.Archive == nil || !*update.Archive || update.Lock == nil || !*update.Lock {
+					t.Errorf("thread %d: got update %+v, want archive and lock", threadID, update)
+				}
+			} else if !update.Delete || update.Archive != nil || update.Lock != nil {
+				t.Errorf("thread %d: got update %+v, want delete", threadID, update)
+			}
+		}
+	}
+}
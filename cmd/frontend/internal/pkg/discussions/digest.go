@@ -0,0 +1,279 @@
+package discussions
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+const (
+	// digestInterval is how often the digest worker runs. Each user is sent at
+	// most one digest per day (or week), on the first run of the day (or ISO
+	// week).
+	digestInterval = time.Hour
+
+	// digestThreadsLimit is the maximum number of threads listed in each
+	// section of a digest.
+	digestThreadsLimit = 10
+)
+
+// digestPeriods are the durations covered by the values of the
+// discussions.digest user setting ("off" is absent).
+var digestPeriods = map[string]time.Duration{
+	"daily":  24 * time.Hour,
+	"weekly": 7 * 24 * time.Hour,
+}
+
+// digestsSent records which users have been sent their digest for a day or
+// week, so that other frontend instances and restarts don't send it again.
+var digestsSent = rcache.NewWithTTL("discussions_digests_sent", 8*24*60*60)
+
+// StartDigestWorker should be invoked only after the DB has been initialized.
+// It starts the background worker which sends digest emails to the users who
+// enabled them with the discussions.digest user setting.
+//
+// It should be invoked in a separate goroutine.
+func StartDigestWorker() {
+	for {
+		if conf.CanSendEmail() {
+			// Only one frontend instance should run this worker at a time, so
+			// we use a distributed lock to guarantee this.
+			ctx, release, ok := rcache.TryAcquireMutex(context.Background(), "discussionsDigestWorker")
+			if ok {
+				if err := sendDigests(ctx, timeNow()); err != nil {
+					log15.Error("discussions: digest worker", "error", err)
+				}
+				release()
+			}
+		}
+		time.Sleep(digestInterval)
+	}
+}
+
+// sendDigests sends a digest to each user whose discussions.digest user
+// setting is "daily" or "weekly" and who has not yet been sent one for the
+// current day or week.
+func sendDigests(ctx context.Context, now time.Time) error {
+	// 🚨 SECURITY: ListAll returns the settings of all users. They are only
+	// used to find the users who enabled digests, and each digest is built
+	// with the permissions of its recipient (see sendDigest).
+	allSettings, err := db.Settings.ListAll(ctx, "discussions.digest")
+	if err != nil {
+		return errors.Wrap(err, "Settings.ListAll")
+	}
+	seen := map[int32]bool{}
+	for _, settings := range allSettings {
+		if settings.Subject.User == nil || seen[*settings.Subject.User] {
+			continue
+		}
+		userID := *settings.Subject.User
+		seen[userID] = true
+
+		period, err := digestPeriod(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if period == "" {
+			continue
+		}
+		key := fmt.Sprintf("%d:%s", userID, now.UTC().Format("2006-01-02"))
+		if period == "weekly" {
+			year, week := now.UTC().ISOWeek()
+			key = fmt.Sprintf("%d:%d-W%02d", userID, year, week)
+		}
+		if _, ok := digestsSent.Get(key); ok {
+			continue
+		}
+		if err := sendDigest(ctx, userID, period, now); err != nil {
+			log15.Error("discussions: sendDigest", "userID", userID, "error", err)
+			continue
+		}
+		digestsSent.Set(key, []byte{1})
+	}
+	return nil
+}
+
+// digestPeriod returns the user's discussions.digest setting ("daily" or
+// "weekly"), or "" if they have not enabled digests.
+func digestPeriod(ctx context.Context, userID int32) (string, error) {
+	settings, err := backend.Configuration.GetForSubject(ctx, api.SettingsSubject{User: &userID})
+	if err != nil {
+		return "", errors.Wrap(err, "Configuration.GetForSubject")
+	}
+	if _, ok := digestPeriods[settings.DiscussionsDigest]; !ok {
+		return "", nil
+	}
+	return settings.DiscussionsDigest, nil
+}
+
+// digestThread is a thread listed in a digest email.
+type digestThread struct {
+	Title string
+	URL   string
+}
+
+// digestEmailData is the data for digestEmailTemplate.
+type digestEmailData struct {
+	Period          string
+	NewThreads      []digestThread
+	AssignedThreads []digestThread
+	StaleThreads    []digestThread
+	SettingsURL     string
+}
+
+// sendDigest emails the user a digest of the threads that are new, assigned
+// to them with new activity, or stale and assigned to them, for the period
+// ending now. Nothing is sent if there is nothing to report or the user has
+// no verified email address.
+func sendDigest(ctx context.Context, userID int32, period string, now time.Time) error {
+	email, verified, err := db.UserEmails.GetPrimaryEmail(ctx, userID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return errors.Wrap(err, "GetPrimaryEmail")
+	}
+	if errcode.IsNotFound(err) || !verified {
+		// User has no email or it is not verified, do not send them any emails.
+		return nil
+	}
+
+	// 🚨 SECURITY: List only the threads that the user can view.
+	ctx = actor.WithActor(ctx, actor.FromUser(userID))
+
+	since := now.Add(-digestPeriods[period])
+	notArchived, notStale, stale := false, false, true
+	newThreads, err := db.DiscussionThreads.List(ctx, &db.DiscussionThreadsListOptions{
+		LimitOffset:      &db.LimitOffset{Limit: digestThreadsLimit},
+		InvolvedUserID:   userID,
+		NotAuthorUserIDs: []int32{userID},
+		CreatedAfter:     &since,
+		Archived:         &notArchived,
+	})
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.List")
+	}
+	assignedThreads, err := db.DiscussionThreads.List(ctx, &db.DiscussionThreadsListOptions{
+		LimitOffset:    &db.LimitOffset{Limit: digestThreadsLimit},
+		AssigneeUserID: userID,
+		Archived:       &notArchived,
+		Stale:          &notStale,
+	})
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.List")
+	}
+	staleThreads, err := db.DiscussionThreads.List(ctx, &db.DiscussionThreadsListOptions{
+		LimitOffset:    &db.LimitOffset{Limit: digestThreadsLimit},
+		AssigneeUserID: userID,
+		Stale:          &stale,
+	})
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.List")
+	}
+
+	// Threads are listed most recently updated first, so the assigned threads
+	// with new activity are a prefix of the list. Threads that are already
+	// listed as new are not repeated.
+	isNew := map[int64]bool{}
+	for _, thread := range newThreads {
+		isNew[thread.ID] = true
+	}
+	var activeThreads []*types.DiscussionThread
+	for _, thread := range assignedThreads {
+		if thread.UpdatedAt.Before(since) {
+			break
+		}
+		if !isNew[thread.ID] {
+			activeThreads = append(activeThreads, thread)
+		}
+	}
+
+	data := digestEmailData{
+		Period:      period,
+		SettingsURL: globals.ExternalURL().ResolveReference(&url.URL{Path: "/settings"}).String(),
+	}
+	for _, section := range []struct {
+		threads []*types.DiscussionThread
+		dst     *[]digestThread
+	}{
+		{newThreads, &data.NewThreads},
+		{activeThreads, &data.AssignedThreads},
+		{staleThreads, &data.StaleThreads},
+	} {
+		for _, thread := range section.threads {
+			u, err := URLToInlineThread(ctx, thread)
+			if err != nil {
+				return errors.Wrap(err, "URLToInlineThread")
+			}
+			if u == nil {
+				continue // can't generate a link to this thread target type
+			}
+			u = globals.ExternalURL().ResolveReference(u)
+			q := u.Query()
+			q.Set("utm_source", "email-digest")
+			u.RawQuery = q.Encode()
+			*section.dst = append(*section.dst, digestThread{Title: thread.Title, URL: u.String()})
+		}
+	}
+	if len(data.NewThreads) == 0 && len(data.AssignedThreads) == 0 && len(data.StaleThreads) == 0 {
+		return nil
+	}
+
+	return txemail.Send(ctx, txemail.Message{
+		To:       []string{email},
+		Template: digestEmailTemplate,
+		Data:     data,
+	})
+}
+
+var digestEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `Your {{.Period}} code discussions digest`,
+	Text: `
+{{- with .NewThreads -}}
+{{- "New threads:\n\n" -}}
+{{- range . -}}{{- "  " -}}{{- .Title -}}{{- "\n  " -}}{{- .URL -}}{{- "\n\n" -}}{{- end -}}
+{{- end -}}
+{{- with .AssignedThreads -}}
+{{- "Assigned to you, with new activity:\n\n" -}}
+{{- range . -}}{{- "  " -}}{{- .Title -}}{{- "\n  " -}}{{- .URL -}}{{- "\n\n" -}}{{- end -}}
+{{- end -}}
+{{- with .StaleThreads -}}
+{{- "Assigned to you, stale and due to be archived:\n\n" -}}
+{{- range . -}}{{- "  " -}}{{- .Title -}}{{- "\n  " -}}{{- .URL -}}{{- "\n\n" -}}{{- end -}}
+{{- end -}}
+{{- "—\n" -}}
+{{- "Change how often you receive this digest (discussions.digest) in your settings: " -}}{{- .SettingsURL -}}
+{{- "\n" -}}
+`,
+	HTML: `
+<html>
+<body>
+{{with .NewThreads}}
+<p><strong>New threads</strong></p>
+<ul>{{range .}}<li><a href="{{.URL}}">{{.Title}}</a></li>{{end}}</ul>
+{{end}}
+{{with .AssignedThreads}}
+<p><strong>Assigned to you, with new activity</strong></p>
+<ul>{{range .}}<li><a href="{{.URL}}">{{.Title}}</a></li>{{end}}</ul>
+{{end}}
+{{with .StaleThreads}}
+<p><strong>Assigned to you, stale and due to be archived</strong></p>
+<ul>{{range .}}<li><a href="{{.URL}}">{{.Title}}</a></li>{{end}}</ul>
+{{end}}
+<p style="font-size: small; color: #666;">—<br/>Change how often you receive this digest (discussions.digest) in <a href="{{.SettingsURL}}">your settings</a>.</p>
+</body>
+</html>
+`,
+})
@@ -0,0 +1,108 @@
+package discussions
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+)
+
+func TestSendDigest(t *testing.T) {
+	defer func() {
+		db.Mocks = db.MockStores{}
+		txemail.MockSend = nil
+	}()
+	ctx := context.Background()
+	now := time.Date(2019, 6, 8, 0, 0, 0, 0, time.UTC)
+	path := "f"
+	thread := func(id int64, title string, updatedAt time.Time) *types.DiscussionThread {
+		return &types.DiscussionThread{ID: id, Title: title, UpdatedAt: updatedAt, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1, Path: &path}}
+	}
+
+	db.Mocks.UserEmails.GetPrimaryEmail = func(_ context.Context, id int32) (string, bool, error) {
+		return "u@example.com", true, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "r"}, nil
+	}
+	var newThreads, assignedThreads, staleThreads []*types.DiscussionThread
+	db.Mocks.DiscussionThreads.List = func(ctx context.Context, opts *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if a := actor.FromContext(ctx); a.UID != 2 {
+			t.Errorf("got actor %+v, want user 2", a)
+		}
+		switch {
+		case opts.InvolvedUserID == 2:
+			if want := now.Add(-7 * 24 * time.Hour); opts.CreatedAfter == nil || !opts.CreatedAfter.Equal(want) {
+				t.Errorf("got created after %v, want %v", opts.CreatedAfter, want)
+			}
+			if want := []int32{2}; !reflect.DeepEqual(opts.NotAuthorUserIDs, want) {
+				t.Errorf("got NotAuthorUserIDs %v, want %v", opts.NotAuthorUserIDs, want)
+			}
+			return newThreads, nil
+		case opts.AssigneeUserID == 2 && opts.Stale != nil && *opts.Stale:
+			return staleThreads, nil
+		case opts.AssigneeUserID == 2:
+			return assignedThreads, nil
+		}
+		t.Fatalf("unexpected options %+v", opts)
+		return nil, nil
+	}
+	var sent []txemail.Message
+	txemail.MockSend = func(_ context.Context, message txemail.Message) error {
+		sent = append(sent, message)
+		return nil
+	}
+
+	t.Run("nothing to report", func(t *testing.T) {
+		sent = nil
+		assignedThreads = []*types.DiscussionThread{thread(1, "old", now.Add(-30*24*time.Hour))}
+		if err := sendDigest(ctx, 2, "weekly", now); err != nil {
+			t.Fatal(err)
+		}
+		if len(sent) != 0 {
+			t.Errorf("got %d emails, want 0", len(sent))
+		}
+	})
+
+	t.Run("digest", func(t *testing.T) {
+		sent = nil
+		newThreads = []*types.DiscussionThread{thread(1, "new", now.Add(-time.Hour))}
+		assignedThreads = []*types.DiscussionThread{
+			thread(1, "new", now.Add(-time.Hour)),
+			thread(2, "active", now.Add(-2*time.Hour)),
+			thread(3, "inactive", now.Add(-8*24*time.Hour)),
+		}
+		staleThreads = []*types.DiscussionThread{thread(4, "stale", now.Add(-60*24*time.Hour))}
+		if err := sendDigest(ctx, 2, "weekly", now); err != nil {
+			t.Fatal(err)
+		}
+		if len(sent) != 1 {
+			t.Fatalf("got %d emails, want 1", len(sent))
+		}
+		if want := []string{"u@example.com"}; !reflect.DeepEqual(sent[0].To, want) {
+			t.Errorf("got To %v, want %v", sent[0].To, want)
+		}
+		data := sent[0].Data.(digestEmailData)
+		titles := func(threads []digestThread) (titles []string) {
+			for _, thread := range threads {
+				titles = append(titles, thread.Title)
+			}
+			return titles
+		}
+		if got, want := titles(data.NewThreads), []string{"new"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got new threads %v, want %v", got, want)
+		}
+		if got, want := titles(data.AssignedThreads), []string{"active"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got assigned threads %v, want %v", got, want)
+		}
+		if got, want := titles(data.StaleThreads), []string{"stale"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got stale threads %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package discussions
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// ErrorCode is a stable, machine-readable code that describes why a
+// discussions operation failed. It is exposed to GraphQL clients as the "code"
+// error extension, so that they can show actionable messages without parsing
+// the error message.
+type ErrorCode string
+
+const (
+	// ErrorCodeNotFound means that the thread, comment, or other object does
+	// not exist (or the user cannot access it).
+	ErrorCodeNotFound ErrorCode = "NOT_FOUND"
+
+	// ErrorCodeForbidden means that the user is not signed in or is not allowed
+	// to perform the operation.
+	ErrorCodeForbidden ErrorCode = "FORBIDDEN"
+
+	// ErrorCodeInvalidState means that the operation is not allowed in the
+	// object's current state (e.g. commenting on a locked thread).
+	ErrorCodeInvalidState ErrorCode = "INVALID_STATE"
+
+	// ErrorCodeInvalidInput means that an input field has an invalid value
+	// (see ValidationError).
+	ErrorCodeInvalidInput ErrorCode = "INVALID_INPUT"
+
+	// ErrorCodeConflict means that the operation conflicts with another object
+	// (e.g. a label with the same name already exists).
+	ErrorCodeConflict ErrorCode = "CONFLICT"
+)
+
+// ErrNoCurrentUser is returned when an operation requires a signed-in user.
+var ErrNoCurrentUser = NewError(ErrorCodeForbidden, "no current user")
+
+// Error is an error with an ErrorCode.
+type Error struct {
+	Code ErrorCode
+	Err  error
+}
+
+// NewError returns an error with the given code and message.
+func NewError(code ErrorCode, message string) error {
+	return &Error{Code: code, Err: errors.New(message)}
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Cause() error { return e.Err }
+
+// NotFound implements the interface checked by errcode.IsNotFound.
+func (e *Error) NotFound() bool { return e.Code == ErrorCodeNotFound }
+
+// Extensions implements the GraphQL error extensions interface.
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": string(e.Code)}
+}
+
+// Code returns the ErrorCode that describes err or one of its causes, or the
+// empty string if there is none.
+func Code(err error) ErrorCode {
+	type causer interface {
+		Cause() error
+	}
+	for err != nil {
+		if code := knownErrorCode(err); code != "" {
+			return code
+		}
+		cause, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return ""
+}
+
+func knownErrorCode(err error) ErrorCode {
+	switch err {
+	case backend.ErrNotAuthenticated, backend.ErrMustBeSiteAdmin, backend.ErrNotAnOrgMember:
+		return ErrorCodeForbidden
+	case ErrThreadLocked, ErrThreadArchived, ErrSuggestionOutdated, db.ErrSuggestionAlreadyApplied, db.ErrTooManyPinnedThreads, db.ErrThreadAlreadyClosed, db.ErrThreadNotClosed:
+		return ErrorCodeInvalidState
+	case db.ErrThreadDependencyCycle, db.ErrConcurrentUpdate, db.ErrDuplicateIdempotencyKey:
+		return ErrorCodeConflict
+	}
+	switch e := err.(type) {
+	case *Error:
+		return e.Code
+	case *ValidationError:
+		return ErrorCodeInvalidInput
+	case *db.ErrNameAlreadyExists:
+		return ErrorCodeConflict
+	case *backend.InsufficientAuthorizationError:
+		return ErrorCodeForbidden
+	}
+	if errcode.IsNotFound(err) {
+		return ErrorCodeNotFound
+	}
+	return ""
+}
+
+// ErrorExtensions returns the GraphQL error extensions for err, or nil if err
+// has none. Errors that implement Extensions themselves (such as
+// ratelimit.ErrRateLimited) keep their own extensions, even when wrapped.
+//
+// The GraphQL library only uses the extensions of the exact error returned by
+// a resolver, so the GraphQL HTTP handler calls ErrorExtensions for the other
+// resolver errors.
+func ErrorExtensions(err error) map[string]interface{} {
+	type extensionser interface {
+		Extensions() map[string]interface{}
+	}
+	type causer interface {
+		Cause() error
+	}
+	for e := err; e != nil; {
+		if ex, ok := e.(extensionser); ok {
+			return ex.Extensions()
+		}
+		cause, ok := e.(causer)
+		if !ok {
+			break
+		}
+		e = cause.Cause()
+	}
+	if code := Code(err); code != "" {
+		return map[string]interface{}{"code": string(code)}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package discussions
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/ratelimit"
+)
+
+func TestCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want ErrorCode
+	}{
+		"nil":              {err: nil, want: ""},
+		"unknown":          {err: errors.New("x"), want: ""},
+		"Error":            {err: NewError(ErrorCodeConflict, "x"), want: ErrorCodeConflict},
+		"no current user":  {err: ErrNoCurrentUser, want: ErrorCodeForbidden},
+		"site admin":       {err: backend.ErrMustBeSiteAdmin, want: ErrorCodeForbidden},
+		"same user":        {err: &backend.InsufficientAuthorizationError{Message: "x"}, want: ErrorCodeForbidden},
+		"thread not found": {err: &db.ErrThreadNotFound{ThreadID: 1}, want: ErrorCodeNotFound},
+		"wrapped":          {err: errors.Wrap(&db.ErrCommentNotFound{CommentID: 1}, "DiscussionComments.Get"), want: ErrorCodeNotFound},
+		"locked":           {err: ErrThreadLocked, want: ErrorCodeInvalidState},
+		"pinned":           {err: db.ErrTooManyPinnedThreads, want: ErrorCodeInvalidState},
+		"cycle":            {err: db.ErrThreadDependencyCycle, want: ErrorCodeConflict},
+		"concurrent":       {err: db.ErrConcurrentUpdate, want: ErrorCodeConflict},
+		"name":             {err: &db.ErrNameAlreadyExists{Message: "x"}, want: ErrorCodeConflict},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Code(test.err); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestErrorExtensions(t *testing.T) {
+	if got := ErrorExtensions(errors.New("x")); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+	if got, want := ErrorExtensions(errors.Wrap(&db.ErrThreadNotFound{ThreadID: 1}, "x")), map[string]interface{}{"code": "NOT_FOUND"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Errors with their own extensions keep them when wrapped.
+	rateLimited := &ratelimit.ErrRateLimited{Action: "threads", RetryAfter: time.Second}
+	if got, want := ErrorExtensions(errors.Wrap(rateLimited, "x")), rateLimited.Extensions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package discussions
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// LogThreadEvent appends an event to a thread's timeline, delivers it to the
+// webhooks that apply to the thread, applies the repository's rules if the
+// event created the thread or edited its title, and notifies the thread's
+// subscribers (and, for archived threads, the notification channels) if the
+// event changed the thread's state.
+//
+// The change that the event describes has already been made by the time this
+// is called, so failures are logged instead of being returned to the caller.
+func LogThreadEvent(ctx context.Context, threadID int64, actorUserID int32, kind types.DiscussionThreadEventKind, data types.DiscussionThreadEventData) {
+	event, err := db.DiscussionThreadEvents.Create(ctx, &types.DiscussionThreadEvent{
+		ThreadID:    threadID,
+		ActorUserID: actorUserID,
+		Kind:        kind,
+		Data:        data,
+	})
+	if err != nil {
+		log15.Error("discussions: LogThreadEvent", "threadID", threadID, "kind", kind, "error", err)
+		return
+	}
+	dispatchThreadEvent(event)
+}
+
+// dispatchThreadEvent delivers an event that was appended to a thread's
+// timeline to the webhooks, applies rules and notifies subscribers (see
+// LogThreadEvent).
+func dispatchThreadEvent(event *types.DiscussionThreadEvent) {
+	threadEventsTotal.WithLabelValues(string(event.Kind)).Inc()
+	deliverThreadEventWebhooks(event)
+	applyRules(event)
+	notifyStateChange(event)
+	if event.Kind == types.DiscussionThreadEventArchived {
+		notifyChannels(channelEventThreadArchived, event.ActorUserID, event.ThreadID, nil)
+	}
+}
@@ -0,0 +1,305 @@
+package discussions
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// ExportFormat is the format that threads are exported in.
+type ExportFormat string
+
+const (
+	// ExportFormatJSON exports a JSON array with one object per thread, which
+	// includes the thread's comments and events.
+	ExportFormatJSON ExportFormat = "json"
+
+	// ExportFormatCSV exports a CSV table with one row per thread, comment,
+	// and event. The "type" column distinguishes them.
+	ExportFormatCSV ExportFormat = "csv"
+)
+
+// ParseExportFormat parses the (case-sensitive) name of an export format.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch f := ExportFormat(s); f {
+	case ExportFormatJSON, ExportFormatCSV:
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid export format %q (must be %q or %q)", s, ExportFormatJSON, ExportFormatCSV)
+}
+
+// ContentType returns the MIME type of exports in the format.
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// ExportTarget is what threads are exported for: exactly one of a repository
+// or an organization.
+type ExportTarget struct {
+	RepoName api.RepoName
+	OrgName  string
+}
+
+// ErrInvalidExportTarget is returned by ExportListOptions when the target does
+// not specify exactly one of a repository or an organization.
+var ErrInvalidExportTarget = errors.New("exactly one of a repository or an organization must be specified")
+
+// ExportURL returns the URL of the HTTP API endpoint that exports the
+// target's threads in the given format.
+func ExportURL(target ExportTarget, format ExportFormat) *url.URL {
+	q := url.Values{}
+	if target.RepoName != "" {
+		q.Set("repository", string(target.RepoName))
+	}
+	if target.OrgName != "" {
+		q.Set("org", target.OrgName)
+	}
+	q.Set("format", string(format))
+	return globals.ExternalURL().ResolveReference(&url.URL{
+		Path:     "/.api/discussions/threads/export",
+		RawQuery: q.Encode(),
+	})
+}
+
+// ExportListOptions checks that the current user can export the target's
+// threads, and returns the options that list them.
+//
+// 🚨 SECURITY: Only authenticated users can export threads. A repository's
+// threads can be exported by users who can access the repository (which
+// db.Repos.GetByName checks), and an organization's threads by its members
+// and site admins.
+func ExportListOptions(ctx context.Context, target ExportTarget) (*db.DiscussionThreadsListOptions, error) {
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+	if (target.RepoName == "") == (target.OrgName == "") {
+		return nil, ErrInvalidExportTarget
+	}
+	if target.RepoName != "" {
+		repo, err := db.Repos.GetByName(ctx, target.RepoName)
+		if err != nil {
+			return nil, err
+		}
+		return &db.DiscussionThreadsListOptions{TargetRepoID: &repo.ID}, nil
+	}
+	org, err := db.Orgs.GetByName(ctx, target.OrgName)
+	if err != nil {
+		return nil, err
+	}
+	if err := backend.CheckOrgAccess(ctx, org.ID); err != nil {
+		return nil, err
+	}
+	return &db.DiscussionThreadsListOptions{OrgID: org.ID}, nil
+}
+
+// exportBatchSize is the number of threads that are read from the database at
+// a time during an export.
+var exportBatchSize = 100
+
+// exportedThread is the JSON representation of an exported thread.
+type exportedThread struct {
+	ID                  int64             `json:"id"`
+	Title               string            `json:"title"`
+	AuthorUserID        int32             `json:"authorUserID"`
+	RepoID              *int32            `json:"repoID,omitempty"`
+	Path                *string           `json:"path,omitempty"`
+	Branch              *string           `json:"branch,omitempty"`
+	Revision            *string           `json:"revision,omitempty"`
+	MilestoneID         *int64            `json:"milestoneID,omitempty"`
+	DuplicateOfThreadID *int64            `json:"duplicateOfThreadID,omitempty"`
+	CreatedAt           time.Time         `json:"createdAt"`
+	UpdatedAt           time.Time         `json:"updatedAt"`
+	ArchivedAt          *time.Time        `json:"archivedAt,omitempty"`
+	LockedAt            *time.Time        `json:"lockedAt,omitempty"`
+	Comments            []exportedComment `json:"comments"`
+	Events              []exportedEvent   `json:"events"`
+}
+
+type exportedComment struct {
+	ID              int64      `json:"id"`
+	ParentCommentID *int64     `json:"parentCommentID,omitempty"`
+	AuthorUserID    int32      `json:"authorUserID"`
+	Contents        string     `json:"contents"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	ResolvedAt      *time.Time `json:"resolvedAt,omitempty"`
+}
+
+type exportedEvent struct {
+	ID          int64                           `json:"id"`
+	Kind        types.DiscussionThreadEventKind `json:"kind"`
+	ActorUserID int32                           `json:"actorUserID"`
+	Data        types.DiscussionThreadEventData `json:"data"`
+	CreatedAt   time.Time                       `json:"createdAt"`
+}
+
+// ExportThreads writes all threads matching opts, with their comments and
+// events, to w in the given format. Threads are read from the database in
+// batches and written as they are read, so that large exports are not held in
+// memory. opts.LimitOffset, opts.After, and the ordering options are
+// overwritten.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is allowed to export the
+// threads. DiscussionThreads.List only checks repository permissions.
+func ExportThreads(ctx context.Context, w io.Writer, format ExportFormat, opts db.DiscussionThreadsListOptions) error {
+	var write func(*exportedThread) error
+	switch format {
+	case ExportFormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		first := true
+		write = func(t *exportedThread) error {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			return enc.Encode(t)
+		}
+	case ExportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		write = func(t *exportedThread) error {
+			for _, record := range exportCSVRecords(t) {
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			}
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		return fmt.Errorf("invalid export format %q", format)
+	}
+
+	opts.LimitOffset = &db.LimitOffset{Limit: exportBatchSize}
+	opts.AscendingOrder = true
+	opts.PinnedFirst = false
+	opts.After = nil
+	for {
+		threads, err := db.DiscussionThreads.List(ctx, &opts)
+		if err != nil {
+			return errors.Wrap(err, "DiscussionThreads.List")
+		}
+		for _, thread := range threads {
+			t, err := exportThread(ctx, thread)
+			if err != nil {
+				return err
+			}
+			if err := write(t); err != nil {
+				return err
+			}
+		}
+		if len(threads) < exportBatchSize {
+			break
+		}
+		last := threads[len(threads)-1]
+		opts.After = db.NewDiscussionThreadsCursor(last, &opts)
+	}
+
+	if format == ExportFormatJSON {
+		if _, err := io.WriteString(w, "]\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportThread(ctx context.Context, thread *types.DiscussionThread) (*exportedThread, error) {
+	t := &exportedThread{
+		ID:                  thread.ID,
+		Title:               thread.Title,
+		AuthorUserID:        thread.AuthorUserID,
+		MilestoneID:         thread.MilestoneID,
+		DuplicateOfThreadID: thread.DuplicateOfThreadID,
+		CreatedAt:           thread.CreatedAt,
+		UpdatedAt:           thread.UpdatedAt,
+		ArchivedAt:          thread.ArchivedAt,
+		LockedAt:            thread.LockedAt,
+		Comments:            []exportedComment{},
+		Events:              []exportedEvent{},
+	}
+	if tr := thread.TargetRepo; tr != nil {
+		repoID := int32(tr.RepoID)
+		t.RepoID = &repoID
+		t.Path = tr.Path
+		t.Branch = tr.Branch
+		t.Revision = tr.Revision
+	}
+
+	comments, err := db.DiscussionComments.List(ctx, &db.DiscussionCommentsListOptions{ThreadID: &thread.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionComments.List")
+	}
+	for _, c := range comments {
+		t.Comments = append(t.Comments, exportedComment{
+			ID:              c.ID,
+			ParentCommentID: c.ParentCommentID,
+			AuthorUserID:    c.AuthorUserID,
+			Contents:        c.Contents,
+			CreatedAt:       c.CreatedAt,
+			UpdatedAt:       c.UpdatedAt,
+			ResolvedAt:      c.ResolvedAt,
+		})
+	}
+
+	events, err := db.DiscussionThreadEvents.List(ctx, &db.DiscussionThreadEventsListOptions{ThreadID: thread.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadEvents.List")
+	}
+	for _, e := range events {
+		t.Events = append(t.Events, exportedEvent{
+			ID:          e.ID,
+			Kind:        e.Kind,
+			ActorUserID: e.ActorUserID,
+			Data:        e.Data,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+	return t, nil
+}
+
+var exportCSVHeader = []string{"type", "thread_id", "id", "parent_comment_id", "user_id", "created_at", "title", "contents", "kind", "data"}
+
+// exportCSVRecords returns the CSV records for the thread: one for the thread
+// itself, followed by one for each of its comments and events.
+func exportCSVRecords(t *exportedThread) [][]string {
+	formatTime := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+	threadID := strconv.FormatInt(t.ID, 10)
+	records := [][]string{
+		{"thread", threadID, threadID, "", strconv.Itoa(int(t.AuthorUserID)), formatTime(t.CreatedAt), t.Title, "", "", ""},
+	}
+	for _, c := range t.Comments {
+		var parentID string
+		if c.ParentCommentID != nil {
+			parentID = strconv.FormatInt(*c.ParentCommentID, 10)
+		}
+		records = append(records, []string{"comment", threadID, strconv.FormatInt(c.ID, 10), parentID, strconv.Itoa(int(c.AuthorUserID)), formatTime(c.CreatedAt), "", c.Contents, "", ""})
+	}
+	for _, e := range t.Events {
+		data, _ := json.Marshal(e.Data)
+		records = append(records, []string{"event", threadID, strconv.FormatInt(e.ID, 10), "", strconv.Itoa(int(e.ActorUserID)), formatTime(e.CreatedAt), "", "", string(e.Kind), string(data)})
+	}
+	return records
+}
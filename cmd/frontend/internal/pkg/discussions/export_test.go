@@ -0,0 +1,142 @@
+package discussions
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func mockExportThreads(t *testing.T) {
+	createdAt := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	threads := []*types.DiscussionThread{
+		{ID: 1, AuthorUserID: 1, Title: "a", TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 2}, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 2, AuthorUserID: 1, Title: "b", TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 2}, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 3, AuthorUserID: 3, Title: "c", TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 2}, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+	db.Mocks.DiscussionThreads.List = func(_ context.Context, opts *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if !opts.AscendingOrder {
+			t.Error("expected threads to be listed in ascending order")
+		}
+		if opts.TargetRepoID == nil || *opts.TargetRepoID != 2 {
+			t.Errorf("got target repo %v, want 2", opts.TargetRepoID)
+		}
+		start := 0
+		if opts.After != nil {
+			start = int(opts.After.ID)
+		}
+		end := start + opts.Limit
+		if end > len(threads) {
+			end = len(threads)
+		}
+		return threads[start:end], nil
+	}
+	db.Mocks.DiscussionComments.List = func(_ context.Context, opts *db.DiscussionCommentsListOptions) ([]*types.DiscussionComment, error) {
+		if *opts.ThreadID != 1 {
+			return nil, nil
+		}
+		return []*types.DiscussionComment{{ID: 10, ThreadID: 1, AuthorUserID: 2, Contents: "hello, world", CreatedAt: createdAt}}, nil
+	}
+	db.Mocks.DiscussionThreadEvents.List = func(_ context.Context, opts *db.DiscussionThreadEventsListOptions) ([]*types.DiscussionThreadEvent, error) {
+		return []*types.DiscussionThreadEvent{{ID: 20 + opts.ThreadID, ThreadID: opts.ThreadID, ActorUserID: 1, Kind: types.DiscussionThreadEventCreated, CreatedAt: createdAt}}, nil
+	}
+}
+
+func TestExportThreads(t *testing.T) {
+	defer func() {
+		db.Mocks = db.MockStores{}
+		exportBatchSize = 100
+	}()
+	exportBatchSize = 2
+	mockExportThreads(t)
+	repoID := api.RepoID(2)
+	opts := db.DiscussionThreadsListOptions{TargetRepoID: &repoID}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ExportThreads(context.Background(), &buf, ExportFormatJSON, opts); err != nil {
+			t.Fatal(err)
+		}
+		var threads []exportedThread
+		if err := json.Unmarshal(buf.Bytes(), &threads); err != nil {
+			t.Fatalf("%s: %s", err, buf.String())
+		}
+		var ids []int64
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		if want := []int64{1, 2, 3}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("got threads %v, want %v", ids, want)
+		}
+		if len(threads[0].Comments) != 1 || threads[0].Comments[0].Contents != "hello, world" {
+			t.Errorf("got comments %+v, want one comment", threads[0].Comments)
+		}
+		if len(threads[1].Events) != 1 || threads[1].Events[0].Kind != types.DiscussionThreadEventCreated {
+			t.Errorf("got events %+v, want one CREATED event", threads[1].Events)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ExportThreads(context.Background(), &buf, ExportFormatCSV, opts); err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got [][]string
+		for _, record := range records {
+			got = append(got, record[:3])
+		}
+		want := [][]string{
+			{"type", "thread_id", "id"},
+			{"thread", "1", "1"},
+			{"comment", "1", "10"},
+			{"event", "1", "21"},
+			{"thread", "2", "2"},
+			{"event", "2", "22"},
+			{"thread", "3", "3"},
+			{"event", "3", "23"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got records %v, want %v", got, want)
+		}
+		if contents := records[2][7]; contents != "hello, world" {
+			t.Errorf("got comment contents %q, want %q", contents, "hello, world")
+		}
+	})
+}
+
+func TestExportListOptions(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	db.Mocks.Repos.GetByName = func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{ID: 2, Name: name}, nil
+	}
+
+	if _, err := ExportListOptions(context.Background(), ExportTarget{RepoName: "r"}); err == nil {
+		t.Error("expected error for unauthenticated user")
+	}
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	if _, err := ExportListOptions(ctx, ExportTarget{}); err != ErrInvalidExportTarget {
+		t.Errorf("got error %v, want %v", err, ErrInvalidExportTarget)
+	}
+	if _, err := ExportListOptions(ctx, ExportTarget{RepoName: "r", OrgName: "o"}); err != ErrInvalidExportTarget {
+		t.Errorf("got error %v, want %v", err, ErrInvalidExportTarget)
+	}
+	opts, err := ExportListOptions(ctx, ExportTarget{RepoName: "r"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.TargetRepoID == nil || *opts.TargetRepoID != 2 {
+		t.Errorf("got options %+v, want threads in repository 2", opts)
+	}
+}
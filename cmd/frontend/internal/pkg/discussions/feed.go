@@ -0,0 +1,166 @@
+package discussions
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/router"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+// feedEntriesLimit is the maximum number of threads in a feed.
+const feedEntriesLimit = 50
+
+// URLToFeed returns the absolute URL of the Atom feed for the feed token with
+// the given value.
+//
+// 🚨 SECURITY: The caller must ensure the URL is ONLY given to the token's
+// user, as it embeds the token's value.
+func URLToFeed(token string) (*url.URL, error) {
+	feedPath, err := router.Router().Get(router.DiscussionsFeed).URLPath()
+	if err != nil {
+		return nil, err
+	}
+	return globals.ExternalURL().ResolveReference(&url.URL{
+		Path:     feedPath.Path,
+		RawQuery: url.Values{"token": []string{token}}.Encode(),
+	}), nil
+}
+
+// atomFeed is an Atom feed document (RFC 4287).
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Author    atomAuthor `xml:"author"`
+	Link      *atomLink  `xml:"link"`
+	Summary   string     `xml:"summary"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// WriteFeed writes the Atom feed of the feed token's threads to w, most
+// recently updated first: the threads of a repository, or the threads
+// assigned to or mentioning the token's user.
+func WriteFeed(ctx context.Context, w io.Writer, token *types.DiscussionFeedToken) error {
+	// 🚨 SECURITY: The feed only lists the threads that the token's user can
+	// view.
+	ctx = actor.WithActor(ctx, actor.FromUser(token.UserID))
+
+	user, err := db.Users.GetByID(ctx, token.UserID)
+	if err != nil {
+		return errors.Wrap(err, "Users.GetByID")
+	}
+	opts := &db.DiscussionThreadsListOptions{LimitOffset: &db.LimitOffset{Limit: feedEntriesLimit}}
+	feed := atomFeed{ID: feedTagURI(fmt.Sprintf("feeds/%d", token.ID))}
+	switch token.Kind {
+	case types.DiscussionFeedRepository:
+		if token.RepoID == nil {
+			return errors.New("repository feed has no repository")
+		}
+		repo, err := db.Repos.Get(ctx, *token.RepoID)
+		if err != nil {
+			return errors.Wrap(err, "Repos.Get")
+		}
+		opts.TargetRepoID = token.RepoID
+		feed.Title = fmt.Sprintf("Discussions in %s", repo.Name)
+	case types.DiscussionFeedAssigned:
+		opts.AssigneeUserID = token.UserID
+		feed.Title = fmt.Sprintf("Discussions assigned to @%s", user.Username)
+	case types.DiscussionFeedMentioned:
+		opts.MentionedUserID = token.UserID
+		feed.Title = fmt.Sprintf("Discussions mentioning @%s", user.Username)
+	default:
+		return fmt.Errorf("invalid feed kind %q", token.Kind)
+	}
+	threads, err := db.DiscussionThreads.List(ctx, opts)
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.List")
+	}
+
+	updated := token.CreatedAt
+	authors := map[int32]string{}
+	for _, thread := range threads {
+		if thread.UpdatedAt.After(updated) {
+			updated = thread.UpdatedAt
+		}
+		author, ok := authors[thread.AuthorUserID]
+		if !ok {
+			u, err := db.Users.GetByID(ctx, thread.AuthorUserID)
+			if err != nil {
+				return errors.Wrap(err, "Author: Users.GetByID")
+			}
+			author = u.Username
+			authors[thread.AuthorUserID] = author
+		}
+		entry := atomEntry{
+			ID:        feedTagURI(fmt.Sprintf("threads/%d", thread.ID)),
+			Title:     thread.Title,
+			Updated:   thread.UpdatedAt.UTC().Format(time.RFC3339),
+			Published: thread.CreatedAt.UTC().Format(time.RFC3339),
+			Author:    atomAuthor{Name: author},
+			Summary:   feedEntrySummary(thread),
+		}
+		u, err := URLToInlineThread(ctx, thread)
+		if err != nil {
+			return errors.Wrap(err, "URLToInlineThread")
+		}
+		if u != nil {
+			entry.Link = &atomLink{Href: globals.ExternalURL().ResolveReference(u).String()}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	feed.Updated = updated.UTC().Format(time.RFC3339)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(feed)
+}
+
+// feedTagURI returns a tag URI (RFC 4151) that identifies a feed or an entry
+// on this Sourcegraph instance.
+func feedTagURI(specific string) string {
+	return fmt.Sprintf("tag:%s,2019:discussions/%s", globals.ExternalURL().Hostname(), specific)
+}
+
+// feedEntrySummary describes the thread's state and comment count.
+func feedEntrySummary(thread *types.DiscussionThread) string {
+	state := "Open"
+	switch {
+	case thread.ArchivedAt != nil:
+		state = "Archived"
+	case thread.StaleAt != nil:
+		state = "Stale"
+	}
+	comments := "comments"
+	if thread.CommentCount == 1 {
+		comments = "comment"
+	}
+	return fmt.Sprintf("%s, %d %s", state, thread.CommentCount, comments)
+}
@@ -0,0 +1,66 @@
+package discussions
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestWriteFeed(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	ctx := context.Background()
+	createdAt := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	path := "f"
+	repoID := api.RepoID(4)
+
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: map[int32]string{1: "alice", 2: "bob"}[id]}, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "github.com/foo/bar"}, nil
+	}
+	db.Mocks.DiscussionThreads.List = func(ctx context.Context, opts *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if a := actor.FromContext(ctx); a.UID != 1 {
+			t.Errorf("got actor %+v, want user 1", a)
+		}
+		if opts.TargetRepoID == nil || *opts.TargetRepoID != repoID {
+			t.Errorf("got TargetRepoID %v, want %d", opts.TargetRepoID, repoID)
+		}
+		return []*types.DiscussionThread{
+			{ID: 10, AuthorUserID: 2, Title: "a", CommentCount: 1, CreatedAt: createdAt, UpdatedAt: updatedAt, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: repoID, Path: &path}},
+			{ID: 11, AuthorUserID: 1, Title: "b", CommentCount: 3, ArchivedAt: &createdAt, CreatedAt: createdAt, UpdatedAt: createdAt, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: repoID}},
+		}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := WriteFeed(ctx, &buf, &types.DiscussionFeedToken{ID: 5, UserID: 1, Kind: types.DiscussionFeedRepository, RepoID: &repoID, CreatedAt: createdAt}); err != nil {
+		t.Fatal(err)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &feed); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Discussions in github.com/foo/bar"; feed.Title != want {
+		t.Errorf("got title %q, want %q", feed.Title, want)
+	}
+	if want := updatedAt.Format(time.RFC3339); feed.Updated != want {
+		t.Errorf("got updated %q, want %q", feed.Updated, want)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(feed.Entries))
+	}
+	if e := feed.Entries[0]; e.Title != "a" || e.Author.Name != "bob" || e.Summary != "Open, 1 comment" || e.Link == nil {
+		t.Errorf("unexpected first entry %+v", e)
+	}
+	if e := feed.Entries[1]; e.Author.Name != "alice" || e.Summary != "Archived, 3 comments" || e.Link != nil {
+		t.Errorf("unexpected second entry %+v", e)
+	}
+}
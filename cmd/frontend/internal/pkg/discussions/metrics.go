@@ -0,0 +1,41 @@
+package discussions
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var threadEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "src",
+	Subsystem: "discussions",
+	Name:      "thread_events_total",
+	Help:      "The total number of events appended to discussion thread timelines, by kind (e.g. CREATED, COMMENTED, ARCHIVED).",
+}, []string{"kind"})
+
+var webhookDeliveryAttemptsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "src",
+	Subsystem: "discussions",
+	Name:      "webhook_delivery_attempts_total",
+	Help:      "The total number of attempts to deliver a thread event to a webhook.",
+}, []string{"success"})
+
+var webhookDeliveryDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: "src",
+	Subsystem: "discussions",
+	Name:      "webhook_delivery_attempt_duration_seconds",
+	Help:      "Time spent on a single attempt to deliver a thread event to a webhook.",
+	Buckets:   []float64{0.05, 0.1, 0.2, 0.5, 1, 2, 5, 10},
+})
+
+var webhookDeliveriesFailedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "src",
+	Subsystem: "discussions",
+	Name:      "webhook_deliveries_failed_total",
+	Help:      "The total number of thread event deliveries to webhooks that were given up on after all attempts failed.",
+})
+
+func init() {
+	prometheus.MustRegister(threadEventsTotal)
+	prometheus.MustRegister(webhookDeliveryAttemptsTotal)
+	prometheus.MustRegister(webhookDeliveryDuration)
+	prometheus.MustRegister(webhookDeliveriesFailedTotal)
+}
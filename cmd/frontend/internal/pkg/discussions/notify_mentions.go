@@ -12,6 +12,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/mentions"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/markdown"
@@ -25,6 +27,7 @@ import (
 //
 // It returns immediately and does not block.
 func NotifyNewThread(newThread *types.DiscussionThread, newComment *types.DiscussionComment) {
+	notifyChannels(channelEventThreadCreated, newComment.AuthorUserID, newThread.ID, newComment)
 	notifyMentions(&notifier{
 		typ:               newThreadNotification,
 		eventAuthorUserID: newComment.AuthorUserID,
@@ -39,6 +42,7 @@ func NotifyNewThread(newThread *types.DiscussionThread, newComment *types.Discus
 //
 // It returns immediately and does not block.
 func NotifyNewComment(updatedThread *types.DiscussionThread, newComment *types.DiscussionComment) {
+	notifyChannels(channelEventCommented, newComment.AuthorUserID, updatedThread.ID, newComment)
 	notifyMentions(&notifier{
 		typ:               newCommentNotification,
 		eventAuthorUserID: newComment.AuthorUserID,
@@ -76,50 +80,96 @@ type notifier struct {
 	thread            *types.DiscussionThread
 	comment           *types.DiscussionComment
 	template          txtypes.Templates
+
+	// recipients, if non-nil, is the list of IDs of users who are newly
+	// mentioned by the event (e.g. by an edited comment). Only these users
+	// are notified, instead of all subscribers.
+	recipients []int32
+
+	// mentioned is the set of usernames mentioned by the event. It is
+	// populated by subscribers.
+	mentioned map[string]struct{}
 }
 
-// subscribers returns a list of all usernames who are subscribed to receive
-// notifications from the thread. Currently, there is no underlying
-// subscription store, so we rely on some simple mechanics to get a good-enough
-// result:
+// subscribers returns a list of all usernames who should be notified of the
+// event:
 //
-// 	1. If you were previously mentioned in the thread, you are subscribed.
-// 	2. If you previously authored a comment, you are subscribed.
+// 	1. Users who are subscribed to the thread (see
+// 	   db.DiscussionThreadSubscriptions).
+// 	2. Users who are mentioned by the event, even if they unsubscribed from
+// 	   the thread. Unless they unsubscribed, they are also automatically
+// 	   subscribed to the thread so that they are notified of future activity.
+// 	   Mentioning an organization mentions all of its members.
 //
+// If n.recipients is set, only those users are returned (and auto-subscribed).
 func (n *notifier) subscribers(ctx context.Context) ([]string, error) {
-	comments, err := db.DiscussionComments.List(ctx, &db.DiscussionCommentsListOptions{
-		LimitOffset: &db.LimitOffset{
-			Limit: 1000,
-		},
-		ThreadID: &n.thread.ID,
-	})
-	if err != nil {
-		return nil, err
-	}
 	var (
 		subscribers []string
 		set         = make(map[string]struct{})
 	)
-	for _, mention := range mentions.Parse(n.thread.Title) {
-		if _, ok := set[mention]; !ok {
-			set[mention] = struct{}{}
-			subscribers = append(subscribers, mention)
+	n.mentioned = make(map[string]struct{})
+	add := func(username string) {
+		if _, ok := set[username]; !ok {
+			set[username] = struct{}{}
+			subscribers = append(subscribers, username)
 		}
 	}
-	for _, comment := range comments {
-		commentAuthor, err := db.Users.GetByID(ctx, comment.AuthorUserID)
+
+	var mentionedUserIDs []int32
+	if n.recipients != nil {
+		mentionedUserIDs = n.recipients
+	} else {
+		names := mentions.Parse(n.comment.Contents)
+		if n.typ == newThreadNotification {
+			names = append(mentions.Parse(n.thread.Title), names...)
+		}
+		users, orgs, err := resolveMentions(ctx, names)
 		if err != nil {
-			return nil, errors.Wrap(err, "CommentAuthor: GetByID")
+			return nil, err
 		}
-		if _, ok := set[commentAuthor.Username]; !ok {
-			set[commentAuthor.Username] = struct{}{}
-			subscribers = append(subscribers, commentAuthor.Username)
+		var userIDs, orgIDs []int32
+		for _, user := range users {
+			userIDs = append(userIDs, user.ID)
 		}
-		for _, mention := range mentions.Parse(comment.Contents) {
-			if _, ok := set[mention]; !ok {
-				set[mention] = struct{}{}
-				subscribers = append(subscribers, mention)
-			}
+		for _, org := range orgs {
+			orgIDs = append(orgIDs, org.ID)
+		}
+		mentionedUserIDs, err = expandMentions(ctx, userIDs, orgIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(mentionedUserIDs) > 0 {
+		users, err := db.Users.List(ctx, &db.UsersListOptions{UserIDs: mentionedUserIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "Users.List")
+		}
+		for _, user := range users {
+			add(user.Username)
+			n.mentioned[user.Username] = struct{}{}
+		}
+	}
+	if err := db.DiscussionThreadSubscriptions.AutoSubscribe(ctx, n.thread.ID, mentionedUserIDs); err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadSubscriptions.AutoSubscribe")
+	}
+	if n.recipients != nil {
+		return subscribers, nil
+	}
+
+	// Mentioned users (added above) are notified regardless of their
+	// notification level, but other subscribers only if they want to be
+	// notified of comments.
+	userIDs, err := db.DiscussionThreadSubscriptions.ListNotifiedUserIDs(ctx, n.thread.ID, types.DiscussionThreadNotifyComments)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadSubscriptions.ListNotifiedUserIDs")
+	}
+	if len(userIDs) > 0 {
+		users, err := db.Users.List(ctx, &db.UsersListOptions{UserIDs: userIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "Users.List")
+		}
+		for _, user := range users {
+			add(user.Username)
 		}
 	}
 	return subscribers, nil
@@ -139,6 +189,16 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 		// Do not send notifications to the user who created the event.
 		return nil
 	}
+	pref := commentsEmailPreference
+	if _, ok := n.mentioned[user.Username]; ok {
+		pref = mentionsEmailPreference
+	}
+	if ok, err := wantsEmail(ctx, user.ID, pref); err != nil {
+		return err
+	} else if !ok {
+		// The user turned off these emails in their settings.
+		return nil
+	}
 
 	var (
 		replyTo    *string
@@ -173,8 +233,10 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 
 		// Get a list of prior comments in the thread and generate the
 		// references list. This makes e.g. Gmail understand that this email is
-		// part of the thread.
-		comments, err := db.DiscussionComments.List(ctx, &db.DiscussionCommentsListOptions{
+		// part of the thread. The user is notified because they may read the
+		// thread, so its comments are listed without checking permissions.
+		internalCtx := actor.WithActor(ctx, &actor.Actor{Internal: true})
+		comments, err := db.DiscussionComments.List(internalCtx, &db.DiscussionCommentsListOptions{
 			LimitOffset: &db.LimitOffset{
 				Limit: 100,
 			},
@@ -218,15 +280,10 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 		codeContextHTML template.HTML
 	)
 	if n.thread.TargetRepo != nil {
-		repo, err := db.Repos.Get(ctx, n.thread.TargetRepo.RepoID)
+		repoShortName, err = shortRepoName(ctx, n.thread.TargetRepo.RepoID)
 		if err != nil {
-			return errors.Wrap(err, "repoShortName: db.Repos.Get")
-		}
-		split := strings.Split(string(repo.Name), "/")
-		if len(split) > 2 {
-			split = split[len(split)-2:]
+			return err
 		}
-		repoShortName = strings.Join(split, "/")
 		if n.thread.TargetRepo.Path != nil {
 			fileName = path.Base(*n.thread.TargetRepo.Path)
 		}
@@ -238,6 +295,11 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 		}
 	}
 
+	unsubscribeURL, err := URLToUnsubscribe(ctx, user.ID, n.thread.ID)
+	if err != nil {
+		return err
+	}
+
 	commentAuthor, err := db.Users.GetByID(ctx, n.comment.AuthorUserID)
 	if err != nil {
 		return errors.Wrap(err, "CommentAuthor: GetByID")
@@ -262,6 +324,7 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 			URL                   string
 			UniqueValue           string
 			CanReply              bool
+			UnsubscribeURL        string
 
 			// These fields may be empty strings depending on the type of comment..
 			RepoName        string
@@ -276,6 +339,7 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 			URL:                   url.String(),
 			UniqueValue:           fmt.Sprint(n.comment.ID),
 			CanReply:              conf.CanReadEmail(),
+			UnsubscribeURL:        unsubscribeURL.String(),
 
 			RepoName:        repoShortName,
 			FileName:        fileName,
@@ -285,6 +349,26 @@ func (n *notifier) notifyUsername(ctx context.Context, username string) error {
 	})
 }
 
+// shortRepoName returns the shortened name of the repository (see
+// shortenRepoName), for use in email subjects.
+func shortRepoName(ctx context.Context, repoID api.RepoID) (string, error) {
+	repo, err := db.Repos.Get(ctx, repoID)
+	if err != nil {
+		return "", errors.Wrap(err, "repoShortName: db.Repos.Get")
+	}
+	return shortenRepoName(repo.Name), nil
+}
+
+// shortenRepoName returns the last two path components of the repository
+// name (e.g. "gorilla/mux" for "github.com/gorilla/mux").
+func shortenRepoName(name api.RepoName) string {
+	split := strings.Split(string(name), "/")
+	if len(split) > 2 {
+		split = split[len(split)-2:]
+	}
+	return strings.Join(split, "/")
+}
+
 var (
 	sharedCommentSubjectTemplate = `
 {{- with .RepoName -}}
@@ -313,6 +397,9 @@ var (
 {{- "\n" -}}
 {{- "  " -}}{{- .URL -}}
 {{- "\n" -}}
+{{- "\n" -}}
+{{- "Unsubscribe from this thread: " -}}{{- .UnsubscribeURL -}}
+{{- "\n" -}}
 `
 
 	sharedCommentHTMLTemplate = `
@@ -340,6 +427,7 @@ var (
 {{else}}
 	<p style="font-size: small; color: #666;">—<br/><a href="{{.URL}}">View and reply on Sourcegraph</a></p>
 {{end}}
+<p style="font-size: small; color: #666;"><a href="{{.UnsubscribeURL}}">Unsubscribe from this thread</a></p>
 <!-- this ensures Gmail doesn't trim the email -->
 <span style="opacity: 0">{{.UniqueValue}}</span>
 </body>
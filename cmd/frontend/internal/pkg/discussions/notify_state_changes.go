@@ -0,0 +1,157 @@
+package discussions
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// stateChangeVerbs describes the thread event kinds whose subscribers are
+// notified by email.
+var stateChangeVerbs = map[types.DiscussionThreadEventKind]string{
+	types.DiscussionThreadEventArchived:   "archived",
+	types.DiscussionThreadEventUnarchived: "unarchived",
+	types.DiscussionThreadEventClosed:     "closed",
+	types.DiscussionThreadEventReopened:   "reopened",
+	types.DiscussionThreadEventLocked:     "locked",
+	types.DiscussionThreadEventUnlocked:   "unlocked",
+}
+
+// notifyStateChange sends an email to the thread's subscribers if the event
+// changed the thread's state (e.g. archived it). Subscribers who chose to only
+// be notified of comments are skipped.
+//
+// It returns immediately and does not block.
+func notifyStateChange(event *types.DiscussionThreadEvent) {
+	verb, ok := stateChangeVerbs[event.Kind]
+	if !ok || !conf.CanSendEmail() {
+		return
+	}
+	goroutine.Go(func() {
+		ctx := context.Background()
+		thread, err := db.DiscussionThreads.Get(ctx, event.ThreadID)
+		if err != nil {
+			log15.Error("discussions: notifyStateChange: DiscussionThreads.Get", "threadID", event.ThreadID, "error", err)
+			return
+		}
+		userIDs, err := db.DiscussionThreadSubscriptions.ListNotifiedUserIDs(ctx, thread.ID, types.DiscussionThreadNotifyStateChanges)
+		if err != nil {
+			log15.Error("discussions: notifyStateChange: ListNotifiedUserIDs", "threadID", thread.ID, "error", err)
+			return
+		}
+		for _, userID := range userIDs {
+			if userID == event.ActorUserID {
+				// Do not send notifications to the user who created the event.
+				continue
+			}
+			if err := notifyUserOfStateChange(ctx, userID, thread, event, verb); err != nil {
+				log15.Error("discussions: notifyUserOfStateChange", "userID", userID, "threadID", thread.ID, "error", err)
+			}
+		}
+	})
+}
+
+func notifyUserOfStateChange(ctx context.Context, userID int32, thread *types.DiscussionThread, event *types.DiscussionThreadEvent, verb string) error {
+	if ok, err := wantsEmail(ctx, userID, stateChangesEmailPreference); err != nil {
+		return err
+	} else if !ok {
+		// The user turned off these emails in their settings.
+		return nil
+	}
+
+	url, err := URLToInlineThread(ctx, thread)
+	if err != nil {
+		return errors.Wrap(err, "URLToInlineThread")
+	}
+	if url == nil {
+		return nil // can't generate a link to this thread target type
+	}
+	q := url.Query()
+	q.Set("utm_source", "email")
+	url.RawQuery = q.Encode()
+
+	email, verified, err := db.UserEmails.GetPrimaryEmail(ctx, userID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return errors.Wrap(err, "GetPrimaryEmail")
+	}
+	if errcode.IsNotFound(err) || !verified {
+		// User has no email or it is not verified, do not send them any emails.
+		return nil
+	}
+
+	var repoShortName string
+	if thread.TargetRepo != nil {
+		repoShortName, err = shortRepoName(ctx, thread.TargetRepo.RepoID)
+		if err != nil {
+			return err
+		}
+	}
+
+	unsubscribeURL, err := URLToUnsubscribe(ctx, userID, thread.ID)
+	if err != nil {
+		return err
+	}
+
+	actor, err := db.Users.GetByID(ctx, event.ActorUserID)
+	if err != nil {
+		return errors.Wrap(err, "Actor: GetByID")
+	}
+	fromName := actor.DisplayName
+	if fromName == "" {
+		fromName = actor.Username
+	}
+
+	return txemail.Send(ctx, txemail.Message{
+		To:       []string{email},
+		FromName: fromName,
+		Template: stateChangeEmailTemplate,
+		Data: struct {
+			ThreadTitle    string
+			ActorUsername  string
+			Verb           string
+			URL            string
+			UnsubscribeURL string
+			RepoName       string
+		}{
+			ThreadTitle:    thread.Title,
+			ActorUsername:  actor.Username,
+			Verb:           verb,
+			URL:            url.String(),
+			UnsubscribeURL: unsubscribeURL.String(),
+			RepoName:       repoShortName,
+		},
+	})
+}
+
+var stateChangeEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: sharedCommentSubjectTemplate,
+	Text: `
+{{- "@" -}}{{- .ActorUsername -}}{{- " " -}}{{- .Verb -}}{{- " this thread." -}}
+{{- "\n" -}}
+{{- "—\n" -}}
+{{- "View it on Sourcegraph:\n" -}}
+{{- "\n" -}}
+{{- "  " -}}{{- .URL -}}
+{{- "\n" -}}
+{{- "\n" -}}
+{{- "Unsubscribe from this thread: " -}}{{- .UnsubscribeURL -}}
+{{- "\n" -}}
+`,
+	HTML: `
+<html>
+<body>
+<p><strong>@{{.ActorUsername}}</strong> {{.Verb}} this thread.</p>
+<p style="font-size: small; color: #666;">—<br/><a href="{{.URL}}">View it on Sourcegraph</a></p>
+<p style="font-size: small; color: #666;"><a href="{{.UnsubscribeURL}}">Unsubscribe from this thread</a></p>
+</body>
+</html>
+`,
+})
@@ -0,0 +1,37 @@
+package discussions
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// emailPreference selects one of the
+// schema.DiscussionsEmailNotifications settings.
+type emailPreference func(*schema.DiscussionsEmailNotifications) *bool
+
+var (
+	commentsEmailPreference     emailPreference = func(s *schema.DiscussionsEmailNotifications) *bool { return s.Comments }
+	mentionsEmailPreference     emailPreference = func(s *schema.DiscussionsEmailNotifications) *bool { return s.Mentions }
+	stateChangesEmailPreference emailPreference = func(s *schema.DiscussionsEmailNotifications) *bool { return s.StateChanges }
+)
+
+// wantsEmail reports whether the user's "discussions.emailNotifications" user
+// settings allow sending them the kind of email selected by pref. All kinds
+// of emails are sent by default.
+func wantsEmail(ctx context.Context, userID int32, pref emailPreference) (bool, error) {
+	settings, err := backend.Configuration.GetForSubject(ctx, api.SettingsSubject{User: &userID})
+	if err != nil {
+		return false, errors.Wrap(err, "Configuration.GetForSubject")
+	}
+	if settings.DiscussionsEmailNotifications == nil {
+		return true, nil
+	}
+	if v := pref(settings.DiscussionsEmailNotifications); v != nil {
+		return *v, nil
+	}
+	return true, nil
+}
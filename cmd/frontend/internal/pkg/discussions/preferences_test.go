@@ -0,0 +1,45 @@
+package discussions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestWantsEmail(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	tests := map[string]struct {
+		settings string
+		pref     emailPreference
+		want     bool
+	}{
+		"no settings":          {pref: commentsEmailPreference, want: true},
+		"unrelated settings":   {settings: `{"motd": ["hi"]}`, pref: commentsEmailPreference, want: true},
+		"unset preference":     {settings: `{"discussions.emailNotifications": {"mentions": false}}`, pref: commentsEmailPreference, want: true},
+		"disabled preference":  {settings: `{"discussions.emailNotifications": {"mentions": false}}`, pref: mentionsEmailPreference, want: false},
+		"enabled preference":   {settings: `{"discussions.emailNotifications": {"stateChanges": true}}`, pref: stateChangesEmailPreference, want: true},
+		"comments in settings": {settings: "{\n  // no emails\n  \"discussions.emailNotifications\": {\"comments\": false},\n}", pref: commentsEmailPreference, want: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db.Mocks.Settings.GetLatest = func(_ context.Context, subject api.SettingsSubject) (*api.Settings, error) {
+				if subject.User == nil || *subject.User != 1 {
+					t.Errorf("got settings subject %+v, want user 1", subject)
+				}
+				if test.settings == "" {
+					return nil, nil
+				}
+				return &api.Settings{Subject: subject, Contents: test.settings}, nil
+			}
+			got, err := wantsEmail(context.Background(), 1, test.pref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned when a user exceeds the configured per-user rate
+// limits (see the "discussions.rateLimits" site configuration property).
+type ErrRateLimited struct {
+	// Action is what the user was rate limited for doing, e.g. "threads".
+	Action string
+
+	// RetryAfter is how long the user must wait until they may try again.
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("You are creating %s too quickly. You may create a new one after %v", e.Action, e.RetryAfter.Round(time.Second))
+}
+
+// Extensions implements the GraphQL error extensions interface, so that
+// clients can detect rate limiting without parsing the error message.
+func (e *ErrRateLimited) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":       "RATE_LIMITED",
+		"retryAfter": int(math.Ceil(e.RetryAfter.Seconds())),
+	}
+}
+
+// buckets is a set of per-user token buckets that all refill at the same
+// rate. Buckets are held in memory, so each frontend replica enforces the
+// limits separately.
+type buckets struct {
+	mu        sync.Mutex
+	limit     int           // the configured number of actions per period
+	period    time.Duration // the period that limit applies to
+	byUser    map[int32]*bucket
+	lastSweep time.Time // when full buckets were last removed from byUser
+	nowFunc   func() time.Time
+}
+
+// bucket is a single user's token bucket.
+type bucket struct {
+	limiter  *rate.Limiter
+	lastTake time.Time // when a token was last taken
+}
+
+// take takes a token from the user's bucket, which holds at most limit tokens
+// and refills at limit tokens per period. If the bucket is empty, it returns
+// how long the user must wait until a token is available (and no token is
+// taken). A limit of 0 disables rate limiting.
+func (b *buckets) take(userID int32, limit int) (mustWait time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if limit <= 0 {
+		b.limit, b.byUser = 0, nil
+		return 0
+	}
+	if limit != b.limit || b.byUser == nil {
+		// The configuration changed, so start with full buckets.
+		b.limit = limit
+		b.byUser = map[int32]*bucket{}
+	}
+
+	now := time.Now()
+	if b.nowFunc != nil {
+		now = b.nowFunc()
+	}
+	b.sweep(now)
+	u, ok := b.byUser[userID]
+	if !ok {
+		u = &bucket{limiter: rate.NewLimiter(rate.Limit(float64(limit)/b.period.Seconds()), limit)}
+		b.byUser[userID] = u
+	}
+	r := u.limiter.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay
+	}
+	u.lastTake = now
+	return 0
+}
+
+// sweep removes the buckets that have refilled completely (which is the case
+// once a period has passed since a token was last taken), so that the buckets
+// of users who are no longer active do not accumulate. A removed bucket is
+// recreated full when it is needed again, so removing it changes nothing
+// else. Sweeping is done at most once per period. The caller must hold b.mu.
+func (b *buckets) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < b.period {
+		return
+	}
+	b.lastSweep = now
+	for userID, u := range b.byUser {
+		if now.Sub(u.lastTake) >= b.period {
+			delete(b.byUser, userID)
+		}
+	}
+}
+
+var (
+	threadBuckets  = &buckets{period: time.Hour}
+	commentBuckets = &buckets{period: time.Minute}
+)
+
+// CheckCanCreateThread returns an *ErrRateLimited error if the user has
+// exceeded the configured number of threads per hour. Otherwise, it counts a
+// thread as created by the user.
+//
+// This ONLY considers rate limiting, it does NOT verify the user otherwise has
+// permission to create discussion threads.
+func CheckCanCreateThread(userID int32) error {
+	var limit int
+	if dc := conf.Get().Discussions; dc != nil && dc.RateLimits != nil {
+		limit = dc.RateLimits.ThreadsPerHour
+	}
+	if mustWait := threadBuckets.take(userID, limit); mustWait != 0 {
+		return &ErrRateLimited{Action: "threads", RetryAfter: mustWait}
+	}
+	return nil
+}
+
+// CheckCanAddComment returns an *ErrRateLimited error if the user has exceeded
+// the configured number of comments per minute. Otherwise, it counts a comment
+// as added by the user.
+//
+// This ONLY considers rate limiting, it does NOT verify the user otherwise has
+// permission to add comments.
+func CheckCanAddComment(userID int32) error {
+	var limit int
+	if dc := conf.Get().Discussions; dc != nil && dc.RateLimits != nil {
+		limit = dc.RateLimits.CommentsPerMinute
+	}
+	if mustWait := commentBuckets.take(userID, limit); mustWait != 0 {
+		return &ErrRateLimited{Action: "comments", RetryAfter: mustWait}
+	}
+	return nil
+}
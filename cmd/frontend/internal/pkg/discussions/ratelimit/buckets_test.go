@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuckets(t *testing.T) {
+	now := time.Now()
+	b := &buckets{period: time.Minute, nowFunc: func() time.Time { return now }}
+
+	// A full bucket allows a burst of up to the limit.
+	for i := 0; i < 3; i++ {
+		if mustWait := b.take(1, 3); mustWait != 0 {
+			t.Fatalf("action %d: got mustWait %v, want 0", i, mustWait)
+		}
+	}
+	if mustWait := b.take(1, 3); mustWait != 20*time.Second {
+		t.Errorf("got mustWait %v, want 20s", mustWait)
+	}
+
+	// Other users have their own buckets.
+	if mustWait := b.take(2, 3); mustWait != 0 {
+		t.Errorf("other user: got mustWait %v, want 0", mustWait)
+	}
+
+	// The bucket refills over time, and a rejected action does not consume a
+	// token.
+	now = now.Add(20 * time.Second)
+	if mustWait := b.take(1, 3); mustWait != 0 {
+		t.Errorf("after refill: got mustWait %v, want 0", mustWait)
+	}
+	if mustWait := b.take(1, 3); mustWait == 0 {
+		t.Error("after refill: expected second action to be rate limited")
+	}
+
+	// Changing the limit resets the buckets, and a limit of 0 disables rate
+	// limiting.
+	if mustWait := b.take(1, 4); mustWait != 0 {
+		t.Errorf("after limit change: got mustWait %v, want 0", mustWait)
+	}
+	for i := 0; i < 10; i++ {
+		if mustWait := b.take(1, 0); mustWait != 0 {
+			t.Fatalf("unlimited: got mustWait %v, want 0", mustWait)
+		}
+	}
+}
+
+func TestBuckets_sweep(t *testing.T) {
+	now := time.Now()
+	b := &buckets{period: time.Minute, nowFunc: func() time.Time { return now }}
+	for i := 0; i < 3; i++ {
+		b.take(1, 3)
+	}
+	now = now.Add(30 * time.Second)
+	b.take(2, 3)
+
+	// User 1's bucket has refilled after a period, so it is removed, but user
+	// 2's bucket has not.
+	now = now.Add(40 * time.Second)
+	b.take(3, 3)
+	if _, ok := b.byUser[1]; ok {
+		t.Error("expected the full bucket of user 1 to be removed")
+	}
+	if _, ok := b.byUser[2]; !ok {
+		t.Error("expected the bucket of user 2 to be kept")
+	}
+
+	// A removed bucket is recreated full.
+	for i := 0; i < 3; i++ {
+		if mustWait := b.take(1, 3); mustWait != 0 {
+			t.Fatalf("action %d: got mustWait %v, want 0", i, mustWait)
+		}
+	}
+}
+
+func TestErrRateLimited(t *testing.T) {
+	err := &ErrRateLimited{Action: "comments", RetryAfter: 1500 * time.Millisecond}
+	if want := "You are creating comments too quickly. You may create a new one after 2s"; err.Error() != want {
+		t.Errorf("got error %q, want %q", err.Error(), want)
+	}
+	if want := map[string]interface{}{"code": "RATE_LIMITED", "retryAfter": 2}; !reflect.DeepEqual(err.Extensions(), want) {
+		t.Errorf("got extensions %v, want %v", err.Extensions(), want)
+	}
+}
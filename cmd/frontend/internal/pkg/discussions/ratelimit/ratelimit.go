@@ -9,9 +9,17 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/mentions"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	log15 "gopkg.in/inconshreveable/log15.v2"
 )
 
+// internalActor returns ctx with an internal actor, so that all of a user's
+// recent comments are counted (including those on threads that the user can
+// no longer read).
+func internalActor(ctx context.Context) context.Context {
+	return actor.WithActor(ctx, &actor.Actor{Internal: true})
+}
+
 type limit struct {
 	// maxActions describes the maximum number of actions that can be performed
 	// within the sliding window period.
@@ -206,7 +214,7 @@ func TimeUntilUserCanAddCommentToThread(ctx context.Context, userID int32, newCo
 	// Determine how many comments the user has created in the last window
 	// period.
 	createdAfter := time.Now().Add(-addCommentLimit.window)
-	comments, err := db.DiscussionComments.List(ctx, &db.DiscussionCommentsListOptions{
+	comments, err := db.DiscussionComments.List(internalActor(ctx), &db.DiscussionCommentsListOptions{
 		AuthorUserID: &userID,
 		CreatedAfter: &createdAfter,
 	})
@@ -248,7 +256,7 @@ func timeUntilUserCanMention(ctx context.Context, userID int32, newContents stri
 	// Determine how many comments the user has created in the last window
 	// period.
 	createdAfter := time.Now().Add(-mentionsLimit.window)
-	comments, err := db.DiscussionComments.List(ctx, &db.DiscussionCommentsListOptions{
+	comments, err := db.DiscussionComments.List(internalActor(ctx), &db.DiscussionCommentsListOptions{
 		CreatedAfter: &createdAfter,
 		AuthorUserID: &userID,
 	})
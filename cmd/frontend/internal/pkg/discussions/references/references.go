@@ -0,0 +1,59 @@
+// Package references provides utilities for references to other threads in
+// discussions.
+package references
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+var (
+	// code matches fenced code blocks and inline code, in which references
+	// are not parsed.
+	code = lazyregexp.New("(?s)```.*?```|`[^`\n]*`")
+
+	references = lazyregexp.New(`(^|[^\w&/])#(\d+)\b|https?://\S+`)
+)
+
+// Parse parses the thread references (like #123, or a URL to a thread on the
+// Sourcegraph instance at externalURL) from the given markdown comment
+// contents and returns the referenced thread IDs, without duplicates.
+func Parse(contents string, externalURL *url.URL) []int64 {
+	contents = code.ReplaceAllString(contents, " ")
+
+	var (
+		ids  []int64
+		seen = map[int64]struct{}{}
+	)
+	for _, groups := range references.FindAllStringSubmatch(contents, -1) {
+		idText := groups[2]
+		if idText == "" {
+			idText = threadIDFromURL(groups[0], externalURL)
+		}
+		id, err := strconv.ParseInt(idText, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// threadIDFromURL returns the value of the threadID parameter (in the fragment,
+// as in the URLs returned by discussions.URLToInlineThread, or in the query) of
+// a URL on the Sourcegraph instance.
+func threadIDFromURL(rawURL string, externalURL *url.URL) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || externalURL == nil || u.Host != externalURL.Host {
+		return ""
+	}
+	if fragment, err := url.ParseQuery(u.Fragment); err == nil && fragment.Get("threadID") != "" {
+		return fragment.Get("threadID")
+	}
+	return u.Query().Get("threadID")
+}
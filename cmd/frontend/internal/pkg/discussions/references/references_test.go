@@ -0,0 +1,39 @@
+package references
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	externalURL := &url.URL{Scheme: "https", Host: "sourcegraph.example.com"}
+	tests := []struct {
+		name, input string
+		want        []int64
+	}{
+		{
+			name:  "basic",
+			input: "#1",
+			want:  []int64{1},
+		},
+		{
+			name:  "complex",
+			input: "see #1, (#2) and #1\nbut not a#3, #4a, or `#5`\n```\n#6\n```",
+			want:  []int64{1, 2},
+		},
+		{
+			name:  "URLs",
+			input: "https://sourcegraph.example.com/r/-/blob/f#L3&tab=discussions&threadID=7 https://sourcegraph.example.com/x?threadID=8 https://other.example.com/x#threadID=9",
+			want:  []int64{7, 8},
+		},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			got := Parse(tst.input, externalURL)
+			if !reflect.DeepEqual(got, tst.want) {
+				t.Fatalf("got %v want %v", got, tst.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package discussions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/highlight"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/markdown"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// Mocked out in tests.
+var highlightCode = highlight.Code
+
+// maxRenderLookups is the maximum number of thread, commit, and file lookups
+// performed when rendering a single comment, so that a comment full of
+// reference-like text cannot make rendering arbitrarily slow.
+const maxRenderLookups = 20
+
+// RenderCommentHTML renders the markdown contents of a comment in the thread
+// to sanitized HTML. In addition to rendering the markdown, it:
+//
+// - Syntax highlights fenced code blocks that specify a language.
+// - Links thread references like #123 to the referenced thread.
+// - Links commit SHAs and repository-relative file paths (in text or inline code) to the commit or file in the thread's repository, if they exist there.
+//
+// Only the markdown rendering can fail; if highlighting or a lookup fails, the
+// affected text is left as is.
+func RenderCommentHTML(ctx context.Context, thread *types.DiscussionThread, contents string, isLightTheme bool) (string, error) {
+	body := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(markdown.Render(contents)), body)
+	if err != nil {
+		return "", err
+	}
+	for _, n := range nodes {
+		body.AppendChild(n)
+	}
+	r := &commentRenderer{ctx: ctx, thread: thread, isLightTheme: isLightTheme}
+	r.walk(body)
+
+	var b bytes.Buffer
+	for n := body.FirstChild; n != nil; n = n.NextSibling {
+		if err := html.Render(&b, n); err != nil {
+			return "", err
+		}
+	}
+	result := b.String()
+
+	// The highlighted code blocks are trusted HTML generated by the syntax
+	// highlighter, so they are substituted only after rendering the rest of
+	// the (sanitized) tree. The sanitizer strips all comments from the
+	// markdown, so the placeholders cannot be forged.
+	for i, code := range r.highlighted {
+		result = strings.Replace(result, "<!--"+codePlaceholder(i)+"-->", code, 1)
+	}
+	return result, nil
+}
+
+// TruncateCommentContents returns the beginning of the markdown contents of a
+// comment that is longer than CommentContentsLengthLimit (e.g. because it was
+// created before the limit was lowered), so that rendering and showing it
+// stays fast. It reports whether the contents were truncated.
+//
+// The contents are cut at the end of the last line that fits, and a fenced
+// code block that is left open is closed.
+func TruncateCommentContents(contents string) (string, bool) {
+	limit := CommentContentsLengthLimit()
+	if len(contents) <= limit || utf8.RuneCountInString(contents) <= limit {
+		return contents, false
+	}
+	var end, n int
+	for end = range contents {
+		if n == limit {
+			break
+		}
+		n++
+	}
+	truncated := contents[:end]
+	if i := strings.LastIndexByte(truncated, '\n'); i > 0 {
+		truncated = truncated[:i]
+	}
+
+	var fences int
+	for _, line := range strings.Split(truncated, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fences++
+		}
+	}
+	if fences%2 == 1 {
+		truncated += "\n```"
+	}
+	return truncated, true
+}
+
+func codePlaceholder(i int) string { return fmt.Sprintf("sourcegraph-highlighted-code-%d", i) }
+
+var (
+	// referencePattern matches thread references, file paths (in text, only
+	// those with a directory and an extension), and commit SHAs.
+	referencePattern = lazyregexp.New(`#(\d+)\b|\b([\w.-]+(?:/[\w.-]+)+\.[A-Za-z0-9]+)\b|\b([0-9a-f]{7,40})\b`)
+	filePathPattern  = lazyregexp.New(`^[\w.-]+(?:/[\w.-]+)+/?$`)
+)
+
+type commentRenderer struct {
+	ctx          context.Context
+	thread       *types.DiscussionThread
+	isLightTheme bool
+
+	lookups     int
+	highlighted []string
+
+	// The thread's repository and the commit its branch (or the default
+	// branch) refers to, resolved lazily.
+	repoResolved bool
+	repo         *types.Repo
+	commit       api.CommitID
+}
+
+func (r *commentRenderer) walk(n *html.Node) {
+	if n.Type == html.ElementNode {
+		switch n.DataAtom {
+		case atom.A:
+			return // never link text that is already a link
+		case atom.Div:
+			if r.highlightCodeBlock(n) {
+				return
+			}
+		case atom.Pre:
+			return // never link text in code blocks
+		case atom.Code:
+			r.linkInlineCode(n)
+			return
+		}
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == html.TextNode {
+			r.linkText(c)
+		} else {
+			r.walk(c)
+		}
+		c = next
+	}
+}
+
+// highlightCodeBlock replaces a fenced code block that specifies a language
+// (rendered as <div class="highlight highlight-*"><pre>) with the syntax
+// highlighted code.
+func (r *commentRenderer) highlightCodeBlock(div *html.Node) bool {
+	var language string
+	for _, attr := range div.Attr {
+		if attr.Key == "class" && strings.HasPrefix(attr.Val, "highlight highlight-") {
+			language = highlight.SyntectLanguageMap[strings.ToLower(strings.TrimPrefix(attr.Val, "highlight highlight-"))]
+		}
+	}
+	pre := div.FirstChild
+	if language == "" || pre == nil || pre.DataAtom != atom.Pre || pre.NextSibling != nil {
+		return false
+	}
+	highlighted, aborted, err := highlightCode(r.ctx, highlight.Params{
+		Content:      []byte(textContent(pre)),
+		Filepath:     "file." + language,
+		IsLightTheme: r.isLightTheme,
+	})
+	if err != nil || aborted {
+		if err != nil {
+			log15.Warn("discussions: highlighting comment code block", "error", err)
+		}
+		return false
+	}
+	placeholder := &html.Node{Type: html.CommentNode, Data: codePlaceholder(len(r.highlighted))}
+	r.highlighted = append(r.highlighted, string(highlighted))
+	div.Parent.InsertBefore(placeholder, div)
+	div.Parent.RemoveChild(div)
+	return true
+}
+
+// linkInlineCode links an inline code element whose text is a path to a file
+// in the thread's repository.
+func (r *commentRenderer) linkInlineCode(code *html.Node) {
+	text := textContent(code)
+	if !filePathPattern.MatchString(text) {
+		return
+	}
+	url := r.fileURL(text)
+	if url == "" {
+		return
+	}
+	parent := code.Parent
+	a := newLink(url)
+	parent.InsertBefore(a, code)
+	parent.RemoveChild(code)
+	a.AppendChild(code)
+}
+
+// linkText replaces references in the text node with links.
+func (r *commentRenderer) linkText(n *html.Node) {
+	text := n.Data
+	matches := referencePattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return
+	}
+	var last int
+	for _, m := range matches {
+		var url string
+		switch {
+		case m[2] != -1:
+			if m[0] > 0 && isWordByte(text[m[0]-1]) {
+				continue // e.g. "abc#123" is not a thread reference
+			}
+			url = r.threadURL(text[m[2]:m[3]])
+		case m[4] != -1:
+			url = r.fileURL(text[m[4]:m[5]])
+		case m[6] != -1:
+			url = r.commitURL(text[m[6]:m[7]])
+		}
+		if url == "" {
+			continue
+		}
+		if m[0] > last {
+			n.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: text[last:m[0]]}, n)
+		}
+		a := newLink(url)
+		a.AppendChild(&html.Node{Type: html.TextNode, Data: text[m[0]:m[1]]})
+		n.Parent.InsertBefore(a, n)
+		last = m[1]
+	}
+	n.Data = text[last:]
+}
+
+// lookup reports whether another lookup may be performed.
+func (r *commentRenderer) lookup() bool {
+	if r.lookups >= maxRenderLookups {
+		return false
+	}
+	r.lookups++
+	return true
+}
+
+func (r *commentRenderer) threadURL(idText string) string {
+	id, err := strconv.ParseInt(idText, 10, 64)
+	if err != nil || !r.lookup() {
+		return ""
+	}
+	thread, err := db.DiscussionThreads.Get(r.ctx, id)
+	if err != nil {
+		return ""
+	}
+	u, err := URLToInlineThread(r.ctx, thread)
+	if err != nil || u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+func (r *commentRenderer) commitURL(sha string) string {
+	// Require a digit, so that words like "defaced" are not looked up.
+	if !strings.ContainsAny(sha, "0123456789") {
+		return ""
+	}
+	repo := r.resolveRepo()
+	if repo == nil || !r.lookup() {
+		return ""
+	}
+	commitID, err := backend.Repos.ResolveRev(r.ctx, repo, sha)
+	// A branch or tag may also look like a commit SHA.
+	if err != nil || !strings.HasPrefix(string(commitID), sha) {
+		return ""
+	}
+	return "/" + string(repo.Name) + "/-/commit/" + string(commitID)
+}
+
+func (r *commentRenderer) fileURL(filePath string) string {
+	repo := r.resolveRepo()
+	if repo == nil || r.commit == "" || !r.lookup() {
+		return ""
+	}
+	gitRepo, err := backend.CachedGitRepo(r.ctx, repo)
+	if err != nil {
+		return ""
+	}
+	fi, err := git.Stat(r.ctx, *gitRepo, r.commit, filePath)
+	if err != nil {
+		return ""
+	}
+	u := "/" + string(repo.Name)
+	if branch := r.thread.TargetRepo.Branch; branch != nil {
+		u += "@" + *branch
+	}
+	if fi.IsDir() {
+		return u + "/-/tree/" + path.Clean(filePath)
+	}
+	return u + "/-/blob/" + path.Clean(filePath)
+}
+
+// resolveRepo returns the thread's repository, or nil if the thread has none.
+func (r *commentRenderer) resolveRepo() *types.Repo {
+	if r.repoResolved {
+		return r.repo
+	}
+	r.repoResolved = true
+	if r.thread == nil || r.thread.TargetRepo == nil {
+		return nil
+	}
+	repo, err := backend.Repos.Get(r.ctx, r.thread.TargetRepo.RepoID)
+	if err != nil {
+		return nil
+	}
+	var rev string
+	if r.thread.TargetRepo.Branch != nil {
+		rev = *r.thread.TargetRepo.Branch
+	}
+	commit, err := backend.Repos.ResolveRev(r.ctx, repo, rev)
+	if err == nil {
+		r.commit = commit
+	}
+	r.repo = repo
+	return repo
+}
+
+func newLink(url string) *html.Node {
+	return &html.Node{
+		Type:     html.ElementNode,
+		Data:     "a",
+		DataAtom: atom.A,
+		Attr:     []html.Attribute{{Key: "href", Val: url}},
+	}
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,156 @@
+package discussions
+
+import (
+	"context"
+	"html/template"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/highlight"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeFileInfo struct{ dir bool }
+
+func (fakeFileInfo) Name() string       { return "" }
+func (fakeFileInfo) Size() int64        { return 0 }
+func (fakeFileInfo) Mode() os.FileMode  { return 0 }
+func (fakeFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fakeFileInfo) IsDir() bool     { return fi.dir }
+func (fakeFileInfo) Sys() interface{}   { return nil }
+
+func TestRenderCommentHTML(t *testing.T) {
+	defer func() {
+		db.Mocks = db.MockStores{}
+		backend.Mocks = backend.MockServices{}
+		git.ResetMocks()
+		highlightCode = highlight.Code
+	}()
+	ctx := context.Background()
+
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	backend.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "github.com/foo/bar"}, nil
+	}
+	backend.Mocks.Repos.ResolveRev = func(_ context.Context, _ *types.Repo, rev string) (api.CommitID, error) {
+		switch rev {
+		case "b":
+			return "head", nil
+		case "0123456":
+			return sha, nil
+		}
+		return "", &gitserver.RevisionNotFoundError{Spec: rev}
+	}
+	git.Mocks.Stat = func(commit api.CommitID, name string) (os.FileInfo, error) {
+		if commit != "head" {
+			t.Errorf("got Stat at commit %q, want %q", commit, "head")
+		}
+		switch name {
+		case "cmd/main.go":
+			return fakeFileInfo{}, nil
+		case "cmd":
+			return fakeFileInfo{dir: true}, nil
+		}
+		return nil, os.ErrNotExist
+	}
+	db.Mocks.DiscussionThreads.Get = func(id int64) (*types.DiscussionThread, error) {
+		path := "README.md"
+		return &types.DiscussionThread{ID: id, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1, Path: &path}}, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "github.com/foo/bar"}, nil
+	}
+	highlightCode = func(_ context.Context, p highlight.Params) (template.HTML, bool, error) {
+		if p.Filepath != "file.go" {
+			t.Errorf("got highlight file path %q, want %q", p.Filepath, "file.go")
+		}
+		return template.HTML("<table>" + template.HTMLEscapeString(string(p.Content)) + "</table>"), false, nil
+	}
+
+	branch := "b"
+	thread := &types.DiscussionThread{ID: 1, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1, Branch: &branch}}
+	tests := map[string]struct {
+		contents string
+		want     string
+	}{
+		"thread reference": {
+			contents: "see #2, not a#3",
+			want:     `<p>see <a href="/github.com/foo/bar/-/blob/README.md#tab=discussions&amp;threadID=2">#2</a>, not a#3</p>`,
+		},
+		"commit": {
+			contents: "fixed in 0123456 (not 7654321)",
+			want:     `<p>fixed in <a href="/github.com/foo/bar/-/commit/` + sha + `">0123456</a> (not 7654321)</p>`,
+		},
+		"file path": {
+			contents: "in cmd/main.go and `cmd`, but not cmd/other.go",
+			want:     `<p>in <a href="/github.com/foo/bar@b/-/blob/cmd/main.go">cmd/main.go</a> and <code>cmd</code>, but not cmd/other.go</p>`,
+		},
+		"inline code path": {
+			contents: "`cmd/main.go`",
+			want:     `<p><a href="/github.com/foo/bar@b/-/blob/cmd/main.go"><code>cmd/main.go</code></a></p>`,
+		},
+		"code block": {
+			contents: "```go\nx := \"#2\" // <b>\n```",
+			want:     "<table>x := &#34;#2&#34; // &lt;b&gt;\n</table>",
+		},
+		"code block without language": {
+			contents: "```\n#2\n```",
+			want:     "<pre><code>#2\n</code></pre>",
+		},
+		"existing link": {
+			contents: "[#2](https://example.com)",
+			want:     `<p><a href="https://example.com" rel="nofollow">#2</a></p>`,
+		},
+		"unsafe HTML": {
+			contents: "<script>alert(1)</script><!--sourcegraph-highlighted-code-0-->",
+			want:     "<p></p>",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := RenderCommentHTML(ctx, thread, test.contents, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.TrimSpace(got) != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTruncateCommentContents(t *testing.T) {
+	defer conf.Mock(nil)
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{MaxCommentLength: 12},
+	}})
+
+	tests := map[string]struct {
+		contents  string
+		want      string
+		truncated bool
+	}{
+		"short":           {"Hello", "Hello", false},
+		"at limit":        {"✓✓✓✓✓✓✓✓✓✓✓✓", "✓✓✓✓✓✓✓✓✓✓✓✓", false},
+		"single line":     {"✓✓✓✓✓✓✓✓✓✓✓✓✓", "✓✓✓✓✓✓✓✓✓✓✓✓", true},
+		"last line":       {"abc\ndef\nghijkl", "abc\ndef", true},
+		"open code fence": {"a\n```\nb\nc\nd\ne", "a\n```\nb\nc\nd\n```", true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, truncated := TruncateCommentContents(test.contents)
+			if got != test.want || truncated != test.truncated {
+				t.Errorf("got %q (truncated %v), want %q (truncated %v)", got, truncated, test.want, test.truncated)
+			}
+		})
+	}
+}
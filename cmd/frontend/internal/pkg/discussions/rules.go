@@ -0,0 +1,130 @@
+package discussions
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// ruleEventKinds describes the thread event kinds after which the rules of the
+// thread's repository are applied to the thread.
+var ruleEventKinds = map[types.DiscussionThreadEventKind]struct{}{
+	types.DiscussionThreadEventCreated:     {},
+	types.DiscussionThreadEventTitleEdited: {},
+}
+
+// applyRules applies the rules of the thread's repository to the thread if the
+// event created the thread or edited its title.
+//
+// It returns immediately and does not block.
+func applyRules(event *types.DiscussionThreadEvent) {
+	if _, ok := ruleEventKinds[event.Kind]; !ok {
+		return
+	}
+	goroutine.Go(func() {
+		// 🚨 SECURITY: Rules are configured by site admins, so they apply to
+		// every thread in their repository regardless of what the user who
+		// caused the event can see.
+		ctx := actor.WithActor(context.Background(), &actor.Actor{Internal: true})
+		if err := applyThreadRules(ctx, event.ThreadID, event.ActorUserID); err != nil {
+			log15.Error("discussions: applyThreadRules", "threadID", event.ThreadID, "error", err)
+		}
+	})
+}
+
+// applyThreadRules adds the labels and assignees of every rule of the thread's
+// repository whose query matches the thread. The resulting events are
+// attributed to the user who caused the rules to be applied and record the
+// rule that made the change.
+func applyThreadRules(ctx context.Context, threadID int64, actorUserID int32) error {
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	if thread.TargetRepo == nil || thread.ArchivedAt != nil {
+		return nil
+	}
+	rules, err := db.DiscussionRules.List(ctx, thread.TargetRepo.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "DiscussionRules.List")
+	}
+	for _, rule := range rules {
+		ok, err := ruleMatchesThread(ctx, rule, thread)
+		if err != nil {
+			log15.Error("discussions: ruleMatchesThread", "ruleID", rule.ID, "threadID", thread.ID, "error", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := applyRule(ctx, rule, thread, actorUserID); err != nil {
+			log15.Error("discussions: applyRule", "ruleID", rule.ID, "threadID", thread.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// ruleMatchesThread reports whether the rule's query (in thread search query
+// syntax) matches the thread.
+func ruleMatchesThread(ctx context.Context, rule *types.DiscussionRule, thread *types.DiscussionThread) (bool, error) {
+	opts := &db.DiscussionThreadsListOptions{}
+	opts.SetFromQuery(ctx, rule.Query)
+	if len(opts.ThreadIDs) > 0 && !containsThreadID(opts.ThreadIDs, thread.ID) {
+		return false, nil
+	}
+	opts.ThreadIDs = []int64{thread.ID}
+	opts.LimitOffset = &db.LimitOffset{Limit: 1}
+	threads, err := db.DiscussionThreads.List(ctx, opts)
+	if err != nil {
+		return false, errors.Wrap(err, "DiscussionThreads.List")
+	}
+	return len(threads) > 0, nil
+}
+
+func applyRule(ctx context.Context, rule *types.DiscussionRule, thread *types.DiscussionThread, actorUserID int32) error {
+	if len(rule.LabelIDs) > 0 {
+		// Skip labels that were deleted (or moved out of the repository)
+		// since the rule was created.
+		labels, err := db.DiscussionLabels.List(ctx, &db.DiscussionLabelsListOptions{LabelIDs: rule.LabelIDs, RepoID: rule.RepoID})
+		if err != nil {
+			return errors.Wrap(err, "DiscussionLabels.List")
+		}
+		labelIDs := make([]int64, len(labels))
+		for i, label := range labels {
+			labelIDs[i] = label.ID
+		}
+		added, err := db.DiscussionLabels.AddToThread(ctx, thread.ID, labelIDs)
+		if err != nil {
+			return errors.Wrap(err, "DiscussionLabels.AddToThread")
+		}
+		for _, labelID := range added {
+			labelID := labelID
+			LogThreadEvent(ctx, thread.ID, actorUserID, types.DiscussionThreadEventLabelAdded, types.DiscussionThreadEventData{LabelID: &labelID, RuleID: &rule.ID})
+		}
+	}
+	for _, userID := range rule.AssigneeUserIDs {
+		added, err := db.DiscussionThreadAssignees.Add(ctx, thread.ID, userID)
+		if err != nil {
+			return errors.Wrap(err, "DiscussionThreadAssignees.Add")
+		}
+		if added {
+			userID := userID
+			LogThreadEvent(ctx, thread.ID, actorUserID, types.DiscussionThreadEventAssigned, types.DiscussionThreadEventData{AssigneeUserID: &userID, RuleID: &rule.ID})
+		}
+	}
+	return nil
+}
+
+func containsThreadID(threadIDs []int64, threadID int64) bool {
+	for _, id := range threadIDs {
+		if id == threadID {
+			return true
+		}
+	}
+	return false
+}
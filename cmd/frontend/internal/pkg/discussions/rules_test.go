@@ -0,0 +1,93 @@
+package discussions
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestApplyThreadRules(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	ctx := context.Background()
+
+	thread := &types.DiscussionThread{ID: 1, Title: "crash on startup", TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 5}}
+	db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+		return thread, nil
+	}
+	db.Mocks.DiscussionThreads.List = func(_ context.Context, opts *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if !reflect.DeepEqual(opts.ThreadIDs, []int64{thread.ID}) {
+			t.Errorf("got ThreadIDs %v, want [%d]", opts.ThreadIDs, thread.ID)
+		}
+		if opts.TitleQuery != nil && !strings.Contains(thread.Title, *opts.TitleQuery) {
+			return nil, nil
+		}
+		return []*types.DiscussionThread{thread}, nil
+	}
+	db.Mocks.DiscussionRules.List = func(_ context.Context, repoID api.RepoID) ([]*types.DiscussionRule, error) {
+		if repoID != 5 {
+			t.Errorf("got repoID %d, want 5", repoID)
+		}
+		return []*types.DiscussionRule{
+			{ID: 10, RepoID: 5, Query: "title:crash", LabelIDs: []int64{20, 21}, AssigneeUserIDs: []int32{30}},
+			{ID: 11, RepoID: 5, Query: "title:typo", LabelIDs: []int64{22}},
+		}, nil
+	}
+	db.Mocks.DiscussionLabels.List = func(_ context.Context, opts *db.DiscussionLabelsListOptions) ([]*types.DiscussionLabel, error) {
+		// Label 21 was deleted after the rule was created.
+		var labels []*types.DiscussionLabel
+		for _, labelID := range opts.LabelIDs {
+			if labelID != 21 {
+				labels = append(labels, &types.DiscussionLabel{ID: labelID, RepoID: opts.RepoID})
+			}
+		}
+		return labels, nil
+	}
+	var addedLabelIDs []int64
+	db.Mocks.DiscussionLabels.AddToThread = func(_ context.Context, threadID int64, labelIDs []int64) ([]int64, error) {
+		addedLabelIDs = append(addedLabelIDs, labelIDs...)
+		return labelIDs, nil
+	}
+	var assigneeUserIDs []int32
+	db.Mocks.DiscussionThreadAssignees.Add = func(_ context.Context, threadID int64, userID int32) (bool, error) {
+		assigneeUserIDs = append(assigneeUserIDs, userID)
+		return true, nil
+	}
+	var events []types.DiscussionThreadEvent
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, event *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		events = append(events, *event)
+		return event, nil
+	}
+
+	if err := applyThreadRules(ctx, thread.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{20}; !reflect.DeepEqual(addedLabelIDs, want) {
+		t.Errorf("got added labels %v, want %v", addedLabelIDs, want)
+	}
+	if want := []int32{30}; !reflect.DeepEqual(assigneeUserIDs, want) {
+		t.Errorf("got assignees %v, want %v", assigneeUserIDs, want)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	for i, kind := range []types.DiscussionThreadEventKind{types.DiscussionThreadEventLabelAdded, types.DiscussionThreadEventAssigned} {
+		if events[i].Kind != kind || events[i].ActorUserID != 2 || events[i].Data.RuleID == nil || *events[i].Data.RuleID != 10 {
+			t.Errorf("got event %+v, want %s by user 2 from rule 10", events[i], kind)
+		}
+	}
+
+	// Rules are not applied to archived threads.
+	events = nil
+	thread.ArchivedAt = &thread.CreatedAt
+	if err := applyThreadRules(ctx, thread.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("got %d events for archived thread, want none", len(events))
+	}
+}
@@ -0,0 +1,162 @@
+package discussions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/schema"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+const (
+	// staleThreadsInterval is how often the stale threads worker runs.
+	staleThreadsInterval = time.Hour
+
+	// staleThreadsBatchSize is the maximum number of threads that are marked
+	// as stale (and archived) each time the worker runs. Any remaining threads
+	// are handled the next time it runs.
+	staleThreadsBatchSize = 100
+
+	defaultStaleThreadsGracePeriodDays = 7
+)
+
+// Mocked out in tests.
+var timeNow = time.Now
+
+// StartStaleThreadsWorker should be invoked only after the DB has been
+// initialized. It starts the background worker which marks inactive threads
+// as stale and archives stale threads that remain inactive, as configured by
+// the discussions.staleThreads site configuration.
+//
+// It should be invoked in a separate goroutine.
+func StartStaleThreadsWorker() {
+	for {
+		if dc := conf.Get().Discussions; dc != nil && dc.StaleThreads != nil {
+			// Only one frontend instance should run this worker at a time, so
+			// we use a distributed lock to guarantee this.
+			ctx, release, ok := rcache.TryAcquireMutex(context.Background(), "discussionsStaleThreadsWorker")
+			if ok {
+				if err := manageStaleThreads(ctx, dc.StaleThreads); err != nil {
+					log15.Error("discussions: stale threads worker", "error", err)
+				}
+				release()
+			}
+		}
+		time.Sleep(staleThreadsInterval)
+	}
+}
+
+// manageStaleThreads archives the threads that have been stale for longer
+// than the grace period, and then marks the threads that have been inactive
+// for longer than the configured period as stale.
+func manageStaleThreads(ctx context.Context, c *schema.DiscussionsStaleThreads) error {
+	// 🚨 SECURITY: Stale thread management is configured by site admins and
+	// applies to all threads, so the lookups must not be restricted to what
+	// any particular user can see.
+	ctx = actor.WithActor(ctx, &actor.Actor{Internal: true})
+
+	user, err := db.Users.GetByUsername(ctx, c.Username)
+	if err != nil {
+		return errors.Wrapf(err, "looking up discussions.staleThreads user %q", c.Username)
+	}
+	var excludedRepoIDs []api.RepoID
+	for _, name := range c.ExcludedRepositories {
+		repo, err := db.Repos.GetByName(ctx, api.RepoName(name))
+		if errcode.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "Repos.GetByName")
+		}
+		excludedRepoIDs = append(excludedRepoIDs, repo.ID)
+	}
+	gracePeriodDays := c.GracePeriodDays
+	if gracePeriodDays == 0 {
+		gracePeriodDays = defaultStaleThreadsGracePeriodDays
+	}
+	now := timeNow()
+
+	// Archive the threads whose grace period is over first, so that the
+	// threads marked as stale below always get the full grace period.
+	notArchived, stale := false, true
+	staleBefore := now.Add(-time.Duration(gracePeriodDays) * 24 * time.Hour)
+	threads, err := db.DiscussionThreads.List(ctx, &db.DiscussionThreadsListOptions{
+		LimitOffset:      &db.LimitOffset{Limit: staleThreadsBatchSize},
+		Archived:         &notArchived,
+		Stale:            &stale,
+		StaleBefore:      &staleBefore,
+		NotTargetRepoIDs: excludedRepoIDs,
+		AscendingOrder:   true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.List")
+	}
+	for _, thread := range threads {
+		archive := true
+		if _, err := db.DiscussionThreads.Update(ctx, thread.ID, &db.DiscussionThreadsUpdateOptions{Archive: &archive}); err != nil {
+			return errors.Wrap(err, "DiscussionThreads.Update")
+		}
+		LogThreadEvent(ctx, thread.ID, user.ID, types.DiscussionThreadEventArchived, types.DiscussionThreadEventData{})
+	}
+
+	notStale := false
+	inactiveBefore := now.Add(-time.Duration(c.InactiveDays) * 24 * time.Hour)
+	threads, err = db.DiscussionThreads.List(ctx, &db.DiscussionThreadsListOptions{
+		LimitOffset:      &db.LimitOffset{Limit: staleThreadsBatchSize},
+		Archived:         &notArchived,
+		Stale:            &notStale,
+		InactiveBefore:   &inactiveBefore,
+		NotTargetRepoIDs: excludedRepoIDs,
+		AscendingOrder:   true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.List")
+	}
+	for _, thread := range threads {
+		if err := markThreadStale(ctx, thread, user.ID, staleThreadWarning(c, gracePeriodDays)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markThreadStale posts the warning comment on the thread and then marks it
+// as stale. The comment is posted first because any comment added to a stale
+// thread makes it no longer stale.
+func markThreadStale(ctx context.Context, thread *types.DiscussionThread, userID int32, warning string) error {
+	comment, err := db.DiscussionComments.Create(ctx, &types.DiscussionComment{
+		ThreadID:     thread.ID,
+		AuthorUserID: userID,
+		Contents:     warning,
+	})
+	if err != nil {
+		return errors.Wrap(err, "DiscussionComments.Create")
+	}
+	LogThreadEvent(ctx, thread.ID, userID, types.DiscussionThreadEventCommented, types.DiscussionThreadEventData{
+		CommentID: &comment.ID,
+	})
+	stale := true
+	thread, err = db.DiscussionThreads.Update(ctx, thread.ID, &db.DiscussionThreadsUpdateOptions{Stale: &stale})
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.Update")
+	}
+	LogThreadEvent(ctx, thread.ID, userID, types.DiscussionThreadEventMarkedStale, types.DiscussionThreadEventData{})
+	NotifyNewComment(thread, comment)
+	return nil
+}
+
+func staleThreadWarning(c *schema.DiscussionsStaleThreads, gracePeriodDays int) string {
+	if c.WarningComment != "" {
+		return c.WarningComment
+	}
+	return fmt.Sprintf("This thread has been marked as stale because it has had no activity in the last %d days. It will be archived in %d days unless there is new activity on it.", c.InactiveDays, gracePeriodDays)
+}
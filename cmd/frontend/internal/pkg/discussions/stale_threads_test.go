@@ -0,0 +1,106 @@
+package discussions
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestManageStaleThreads(t *testing.T) {
+	defer func() {
+		db.Mocks = db.MockStores{}
+		timeNow = time.Now
+	}()
+	ctx := context.Background()
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	db.Mocks.Users.GetByUsername = func(_ context.Context, username string) (*types.User, error) {
+		if username != "stalebot" {
+			t.Errorf("got username %q, want stalebot", username)
+		}
+		return &types.User{ID: 1, Username: username}, nil
+	}
+	db.Mocks.Repos.GetByName = func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		if name == "r" {
+			return &types.Repo{ID: 2, Name: name}, nil
+		}
+		return nil, &errcode.Mock{IsNotFound: true}
+	}
+	db.Mocks.DiscussionThreads.List = func(_ context.Context, opts *db.DiscussionThreadsListOptions) ([]*types.DiscussionThread, error) {
+		if want := []api.RepoID{2}; !reflect.DeepEqual(opts.NotTargetRepoIDs, want) {
+			t.Errorf("got excluded repos %v, want %v", opts.NotTargetRepoIDs, want)
+		}
+		if opts.Archived == nil || *opts.Archived || opts.Stale == nil {
+			t.Fatalf("unexpected options %+v", opts)
+		}
+		if *opts.Stale {
+			if want := now.Add(-3 * 24 * time.Hour); opts.StaleBefore == nil || !opts.StaleBefore.Equal(want) {
+				t.Errorf("got stale before %v, want %v", opts.StaleBefore, want)
+			}
+			return []*types.DiscussionThread{{ID: 10}}, nil
+		}
+		if want := now.Add(-30 * 24 * time.Hour); opts.InactiveBefore == nil || !opts.InactiveBefore.Equal(want) {
+			t.Errorf("got inactive before %v, want %v", opts.InactiveBefore, want)
+		}
+		return []*types.DiscussionThread{{ID: 20}}, nil
+	}
+	updates := map[int64]db.DiscussionThreadsUpdateOptions{}
+	db.Mocks.DiscussionThreads.Update = func(_ context.Context, threadID int64, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error) {
+		updates[threadID] = *opts
+		return &types.DiscussionThread{ID: threadID}, nil
+	}
+	var comments []*types.DiscussionComment
+	db.Mocks.DiscussionComments.Create = func(_ context.Context, comment *types.DiscussionComment) (*types.DiscussionComment, error) {
+		if len(updates) != 1 {
+			t.Error("expected the warning comment to be created before the thread is marked as stale")
+		}
+		comment.ID = 30
+		comments = append(comments, comment)
+		return comment, nil
+	}
+	events := map[int64][]types.DiscussionThreadEventKind{}
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, event *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		if event.ActorUserID != 1 {
+			t.Errorf("got actor %d, want 1", event.ActorUserID)
+		}
+		events[event.ThreadID] = append(events[event.ThreadID], event.Kind)
+		return event, nil
+	}
+
+	err := manageStaleThreads(ctx, &schema.DiscussionsStaleThreads{
+		InactiveDays:         30,
+		GracePeriodDays:      3,
+		Username:             "stalebot",
+		ExcludedRepositories: []string{"r", "missing"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archive := updates[10].Archive; archive == nil || !*archive {
+		t.Errorf("got update %+v for thread 10, want it to be archived", updates[10])
+	}
+	if stale := updates[20].Stale; stale == nil || !*stale {
+		t.Errorf("got update %+v for thread 20, want it to be marked as stale", updates[20])
+	}
+	if len(comments) != 1 || comments[0].ThreadID != 20 || comments[0].AuthorUserID != 1 {
+		t.Fatalf("unexpected warning comments %+v", comments)
+	}
+	if want := "This thread has been marked as stale because it has had no activity in the last 30 days. It will be archived in 3 days unless there is new activity on it."; comments[0].Contents != want {
+		t.Errorf("got warning %q, want %q", comments[0].Contents, want)
+	}
+	want := map[int64][]types.DiscussionThreadEventKind{
+		10: {types.DiscussionThreadEventArchived},
+		20: {types.DiscussionThreadEventCommented, types.DiscussionThreadEventMarkedStale},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("got events %v, want %v", events, want)
+	}
+}
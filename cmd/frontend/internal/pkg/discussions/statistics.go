@@ -0,0 +1,46 @@
+package discussions
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+)
+
+// statisticsWeeks is the number of weeks of comment counts included in thread
+// statistics (including the current week).
+const statisticsWeeks = 12
+
+// statisticsCache caches the thread statistics of repositories, because they
+// are computed by scanning all of a repository's threads and events.
+var statisticsCache = rcache.NewWithTTL("discussions_thread_statistics", 10*60)
+
+// ThreadStatistics returns the statistics of the threads that target the
+// repository. They may be up to 10 minutes out of date.
+//
+// It does NOT verify that the user has permission to read the repository.
+// That is the responsibility of the caller.
+func ThreadStatistics(ctx context.Context, repoID api.RepoID) (*types.DiscussionThreadStatistics, error) {
+	key := strconv.Itoa(int(repoID))
+	if b, ok := statisticsCache.Get(key); ok {
+		var stats types.DiscussionThreadStatistics
+		if err := json.Unmarshal(b, &stats); err == nil {
+			return &stats, nil
+		}
+	}
+
+	since := timeNow().AddDate(0, 0, -7*(statisticsWeeks-1))
+	stats, err := db.DiscussionThreadEvents.Statistics(ctx, repoID, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreadEvents.Statistics")
+	}
+	if b, err := json.Marshal(stats); err == nil {
+		statisticsCache.Set(key, b)
+	}
+	return stats, nil
+}
@@ -0,0 +1,20 @@
+package discussions
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+// AutoSubscribe subscribes the users to the thread, unless they have
+// explicitly unsubscribed from it. It is called for the authors of new threads
+// and comments.
+//
+// Failures are logged instead of being returned to the caller, because the
+// thread or comment has already been created by the time this is called.
+func AutoSubscribe(ctx context.Context, threadID int64, userIDs ...int32) {
+	if err := db.DiscussionThreadSubscriptions.AutoSubscribe(ctx, threadID, userIDs); err != nil {
+		log15.Error("discussions: AutoSubscribe", "threadID", threadID, "error", err)
+	}
+}
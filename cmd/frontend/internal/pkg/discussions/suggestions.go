@@ -0,0 +1,200 @@
+package discussions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// Mocked out in tests.
+var (
+	gitReadFile = git.ReadFile
+
+	gitserverCreateCommitFromPatch = func(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
+		return gitserver.DefaultClient.CreateCommitFromPatch(ctx, req)
+	}
+)
+
+// ErrSuggestionOutdated is returned by ApplySuggestion when the lines the
+// suggestion replaces have changed on the thread's branch since the thread
+// was created.
+var ErrSuggestionOutdated = errors.New("the lines this suggestion replaces have changed on the branch since the thread was created")
+
+// CheckCanSuggestChanges returns an error if comments on the thread cannot
+// carry suggested changes. Suggestions replace the lines that the thread
+// selects, and are committed to the thread's branch.
+func CheckCanSuggestChanges(thread *types.DiscussionThread) error {
+	t := thread.TargetRepo
+	if t == nil || t.Path == nil {
+		return errors.New("suggestions can only be made on threads about a file")
+	}
+	if t.Branch == nil {
+		return errors.New("suggestions can only be made on threads about a branch")
+	}
+	if t.StartLine == nil || t.EndLine == nil || t.Lines == nil {
+		return errors.New("suggestions can only be made on threads with a selection")
+	}
+	return nil
+}
+
+// ApplySuggestion commits the comment's suggested change to the thread's
+// branch on behalf of the user, and records it on the thread's timeline. It
+// returns the updated suggestion.
+//
+// It does NOT verify that the user has permission to push to the branch. That
+// is the responsibility of the caller.
+func ApplySuggestion(ctx context.Context, thread *types.DiscussionThread, comment *types.DiscussionComment, user *types.User) (*types.DiscussionCommentSuggestion, error) {
+	if err := CheckCanSuggestChanges(thread); err != nil {
+		return nil, err
+	}
+	suggestion, err := db.DiscussionCommentSuggestions.Get(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion.AppliedAt != nil {
+		return nil, db.ErrSuggestionAlreadyApplied
+	}
+
+	t := thread.TargetRepo
+	repo, err := backend.Repos.Get(ctx, t.RepoID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Repos.Get")
+	}
+	baseCommit, err := backend.Repos.ResolveRev(ctx, repo, *t.Branch)
+	if err != nil {
+		return nil, errors.Wrap(err, "Repos.ResolveRev")
+	}
+	gitRepo, err := backend.CachedGitRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	fileContent, err := gitReadFile(ctx, *gitRepo, baseCommit, *t.Path, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "ReadFile")
+	}
+	patch, err := suggestionPatch(*t.Path, string(fileContent), LineRange{
+		StartLine: int(*t.StartLine),
+		EndLine:   int(*t.EndLine),
+	}, *t.Lines, suggestion.Contents)
+	if err != nil {
+		return nil, err
+	}
+
+	authorName := user.DisplayName
+	if authorName == "" {
+		authorName = user.Username
+	}
+	authorEmail, _, err := db.UserEmails.GetPrimaryEmail(ctx, user.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "GetPrimaryEmail")
+	}
+	commentAuthor, err := db.Users.GetByID(ctx, comment.AuthorUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Users.GetByID")
+	}
+
+	ref, err := gitserverCreateCommitFromPatch(ctx, protocol.CreateCommitFromPatchRequest{
+		Repo:       repo.Name,
+		BaseCommit: baseCommit,
+		Patch:      patch,
+		TargetRef:  *t.Branch,
+		CommitInfo: protocol.PatchCommitInfo{
+			Message:     fmt.Sprintf("%s\n\nApplied suggestion from @%s in discussion thread #%d.", thread.Title, commentAuthor.Username, thread.ID),
+			AuthorName:  authorName,
+			AuthorEmail: authorEmail,
+			Date:        time.Now(),
+		},
+		Push: true,
+		// Never overwrite commits that were pushed to the branch after
+		// baseCommit.
+		PushWithLease: true,
+		GitApplyArgs:  []string{"--unidiff-zero"},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateCommitFromPatch")
+	}
+	commitID, err := backend.Repos.ResolveRev(ctx, repo, ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "Repos.ResolveRev")
+	}
+
+	suggestion, err = db.DiscussionCommentSuggestions.MarkApplied(ctx, comment.ID, user.ID, string(commitID))
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionCommentSuggestions.MarkApplied")
+	}
+	LogThreadEvent(ctx, thread.ID, user.ID, types.DiscussionThreadEventSuggestionApplied, types.DiscussionThreadEventData{
+		CommentID: &comment.ID,
+	})
+	return suggestion, nil
+}
+
+// suggestionPatch returns a unified diff (with zero lines of context, to be
+// applied with `git apply --unidiff-zero`) that replaces the selected lines of
+// the file with the suggested contents.
+//
+// It returns ErrSuggestionOutdated if the selected lines of the file are no
+// longer the expected lines.
+func suggestionPatch(path, fileContent string, selection LineRange, expectedLines []string, suggested string) (string, error) {
+	splitLines := func(s string) (lines []string, trailingNewline bool) {
+		if s == "" {
+			return nil, false
+		}
+		trailingNewline = strings.HasSuffix(s, "\n")
+		return strings.Split(strings.TrimSuffix(s, "\n"), "\n"), trailingNewline
+	}
+	fileLines, fileTrailingNewline := splitLines(fileContent)
+	if selection.StartLine < 0 || selection.EndLine < selection.StartLine || selection.EndLine > len(fileLines) {
+		return "", ErrSuggestionOutdated
+	}
+	oldLines := fileLines[selection.StartLine:selection.EndLine]
+	if len(oldLines) != len(expectedLines) {
+		return "", ErrSuggestionOutdated
+	}
+	for i := range oldLines {
+		if oldLines[i] != expectedLines[i] {
+			return "", ErrSuggestionOutdated
+		}
+	}
+	newLines, _ := splitLines(suggested)
+
+	// In a unified diff, an empty range is identified by the line just before
+	// it.
+	hunkRange := func(startLine, count int) string {
+		if count == 0 {
+			return fmt.Sprintf("%d,0", startLine)
+		}
+		return fmt.Sprintf("%d,%d", startLine+1, count)
+	}
+	// If the selection includes the last line of a file that does not end in a
+	// newline, the replacement must not end in a newline either.
+	noNewlineAtEOF := !fileTrailingNewline && selection.EndLine == len(fileLines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+	fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(selection.StartLine, len(oldLines)), hunkRange(selection.StartLine, len(newLines)))
+	for _, line := range oldLines {
+		fmt.Fprintf(&b, "-%s\n", line)
+	}
+	if noNewlineAtEOF && len(oldLines) > 0 {
+		b.WriteString("\\ No newline at end of file\n")
+	}
+	for _, line := range newLines {
+		fmt.Fprintf(&b, "+%s\n", line)
+	}
+	if noNewlineAtEOF && len(newLines) > 0 {
+		b.WriteString("\\ No newline at end of file\n")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,182 @@
+package discussions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestSuggestionPatch(t *testing.T) {
+	tests := map[string]struct {
+		fileContent   string
+		selection     LineRange
+		expectedLines []string
+		suggested     string
+		want          string
+		wantErr       error
+	}{
+		"replace": {
+			fileContent:   "a\nb\nc\n",
+			selection:     LineRange{StartLine: 1, EndLine: 2},
+			expectedLines: []string{"b"},
+			suggested:     "B\nBB\n",
+			want: `diff --git a/f.txt b/f.txt
+--- a/f.txt
++++ b/f.txt
+@@ -2,1 +2,2 @@
+-b
++B
++BB
+`,
+		},
+		"delete": {
+			fileContent:   "a\nb\nc\n",
+			selection:     LineRange{StartLine: 1, EndLine: 2},
+			expectedLines: []string{"b"},
+			suggested:     "",
+			want: `diff --git a/f.txt b/f.txt
+--- a/f.txt
++++ b/f.txt
+@@ -2,1 +1,0 @@
+-b
+`,
+		},
+		"no newline at end of file": {
+			fileContent:   "a\nb",
+			selection:     LineRange{StartLine: 1, EndLine: 2},
+			expectedLines: []string{"b"},
+			suggested:     "B",
+			want: `diff --git a/f.txt b/f.txt
+--- a/f.txt
++++ b/f.txt
+@@ -2,1 +2,1 @@
+-b
+\ No newline at end of file
++B
+\ No newline at end of file
+`,
+		},
+		"lines changed": {
+			fileContent:   "a\nx\nc\n",
+			selection:     LineRange{StartLine: 1, EndLine: 2},
+			expectedLines: []string{"b"},
+			wantErr:       ErrSuggestionOutdated,
+		},
+		"file shrunk": {
+			fileContent:   "a\n",
+			selection:     LineRange{StartLine: 1, EndLine: 2},
+			expectedLines: []string{"b"},
+			wantErr:       ErrSuggestionOutdated,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := suggestionPatch("f.txt", test.fileContent, test.selection, test.expectedLines, test.suggested)
+			if err != test.wantErr {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got patch\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestApplySuggestion(t *testing.T) {
+	defer func() {
+		db.Mocks = db.MockStores{}
+		backend.Mocks = backend.MockServices{}
+		gitReadFile = git.ReadFile
+		gitserverCreateCommitFromPatch = func(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
+			return gitserver.DefaultClient.CreateCommitFromPatch(ctx, req)
+		}
+	}()
+	ctx := context.Background()
+
+	backend.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "r"}, nil
+	}
+	backend.Mocks.Repos.ResolveRev = func(_ context.Context, _ *types.Repo, rev string) (api.CommitID, error) {
+		if rev == "refs/heads/b" {
+			return "new", nil
+		}
+		return "base", nil
+	}
+	gitReadFile = func(_ context.Context, _ gitserver.Repo, commit api.CommitID, name string, _ int64) ([]byte, error) {
+		if commit != "base" || name != "f.txt" {
+			t.Errorf("got ReadFile(%q, %q), want ReadFile(base, f.txt)", commit, name)
+		}
+		return []byte("a\nb\nc\n"), nil
+	}
+	var req *protocol.CreateCommitFromPatchRequest
+	gitserverCreateCommitFromPatch = func(_ context.Context, r protocol.CreateCommitFromPatchRequest) (string, error) {
+		req = &r
+		return "refs/heads/b", nil
+	}
+	db.Mocks.DiscussionCommentSuggestions.Get = func(_ context.Context, commentID int64) (*types.DiscussionCommentSuggestion, error) {
+		return &types.DiscussionCommentSuggestion{CommentID: commentID, Contents: "B"}, nil
+	}
+	var appliedCommitID string
+	db.Mocks.DiscussionCommentSuggestions.MarkApplied = func(_ context.Context, commentID int64, userID int32, commitID string) (*types.DiscussionCommentSuggestion, error) {
+		appliedCommitID = commitID
+		return &types.DiscussionCommentSuggestion{CommentID: commentID, AppliedByUserID: &userID, AppliedCommitID: &commitID}, nil
+	}
+	db.Mocks.UserEmails.GetPrimaryEmail = func(context.Context, int32) (string, bool, error) {
+		return "alice@example.com", true, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "bob"}, nil
+	}
+	var eventKind types.DiscussionThreadEventKind
+	db.Mocks.DiscussionThreadEvents.Create = func(_ context.Context, event *types.DiscussionThreadEvent) (*types.DiscussionThreadEvent, error) {
+		eventKind = event.Kind
+		return event, nil
+	}
+
+	path, branch := "f.txt", "b"
+	startLine, endLine := int32(1), int32(2)
+	lines := []string{"b"}
+	thread := &types.DiscussionThread{ID: 1, Title: "t", TargetRepo: &types.DiscussionThreadTargetRepo{
+		RepoID:    1,
+		Path:      &path,
+		Branch:    &branch,
+		StartLine: &startLine,
+		EndLine:   &endLine,
+		Lines:     &lines,
+	}}
+	comment := &types.DiscussionComment{ID: 2, ThreadID: 1, AuthorUserID: 3}
+	user := &types.User{ID: 4, Username: "alice"}
+
+	if _, err := ApplySuggestion(ctx, thread, comment, user); err != nil {
+		t.Fatal(err)
+	}
+	if req == nil {
+		t.Fatal("expected a commit to be created")
+	}
+	if req.BaseCommit != "base" || req.TargetRef != "b" || !req.Push || !req.PushWithLease {
+		t.Errorf("got request %+v, want a push with lease of base commit to branch b", req)
+	}
+	if req.CommitInfo.AuthorName != "alice" || req.CommitInfo.AuthorEmail != "alice@example.com" {
+		t.Errorf("got commit author %q <%q>, want alice <alice@example.com>", req.CommitInfo.AuthorName, req.CommitInfo.AuthorEmail)
+	}
+	if appliedCommitID != "new" {
+		t.Errorf("got applied commit ID %q, want %q", appliedCommitID, "new")
+	}
+	if eventKind != types.DiscussionThreadEventSuggestionApplied {
+		t.Errorf("got event kind %q, want %q", eventKind, types.DiscussionThreadEventSuggestionApplied)
+	}
+
+	t.Run("thread without branch", func(t *testing.T) {
+		thread := &types.DiscussionThread{ID: 1, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 1, Path: &path}}
+		if _, err := ApplySuggestion(ctx, thread, comment, user); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/router"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 )
 
@@ -63,3 +65,47 @@ func urlToInline(ctx context.Context, t *types.DiscussionThread, c *types.Discus
 	}
 	return u, nil
 }
+
+// URLToUnsubscribe returns the absolute URL of a page that unsubscribes the
+// user from the thread (after they confirm), without requiring the user to
+// sign in.
+//
+// 🚨 SECURITY: The caller must ensure the URL is ONLY given to the specified
+// user, as it embeds a token that grants unsubscribing them from the thread.
+func URLToUnsubscribe(ctx context.Context, userID int32, threadID int64) (*url.URL, error) {
+	token, err := db.DiscussionUnsubscribeTokens.Generate(ctx, userID, threadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionUnsubscribeTokens.Generate")
+	}
+	unsubscribePath, err := router.Router().Get(router.DiscussionsUnsubscribe).URLPath()
+	if err != nil {
+		return nil, err
+	}
+	return globals.ExternalURL().ResolveReference(&url.URL{
+		Path:     unsubscribePath.Path,
+		RawQuery: url.Values{"token": {token}}.Encode(),
+	}), nil
+}
+
+// URLToAttachment returns the URL at which the attachment's contents can be
+// downloaded.
+func URLToAttachment(attachment *types.DiscussionCommentAttachment) (*url.URL, error) {
+	return router.Router().Get(router.DiscussionsAttachment).URLPath("ID", strconv.FormatInt(attachment.ID, 10))
+}
+
+// URLToUploadAttachment returns the absolute URL to which the contents of an
+// attachment are uploaded with a PUT request, authorized by the attachment's
+// upload token (see db.DiscussionCommentAttachments.Create).
+//
+// 🚨 SECURITY: The caller must ensure the URL is ONLY given to the author of
+// the attachment's comment, as it embeds the upload token.
+func URLToUploadAttachment(token string) (*url.URL, error) {
+	uploadPath, err := router.Router().Get(router.DiscussionsAttachmentUpload).URLPath()
+	if err != nil {
+		return nil, err
+	}
+	return globals.ExternalURL().ResolveReference(&url.URL{
+		Path:     uploadPath.Path,
+		RawQuery: url.Values{"token": {token}}.Encode(),
+	}), nil
+}
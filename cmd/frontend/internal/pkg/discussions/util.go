@@ -5,34 +5,177 @@ import (
 	"fmt"
 	"time"
 
+	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/discussions/ratelimit"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
+// InsecureCreateThread creates a new thread along with its first comment. It
+// handles:
+//
+// 1. Creating the thread, the comment and the CREATED event in one transaction.
+// 2. Delivering the event to webhooks.
+//
+// It does NOT verify that the user has permission to create this thread, nor
+// does it rate limit. That is the responsibility of the caller.
+func InsecureCreateThread(ctx context.Context, newThread *types.DiscussionThread, newComment *types.DiscussionComment) (_ *types.DiscussionThread, err error) {
+	tr, ctx := trace.New(ctx, "discussions.InsecureCreateThread", newThread.Title)
+	if newThread.TargetRepo != nil {
+		tr.LogFields(otlog.Int32("repoID", int32(newThread.TargetRepo.RepoID)))
+	}
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	event := &types.DiscussionThreadEvent{
+		ActorUserID: newThread.AuthorUserID,
+		Kind:        types.DiscussionThreadEventCreated,
+	}
+	thread, err := db.DiscussionThreads.CreateWithComment(ctx, newThread, newComment, event)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.CreateWithComment")
+	}
+	tr.LogFields(otlog.Int64("threadID", thread.ID))
+	dispatchThreadEvent(event)
+	return thread, nil
+}
+
+// InsecureRestoreThread restores a deleted thread. It handles:
+//
+// 1. Restoring the thread and creating the RESTORED event in one transaction.
+// 2. Delivering the event to webhooks.
+//
+// It does NOT verify that the actor has permission to restore the thread. That
+// is the responsibility of the caller.
+func InsecureRestoreThread(ctx context.Context, threadID int64, actorUserID int32) (*types.DiscussionThread, error) {
+	event := &types.DiscussionThreadEvent{
+		ActorUserID: actorUserID,
+		Kind:        types.DiscussionThreadEventRestored,
+	}
+	thread, err := db.DiscussionThreads.Restore(ctx, threadID, event)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.Restore")
+	}
+	dispatchThreadEvent(event)
+	return thread, nil
+}
+
+// InsecureUpdateThread updates a thread. It handles:
+//
+// 1. Updating the thread and recording the changes to its title, archived, locked and deleted states on its timeline in one transaction.
+// 2. Delivering the recorded events to webhooks and notifying the thread's subscribers.
+//
+// It returns nil if the thread was deleted.
+//
+// It does NOT verify that the actor has permission to update the thread. That
+// is the responsibility of the caller.
+func InsecureUpdateThread(ctx context.Context, threadID int64, actorUserID int32, opts *db.DiscussionThreadsUpdateOptions) (*types.DiscussionThread, error) {
+	thread, events, err := db.DiscussionThreads.UpdateAndRecord(ctx, threadID, actorUserID, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.UpdateAndRecord")
+	}
+	for _, event := range events {
+		dispatchThreadEvent(event)
+	}
+	return thread, nil
+}
+
+// InsecureUpdateThreads is like InsecureUpdateThread, except that it applies
+// the same update to many threads in one transaction (see
+// db.DiscussionThreads.UpdateMany). It returns the IDs of the threads that
+// were updated.
+//
+// It does NOT verify that the actor has permission to update the threads.
+// That is the responsibility of the caller.
+func InsecureUpdateThreads(ctx context.Context, threadIDs []int64, actorUserID int32, opts *db.DiscussionThreadsUpdateOptions) ([]int64, error) {
+	if actorUserID == 0 {
+		return nil, errors.New("actorUserID must be specified")
+	}
+	updated, events, err := db.DiscussionThreads.UpdateMany(ctx, threadIDs, actorUserID, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.UpdateMany")
+	}
+	for _, event := range events {
+		dispatchThreadEvent(event)
+	}
+	return updated, nil
+}
+
+// InsecureSetThreadClosed closes or reopens a thread. It handles:
+//
+// 1. Closing or reopening the thread and creating the CLOSED or REOPENED event in one transaction.
+// 2. Delivering the event to webhooks and notifying the thread's subscribers.
+//
+// Closing a closed thread or reopening an open one fails (with
+// db.ErrThreadAlreadyClosed or db.ErrThreadNotClosed).
+//
+// It does NOT verify that the actor has permission to close or reopen the
+// thread. That is the responsibility of the caller.
+func InsecureSetThreadClosed(ctx context.Context, threadID int64, actorUserID int32, closed bool) (*types.DiscussionThread, error) {
+	event := &types.DiscussionThreadEvent{
+		ActorUserID: actorUserID,
+		Kind:        types.DiscussionThreadEventReopened,
+	}
+	if closed {
+		event.Kind = types.DiscussionThreadEventClosed
+	}
+	thread, err := db.DiscussionThreads.SetClosed(ctx, threadID, closed, event)
+	if err != nil {
+		return nil, errors.Wrap(err, "DiscussionThreads.SetClosed")
+	}
+	dispatchThreadEvent(event)
+	return thread, nil
+}
+
 // InsecureAddCommentToThread handles adding a new comment to an existing
 // thread. It handles:
 //
-// 1. Rate limiting (NOT general permission handling).
-// 2. Creating the actual database entry.
-// 3. Notifying other users of the new comment.
-// 4. Fetching and returning the updated thread.
+// 1. Rejecting comments that are too long, and rate limiting (NOT general permission handling).
+// 2. Rejecting comments on archived threads, and on locked threads from authors who are not site admins.
+// 3. Creating the actual database entry.
+// 4. Recording the comment on the thread's timeline, its mentions, and the threads it references.
+// 5. Subscribing the comment author to the thread.
+// 6. Notifying other users of the new comment.
+// 7. Fetching and returning the updated thread.
 //
 // It does NOT verify that the user has permission to create this comment. That
 // is the responsibility of the caller.
-func InsecureAddCommentToThread(ctx context.Context, newComment *types.DiscussionComment) (*types.DiscussionThread, error) {
+func InsecureAddCommentToThread(ctx context.Context, newComment *types.DiscussionComment) (_ *types.DiscussionThread, err error) {
+	tr, ctx := trace.New(ctx, "discussions.InsecureAddCommentToThread", "")
+	tr.LogFields(otlog.Int64("threadID", newComment.ThreadID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := ValidateCommentLength("contents", newComment.Contents); err != nil {
+		return nil, err
+	}
 	if dc := conf.Get().Discussions; dc != nil && dc.AbuseProtection {
 		if mustWait := ratelimit.TimeUntilUserCanAddCommentToThread(ctx, newComment.AuthorUserID, newComment.Contents); mustWait != 0 {
 			return nil, fmt.Errorf("You are creating comments too quickly. You may create a new one after %v", mustWait.Round(time.Second))
 		}
 	}
 
-	_, err := db.DiscussionComments.Create(ctx, newComment)
-	if err != nil {
+	if err := checkThreadAcceptsComments(ctx, newComment.ThreadID, newComment.AuthorUserID); err != nil {
+		return nil, err
+	}
+
+	if _, err := db.DiscussionComments.Create(ctx, newComment); err != nil {
 		return nil, err // Intentionally not wrapping the error here for cleaner error messages.
 	}
+	tr.LogFields(otlog.Int64("commentID", newComment.ID))
+	LogThreadEvent(ctx, newComment.ThreadID, newComment.AuthorUserID, types.DiscussionThreadEventCommented, types.DiscussionThreadEventData{
+		CommentID: &newComment.ID,
+	})
+	StoreCommentMentions(ctx, newComment)
+	StoreCommentReferences(ctx, newComment)
+	AutoSubscribe(ctx, newComment.ThreadID, newComment.AuthorUserID)
 
 	updatedThread, err := db.DiscussionThreads.Get(ctx, newComment.ThreadID)
 	if err != nil {
@@ -41,3 +184,115 @@ func InsecureAddCommentToThread(ctx context.Context, newComment *types.Discussio
 	NotifyNewComment(updatedThread, newComment)
 	return updatedThread, nil
 }
+
+// InsecureApplyThreadActions applies a list of actions to an existing thread.
+// It handles:
+//
+// 1. Rejecting comments that are too long, and rate limiting comments (NOT general permission handling).
+// 2. Rejecting actions (other than unarchiving) on threads that are archived when the action is applied (by an earlier action or before), and comments on locked threads from actors who are not site admins.
+// 3. Applying the actions and recording them on the thread's timeline in one transaction.
+// 4. Delivering the recorded events to webhooks.
+// 5. Recording each comment's mentions and the threads it references, subscribing the actor to the thread, and notifying other users of the comments.
+// 6. Returning the updated thread.
+//
+// It does NOT verify that the actor has permission to perform the actions. That
+// is the responsibility of the caller.
+func InsecureApplyThreadActions(ctx context.Context, thread *types.DiscussionThread, actorUserID int32, actions []*db.DiscussionThreadAction) (_ *types.DiscussionThread, err error) {
+	tr, ctx := trace.New(ctx, "discussions.InsecureApplyThreadActions", "")
+	tr.LogFields(otlog.Int64("threadID", thread.ID), otlog.Int("actions", len(actions)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	var comments []*types.DiscussionComment
+	archived := thread.ArchivedAt != nil
+	for _, a := range actions {
+		if a.Archive != nil {
+			archived = *a.Archive
+			continue
+		}
+		if a.Comment == nil {
+			if archived {
+				return nil, ErrThreadArchived
+			}
+			continue
+		}
+		if err := ValidateCommentLength("comment", a.Comment.Contents); err != nil {
+			return nil, err
+		}
+		if dc := conf.Get().Discussions; dc != nil && dc.AbuseProtection {
+			if mustWait := ratelimit.TimeUntilUserCanAddCommentToThread(ctx, a.Comment.AuthorUserID, a.Comment.Contents); mustWait != 0 {
+				return nil, fmt.Errorf("You are creating comments too quickly. You may create a new one after %v", mustWait.Round(time.Second))
+			}
+		}
+		if archived {
+			return nil, ErrThreadArchived
+		}
+		if err := checkLockedThreadAcceptsComments(ctx, thread, a.Comment.AuthorUserID); err != nil {
+			return nil, err
+		}
+		comments = append(comments, a.Comment)
+	}
+
+	updatedThread, events, err := db.DiscussionThreads.ApplyActions(ctx, thread.ID, actorUserID, actions)
+	if err != nil {
+		return nil, err // Intentionally not wrapping the error here for cleaner error messages.
+	}
+	for _, event := range events {
+		dispatchThreadEvent(event)
+	}
+	for _, c := range comments {
+		StoreCommentMentions(ctx, c)
+		StoreCommentReferences(ctx, c)
+		AutoSubscribe(ctx, c.ThreadID, c.AuthorUserID)
+		NotifyNewComment(updatedThread, c)
+	}
+	return updatedThread, nil
+}
+
+// ErrThreadLocked is returned when a user who is neither a site admin nor a
+// contributor tries to add a comment to a locked thread.
+var ErrThreadLocked = errors.New("this thread has been locked; only site admins and contributors can add comments to it")
+
+// ErrThreadArchived is returned when a user tries to change an archived thread,
+// e.g. to add a comment to it or to label it. Archived threads are read-only
+// until they are unarchived.
+var ErrThreadArchived = errors.New("this thread has been archived; it must be unarchived before it can be changed")
+
+// checkThreadAcceptsComments returns ErrThreadArchived if the thread is
+// archived, and ErrThreadLocked if the thread is locked and the user is neither
+// a site admin nor a contributor (see CheckCanComment).
+func checkThreadAcceptsComments(ctx context.Context, threadID int64, userID int32) error {
+	thread, err := db.DiscussionThreads.Get(ctx, threadID)
+	if err != nil {
+		return errors.Wrap(err, "DiscussionThreads.Get")
+	}
+	if thread.ArchivedAt != nil {
+		return ErrThreadArchived
+	}
+	return checkLockedThreadAcceptsComments(ctx, thread, userID)
+}
+
+// checkLockedThreadAcceptsComments returns ErrThreadLocked if the thread is
+// locked and the user is neither a site admin nor a contributor.
+func checkLockedThreadAcceptsComments(ctx context.Context, thread *types.DiscussionThread, userID int32) error {
+	if thread.LockedAt == nil {
+		return nil
+	}
+	user, err := db.Users.GetByID(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "Users.GetByID")
+	}
+	if user.SiteAdmin {
+		return nil
+	}
+	role, err := discussionRole(ctx, userID, thread)
+	if err != nil {
+		return err
+	}
+	if !role.Includes(types.DiscussionRoleContributor) {
+		return ErrThreadLocked
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package discussions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestCheckThreadAcceptsComments(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	lockedAt := time.Now()
+	tests := map[string]struct {
+		lockedAt   *time.Time
+		archivedAt *time.Time
+		siteAdmin  bool
+		role       types.DiscussionRoleKind
+		wantErr    error
+	}{
+		"unlocked":             {},
+		"locked, non-admin":    {lockedAt: &lockedAt, wantErr: ErrThreadLocked},
+		"locked, site admin":   {lockedAt: &lockedAt, siteAdmin: true},
+		"locked, contributor":  {lockedAt: &lockedAt, role: types.DiscussionRoleContributor},
+		"locked, maintainer":   {lockedAt: &lockedAt, role: types.DiscussionRoleMaintainer},
+		"unlocked, site admin": {siteAdmin: true},
+		"archived":             {archivedAt: &lockedAt, wantErr: ErrThreadArchived},
+		"archived, site admin": {archivedAt: &lockedAt, siteAdmin: true, wantErr: ErrThreadArchived},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db.Mocks.DiscussionThreads.Get = func(threadID int64) (*types.DiscussionThread, error) {
+				return &types.DiscussionThread{ID: threadID, LockedAt: test.lockedAt, ArchivedAt: test.archivedAt, TargetRepo: &types.DiscussionThreadTargetRepo{RepoID: 3}}, nil
+			}
+			db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+				return &types.User{ID: id, SiteAdmin: test.siteAdmin}, nil
+			}
+			db.Mocks.DiscussionRoles.List = func(_ context.Context, opts *db.DiscussionRolesListOptions) ([]*types.DiscussionRole, error) {
+				if opts.UserID != 2 || opts.RepoID != 3 || test.role == "" {
+					return nil, nil
+				}
+				return []*types.DiscussionRole{{UserID: 2, RepoID: &opts.RepoID, Role: test.role}}, nil
+			}
+			if err := checkThreadAcceptsComments(context.Background(), 1, 2); err != test.wantErr {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestInsecureApplyThreadActions_archived(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	archivedAt := time.Now()
+	archive, unarchive := true, false
+	comment := func() *db.DiscussionThreadAction {
+		return &db.DiscussionThreadAction{Comment: &types.DiscussionComment{ThreadID: 1, AuthorUserID: 2, Contents: "c"}}
+	}
+	tests := map[string]struct {
+		archivedAt *time.Time
+		actions    []*db.DiscussionThreadAction
+		wantErr    error
+	}{
+		"comment":                     {actions: []*db.DiscussionThreadAction{comment()}},
+		"comment, then archive":       {actions: []*db.DiscussionThreadAction{comment(), {Archive: &archive}}},
+		"archive, then comment":       {actions: []*db.DiscussionThreadAction{{Archive: &archive}, comment()}, wantErr: ErrThreadArchived},
+		"archived, comment":           {archivedAt: &archivedAt, actions: []*db.DiscussionThreadAction{comment()}, wantErr: ErrThreadArchived},
+		"archived, unarchive/comment": {archivedAt: &archivedAt, actions: []*db.DiscussionThreadAction{{Archive: &unarchive}, comment()}},
+		"archived, label":             {archivedAt: &archivedAt, actions: []*db.DiscussionThreadAction{{AddLabelIDs: []int64{1}}}, wantErr: ErrThreadArchived},
+		"archived, unarchive/label":   {archivedAt: &archivedAt, actions: []*db.DiscussionThreadAction{{Archive: &unarchive}, {AddLabelIDs: []int64{1}}}},
+	}
+	// The mock store returns this error so that the actions' side effects
+	// (which are not mocked) are not performed.
+	errApplied := errors.New("applied")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db.Mocks.DiscussionThreads.ApplyActions = func(context.Context, int64, int32, []*db.DiscussionThreadAction) (*types.DiscussionThread, []*types.DiscussionThreadEvent, error) {
+				return nil, nil, errApplied
+			}
+			thread := &types.DiscussionThread{ID: 1, ArchivedAt: test.archivedAt}
+			wantErr := test.wantErr
+			if wantErr == nil {
+				wantErr = errApplied
+			}
+			if _, err := InsecureApplyThreadActions(context.Background(), thread, 2, test.actions); err != wantErr {
+				t.Errorf("got error %v, want %v", err, wantErr)
+			}
+		})
+	}
+}
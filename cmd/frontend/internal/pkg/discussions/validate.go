@@ -0,0 +1,142 @@
+package discussions
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+const (
+	// MaxThreadTitleLength is the maximum length of a thread title, in
+	// UTF-8 characters.
+	MaxThreadTitleLength = 500
+
+	// MaxCommentContentsLength is the default maximum length of the contents
+	// of a comment (including the first comment of a thread), in UTF-8
+	// characters. It can be changed with the discussions.maxCommentLength
+	// site configuration.
+	MaxCommentContentsLength = 100000
+)
+
+// CommentContentsLengthLimit returns the maximum length of the contents of a
+// comment, in UTF-8 characters.
+func CommentContentsLengthLimit() int {
+	if dc := conf.Get().Discussions; dc != nil && dc.MaxCommentLength > 0 {
+		return dc.MaxCommentLength
+	}
+	return MaxCommentContentsLength
+}
+
+// ValidationError describes why the value of an input field is invalid.
+type ValidationError struct {
+	// Field is the name of the input field, e.g. "title" or "targetRepo.branch".
+	Field string
+
+	// Message describes the problem, e.g. "must not be empty".
+	Message string
+}
+
+func (e *ValidationError) Error() string { return e.Field + " " + e.Message }
+
+// Extensions implements the GraphQL error extensions interface, so that
+// clients can show the error next to the invalid field.
+func (e *ValidationError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": string(ErrorCodeInvalidInput), "field": e.Field}
+}
+
+// ValidateThreadTitle returns a *ValidationError if the title is empty, too
+// long, or contains control characters.
+func ValidateThreadTitle(field, title string) error {
+	if strings.TrimSpace(title) == "" {
+		return &ValidationError{Field: field, Message: "must not be empty"}
+	}
+	if utf8.RuneCountInString(title) > MaxThreadTitleLength {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("must be at most %d characters long", MaxThreadTitleLength)}
+	}
+	return validateNoControlCharacters(field, title, "")
+}
+
+// ValidateCommentContents returns a *ValidationError if the contents are
+// empty, too long, or contain control characters other than newlines and tabs.
+func ValidateCommentContents(field, contents string) error {
+	if strings.TrimSpace(contents) == "" {
+		return &ValidationError{Field: field, Message: "must not be empty"}
+	}
+	if err := ValidateCommentLength(field, contents); err != nil {
+		return err
+	}
+	return validateNoControlCharacters(field, contents, "\n\r\t")
+}
+
+// ValidateCommentLength returns a *ValidationError if the contents are longer
+// than CommentContentsLengthLimit. Unlike ValidateCommentContents, it accepts
+// any characters (e.g. the escape sequences of pasted terminal output).
+func ValidateCommentLength(field, contents string) error {
+	if limit := CommentContentsLengthLimit(); utf8.RuneCountInString(contents) > limit {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("must be at most %d characters long", limit)}
+	}
+	return nil
+}
+
+func validateNoControlCharacters(field, s, allowed string) error {
+	if !utf8.ValidString(s) {
+		return &ValidationError{Field: field, Message: "must be valid UTF-8"}
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) && !strings.ContainsRune(allowed, r) {
+			return &ValidationError{Field: field, Message: fmt.Sprintf("must not contain control characters (found %U)", r)}
+		}
+	}
+	return nil
+}
+
+// ValidateRefName returns a *ValidationError if name is not a valid Git branch
+// or other ref name (as described in `man git-check-ref-format`), optionally
+// followed by ancestry suffixes (e.g. "HEAD~2" or "master^"). Unlike Git, it
+// allows names with a single component (e.g. "master").
+func ValidateRefName(field, name string) error {
+	invalid := func(reason string) error {
+		return &ValidationError{Field: field, Message: "must be a valid Git ref name (" + reason + ")"}
+	}
+	if i := strings.IndexAny(name, "~^"); i != -1 {
+		if !refAncestrySuffix.MatchString(name[i:]) {
+			return invalid(`"~" and "^" may only be used as suffixes such as "~2"`)
+		}
+		name = name[:i]
+	}
+	switch {
+	case name == "":
+		return invalid("must not be empty")
+	case name == "@":
+		return invalid(`must not be "@"`)
+	case strings.HasPrefix(name, "-"):
+		return invalid("must not begin with a dash")
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") || strings.Contains(name, "//"):
+		return invalid("must not begin or end with a slash or contain consecutive slashes")
+	case strings.HasSuffix(name, "."):
+		return invalid("must not end with a dot")
+	case strings.Contains(name, ".."):
+		return invalid("must not contain two consecutive dots")
+	case strings.Contains(name, "@{"):
+		return invalid(`must not contain "@{"`)
+	case strings.ContainsAny(name, " :?*[\\\x7f"):
+		return invalid(`must not contain spaces or any of :?*[\`)
+	}
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return invalid(`components must not begin with a dot or end with ".lock"`)
+		}
+	}
+	for _, r := range name {
+		if r < 0x20 {
+			return invalid("must not contain control characters")
+		}
+	}
+	return nil
+}
+
+var refAncestrySuffix = lazyregexp.New(`^([~^][0-9]*)+$`)
@@ -0,0 +1,105 @@
+package discussions
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateThreadTitle(t *testing.T) {
+	tests := map[string]bool{
+		"Hello world": true,
+		"Unicode ✓":   true,
+		"":            false,
+		"   ":         false,
+		"a\nb":        false,
+		"a\x00b":      false,
+		strings.Repeat("a", MaxThreadTitleLength):   true,
+		strings.Repeat("a", MaxThreadTitleLength+1): false,
+		"\xff": false,
+	}
+	for title, wantValid := range tests {
+		if err := ValidateThreadTitle("title", title); (err == nil) != wantValid {
+			t.Errorf("%q: got error %v, want valid %v", title, err, wantValid)
+		}
+	}
+}
+
+func TestValidateCommentContents(t *testing.T) {
+	tests := map[string]bool{
+		"Hello\n\tworld\r\n": true,
+		"":                   false,
+		"\n":                 false,
+		"a\x1bb":             false,
+		strings.Repeat("a", MaxCommentContentsLength+1): false,
+	}
+	for contents, wantValid := range tests {
+		if err := ValidateCommentContents("contents", contents); (err == nil) != wantValid {
+			t.Errorf("%q: got error %v, want valid %v", contents, err, wantValid)
+		}
+	}
+}
+
+func TestValidateRefName(t *testing.T) {
+	tests := map[string]bool{
+		"master":          true,
+		"feature/foo-bar": true,
+		"HEAD~2":          true,
+		"master^":         true,
+		"v1.2.3^2~1":      true,
+		"":                false,
+		"@":               false,
+		"-f":              false,
+		"a b":             false,
+		"a..b":            false,
+		"a~b":             false,
+		"a:b":             false,
+		"/a":              false,
+		"a/":              false,
+		"a//b":            false,
+		"a.":              false,
+		"a/.b":            false,
+		"a.lock":          false,
+		"a@{1}":           false,
+		"a\x01":           false,
+	}
+	for name, wantValid := range tests {
+		if err := ValidateRefName("branch", name); (err == nil) != wantValid {
+			t.Errorf("%q: got error %v, want valid %v", name, err, wantValid)
+		}
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := ValidateThreadTitle("title", "")
+	if want := "title must not be empty"; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+	if want := map[string]interface{}{"code": "INVALID_INPUT", "field": "title"}; !reflect.DeepEqual(ErrorExtensions(err), want) {
+		t.Errorf("got extensions %v, want %v", ErrorExtensions(err), want)
+	}
+	if Code(err) != ErrorCodeInvalidInput {
+		t.Errorf("got code %q, want %q", Code(err), ErrorCodeInvalidInput)
+	}
+}
+
+func TestValidateCommentLength(t *testing.T) {
+	defer conf.Mock(nil)
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Discussions: &schema.Discussions{MaxCommentLength: 5},
+	}})
+
+	tests := map[string]bool{
+		"a\x1bbcd": true,
+		"✓✓✓✓✓":    true,
+		"abcdef":   false,
+	}
+	for contents, wantValid := range tests {
+		if err := ValidateCommentLength("contents", contents); (err == nil) != wantValid {
+			t.Errorf("%q: got error %v, want valid %v", contents, err, wantValid)
+		}
+	}
+}
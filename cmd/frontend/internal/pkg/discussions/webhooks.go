@@ -0,0 +1,165 @@
+package discussions
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	log15 "gopkg.in/inconshreveable/log15.v2"
+)
+
+var (
+	// webhookClient is the HTTP client used to deliver webhooks.
+	webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+	// webhookMaxAttempts is the number of times a delivery is attempted before
+	// it is given up on.
+	webhookMaxAttempts int32 = 5
+
+	// webhookBackoff returns how long to wait after the given (1-based)
+	// failed attempt before making the next one.
+	webhookBackoff = func(attempt int32) time.Duration {
+		return time.Duration(1<<uint(attempt-1)) * time.Second
+	}
+)
+
+// webhookPayload is the JSON body POSTed to a webhook URL for a thread event.
+type webhookPayload struct {
+	Event struct {
+		ID          int64                           `json:"id"`
+		Kind        types.DiscussionThreadEventKind `json:"kind"`
+		ThreadID    int64                           `json:"threadID"`
+		ActorUserID int32                           `json:"actorUserID"`
+		Data        types.DiscussionThreadEventData `json:"data"`
+		CreatedAt   time.Time                       `json:"createdAt"`
+	} `json:"event"`
+}
+
+// webhookSignature returns the value of the X-Sourcegraph-Signature header for
+// the payload: the hex-encoded HMAC-SHA256 of the body keyed with the
+// webhook's secret.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverThreadEventWebhooks delivers the event to every webhook that applies
+// to the event's thread.
+//
+// It returns immediately and does not block.
+func deliverThreadEventWebhooks(event *types.DiscussionThreadEvent) {
+	goroutine.Go(func() {
+		// 🚨 SECURITY: Webhooks are configured by site admins and receive
+		// every event on the threads they apply to, so the lookup must not be
+		// restricted to what the user who caused the event can see.
+		ctx := actor.WithActor(context.Background(), &actor.Actor{Internal: true})
+		webhooks, err := db.DiscussionWebhooks.List(ctx, &db.DiscussionWebhooksListOptions{ForThreadID: event.ThreadID})
+		if err != nil {
+			log15.Error("discussions: listing webhooks", "threadID", event.ThreadID, "error", err)
+			return
+		}
+		for _, webhook := range webhooks {
+			if err := deliverWebhook(ctx, webhook, event); err != nil {
+				log15.Error("discussions: deliverWebhook", "webhookID", webhook.ID, "eventID", event.ID, "error", err)
+			}
+		}
+	})
+}
+
+// deliverWebhook POSTs the event to the webhook, retrying with exponential
+// backoff until the webhook responds with a 2xx status code or
+// webhookMaxAttempts is reached. Each attempt is recorded in the webhook's
+// delivery log.
+func deliverWebhook(ctx context.Context, webhook *types.DiscussionWebhook, event *types.DiscussionThreadEvent) (err error) {
+	tr, ctx := trace.New(ctx, "discussions.deliverWebhook", string(event.Kind))
+	tr.LogFields(otlog.Int64("webhookID", webhook.ID), otlog.Int64("eventID", event.ID), otlog.Int64("threadID", event.ThreadID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	var payload webhookPayload
+	payload.Event.ID = event.ID
+	payload.Event.Kind = event.Kind
+	payload.Event.ThreadID = event.ThreadID
+	payload.Event.ActorUserID = event.ActorUserID
+	payload.Event.Data = event.Data
+	payload.Event.CreatedAt = event.CreatedAt
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	delivery, err := db.DiscussionWebhooks.CreateDelivery(ctx, webhook.ID, event.ID)
+	if err != nil {
+		return errors.Wrap(err, "DiscussionWebhooks.CreateDelivery")
+	}
+	for delivery.Attempts < webhookMaxAttempts {
+		if delivery.Attempts > 0 {
+			time.Sleep(webhookBackoff(delivery.Attempts))
+		}
+		delivery.Attempts++
+		start := time.Now()
+		statusCode, err := postWebhook(ctx, webhook, delivery.ID, string(event.Kind), body)
+		webhookDeliveryDuration.Observe(time.Since(start).Seconds())
+		webhookDeliveryAttemptsTotal.WithLabelValues(strconv.FormatBool(err == nil)).Inc()
+		tr.LogFields(otlog.Int32("attempt", delivery.Attempts), otlog.Int32("statusCode", statusCode))
+		delivery.StatusCode, delivery.Error = nil, nil
+		if statusCode != 0 {
+			delivery.StatusCode = &statusCode
+		}
+		if err != nil {
+			msg := err.Error()
+			delivery.Error = &msg
+		} else {
+			now := time.Now()
+			delivery.DeliveredAt = &now
+		}
+		if err := db.DiscussionWebhooks.UpdateDelivery(ctx, delivery); err != nil {
+			return errors.Wrap(err, "DiscussionWebhooks.UpdateDelivery")
+		}
+		if delivery.DeliveredAt != nil {
+			return nil
+		}
+	}
+	webhookDeliveriesFailedTotal.Inc()
+	return fmt.Errorf("giving up after %d attempts: %s", delivery.Attempts, *delivery.Error)
+}
+
+// postWebhook makes a single delivery attempt. It returns the response status
+// code (if a response was received) and an error if the attempt failed.
+func postWebhook(ctx context.Context, webhook *types.DiscussionWebhook, deliveryID int64, eventKind string, body []byte) (int32, error) {
+	req, err := http.NewRequest("POST", webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sourcegraph-Webhook")
+	req.Header.Set("X-Sourcegraph-Event", eventKind)
+	req.Header.Set("X-Sourcegraph-Delivery", strconv.FormatInt(deliveryID, 10))
+	req.Header.Set("X-Sourcegraph-Signature", webhookSignature(webhook.Secret, body))
+	resp, err := webhookClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return int32(resp.StatusCode), fmt.Errorf("unexpected HTTP response status %d", resp.StatusCode)
+	}
+	return int32(resp.StatusCode), nil
+}
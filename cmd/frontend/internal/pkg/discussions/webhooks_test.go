@@ -0,0 +1,85 @@
+package discussions
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestDeliverWebhook(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+	origBackoff := webhookBackoff
+	webhookBackoff = func(int32) time.Duration { return 0 }
+	defer func() { webhookBackoff = origBackoff }()
+
+	const secret = "s3cr3t"
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := r.Header.Get("X-Sourcegraph-Signature"), webhookSignature(secret, body); got != want {
+			t.Errorf("got signature %q, want %q", got, want)
+		}
+		if got, want := r.Header.Get("X-Sourcegraph-Event"), "ARCHIVED"; got != want {
+			t.Errorf("got event header %q, want %q", got, want)
+		}
+		if got, want := r.Header.Get("X-Sourcegraph-Delivery"), "3"; got != want {
+			t.Errorf("got delivery header %q, want %q", got, want)
+		}
+		if requests < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	db.Mocks.DiscussionWebhooks.CreateDelivery = func(_ context.Context, webhookID, eventID int64) (*types.DiscussionWebhookDelivery, error) {
+		return &types.DiscussionWebhookDelivery{ID: 3, WebhookID: webhookID, EventID: eventID}, nil
+	}
+	var updates []types.DiscussionWebhookDelivery
+	db.Mocks.DiscussionWebhooks.UpdateDelivery = func(_ context.Context, delivery *types.DiscussionWebhookDelivery) error {
+		updates = append(updates, *delivery)
+		return nil
+	}
+
+	webhook := &types.DiscussionWebhook{ID: 1, URL: ts.URL, Secret: secret}
+	event := &types.DiscussionThreadEvent{ID: 2, ThreadID: 4, Kind: types.DiscussionThreadEventArchived}
+	if err := deliverWebhook(context.Background(), webhook, event); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+	if len(updates) != 3 {
+		t.Fatalf("got %d delivery updates, want 3", len(updates))
+	}
+	if last := updates[2]; last.Attempts != 3 || last.DeliveredAt == nil || last.Error != nil || last.StatusCode == nil || *last.StatusCode != 200 {
+		t.Errorf("got last delivery update %+v, want successful 3rd attempt", last)
+	}
+	if first := updates[0]; first.DeliveredAt != nil || first.Error == nil || first.StatusCode == nil || *first.StatusCode != 500 {
+		t.Errorf("got first delivery update %+v, want failed attempt", first)
+	}
+
+	// Deliveries that never succeed are given up on.
+	requests = -100
+	updates = nil
+	failedBefore := testutil.ToFloat64(webhookDeliveriesFailedTotal)
+	if err := deliverWebhook(context.Background(), webhook, event); err == nil {
+		t.Error("expected error after exhausting attempts")
+	}
+	if int32(len(updates)) != webhookMaxAttempts {
+		t.Errorf("got %d delivery updates, want %d", len(updates), webhookMaxAttempts)
+	}
+	if got := testutil.ToFloat64(webhookDeliveriesFailedTotal) - failedBefore; got != 1 {
+		t.Errorf("got %v failed deliveries recorded, want 1", got)
+	}
+}
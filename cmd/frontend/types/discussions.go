@@ -9,14 +9,28 @@ import (
 // DiscussionThread mirrors the underlying discussion_threads field types exactly.
 // It intentionally does not try to e.g. alleviate null fields.
 type DiscussionThread struct {
-	ID           int64
-	AuthorUserID int32
-	Title        string
-	TargetRepo   *DiscussionThreadTargetRepo
-	CreatedAt    time.Time
-	ArchivedAt   *time.Time
-	UpdatedAt    time.Time
-	DeletedAt    *time.Time
+	ID                  int64
+	AuthorUserID        int32
+	Title               string
+	TargetRepo          *DiscussionThreadTargetRepo
+	CreatedAt           time.Time
+	ClosedAt            *time.Time
+	ArchivedAt          *time.Time
+	LockedAt            *time.Time
+	StaleAt             *time.Time
+	PinnedAt            *time.Time
+	Reports             []string
+	DuplicateOfThreadID *int64
+	MilestoneID         *int64
+	CommentCount        int32
+	ReactionCount       int32
+	IdempotencyKey      *string
+	UpdatedAt           time.Time
+	DeletedAt           *time.Time
+
+	// RepoDeletedAt is when the thread's target repository was deleted (which
+	// detached the thread from it), if it was.
+	RepoDeletedAt *time.Time
 }
 
 // DiscussionThreadTargetRepo mirrors the underlying discussion_threads_target_repo field types exactly.
@@ -25,6 +39,7 @@ type DiscussionThreadTargetRepo struct {
 	ID       int64
 	ThreadID int64
 	RepoID   api.RepoID
+	Number   int32
 	Path     *string
 	Branch   *string
 	Revision *string
@@ -47,12 +62,382 @@ func (d *DiscussionThreadTargetRepo) HasSelection() bool {
 // DiscussionComment mirrors the underlying discussion_comments field types exactly.
 // It intentionally does not try to e.g. alleviate null fields.
 type DiscussionComment struct {
-	ID           int64
-	ThreadID     int64
-	AuthorUserID int32
-	Contents     string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    *time.Time
-	Reports      []string
+	ID               int64
+	ThreadID         int64
+	ParentCommentID  *int64
+	AuthorUserID     int32
+	Contents         string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        *time.Time
+	Reports          []string
+	ResolvedAt       *time.Time
+	ResolvedByUserID *int32
+	HiddenAt         *time.Time
+	HiddenByUserID   *int32
+}
+
+// DiscussionLabel mirrors the underlying discussion_labels field types exactly.
+// It intentionally does not try to e.g. alleviate null fields.
+type DiscussionLabel struct {
+	ID          int64
+	RepoID      api.RepoID
+	Name        string
+	Description *string
+	Color       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DiscussionThreadTemplate mirrors the underlying discussion_thread_templates
+// field types exactly. It intentionally does not try to e.g. alleviate null
+// fields.
+type DiscussionThreadTemplate struct {
+	ID          int64
+	RepoID      api.RepoID
+	Name        string
+	Description *string
+	Title       *string
+	Contents    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DiscussionSavedReply mirrors the underlying discussion_saved_replies field
+// types exactly. It intentionally does not try to e.g. alleviate null fields.
+type DiscussionSavedReply struct {
+	ID        int64
+	UserID    int32
+	Name      string
+	Contents  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DiscussionMilestone mirrors the underlying discussion_milestones field
+// types exactly. It intentionally does not try to e.g. alleviate null fields.
+type DiscussionMilestone struct {
+	ID          int64
+	RepoID      *api.RepoID
+	OrgID       *int32
+	Title       string
+	Description *string
+	DueDate     *time.Time
+	ClosedAt    *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DiscussionCommentMention is a user or organization mentioned in a
+// discussion comment. Exactly one of UserID and OrgID is set.
+type DiscussionCommentMention struct {
+	CommentID int64
+	UserID    *int32
+	OrgID     *int32
+}
+
+// DiscussionCommentEdit is a prior version of a discussion comment's
+// contents, recorded when the comment was edited.
+type DiscussionCommentEdit struct {
+	ID               int64
+	CommentID        int64
+	EditorUserID     int32
+	PreviousContents string
+	CreatedAt        time.Time
+}
+
+// DiscussionCommentSuggestion is a change suggested by a discussion comment: a
+// replacement for the lines selected by the comment's thread.
+type DiscussionCommentSuggestion struct {
+	CommentID       int64
+	Contents        string
+	AppliedAt       *time.Time
+	AppliedByUserID *int32
+	AppliedCommitID *string
+}
+
+// DiscussionCommentAttachment is a file attached to a discussion comment. Its
+// contents are kept in the attachment storage (see the
+// discussions/attachments package), not in the database.
+type DiscussionCommentAttachment struct {
+	ID          int64
+	CommentID   int64
+	Filename    string
+	ContentType string
+	Size        int64
+	UploadedAt  *time.Time
+	CreatedAt   time.Time
+}
+
+// DiscussionReactionContent is the emoji of a reaction to a discussion
+// comment.
+type DiscussionReactionContent string
+
+// The reaction contents that are allowed.
+const (
+	DiscussionReactionThumbsUp   DiscussionReactionContent = "THUMBS_UP"
+	DiscussionReactionThumbsDown DiscussionReactionContent = "THUMBS_DOWN"
+	DiscussionReactionLaugh      DiscussionReactionContent = "LAUGH"
+	DiscussionReactionHooray     DiscussionReactionContent = "HOORAY"
+	DiscussionReactionConfused   DiscussionReactionContent = "CONFUSED"
+	DiscussionReactionHeart      DiscussionReactionContent = "HEART"
+	DiscussionReactionRocket     DiscussionReactionContent = "ROCKET"
+	DiscussionReactionEyes       DiscussionReactionContent = "EYES"
+)
+
+// DiscussionReactionGroup is the aggregate of all reactions with the same
+// content to a discussion comment.
+type DiscussionReactionGroup struct {
+	Content DiscussionReactionContent
+	Count   int
+
+	// ViewerHasReacted is whether the user that the group was computed for
+	// is one of the users who reacted.
+	ViewerHasReacted bool
+}
+
+// DiscussionWebhook mirrors the underlying discussion_webhooks field types
+// exactly. It intentionally does not try to e.g. alleviate null fields.
+type DiscussionWebhook struct {
+	ID        int64
+	RepoID    *api.RepoID
+	URL       string
+	Secret    string
+	CreatedAt time.Time
+}
+
+// DiscussionWebhookDelivery mirrors the underlying
+// discussion_webhook_deliveries field types exactly. It intentionally does not
+// try to e.g. alleviate null fields.
+type DiscussionWebhookDelivery struct {
+	ID          int64
+	WebhookID   int64
+	EventID     int64
+	Attempts    int32
+	StatusCode  *int32
+	Error       *string
+	CreatedAt   time.Time
+	DeliveredAt *time.Time
+}
+
+// DiscussionFeedKind is the kind of threads that a discussion feed lists.
+type DiscussionFeedKind string
+
+const (
+	// DiscussionFeedRepository lists the threads of a repository.
+	DiscussionFeedRepository DiscussionFeedKind = "repository"
+
+	// DiscussionFeedAssigned lists the threads assigned to the feed's user.
+	DiscussionFeedAssigned DiscussionFeedKind = "assigned"
+
+	// DiscussionFeedMentioned lists the threads that @mention the feed's
+	// user.
+	DiscussionFeedMentioned DiscussionFeedKind = "mentioned"
+)
+
+// DiscussionFeedToken mirrors the underlying discussion_feed_tokens field
+// types exactly, except that the token's hash is omitted. It intentionally
+// does not try to e.g. alleviate null fields.
+type DiscussionFeedToken struct {
+	ID         int64
+	UserID     int32
+	Kind       DiscussionFeedKind
+	RepoID     *api.RepoID
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// DiscussionDiagnosticSeverity is the severity of a DiscussionThreadDiagnostic.
+// The severities match those of the Language Server Protocol.
+type DiscussionDiagnosticSeverity string
+
+const (
+	DiscussionDiagnosticError   DiscussionDiagnosticSeverity = "error"
+	DiscussionDiagnosticWarning DiscussionDiagnosticSeverity = "warning"
+	DiscussionDiagnosticInfo    DiscussionDiagnosticSeverity = "info"
+	DiscussionDiagnosticHint    DiscussionDiagnosticSeverity = "hint"
+)
+
+// DiscussionThreadDiagnostic mirrors the underlying
+// discussion_thread_diagnostics field types exactly. It is a problem (such as
+// a compiler error or lint warning) in a file of the thread's repository,
+// reported by a tool or extension (the source).
+type DiscussionThreadDiagnostic struct {
+	ID             int64
+	ThreadID       int64
+	Source         string
+	Path           string
+	StartLine      int32
+	StartCharacter int32
+	EndLine        int32
+	EndCharacter   int32
+	Message        string
+	Severity       DiscussionDiagnosticSeverity
+	CreatedAt      time.Time
+}
+
+// DiscussionRule mirrors the underlying discussion_rules field types exactly.
+// A rule adds labels and assignees to the threads of its repository that
+// match its query (in discussion thread search query syntax) when they are
+// created or their title is edited.
+type DiscussionRule struct {
+	ID              int64
+	RepoID          api.RepoID
+	Name            string
+	Query           string
+	LabelIDs        []int64
+	AssigneeUserIDs []int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// DiscussionThreadEventKind is the kind of a DiscussionThreadEvent.
+type DiscussionThreadEventKind string
+
+const (
+	DiscussionThreadEventCreated           DiscussionThreadEventKind = "CREATED"
+	DiscussionThreadEventTitleEdited       DiscussionThreadEventKind = "TITLE_EDITED"
+	DiscussionThreadEventArchived          DiscussionThreadEventKind = "ARCHIVED"
+	DiscussionThreadEventUnarchived        DiscussionThreadEventKind = "UNARCHIVED"
+	DiscussionThreadEventClosed            DiscussionThreadEventKind = "CLOSED"
+	DiscussionThreadEventReopened          DiscussionThreadEventKind = "REOPENED"
+	DiscussionThreadEventLocked            DiscussionThreadEventKind = "LOCKED"
+	DiscussionThreadEventUnlocked          DiscussionThreadEventKind = "UNLOCKED"
+	DiscussionThreadEventLabelAdded        DiscussionThreadEventKind = "LABEL_ADDED"
+	DiscussionThreadEventLabelRemoved      DiscussionThreadEventKind = "LABEL_REMOVED"
+	DiscussionThreadEventAssigned          DiscussionThreadEventKind = "ASSIGNED"
+	DiscussionThreadEventUnassigned        DiscussionThreadEventKind = "UNASSIGNED"
+	DiscussionThreadEventMilestoned        DiscussionThreadEventKind = "MILESTONED"
+	DiscussionThreadEventDemilestoned      DiscussionThreadEventKind = "DEMILESTONED"
+	DiscussionThreadEventCommented         DiscussionThreadEventKind = "COMMENTED"
+	DiscussionThreadEventDeleted           DiscussionThreadEventKind = "DELETED"
+	DiscussionThreadEventRestored          DiscussionThreadEventKind = "RESTORED"
+	DiscussionThreadEventSuggestionApplied DiscussionThreadEventKind = "SUGGESTION_APPLIED"
+	DiscussionThreadEventCommentResolved   DiscussionThreadEventKind = "COMMENT_RESOLVED"
+	DiscussionThreadEventCommentUnresolved DiscussionThreadEventKind = "COMMENT_UNRESOLVED"
+	DiscussionThreadEventReferenced        DiscussionThreadEventKind = "REFERENCED"
+	DiscussionThreadEventMarkedStale       DiscussionThreadEventKind = "MARKED_STALE"
+	DiscussionThreadEventTransferred       DiscussionThreadEventKind = "TRANSFERRED"
+	DiscussionThreadEventMarkedAsDuplicate DiscussionThreadEventKind = "MARKED_AS_DUPLICATE"
+	DiscussionThreadEventPinned            DiscussionThreadEventKind = "PINNED"
+	DiscussionThreadEventUnpinned          DiscussionThreadEventKind = "UNPINNED"
+	DiscussionThreadEventDependencyAdded   DiscussionThreadEventKind = "DEPENDENCY_ADDED"
+	DiscussionThreadEventDependencyRemoved DiscussionThreadEventKind = "DEPENDENCY_REMOVED"
+)
+
+// DiscussionThreadEvent mirrors the underlying discussion_thread_events field
+// types exactly. It intentionally does not try to e.g. alleviate null fields.
+type DiscussionThreadEvent struct {
+	ID          int64
+	ThreadID    int64
+	ActorUserID int32
+	Kind        DiscussionThreadEventKind
+	Data        DiscussionThreadEventData
+	CreatedAt   time.Time
+}
+
+// DiscussionThreadEventData is the kind-specific data of a
+// DiscussionThreadEvent. Only the fields relevant to the event's kind are set.
+type DiscussionThreadEventData struct {
+	PreviousTitle      *string     `json:",omitempty"` // TITLE_EDITED
+	Title              *string     `json:",omitempty"` // TITLE_EDITED
+	LabelID            *int64      `json:",omitempty"` // LABEL_ADDED, LABEL_REMOVED
+	AssigneeUserID     *int32      `json:",omitempty"` // ASSIGNED, UNASSIGNED
+	CommentID          *int64      `json:",omitempty"` // COMMENTED, SUGGESTION_APPLIED, COMMENT_RESOLVED, COMMENT_UNRESOLVED, REFERENCED
+	MilestoneID        *int64      `json:",omitempty"` // MILESTONED, DEMILESTONED
+	PreviousRepoID     *api.RepoID `json:",omitempty"` // TRANSFERRED
+	RepoID             *api.RepoID `json:",omitempty"` // TRANSFERRED
+	OriginalThreadID   *int64      `json:",omitempty"` // MARKED_AS_DUPLICATE
+	DependencyThreadID *int64      `json:",omitempty"` // DEPENDENCY_ADDED, DEPENDENCY_REMOVED
+	RuleID             *int64      `json:",omitempty"` // LABEL_ADDED, ASSIGNED (when made by a rule)
+}
+
+// DiscussionThreadStatistics summarizes the threads of a repository. It is
+// computed from the discussion_threads and discussion_thread_events tables.
+type DiscussionThreadStatistics struct {
+	OpenCount     int
+	ArchivedCount int
+
+	// MedianTimeToArchive is the median time from the creation of an archived
+	// thread until it was first archived, or nil if no thread is archived.
+	MedianTimeToArchive *time.Duration
+
+	// WeeklyComments is the number of comments made in each week, oldest
+	// first. Weeks begin on Monday (UTC).
+	WeeklyComments []DiscussionWeeklyCount
+}
+
+// DiscussionWeeklyCount is the number of occurrences of something in a week.
+type DiscussionWeeklyCount struct {
+	Week  time.Time
+	Count int
+}
+
+// DiscussionAuditLogObjectKind is the kind of object that a
+// DiscussionAuditLogEntry records a mutation of.
+type DiscussionAuditLogObjectKind string
+
+const (
+	DiscussionAuditLogObjectThread  DiscussionAuditLogObjectKind = "thread"
+	DiscussionAuditLogObjectComment DiscussionAuditLogObjectKind = "comment"
+)
+
+// DiscussionAuditLogEntry mirrors the underlying discussion_audit_log field
+// types exactly. It intentionally does not try to e.g. alleviate null fields.
+type DiscussionAuditLogEntry struct {
+	ID                int64
+	ActorUserID       *int32
+	ActorIP           *string
+	ActorForwardedFor *string
+	ObjectKind        DiscussionAuditLogObjectKind
+	ObjectID          int64
+	Action            string
+	Before            []byte
+	After             []byte
+	CreatedAt         time.Time
+}
+
+// DiscussionThreadNotificationLevel is the kind of thread activity that a
+// subscriber of a thread is notified of. Users are always notified when they
+// are mentioned, regardless of their notification level.
+type DiscussionThreadNotificationLevel string
+
+const (
+	DiscussionThreadNotifyAll          DiscussionThreadNotificationLevel = "all"
+	DiscussionThreadNotifyComments     DiscussionThreadNotificationLevel = "comments"
+	DiscussionThreadNotifyStateChanges DiscussionThreadNotificationLevel = "state_changes"
+)
+
+// DiscussionRoleKind is a role that a user can have in the discussions of a
+// repository or organization. Each role includes the permissions of the roles
+// before it in this list.
+type DiscussionRoleKind string
+
+const (
+	DiscussionRoleContributor DiscussionRoleKind = "CONTRIBUTOR"
+	DiscussionRoleTriager     DiscussionRoleKind = "TRIAGER"
+	DiscussionRoleMaintainer  DiscussionRoleKind = "MAINTAINER"
+)
+
+// Includes reports whether the role includes the permissions of the other
+// role.
+func (r DiscussionRoleKind) Includes(other DiscussionRoleKind) bool {
+	return discussionRoleLevels[r] >= discussionRoleLevels[other] && discussionRoleLevels[other] > 0
+}
+
+var discussionRoleLevels = map[DiscussionRoleKind]int{
+	DiscussionRoleContributor: 1,
+	DiscussionRoleTriager:     2,
+	DiscussionRoleMaintainer:  3,
+}
+
+// DiscussionRole mirrors the underlying discussion_roles field types exactly.
+// Exactly one of RepoID and OrgID is set.
+type DiscussionRole struct {
+	UserID    int32
+	RepoID    *api.RepoID
+	OrgID     *int32
+	Role      DiscussionRoleKind
+	CreatedAt time.Time
 }
@@ -198,7 +198,11 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 			return http.StatusInternalServerError, resp
 		}
 
-		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL, fmt.Sprintf("%s:%s", cmtHash, ref))
+		force := "--force"
+		if req.PushWithLease {
+			force = fmt.Sprintf("--force-with-lease=%s:%s", ref, req.BaseCommit)
+		}
+		cmd = exec.CommandContext(ctx, "git", "push", force, remoteURL, fmt.Sprintf("%s:%s", cmtHash, ref))
 		cmd.Dir = repoGitDir
 
 		if out, err = run(cmd, "pushing ref"); err != nil {
@@ -142,6 +142,10 @@ type CreateCommitFromPatchRequest struct {
 	CommitInfo PatchCommitInfo
 	// Push specifies whether the target ref will be pushed to the code host
 	Push bool
+	// PushWithLease, when Push is true, specifies that the push must fail if
+	// the target ref on the code host no longer points at BaseCommit, instead
+	// of overwriting it.
+	PushWithLease bool
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
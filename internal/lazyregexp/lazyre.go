@@ -67,6 +67,10 @@ func (r *Regexp) FindAllStringSubmatch(s string, n int) [][]string {
 	return r.re().FindAllStringSubmatch(s, n)
 }
 
+func (r *Regexp) FindAllStringSubmatchIndex(s string, n int) [][]int {
+	return r.re().FindAllStringSubmatchIndex(s, n)
+}
+
 func (r *Regexp) Split(s string, n int) []string {
 	return r.re().Split(s, n)
 }
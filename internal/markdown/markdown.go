@@ -24,6 +24,8 @@ func Render(content string) string {
 		policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
 		policy.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^$`)).OnElements("input")
 		policy.AllowAttrs("class").Matching(regexp.MustCompile("^language-[a-zA-Z0-9]+$")).OnElements("code")
+		// Fenced code blocks with a language are rendered as <div class="highlight highlight-LANG"><pre>.
+		policy.AllowAttrs("class").Matching(regexp.MustCompile(`^highlight highlight-[a-zA-Z0-9+#-]+$`)).OnElements("div")
 	})
 
 	unsafeHTML := gfm.Markdown([]byte(content))
@@ -0,0 +1,48 @@
+// Package requestclient provides the client (such as the IP address) that made the HTTP request
+// that a context belongs to.
+package requestclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+)
+
+// Client describes the client that made an HTTP request.
+type Client struct {
+	// IP is the IP address of the client's connection (which may be a proxy).
+	IP string `json:",omitempty"`
+
+	// ForwardedFor is the value of the request's X-Forwarded-For header, if any.
+	ForwardedFor string `json:",omitempty"`
+}
+
+type key int
+
+const clientKey key = iota
+
+// FromContext returns the client of the HTTP request that the context belongs to, or nil if there
+// is none (e.g. for background jobs).
+func FromContext(ctx context.Context) *Client {
+	c, _ := ctx.Value(clientKey).(*Client)
+	return c
+}
+
+// WithClient returns a copy of the context with the given client.
+func WithClient(ctx context.Context, c *Client) context.Context {
+	return context.WithValue(ctx, clientKey, c)
+}
+
+// HTTPMiddleware sets the client of each request in the request's context.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			ip = host
+		}
+		next.ServeHTTP(w, r.WithContext(WithClient(r.Context(), &Client{
+			IP:           ip,
+			ForwardedFor: r.Header.Get("X-Forwarded-For"),
+		})))
+	})
+}
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS discussion_threads_labels;
+DROP TABLE IF EXISTS discussion_labels;
+
+COMMIT;
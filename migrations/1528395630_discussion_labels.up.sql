@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_labels (
+    id bigserial PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    description text,
+    color text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_labels_repo_id_name_idx ON discussion_labels(repo_id, name);
+
+CREATE TABLE IF NOT EXISTS discussion_threads_labels (
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    label_id bigint NOT NULL REFERENCES discussion_labels(id) ON DELETE CASCADE,
+    PRIMARY KEY (thread_id, label_id)
+);
+CREATE INDEX IF NOT EXISTS discussion_threads_labels_label_id_idx ON discussion_threads_labels(label_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS discussion_threads_assignees;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_threads_assignees (
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (thread_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS discussion_threads_assignees_user_id_idx ON discussion_threads_assignees(user_id);
+
+COMMIT;
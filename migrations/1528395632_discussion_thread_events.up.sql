@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_thread_events (
+    id bigserial NOT NULL PRIMARY KEY,
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    actor_user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind text NOT NULL,
+    data jsonb NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS discussion_thread_events_thread_id_created_at_idx ON discussion_thread_events(thread_id, created_at);
+
+COMMIT;
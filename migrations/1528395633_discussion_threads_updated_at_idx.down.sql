@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS discussion_threads_updated_at_id_idx;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS discussion_threads_updated_at_id_idx ON discussion_threads(updated_at, id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS discussion_threads_title_fts_idx;
+DROP INDEX IF EXISTS discussion_comments_contents_fts_idx;
+
+COMMIT;
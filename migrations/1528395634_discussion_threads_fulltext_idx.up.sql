@@ -0,0 +1,6 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS discussion_threads_title_fts_idx ON discussion_threads USING gin(to_tsvector('english', title));
+CREATE INDEX IF NOT EXISTS discussion_comments_contents_fts_idx ON discussion_comments USING gin(to_tsvector('english', contents));
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS discussion_thread_subscriptions;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_thread_subscriptions (
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subscribed boolean NOT NULL DEFAULT true,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (thread_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS discussion_thread_subscriptions_user_id_idx ON discussion_thread_subscriptions(user_id);
+
+-- Previously, everyone who commented on a thread was implicitly subscribed to it.
+INSERT INTO discussion_thread_subscriptions(thread_id, user_id)
+    SELECT DISTINCT thread_id, author_user_id FROM discussion_comments WHERE deleted_at IS NULL
+    ON CONFLICT DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS locked_at;
+
+COMMIT;
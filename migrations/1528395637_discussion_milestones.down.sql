@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS milestone_id;
+DROP TABLE IF EXISTS discussion_milestones;
+
+COMMIT;
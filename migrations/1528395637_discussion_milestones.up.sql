@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_milestones (
+    id bigserial PRIMARY KEY,
+    repo_id integer REFERENCES repo(id) ON DELETE CASCADE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    title text NOT NULL,
+    description text,
+    due_date timestamp with time zone,
+    closed_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT discussion_milestones_has_one_owner CHECK ((repo_id IS NULL) <> (org_id IS NULL))
+);
+CREATE INDEX IF NOT EXISTS discussion_milestones_repo_id_idx ON discussion_milestones(repo_id);
+CREATE INDEX IF NOT EXISTS discussion_milestones_org_id_idx ON discussion_milestones(org_id);
+
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS milestone_id bigint REFERENCES discussion_milestones(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS discussion_threads_milestone_id_idx ON discussion_threads(milestone_id);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_thread_templates (
+    id bigserial PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    description text,
+    title text,
+    contents text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_thread_templates_repo_id_name_idx ON discussion_thread_templates(repo_id, name);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS discussion_webhook_deliveries;
+DROP TABLE IF EXISTS discussion_webhooks;
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_webhooks (
+    id bigserial PRIMARY KEY,
+    repo_id integer REFERENCES repo(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    secret text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS discussion_webhooks_repo_id_idx ON discussion_webhooks(repo_id);
+
+CREATE TABLE IF NOT EXISTS discussion_webhook_deliveries (
+    id bigserial PRIMARY KEY,
+    webhook_id bigint NOT NULL REFERENCES discussion_webhooks(id) ON DELETE CASCADE,
+    event_id bigint NOT NULL REFERENCES discussion_thread_events(id) ON DELETE CASCADE,
+    attempts integer NOT NULL DEFAULT 0,
+    status_code integer,
+    error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    delivered_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS discussion_webhook_deliveries_webhook_id_idx ON discussion_webhook_deliveries(webhook_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_unsubscribe_tokens (
+    token text PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_unsubscribe_tokens_user_id_thread_id_idx ON discussion_unsubscribe_tokens(user_id, thread_id);
+
+COMMIT;
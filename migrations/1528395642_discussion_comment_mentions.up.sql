@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_comment_mentions (
+    comment_id bigint NOT NULL REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    CONSTRAINT discussion_comment_mentions_has_one_target CHECK ((user_id IS NULL) <> (org_id IS NULL))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_comment_mentions_comment_id_user_id_idx ON discussion_comment_mentions(comment_id, user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_comment_mentions_comment_id_org_id_idx ON discussion_comment_mentions(comment_id, org_id);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_comment_edits (
+    id bigserial PRIMARY KEY,
+    comment_id bigint NOT NULL REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    editor_user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    previous_contents text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS discussion_comment_edits_comment_id_idx ON discussion_comment_edits(comment_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE discussion_comments DROP COLUMN IF EXISTS parent_comment_id;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE discussion_comments ADD COLUMN IF NOT EXISTS parent_comment_id bigint REFERENCES discussion_comments(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS discussion_comments_parent_comment_id_idx ON discussion_comments(parent_comment_id);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_comment_suggestions (
+    comment_id bigint PRIMARY KEY REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    contents text NOT NULL,
+    applied_at timestamp with time zone,
+    applied_by_user_id integer REFERENCES users(id) ON DELETE SET NULL,
+    applied_commit_id text
+);
+
+COMMIT;
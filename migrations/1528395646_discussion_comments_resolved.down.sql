@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE discussion_comments DROP COLUMN IF EXISTS resolved_by_user_id;
+ALTER TABLE discussion_comments DROP COLUMN IF EXISTS resolved_at;
+
+COMMIT;
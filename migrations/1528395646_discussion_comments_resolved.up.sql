@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE discussion_comments ADD COLUMN IF NOT EXISTS resolved_at timestamp with time zone;
+ALTER TABLE discussion_comments ADD COLUMN IF NOT EXISTS resolved_by_user_id integer REFERENCES users(id) ON DELETE SET NULL;
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_comment_attachments (
+    id bigserial PRIMARY KEY,
+    comment_id bigint NOT NULL REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    filename text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    upload_token text UNIQUE,
+    upload_expires_at timestamp with time zone,
+    uploaded_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS discussion_comment_attachments_comment_id_idx ON discussion_comment_attachments(comment_id);
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_thread_references (
+    comment_id bigint NOT NULL REFERENCES discussion_comments(id) ON DELETE CASCADE,
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    referenced_thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (comment_id, referenced_thread_id),
+    CONSTRAINT discussion_thread_references_not_self CHECK (thread_id <> referenced_thread_id)
+);
+CREATE INDEX IF NOT EXISTS discussion_thread_references_thread_id_idx ON discussion_thread_references(thread_id);
+CREATE INDEX IF NOT EXISTS discussion_thread_references_referenced_thread_id_idx ON discussion_thread_references(referenced_thread_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS duplicate_of_thread_id bigint REFERENCES discussion_threads(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS discussion_threads_duplicate_of_thread_id_idx ON discussion_threads(duplicate_of_thread_id);
+
+COMMIT;
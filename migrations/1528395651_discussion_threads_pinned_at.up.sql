@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS pinned_at timestamp with time zone;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS discussion_audit_log;
+DROP FUNCTION IF EXISTS discussion_audit_log_immutable();
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_audit_log (
+    id bigserial PRIMARY KEY,
+    actor_user_id integer,
+    actor_ip text,
+    actor_forwarded_for text,
+    object_kind text NOT NULL CHECK (object_kind IN ('thread', 'comment')),
+    object_id bigint NOT NULL,
+    action text NOT NULL,
+    before jsonb,
+    after jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS discussion_audit_log_object_idx ON discussion_audit_log(object_kind, object_id);
+CREATE INDEX IF NOT EXISTS discussion_audit_log_actor_user_id_idx ON discussion_audit_log(actor_user_id);
+CREATE INDEX IF NOT EXISTS discussion_audit_log_created_at_idx ON discussion_audit_log(created_at);
+
+-- The audit log is append-only: entries can never be changed or removed.
+CREATE OR REPLACE FUNCTION discussion_audit_log_immutable() RETURNS TRIGGER AS
+$discussion_audit_log_immutable$
+BEGIN
+  RAISE EXCEPTION 'discussion_audit_log entries cannot be modified or deleted';
+END;
+$discussion_audit_log_immutable$
+LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trig_discussion_audit_log_immutable ON discussion_audit_log;
+CREATE TRIGGER trig_discussion_audit_log_immutable
+BEFORE UPDATE OR DELETE ON discussion_audit_log
+FOR EACH ROW EXECUTE PROCEDURE discussion_audit_log_immutable();
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE discussion_thread_subscriptions DROP COLUMN IF EXISTS notification_level;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE discussion_thread_subscriptions ADD COLUMN IF NOT EXISTS notification_level text NOT NULL DEFAULT 'all' CHECK (notification_level IN ('all', 'comments', 'state_changes'));
+
+COMMIT;
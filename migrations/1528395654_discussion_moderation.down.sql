@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE discussion_comments DROP COLUMN IF EXISTS hidden_by_user_id;
+ALTER TABLE discussion_comments DROP COLUMN IF EXISTS hidden_at;
+
+DROP INDEX IF EXISTS discussion_threads_reports_array_length_idx;
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS reports;
+
+COMMIT;
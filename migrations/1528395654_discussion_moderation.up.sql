@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS reports text[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS discussion_threads_reports_array_length_idx ON discussion_threads(array_length(reports, 1));
+
+ALTER TABLE discussion_comments ADD COLUMN IF NOT EXISTS hidden_at timestamp with time zone;
+ALTER TABLE discussion_comments ADD COLUMN IF NOT EXISTS hidden_by_user_id integer REFERENCES users(id) ON DELETE SET NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_thread_dependencies (
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    blocked_by_thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (thread_id, blocked_by_thread_id),
+    CONSTRAINT discussion_thread_dependencies_not_self CHECK (thread_id <> blocked_by_thread_id)
+);
+CREATE INDEX IF NOT EXISTS discussion_thread_dependencies_blocked_by_thread_id_idx ON discussion_thread_dependencies(blocked_by_thread_id);
+
+COMMIT;
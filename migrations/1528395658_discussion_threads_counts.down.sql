@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_discussion_comment_reactions_update_thread_counts ON discussion_comment_reactions;
+DROP FUNCTION IF EXISTS discussion_comment_reactions_update_thread_counts();
+DROP TRIGGER IF EXISTS trig_discussion_comments_update_thread_counts ON discussion_comments;
+DROP FUNCTION IF EXISTS discussion_comments_update_thread_counts();
+
+DROP INDEX IF EXISTS discussion_threads_comment_count_id_idx;
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS reaction_count;
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS comment_count;
+
+COMMIT;
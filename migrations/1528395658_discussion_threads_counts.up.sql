@@ -0,0 +1,91 @@
+BEGIN;
+
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS comment_count integer NOT NULL DEFAULT 0;
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS reaction_count integer NOT NULL DEFAULT 0;
+
+UPDATE discussion_threads t SET
+  comment_count = (SELECT COUNT(*) FROM discussion_comments c WHERE c.thread_id = t.id AND c.deleted_at IS NULL),
+  reaction_count = (
+    SELECT COUNT(*) FROM discussion_comment_reactions r
+    JOIN discussion_comments c ON c.id = r.comment_id
+    WHERE c.thread_id = t.id AND c.deleted_at IS NULL
+  );
+
+CREATE INDEX IF NOT EXISTS discussion_threads_comment_count_id_idx ON discussion_threads(comment_count, id);
+
+-- comment_count is the number of the thread's (non-deleted) comments, and
+-- reaction_count is the number of reactions to those comments.
+--
+-- The comments trigger runs BEFORE DELETE so that the reactions of a deleted
+-- comment can still be counted (they are deleted by the cascade after the
+-- comment).
+CREATE OR REPLACE FUNCTION discussion_comments_update_thread_counts() RETURNS TRIGGER AS
+$discussion_comments_update_thread_counts$
+DECLARE
+  delta integer := 0;
+  comment_row discussion_comments;
+BEGIN
+  IF (TG_OP = 'INSERT') THEN
+    comment_row := NEW;
+    IF (NEW.deleted_at IS NULL) THEN
+      delta := 1;
+    END IF;
+  ELSIF (TG_OP = 'DELETE') THEN
+    comment_row := OLD;
+    IF (OLD.deleted_at IS NULL) THEN
+      delta := -1;
+    END IF;
+  ELSE
+    comment_row := NEW;
+    IF (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL) THEN
+      delta := -1;
+    ELSIF (OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL) THEN
+      delta := 1;
+    END IF;
+  END IF;
+
+  IF (delta != 0) THEN
+    UPDATE discussion_threads SET
+      comment_count = comment_count + delta,
+      reaction_count = reaction_count + delta * (SELECT COUNT(*) FROM discussion_comment_reactions WHERE comment_id = comment_row.id)
+    WHERE id = comment_row.thread_id;
+  END IF;
+
+  IF (TG_OP = 'DELETE') THEN
+    RETURN OLD;
+  END IF;
+  RETURN NEW;
+END;
+$discussion_comments_update_thread_counts$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER trig_discussion_comments_update_thread_counts
+BEFORE INSERT OR UPDATE OF deleted_at OR DELETE ON discussion_comments
+FOR EACH ROW EXECUTE PROCEDURE discussion_comments_update_thread_counts();
+
+-- Reactions to deleted comments are not counted (see above). The trigger runs
+-- AFTER INSERT so that reactions that already existed (ON CONFLICT DO
+-- NOTHING) are not counted twice.
+CREATE OR REPLACE FUNCTION discussion_comment_reactions_update_thread_counts() RETURNS TRIGGER AS
+$discussion_comment_reactions_update_thread_counts$
+BEGIN
+  IF (TG_OP = 'INSERT') THEN
+    UPDATE discussion_threads t SET reaction_count = reaction_count + 1
+    FROM discussion_comments c
+    WHERE c.id = NEW.comment_id AND c.deleted_at IS NULL AND t.id = c.thread_id;
+    RETURN NEW;
+  END IF;
+
+  UPDATE discussion_threads t SET reaction_count = reaction_count - 1
+  FROM discussion_comments c
+  WHERE c.id = OLD.comment_id AND c.deleted_at IS NULL AND t.id = c.thread_id;
+  RETURN OLD;
+END;
+$discussion_comment_reactions_update_thread_counts$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER trig_discussion_comment_reactions_update_thread_counts
+AFTER INSERT OR DELETE ON discussion_comment_reactions
+FOR EACH ROW EXECUTE PROCEDURE discussion_comment_reactions_update_thread_counts();
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS discussion_threads_title_id_idx;
+DROP INDEX IF EXISTS discussion_threads_created_at_id_idx;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Indexes for ordering threads (see DiscussionThreadsListColumn). The
+-- (updated_at, id) and (comment_count, id) indexes already exist.
+CREATE INDEX IF NOT EXISTS discussion_threads_created_at_id_idx ON discussion_threads(created_at, id);
+CREATE INDEX IF NOT EXISTS discussion_threads_title_id_idx ON discussion_threads(title, id);
+
+COMMIT;
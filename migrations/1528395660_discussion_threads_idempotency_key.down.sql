@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS discussion_threads_author_user_id_idempotency_key_idx;
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS idempotency_key;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- The key given by the client that created the thread, so that retried
+-- requests return the existing thread instead of creating a duplicate (see
+-- DiscussionThreadIdempotencyWindow).
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS idempotency_key text;
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_threads_author_user_id_idempotency_key_idx ON discussion_threads(author_user_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+
+COMMIT;
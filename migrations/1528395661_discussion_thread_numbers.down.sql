@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_discussion_threads_target_repo_set_number ON discussion_threads_target_repo;
+DROP FUNCTION IF EXISTS discussion_threads_target_repo_set_number();
+DROP TABLE IF EXISTS discussion_repo_thread_numbers;
+DROP INDEX IF EXISTS discussion_threads_target_repo_repo_id_number_idx;
+ALTER TABLE discussion_threads_target_repo DROP COLUMN IF EXISTS number;
+
+COMMIT;
@@ -0,0 +1,43 @@
+BEGIN;
+
+-- Threads are numbered per repository (like issue numbers), starting at 1.
+ALTER TABLE discussion_threads_target_repo ADD COLUMN IF NOT EXISTS number integer;
+UPDATE discussion_threads_target_repo tr SET number=n.number
+	FROM (SELECT id, row_number() OVER (PARTITION BY repo_id ORDER BY thread_id) AS number FROM discussion_threads_target_repo) n
+	WHERE n.id=tr.id AND tr.number IS NULL;
+
+-- The last thread number assigned in each repository. Numbers are not reused,
+-- even when threads are deleted or transferred to another repository.
+CREATE TABLE IF NOT EXISTS discussion_repo_thread_numbers (
+	repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+	last_number integer NOT NULL
+);
+INSERT INTO discussion_repo_thread_numbers(repo_id, last_number)
+	SELECT repo_id, max(number) FROM discussion_threads_target_repo GROUP BY repo_id
+	ON CONFLICT DO NOTHING;
+
+-- During a rolling deploy, frontends from before this migration still create
+-- threads without a number. Number them on insert, the same way the frontend
+-- does, so that number can be NOT NULL right away.
+CREATE OR REPLACE FUNCTION discussion_threads_target_repo_set_number() RETURNS TRIGGER AS
+$discussion_threads_target_repo_set_number$
+BEGIN
+  IF NEW.number IS NULL THEN
+    INSERT INTO discussion_repo_thread_numbers(repo_id, last_number) VALUES (NEW.repo_id, 1)
+      ON CONFLICT (repo_id) DO UPDATE SET last_number=discussion_repo_thread_numbers.last_number+1
+      RETURNING last_number INTO NEW.number;
+  END IF;
+  RETURN NEW;
+END;
+$discussion_threads_target_repo_set_number$
+LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trig_discussion_threads_target_repo_set_number ON discussion_threads_target_repo;
+CREATE TRIGGER trig_discussion_threads_target_repo_set_number
+BEFORE INSERT ON discussion_threads_target_repo
+FOR EACH ROW EXECUTE PROCEDURE discussion_threads_target_repo_set_number();
+
+ALTER TABLE discussion_threads_target_repo ALTER COLUMN number SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_threads_target_repo_repo_id_number_idx ON discussion_threads_target_repo(repo_id, number);
+
+COMMIT;
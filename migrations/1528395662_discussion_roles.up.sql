@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Roles that users have in the discussions of a repository or organization.
+-- A user has at most one role per repository or organization.
+CREATE TABLE IF NOT EXISTS discussion_roles (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    repo_id integer REFERENCES repo(id) ON DELETE CASCADE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE,
+    role text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT discussion_roles_has_one_scope CHECK ((repo_id IS NULL) <> (org_id IS NULL)),
+    CONSTRAINT discussion_roles_valid_role CHECK (role IN ('CONTRIBUTOR', 'TRIAGER', 'MAINTAINER'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_roles_repo_id_user_id_idx ON discussion_roles(repo_id, user_id) WHERE repo_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS discussion_roles_org_id_user_id_idx ON discussion_roles(org_id, user_id) WHERE org_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS discussion_roles_user_id_idx ON discussion_roles(user_id);
+
+COMMIT;
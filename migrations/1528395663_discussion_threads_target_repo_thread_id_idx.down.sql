@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS discussion_threads_target_repo_thread_id_idx;
@@ -0,0 +1,9 @@
+-- Threads are joined to their target repo by thread_id (e.g. when listing
+-- threads by repository, transferring threads and cascading thread deletes),
+-- which was not indexed.
+--
+-- The index is built concurrently so that writes to the table are not blocked
+-- on large instances, which requires that this is the only statement in the
+-- migration (it cannot run in a transaction block). If building it fails, the
+-- invalid index must be dropped before the migration is retried.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS discussion_threads_target_repo_thread_id_idx ON discussion_threads_target_repo(thread_id);
@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS discussion_threads_open_updated_at_id_idx;
@@ -0,0 +1,5 @@
+-- Thread lists default to the non-archived threads, most recently updated first
+-- (deleted threads are never listed). See
+-- 1528395663_discussion_threads_target_repo_thread_id_idx.up.sql for why the
+-- index is built concurrently in its own migration.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS discussion_threads_open_updated_at_id_idx ON discussion_threads(updated_at, id) WHERE deleted_at IS NULL AND archived_at IS NULL;
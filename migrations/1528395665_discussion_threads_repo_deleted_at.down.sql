@@ -0,0 +1,15 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_discussion_threads_set_repo_deleted_at ON discussion_threads;
+DROP FUNCTION IF EXISTS discussion_threads_set_repo_deleted_at();
+
+-- Previously, threads were deleted along with their target repository.
+DELETE FROM discussion_threads WHERE repo_deleted_at IS NOT NULL;
+
+ALTER TABLE discussion_threads DROP CONSTRAINT IF EXISTS discussion_threads_target_repo_id_fk;
+ALTER TABLE discussion_threads ADD CONSTRAINT discussion_threads_target_repo_id_fk FOREIGN KEY (target_repo_id) REFERENCES discussion_threads_target_repo(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS discussion_threads_repo_deleted_at_idx;
+ALTER TABLE discussion_threads DROP COLUMN IF EXISTS repo_deleted_at;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+-- When a thread's target repository is deleted, the thread is detached from it
+-- (instead of being deleted along with the target) and repo_deleted_at records
+-- when that happened. Such threads are archived or deleted by the frontend, as
+-- configured by the discussions.deletedRepositoryThreads site configuration.
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS repo_deleted_at timestamp with time zone;
+CREATE INDEX IF NOT EXISTS discussion_threads_repo_deleted_at_idx ON discussion_threads(repo_deleted_at) WHERE repo_deleted_at IS NOT NULL;
+
+ALTER TABLE discussion_threads DROP CONSTRAINT IF EXISTS discussion_threads_target_repo_id_fk;
+ALTER TABLE discussion_threads ADD CONSTRAINT discussion_threads_target_repo_id_fk FOREIGN KEY (target_repo_id) REFERENCES discussion_threads_target_repo(id) ON DELETE SET NULL;
+
+CREATE OR REPLACE FUNCTION discussion_threads_set_repo_deleted_at() RETURNS TRIGGER AS
+$discussion_threads_set_repo_deleted_at$
+BEGIN
+  IF (OLD.target_repo_id IS NOT NULL AND NEW.target_repo_id IS NULL) THEN
+    NEW.repo_deleted_at := now();
+  END IF;
+  RETURN NEW;
+END;
+$discussion_threads_set_repo_deleted_at$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trig_discussion_threads_set_repo_deleted_at ON discussion_threads;
+CREATE TRIGGER trig_discussion_threads_set_repo_deleted_at BEFORE UPDATE OF target_repo_id ON discussion_threads FOR EACH ROW EXECUTE PROCEDURE discussion_threads_set_repo_deleted_at();
+
+COMMIT;
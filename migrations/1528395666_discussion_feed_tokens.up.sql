@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_feed_tokens (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    value_sha256 bytea NOT NULL UNIQUE,
+    kind text NOT NULL,
+    repo_id integer REFERENCES repo(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    CONSTRAINT discussion_feed_tokens_kind_check CHECK (kind IN ('repository', 'assigned', 'mentioned')),
+    CONSTRAINT discussion_feed_tokens_repo_id_check CHECK ((kind = 'repository') = (repo_id IS NOT NULL))
+);
+CREATE INDEX IF NOT EXISTS discussion_feed_tokens_user_id_idx ON discussion_feed_tokens(user_id);
+
+COMMIT;
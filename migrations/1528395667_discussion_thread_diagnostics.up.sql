@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_thread_diagnostics (
+    id bigserial PRIMARY KEY,
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    source text NOT NULL,
+    path text NOT NULL,
+    start_line integer NOT NULL,
+    start_character integer NOT NULL,
+    end_line integer NOT NULL,
+    end_character integer NOT NULL,
+    message text NOT NULL,
+    severity text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT discussion_thread_diagnostics_severity_check CHECK (severity IN ('error', 'warning', 'info', 'hint'))
+);
+CREATE INDEX IF NOT EXISTS discussion_thread_diagnostics_thread_id_idx ON discussion_thread_diagnostics(thread_id);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS discussion_rules (
+    id bigserial PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    query text NOT NULL,
+    label_ids bigint[] NOT NULL DEFAULT '{}',
+    assignee_user_ids integer[] NOT NULL DEFAULT '{}',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS discussion_rules_repo_id_idx ON discussion_rules(repo_id);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- The numbers that threads had in the repositories they were transferred out
+-- of, so that links to a thread by its old number keep working.
+CREATE TABLE IF NOT EXISTS discussion_thread_previous_numbers (
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    number integer NOT NULL,
+    thread_id bigint NOT NULL REFERENCES discussion_threads(id) ON DELETE CASCADE,
+    PRIMARY KEY (repo_id, number)
+);
+CREATE INDEX IF NOT EXISTS discussion_thread_previous_numbers_thread_id_idx ON discussion_thread_previous_numbers(thread_id);
+
+COMMIT;
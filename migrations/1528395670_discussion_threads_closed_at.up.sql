@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Closed threads are resolved but, unlike archived threads, not read-only.
+ALTER TABLE discussion_threads ADD COLUMN IF NOT EXISTS closed_at timestamp with time zone;
+
+COMMIT;
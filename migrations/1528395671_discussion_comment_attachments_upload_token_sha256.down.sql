@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE discussion_comment_attachments DROP COLUMN IF EXISTS upload_token_sha256;
+ALTER TABLE discussion_comment_attachments ADD COLUMN IF NOT EXISTS upload_token text UNIQUE;
+
+COMMIT;
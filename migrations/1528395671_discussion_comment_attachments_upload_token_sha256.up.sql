@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Upload tokens are stored hashed, like access tokens. The tokens of pending
+-- uploads cannot be hashed here (they expire after an hour anyway), so those
+-- uploads must be retried.
+ALTER TABLE discussion_comment_attachments DROP COLUMN IF EXISTS upload_token;
+ALTER TABLE discussion_comment_attachments ADD COLUMN IF NOT EXISTS upload_token_sha256 bytea UNIQUE;
+
+COMMIT;
@@ -52,6 +52,90 @@
 // 1528395628_add_published_at_to_campaigns.up.sql (125B)
 // 1528395629_repo_external_always.down.sql (402B)
 // 1528395629_repo_external_always.up.sql (978B)
+// 1528395630_discussion_labels.down.sql (105B)
+// 1528395630_discussion_labels.up.sql (836B)
+// 1528395631_discussion_threads_assignees.down.sql (68B)
+// 1528395631_discussion_threads_assignees.up.sql (441B)
+// 1528395632_discussion_thread_events.down.sql (64B)
+// 1528395632_discussion_thread_events.up.sql (532B)
+// 1528395633_discussion_threads_updated_at_idx.down.sql (76B)
+// 1528395633_discussion_threads_updated_at_idx.up.sql (120B)
+// 1528395634_discussion_threads_fulltext_idx.down.sql (131B)
+// 1528395634_discussion_threads_fulltext_idx.up.sql (273B)
+// 1528395635_discussion_thread_subscriptions.down.sql (71B)
+// 1528395635_discussion_thread_subscriptions.up.sql (832B)
+// 1528395636_discussion_threads_locked_at.down.sql (81B)
+// 1528395636_discussion_threads_locked_at.up.sql (109B)
+// 1528395637_discussion_milestones.down.sql (128B)
+// 1528395637_discussion_milestones.up.sql (993B)
+// 1528395638_discussion_thread_templates.down.sql (67B)
+// 1528395638_discussion_thread_templates.up.sql (518B)
+// 1528395639_discussion_webhooks.down.sql (111B)
+// 1528395639_discussion_webhooks.up.sql (934B)
+// 1528395640_discussion_unsubscribe_tokens.down.sql (69B)
+// 1528395640_discussion_unsubscribe_tokens.up.sql (398B)
+// 1528395641_discussion_comment_reactions.down.sql (68B)
+// 1528395641_discussion_comment_reactions.up.sql (370B)
+// 1528395642_discussion_comment_mentions.down.sql (67B)
+// 1528395642_discussion_comment_mentions.up.sql (658B)
+// 1528395643_discussion_comment_edits.down.sql (64B)
+// 1528395643_discussion_comment_edits.up.sql (473B)
+// 1528395644_discussion_comments_parent.down.sql (90B)
+// 1528395644_discussion_comments_parent.up.sql (265B)
+// 1528395645_discussion_comment_suggestions.down.sql (70B)
+// 1528395645_discussion_comment_suggestions.up.sql (336B)
+// 1528395646_discussion_comments_resolved.down.sql (159B)
+// 1528395646_discussion_comments_resolved.up.sql (238B)
+// 1528395647_discussion_comment_attachments.down.sql (70B)
+// 1528395647_discussion_comment_attachments.up.sql (584B)
+// 1528395648_discussion_thread_references.down.sql (68B)
+// 1528395648_discussion_thread_references.up.sql (801B)
+// 1528395649_discussion_threads_stale_at.down.sql (80B)
+// 1528395649_discussion_threads_stale_at.up.sql (108B)
+// 1528395650_discussion_threads_duplicate_of.down.sql (94B)
+// 1528395650_discussion_threads_duplicate_of.up.sql (277B)
+// 1528395651_discussion_threads_pinned_at.down.sql (81B)
+// 1528395651_discussion_threads_pinned_at.up.sql (109B)
+// 1528395652_discussion_audit_log.down.sql (118B)
+// 1528395652_discussion_audit_log.up.sql (1.299kB)
+// 1528395653_discussion_thread_subscriptions_notification_level.down.sql (103B)
+// 1528395653_discussion_thread_subscriptions_notification_level.up.sql (201B)
+// 1528395654_discussion_moderation.down.sql (284B)
+// 1528395654_discussion_moderation.up.sql (449B)
+// 1528395655_discussion_thread_reads.down.sql (63B)
+// 1528395655_discussion_thread_reads.up.sql (428B)
+// 1528395656_discussion_saved_replies.down.sql (64B)
+// 1528395656_discussion_saved_replies.up.sql (472B)
+// 1528395657_discussion_thread_dependencies.down.sql (70B)
+// 1528395657_discussion_thread_dependencies.up.sql (609B)
+// 1528395658_discussion_threads_counts.down.sql (566B)
+// 1528395658_discussion_threads_counts.up.sql (3.334kB)
+// 1528395659_discussion_threads_order_indexes.down.sql (130B)
+// 1528395659_discussion_threads_order_indexes.up.sql (351B)
+// 1528395660_discussion_threads_idempotency_key.down.sql (163B)
+// 1528395660_discussion_threads_idempotency_key.up.sql (460B)
+// 1528395661_discussion_thread_numbers.down.sql (389B)
+// 1528395661_discussion_thread_numbers.up.sql (2.102kB)
+// 1528395662_discussion_roles.down.sql (56B)
+// 1528395662_discussion_roles.up.sql (1.058kB)
+// 1528395663_discussion_threads_target_repo_thread_id_idx.down.sql (80B)
+// 1528395663_discussion_threads_target_repo_thread_id_idx.up.sql (614B)
+// 1528395664_discussion_threads_open_updated_at_id_idx.down.sql (77B)
+// 1528395664_discussion_threads_open_updated_at_id_idx.up.sql (425B)
+// 1528395665_discussion_threads_repo_deleted_at.down.sql (717B)
+// 1528395665_discussion_threads_repo_deleted_at.up.sql (1.455kB)
+// 1528395666_discussion_feed_tokens.down.sql (62B)
+// 1528395666_discussion_feed_tokens.up.sql (752B)
+// 1528395667_discussion_thread_diagnostics.down.sql (69B)
+// 1528395667_discussion_thread_diagnostics.up.sql (732B)
+// 1528395668_discussion_rules.down.sql (56B)
+// 1528395668_discussion_rules.up.sql (527B)
+// 1528395669_discussion_thread_previous_numbers.down.sql (74B)
+// 1528395669_discussion_thread_previous_numbers.up.sql (567B)
+// 1528395670_discussion_threads_closed_at.down.sql (81B)
+// 1528395670_discussion_threads_closed_at.up.sql (185B)
+// 1528395671_discussion_comment_attachments_upload_token_sha256.down.sql (197B)
+// 1528395671_discussion_comment_attachments_upload_token_sha256.up.sql (382B)
 
 package migrations
 
@@ -1160,6 +1244,1686 @@ func _1528395629_repo_external_alwaysUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395630_discussion_labelsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x69\x00\x96\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x5f\x6c\x61\x62\x65\x6c\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x6c\x61\x62\x65\x6c\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x28\x6b\xf0\x0b\x69\x00\x00\x00")
+
+func _1528395630_discussion_labelsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395630_discussion_labelsDownSql,
+		"1528395630_discussion_labels.down.sql",
+	)
+}
+
+func _1528395630_discussion_labelsDownSql() (*asset, error) {
+	bytes, err := _1528395630_discussion_labelsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395630_discussion_labels.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x49, 0x6, 0x25, 0x35, 0x70, 0x16, 0xfb, 0xe8, 0xc6, 0xde, 0x7d, 0xc4, 0xec, 0x90, 0x43, 0xe0, 0xe3, 0xf5, 0x17, 0xa1, 0x39, 0x7f, 0xc0, 0xf9, 0xd3, 0x12, 0xe1, 0x39, 0xa4, 0xdd, 0xda, 0x13}}
+	return a, nil
+}
+
+var __1528395630_discussion_labelsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\xcf\xae\xb2\x30\x10\xc5\xf7\x3c\xc5\x2c\x21\xf1\x0d\x58\x21\x8c\x5f\xc8\x87\x78\x2f\x7f\x12\x5d\x35\x95\x36\x3a\x09\xb6\xa4\xad\xd1\xdc\xa7\xbf\x01\x94\x68\x34\x6a\xee\x92\x99\x73\xce\xc0\xef\x30\xc7\x7f\x69\x1e\x7a\x5e\x5c\x60\x54\x21\x54\xd1\x3c\x43\x48\x17\x90\xaf\x2a\xc0\x75\x5a\x56\x25\x08\xb2\xcd\xd1\x5a\xd2\x8a\xb5\x7c\x2b\x5b\x0b\xbe\x07\x00\x40\x02\xb6\xb4\xb3\xd2\x10\x6f\xe1\xab\x48\x97\x51\xb1\x81\xff\xb8\x99\x0d\x5b\x23\x3b\xcd\x48\x00\x29\x27\x77\xd2\x0c\x81\x79\x9d\x65\x50\xe0\x02\x0b\xcc\x63\x2c\x07\x8d\x4f\x22\x80\x55\x0e\x09\x66\x58\x21\xc4\x51\x19\x47\x09\x8e\x19\x8a\x1f\x24\x38\x79\x76\x93\x7b\x9c\x0b\x69\x1b\x43\x9d\x23\xad\x86\xf5\x38\x6d\x74\xab\xcd\x33\x79\x63\x24\x77\x52\x30\xee\xc0\xd1\x41\x5a\xc7\x0f\x1d\x9c\xc8\xed\x87\x47\xf8\xd1\x4a\x4e\x0e\x48\x70\x11\xd5\x59\x05\x4a\x9f\xfc\x60\xf4\x1f\x3b\xf1\x47\xbf\x17\x84\x57\xb2\x75\x9e\x7e\xd7\x08\x69\x9e\xe0\xfa\x1d\x60\x76\x81\xc7\x7a\x00\x8c\xc4\xb9\x07\xf4\xa0\xf2\x2f\xaa\x19\xf4\xb2\xe0\xe3\x12\xdd\xde\x48\x2e\xec\x7d\x99\xe3\xb0\x2f\x6c\x4b\x3b\x52\xee\x69\x5f\x8f\x21\xaf\xda\x1b\xf2\x3f\x4f\xbc\x7c\xd4\x8b\xc0\x9b\x7f\x0c\xfc\xe9\x85\x67\xd3\xa1\x5b\xdc\x6f\x38\xdf\x33\x60\xd7\x84\x27\xac\xef\x95\xfe\x74\x2b\xf4\xbc\x78\xb5\x5c\xa6\x55\xe8\xfd\x0e\x00\x43\xff\x09\x64\x44\x03\x00\x00")
+
+func _1528395630_discussion_labelsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395630_discussion_labelsUpSql,
+		"1528395630_discussion_labels.up.sql",
+	)
+}
+
+func _1528395630_discussion_labelsUpSql() (*asset, error) {
+	bytes, err := _1528395630_discussion_labelsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395630_discussion_labels.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3b, 0x2c, 0x53, 0xe4, 0x94, 0x71, 0xe4, 0x6b, 0xe0, 0xfd, 0x50, 0xdf, 0xc8, 0x15, 0xb0, 0xec, 0x17, 0x1d, 0x93, 0x4, 0x45, 0x3b, 0x8, 0x5b, 0x77, 0x4d, 0x93, 0x14, 0xa, 0x2f, 0xe1, 0x7f}}
+	return a, nil
+}
+
+var __1528395631_discussion_threads_assigneesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x44\x00\xbb\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x5f\x61\x73\x73\x69\x67\x6e\x65\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xdc\x2c\x0c\xb5\x44\x00\x00\x00")
+
+func _1528395631_discussion_threads_assigneesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395631_discussion_threads_assigneesDownSql,
+		"1528395631_discussion_threads_assignees.down.sql",
+	)
+}
+
+func _1528395631_discussion_threads_assigneesDownSql() (*asset, error) {
+	bytes, err := _1528395631_discussion_threads_assigneesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395631_discussion_threads_assignees.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8b, 0x99, 0xf4, 0x9c, 0x3e, 0xdc, 0xfb, 0xbd, 0xc1, 0xcf, 0x13, 0xab, 0x3b, 0x8a, 0x54, 0x82, 0x9f, 0xfe, 0xcf, 0xd7, 0x76, 0x23, 0x68, 0xab, 0x8b, 0x47, 0xc7, 0xba, 0x6b, 0xf3, 0x2, 0xc2}}
+	return a, nil
+}
+
+var __1528395631_discussion_threads_assigneesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xd0\xb1\x6a\xc3\x30\x10\x06\xe0\x5d\x4f\xf1\x8f\x36\xe4\x0d\x3c\x29\xf6\xa5\x88\x3a\x4a\xb1\x15\x48\x26\xe1\x46\xc2\xb9\x21\x72\xb1\x14\x52\xfa\xf4\xc5\x4e\xdd\x2e\x21\xd0\x51\xe2\xf4\xe9\xbf\x7f\x4d\x2f\x4a\x17\x42\x94\x0d\x49\x43\x30\x72\x5d\x13\xd4\x06\x7a\x67\x40\x07\xd5\x9a\x16\x8e\xe3\xe9\x1a\x23\x0f\xc1\xa6\xf3\xe8\x3b\x17\x6d\x17\x23\xf7\xc1\xfb\x88\x4c\x00\xc0\xfd\xde\xb2\xc3\x3b\xf7\x1c\xd2\xfc\x5c\xef\xeb\x1a\x0d\x6d\xa8\x21\x5d\xd2\x23\x27\x63\x97\x63\xa7\x51\x51\x4d\x86\x50\xca\xb6\x94\x15\xad\x66\xf2\x1a\xfd\x38\x81\x1c\x92\xef\xfd\xf8\x50\x9c\x66\x9e\x22\xa7\xd1\x77\xc9\x3b\xdb\x25\x24\xbe\xf8\x98\xba\xcb\x07\x6e\x9c\xce\xf3\x11\x5f\x43\xf0\x7f\x70\x45\x1b\xb9\xaf\x0d\xc2\x70\xcb\xf2\x7b\x88\xb7\x46\x6d\x65\x73\xc4\x2b\x1d\x91\xfd\x2e\xb9\x5a\xc2\xe5\x22\x2f\x96\xe6\x94\xae\xe8\xf0\x8f\xe6\xec\x8f\x61\xd9\x7d\x4e\x1d\x3c\x9b\xcd\x96\xff\x0a\x21\xca\xdd\x76\xab\x4c\x21\xbe\x07\x00\x47\x15\x75\x20\xb9\x01\x00\x00")
+
+func _1528395631_discussion_threads_assigneesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395631_discussion_threads_assigneesUpSql,
+		"1528395631_discussion_threads_assignees.up.sql",
+	)
+}
+
+func _1528395631_discussion_threads_assigneesUpSql() (*asset, error) {
+	bytes, err := _1528395631_discussion_threads_assigneesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395631_discussion_threads_assignees.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x47, 0x21, 0x31, 0xee, 0xc, 0xcb, 0x2d, 0xcb, 0x6a, 0x5e, 0xfb, 0xe6, 0xec, 0x92, 0x10, 0xa9, 0xd5, 0xb7, 0xd1, 0x1a, 0xd7, 0x3e, 0x21, 0x37, 0xf3, 0x46, 0xec, 0xbf, 0x2e, 0x6b, 0x6a, 0x30}}
+	return a, nil
+}
+
+var __1528395632_discussion_thread_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x40\x00\xbf\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x65\x76\x65\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x51\xe2\xe2\x83\x40\x00\x00\x00")
+
+func _1528395632_discussion_thread_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395632_discussion_thread_eventsDownSql,
+		"1528395632_discussion_thread_events.down.sql",
+	)
+}
+
+func _1528395632_discussion_thread_eventsDownSql() (*asset, error) {
+	bytes, err := _1528395632_discussion_thread_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395632_discussion_thread_events.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa, 0x49, 0x38, 0x44, 0xf4, 0x2a, 0x17, 0xaa, 0x4, 0xbd, 0xb8, 0x3a, 0xdc, 0x9f, 0x5e, 0x2e, 0x7e, 0x7f, 0x95, 0x42, 0x76, 0x49, 0x40, 0xaf, 0x6d, 0xe5, 0x68, 0x96, 0x1b, 0xcf, 0x14, 0xf6}}
+	return a, nil
+}
+
+var __1528395632_discussion_thread_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xc1\x6e\xea\x30\x10\x45\xf7\xfe\x8a\xd9\x91\x48\x7c\x01\x59\x85\x64\x78\x8a\x5e\x08\x55\x30\x12\xac\x2c\x13\x5b\x30\x6d\x71\x2a\x7b\x28\xa8\x55\xff\xbd\x22\x29\x4d\xa5\xd2\xaa\xcb\x91\xcf\x3d\x77\xe4\x99\xe2\xbf\xa2\x4a\x84\xc8\x6a\x4c\x25\x82\x4c\xa7\x25\x42\x31\x83\x6a\x21\x01\xd7\xc5\x52\x2e\xc1\x50\x68\x8e\x21\x50\xeb\x14\xef\xbd\xd5\x46\xd9\x67\xeb\x38\x40\x24\x00\x00\xc8\xc0\x96\x76\xc1\x7a\xd2\x8f\x5d\xac\x5a\x95\x25\xdc\xd5\xc5\x3c\xad\x37\xf0\x1f\x37\xe3\x0e\xfb\x88\xf6\x34\x39\x1e\xd0\x1a\x67\x58\x63\x95\xe1\x8d\xaa\x10\x91\x89\x61\x51\x41\x8e\x25\x4a\x84\x2c\x5d\x66\x69\x8e\xbd\x52\x37\xdc\x7a\x75\x0c\xd6\x2b\x32\x40\x8e\xed\xce\xfa\x9b\xde\x0b\xf3\xab\xea\x81\x9c\x01\xb6\xe7\x61\xad\xbe\xc2\x68\xd6\x70\x1f\x5a\xb7\x1d\xbc\x39\xce\xd2\x55\x29\x61\xf4\xfa\x36\x9a\x4c\xba\xc7\x1e\x6e\xbc\xd5\x6c\x8d\xd2\x0c\x4c\x07\x1b\x58\x1f\x9e\xe0\x44\xbc\xef\x46\x78\x69\x9d\xfd\x6e\x71\xed\x29\x8a\x45\x9c\x5c\x2f\x50\x54\x39\xae\xff\x78\x81\xeb\x44\x46\x0d\xdd\x8a\xcc\xf9\xf2\x63\x3f\x85\xa2\xcf\xd0\xf8\xcb\xc6\x71\x22\x44\xb6\x98\xcf\x0b\x99\x88\xf7\x01\x00\x8b\x05\x1c\x3c\x14\x02\x00\x00")
+
+func _1528395632_discussion_thread_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395632_discussion_thread_eventsUpSql,
+		"1528395632_discussion_thread_events.up.sql",
+	)
+}
+
+func _1528395632_discussion_thread_eventsUpSql() (*asset, error) {
+	bytes, err := _1528395632_discussion_thread_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395632_discussion_thread_events.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x63, 0x77, 0x9f, 0x10, 0x90, 0x5a, 0x66, 0x4d, 0x7, 0x4a, 0x8d, 0x6, 0x34, 0x6, 0x92, 0x34, 0xd3, 0xd0, 0x5a, 0xab, 0xdd, 0x6d, 0xde, 0x7e, 0xbf, 0xd0, 0x49, 0x56, 0x24, 0xf2, 0x2b, 0xe3}}
+	return a, nil
+}
+
+var __1528395633_discussion_threads_updated_at_idxDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4c\x00\xb3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x5f\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x5f\x69\x64\x5f\x69\x64\x78\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xcd\x29\x7c\x84\x4c\x00\x00\x00")
+
+func _1528395633_discussion_threads_updated_at_idxDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395633_discussion_threads_updated_at_idxDownSql,
+		"1528395633_discussion_threads_updated_at_idx.down.sql",
+	)
+}
+
+func _1528395633_discussion_threads_updated_at_idxDownSql() (*asset, error) {
+	bytes, err := _1528395633_discussion_threads_updated_at_idxDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395633_discussion_threads_updated_at_idx.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7e, 0xad, 0xdf, 0xcd, 0x3f, 0x88, 0x32, 0x5, 0x11, 0xa6, 0x31, 0xbf, 0x37, 0x6a, 0x63, 0xd8, 0x83, 0xac, 0x41, 0xf6, 0xc2, 0xab, 0x7f, 0x72, 0xf6, 0x2f, 0xea, 0x5b, 0xd9, 0x2f, 0x87, 0x5e}}
+	return a, nil
+}
+
+var __1528395633_discussion_threads_updated_at_idxUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x78\x00\x87\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x43\x52\x45\x41\x54\x45\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x5f\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x5f\x69\x64\x5f\x69\x64\x78\x20\x4f\x4e\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x28\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x2c\x20\x69\x64\x29\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x74\x4e\x66\xd8\x78\x00\x00\x00")
+
+func _1528395633_discussion_threads_updated_at_idxUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395633_discussion_threads_updated_at_idxUpSql,
+		"1528395633_discussion_threads_updated_at_idx.up.sql",
+	)
+}
+
+func _1528395633_discussion_threads_updated_at_idxUpSql() (*asset, error) {
+	bytes, err := _1528395633_discussion_threads_updated_at_idxUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395633_discussion_threads_updated_at_idx.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9a, 0x5d, 0xa8, 0x6c, 0x8a, 0xc7, 0xfc, 0x6, 0x2a, 0xc4, 0xf5, 0x9, 0x8d, 0xa0, 0x35, 0x55, 0x1a, 0xff, 0x64, 0xc7, 0xeb, 0xf1, 0x5e, 0xaf, 0x5d, 0x37, 0x40, 0xd3, 0x1e, 0xf9, 0x6b, 0xa9}}
+	return a, nil
+}
+
+var __1528395634_discussion_threads_fulltext_idxDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xc9\x2c\x4e\x2e\x2d\x2e\xce\xcc\xcf\x8b\x2f\xc9\x28\x4a\x4d\x4c\x29\x8e\x2f\xc9\x2c\xc9\x49\x8d\x4f\x2b\x29\x8e\xcf\x4c\xa9\xb0\x26\xa8\x2d\x39\x3f\x37\x37\x35\xaf\xa4\x38\x3e\x39\x3f\xaf\x04\xcc\x80\x6b\xe5\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x0c\x00\xb6\xe0\x84\xb6\x83\x00\x00\x00")
+
+func _1528395634_discussion_threads_fulltext_idxDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395634_discussion_threads_fulltext_idxDownSql,
+		"1528395634_discussion_threads_fulltext_idx.down.sql",
+	)
+}
+
+func _1528395634_discussion_threads_fulltext_idxDownSql() (*asset, error) {
+	bytes, err := _1528395634_discussion_threads_fulltext_idxDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395634_discussion_threads_fulltext_idx.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4, 0x3b, 0xfe, 0x3e, 0x3b, 0x94, 0xa9, 0xea, 0x62, 0xd4, 0x43, 0x2, 0x7, 0xd8, 0x5c, 0x14, 0xf1, 0xb6, 0xea, 0xb6, 0x22, 0x41, 0xcf, 0x6e, 0xbc, 0xe4, 0x92, 0x7c, 0x27, 0x81, 0x78, 0x3a}}
+	return a, nil
+}
+
+var __1528395634_discussion_threads_fulltext_idxUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8e\xb1\x0a\x83\x30\x14\x45\xf7\x7c\xc5\xdb\x54\xe8\x1f\x38\xb5\x36\x95\x0c\x46\xa8\x29\xb8\x85\x92\xa4\xfa\x40\x13\xf0\xbd\x96\x7e\x7e\xa9\xe0\x26\xb4\xdb\x1d\x2e\xe7\x9c\x93\xac\x95\x2e\x85\xa8\xae\xf2\x68\x24\x28\x7d\x96\x3d\xa8\x0b\xe8\xd6\x80\xec\x55\x67\x3a\xf0\x48\xee\x49\x84\x29\x5a\x1e\x97\x70\xf7\x64\x19\x79\x0a\xf6\xc1\x64\xd1\xbf\xa1\xd5\x3b\x1f\xb8\x75\x4a\xd7\x30\x60\xcc\x39\x59\xa6\x57\x70\x9c\x96\x3c\x0b\x71\x98\x90\xc6\xec\x00\x2b\xa4\x28\xca\x3f\xdd\x2e\xcd\x73\x88\x4c\xd6\xa5\xc8\xeb\xd8\xf7\x6f\xbf\xdf\x01\x1b\xe8\xdb\x20\xaa\xb6\x69\x94\x29\xc5\x67\x00\x85\x4e\xd5\x7f\x11\x01\x00\x00")
+
+func _1528395634_discussion_threads_fulltext_idxUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395634_discussion_threads_fulltext_idxUpSql,
+		"1528395634_discussion_threads_fulltext_idx.up.sql",
+	)
+}
+
+func _1528395634_discussion_threads_fulltext_idxUpSql() (*asset, error) {
+	bytes, err := _1528395634_discussion_threads_fulltext_idxUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395634_discussion_threads_fulltext_idx.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdc, 0xb6, 0xd, 0xf, 0x30, 0xde, 0xda, 0xa5, 0x47, 0x43, 0x84, 0x2a, 0x7f, 0xd3, 0xc4, 0xaf, 0x95, 0x42, 0xf1, 0x8e, 0x5b, 0x35, 0x26, 0x27, 0x1a, 0x78, 0x66, 0x3d, 0x54, 0x41, 0x5f, 0x26}}
+	return a, nil
+}
+
+var __1528395635_discussion_thread_subscriptionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x47\x00\xb8\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x73\x75\x62\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x74\x9c\x22\x10\x47\x00\x00\x00")
+
+func _1528395635_discussion_thread_subscriptionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395635_discussion_thread_subscriptionsDownSql,
+		"1528395635_discussion_thread_subscriptions.down.sql",
+	)
+}
+
+func _1528395635_discussion_thread_subscriptionsDownSql() (*asset, error) {
+	bytes, err := _1528395635_discussion_thread_subscriptionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395635_discussion_thread_subscriptions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3b, 0x3c, 0x5, 0xcd, 0x5c, 0x2a, 0xc7, 0x2f, 0xeb, 0xd3, 0x86, 0x4e, 0x9c, 0x5, 0xb7, 0x76, 0xff, 0x15, 0x91, 0x54, 0x3c, 0x3e, 0xb9, 0x4f, 0x1e, 0xe9, 0xca, 0x4e, 0xfb, 0x72, 0x47, 0x92}}
+	return a, nil
+}
+
+var __1528395635_discussion_thread_subscriptionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\x51\x6f\x9b\x30\x14\x85\xdf\xfd\x2b\xce\x23\x91\xd2\xfd\x01\x9e\x28\x5c\x5a\x6b\xc4\x54\xe0\x6a\xed\x13\x22\xd8\x6a\x2c\x01\x8e\x6c\xd3\x2c\xfb\xf5\x13\x8c\xac\x93\x16\x2d\x9a\xfa\x88\xb8\xf7\x3b\xc7\xe7\xdc\x7b\x7a\xe0\x22\x66\x2c\xad\x28\x91\x04\x99\xdc\x17\x04\x9e\x43\x94\x12\xf4\xc2\x6b\x59\x43\x19\xdf\x4d\xde\x1b\x3b\x36\xe1\xe0\x74\xab\x1a\x3f\xed\x7d\xe7\xcc\x31\x18\x3b\x7a\x44\x0c\x00\xd6\x5f\x46\x61\x6f\xde\xcc\x18\x16\x82\x78\x2e\x0a\x54\x94\x53\x45\x22\xa5\x2b\x28\x1f\x19\xb5\x41\x29\x90\x51\x41\x92\x90\x26\x75\x9a\x64\xb4\x5d\x90\x93\xd7\xae\x31\x0a\x66\x0c\xfa\x4d\xbb\xab\xc4\x79\xe6\x9f\x90\xd5\xeb\x5e\x2b\xec\xad\xed\x75\x3b\x7e\x70\x32\xca\x93\xe7\x42\x22\xb8\x49\xff\x92\xec\x9c\x6e\x83\x56\x4d\x1b\x10\xcc\xa0\x7d\x68\x87\x23\x4e\x26\x1c\x96\x4f\xfc\xb0\xa3\xfe\x7b\x7d\xb4\xa7\x68\xb3\x5a\x3e\xaa\x4f\xed\x3f\x55\x7c\x97\x54\xaf\xf8\x4a\xaf\x88\x7e\x47\xba\xbd\x44\xb1\x61\x9b\xf8\x52\x15\x17\x19\xbd\xfc\x5f\x55\xcd\x8a\x69\x8c\xfa\x3e\x87\x7e\x63\x3c\xba\xa8\xc6\x8c\xdd\xdd\xe1\xc9\xe9\x77\x63\x27\xdf\x9f\xb7\xd0\xef\xda\x9d\xe7\x30\x4e\x07\x8b\xce\x0e\x83\x1e\x83\x56\xb0\x23\xda\xf5\x12\x70\x6a\x3d\xcc\x70\xec\x4d\x67\x42\x7f\xc6\x4a\x9e\x7b\x08\x16\x26\x7c\x61\x5c\xd4\x54\x49\x70\x21\xcb\x9b\x4e\xae\x45\x31\xe7\x5d\x53\x41\xa9\x44\xc6\x6b\xc9\x45\x2a\x3f\xae\x70\x8b\x76\x0a\x07\xeb\x2e\x4f\x46\x5e\x95\xbb\x3f\x65\x56\xd3\x1e\xdf\x1e\xa9\x22\x28\xdd\xeb\xb5\x38\x5e\x2f\xf5\x2c\x7d\x94\x02\x69\x29\xf2\x82\xcf\x22\xe5\x5c\xdd\x23\x17\x0f\x31\x63\x69\xb9\xdb\x71\x19\xb3\x9f\x03\x00\x8b\x87\xa0\x40\x40\x03\x00\x00")
+
+func _1528395635_discussion_thread_subscriptionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395635_discussion_thread_subscriptionsUpSql,
+		"1528395635_discussion_thread_subscriptions.up.sql",
+	)
+}
+
+func _1528395635_discussion_thread_subscriptionsUpSql() (*asset, error) {
+	bytes, err := _1528395635_discussion_thread_subscriptionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395635_discussion_thread_subscriptions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x10, 0x1e, 0xe8, 0xef, 0x25, 0x64, 0x58, 0xfb, 0xe0, 0x9a, 0xd8, 0x61, 0x7d, 0x99, 0x5f, 0xa, 0x6, 0x25, 0x84, 0x59, 0xa6, 0x4b, 0xdb, 0xbf, 0x3a, 0xe6, 0xde, 0x4c, 0x7c, 0x49, 0xc6, 0x4}}
+	return a, nil
+}
+
+var __1528395636_discussion_threads_locked_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x51\x00\xae\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x6f\x63\x6b\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x05\xbd\x83\x05\x51\x00\x00\x00")
+
+func _1528395636_discussion_threads_locked_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395636_discussion_threads_locked_atDownSql,
+		"1528395636_discussion_threads_locked_at.down.sql",
+	)
+}
+
+func _1528395636_discussion_threads_locked_atDownSql() (*asset, error) {
+	bytes, err := _1528395636_discussion_threads_locked_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395636_discussion_threads_locked_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x30, 0x61, 0x66, 0xbe, 0x8f, 0x61, 0x7d, 0x2, 0x39, 0x74, 0xba, 0xac, 0x4d, 0xf4, 0xd2, 0xf0, 0xd3, 0xa6, 0x8e, 0xc6, 0x45, 0x2b, 0x0, 0x2, 0x56, 0xa, 0x90, 0x70, 0xa3, 0x3f, 0x7a, 0xe1}}
+	return a, nil
+}
+
+var __1528395636_discussion_threads_locked_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6d\x00\x92\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x6f\x63\x6b\x65\x64\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x77\x69\x74\x68\x20\x74\x69\x6d\x65\x20\x7a\x6f\x6e\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x22\x90\x56\x2c\x6d\x00\x00\x00")
+
+func _1528395636_discussion_threads_locked_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395636_discussion_threads_locked_atUpSql,
+		"1528395636_discussion_threads_locked_at.up.sql",
+	)
+}
+
+func _1528395636_discussion_threads_locked_atUpSql() (*asset, error) {
+	bytes, err := _1528395636_discussion_threads_locked_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395636_discussion_threads_locked_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3a, 0x35, 0x4a, 0x23, 0x44, 0x41, 0xb9, 0xbc, 0xf0, 0x74, 0x60, 0x4c, 0x88, 0x37, 0x52, 0xd1, 0x46, 0x74, 0x57, 0x24, 0xbf, 0xdf, 0xe6, 0x84, 0xf7, 0x99, 0x36, 0xfc, 0x6f, 0xbc, 0xef, 0xd4}}
+	return a, nil
+}
+
+var __1528395637_discussion_milestonesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xc9\x2c\x4e\x2e\x2d\x2e\xce\xcc\xcf\x8b\x2f\xc9\x28\x4a\x4d\x4c\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\xcd\xcc\x49\x2d\x2e\xc9\xcf\x4b\x8d\xcf\x4c\xb1\xe6\x02\xab\x81\x98\x80\x50\x82\x64\x16\x5c\x75\xb1\x35\x17\x97\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x60\x00\x71\x2a\x52\x8c\x80\x00\x00\x00")
+
+func _1528395637_discussion_milestonesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395637_discussion_milestonesDownSql,
+		"1528395637_discussion_milestones.down.sql",
+	)
+}
+
+func _1528395637_discussion_milestonesDownSql() (*asset, error) {
+	bytes, err := _1528395637_discussion_milestonesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395637_discussion_milestones.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd9, 0xe8, 0x83, 0xe0, 0xc0, 0xf2, 0xd1, 0xeb, 0x7e, 0x8b, 0x44, 0xf0, 0x2d, 0x7, 0xea, 0x6d, 0x12, 0x92, 0xf0, 0xc1, 0x43, 0x47, 0x71, 0xe, 0x3a, 0x6b, 0x69, 0xc5, 0x3b, 0x25, 0xd9, 0x62}}
+	return a, nil
+}
+
+var __1528395637_discussion_milestonesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\xc1\x8a\xdb\x30\x10\x86\xef\x7e\x8a\x39\xda\xd0\x37\x48\x29\x68\xe5\x49\x6b\xd6\x91\x8b\xad\xc0\xee\x49\x78\xa3\xc1\x19\x48\xa4\x20\x29\x64\xe9\xd3\x97\xd8\xce\x36\x2d\x69\xba\x74\x8f\xd6\x7c\xf3\xcf\x8c\xff\xff\x01\xbf\x56\x6a\x91\x65\xb2\x45\xa1\x11\xb4\x78\xa8\x11\xaa\x25\xa8\x46\x03\x3e\x55\x9d\xee\xc0\x72\xdc\x1c\x63\x64\xef\xcc\x9e\x77\x14\x93\x77\x14\x21\xcf\x00\x00\xd8\xc2\x0b\x0f\x91\x02\xf7\x3b\xf8\xde\x56\x2b\xd1\x3e\xc3\x23\x3e\x7f\x1a\xab\x81\x0e\xde\xb0\x05\x76\x89\x06\x0a\xd0\xe2\x12\x5b\x54\x12\xbb\xb1\x94\xb3\x2d\xa0\x51\x50\x62\x8d\x1a\x41\x8a\x4e\x8a\x12\xa7\x56\x1f\x86\xbf\x74\xfa\x30\xc4\x7b\x9d\x89\xd3\x8e\x20\xd1\x6b\x1a\x8f\x50\xeb\xba\x9e\x0a\x96\xe2\x26\xf0\x21\xb1\x77\x63\x79\x7e\x3d\x92\xb1\x7d\x22\x48\xbc\xa7\x98\xfa\xfd\x01\x4e\x9c\xb6\xe3\x27\xfc\xf0\x8e\x26\x6c\xb3\xf3\x91\xac\xe9\xd3\xbf\xb8\x40\x7d\xba\x0f\xbe\xad\x05\x25\x2e\xc5\xba\xd6\xe0\xfc\x29\x2f\xa6\x39\xc7\x83\xfd\x50\xbf\x6c\x54\xa7\x5b\x51\x29\x7d\xdb\x37\xb3\xed\xa3\xf1\x8e\x8c\x3f\x39\x0a\x20\xbf\xa1\x7c\x84\x3c\xbf\x38\x55\x75\xe3\x66\x05\x7c\xfe\x02\xf9\xec\xc1\xe5\xad\xc8\x8a\xc5\x25\x27\x95\x2a\xf1\xe9\x3d\x39\x31\xb3\xb2\x61\xfb\x7a\x76\xec\x26\x74\x19\xff\x3f\xfa\xd3\x92\xf7\xe5\x27\xa6\x58\x64\x99\xa8\x35\xb6\x73\xca\xaf\xd8\xb4\x0d\xd4\xdb\x08\xa2\x2c\x41\x36\xf5\x7a\xa5\xfe\x98\xfd\x26\x76\xfe\x49\x2f\x3c\xb0\x4b\xd7\x99\xbc\x3d\xf6\xf7\x90\x76\x38\xb9\xf6\xde\x1b\xe7\x9d\x7e\x09\xde\x3e\x73\xc6\xf2\x6b\xec\x7c\xa9\x6c\x56\xab\x4a\x2f\xb2\x9f\x03\x00\x44\x81\x07\xb7\xe1\x03\x00\x00")
+
+func _1528395637_discussion_milestonesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395637_discussion_milestonesUpSql,
+		"1528395637_discussion_milestones.up.sql",
+	)
+}
+
+func _1528395637_discussion_milestonesUpSql() (*asset, error) {
+	bytes, err := _1528395637_discussion_milestonesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395637_discussion_milestones.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x67, 0x85, 0xa1, 0xab, 0xc0, 0x45, 0x84, 0xb3, 0x99, 0x95, 0xe9, 0x42, 0xac, 0x43, 0x1c, 0x2c, 0xa, 0x9a, 0x44, 0xb5, 0x74, 0xd2, 0xfe, 0xa7, 0x7e, 0x51, 0xec, 0xd2, 0x15, 0x54, 0xa7, 0xa0}}
+	return a, nil
+}
+
+var __1528395638_discussion_thread_templatesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x43\x00\xbc\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x74\x65\x6d\x70\x6c\x61\x74\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x3c\x12\x9c\xa6\x43\x00\x00\x00")
+
+func _1528395638_discussion_thread_templatesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395638_discussion_thread_templatesDownSql,
+		"1528395638_discussion_thread_templates.down.sql",
+	)
+}
+
+func _1528395638_discussion_thread_templatesDownSql() (*asset, error) {
+	bytes, err := _1528395638_discussion_thread_templatesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395638_discussion_thread_templates.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x96, 0x77, 0x62, 0x43, 0xd8, 0xb, 0x16, 0xa8, 0x81, 0xf6, 0x91, 0xb8, 0x15, 0x8e, 0x29, 0xa6, 0x57, 0x96, 0xe1, 0x42, 0x53, 0xf8, 0x0, 0xd0, 0x3f, 0x3f, 0x37, 0xe5, 0x64, 0x16, 0x6, 0x55}}
+	return a, nil
+}
+
+var __1528395638_discussion_thread_templatesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\x41\x6b\xb3\x40\x10\x86\xef\xfe\x8a\x39\x2a\xe4\x1f\x78\x32\x3a\xf9\x90\xcf\x6c\x5a\x5d\x21\x39\x2d\x5b\x77\x48\x06\xe2\x2a\xee\x84\x84\xfe\xfa\x12\x4d\x43\x0f\xa5\x94\x1e\x67\xde\xf7\x79\x0f\xcf\x1a\xff\x95\x2a\x8d\xa2\xbc\xc6\x4c\x23\xe8\x6c\x5d\x21\x94\x1b\x50\x3b\x0d\xb8\x2f\x1b\xdd\x80\xe3\xd0\x5d\x42\xe0\xc1\x1b\x39\x4d\x64\x9d\x11\xea\xc7\xb3\x15\x0a\x10\x47\x00\x00\xec\xe0\x8d\x8f\x81\x26\xb6\x67\x78\xa9\xcb\x6d\x56\x1f\xe0\x3f\x1e\x56\x73\x3a\xd1\x38\x18\x76\xc0\x5e\xe8\x48\xd3\x3c\xad\xda\xaa\x82\x1a\x37\x58\xa3\xca\xb1\x99\x3b\x31\xbb\x04\x76\x0a\x0a\xac\x50\x23\xe4\x59\x93\x67\x05\x2e\x1b\xde\xf6\x04\x42\x37\x79\xd2\xcb\xdf\x51\xe8\x26\x1e\x85\x07\x3f\xc7\xcb\x57\x58\xce\xf4\xe5\xee\x06\x2f\xe4\x25\x7c\xb7\xd0\x4d\x64\x85\x9c\xb1\x02\xc2\x3d\x05\xb1\xfd\x08\x57\x96\xd3\x7c\xc2\xfb\xe0\xe9\x49\x40\x81\x9b\xac\xad\x34\xf8\xe1\x1a\x27\x0b\x7f\x19\xdd\x1f\xf9\x28\x49\x3f\xb5\xb7\xaa\x7c\x6d\x11\x4a\x55\xe0\xfe\xf7\xf6\xcd\xc3\xac\xb9\xdb\x31\xec\x6e\x77\x7b\x3f\xf4\xe3\x47\x7f\x05\x77\x20\x49\xa3\x28\xdf\x6d\xb7\xa5\x4e\xa3\x8f\x01\x00\xc6\x00\xdb\x1e\x06\x02\x00\x00")
+
+func _1528395638_discussion_thread_templatesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395638_discussion_thread_templatesUpSql,
+		"1528395638_discussion_thread_templates.up.sql",
+	)
+}
+
+func _1528395638_discussion_thread_templatesUpSql() (*asset, error) {
+	bytes, err := _1528395638_discussion_thread_templatesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395638_discussion_thread_templates.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0x37, 0xda, 0x57, 0x65, 0x5a, 0x4e, 0x2f, 0xc8, 0x70, 0xc5, 0xb3, 0xcd, 0xcb, 0x32, 0xb6, 0xf0, 0xe3, 0x90, 0x67, 0x4f, 0x98, 0xf, 0xf1, 0x1, 0xfe, 0xfa, 0xa7, 0x68, 0xce, 0x9c, 0xff}}
+	return a, nil
+}
+
+var __1528395639_discussion_webhooksDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6f\x00\x90\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x64\x65\x6c\x69\x76\x65\x72\x69\x65\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x77\x65\x62\x68\x6f\x6f\x6b\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x95\x8b\x6e\x58\x6f\x00\x00\x00")
+
+func _1528395639_discussion_webhooksDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395639_discussion_webhooksDownSql,
+		"1528395639_discussion_webhooks.down.sql",
+	)
+}
+
+func _1528395639_discussion_webhooksDownSql() (*asset, error) {
+	bytes, err := _1528395639_discussion_webhooksDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395639_discussion_webhooks.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xde, 0x3b, 0x33, 0xff, 0xdd, 0x7b, 0x95, 0x6e, 0x4, 0xea, 0xea, 0x25, 0x98, 0xcf, 0x5e, 0xed, 0xc0, 0xf4, 0x67, 0x1d, 0x8e, 0xe7, 0x5f, 0xb0, 0x3a, 0x27, 0xb0, 0x6d, 0x39, 0x42, 0xa5, 0x29}}
+	return a, nil
+}
+
+var __1528395639_discussion_webhooksUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x52\xcb\x8e\xaa\x40\x10\xdd\xf3\x15\xb5\x84\xe4\x2e\xee\xde\x15\x42\x79\x43\x2e\xe2\x04\x30\xd1\x55\x07\xe9\x8a\x56\x46\x69\xd3\x5d\x3e\x32\x5f\x3f\x19\xc0\x47\x32\x86\xd1\xcc\xb2\xbb\xce\xa9\xd4\x79\x8c\xf1\x5f\x92\x8d\x3c\x2f\xca\x31\x2c\x11\xca\x70\x9c\x22\x24\x13\xc8\x66\x25\xe0\x22\x29\xca\x02\x34\xbb\xfa\xe0\x1c\x9b\x46\x9d\x68\xb5\x31\xe6\xdd\x81\xef\x01\x00\xb0\x86\x15\xaf\x1d\x59\xae\xb6\xf0\x96\x27\xd3\x30\x5f\xc2\x7f\x5c\xfe\x69\xa7\x96\xf6\x46\xb1\x06\x6e\x84\xd6\x64\x21\xc7\x09\xe6\x98\x45\x58\xb4\x23\x9f\x75\x00\xb3\x0c\x62\x4c\xb1\x44\x88\xc2\x22\x0a\x63\xec\xa8\x07\xbb\x05\xa1\xb3\xb4\x67\x64\xf3\x34\xed\xbe\x1d\xd5\x96\xe4\xd1\xa4\xb6\x54\x09\x69\x55\x09\x08\xef\xc8\x49\xb5\xdb\xc3\x89\x65\xd3\x3e\xe1\xc3\x34\x74\x65\x40\x8c\x93\x70\x9e\x96\xd0\x98\x93\x1f\x78\xc1\xe8\x22\x3e\xc9\x62\x5c\xfc\x2c\x5e\xf5\xc2\x14\xeb\xf3\x97\x80\x07\x10\xbf\x87\x04\xaf\x3a\xab\x34\x6d\xf9\x48\x96\xe9\x39\x8f\x2f\xb4\x0e\xc5\xcd\xcd\x97\x7b\xbb\x1f\x5d\x38\xe0\x3e\x1d\xa9\x91\xe7\x57\xca\xc6\x52\xa5\x55\xcb\x1a\xdc\x5b\x89\xd0\x6e\x2f\xee\xda\x88\x6f\x89\xfc\xed\x73\x96\x4a\x0e\x4e\xd5\x46\xd3\x05\xdb\x5f\x66\xad\xb1\x6d\xfe\xbf\x8d\xbd\xe3\xf7\x6e\x0f\x6f\x78\xb9\x21\x77\x21\x5e\xbf\x86\xea\x72\x87\xf7\x6f\xf8\xb6\x3b\xb3\xe9\x34\x29\x47\xde\xe7\x00\x33\x8b\x97\xb8\xa6\x03\x00\x00")
+
+func _1528395639_discussion_webhooksUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395639_discussion_webhooksUpSql,
+		"1528395639_discussion_webhooks.up.sql",
+	)
+}
+
+func _1528395639_discussion_webhooksUpSql() (*asset, error) {
+	bytes, err := _1528395639_discussion_webhooksUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395639_discussion_webhooks.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x80, 0x53, 0x6b, 0x9d, 0xd6, 0x81, 0xbd, 0xef, 0xf8, 0x91, 0x57, 0xd2, 0xab, 0x15, 0xa, 0xd4, 0xb3, 0xcb, 0xc4, 0x7a, 0xd5, 0x47, 0x63, 0x69, 0xc5, 0xfe, 0xd9, 0x6, 0x99, 0x10, 0x5d, 0x82}}
+	return a, nil
+}
+
+var __1528395640_discussion_unsubscribe_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x45\x00\xba\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x75\x6e\x73\x75\x62\x73\x63\x72\x69\x62\x65\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x98\x02\xdb\x07\x45\x00\x00\x00")
+
+func _1528395640_discussion_unsubscribe_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395640_discussion_unsubscribe_tokensDownSql,
+		"1528395640_discussion_unsubscribe_tokens.down.sql",
+	)
+}
+
+func _1528395640_discussion_unsubscribe_tokensDownSql() (*asset, error) {
+	bytes, err := _1528395640_discussion_unsubscribe_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395640_discussion_unsubscribe_tokens.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x69, 0x18, 0x34, 0x99, 0xa4, 0xdc, 0xa9, 0xb1, 0xa7, 0xcb, 0x4a, 0xb2, 0xa, 0x2b, 0xb3, 0xee, 0x29, 0x77, 0xe0, 0x57, 0x5f, 0x2e, 0xde, 0x57, 0x4, 0x86, 0xae, 0xd8, 0x8, 0xfb, 0x4f, 0xbc}}
+	return a, nil
+}
+
+var __1528395640_discussion_unsubscribe_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x8f\xcd\x6a\xc3\x30\x10\x84\xef\x7a\x8a\x39\xda\x90\x37\xf0\xc9\x91\x37\x45\xd4\x96\x5b\x59\x86\xe4\x24\xea\x48\xa4\x4b\x41\x01\x4b\x86\x3c\x7e\x89\x93\xfe\x1c\xda\x42\x8f\xcb\xce\x7c\xcc\xb7\xa5\x07\xa5\x2b\x21\xa4\xa1\xda\x12\x6c\xbd\x6d\x09\x6a\x07\xdd\x5b\xd0\x5e\x0d\x76\x80\xe7\x74\x5c\x52\xe2\x73\x74\x4b\x4c\xcb\x94\x8e\x33\x4f\xc1\xe5\xf3\x5b\x88\x09\x85\x00\x80\xf5\x40\x0e\x97\x8c\x27\xa3\xba\xda\x1c\xf0\x48\x87\xcd\xfa\x5b\x52\x98\x1d\x7b\x70\xcc\xe1\x14\xe6\x15\xad\xc7\xb6\x85\xa1\x1d\x19\xd2\x92\x86\x35\x93\x0a\xf6\x25\x7a\x8d\x86\x5a\xb2\x04\x59\x0f\xb2\x6e\xe8\x06\xc9\xaf\x73\x78\xf1\x57\xcc\xc4\x27\x8e\xf9\x47\xca\xb7\xa5\xb7\xfc\x2f\x48\x51\x56\x1f\xc2\xa3\x56\xcf\x23\x41\xe9\x86\xf6\xff\xf1\x76\x77\x2b\xf7\x39\xcc\xb1\xbf\x5c\xd7\xff\x59\x2b\xee\xb5\xcd\x97\x50\x59\x09\x21\xfb\xae\x53\xb6\x12\xef\x03\x00\x0a\x30\x23\xf7\x8e\x01\x00\x00")
+
+func _1528395640_discussion_unsubscribe_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395640_discussion_unsubscribe_tokensUpSql,
+		"1528395640_discussion_unsubscribe_tokens.up.sql",
+	)
+}
+
+func _1528395640_discussion_unsubscribe_tokensUpSql() (*asset, error) {
+	bytes, err := _1528395640_discussion_unsubscribe_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395640_discussion_unsubscribe_tokens.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1d, 0xab, 0x91, 0x33, 0xc0, 0x38, 0x88, 0xa2, 0x1e, 0xf1, 0x3b, 0x70, 0x28, 0x63, 0xf6, 0xe8, 0x5f, 0x3a, 0x3b, 0x7e, 0x7f, 0x8c, 0x51, 0x77, 0xa8, 0x9a, 0x70, 0xb3, 0xf, 0xa2, 0x32, 0xa1}}
+	return a, nil
+}
+
+var __1528395641_discussion_comment_reactionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x44\x00\xbb\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x72\x65\x61\x63\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x26\x38\x76\x2b\x44\x00\x00\x00")
+
+func _1528395641_discussion_comment_reactionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395641_discussion_comment_reactionsDownSql,
+		"1528395641_discussion_comment_reactions.down.sql",
+	)
+}
+
+func _1528395641_discussion_comment_reactionsDownSql() (*asset, error) {
+	bytes, err := _1528395641_discussion_comment_reactionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395641_discussion_comment_reactions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7e, 0xd3, 0x53, 0xc, 0x5b, 0xab, 0x47, 0x97, 0x3a, 0x38, 0x6f, 0x90, 0x46, 0x24, 0xdb, 0x2f, 0x70, 0x9c, 0x5a, 0x2d, 0xeb, 0x97, 0xd1, 0x4d, 0xc3, 0x8c, 0xe2, 0x7d, 0x4e, 0xa, 0x23, 0x37}}
+	return a, nil
+}
+
+var __1528395641_discussion_comment_reactionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\xcd\x6a\x84\x40\x10\x84\xef\xf3\x14\x75\x54\xf0\x0d\x3c\xcd\x6a\x1b\x24\xfe\x04\x9d\x85\xec\x49\x8c\x0e\x9b\x3e\x38\x13\x9c\x5e\x36\xe4\xe9\x43\x4c\xc4\xcb\x92\x63\xff\xd4\x57\x45\x9d\xe8\xa9\x6c\x52\xa5\xb2\x8e\xb4\x21\x18\x7d\xaa\x08\x65\x81\xa6\x35\xa0\xd7\xb2\x37\x3d\x66\x0e\xd3\x2d\x04\xf6\x6e\x98\xfc\xb2\x58\x27\xc3\x6a\xc7\x49\xd8\xbb\x80\x48\x01\xc0\xbe\xe7\x19\x6f\x7c\x65\x27\x9b\xbe\x39\x57\x15\x3a\x2a\xa8\xa3\x26\xa3\x47\xa0\x10\xf1\x1c\xa3\x6d\x90\x53\x45\x86\x90\xe9\x3e\xd3\x39\x25\x1b\xf4\x16\xec\x3a\xf0\x0c\x76\x62\xaf\x76\x7d\x88\xfc\xf9\xf9\x17\x32\x79\x27\xd6\x09\xc4\x7e\x1e\xa1\xfe\x4e\xab\x1d\xc5\xce\xc3\x28\x10\x5e\x6c\x90\x71\xf9\xc0\x9d\xe5\x7d\x1b\xf1\xe5\x9d\x3d\x3c\x73\x2a\xf4\xb9\x32\x70\xfe\x1e\xc5\xbf\xfa\x97\xae\xac\x75\x77\xc1\x33\x5d\x10\x1d\x0d\x24\x7b\xf0\x64\x37\x8f\x55\x9c\x2a\x95\xb5\x75\x5d\x9a\x54\x7d\x0f\x00\x30\x33\x94\x58\x72\x01\x00\x00")
+
+func _1528395641_discussion_comment_reactionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395641_discussion_comment_reactionsUpSql,
+		"1528395641_discussion_comment_reactions.up.sql",
+	)
+}
+
+func _1528395641_discussion_comment_reactionsUpSql() (*asset, error) {
+	bytes, err := _1528395641_discussion_comment_reactionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395641_discussion_comment_reactions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe8, 0xf1, 0xb5, 0x2e, 0xa, 0xc5, 0xf8, 0xef, 0x87, 0xf4, 0x88, 0xa8, 0x8b, 0x3b, 0x9c, 0x87, 0x5b, 0x4a, 0xe9, 0x2d, 0x30, 0x98, 0x38, 0xbf, 0x20, 0xf3, 0x8e, 0xbe, 0xdb, 0xac, 0xfd, 0x69}}
+	return a, nil
+}
+
+var __1528395642_discussion_comment_mentionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x43\x00\xbc\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x6d\x65\x6e\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x3d\xdc\x21\x30\x43\x00\x00\x00")
+
+func _1528395642_discussion_comment_mentionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395642_discussion_comment_mentionsDownSql,
+		"1528395642_discussion_comment_mentions.down.sql",
+	)
+}
+
+func _1528395642_discussion_comment_mentionsDownSql() (*asset, error) {
+	bytes, err := _1528395642_discussion_comment_mentionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395642_discussion_comment_mentions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xef, 0xf9, 0xe2, 0x14, 0x0, 0x54, 0x9f, 0x28, 0xf3, 0x79, 0x85, 0xa2, 0xae, 0x67, 0x10, 0xdc, 0x1c, 0x24, 0x19, 0x73, 0xdd, 0x13, 0x8, 0x86, 0x7a, 0x82, 0x39, 0xdc, 0xe0, 0x87, 0x81, 0x5d}}
+	return a, nil
+}
+
+var __1528395642_discussion_comment_mentionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x90\xc1\x4a\xc4\x30\x18\x84\xef\x79\x8a\x39\xa6\xb0\x6f\x50\x11\xba\xd9\x7f\x35\xd8\x4d\xb1\x4d\x61\x6f\x61\xdd\x84\x9a\xc3\x26\xd0\x74\xc1\xc7\x97\x76\x5b\xf5\xa0\x45\xc1\x4b\x0e\x13\xbe\xf9\x67\x66\x4b\x0f\x52\xe5\x8c\x89\x9a\x0a\x4d\xd0\xc5\xb6\x24\xc8\x3d\x54\xa5\x41\x47\xd9\xe8\x06\xd6\xa7\xf3\x35\x25\x1f\x83\x39\xc7\xcb\xc5\x85\xc1\x8c\x8f\x8f\x21\x81\x33\x00\x58\x64\x6f\xf1\xe2\x3b\x1f\x86\x09\x57\x6d\x59\xa2\xa6\x3d\xd5\xa4\x04\x7d\xe7\x93\xb8\xb7\x19\x2a\x85\x1d\x95\xa4\x09\xa2\x68\x44\xb1\xa3\xcd\x64\x7a\x4d\xae\x37\xde\xc2\x87\xc1\x75\xae\xff\xea\x34\x7e\xad\xb2\xb1\xef\x7e\x40\x63\xdf\xad\x92\xa2\x52\x8d\xae\x0b\xa9\xf4\x5a\x6f\xf3\x7a\x4a\x26\x06\x67\x86\x53\xdf\xb9\x01\xe2\x91\xc4\x13\x38\x5f\x32\xcb\x66\x6a\x9f\xe1\xee\x1e\x7c\x0e\xb3\x68\x19\xcb\xf2\x65\xee\x56\xc9\xe7\x96\x20\xd5\x8e\x8e\xbf\x5f\xfd\x43\xf0\xd6\xcc\x17\x8d\xb7\x6f\xe3\x90\x2b\x14\xff\xa4\x36\x98\xb1\xff\x4c\x72\xab\xf9\xd7\x20\x37\x2a\xcb\x19\x13\xd5\xe1\x20\x75\xce\xde\x07\x00\xea\x66\xa4\xac\x92\x02\x00\x00")
+
+func _1528395642_discussion_comment_mentionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395642_discussion_comment_mentionsUpSql,
+		"1528395642_discussion_comment_mentions.up.sql",
+	)
+}
+
+func _1528395642_discussion_comment_mentionsUpSql() (*asset, error) {
+	bytes, err := _1528395642_discussion_comment_mentionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395642_discussion_comment_mentions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf0, 0xf6, 0xe0, 0xa, 0x1c, 0x49, 0xe3, 0x46, 0x7e, 0x7c, 0xf2, 0xf4, 0x1, 0xbf, 0xa9, 0xf7, 0xb8, 0x40, 0x7a, 0x38, 0xde, 0x20, 0x3, 0x31, 0x67, 0x32, 0x30, 0xf0, 0x2, 0x95, 0xdf, 0x7f}}
+	return a, nil
+}
+
+var __1528395643_discussion_comment_editsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x40\x00\xbf\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x65\x64\x69\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x2b\xdd\xd9\x60\x40\x00\x00\x00")
+
+func _1528395643_discussion_comment_editsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395643_discussion_comment_editsDownSql,
+		"1528395643_discussion_comment_edits.down.sql",
+	)
+}
+
+func _1528395643_discussion_comment_editsDownSql() (*asset, error) {
+	bytes, err := _1528395643_discussion_comment_editsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395643_discussion_comment_edits.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5a, 0x1, 0xd2, 0x84, 0x30, 0x86, 0x98, 0x6f, 0x38, 0x53, 0xf8, 0x6f, 0xdb, 0x79, 0xaa, 0x72, 0x88, 0xed, 0x5a, 0x6e, 0xfd, 0x47, 0x7e, 0x39, 0x2d, 0xc8, 0xee, 0x11, 0x18, 0xc, 0x36, 0x3e}}
+	return a, nil
+}
+
+var __1528395643_discussion_comment_editsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x6e\xea\x30\x10\x45\xf7\xfe\x8a\x59\x26\xd2\xfb\x83\xac\x42\x32\x3c\x59\x0d\xa6\x0a\x46\x82\x95\x95\xe2\x11\x1d\xa9\xb1\x91\x3d\x14\xd4\xaf\xaf\x80\xd2\x6c\x68\xd5\xe5\x95\x8f\xcf\xb5\xef\x0c\xff\x6b\x53\x29\xd5\xf4\x58\x5b\x04\x5b\xcf\x3a\x04\x3d\x07\xb3\xb4\x80\x1b\xbd\xb2\x2b\xf0\x9c\x77\xc7\x9c\x39\x06\xb7\x8b\xe3\x48\x41\x1c\x79\x96\x0c\x85\x02\x00\x60\x0f\x2f\xbc\xcf\x94\x78\x78\x83\xe7\x5e\x2f\xea\x7e\x0b\x4f\xb8\xfd\x77\x3d\xbd\xdf\xb8\x51\x1c\xe4\x6a\x36\xeb\xae\x83\x1e\xe7\xd8\xa3\x69\xf0\x51\x45\x2e\xd8\x97\xb0\x34\xd0\x62\x87\x16\xa1\xa9\x57\x4d\xdd\xe2\x4d\x7a\xa9\x8f\xc9\x1d\x33\x25\xc7\x1e\x38\x08\xed\x29\x3d\x34\x5f\x98\x5f\x5d\x87\x44\xef\x1c\x8f\xd9\xed\x62\x10\x0a\x92\x41\xe8\x3c\xbd\xf2\xeb\x17\x89\x06\x21\xef\x06\x01\xe1\x91\xb2\x0c\xe3\x01\x4e\x2c\xaf\xd7\x08\x1f\x31\xd0\xd4\xde\xe2\xbc\x5e\x77\x16\x42\x3c\x15\xa5\x2a\xab\xfb\xb6\xda\xb4\xb8\xf9\xe3\xb6\xdf\x89\xbd\x63\x7f\xbe\x0c\xf1\x13\x5a\x4c\x68\x59\x29\xd5\x2c\x17\x0b\x6d\x2b\xf5\x39\x00\xb9\xf3\x84\xda\xd9\x01\x00\x00")
+
+func _1528395643_discussion_comment_editsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395643_discussion_comment_editsUpSql,
+		"1528395643_discussion_comment_edits.up.sql",
+	)
+}
+
+func _1528395643_discussion_comment_editsUpSql() (*asset, error) {
+	bytes, err := _1528395643_discussion_comment_editsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395643_discussion_comment_edits.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3b, 0x46, 0x3e, 0x9a, 0xa3, 0xd1, 0x5f, 0x38, 0xcf, 0x1d, 0x40, 0x6f, 0x99, 0xff, 0x31, 0xfc, 0x74, 0x1, 0xa, 0x3a, 0xc9, 0x4a, 0xdc, 0xf9, 0x67, 0x4c, 0x80, 0x69, 0x7c, 0x27, 0xaf, 0xe1}}
+	return a, nil
+}
+
+var __1528395644_discussion_comments_parentDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5a\x00\xa5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x61\x72\x65\x6e\x74\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x69\x64\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x58\xc1\x5a\x08\x5a\x00\x00\x00")
+
+func _1528395644_discussion_comments_parentDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395644_discussion_comments_parentDownSql,
+		"1528395644_discussion_comments_parent.down.sql",
+	)
+}
+
+func _1528395644_discussion_comments_parentDownSql() (*asset, error) {
+	bytes, err := _1528395644_discussion_comments_parentDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395644_discussion_comments_parent.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9b, 0xd6, 0x80, 0x89, 0x99, 0x42, 0x4d, 0xc8, 0x6b, 0xa5, 0x80, 0xe7, 0x85, 0x8, 0xba, 0x1a, 0x6f, 0x8c, 0x7e, 0x2b, 0x96, 0x61, 0xc0, 0x6c, 0x15, 0xb7, 0x89, 0xdf, 0xdc, 0x8e, 0x29, 0x3b}}
+	return a, nil
+}
+
+var __1528395644_discussion_comments_parentUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcd\xb1\xaa\xc3\x20\x18\x47\xf1\xdd\xa7\xf8\xc6\x9b\x67\x70\x32\xfa\xcf\x45\x30\x0a\xea\x85\x6c\x72\x1b\x43\x71\x88\x29\x35\x85\x3e\x7e\xe9\xd0\xa5\xc9\x7e\x38\xbf\x1e\xbf\xda\x72\xc6\x84\x89\xf0\x14\x45\x6f\x40\xb9\xb4\xf9\xd1\x5a\xd9\x6a\x9a\xb7\x75\x5d\xea\xde\x48\x28\x45\xd2\x99\xbf\xd1\x92\x1e\xc8\xba\x48\x98\x74\x88\x81\x6e\xff\xf7\xa5\xee\x9f\x30\x95\x4c\x97\x72\x2d\x75\x27\x8f\x01\x1e\x56\x22\x9c\x0d\x7f\x4a\xee\xc8\x59\x52\x30\x88\x20\x29\x82\x14\x0a\x9c\x49\x0f\x11\x41\xda\x2a\x4c\x5f\xd2\xc9\x25\x1d\xf4\x54\xf2\xf3\xfd\x3d\x23\x0f\x71\xc7\x19\x93\x6e\x1c\x75\xe4\xec\x35\x00\x7d\xd6\xbc\x52\x09\x01\x00\x00")
+
+func _1528395644_discussion_comments_parentUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395644_discussion_comments_parentUpSql,
+		"1528395644_discussion_comments_parent.up.sql",
+	)
+}
+
+func _1528395644_discussion_comments_parentUpSql() (*asset, error) {
+	bytes, err := _1528395644_discussion_comments_parentUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395644_discussion_comments_parent.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x71, 0xdd, 0xb, 0x5, 0x35, 0x85, 0x15, 0xb2, 0x31, 0x4, 0x79, 0x43, 0xd2, 0x71, 0xcf, 0x6c, 0x71, 0xb7, 0xf9, 0x99, 0xc3, 0xf4, 0x36, 0x9f, 0x39, 0x5b, 0xf, 0xba, 0x83, 0x54, 0x2d, 0xae}}
+	return a, nil
+}
+
+var __1528395645_discussion_comment_suggestionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x73\x75\x67\x67\x65\x73\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x01\xe7\xbb\xa9\x46\x00\x00\x00")
+
+func _1528395645_discussion_comment_suggestionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395645_discussion_comment_suggestionsDownSql,
+		"1528395645_discussion_comment_suggestions.down.sql",
+	)
+}
+
+func _1528395645_discussion_comment_suggestionsDownSql() (*asset, error) {
+	bytes, err := _1528395645_discussion_comment_suggestionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395645_discussion_comment_suggestions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc9, 0xfd, 0xd6, 0x8a, 0x40, 0x79, 0xda, 0x0, 0x4a, 0xd2, 0x4c, 0xbe, 0x2d, 0x15, 0xc1, 0x7f, 0xe8, 0xb1, 0x76, 0x5, 0xc6, 0x5e, 0x32, 0x50, 0x9, 0x27, 0x7a, 0x41, 0x66, 0xa1, 0xc9, 0xd2}}
+	return a, nil
+}
+
+var __1528395645_discussion_comment_suggestionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\xcd\x6a\x83\x50\x10\x85\xf7\xf7\x29\xce\x32\x81\xbe\x81\x2b\x63\x26\x45\xea\x4f\xd1\x5b\x68\x56\x62\xe2\x60\x07\xea\x55\x32\x23\xfd\x79\xfa\xa2\x6d\xa1\x0d\x59\x0e\xdf\x70\xce\x77\x76\x74\x9f\x16\x91\x73\x49\x45\xb1\x27\xf8\x78\x97\x11\xd2\x03\x8a\xd2\x83\x9e\xd3\xda\xd7\xe8\x44\xcf\xb3\xaa\x8c\xa1\x39\x8f\xc3\xc0\xc1\x1a\x9d\xfb\x9e\xd5\x64\x0c\x8a\x8d\x03\x80\x5f\x22\x1d\x4e\xd2\x4b\x30\x3c\x56\x69\x1e\x57\x47\x3c\xd0\x11\x15\x1d\xa8\xa2\x22\xa1\x5b\x69\xba\x91\x6e\x8b\xb2\xc0\x9e\x32\xf2\x84\x24\xae\x93\x78\x4f\x77\x3f\xb9\xc1\x38\x98\xc2\xf8\xdd\x56\xab\xe2\x29\xcb\xbe\x59\x3b\x4d\xaf\xc2\x5d\xd3\x1a\x4c\x06\x56\x6b\x87\x09\x6f\x62\x2f\xeb\x89\xcf\x31\xf0\xff\xc7\xd3\x47\x33\x2b\x5f\x1a\xe9\x20\xc1\xb8\xe7\xcb\x5f\xb3\x05\x5d\xbb\xd4\x74\xab\x70\x19\x2b\xeb\xd6\xc5\xca\x6d\x23\xe7\x92\x32\xcf\x53\x1f\xb9\xaf\x01\x00\xf9\x9a\x58\xe5\x50\x01\x00\x00")
+
+func _1528395645_discussion_comment_suggestionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395645_discussion_comment_suggestionsUpSql,
+		"1528395645_discussion_comment_suggestions.up.sql",
+	)
+}
+
+func _1528395645_discussion_comment_suggestionsUpSql() (*asset, error) {
+	bytes, err := _1528395645_discussion_comment_suggestionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395645_discussion_comment_suggestions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa1, 0x8, 0x30, 0x27, 0xdd, 0x62, 0xa9, 0xe1, 0x88, 0x28, 0x4, 0x5a, 0x73, 0xce, 0xcd, 0x73, 0x4f, 0x81, 0x43, 0xb, 0x42, 0xf5, 0xd, 0xdc, 0x9b, 0xdd, 0xea, 0xfb, 0xba, 0xb0, 0xaf, 0xb4}}
+	return a, nil
+}
+
+var __1528395646_discussion_comments_resolvedDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xc9\x2c\x4e\x2e\x2d\x2e\xce\xcc\xcf\x8b\x4f\xce\xcf\xcd\x4d\xcd\x2b\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x2d\xce\xcf\x29\x4b\x4d\x89\x4f\xaa\x8c\x2f\x2d\x4e\x2d\x8a\xcf\x4c\xb1\xa6\xd4\xa8\xc4\x12\x6b\x2e\x2e\x67\x7f\x5f\x5f\xcf\x10\x6b\x2e\xc0\x00\x71\x1f\x69\xb1\x9f\x00\x00\x00")
+
+func _1528395646_discussion_comments_resolvedDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395646_discussion_comments_resolvedDownSql,
+		"1528395646_discussion_comments_resolved.down.sql",
+	)
+}
+
+func _1528395646_discussion_comments_resolvedDownSql() (*asset, error) {
+	bytes, err := _1528395646_discussion_comments_resolvedDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395646_discussion_comments_resolved.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4, 0x5, 0x16, 0xd6, 0x52, 0x68, 0xca, 0xd7, 0x3c, 0x4f, 0x99, 0x74, 0x16, 0x78, 0xd1, 0xfc, 0xd4, 0xb8, 0xa0, 0x1c, 0xb5, 0xef, 0x7f, 0x68, 0x6a, 0xd0, 0xee, 0x9e, 0xe9, 0x9d, 0x52, 0x41}}
+	return a, nil
+}
+
+var __1528395646_discussion_comments_resolvedUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xcc\x41\x4a\xc4\x30\x14\x87\xf1\x7d\x4e\xf1\x5f\xea\x19\xb2\xea\xb4\x6f\x24\x90\x26\xd0\x64\xc0\x5d\x18\x27\x0f\x0d\x98\x44\xfa\x52\x45\x4f\x2f\x7a\x86\x59\x7e\x7c\xf0\x3b\xd1\x93\x71\x5a\xa9\xc9\x46\xda\x10\xa7\x93\x25\xe4\x22\xb7\x43\xa4\xf4\x96\x6e\xbd\x56\x6e\x43\x30\x2d\x0b\x66\x6f\x2f\xab\x83\x39\xc3\xf9\x08\x7a\x36\x21\x06\xec\x2c\xfd\xfd\x93\x73\xba\x0e\x8c\x52\x59\xc6\xb5\x7e\xe0\xab\x8c\xb7\xff\xc4\x4f\x6f\xac\xef\xc0\xbf\x7c\xa7\x43\x78\x4f\x25\xa3\xb4\xc1\xaf\xbc\x63\xa3\x33\x6d\xe4\x66\x0a\xf8\x5b\xf2\x50\xf2\x23\xbc\xc3\x42\x96\x22\x21\x50\x84\xbb\x58\xab\x95\x9a\xfd\xba\x9a\xa8\xd5\xef\x00\xbc\x72\xee\x05\xee\x00\x00\x00")
+
+func _1528395646_discussion_comments_resolvedUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395646_discussion_comments_resolvedUpSql,
+		"1528395646_discussion_comments_resolved.up.sql",
+	)
+}
+
+func _1528395646_discussion_comments_resolvedUpSql() (*asset, error) {
+	bytes, err := _1528395646_discussion_comments_resolvedUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395646_discussion_comments_resolved.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc6, 0x33, 0xc2, 0x5d, 0xbc, 0x98, 0x39, 0xae, 0xe4, 0x73, 0xcf, 0x86, 0x9a, 0x3c, 0xe7, 0x54, 0xaa, 0xaa, 0x9e, 0xe1, 0x42, 0xdc, 0x69, 0xd3, 0xc6, 0x93, 0x87, 0x7b, 0xe8, 0x19, 0x28, 0x6a}}
+	return a, nil
+}
+
+var __1528395647_discussion_comment_attachmentsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x5f\x61\x74\x74\x61\x63\x68\x6d\x65\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x6e\x83\x2c\xa2\x46\x00\x00\x00")
+
+func _1528395647_discussion_comment_attachmentsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395647_discussion_comment_attachmentsDownSql,
+		"1528395647_discussion_comment_attachments.down.sql",
+	)
+}
+
+func _1528395647_discussion_comment_attachmentsDownSql() (*asset, error) {
+	bytes, err := _1528395647_discussion_comment_attachmentsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395647_discussion_comment_attachments.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfe, 0x41, 0xd, 0x99, 0xa1, 0xb0, 0x74, 0xc7, 0xb, 0x2f, 0x1a, 0x4f, 0x31, 0x46, 0x0, 0x91, 0x6e, 0xc5, 0x59, 0x73, 0xb3, 0x10, 0xd7, 0xe0, 0x9f, 0xe1, 0x3f, 0x1d, 0x71, 0xb8, 0x74, 0x55}}
+	return a, nil
+}
+
+var __1528395647_discussion_comment_attachmentsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xc1\x6e\xc2\x30\x10\x44\xef\xfe\x8a\x3d\x26\x52\xff\x20\xa7\x90\x2c\x95\xd5\x60\xda\xe0\x48\x70\x8a\xdc\xd8\x2d\xab\x12\x27\xc2\x8b\xa0\x7c\x7d\x05\x51\xa0\xad\x10\x55\x6f\xb6\x67\xde\xcc\xca\x3b\xc1\x47\xa9\x12\x21\xb2\x12\x53\x8d\xa0\xd3\x49\x81\x20\xa7\xa0\xe6\x1a\x70\x29\x17\x7a\x01\x96\x42\xb3\x0b\x81\x3a\x5f\x37\x5d\xdb\x3a\xcf\xb5\x61\x36\xcd\xfa\x74\x0c\x10\x09\x00\x00\xb2\xf0\x4a\xef\xc1\x6d\xc9\x6c\xe0\xb9\x94\xb3\xb4\x5c\xc1\x13\xae\x1e\xce\xea\xc8\x0d\x2e\xf2\x7c\xce\x57\x55\x51\x40\x89\x53\x2c\x51\x65\x78\xab\x28\x44\x64\x63\x98\x2b\xc8\xb1\x40\x8d\x90\xa5\x8b\x2c\xcd\x71\x08\x7d\xa3\x8d\xf3\xa6\x75\xc0\xee\x70\x0d\x1c\x0b\x3d\x9f\x0a\xf9\xb3\xbf\xa9\x07\x3a\xba\xdf\xa3\x0c\xe4\xae\xdf\x74\xc6\xd6\xdc\x7d\x38\x3f\x90\x95\x92\x2f\x15\xfe\x50\xdd\xa1\xa7\xad\x0b\xb5\x61\x60\x6a\x5d\x60\xd3\xf6\xb0\x27\x5e\x9f\xaf\x70\xec\xbc\xfb\xee\x77\xf6\x6f\x67\xb3\x75\x86\xef\x1b\x2f\x93\x42\x8e\xd3\xb4\x2a\x34\xf8\x6e\x1f\xc5\x22\x4e\xc6\xf5\x49\x95\xe3\xf2\x5f\xeb\xbb\xbc\x91\xad\xc9\x1e\x4e\x7f\x7d\x1f\x88\xae\x40\x9c\x08\x91\xcd\x67\x33\xa9\x13\xf1\x35\x00\xc1\x25\xb5\xf8\x48\x02\x00\x00")
+
+func _1528395647_discussion_comment_attachmentsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395647_discussion_comment_attachmentsUpSql,
+		"1528395647_discussion_comment_attachments.up.sql",
+	)
+}
+
+func _1528395647_discussion_comment_attachmentsUpSql() (*asset, error) {
+	bytes, err := _1528395647_discussion_comment_attachmentsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395647_discussion_comment_attachments.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfe, 0xe7, 0x63, 0xe, 0xd, 0x48, 0xd4, 0x1c, 0xcf, 0xfd, 0x92, 0x5d, 0xc5, 0x6, 0x17, 0xb1, 0xb, 0xb4, 0xe7, 0xdf, 0x39, 0xf7, 0x44, 0x34, 0x3d, 0xd5, 0xe8, 0x23, 0xf1, 0xd0, 0x24, 0x61}}
+	return a, nil
+}
+
+var __1528395648_discussion_thread_referencesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x44\x00\xbb\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x72\x65\x66\x65\x72\x65\x6e\x63\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x52\xfe\x2b\x79\x44\x00\x00\x00")
+
+func _1528395648_discussion_thread_referencesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395648_discussion_thread_referencesDownSql,
+		"1528395648_discussion_thread_references.down.sql",
+	)
+}
+
+func _1528395648_discussion_thread_referencesDownSql() (*asset, error) {
+	bytes, err := _1528395648_discussion_thread_referencesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395648_discussion_thread_references.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x36, 0x78, 0x3f, 0x86, 0x64, 0x17, 0xb5, 0xb0, 0xc9, 0x5d, 0x21, 0x76, 0x62, 0x4a, 0xe2, 0x4a, 0x66, 0xd7, 0xc9, 0xa, 0xcf, 0x8b, 0x49, 0x48, 0x5, 0x36, 0x3f, 0x52, 0x44, 0x7e, 0x8d, 0x4c}}
+	return a, nil
+}
+
+var __1528395648_discussion_thread_referencesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x92\x51\x6b\xb3\x30\x14\x86\xef\xf3\x2b\xce\xa5\x42\xff\x81\x1f\x1f\xa4\xf1\x74\x0b\xb5\x71\xc4\x14\xda\xab\xe0\x4c\xba\x06\x66\x1c\x26\xa3\x63\xbf\x7e\xac\x75\x95\x81\x48\xc7\xd8\xa5\x70\x9e\xe7\x7d\x3d\x39\x4b\xbc\xe3\x22\x23\x84\x49\xa4\x0a\x41\xd1\x65\x81\xc0\x57\x20\x4a\x05\xb8\xe3\x95\xaa\xc0\xb8\xd0\xbc\x86\xe0\x3a\xaf\xe3\xb1\xb7\xb5\xd1\xbd\x3d\xd8\xde\xfa\xc6\x06\x48\x08\x00\x40\xd3\xb5\xad\xf5\x51\x3b\x03\x8f\xee\xc9\xf9\x78\xe6\xc5\xb6\x28\x40\xe2\x0a\x25\x0a\x86\xdf\x44\x03\x10\x12\x67\x52\x28\x05\xe4\x58\xa0\x42\x60\xb4\x62\x34\xc7\xc5\x59\x3a\x84\xdd\xec\xbc\xcc\xcf\x2a\xaf\xc5\x8d\xfe\x03\x7b\xd3\xdb\x3a\x5a\xa3\xeb\x08\xd1\xb5\x36\xc4\xba\x7d\x81\x93\x8b\xc7\xf3\x27\xbc\x77\xde\x8e\x29\x39\xae\xe8\xb6\x50\xe0\xbb\x53\x92\x5e\xf8\x07\xc9\x37\x54\xee\x61\x8d\x7b\x48\xc6\x95\x2e\x26\x6b\x0f\x0c\x2b\x45\xa5\x24\xe5\x42\xcd\xbe\x93\xf6\x5d\xd4\xc1\x3e\x1f\x80\xdd\x23\x5b\x43\x72\xf5\xc0\xbf\xff\xd3\x7e\x92\x66\x5f\x57\xc1\x45\x8e\xbb\x1f\x5c\xc5\x68\xd1\xce\xbc\x7d\xae\x6b\x6e\x7a\xec\xf2\x8b\xc4\xa9\x5f\xb8\x29\x7c\x0a\x4c\x33\x42\x58\xb9\xd9\x70\x95\x91\x8f\x01\x00\xb0\x26\xfb\xfb\x21\x03\x00\x00")
+
+func _1528395648_discussion_thread_referencesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395648_discussion_thread_referencesUpSql,
+		"1528395648_discussion_thread_references.up.sql",
+	)
+}
+
+func _1528395648_discussion_thread_referencesUpSql() (*asset, error) {
+	bytes, err := _1528395648_discussion_thread_referencesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395648_discussion_thread_references.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x0, 0x48, 0xb0, 0x21, 0x4c, 0x47, 0xc9, 0x24, 0x84, 0x3d, 0x4f, 0x63, 0x26, 0xd0, 0x7, 0x17, 0xc8, 0x4b, 0x93, 0xcc, 0x9b, 0xbf, 0x16, 0x6d, 0xf4, 0x80, 0xfa, 0xf5, 0xf4, 0x32, 0x45, 0x48}}
+	return a, nil
+}
+
+var __1528395649_discussion_threads_stale_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x50\x00\xaf\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x74\x61\x6c\x65\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x71\x4e\x3a\xfd\x50\x00\x00\x00")
+
+func _1528395649_discussion_threads_stale_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395649_discussion_threads_stale_atDownSql,
+		"1528395649_discussion_threads_stale_at.down.sql",
+	)
+}
+
+func _1528395649_discussion_threads_stale_atDownSql() (*asset, error) {
+	bytes, err := _1528395649_discussion_threads_stale_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395649_discussion_threads_stale_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe, 0x49, 0x7f, 0x5a, 0x30, 0x90, 0xae, 0xc1, 0xef, 0x1a, 0xb2, 0xf2, 0x3e, 0x68, 0x5d, 0x2f, 0xd4, 0xec, 0xa2, 0x3e, 0xcb, 0x4e, 0x77, 0xd1, 0x98, 0xbd, 0x38, 0x8c, 0xfb, 0x88, 0x2f, 0x62}}
+	return a, nil
+}
+
+var __1528395649_discussion_threads_stale_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x73\x74\x61\x6c\x65\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x77\x69\x74\x68\x20\x74\x69\x6d\x65\x20\x7a\x6f\x6e\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x6c\xac\xa0\x79\x6c\x00\x00\x00")
+
+func _1528395649_discussion_threads_stale_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395649_discussion_threads_stale_atUpSql,
+		"1528395649_discussion_threads_stale_at.up.sql",
+	)
+}
+
+func _1528395649_discussion_threads_stale_atUpSql() (*asset, error) {
+	bytes, err := _1528395649_discussion_threads_stale_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395649_discussion_threads_stale_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd2, 0x9a, 0xbc, 0x4c, 0x94, 0xc0, 0xfd, 0xbf, 0x36, 0x61, 0x8b, 0xae, 0xbd, 0x6f, 0x13, 0xca, 0x7d, 0xa9, 0xcb, 0x22, 0xc, 0x37, 0x4f, 0x56, 0x40, 0x75, 0xf4, 0xbc, 0x20, 0xd0, 0xf1, 0x3f}}
+	return a, nil
+}
+
+var __1528395650_discussion_threads_duplicate_ofDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5e\x00\xa1\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x75\x70\x6c\x69\x63\x61\x74\x65\x5f\x6f\x66\x5f\x74\x68\x72\x65\x61\x64\x5f\x69\x64\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xac\xc7\x93\x7f\x5e\x00\x00\x00")
+
+func _1528395650_discussion_threads_duplicate_ofDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395650_discussion_threads_duplicate_ofDownSql,
+		"1528395650_discussion_threads_duplicate_of.down.sql",
+	)
+}
+
+func _1528395650_discussion_threads_duplicate_ofDownSql() (*asset, error) {
+	bytes, err := _1528395650_discussion_threads_duplicate_ofDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395650_discussion_threads_duplicate_of.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x41, 0xc7, 0x20, 0xa5, 0xe2, 0x3c, 0x76, 0xa3, 0xe0, 0x46, 0x2e, 0x3c, 0xf4, 0x4e, 0xf5, 0xa5, 0xc0, 0x9c, 0x78, 0xfa, 0x6b, 0xfb, 0xb5, 0x59, 0x5a, 0x11, 0x50, 0x65, 0xbf, 0xb5, 0x2f, 0x72}}
+	return a, nil
+}
+
+var __1528395650_discussion_threads_duplicate_ofUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xc1\x6a\xc4\x20\x10\xc6\xf1\xbb\x4f\x31\xc7\xe6\x19\x3c\x19\x9d\x14\xc1\x28\xe8\x04\x72\x93\x34\xa6\xed\x40\x49\x4a\x4d\xa0\x8f\xbf\x2c\xec\x69\xd7\xfb\xcc\xef\xff\xf5\xf8\x6e\xbd\x14\x42\x39\xc2\x08\xa4\x7a\x87\x50\xb8\xae\x57\xad\x7c\xec\xf9\xfc\xfe\xdb\x96\x52\x41\x19\x03\x3a\xb8\x69\xf4\x60\x07\xf0\x81\x00\x67\x9b\x28\x41\xb9\x7e\x7f\x78\x5d\xce\x2d\x1f\x9f\x8f\xeb\xcc\x05\x3e\xf8\x8b\xf7\x13\x22\x0e\x18\xd1\x6b\x4c\x0d\xf4\x8d\x4b\x07\xc1\x83\x41\x87\x84\x90\x90\xc0\x4f\xce\x49\xa1\x23\x2a\x42\xb0\xde\xe0\xfc\x9c\x7b\x51\x72\x7b\x41\xe6\xf2\x7f\xc7\x1b\xd9\xf6\x43\x27\x85\xd0\x61\x1c\x2d\x49\x71\x1b\x00\x8b\xb9\xee\xbc\x15\x01\x00\x00")
+
+func _1528395650_discussion_threads_duplicate_ofUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395650_discussion_threads_duplicate_ofUpSql,
+		"1528395650_discussion_threads_duplicate_of.up.sql",
+	)
+}
+
+func _1528395650_discussion_threads_duplicate_ofUpSql() (*asset, error) {
+	bytes, err := _1528395650_discussion_threads_duplicate_ofUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395650_discussion_threads_duplicate_of.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x55, 0x68, 0x46, 0xd1, 0x8e, 0xbb, 0xde, 0x24, 0xaa, 0xb0, 0x6d, 0xa4, 0x26, 0xa9, 0x5b, 0x11, 0x33, 0xc8, 0x79, 0xb3, 0x15, 0xe2, 0xd0, 0x63, 0xa4, 0x79, 0xf1, 0xb1, 0xe3, 0x5a, 0x0, 0x3b}}
+	return a, nil
+}
+
+var __1528395651_discussion_threads_pinned_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x51\x00\xae\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x69\x6e\x6e\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xbf\x03\xaa\xac\x51\x00\x00\x00")
+
+func _1528395651_discussion_threads_pinned_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395651_discussion_threads_pinned_atDownSql,
+		"1528395651_discussion_threads_pinned_at.down.sql",
+	)
+}
+
+func _1528395651_discussion_threads_pinned_atDownSql() (*asset, error) {
+	bytes, err := _1528395651_discussion_threads_pinned_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395651_discussion_threads_pinned_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x96, 0x3, 0x8e, 0xe, 0xb9, 0x64, 0xfd, 0x81, 0x28, 0x71, 0x13, 0x5f, 0x5f, 0xec, 0x1, 0x40, 0x2d, 0x5e, 0xa2, 0x22, 0x66, 0x49, 0x82, 0x2e, 0xd6, 0x67, 0xb6, 0x93, 0xa4, 0x4, 0x5d, 0x61}}
+	return a, nil
+}
+
+var __1528395651_discussion_threads_pinned_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6d\x00\x92\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x70\x69\x6e\x6e\x65\x64\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x77\x69\x74\x68\x20\x74\x69\x6d\x65\x20\x7a\x6f\x6e\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xac\x53\x2a\x8b\x6d\x00\x00\x00")
+
+func _1528395651_discussion_threads_pinned_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395651_discussion_threads_pinned_atUpSql,
+		"1528395651_discussion_threads_pinned_at.up.sql",
+	)
+}
+
+func _1528395651_discussion_threads_pinned_atUpSql() (*asset, error) {
+	bytes, err := _1528395651_discussion_threads_pinned_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395651_discussion_threads_pinned_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x83, 0x6e, 0x67, 0xc5, 0x75, 0x80, 0x1, 0xb2, 0x5d, 0xcc, 0x62, 0xf9, 0xbd, 0xa4, 0xf8, 0xd2, 0x7, 0xf0, 0x92, 0xd2, 0x40, 0x95, 0x8d, 0x12, 0xcd, 0x5a, 0xf0, 0x3f, 0x78, 0xd, 0x87, 0x97}}
+	return a, nil
+}
+
+var __1528395652_discussion_audit_logDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x76\x00\x89\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x61\x75\x64\x69\x74\x5f\x6c\x6f\x67\x3b\x0a\x44\x52\x4f\x50\x20\x46\x55\x4e\x43\x54\x49\x4f\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x61\x75\x64\x69\x74\x5f\x6c\x6f\x67\x5f\x69\x6d\x6d\x75\x74\x61\x62\x6c\x65\x28\x29\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xc9\x5d\x1a\xba\x76\x00\x00\x00")
+
+func _1528395652_discussion_audit_logDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395652_discussion_audit_logDownSql,
+		"1528395652_discussion_audit_log.down.sql",
+	)
+}
+
+func _1528395652_discussion_audit_logDownSql() (*asset, error) {
+	bytes, err := _1528395652_discussion_audit_logDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395652_discussion_audit_log.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3f, 0x4b, 0x7, 0xcf, 0x63, 0x77, 0x4e, 0xc4, 0x26, 0xc4, 0x26, 0xb0, 0xbd, 0x70, 0x77, 0x87, 0xfc, 0x81, 0x76, 0x5e, 0xe6, 0x17, 0xc0, 0xb4, 0x3d, 0xf1, 0xa7, 0x53, 0xd1, 0xbf, 0x99, 0x8c}}
+	return a, nil
+}
+
+var __1528395652_discussion_audit_logUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x53\xcd\x6e\xf2\x38\x14\xdd\xfb\x29\xce\xa2\x12\x20\xb5\xf3\x00\xc3\x2a\x0d\x17\x1a\x35\x4d\x90\x49\x34\x74\x15\x85\xf8\x02\xee\x24\x36\xe3\x98\xb6\xf3\x3d\xfd\xa7\xa6\x05\x82\xd4\xf2\x55\x5d\xc6\xf7\xf8\xfc\xf8\xdc\xdc\xd2\x2c\x4a\xc6\x42\x84\x92\x82\x8c\x90\x05\xb7\x31\x21\x9a\x22\x49\x33\xd0\x32\x5a\x64\x0b\x28\xdd\x56\xfb\xb6\xd5\xd6\x14\xe5\x5e\x69\x5f\xd4\x76\x83\xa1\x00\x00\xad\xb0\xd2\x9b\x96\x9d\x2e\x6b\xcc\x65\xf4\x10\xc8\x47\xdc\xd3\xe3\x75\x37\x2d\x2b\x6f\x5d\xb1\x6f\xd9\x15\x5a\x41\x1b\xcf\x1b\x76\xfd\x91\xde\xc1\xf3\xab\xef\x1f\xad\xad\x7b\x29\x9d\x62\x55\xac\xad\xeb\x4d\xed\xea\x89\x2b\x5f\xfc\xab\x8d\xea\x4e\x3b\x83\x49\x1e\xc7\x08\xef\x28\xbc\xc7\xb0\x0f\x88\x12\x0c\x07\x7e\xeb\xb8\x54\x83\x6b\x0c\x2a\xdb\x34\x6c\xfc\x60\x34\x3a\xe3\x7a\x37\xaf\xcd\x89\xeb\x68\x44\x5b\x73\xae\xf2\x3e\x59\xf1\xda\x3a\xc6\x53\x6b\xcd\xea\x03\xbb\xf6\xec\xfa\x07\x95\xe3\xd2\xb3\x2a\x4a\x0f\xaf\x1b\x6e\x7d\xd9\xec\xf0\xa2\xfd\xb6\xfb\xc4\x2f\x6b\xf8\x48\x8a\x09\x4d\x83\x3c\xce\x60\xec\xcb\x70\x24\x46\xe3\x43\x0b\x51\x32\xa1\xe5\x37\x5a\x28\x8e\x49\x5e\x91\x26\x9f\x42\xfa\xef\x72\x7d\x4a\xfe\x03\xad\xb3\x36\x2f\x4a\x9e\x21\x7f\xa0\x74\x7a\xc3\x8b\x32\x27\xd8\x68\x2c\xc4\xcd\x0d\xb2\x2d\xa3\x0b\x8e\xb7\x0d\xd5\x2d\xca\xdd\x8e\x8d\xba\xb1\xa6\xfe\xff\x6f\xb0\xf1\x4e\x73\x8b\xaa\x34\x30\xfc\xcc\x0e\x2b\x46\xb5\x2d\xcd\x86\x15\xac\x83\xe3\xc6\x3e\xb3\xfa\xeb\x60\x37\x95\x90\x34\x8f\x83\x90\x30\xcd\x93\x30\x8b\xbe\xf0\x51\xe8\xa6\xd9\xfb\x72\x55\xf3\x70\x04\x49\x59\x2e\x93\x05\x32\x19\xcd\x66\x24\x11\x2c\xc4\xd5\xe5\x4b\x57\xa2\xfb\x05\x05\x20\x83\x68\x41\xa0\x65\x48\xf3\x4e\x6c\xf0\xd9\xc5\x7e\x0c\x63\xfd\x5b\x86\xc6\x2a\xbd\xd6\xef\x21\x14\xd7\xec\x59\x0d\xc6\x82\x92\xc9\xf8\xcf\xda\x71\x90\xcc\xf2\x60\x46\xd8\xd5\xbb\x4d\xfb\x5f\x3d\x16\x62\x22\xd3\xf9\xd1\x7f\x34\x3d\x34\xe5\x9d\xde\x14\x97\xe9\xbe\x6a\xea\xb8\x01\x07\xd6\x6f\x70\x89\x5b\x9a\xa6\x92\x90\xcf\x27\x1f\x65\x4c\x28\xa6\x8c\xbe\xd2\x10\xd3\x54\x82\x82\xf0\x0e\x32\xfd\x07\xb4\xa4\x30\xcf\x08\x73\x99\x86\x34\xc9\x25\xe1\xb2\xda\xf0\x6d\x83\xc2\xf4\xe1\x21\xca\xc6\xe2\xf7\x00\x10\x15\xb1\x86\x13\x05\x00\x00")
+
+func _1528395652_discussion_audit_logUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395652_discussion_audit_logUpSql,
+		"1528395652_discussion_audit_log.up.sql",
+	)
+}
+
+func _1528395652_discussion_audit_logUpSql() (*asset, error) {
+	bytes, err := _1528395652_discussion_audit_logUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395652_discussion_audit_log.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x47, 0x1b, 0xf0, 0x8b, 0xf9, 0x93, 0x3a, 0xf2, 0xe3, 0xf0, 0xf1, 0x7c, 0xba, 0x6b, 0xc6, 0xed, 0x58, 0x16, 0xcb, 0xad, 0x8b, 0x3, 0xcb, 0xf7, 0x5, 0xa4, 0x58, 0x81, 0x80, 0x68, 0x5c, 0xe6}}
+	return a, nil
+}
+
+var __1528395653_discussion_thread_subscriptions_notification_levelDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x67\x00\x98\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x73\x75\x62\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6e\x6f\x74\x69\x66\x69\x63\x61\x74\x69\x6f\x6e\x5f\x6c\x65\x76\x65\x6c\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x07\xf0\x9b\x75\x67\x00\x00\x00")
+
+func _1528395653_discussion_thread_subscriptions_notification_levelDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395653_discussion_thread_subscriptions_notification_levelDownSql,
+		"1528395653_discussion_thread_subscriptions_notification_level.down.sql",
+	)
+}
+
+func _1528395653_discussion_thread_subscriptions_notification_levelDownSql() (*asset, error) {
+	bytes, err := _1528395653_discussion_thread_subscriptions_notification_levelDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395653_discussion_thread_subscriptions_notification_level.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x58, 0x54, 0x3a, 0xae, 0xcd, 0x59, 0x51, 0x7d, 0xbf, 0x88, 0x7, 0x60, 0x9d, 0xda, 0xd1, 0xd1, 0xd3, 0x48, 0xec, 0xb8, 0xa7, 0x76, 0x6e, 0x36, 0x9, 0xff, 0xa7, 0x47, 0x5, 0xbd, 0x1e, 0x5c}}
+	return a, nil
+}
+
+var __1528395653_discussion_thread_subscriptions_notification_levelUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcb\x41\x4e\x03\x21\x14\x06\xe0\x3d\xa7\xf8\x77\xb4\x49\x6f\x30\xab\x29\x43\x95\xc8\x30\x89\x65\x12\x77\x04\x29\x5a\x12\x0a\xa6\xef\xd5\x78\x7c\xe3\xac\xdd\x7f\xdf\x51\x3f\x19\x37\x08\x31\x5a\xaf\x5f\xe1\xc7\xa3\xd5\xb8\x14\x4a\x0f\xa2\xd2\x5b\xe0\xeb\x3d\xc7\x4b\xa0\xc7\x3b\xa5\x7b\xf9\xe2\xd2\x1b\x61\x9c\x26\xa8\xc5\xae\xb3\x83\x39\xc1\x2d\x1e\xfa\xcd\x9c\xfd\x19\xad\x73\xf9\x28\x29\xfe\xb1\x50\xf3\x77\xae\xe0\xfc\xc3\x1b\x71\xab\xb5\x98\xf4\x69\x5c\xad\x87\x8c\xb5\x4a\xa8\x67\xad\x5e\xb0\xfb\x67\x19\x87\xdd\x66\x0e\x90\xa9\xdf\x6e\xb9\x31\xc9\x03\x24\x71\xe4\x1c\xd2\x35\xb6\xcf\x4c\x72\xbf\x1f\x84\x50\xcb\x3c\x1b\x3f\x88\xdf\x01\x00\x52\x85\xe5\x63\xc9\x00\x00\x00")
+
+func _1528395653_discussion_thread_subscriptions_notification_levelUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395653_discussion_thread_subscriptions_notification_levelUpSql,
+		"1528395653_discussion_thread_subscriptions_notification_level.up.sql",
+	)
+}
+
+func _1528395653_discussion_thread_subscriptions_notification_levelUpSql() (*asset, error) {
+	bytes, err := _1528395653_discussion_thread_subscriptions_notification_levelUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395653_discussion_thread_subscriptions_notification_level.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4b, 0xe0, 0xba, 0x53, 0xad, 0xf9, 0x7e, 0xe3, 0xc7, 0x16, 0x11, 0x62, 0x7f, 0x8c, 0x90, 0xb2, 0x9a, 0x7c, 0xd, 0x77, 0xcc, 0x86, 0x37, 0x11, 0x84, 0xb, 0xd2, 0xaf, 0xfb, 0x8c, 0x23, 0x5e}}
+	return a, nil
+}
+
+var __1528395654_discussion_moderationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xce\x4b\x0a\x83\x30\x10\xc6\xf1\xfd\x9c\x62\xee\x91\x95\x8f\xb4\x04\x7c\x14\x4d\xc1\xdd\x90\x9a\x50\x03\x35\x96\x4c\x84\x7a\xfb\x42\x71\xe1\xc6\x55\xf7\x33\xbf\xef\x9f\xcb\xab\x6a\x04\x40\x56\x69\xd9\xa1\xce\xf2\x4a\xa2\xf5\x3c\xae\xcc\x7e\x09\x34\x2e\xf3\xec\x42\x62\x2c\xbb\xf6\x86\x45\x5b\xdd\xeb\x06\xd5\x05\xe5\xa0\x7a\xdd\xe3\xe4\xad\x75\x81\x1e\x1b\xad\xec\x22\x79\x2b\xfe\x83\x4c\x12\x00\xbf\x29\xd5\x94\x72\x38\x1c\x1c\xa8\x34\x45\x67\x2c\x53\x74\xef\x25\x26\x26\x13\xa3\xd9\xe8\xe5\xc2\x33\x4d\xe4\xed\xe7\xb4\x61\x7f\x3c\x49\xd8\x39\x01\x50\xb4\x75\xad\xb4\x80\xef\x00\xa7\xfe\xb8\xf3\x1c\x01\x00\x00")
+
+func _1528395654_discussion_moderationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395654_discussion_moderationDownSql,
+		"1528395654_discussion_moderation.down.sql",
+	)
+}
+
+func _1528395654_discussion_moderationDownSql() (*asset, error) {
+	bytes, err := _1528395654_discussion_moderationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395654_discussion_moderation.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3c, 0x9c, 0x88, 0xd0, 0xf0, 0x4b, 0x16, 0x1c, 0xdd, 0x1f, 0x1, 0x60, 0xd, 0x79, 0x22, 0xe0, 0x78, 0xfc, 0xd6, 0x28, 0x28, 0xf3, 0xdb, 0x85, 0xf8, 0x2, 0x1d, 0xa, 0x39, 0xc1, 0x55, 0x39}}
+	return a, nil
+}
+
+var __1528395654_discussion_moderationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x90\xcf\x6a\xc3\x30\x0c\x87\xef\x7e\x0a\xdd\x9a\xc0\x2e\x3b\xe7\x94\x26\xca\x08\x38\x0e\x24\x0e\x14\xc6\x30\x59\x2d\x1a\xc3\xe2\x14\x5b\x65\xed\xc6\xde\x7d\xb4\xeb\x61\xec\xdf\x65\x47\xf1\x93\x3e\xe9\xd3\x1a\xef\x6a\x95\x09\x91\x4b\x8d\x1d\xe8\x7c\x2d\x11\xac\x8b\xdb\x43\x8c\x6e\xf1\x86\xa7\x40\xa3\x8d\x90\x97\x25\x14\xad\x1c\x1a\x05\x75\x05\xaa\xd5\x80\x9b\xba\xd7\x3d\x04\xda\x2f\x81\x23\x30\x1d\xf9\xfe\xe1\x92\xa8\x41\x4a\x28\xb1\xca\x07\xa9\x61\xf5\xfa\xb6\xca\x44\xd1\x61\xae\x11\x6a\x55\xe2\xe6\x0b\xe0\xfb\x32\x73\x65\x9a\x31\x84\xf1\x64\x9e\xc8\xef\x78\x32\xce\x1e\xa1\x55\x3f\xdc\x96\x7c\x6e\x4b\xae\xb3\x37\x70\x9b\xa6\xbf\x6b\x6d\x97\x79\x26\xcf\x7f\x78\x4d\xce\x5a\xf2\x66\x64\x60\x37\x53\xe4\x71\xde\xc3\xb3\xe3\xe9\x52\xc2\xcb\xe2\x29\xfb\x37\xfc\xf1\x64\x0e\x91\x82\x71\x16\x9c\x67\xda\x51\x80\x0e\x2b\xec\x50\x15\xd8\xc3\x39\x8a\x89\xb3\xe9\x59\xbb\x44\x89\x1a\xa1\xc7\x8f\xf7\x66\x42\x14\x6d\xd3\xd4\x3a\x13\xef\x03\x00\x4f\xdf\x65\xe7\xc1\x01\x00\x00")
+
+func _1528395654_discussion_moderationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395654_discussion_moderationUpSql,
+		"1528395654_discussion_moderation.up.sql",
+	)
+}
+
+func _1528395654_discussion_moderationUpSql() (*asset, error) {
+	bytes, err := _1528395654_discussion_moderationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395654_discussion_moderation.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8d, 0x39, 0x67, 0x6d, 0xc2, 0x9a, 0x66, 0xfa, 0x68, 0xd9, 0x29, 0x1e, 0xe2, 0xc9, 0xe9, 0x29, 0x44, 0x30, 0x4e, 0x9b, 0x56, 0x2e, 0xee, 0xf9, 0xc9, 0x2, 0x3f, 0x6d, 0x6c, 0x6b, 0x5, 0x21}}
+	return a, nil
+}
+
+var __1528395655_discussion_thread_readsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3f\x00\xc0\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x72\x65\x61\x64\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x67\x06\x80\x58\x3f\x00\x00\x00")
+
+func _1528395655_discussion_thread_readsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395655_discussion_thread_readsDownSql,
+		"1528395655_discussion_thread_reads.down.sql",
+	)
+}
+
+func _1528395655_discussion_thread_readsDownSql() (*asset, error) {
+	bytes, err := _1528395655_discussion_thread_readsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395655_discussion_thread_reads.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9c, 0x5, 0xae, 0xad, 0xea, 0x1d, 0xea, 0xa6, 0x23, 0x75, 0xc0, 0xae, 0x54, 0x49, 0x28, 0x48, 0xb6, 0xe6, 0xf7, 0x6b, 0x44, 0x32, 0x93, 0x35, 0x83, 0xe, 0x17, 0xf, 0x62, 0xc9, 0x94, 0x7a}}
+	return a, nil
+}
+
+var __1528395655_discussion_thread_readsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x6a\xc3\x30\x10\x44\xef\xfa\x8a\x39\xda\x90\x3f\xf0\x49\xb1\xd7\x45\xd4\x96\x8b\xad\x40\x72\x12\x6e\x25\x92\x85\xc6\x2e\x96\x42\x4a\xbf\xbe\xd8\x8d\xdb\x4b\x5a\x7a\x11\x68\x99\x79\x3b\x3b\x5b\x7a\x50\x3a\x13\x22\x6f\x49\x1a\x82\x91\xdb\x8a\xa0\x4a\xe8\xc6\x80\xf6\xaa\x33\x1d\x1c\x87\x97\x4b\x08\x3c\x0e\x36\x9e\x26\xdf\x3b\x3b\x3f\x01\x89\x00\x80\xdb\x88\x1d\x9e\xf9\xc8\x43\x5c\x9c\x7a\x57\x55\x68\xa9\xa4\x96\x74\x4e\x77\x10\x21\x61\x97\xa2\xd1\x28\xa8\x22\x43\xc8\x65\x97\xcb\x82\x36\x0b\xf2\x12\xfc\x64\xd9\x81\x87\xe8\x8f\x7e\xba\x4b\x9c\x35\x7f\x42\x5e\xfb\x10\x97\xa0\xb6\x8f\x88\x7c\xf6\x21\xf6\xe7\x37\x5c\x39\x9e\x96\x2f\x3e\xc6\xc1\xff\xa0\x0b\x2a\xe5\xae\x32\x18\xc6\x6b\x92\x7e\xc5\x78\x6a\x55\x2d\xdb\x03\x1e\xe9\x80\xe4\xfb\xcc\xcd\x1a\x2f\x15\x69\xb6\xd6\xa6\x74\x41\xfb\xff\xd5\x66\x6f\x76\xcb\xee\x7d\x2e\xe0\x17\x59\xb2\x6e\xc9\x84\xc8\x9b\xba\x56\x26\x13\x9f\x03\x00\x52\xef\x78\x3d\xac\x01\x00\x00")
+
+func _1528395655_discussion_thread_readsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395655_discussion_thread_readsUpSql,
+		"1528395655_discussion_thread_reads.up.sql",
+	)
+}
+
+func _1528395655_discussion_thread_readsUpSql() (*asset, error) {
+	bytes, err := _1528395655_discussion_thread_readsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395655_discussion_thread_reads.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x91, 0x40, 0x73, 0xb5, 0xdb, 0xbf, 0x6e, 0x28, 0x5a, 0xf2, 0x79, 0xaf, 0x91, 0x40, 0x93, 0x5e, 0x79, 0x60, 0xba, 0x80, 0x35, 0xa2, 0x28, 0x32, 0x8, 0xb5, 0xf3, 0x89, 0xcb, 0x7b, 0xa0, 0xbf}}
+	return a, nil
+}
+
+var __1528395656_discussion_saved_repliesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x40\x00\xbf\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x73\x61\x76\x65\x64\x5f\x72\x65\x70\x6c\x69\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x36\x35\x78\x46\x40\x00\x00\x00")
+
+func _1528395656_discussion_saved_repliesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395656_discussion_saved_repliesDownSql,
+		"1528395656_discussion_saved_replies.down.sql",
+	)
+}
+
+func _1528395656_discussion_saved_repliesDownSql() (*asset, error) {
+	bytes, err := _1528395656_discussion_saved_repliesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395656_discussion_saved_replies.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x63, 0xf9, 0xbe, 0xd8, 0xa0, 0x89, 0x22, 0x7b, 0x9a, 0x18, 0x1f, 0xf7, 0xf7, 0x20, 0xf7, 0xaf, 0x84, 0xa5, 0xfd, 0x34, 0x7f, 0xd0, 0xd7, 0x5f, 0xf4, 0x9, 0xa, 0x90, 0xc, 0xa7, 0xaa, 0xed}}
+	return a, nil
+}
+
+var __1528395656_discussion_saved_repliesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xd1\x6a\xc2\x30\x18\x85\xef\xf3\x14\xe7\xb2\x05\xdf\xa0\x57\xb5\xfd\x1d\x61\x35\x6e\x6d\x0a\x7a\x15\x32\xf3\xe3\x02\x9a\x4a\x13\xa7\xec\xe9\x87\xd5\x79\xb5\xc1\xd8\x65\x72\xbe\x73\x48\xbe\x39\x3d\x49\x55\x08\x51\xb5\x54\x6a\x82\x2e\xe7\x0d\x41\x2e\xa0\x56\x1a\xb4\x96\x9d\xee\xe0\x7c\xdc\x9e\x62\xf4\x43\x30\xd1\x7e\xb0\x33\x23\x1f\xf7\x9e\x23\x32\x01\x00\xde\xe1\xcd\xef\x22\x8f\xde\xee\xf1\xd2\xca\x65\xd9\x6e\xf0\x4c\x9b\xd9\x94\x9e\x22\x8f\xc6\x3b\xf8\x90\x78\xc7\xe3\xb4\xab\xfa\xa6\x41\x4b\x0b\x6a\x49\x55\xd4\x4d\x4c\xcc\xbc\xcb\xb1\x52\xa8\xa9\x21\x4d\xa8\xca\xae\x2a\x6b\xba\x8d\x04\x7b\x60\x24\xbe\xa4\x47\xfd\x76\xbf\x1d\x42\xe2\x90\xe2\x8f\xd9\xc8\x36\xb1\x33\x36\x21\xf9\x03\xc7\x64\x0f\x47\x9c\x7d\x7a\x9f\x8e\xf8\x1c\x02\x3f\x1a\xa8\x69\x51\xf6\x8d\x46\x18\xce\x59\x7e\x7f\xf8\xd1\xfd\xb3\x2f\xf2\xe2\x5b\x67\xaf\xe4\x6b\x4f\x90\xaa\xa6\xf5\x1f\xad\x9a\xbb\x31\x73\xfd\xb4\xf1\xee\x72\x95\xf2\x1b\x9c\xdd\xe1\x19\xae\x74\x5e\x08\x51\xad\x96\x4b\xa9\x0b\xf1\x35\x00\x25\x0c\xc5\xd4\xd8\x01\x00\x00")
+
+func _1528395656_discussion_saved_repliesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395656_discussion_saved_repliesUpSql,
+		"1528395656_discussion_saved_replies.up.sql",
+	)
+}
+
+func _1528395656_discussion_saved_repliesUpSql() (*asset, error) {
+	bytes, err := _1528395656_discussion_saved_repliesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395656_discussion_saved_replies.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0x25, 0xb2, 0x8b, 0x25, 0x35, 0x3f, 0xb, 0xd3, 0xeb, 0xce, 0xd0, 0x9a, 0xeb, 0x7c, 0xf9, 0xe7, 0xa6, 0x7e, 0x89, 0xf0, 0x6c, 0xc8, 0x37, 0x8d, 0x2e, 0x2f, 0xc4, 0xac, 0xc3, 0x5, 0x14}}
+	return a, nil
+}
+
+var __1528395657_discussion_thread_dependenciesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x64\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xe0\x36\x90\xae\x46\x00\x00\x00")
+
+func _1528395657_discussion_thread_dependenciesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395657_discussion_thread_dependenciesDownSql,
+		"1528395657_discussion_thread_dependencies.down.sql",
+	)
+}
+
+func _1528395657_discussion_thread_dependenciesDownSql() (*asset, error) {
+	bytes, err := _1528395657_discussion_thread_dependenciesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395657_discussion_thread_dependencies.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2e, 0xe2, 0xe9, 0xa5, 0x63, 0xfa, 0x58, 0x99, 0x15, 0x2b, 0xf, 0xe2, 0xb5, 0xff, 0xbf, 0x3c, 0x88, 0xd9, 0x19, 0xa3, 0x18, 0xe7, 0x45, 0x0, 0x23, 0x39, 0xc7, 0x22, 0x2e, 0xb, 0x6d, 0xd1}}
+	return a, nil
+}
+
+var __1528395657_discussion_thread_dependenciesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x91\xcd\x6a\xeb\x30\x14\x84\xf7\x7a\x8a\x59\x3a\x90\x37\xf0\xe5\x82\x22\x9f\xb4\x22\x8e\x5c\x6c\x05\x92\x95\x70\x2c\xb5\x11\x4d\xe4\x10\xa9\xa4\xed\xd3\x97\xba\xe9\xcf\xc2\xb4\x74\xd1\xe5\xe1\x30\xdf\x0c\x33\x33\xba\x92\x2a\x67\x4c\xd4\xc4\x35\x41\xf3\x59\x49\x90\x73\xa8\x4a\x83\xd6\xb2\xd1\x0d\xac\x8f\xdd\x43\x8c\xbe\x0f\x26\xed\x4e\xae\xb5\xc6\xba\xa3\x0b\xd6\x85\xce\xbb\x88\x8c\x01\xc0\xe5\xe3\x2d\xb6\xfe\xce\x87\x34\x00\xd4\xaa\x2c\x51\xd3\x9c\x6a\x52\x82\x46\x48\x31\xf3\x76\x82\x4a\xa1\xa0\x92\x34\x41\xf0\x46\xf0\x82\xa6\x03\x72\xbb\xef\xbb\x7b\x67\xcd\xf6\xc9\xfc\x01\xbd\x3b\xb9\x36\x39\x6b\xda\x84\xe4\x0f\x2e\xa6\xf6\x70\xc4\xd9\xa7\xdd\x70\xe2\xb9\x0f\xee\xd3\xa5\xa0\x39\x5f\x95\x1a\xa1\x3f\x67\x93\x37\xfd\x4d\x2d\x97\xbc\xde\x60\x41\x1b\x64\x1f\xf9\xa6\xa3\xa9\x2f\x12\x51\xa9\x46\xd7\x5c\x2a\xfd\x43\xa7\x26\xf4\xc9\x44\xb7\xbf\x85\xb8\x26\xb1\xf8\xc2\xc7\xbf\xff\xe3\x0e\x6c\x92\xbf\x6f\x28\x55\x41\xeb\x5f\x6d\x68\xc6\x90\xc6\xdb\xc7\xd7\xf2\xbe\x97\x66\xa3\x69\x72\xc6\x44\xb5\x5c\x4a\x9d\xb3\x97\x01\x00\x0d\x70\x5c\xbb\x61\x02\x00\x00")
+
+func _1528395657_discussion_thread_dependenciesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395657_discussion_thread_dependenciesUpSql,
+		"1528395657_discussion_thread_dependencies.up.sql",
+	)
+}
+
+func _1528395657_discussion_thread_dependenciesUpSql() (*asset, error) {
+	bytes, err := _1528395657_discussion_thread_dependenciesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395657_discussion_thread_dependencies.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xab, 0xae, 0xbe, 0x8d, 0x1f, 0xcb, 0x31, 0x49, 0xab, 0x5c, 0x9a, 0x9, 0x97, 0x3b, 0x82, 0xba, 0xd5, 0x1a, 0xe7, 0xf3, 0x82, 0xac, 0x72, 0x7d, 0x10, 0x51, 0xc2, 0xc2, 0x6f, 0xf4, 0xfa, 0x34}}
+	return a, nil
+}
+
+var __1528395658_discussion_threads_countsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\x41\x6a\xc4\x20\x14\x86\xf7\x9e\xe2\x2d\xdb\x33\xb8\x9a\xc9\x98\x20\x24\x5a\x8c\x81\xec\x1e\x41\xa5\x75\x11\x2d\xd1\x40\x8f\x5f\xa8\x04\x52\x48\x0b\xce\x01\xde\xf7\x7d\xff\xbb\xb3\x8e\x0b\x4a\xc8\x43\xc9\x37\xd0\x8a\x77\x1d\x53\xc0\x5b\x60\x33\x1f\xf5\x08\x79\xf3\xef\x68\x7d\x32\x7b\x4a\x3e\x06\x34\x71\x5d\x5d\xc8\xb8\xb9\xc5\x64\x1f\x43\xc2\xfd\xd3\x2e\xd9\x61\xfe\xd8\xdc\x62\xd1\xc4\x3d\xe4\x04\x52\xc0\x7f\x47\xb4\xe8\xda\x49\x34\x9a\x4b\x71\xf2\x55\xab\x5e\x5e\x69\x65\x7b\x4d\x72\x55\xe9\xdf\x81\x05\xc2\xc5\x83\xcd\xd7\x84\x32\x29\x1d\xa4\xb2\x0d\xbd\x45\x6f\xbf\x28\xb9\xf5\x9a\x29\xd0\xb7\x7b\xcf\x2e\x8e\xe0\x07\xde\xc8\x7e\x1a\xce\x7d\xc7\xdb\x0a\xeb\x49\xc8\xaf\x1e\x4a\x48\x23\x87\x81\x6b\x4a\xbe\x07\x00\x85\x2a\x43\x2a\x36\x02\x00\x00")
+
+func _1528395658_discussion_threads_countsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395658_discussion_threads_countsDownSql,
+		"1528395658_discussion_threads_counts.down.sql",
+	)
+}
+
+func _1528395658_discussion_threads_countsDownSql() (*asset, error) {
+	bytes, err := _1528395658_discussion_threads_countsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395658_discussion_threads_counts.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb5, 0x25, 0x80, 0x94, 0x9, 0x3d, 0x8e, 0xac, 0x4a, 0x4c, 0x96, 0xa8, 0x25, 0x3b, 0x23, 0x52, 0x88, 0xba, 0xc7, 0x0, 0x27, 0x76, 0x3d, 0x81, 0x46, 0xa3, 0x1d, 0x7c, 0xb1, 0xbf, 0xa6, 0xfb}}
+	return a, nil
+}
+
+var __1528395658_discussion_threads_countsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\xd1\x6e\xea\x38\x10\x7d\xf7\x57\x9c\x95\xae\x54\xb8\x5b\xd0\xed\x6b\x11\x0f\x69\x62\x68\x56\xa9\x5d\x05\xa3\xf6\x2d\x4a\x13\xb7\x8d\x44\x93\x6e\x6c\xb6\xb7\x7f\xbf\xb2\x93\x90\x00\xa1\xc0\xed\x1b\x98\x99\x33\x67\x3c\x73\x0e\xbe\xa1\x73\x9f\x4d\x08\x71\x02\x41\x43\x08\xe7\x26\xa0\x48\x33\x95\xac\x95\xca\x8a\x3c\xd2\xaf\xa5\x8c\x53\x05\xc7\xf3\xe0\xf2\x60\x79\xc7\xe0\xcf\xc0\xb8\x00\x7d\xf4\x17\x62\x81\xa4\x78\x7b\x93\xb9\x8e\x92\x62\x9d\x6b\x64\xb9\x96\x2f\xb2\xb4\x01\x6c\x19\x04\xf0\xe8\xcc\x59\x06\x02\xbf\x26\x7f\x5c\xa1\x94\x71\xa2\x4d\xe4\xf1\x12\x64\x79\xef\x39\xa2\x17\x5e\x63\x41\x05\xc1\x0e\xdf\x29\x06\x0b\x1a\x50\x57\xc0\xe5\x4b\x26\x06\x3f\x87\x98\x85\xfc\xae\x0b\x50\x27\x28\x24\x78\xb8\xa5\x21\x45\x32\xae\x28\x47\x59\x8a\x29\xf4\x38\x4b\xe1\x30\x0f\xc9\x38\x95\x2b\xa9\x65\x1a\xc5\x1a\xfe\xc2\x72\x1b\x5e\x12\xec\xf2\x9f\x62\x40\x00\xe0\xc4\xba\x51\x93\xae\x50\xda\xbc\x7f\xb8\xcf\x0e\xf0\xe3\x0c\x89\xa1\x33\x45\x39\x6e\xd2\xb3\xd4\x66\x9d\x4d\x9d\x00\xc3\x09\x21\x6e\x48\xcd\x85\xfa\xcc\xa3\x8f\x3b\x73\xd9\xbf\xe4\x0d\x67\x3b\xa9\x28\x4b\xa3\x2c\xfd\x0d\xce\x7a\x42\x07\x5b\xa1\x97\xc8\x52\x53\x6d\x34\xda\xdd\x27\x05\xfd\x2a\x91\xaf\xdf\x9e\x64\x89\xe2\xd9\x7e\xab\x7a\xb8\x50\x18\xe4\x45\x3e\xaa\xa9\x0f\x9b\x4c\x75\x89\x38\x4f\xc9\x68\xb4\x7b\xf1\x7b\x58\xed\xcd\xea\x02\xfa\xb5\x50\x72\x83\x31\x26\xa3\x91\x81\x10\xaf\xed\x19\x74\x99\xbd\x98\xcd\x2b\xd7\xb9\xc2\x0d\x9d\xf1\x90\xc2\xa3\x01\x15\x14\xca\x00\xc4\xda\x16\x68\x61\x8b\x67\xc4\xa8\xf9\x75\x7a\x43\x12\xe7\x50\x3a\x5b\xad\xf0\x64\xe0\xd7\xb9\x96\x29\x06\xfa\x55\x7e\x22\x2e\x65\x93\x81\xa7\x4f\x8b\x97\xc4\x2a\x89\x53\x89\xf8\x59\xcb\xd2\x9c\x74\xa0\x86\xe3\x66\x42\x3c\x44\x48\xef\x03\xc7\xa5\x98\x2d\x99\x2b\xfc\xed\x6b\xaf\xe3\x55\xb4\x7e\x4f\x63\x2d\xeb\x31\x54\xb7\xac\x06\x43\x84\x54\x2c\x43\xb6\x80\x08\xfd\xf9\x9c\x86\x70\x16\xe4\xc7\xa9\xe9\x3f\x88\x47\xdd\xc0\x09\x29\x81\xe1\xae\xe3\x8d\x46\xaf\xa7\x46\x95\xad\xe8\xca\xe2\xa3\x8f\xd4\x84\x58\x17\x22\x30\x1b\x36\x10\xf3\x88\xdf\x63\x8a\x0b\x9f\x2d\x68\x28\x2e\x86\x10\xb7\x94\xd9\x2d\xee\xe2\x5c\x4f\xc1\xe8\x83\x41\xaf\xd2\x18\x7d\xe8\x53\x60\x9b\xdc\x90\xbb\x9e\xe2\xaa\x4a\xa3\xcc\x83\x3f\x33\x9f\x69\xb0\xd8\x2a\x5d\x8d\xf5\x8b\xd2\x3c\xf0\xda\xd2\x3c\xf0\x4e\x2e\x3d\xea\xab\x4d\x8f\x76\xd7\x5f\xc2\xaa\xb7\xa7\x71\x2e\x4e\x61\x10\x2c\x0e\x20\x73\xf1\x25\xfa\x41\xe4\xfd\xd6\xea\x4f\xf5\x64\xab\xc0\xbf\xa6\xf8\xd5\xc9\x3f\x6c\xd9\x95\x61\x03\xfb\xa6\xbd\xfd\xfd\xef\x6a\xb0\x97\x75\x70\xa3\xbf\x4d\xf4\xce\x41\x1d\x8e\x9f\x18\x9c\xef\xc1\xb5\x8f\xd6\xe7\x59\xda\x21\x53\x16\x1f\xe3\x2c\x1d\x76\xec\x76\xef\xe7\x5a\x36\x59\xda\x73\x39\x5f\xec\x5e\x25\xce\x66\xe7\x9a\xbc\xcd\xb9\x95\x01\x65\xde\xe4\x1c\xc9\x06\x0e\x9b\x2f\x9d\x39\xc5\xfb\xea\xfd\x45\xfd\xbb\x6a\xbd\xbe\xb1\x00\x63\x77\xd1\xa9\x80\xa4\xf6\xc3\x4a\xb3\xe0\x61\x33\x57\x3e\x6b\xfc\xcc\x48\x83\x87\x8d\x63\xf6\xdb\x13\x99\xf1\x10\xd4\x71\x6f\x11\xf2\x07\xd0\x47\xea\x2e\x05\xc5\x7d\xc8\x5d\xea\x2d\x43\xda\x97\xd2\x4b\x67\x50\xff\x9b\x84\x9b\xc1\xe9\x62\xe3\xab\x4d\xa6\x35\xdb\xbc\xd0\xad\x05\x2b\x29\x11\x3f\x15\xff\xc9\xe1\xd8\x5a\x7f\xd7\xf1\x8d\xeb\x3a\x33\xf3\x48\xaa\x7b\x6c\x3c\xbf\x5d\x0e\xfb\x17\x10\xaf\x0c\x8f\x4f\xc8\xdf\x99\xb2\xbe\xce\x19\x5c\xce\x66\x81\xef\x0a\x78\xdc\xc0\x30\x2e\x6e\x7d\x36\x1f\xee\x11\xd0\x1f\x59\x22\xcf\xb4\xf4\x76\x39\xbf\xe7\xed\x47\x70\x7e\x9c\xea\xd1\x87\xf5\x6c\x9f\x60\x27\xc8\xf3\xca\x2e\xfd\x01\x2d\x2a\x24\x5b\x0f\x1a\xab\x31\x63\x52\x4d\x1b\x5f\xbc\x69\xec\x0f\xba\x7a\x21\x75\x9e\x42\x93\xae\xc8\x6a\xd7\xed\x88\xf3\xbb\x0d\x8d\x70\x45\x8e\xb4\xb3\xd5\x8c\x31\xe4\xef\x35\x53\xb7\x62\xfd\xe2\x90\x2f\x1c\x1d\xf7\x1f\x1b\xc4\x11\x64\xb2\x25\xa2\x23\x86\xd0\x62\x9d\xef\x0c\x47\x78\x58\x8b\x70\xf9\xdd\x9d\x2f\x26\xe4\xff\x01\x00\x1d\x8c\x26\x6e\x06\x0d\x00\x00")
+
+func _1528395658_discussion_threads_countsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395658_discussion_threads_countsUpSql,
+		"1528395658_discussion_threads_counts.up.sql",
+	)
+}
+
+func _1528395658_discussion_threads_countsUpSql() (*asset, error) {
+	bytes, err := _1528395658_discussion_threads_countsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395658_discussion_threads_counts.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb5, 0xb7, 0x9b, 0xc1, 0xa1, 0xf3, 0x1a, 0x95, 0xc5, 0xdd, 0x98, 0x64, 0xf8, 0x9f, 0x0, 0x73, 0xa9, 0xa7, 0xe7, 0xe7, 0x10, 0xfa, 0x9b, 0x50, 0x9f, 0xb9, 0x50, 0x46, 0xe3, 0xcc, 0x2c, 0x7}}
+	return a, nil
+}
+
+var __1528395659_discussion_threads_order_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xc9\x2c\x4e\x2e\x2d\x2e\xce\xcc\xcf\x8b\x2f\xc9\x28\x4a\x4d\x4c\x29\x8e\x2f\xc9\x2c\xc9\x49\x8d\xcf\x4c\x89\xcf\x4c\xa9\xb0\x26\x5a\x57\x72\x51\x6a\x62\x49\x6a\x4a\x7c\x62\x09\x5c\x2b\x97\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x60\x00\xdc\x85\xc5\x3d\x82\x00\x00\x00")
+
+func _1528395659_discussion_threads_order_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395659_discussion_threads_order_indexesDownSql,
+		"1528395659_discussion_threads_order_indexes.down.sql",
+	)
+}
+
+func _1528395659_discussion_threads_order_indexesDownSql() (*asset, error) {
+	bytes, err := _1528395659_discussion_threads_order_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395659_discussion_threads_order_indexes.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0xe2, 0xf9, 0xa, 0x0, 0xb8, 0x25, 0x17, 0x1b, 0xd5, 0xaf, 0x7f, 0x3f, 0xe0, 0xfc, 0x35, 0xeb, 0x36, 0x61, 0xde, 0xe3, 0xa9, 0x4c, 0xb8, 0xed, 0x25, 0x54, 0x53, 0x26, 0x4b, 0x89, 0x4a}}
+	return a, nil
+}
+
+var __1528395659_discussion_threads_order_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xce\xc1\x4a\xc4\x30\x10\xc6\xf1\x7b\x9e\xe2\x3b\x76\xc1\xdd\x17\xe8\x49\xbb\x51\x02\x6e\x0b\x6e\x0e\x7b\x0b\xa1\x33\xba\x03\x6d\x22\x49\x0a\xf5\xed\x65\x6b\xd0\x8b\x08\x5e\x87\x8f\xff\x6f\x1e\xf4\x93\xe9\x5b\xa5\xf6\x7b\x98\x40\xbc\x72\xc6\x6b\x4c\x88\x89\x38\x49\x78\x43\xb9\x26\xf6\x94\xd1\x64\x66\x1c\x25\x8f\x4b\xce\x12\x83\xfd\x3a\x3f\x4b\x2e\x5d\x9c\x96\x39\xec\x0e\xb0\x57\xbe\x65\x9a\xe5\x9d\x7c\x61\x72\xbe\xdc\x41\x68\x07\x1f\x08\xcd\x18\xe7\x99\x43\x71\x63\x5c\x42\xbd\x4b\xf5\xfc\x74\x6b\x7d\x80\x57\xc9\xe5\xa0\xba\x17\x7d\x6f\x35\x4c\x7f\xd4\x17\x98\x47\xf4\x83\x85\xbe\x98\xb3\x3d\x83\xbe\x7d\x57\xff\x72\x63\xe2\x8a\x39\x21\x27\xb4\x62\xe8\x7f\xd9\x35\x3f\xbb\x0d\x6f\xff\xc9\x14\x29\x13\xff\x2d\x6c\x93\x1a\x57\xdd\x70\x3a\x19\xdb\xaa\xcf\x01\x00\xac\x9b\x1f\x77\x5f\x01\x00\x00")
+
+func _1528395659_discussion_threads_order_indexesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395659_discussion_threads_order_indexesUpSql,
+		"1528395659_discussion_threads_order_indexes.up.sql",
+	)
+}
+
+func _1528395659_discussion_threads_order_indexesUpSql() (*asset, error) {
+	bytes, err := _1528395659_discussion_threads_order_indexesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395659_discussion_threads_order_indexes.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4d, 0x2d, 0x9f, 0x9a, 0xc7, 0x8b, 0x18, 0x2b, 0x7c, 0xcb, 0xc5, 0xb1, 0x7c, 0x2b, 0x15, 0x49, 0xe8, 0x8d, 0xdd, 0x69, 0xf8, 0xeb, 0x9f, 0x4f, 0xfe, 0x5f, 0x47, 0x6e, 0xce, 0xe5, 0x8c, 0x55}}
+	return a, nil
+}
+
+var __1528395660_discussion_threads_idempotency_keyDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcd\x4b\xca\xc3\x20\x10\x00\xe0\xfd\x9c\x62\xee\xe1\x2a\x0f\xff\x1f\xc1\x47\x49\x2c\x64\x37\x84\x28\x44\x4a\x63\x71\x14\x9a\xdb\x17\xba\x2a\xa5\x17\xf8\xbe\x5e\xfe\x2b\x2b\x00\xc6\xc9\x5d\x50\xd9\x51\x2e\xa8\xfe\x50\x2e\x6a\xf6\x33\x86\xc4\x5b\x63\x4e\xf9\xa0\xba\x97\xb8\x06\xa6\xb5\xd5\x3d\x17\x6a\x1c\x0b\xa5\x40\x29\xc4\xfb\x23\xd7\x78\x6c\x27\xdd\xe2\x49\x29\x3c\x05\x74\xda\xcb\x09\x7d\xd7\x6b\xf9\x83\xc0\x77\x35\x38\x7d\x35\xf6\xe3\xfa\x92\x04\xc0\xe0\x8c\x51\x5e\xc0\x6b\x00\x1e\x8f\xc7\x91\xa3\x00\x00\x00")
+
+func _1528395660_discussion_threads_idempotency_keyDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395660_discussion_threads_idempotency_keyDownSql,
+		"1528395660_discussion_threads_idempotency_key.down.sql",
+	)
+}
+
+func _1528395660_discussion_threads_idempotency_keyDownSql() (*asset, error) {
+	bytes, err := _1528395660_discussion_threads_idempotency_keyDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395660_discussion_threads_idempotency_key.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x30, 0x77, 0xf2, 0x64, 0xfd, 0x23, 0x99, 0xd5, 0xc1, 0x67, 0xf3, 0x3, 0x76, 0x42, 0xef, 0x92, 0xbc, 0xdf, 0xc3, 0x58, 0x93, 0x43, 0xe0, 0xc6, 0x17, 0x78, 0xb4, 0xd9, 0x59, 0x95, 0x2b, 0xef}}
+	return a, nil
+}
+
+var __1528395660_discussion_threads_idempotency_keyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\xc1\x6e\xc2\x30\x0c\x86\xef\x79\x0a\x1f\x41\x82\xbd\x40\x4f\x85\x66\x5b\xa4\x92\x6a\x90\x0a\x6e\x51\xd6\x78\xd4\x1a\x4b\x59\xe2\x6e\xf0\xf6\x53\xcb\xb4\x69\xb0\xab\xed\xef\xf3\xff\x2f\xe4\x83\xd2\x99\x10\xf3\x39\x98\x16\xe1\x15\xcf\xb0\xa7\x0f\x0c\xf0\x7c\x06\x6e\x11\x9a\x03\x61\x60\xe0\xd6\x31\x34\x11\x1d\xa3\x1f\xe7\xdc\x46\x74\x7e\x06\xa9\xbb\xec\x22\x72\x24\xf4\x83\x27\xe2\x7b\x8f\x89\x13\x44\xe4\x3e\x86\xf1\x1c\x4f\x94\x98\xc2\xfe\x9b\x03\x0a\x89\xd1\x79\xe8\x5e\x2e\xd6\x61\xe5\xc0\xf7\xc7\x03\x35\x8e\x11\x26\x09\x71\x70\x15\x94\x9a\x3e\x25\xea\x82\x19\x41\xe5\xf1\xed\xd8\x31\x86\xe6\xbc\xa5\xe0\xbb\xcf\xe9\x9d\xc8\x4b\x23\xd7\x60\xf2\x45\x29\xc1\xff\xdc\xdb\xcb\xa7\x04\x79\x51\xc0\xb2\x2a\xeb\x95\x06\x75\x0f\xba\x32\x20\x77\x6a\x63\x36\x40\xbf\x2e\x3b\xf4\x66\x3c\x71\x26\x96\x6b\x99\x1b\x09\xb5\x56\x4f\xb5\x04\xa5\x0b\xb9\xbb\xe2\x6e\x7f\x58\xd7\x73\xdb\x45\xdb\x27\x8c\x96\xbc\xbd\x32\x5b\xf2\x27\xa8\xf4\x3f\xe1\x26\x7f\xc1\xd9\x75\xa6\x29\x6c\x1f\xe5\x5a\xde\x44\x55\x9b\xb1\x88\xae\xcb\x32\x13\x62\x59\xad\x56\xca\x64\xe2\x6b\x00\xa3\x33\xe9\x0d\xcc\x01\x00\x00")
+
+func _1528395660_discussion_threads_idempotency_keyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395660_discussion_threads_idempotency_keyUpSql,
+		"1528395660_discussion_threads_idempotency_key.up.sql",
+	)
+}
+
+func _1528395660_discussion_threads_idempotency_keyUpSql() (*asset, error) {
+	bytes, err := _1528395660_discussion_threads_idempotency_keyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395660_discussion_threads_idempotency_key.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9b, 0x8d, 0x63, 0x9a, 0xf0, 0x6b, 0x79, 0x7, 0x80, 0x12, 0xac, 0xd0, 0x3a, 0x62, 0xb0, 0x27, 0x39, 0x72, 0xa1, 0xef, 0xb2, 0x78, 0x30, 0x8f, 0x29, 0xf6, 0x58, 0x66, 0x50, 0xb3, 0x19, 0x9a}}
+	return a, nil
+}
+
+var __1528395661_discussion_thread_numbersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xbd\x0a\x83\x30\x14\x46\xf7\x3c\x45\xc6\xf6\x19\x32\xf9\x13\xe5\x82\x26\x25\x46\x70\xbb\xd8\x1a\x6c\x86\x6a\x49\x22\xf4\xf1\x0b\x9a\x82\x43\x11\xf7\x7b\xce\xb9\x5f\xca\x4b\x10\x8c\x90\x5c\xc9\x1b\xd5\x0a\xca\x92\x2b\x0a\x05\xe5\x1d\x34\xba\xa1\xc1\xd9\x11\x07\xeb\x1f\x8b\xf7\x76\x9e\x30\x3c\x9d\xe9\x07\x8f\xa1\x77\xa3\x09\xe8\xcc\x7b\x46\x6f\x02\x4e\xcb\xeb\x6e\x1c\x95\x82\x1e\x1f\xb3\x2d\x54\xb4\x22\xd3\x20\xc5\xae\x74\x3a\x72\xb9\x46\x89\x4e\xd2\x8a\xff\x37\xac\xc8\xf6\x6b\xa4\x7c\x84\x40\xe4\xbc\x3b\x9f\x5d\x45\xf6\x27\x41\x3b\x7c\x18\x49\x2a\xcd\x55\xac\x1f\xe3\x74\x4d\x66\xb2\x6a\xeb\xfd\xd4\x4d\xc6\x08\xc9\x64\x5d\x83\x66\xe4\x3b\x00\x44\xa7\x02\x4d\x85\x01\x00\x00")
+
+func _1528395661_discussion_thread_numbersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395661_discussion_thread_numbersDownSql,
+		"1528395661_discussion_thread_numbers.down.sql",
+	)
+}
+
+func _1528395661_discussion_thread_numbersDownSql() (*asset, error) {
+	bytes, err := _1528395661_discussion_thread_numbersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395661_discussion_thread_numbers.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xff, 0xf1, 0x86, 0x4f, 0xe7, 0x6b, 0xa9, 0x89, 0x5d, 0x47, 0xcd, 0x83, 0x1a, 0x90, 0x66, 0xaf, 0x69, 0x7e, 0x5c, 0xc, 0xe6, 0x8f, 0xea, 0x7e, 0xb8, 0x83, 0x73, 0xb7, 0x79, 0xb0, 0xa7, 0x91}}
+	return a, nil
+}
+
+var __1528395661_discussion_thread_numbersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x55\xc1\x6e\xe3\x36\x14\x3c\x9b\x5f\x31\x87\x3d\xc8\xa8\xd7\x40\xce\x42\x0e\x8a\xf4\xec\x08\x55\x28\x97\x92\x36\xc9\xc9\x50\x22\xc6\x26\x6a\x4b\x29\x49\x37\x9b\xbf\x2f\x28\x51\x8e\x53\xb4\x71\x82\xbd\xd1\xe6\xe3\xbc\xe1\xcc\xf0\xe9\x8a\x96\x29\x0f\x19\xfb\xfe\x1d\xe5\x56\xcb\xba\x31\xa8\xb5\x44\x7b\xd8\x3f\x48\x2d\x1b\x3c\x4b\x0d\x2d\x9f\x3b\xa3\x6c\xa7\x5f\x11\xec\xd4\x9f\x12\xca\x98\xc3\x58\x63\xa6\x33\x18\x5b\x6b\xab\xda\x0d\x6a\x8b\x8b\x39\x8b\xb2\x92\x04\xca\xe8\x2a\x23\x34\xca\x3c\x1e\x8c\x51\x5d\xbb\xb6\x03\xfe\xda\xd6\x7a\x23\xed\xda\xa1\x22\x4a\x12\xc4\x79\x56\xdd\x70\xa4\x0b\xf0\xbc\x04\xdd\xa5\x45\x59\x78\x70\xa8\xd6\xca\x8d\xd4\x21\xab\x56\x49\x54\x9e\x85\xb3\x1a\x05\x95\xfe\xf0\x65\x3b\x1f\x16\x6c\xb2\x10\xf9\x0d\x82\x82\x32\x8a\x4b\xa8\x66\x06\xdd\xbd\xac\x87\xcd\x60\x8a\xfc\x07\x09\x04\xab\x48\x94\x69\x99\xe6\x1c\x57\xf7\xfd\x95\xd7\xaa\x41\x2e\x12\x12\xee\x8f\x81\xfc\x5a\x35\x53\x44\x47\x76\x3d\xec\xc7\x94\xa6\x68\xd9\xe4\xf6\x9a\x04\xa1\x9d\xab\xe6\xd2\xea\xb9\x6a\x10\xf1\x04\x56\x7b\x7a\x48\x0b\xf0\x2a\xcb\x46\x17\x24\x76\xb5\xb1\xbe\xe3\xd8\xaa\x36\x46\x6d\x5a\xd9\x40\xb5\x90\xf5\xe3\xf6\xc4\x94\x39\x78\x5f\xe3\x9d\xeb\x2c\xb4\x3c\x18\xd9\xcc\x1c\x9c\xfc\x5b\xb6\x78\xd9\xca\xd6\xe3\x0d\x45\x8d\xdc\x49\x2b\x1b\x74\x1a\x56\xd7\xad\x79\x92\xda\x99\x6d\x3b\xd4\x6d\x67\xb7\xef\x3c\x9f\xb3\x58\x90\xd3\x7e\x30\xf4\xbd\x4d\x27\x97\x77\x84\xbc\x02\x5e\x5a\x83\x80\x4d\x46\x25\xbd\x93\x58\x89\xf4\x26\x12\xf7\xf8\x9d\xee\x21\x68\x41\x82\x78\x4c\x45\xdf\x2f\x70\xea\xe6\x1c\x09\x65\x54\x12\xe2\xa8\x88\xa3\x84\x66\x6c\xe2\xf4\x58\xbf\x4f\x44\x4f\xc2\xa9\xc6\xa6\x21\x4b\x79\x41\xa2\x44\xca\xcb\xfc\x0c\xa3\xc0\xd3\x99\xe1\x04\x73\xca\x26\x3e\x1a\xc7\xdd\x7d\xfd\x33\xf0\x9b\x9f\x71\x19\x4b\x91\x57\xab\x93\xe0\xb0\x49\xce\x11\xe7\x7c\x91\xa5\x71\x89\x24\x77\x74\xaf\x53\xbe\x1c\x3c\x4e\x0e\xba\x7f\x2d\xd0\xdd\x6e\xe7\x56\x8d\x7c\xde\x75\xaf\x33\x3c\xe9\xae\xb5\xb2\x6d\x8c\x5b\xed\xf1\x20\x9f\x3a\x2d\x61\xb7\xca\x60\xaf\x36\xba\xb6\xaa\x6b\x61\xac\xda\xed\xf0\xa8\x65\x6d\xa5\x43\x1b\x8d\x7d\x51\x76\xdb\x1d\x2c\x6a\x9f\x99\x31\x17\xb0\x5b\xb9\x47\xd7\x42\xb5\x46\x6a\x3b\x73\xbf\x61\xea\xbd\xc4\x4b\xfd\xda\xff\x18\xdb\x3a\xb4\xa6\x93\x66\x06\xd3\xc1\x6e\x6b\x3b\xa6\xef\xb1\x6e\xf1\x20\x8f\x9a\x43\xab\xcd\xd6\xa2\x7e\xa9\xdf\xd2\x91\x0b\x08\x5a\x65\x51\x4c\x58\x54\x3c\xee\x1f\xd2\xc7\xa2\xad\x8d\x1c\x1d\x08\xa6\x10\x54\x56\x82\x17\x28\x45\xba\x5c\x92\x40\x54\xb0\x6f\x9f\x3e\xff\x8d\xf5\x73\x8c\xa1\x9f\x22\x74\xfb\xaf\x97\x85\xf2\x9a\x38\x03\x80\x5f\x4d\x0a\x7e\x44\x59\x45\x05\x02\x4e\xb7\xf3\x63\xc5\xc5\xb4\x07\x07\x4e\x4d\x1f\x01\xa6\xce\x7e\x3f\xbe\xdc\x70\x3a\x81\xbb\xfc\x98\xc2\xfc\xa4\xf4\xb7\x0b\xdf\x62\xd0\x29\xe5\xcb\x53\xa0\xe1\x42\x6f\x17\x0f\x19\x40\x3c\x41\xba\x70\xab\xe1\x08\x38\xdd\x86\x8c\x78\x12\x7e\x49\xd8\x2c\xe2\xcb\x2a\x5a\x12\x9e\x77\xcf\x1b\xf3\xd7\x2e\x64\x2c\x11\xf9\xea\xe8\x53\xba\x18\x67\x81\xd5\x6a\xb3\xfe\x34\x32\xce\xe6\x23\x3c\x0e\x1e\xdf\xea\x6b\x0d\xd8\x15\x2d\x72\x41\xa3\xe5\x67\xdb\xb1\x45\x2e\x40\x51\x7c\x0d\x91\xdf\x82\xee\x28\xae\x4a\xc2\x4a\xe4\x31\x25\x95\x20\x7c\xba\x71\x30\x0d\xd9\x97\xbe\x81\x7d\xa9\xff\x0a\x7a\x71\x5c\x50\xc6\xd7\x76\xd4\xa1\xe2\xe9\x1f\x95\xbb\x50\x42\x77\xff\x3f\x87\xff\x8b\x9c\x8f\xa2\x27\xb8\x56\xcd\xcf\xf3\x7a\xbc\x3d\x00\x9f\xfd\x90\xb1\x38\xbf\xb9\x49\xcb\x90\xfd\x33\x00\x97\x1c\xd6\xf4\x36\x08\x00\x00")
+
+func _1528395661_discussion_thread_numbersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395661_discussion_thread_numbersUpSql,
+		"1528395661_discussion_thread_numbers.up.sql",
+	)
+}
+
+func _1528395661_discussion_thread_numbersUpSql() (*asset, error) {
+	bytes, err := _1528395661_discussion_thread_numbersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395661_discussion_thread_numbers.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0xd6, 0x94, 0x7, 0x83, 0xad, 0x34, 0x48, 0x3b, 0x30, 0xe, 0x72, 0x9c, 0x38, 0xf1, 0x75, 0xfa, 0x3a, 0x1e, 0x43, 0xb6, 0x84, 0xd0, 0x6d, 0x8d, 0xe8, 0xb1, 0xf8, 0x4e, 0x6e, 0xc9, 0xe4}}
+	return a, nil
+}
+
+var __1528395662_discussion_rolesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x38\x00\xc7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x72\x6f\x6c\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x5a\xde\xf2\xe3\x38\x00\x00\x00")
+
+func _1528395662_discussion_rolesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395662_discussion_rolesDownSql,
+		"1528395662_discussion_roles.down.sql",
+	)
+}
+
+func _1528395662_discussion_rolesDownSql() (*asset, error) {
+	bytes, err := _1528395662_discussion_rolesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395662_discussion_roles.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf4, 0x4c, 0x3, 0x14, 0x95, 0x48, 0x6f, 0x93, 0x87, 0x14, 0xb1, 0x88, 0x14, 0xee, 0xd8, 0xcf, 0xe4, 0xe4, 0x93, 0x65, 0x50, 0xa9, 0x64, 0xa6, 0x46, 0xd8, 0x14, 0xa2, 0x62, 0x73, 0x6, 0x4}}
+	return a, nil
+}
+
+var __1528395662_discussion_rolesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\x5f\x6f\xda\x30\x14\xc5\xdf\xf3\x29\xce\x1b\x89\x44\xf7\x05\x98\x26\x99\x70\x69\xad\x82\xb3\x39\x46\x6b\x9f\x22\x8f\x78\xc4\x12\xc4\xc8\x76\xff\xac\x9f\x7e\x4a\x20\x08\xb5\x1a\x54\x7b\x73\xe2\x7b\xcf\xfd\x9d\xe3\x3b\xa5\x5b\x2e\x26\x49\x72\x73\x03\xe9\xb6\x26\x20\x36\x3a\xe2\x29\x18\x1f\xd0\xe8\x67\x03\xdb\x22\x36\x06\xb5\x0d\xeb\xa7\x10\xac\x6b\x03\xdc\x6f\x68\x78\xb3\x77\xc1\x46\xe7\xff\xc0\x79\x38\xbf\xd1\xad\x7d\xd3\xd1\xba\xf6\x4b\x27\xc6\x7a\x0d\x34\x3a\x40\x47\xec\x5c\x88\x70\xad\x81\x77\x5b\x83\xbd\xf1\x17\xdb\x73\x49\x4c\x11\x14\x9b\x2e\x08\x7c\x0e\x51\x28\xd0\x03\x2f\x55\x79\x86\x51\x75\x52\x01\x69\x02\x00\xb6\xc6\x2f\xbb\x09\xc6\x5b\xbd\xc5\x77\xc9\x97\x4c\x3e\xe2\x9e\x1e\xc7\xfd\x6d\x47\x52\xd9\x1a\xb6\x8d\x66\x63\x7c\xaf\x27\x56\x8b\x05\x24\xcd\x49\x92\xc8\xa9\xec\x69\x43\x6a\xeb\x0c\x85\xc0\x8c\x16\xa4\x08\x39\x2b\x73\x36\xa3\x83\x48\x07\x7c\x2e\x72\xd6\xdb\x5d\x5d\x6a\x75\x7e\xf3\x8f\x4e\xe7\x37\x97\x87\x76\x71\x45\xf3\x1a\x4f\xcc\x07\xc5\xb5\x37\x3a\x9a\xba\xd2\x11\xd1\xee\x4c\x88\x7a\xb7\xc7\x8b\x8d\x4d\xff\x89\xb7\x2e\xea\x93\xcb\x19\xcd\xd9\x6a\xa1\xd0\xba\x97\x34\x3b\xf4\xe7\x85\x28\x95\x64\x5c\xa8\x0f\x89\x56\x8d\x0e\x95\x6b\x4d\x15\xd6\x6e\x6f\x90\xdf\x51\x7e\x8f\x34\x1d\xec\xf3\xb2\xc7\xc8\xf0\xf5\x1b\xd2\xa3\xb1\xe1\xdf\x27\xc4\x9f\xf5\xd6\xd6\xfd\x79\x50\xee\xcf\x5c\x20\x1d\xe5\x85\x50\x92\x4f\x57\xaa\x90\xa3\x31\x46\x4a\x72\x76\x4b\xfd\x71\xd9\x91\x32\x2e\x48\x8e\xb2\x2c\xc9\x26\xc3\x86\xac\x04\xff\xb1\x22\x70\x31\xa3\x87\x2b\x8b\x52\x1d\x0d\x54\xc7\x65\xa8\x6c\xfd\xda\xbd\xd7\xfb\xba\xc1\xe8\x18\xc7\xc2\x0c\x3f\xef\x48\xd2\xe9\xfd\x79\x79\x4a\xf6\xbf\x38\x0e\x99\x5d\xc5\x38\x94\x7d\xa0\x38\x4b\xfc\x3d\xc4\xa7\xa6\x5f\x1b\x3b\x8c\x9b\x24\x49\x5e\x2c\x97\x5c\x4d\x92\xbf\x03\x00\x63\x21\x2d\x17\x22\x04\x00\x00")
+
+func _1528395662_discussion_rolesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395662_discussion_rolesUpSql,
+		"1528395662_discussion_roles.up.sql",
+	)
+}
+
+func _1528395662_discussion_rolesUpSql() (*asset, error) {
+	bytes, err := _1528395662_discussion_rolesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395662_discussion_roles.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd0, 0x5d, 0x16, 0x1f, 0xc7, 0xe5, 0x5c, 0xc5, 0xc0, 0x65, 0xcf, 0xa7, 0x9d, 0xe, 0x90, 0x24, 0x62, 0xba, 0x14, 0x4c, 0xb9, 0x6f, 0x29, 0xd5, 0x23, 0xd5, 0x9d, 0x35, 0x4c, 0x28, 0x72, 0x27}}
+	return a, nil
+}
+
+var __1528395663_discussion_threads_target_repo_thread_id_idxDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x50\x00\xaf\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x43\x4f\x4e\x43\x55\x52\x52\x45\x4e\x54\x4c\x59\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x5f\x74\x61\x72\x67\x65\x74\x5f\x72\x65\x70\x6f\x5f\x74\x68\x72\x65\x61\x64\x5f\x69\x64\x5f\x69\x64\x78\x3b\x0a\x03\x00\x82\x31\x18\xf1\x50\x00\x00\x00")
+
+func _1528395663_discussion_threads_target_repo_thread_id_idxDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395663_discussion_threads_target_repo_thread_id_idxDownSql,
+		"1528395663_discussion_threads_target_repo_thread_id_idx.down.sql",
+	)
+}
+
+func _1528395663_discussion_threads_target_repo_thread_id_idxDownSql() (*asset, error) {
+	bytes, err := _1528395663_discussion_threads_target_repo_thread_id_idxDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395663_discussion_threads_target_repo_thread_id_idx.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x35, 0xad, 0x15, 0x15, 0xcf, 0x47, 0x72, 0x6a, 0x70, 0x4f, 0x2d, 0xc5, 0xb1, 0x7c, 0x11, 0xd3, 0x11, 0xe4, 0x6f, 0x39, 0x3f, 0xc6, 0x8e, 0x21, 0xdb, 0x25, 0x8, 0xb, 0x62, 0x24, 0xf2, 0xd6}}
+	return a, nil
+}
+
+var __1528395663_discussion_threads_target_repo_thread_id_idxUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x91\x41\xaf\xda\x40\x0c\x84\xef\xfc\x8a\x39\x82\x44\xf8\x03\x3d\x55\x94\x4a\x48\x15\x48\xbc\x54\x7a\x3d\x45\x9b\xac\x21\x6e\x83\x97\xda\x4e\x79\xfc\xfb\xca\x21\x7d\xed\xad\x57\x7b\xf6\x9b\x9d\x71\x55\xa1\xee\x95\x52\x36\x24\x25\x7c\x2f\x2c\x94\xe1\x05\xde\x13\x2b\x3c\xe9\x85\x1c\x4a\xb7\x82\xf6\x01\x9f\xa4\x0d\x67\x2c\x69\x73\xd9\xe0\xde\x93\x60\x60\x73\x96\xcb\xa2\xaa\xe6\xbd\x85\x34\x9e\x18\x7b\xd1\xc7\x1a\xae\x49\xec\x4c\xaa\x2c\x97\x77\x4d\x92\x8c\x2e\x59\x97\xf2\xdf\x29\x32\x0d\xe4\x64\xab\x75\xd0\xee\x3d\x77\x3d\xee\xc9\x20\xc5\xc1\x92\xe9\x8d\xf2\x66\x51\x55\xb1\xac\x7b\x7a\x8e\xc0\x86\x76\xe4\xc1\xd1\x15\xe9\x46\x55\x12\x1f\x1e\xb0\x88\x90\x1c\x77\x65\x27\x9b\x13\xc1\x53\x3b\xd0\x94\x34\x90\xed\x50\xba\x1f\x94\x03\x57\x04\x43\x64\x05\x8b\x79\x92\x8e\x6c\x3d\xfb\x2b\xfd\x1c\x59\x03\xd1\x27\x87\xf7\x6c\xe1\x18\xb0\x22\xe1\xe3\xc9\xe9\x4a\x12\x1f\x8c\xd2\x02\x76\xe5\x8b\x26\xe7\x22\x58\xb2\xa3\x4b\x12\x66\x3a\x4a\x48\xd2\xb3\x8d\xd4\x4d\xfb\xe9\x07\xab\x0d\xf6\xe7\x29\xc3\x54\x05\x3b\xce\x89\x07\x5b\xff\xc1\xb1\xfc\x4a\x03\xe7\x39\xee\x75\x34\x47\x4b\xc8\x5a\x6e\x37\xca\x68\xe9\x5c\x94\x42\xfb\x8f\x2f\x1b\x94\x5c\x39\xfa\xda\x9e\x76\x1f\xeb\x1d\xf6\x87\x4f\xbb\x57\x6c\x8f\x87\xed\xd7\xd3\x69\x77\xa8\xbf\x7c\xc3\xfe\x33\x0e\xc7\x1a\xbb\xd7\xfd\x4b\xfd\x82\xcc\xd6\x8d\x66\x5c\xa4\x99\x6f\xd4\x3c\xcf\xdf\xc4\x2d\xe7\x59\xc3\xb9\xe1\xfc\x86\xe3\xe1\x3f\xfa\xe5\xbb\x7e\xf5\x61\xf1\x7b\x00\x72\x81\xf8\xa5\x66\x02\x00\x00")
+
+func _1528395663_discussion_threads_target_repo_thread_id_idxUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395663_discussion_threads_target_repo_thread_id_idxUpSql,
+		"1528395663_discussion_threads_target_repo_thread_id_idx.up.sql",
+	)
+}
+
+func _1528395663_discussion_threads_target_repo_thread_id_idxUpSql() (*asset, error) {
+	bytes, err := _1528395663_discussion_threads_target_repo_thread_id_idxUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395663_discussion_threads_target_repo_thread_id_idx.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1, 0x2b, 0xa8, 0x97, 0x9d, 0xfd, 0xa4, 0x95, 0xeb, 0xfd, 0xfb, 0x5, 0x9b, 0xb4, 0x64, 0xaf, 0x36, 0xfd, 0xd7, 0x59, 0x26, 0x47, 0x9c, 0x1f, 0x90, 0x7a, 0x33, 0xd4, 0x44, 0x1b, 0x80, 0xd4}}
+	return a, nil
+}
+
+var __1528395664_discussion_threads_open_updated_at_id_idxDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4d\x00\xb2\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x43\x4f\x4e\x43\x55\x52\x52\x45\x4e\x54\x4c\x59\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x5f\x6f\x70\x65\x6e\x5f\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x5f\x69\x64\x5f\x69\x64\x78\x3b\x0a\x03\x00\xc7\xe6\xdb\xf7\x4d\x00\x00\x00")
+
+func _1528395664_discussion_threads_open_updated_at_id_idxDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395664_discussion_threads_open_updated_at_id_idxDownSql,
+		"1528395664_discussion_threads_open_updated_at_id_idx.down.sql",
+	)
+}
+
+func _1528395664_discussion_threads_open_updated_at_id_idxDownSql() (*asset, error) {
+	bytes, err := _1528395664_discussion_threads_open_updated_at_id_idxDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395664_discussion_threads_open_updated_at_id_idx.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe9, 0x8a, 0x62, 0x6b, 0x10, 0xe8, 0xcd, 0xc0, 0x81, 0xd7, 0xb8, 0x2e, 0xac, 0x32, 0x14, 0xf8, 0xfa, 0x96, 0x56, 0x86, 0x94, 0x8, 0x99, 0xa6, 0xd3, 0xc5, 0x89, 0xa8, 0x16, 0x15, 0x93, 0x21}}
+	return a, nil
+}
+
+var __1528395664_discussion_threads_open_updated_at_id_idxUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x4d\x6b\xf2\x40\x14\x85\xf7\xfe\x8a\xb3\x54\x30\x81\xf7\x15\xa5\xa5\x2b\xd1\x94\x06\x24\x42\x12\xa9\x5d\x0d\xd3\xcc\xd5\x5c\x88\x33\xe9\xcc\x8d\x1f\xff\xbe\x68\x53\xec\xc2\xed\xe1\x39\x33\xcf\x3d\x51\x84\xb2\xf6\xa4\x0d\x1a\x0e\x12\x60\x68\xa7\xbb\x46\x20\x0e\x52\x13\xac\xb3\x91\xf6\x55\xcd\x47\x32\x90\x1b\x18\xc6\x38\xb8\x20\xf0\x54\x91\x95\xe6\x82\xae\x35\x5a\xc8\x60\xc7\x3e\xc8\x20\x8a\x30\x34\xd4\x90\xdc\x0b\xd0\x9e\x60\xe9\x48\xfe\xf6\x09\x99\x51\x8c\x82\xe8\x8a\xfe\x9b\xfe\x7f\x9a\x3c\x4f\x67\xb3\x89\x32\x1c\xaa\x2e\x04\x76\x56\xf5\x3d\x25\xda\xef\x49\x94\xa7\xd6\xf5\x99\x62\xa3\xd8\x9c\xe3\xae\x8d\xc3\x57\x83\x9d\xf3\x38\xd5\x97\xab\xea\xf5\x35\xb6\x86\xce\xe0\x80\xcf\x8e\x1b\x41\xe5\x6c\xd5\x79\xff\x63\xc9\x16\x2c\x01\xee\x64\x71\xe0\xbd\xd7\xc2\xce\xc6\x83\x45\x9e\xcc\xcb\x04\x69\xb6\x4c\xb6\x58\xac\xb3\xc5\x26\xcf\x93\xac\x5c\x7d\x20\x7d\x45\xb6\x2e\x91\x6c\xd3\xa2\x2c\xf0\x40\xce\xb5\x64\x55\x7f\xbb\xd2\xd2\x9b\x61\x9d\x3d\x80\x87\x77\x6e\x0c\x36\x23\xbc\xbf\x25\x79\x82\x7e\x27\xa5\x05\x69\x81\x6c\xb3\x5a\x61\x9e\x2d\xf1\x3b\xf8\x9f\xfc\x65\xf0\x3d\x00\x1c\x3f\x01\x63\xa9\x01\x00\x00")
+
+func _1528395664_discussion_threads_open_updated_at_id_idxUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395664_discussion_threads_open_updated_at_id_idxUpSql,
+		"1528395664_discussion_threads_open_updated_at_id_idx.up.sql",
+	)
+}
+
+func _1528395664_discussion_threads_open_updated_at_id_idxUpSql() (*asset, error) {
+	bytes, err := _1528395664_discussion_threads_open_updated_at_id_idxUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395664_discussion_threads_open_updated_at_id_idx.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbe, 0x8b, 0x1c, 0xcc, 0x94, 0x72, 0x59, 0xf4, 0x90, 0x19, 0x90, 0x56, 0x3d, 0x33, 0xc8, 0xdf, 0x5a, 0xa1, 0x77, 0xf8, 0xf2, 0xbd, 0xe5, 0xf2, 0xec, 0xdb, 0x8e, 0xe1, 0xbb, 0xd0, 0xae, 0x15}}
+	return a, nil
+}
+
+var __1528395665_discussion_threads_repo_deleted_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\xc1\x6e\xe2\x30\x14\x45\xf7\xfe\x8a\xbb\x04\x69\x98\x1f\xf0\x2a\x24\x2f\x19\x6b\x12\x1b\x39\x46\xc3\xac\xac\xa8\x71\xc1\x2a\x22\x95\x6d\x4a\xf9\xfb\x8a\x42\x25\x4a\x11\xb0\x7e\x57\x47\xf7\x5c\xbd\x29\x55\x42\x72\xc6\x0a\xad\x66\x30\x5a\x54\x15\x69\x88\x12\xb4\x10\xad\x69\x91\x82\x5f\xda\xde\xc7\xa7\x6d\x8c\x7e\xd8\xd8\xb4\x0a\xae\xeb\xa3\x8d\x2e\xd9\xe0\x5e\x07\xdb\xbb\xb5\x4b\xae\xb7\x5d\x82\x92\xf8\x99\xe4\x47\x72\x39\x97\xb9\x11\x4a\x9e\xa1\x1f\xa3\x8e\xc6\x9c\xb1\xc9\x04\xb3\xe0\xde\xfc\xb0\x8d\xeb\xfd\x2f\x9c\xe2\xd8\xb9\xe0\x70\x8a\xa2\x5b\x0f\x9b\x25\x76\x3e\xad\x90\x56\xce\x07\xa4\x2e\x2c\x5d\xc2\xa1\x65\xf4\x69\x08\xfb\xdf\xac\xa0\x9a\x0c\xa1\xd4\xaa\xb9\x52\x15\xff\xfe\x90\x26\x5c\x6a\x89\x16\x52\x19\xc8\x79\x5d\x73\xc6\xb2\xda\x90\x86\xc9\xa6\x35\x5d\x43\x7c\xca\xe6\x4a\xb6\x46\x67\x42\x9a\xdb\xba\xc7\x86\x47\x63\xdf\xdb\xe7\x17\x7e\x0f\x9f\x15\xc5\x39\xfd\x11\x26\x4a\xa5\x49\x54\x12\x7f\xe9\x3f\x46\xdf\xcf\x63\x68\x2a\x49\x93\xcc\xe9\x5e\xc1\xd1\x21\xad\x24\x4e\x13\xe6\x59\x9b\x67\x05\x7d\x3d\x8e\x90\x05\x2d\x6e\xcb\x5e\xcc\x6a\x7d\xff\xce\x1f\x5c\xb3\x9e\x37\xe7\x8f\x73\x41\xe2\x8c\xe5\xaa\x69\x84\xe1\xec\x63\x00\xb8\x40\xcb\x6e\xcd\x02\x00\x00")
+
+func _1528395665_discussion_threads_repo_deleted_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395665_discussion_threads_repo_deleted_atDownSql,
+		"1528395665_discussion_threads_repo_deleted_at.down.sql",
+	)
+}
+
+func _1528395665_discussion_threads_repo_deleted_atDownSql() (*asset, error) {
+	bytes, err := _1528395665_discussion_threads_repo_deleted_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395665_discussion_threads_repo_deleted_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd1, 0x66, 0xa, 0xe6, 0x5e, 0x5e, 0x8c, 0xea, 0x3d, 0x64, 0x68, 0x2b, 0x77, 0x93, 0x5e, 0xdb, 0xd7, 0xd6, 0xe0, 0x2c, 0x75, 0xfd, 0x97, 0xae, 0x93, 0x6f, 0x3f, 0x75, 0x5, 0x6b, 0x92, 0xe9}}
+	return a, nil
+}
+
+var __1528395665_discussion_threads_repo_deleted_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\xc1\x6e\xdb\x3c\x10\x84\xef\x7c\x8a\x39\x04\xf8\x6d\x20\xf1\x03\xfc\x42\x0f\x8a\xb4\x76\x84\x2a\x94\x41\x51\x70\x7a\x12\x18\x8b\xb6\x88\xda\x92\x2b\x32\x4d\xd3\xa7\x2f\x28\xcb\x4e\xe2\xb8\x88\x7b\x13\xc4\xdd\x99\x6f\x87\xcb\x5b\x9a\x25\x3c\x60\xec\xe6\x06\x8b\x5a\x37\x50\x70\x75\xa7\x55\xf5\x9f\x85\x53\xdd\x5a\x3b\x74\x7a\xd7\x5a\xe3\xda\xee\x05\xc6\xa2\xd2\x1b\xed\x74\x75\x0d\x57\xeb\xa1\x74\xff\xdb\xa9\x65\xad\x2b\xac\xba\x76\x0b\xe3\xbc\xde\xc8\x34\xd6\xf9\xf3\x76\x85\x47\x6d\x9a\xf5\xa1\x19\x6a\xd3\x36\x6b\x3c\x1b\x57\xef\x65\x7a\xa3\x31\x54\x53\xf5\x6e\xe5\x50\x57\x2a\xef\xbe\x6c\xbb\xca\x7a\xbd\x67\xcf\xe7\x6a\xe5\x50\xab\xdd\x4e\x37\xba\x9a\x20\x7f\x5a\xd6\x03\x86\x85\xea\x34\x54\xb7\xac\xcd\x4f\x5d\xa1\xed\x8e\x76\x8f\x2f\xbd\xcd\xaa\x6b\x1b\xa7\x9b\xea\x1a\xaa\xd7\x5b\xb6\xcd\xca\xac\x9f\xba\xd7\x8a\xca\xd8\xe5\x93\xb5\xa6\x6d\xec\x64\x68\x16\xc7\xe9\xe5\xe0\x62\x8d\xd3\xc7\x5e\xe5\x4c\xdb\x4c\x58\x98\x4a\x12\x90\xe1\x6d\x4a\x6f\x44\xca\x03\x58\x18\xc7\x88\xb2\xb4\xb8\xe7\x48\xa6\xe0\x99\x04\x3d\x24\xb9\xcc\x3f\x4c\xeb\xcc\x56\x5b\xa7\xb6\xbb\x21\x1c\xb3\xd5\xf8\xdd\x36\x3a\x60\x91\xa0\x50\x12\x12\x1e\xd3\xc3\x89\xc8\x47\xc3\xf2\x44\xb7\x34\xd5\x2f\x64\xfc\x0c\xda\xe8\xa4\x72\x8c\xc5\x1d\x09\xfa\x00\x96\xe4\x3d\x36\x2f\xd2\x34\x60\x9f\x8d\x1b\x8b\x6c\x8e\x28\xe3\xb9\x14\x61\xc2\xa5\xc7\xfd\x3b\xea\x7e\xcb\xf6\xc4\xa6\x2a\x57\xdf\x83\xcb\xd2\x3c\xaa\x5f\xa2\x89\x69\x26\x28\x99\x71\x7c\xa5\x6f\x18\xbd\x3f\x1e\x43\xd0\x94\x04\xf1\x88\x3e\x03\x1c\xf9\xea\x8c\x23\xa6\x94\x24\x21\xa7\x63\x22\xc3\xf5\x64\x02\x82\xe6\x69\x18\x11\xa6\x05\x8f\x64\x72\x36\xf4\xd2\x1e\xdc\x5f\x13\x1e\x79\x0c\x59\x08\x9e\x43\x8a\x64\x36\x23\x81\x30\x67\x57\x97\x35\x5f\xb1\xfe\x19\x33\xf8\xa8\x47\x59\x1a\x4f\xde\x8f\xf8\xf6\xfa\x10\xf2\x18\x9c\x16\xe7\x4a\x8a\x34\x1d\x43\xde\x11\x67\x00\xfa\xa2\x13\x23\xfc\xff\x05\x4d\xfb\x3c\x1a\x07\x0c\x20\x1e\x23\x99\xfa\xaf\x3d\xb9\x6f\x08\x18\xf1\x38\xb8\x98\x1b\x69\xc8\x67\x45\x38\x23\xec\x36\xbb\xb5\xfd\xb1\x09\x18\xeb\xb7\xe7\x90\xc1\xeb\xea\xb8\xce\xac\xcb\xcb\x64\xcf\xaf\xfa\xf1\x0d\x1d\xb4\xff\x45\xf1\x96\xfc\x06\xa1\x98\xc7\xfe\x15\x66\x53\x9c\xa4\x77\xd6\xd1\x6f\x1d\x28\x8c\xee\x20\xb2\x05\xe8\x81\xa2\x42\x12\xe6\x22\x8b\x28\x2e\x04\xe1\x32\x6f\x1f\x36\x8b\xb2\xfb\xfb\x44\x06\xec\xcf\x00\x91\x3a\x9c\x53\xaf\x05\x00\x00")
+
+func _1528395665_discussion_threads_repo_deleted_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395665_discussion_threads_repo_deleted_atUpSql,
+		"1528395665_discussion_threads_repo_deleted_at.up.sql",
+	)
+}
+
+func _1528395665_discussion_threads_repo_deleted_atUpSql() (*asset, error) {
+	bytes, err := _1528395665_discussion_threads_repo_deleted_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395665_discussion_threads_repo_deleted_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdf, 0x67, 0xe2, 0xad, 0x1f, 0x27, 0xae, 0x2d, 0x3a, 0xf, 0x1e, 0x89, 0x5b, 0x89, 0xc5, 0x1, 0xd7, 0x0, 0xf, 0x82, 0x13, 0x82, 0x6e, 0x29, 0x7b, 0xfa, 0x6c, 0x44, 0x69, 0x0, 0x3d, 0x9c}}
+	return a, nil
+}
+
+var __1528395666_discussion_feed_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3e\x00\xc1\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x66\x65\x65\x64\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x07\x26\x70\x80\x3e\x00\x00\x00")
+
+func _1528395666_discussion_feed_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395666_discussion_feed_tokensDownSql,
+		"1528395666_discussion_feed_tokens.down.sql",
+	)
+}
+
+func _1528395666_discussion_feed_tokensDownSql() (*asset, error) {
+	bytes, err := _1528395666_discussion_feed_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395666_discussion_feed_tokens.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x28, 0x55, 0xc5, 0xb5, 0xb5, 0xad, 0xeb, 0xe7, 0xa3, 0xa5, 0x2e, 0xd6, 0x17, 0xdc, 0x7e, 0x82, 0x48, 0x2a, 0xf5, 0x77, 0x24, 0x57, 0x76, 0x40, 0x70, 0xde, 0xba, 0xe5, 0x85, 0x92, 0xd4, 0x74}}
+	return a, nil
+}
+
+var __1528395666_discussion_feed_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x4f\x6f\xdb\x30\x0c\xc5\xef\xfe\x14\xef\x16\x0b\xe8\x69\xc0\x76\x09\x7a\x70\x1d\x66\x13\xea\x28\x9b\xad\x00\xed\x49\x50\x23\x2e\x11\x92\x58\x85\xa5\xf4\xcf\x3e\xfd\xe0\xc4\x69\x33\x60\x2d\x72\x33\xc9\xe7\xf7\xa3\x48\xde\xd0\x77\xa9\xc6\x59\x56\xd6\x54\x68\x82\x2e\x6e\x2a\x82\x9c\x42\xcd\x35\xe8\x4e\x36\xba\x81\xf3\x71\xb9\x8f\xd1\x87\xd6\xfc\x66\x76\x26\x85\x0d\xb7\x11\x79\x06\x00\xde\xe1\xc1\xaf\x22\x77\xde\x6e\xf1\xb3\x96\xb3\xa2\xbe\xc7\x2d\xdd\x5f\x1d\xaa\xfb\xc8\x9d\xf1\x0e\xbe\x4d\xbc\xe2\xee\xe0\xaa\x16\x55\x85\x9a\xa6\x54\x93\x2a\xa9\x39\x68\x62\xee\x9d\xc0\x5c\x61\x42\x15\x69\x42\x59\x34\x65\x31\xa1\xa3\xc9\x93\xdd\xee\xd9\xc4\xb5\xfd\xf2\xf5\x1b\x1e\x5e\x13\xdb\x77\x9f\x85\x92\xbf\x16\x83\x6e\xe3\x5b\x87\xc4\x2f\xe9\xad\x7c\xcc\x77\xfc\x18\xce\x9b\x38\x63\xf7\xa5\xcf\xd0\xcb\x8e\x6d\x62\x67\x6c\x42\xf2\x3b\x8e\xc9\xee\x1e\xf1\xec\xd3\xfa\x10\xe2\x4f\x68\xf9\xbd\x97\x09\x4d\x8b\x45\xa5\xd1\x86\xe7\x5c\x1c\xff\xdf\xda\x98\xcc\x3e\x7e\xee\x70\xea\xf2\x29\x6c\x2e\x11\x96\x73\xd5\xe8\xba\x90\x4a\x7f\xb0\x19\xd3\x0f\xc2\x2c\xd7\xbc\xdc\xa0\xfc\x41\xe5\x2d\xf2\x3e\x03\xa9\x90\x8f\xfa\x17\x47\x9f\x42\xf7\x3a\xba\xc2\xc8\xc6\xe8\x57\x2d\xbb\xfe\x7b\xc7\x6d\xf2\xa1\x0f\x84\xb8\x94\x34\x8c\xf6\x5f\xd8\x91\x76\x8d\x73\x96\xc0\x35\xf2\xd3\x22\x64\xf3\x36\x34\x21\x32\x31\x3e\x1d\x9f\x54\x13\xba\xbb\xe8\xf8\xcc\x70\x58\xc6\xbb\x97\x7e\x77\xff\x57\xe5\x83\x4a\x8c\xb3\xac\x9c\xcf\x66\x52\x8f\xb3\xbf\x03\x00\xc0\x67\x8e\xe1\xf0\x02\x00\x00")
+
+func _1528395666_discussion_feed_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395666_discussion_feed_tokensUpSql,
+		"1528395666_discussion_feed_tokens.up.sql",
+	)
+}
+
+func _1528395666_discussion_feed_tokensUpSql() (*asset, error) {
+	bytes, err := _1528395666_discussion_feed_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395666_discussion_feed_tokens.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd2, 0x51, 0xc0, 0x68, 0x85, 0xfb, 0x6c, 0xba, 0xc7, 0xbc, 0x4d, 0x99, 0xdf, 0x64, 0x1c, 0xcd, 0xa1, 0xc2, 0xf7, 0xfa, 0x2e, 0x3c, 0xc3, 0xaf, 0x23, 0xf8, 0xc6, 0x93, 0x67, 0x49, 0x61, 0xe9}}
+	return a, nil
+}
+
+var __1528395667_discussion_thread_diagnosticsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x45\x00\xba\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x64\x69\x61\x67\x6e\x6f\x73\x74\x69\x63\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x30\xc7\x13\xdf\x45\x00\x00\x00")
+
+func _1528395667_discussion_thread_diagnosticsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395667_discussion_thread_diagnosticsDownSql,
+		"1528395667_discussion_thread_diagnostics.down.sql",
+	)
+}
+
+func _1528395667_discussion_thread_diagnosticsDownSql() (*asset, error) {
+	bytes, err := _1528395667_discussion_thread_diagnosticsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395667_discussion_thread_diagnostics.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x59, 0x18, 0xaf, 0x4c, 0x59, 0xc9, 0xac, 0xdd, 0xd1, 0xfc, 0x88, 0xda, 0x43, 0x7f, 0xfc, 0x8e, 0x73, 0x8a, 0x68, 0xc1, 0x98, 0x5f, 0xa, 0x8e, 0x1e, 0x3d, 0x2d, 0x40, 0x86, 0x60, 0x7b, 0x26}}
+	return a, nil
+}
+
+var __1528395667_discussion_thread_diagnosticsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x52\xcd\x6e\x9c\x30\x10\xbe\xf3\x14\x73\x03\xa4\xbc\x01\x27\x62\x66\x5b\x2b\xac\xa9\xc0\x91\x92\x13\x72\xcd\x14\x46\x0d\x26\xb2\x9d\x6e\xda\xa7\xaf\x58\xba\xdb\x0b\xdd\x2a\x92\x25\x5b\xf3\xfd\xf8\xb3\xfc\xdd\xe3\x27\xa9\x8a\x24\x11\x2d\x96\x1a\x41\x97\xf7\x35\x82\x3c\x80\x6a\x34\xe0\x93\xec\x74\x07\x03\x07\xfb\x16\x02\x2f\xae\x8f\x93\x27\x33\xf4\x03\x9b\xd1\x2d\x21\xb2\x0d\x90\x25\x00\x00\x3c\xc0\x57\x1e\x03\x79\x36\x2f\xf0\xa5\x95\xc7\xb2\x7d\x86\x07\x7c\xbe\x3b\xa3\x7f\x64\x1b\x89\x5d\x3c\xbb\xab\xc7\xba\x86\x16\x0f\xd8\xa2\x12\xb8\x73\x4d\xc8\x78\xc8\xa1\x51\x50\x61\x8d\x1a\x41\x94\x9d\x28\x2b\xdc\x2c\xc3\xf2\xe6\x2d\x41\xa4\xf7\xbf\x6e\x1b\xf2\x6a\xe2\xb4\x37\x0f\xd1\xf8\xd8\xbf\xb0\x23\x60\x17\x69\x24\xbf\x4b\xb0\x93\xf1\xc6\x46\xf2\xff\x60\x91\x1b\x6e\x99\xac\xf0\xff\x2c\x66\x0a\xc1\x8c\xbb\xe1\x03\xfd\x20\xcf\xf1\xe7\x1e\x66\x3d\x99\x48\x43\x6f\x22\x44\x9e\x29\x44\x33\xbf\xc2\x89\xd7\xd7\xf2\x4c\xf0\x6b\x71\x74\x55\x40\x85\x87\xf2\xb1\xd6\xe0\x96\x53\x96\x6f\x7a\xd1\xa8\x4e\xb7\xa5\x54\xfa\xf6\x97\xf6\x97\x10\xbd\x9d\xc8\x7e\x07\xf1\x19\xc5\x03\x64\xd7\x68\x52\x41\x96\x92\xf7\x8b\x4f\xef\x20\x3d\x19\xef\xd8\x8d\xeb\x91\xdd\xb7\x65\xdd\x27\x76\x31\xcd\xf3\x24\x2f\x2e\xb5\x92\xaa\xc2\xa7\x8f\xd4\xea\x32\xe2\x75\xbd\xaf\x25\xb8\x49\xcf\xae\xf4\xbc\x48\x12\xd1\x1c\x8f\x52\x17\xc9\xef\x01\x00\xb4\xeb\x83\x09\xdc\x02\x00\x00")
+
+func _1528395667_discussion_thread_diagnosticsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395667_discussion_thread_diagnosticsUpSql,
+		"1528395667_discussion_thread_diagnostics.up.sql",
+	)
+}
+
+func _1528395667_discussion_thread_diagnosticsUpSql() (*asset, error) {
+	bytes, err := _1528395667_discussion_thread_diagnosticsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395667_discussion_thread_diagnostics.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x52, 0xb8, 0xad, 0x6c, 0x6, 0x5a, 0x22, 0xa9, 0x63, 0xc4, 0x21, 0x61, 0x98, 0x84, 0x47, 0xfa, 0xb3, 0xd2, 0x13, 0x5b, 0x3e, 0xae, 0x73, 0xea, 0xa1, 0xa7, 0xc9, 0x8b, 0x6, 0x4, 0xbd, 0x35}}
+	return a, nil
+}
+
+var __1528395668_discussion_rulesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x38\x00\xc7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x72\x75\x6c\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x03\x3c\x9f\xc0\x38\x00\x00\x00")
+
+func _1528395668_discussion_rulesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395668_discussion_rulesDownSql,
+		"1528395668_discussion_rules.down.sql",
+	)
+}
+
+func _1528395668_discussion_rulesDownSql() (*asset, error) {
+	bytes, err := _1528395668_discussion_rulesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395668_discussion_rules.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x0, 0x18, 0x6c, 0x25, 0x17, 0x36, 0x53, 0xc0, 0xf5, 0x50, 0x8, 0x83, 0xf0, 0x17, 0x5e, 0x88, 0xff, 0x1e, 0x26, 0xe1, 0xe, 0x36, 0x7, 0x26, 0xd7, 0xc1, 0x5f, 0xea, 0x5a, 0x94, 0xd6, 0x8c}}
+	return a, nil
+}
+
+var __1528395668_discussion_rulesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\xc1\x4a\xc3\x40\x10\x86\xef\xfb\x14\x73\x6b\x02\xbe\x41\x4e\x69\x32\x95\x60\x9a\x4a\xb2\x85\x16\x91\x65\xdb\x1d\xea\x40\xba\xa9\xbb\x1b\x5a\x15\xdf\x5d\x9a\xd4\x1e\x54\x14\x3c\xee\x7e\xff\x37\x0c\xf3\x4f\xf1\xb6\xa8\x12\x21\xb2\x1a\x53\x89\x20\xd3\x69\x89\x50\xcc\xa0\x5a\x48\xc0\x55\xd1\xc8\x06\x0c\xfb\x6d\xef\x3d\x77\x56\xb9\xbe\x25\x0f\x91\x00\x00\x60\x03\x1b\xde\x79\x72\xac\x5b\xb8\xaf\x8b\x79\x5a\xaf\xe1\x0e\xd7\x37\x03\x75\x74\xe8\x14\x1b\x60\x1b\x68\x47\x6e\x98\x57\x2d\xcb\x12\x6a\x9c\x61\x8d\x55\x86\xcd\x90\x89\xd8\xc4\xb0\xa8\x20\xc7\x12\x25\x42\x96\x36\x59\x9a\xe3\x38\xc3\xea\x3d\x41\xa0\x53\xb8\xda\xe3\xff\x73\x4f\xee\xe5\x27\xd0\xea\x0d\xb5\x8a\x8d\x3f\x6f\xc6\x36\x3c\x3c\x5e\x03\x90\xe3\x2c\x5d\x96\x12\x26\x6f\xef\x93\x31\xad\xbd\xe7\x9d\x25\x52\xbd\x27\x37\x58\x97\x65\x7f\xd7\xb6\x8e\x74\x20\xa3\x74\x80\xc0\x7b\xf2\x41\xef\x0f\x70\xe4\xf0\x34\x3c\xe1\xb5\xb3\xf4\x5d\xb7\xdd\x31\x8a\x47\xbf\x3f\x98\x7f\xfa\x22\x4e\x3e\x7b\x2a\xaa\x1c\x57\x7f\xf4\xa4\x2e\x1d\x28\x36\xa7\xf3\x89\xbf\xf2\xe8\xc2\xe3\x44\x88\x6c\x31\x9f\x17\x32\x11\x1f\x03\x00\x8c\xc6\x21\xc3\x0f\x02\x00\x00")
+
+func _1528395668_discussion_rulesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395668_discussion_rulesUpSql,
+		"1528395668_discussion_rules.up.sql",
+	)
+}
+
+func _1528395668_discussion_rulesUpSql() (*asset, error) {
+	bytes, err := _1528395668_discussion_rulesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395668_discussion_rules.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7d, 0xb3, 0x60, 0xf, 0xf3, 0xf0, 0x44, 0x22, 0xd6, 0xc7, 0xdd, 0xd3, 0xa2, 0x6f, 0xad, 0xb6, 0xe5, 0x49, 0xdc, 0x28, 0x4e, 0x56, 0x41, 0xea, 0x1, 0x41, 0x70, 0xbc, 0xbf, 0x3d, 0xc3, 0x20}}
+	return a, nil
+}
+
+var __1528395669_discussion_thread_previous_numbersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4a\x00\xb5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x5f\x70\x72\x65\x76\x69\x6f\x75\x73\x5f\x6e\x75\x6d\x62\x65\x72\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x61\x49\x4b\x39\x4a\x00\x00\x00")
+
+func _1528395669_discussion_thread_previous_numbersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395669_discussion_thread_previous_numbersDownSql,
+		"1528395669_discussion_thread_previous_numbers.down.sql",
+	)
+}
+
+func _1528395669_discussion_thread_previous_numbersDownSql() (*asset, error) {
+	bytes, err := _1528395669_discussion_thread_previous_numbersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395669_discussion_thread_previous_numbers.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x79, 0x7e, 0x48, 0xd1, 0xd9, 0xf3, 0x3f, 0xf4, 0x2, 0xab, 0x3b, 0x20, 0x36, 0x85, 0x19, 0x7d, 0x13, 0xf7, 0xea, 0x88, 0x46, 0xa, 0x89, 0xab, 0x7, 0x5f, 0x8c, 0xa, 0xe6, 0xf0, 0x27, 0xec}}
+	return a, nil
+}
+
+var __1528395669_discussion_thread_previous_numbersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\xc1\x6e\xe2\x30\x10\x86\xef\x7e\x8a\xff\x98\x48\xb0\x2f\x90\x53\x08\xc3\x2a\xda\x10\x56\x49\x56\x82\x53\x14\x36\x03\x19\x41\x6d\x64\x3b\xa5\xbc\x7d\x95\x10\x7a\x68\xab\x56\x95\x7c\xb2\x67\xbe\xff\xf3\xbf\xa0\xdf\x69\x1e\x29\x35\x9f\xa3\xea\x18\xba\x7f\xda\xb3\x75\xf0\x5d\xe3\xe1\x3b\xcb\x4d\xeb\xd0\x35\x2d\x44\xc3\x77\x0c\xcb\x17\xe3\xc4\x1b\x2b\x3c\x0c\xf1\x0d\x57\xb6\x0c\x6f\x1b\xed\x0e\x6c\x2d\xb7\x30\xbd\x1f\x68\xe6\x30\x83\x33\x77\xd0\x59\xf4\xc9\xc1\x1b\x34\x13\x13\xfb\x1b\xc4\x3b\x98\x73\x3b\x45\xe2\xc4\x7c\xc1\xd5\xd8\x93\xe8\xe3\x2f\x95\x14\x14\x57\x84\x2a\x5e\x64\x84\x74\x85\x7c\x53\x81\xb6\x69\x59\x95\x68\xc5\xfd\xef\x9d\x13\xa3\xeb\x3b\xac\xbe\x58\x7e\x16\xd3\xbb\xfa\x61\x1f\x28\x00\xa3\x6b\x2d\x83\xba\xe7\x23\xdb\x91\x91\xff\xcb\x32\x14\xb4\xa2\x82\xf2\x84\xca\x71\x26\x90\x36\xc4\x26\xc7\x92\x32\xaa\x08\x49\x5c\x26\xf1\x92\x66\x23\x63\x92\x7b\x8f\xb8\x3f\x4e\xf1\xd2\x62\x2f\x47\xd1\xfe\xd3\x84\x0f\xba\xee\xab\xbc\xbf\x45\xba\x8e\x8b\x1d\xfe\xd0\x0e\xc1\xf4\x81\xd9\x54\x51\xa8\xc2\xe8\x51\x4c\x9a\x2f\x69\xfb\xe3\x62\x1e\xf7\x32\x9c\x97\xc1\xe1\xfb\x9d\xe0\x6d\x27\x8c\x94\x4a\x36\xeb\x75\x5a\x45\xea\x75\x00\x54\x4d\x1f\xa1\x37\x02\x00\x00")
+
+func _1528395669_discussion_thread_previous_numbersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395669_discussion_thread_previous_numbersUpSql,
+		"1528395669_discussion_thread_previous_numbers.up.sql",
+	)
+}
+
+func _1528395669_discussion_thread_previous_numbersUpSql() (*asset, error) {
+	bytes, err := _1528395669_discussion_thread_previous_numbersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395669_discussion_thread_previous_numbers.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x97, 0xab, 0x19, 0x16, 0x2, 0x3d, 0x33, 0x83, 0x20, 0x5d, 0xc1, 0x35, 0x11, 0xb6, 0x52, 0xf3, 0xed, 0xc7, 0xa1, 0x3c, 0x40, 0x25, 0xec, 0x45, 0xe9, 0x51, 0xc, 0x7, 0xe4, 0xaf, 0xb2, 0xb0}}
+	return a, nil
+}
+
+var __1528395670_discussion_threads_closed_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x51\x00\xae\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x64\x69\x73\x63\x75\x73\x73\x69\x6f\x6e\x5f\x74\x68\x72\x65\x61\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6c\x6f\x73\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x64\xe4\xf2\x37\x51\x00\x00\x00")
+
+func _1528395670_discussion_threads_closed_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395670_discussion_threads_closed_atDownSql,
+		"1528395670_discussion_threads_closed_at.down.sql",
+	)
+}
+
+func _1528395670_discussion_threads_closed_atDownSql() (*asset, error) {
+	bytes, err := _1528395670_discussion_threads_closed_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395670_discussion_threads_closed_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x28, 0x34, 0xed, 0xcb, 0x97, 0xb4, 0x42, 0x2, 0xbe, 0x38, 0xb4, 0x19, 0x5f, 0x36, 0x48, 0x83, 0x6e, 0x8f, 0xf7, 0x5b, 0x32, 0x74, 0xcf, 0x5b, 0xb, 0x50, 0x44, 0xfa, 0x4b, 0xc1, 0x62, 0x12}}
+	return a, nil
+}
+
+var __1528395670_discussion_threads_closed_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8e\x4d\xaa\x83\x30\x18\x45\xe7\x59\xc5\x5d\x80\xbe\x0d\x38\xf2\x27\xef\x11\xf0\x07\x9e\x29\x74\x26\xa9\xf9\xc0\xd0\x98\x94\x24\x5a\xda\xd5\x97\x0a\xa5\xb3\x7b\xe1\x70\x38\x15\xff\x13\x7d\xc1\x58\x9e\xa3\xb6\x3e\x92\x46\x5a\x02\x29\x1d\xa1\x02\x21\x50\xf4\x76\x27\x8d\xcb\x96\x32\x6c\xce\x9a\x2b\x41\x85\x79\x31\xfb\x97\xcc\xe0\x7c\xc2\x7b\xe6\xde\xd9\xc7\x0f\x2b\x5b\xc9\xff\x21\xcb\xaa\xe5\xd0\x26\xce\x5b\x8c\xc6\xbb\xe9\x23\x2e\x9b\x06\xf5\xd0\x9e\xba\x1e\xe2\x17\xfd\x20\xc1\xcf\x62\x94\x23\xe6\x23\x60\x52\x09\xc9\xac\x14\x93\x5a\x6f\xb8\x9b\xb4\x1c\x17\x4f\xef\xa8\x60\xac\x1e\xba\x4e\xc8\x82\xbd\x06\x00\x94\xa7\xeb\xe0\xb9\x00\x00\x00")
+
+func _1528395670_discussion_threads_closed_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395670_discussion_threads_closed_atUpSql,
+		"1528395670_discussion_threads_closed_at.up.sql",
+	)
+}
+
+func _1528395670_discussion_threads_closed_atUpSql() (*asset, error) {
+	bytes, err := _1528395670_discussion_threads_closed_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395670_discussion_threads_closed_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7b, 0x54, 0x5d, 0x58, 0xb2, 0x7c, 0x52, 0xfe, 0xb7, 0x59, 0x8c, 0x90, 0x5, 0xdb, 0x38, 0x8c, 0xa5, 0x5b, 0x44, 0x9, 0xad, 0xcd, 0x18, 0x70, 0xc8, 0xa0, 0x83, 0x7f, 0x62, 0x3d, 0x29, 0x4d}}
+	return a, nil
+}
+
+var __1528395671_discussion_comment_attachments_upload_token_sha256DownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcc\x3d\x0a\xc2\x30\x14\x00\xe0\x3d\xa7\x78\x67\x10\x74\xc9\x94\x36\x51\x02\xf9\xd1\x36\x01\xb7\x10\xd2\x40\x8b\x36\x11\xf2\x0a\x1e\x5f\xdc\x1c\x5c\xba\x7d\xd3\xd7\x89\x8b\x34\x94\x10\xa6\x9c\x18\xc0\xb1\x4e\x09\x98\x96\x96\xb6\xd6\x96\x5a\x42\xaa\xeb\x9a\x0b\x86\x88\x18\xd3\xfc\x65\x03\x3e\xd8\x2b\xf4\x56\x79\x6d\x40\x9e\x41\xdc\xe5\xe8\x46\xd8\x5e\xcf\x1a\xa7\x80\xf5\x91\x4b\x68\x73\x3c\x1c\x4f\x74\xcf\xca\x38\xff\x49\x8d\x75\xff\x62\xc0\xfc\x46\xf0\x46\xde\xbc\xa0\x84\xf4\x56\x6b\xe9\x28\xf9\x0c\x00\x68\x90\xdf\x89\xc5\x00\x00\x00")
+
+func _1528395671_discussion_comment_attachments_upload_token_sha256DownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395671_discussion_comment_attachments_upload_token_sha256DownSql,
+		"1528395671_discussion_comment_attachments_upload_token_sha256.down.sql",
+	)
+}
+
+func _1528395671_discussion_comment_attachments_upload_token_sha256DownSql() (*asset, error) {
+	bytes, err := _1528395671_discussion_comment_attachments_upload_token_sha256DownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395671_discussion_comment_attachments_upload_token_sha256.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6d, 0xe7, 0xc8, 0xf8, 0x6a, 0x4b, 0xf2, 0x52, 0x8c, 0xe, 0x6a, 0xd6, 0xd4, 0x36, 0xa0, 0x33, 0xeb, 0xe1, 0x17, 0x94, 0xa, 0xd1, 0x24, 0x9f, 0x16, 0xf, 0x8a, 0x4e, 0xfb, 0x53, 0x1d, 0x12}}
+	return a, nil
+}
+
+var __1528395671_discussion_comment_attachments_upload_token_sha256UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcf\xbf\x6a\xf3\x30\x14\x05\xf0\x5d\x4f\x71\xc6\xef\x83\x24\x43\xa1\x5d\x3c\xe5\x8f\x5b\x0c\x89\xdd\x26\x32\x74\x33\x37\xd2\x4d\x25\x92\x48\x41\x57\xa6\xf5\xdb\x97\x04\x17\x32\x74\xe9\x74\xef\x70\xce\x0f\xce\xa2\x7c\xa9\xea\x42\xa9\xe9\x14\xed\xe5\x14\xc9\x22\xc7\x23\x07\x01\x25\x86\xe4\x98\xd8\xc2\x91\x38\xb6\x13\x9c\xfc\x91\x41\xc6\xb0\xc8\x98\x9a\x41\x3b\xfe\x69\xc4\x03\x2e\x1c\xac\x0f\x1f\x57\xad\xbf\x69\x02\x43\x21\xc4\x8c\x3d\x8f\x0c\x1c\x27\xc6\xbf\xec\x78\x00\x7f\x5d\x7c\x62\xd0\x21\x73\x02\x05\xb8\xd8\x5f\xef\xf0\x49\xc3\xff\x09\x24\x22\xbb\x28\x7c\xaf\x9d\x7b\xb9\x59\x89\x73\xf2\x6c\x67\x6a\xbe\xd6\xe5\x16\x7a\xbe\x58\x97\xb0\x5e\x4c\x2f\xe2\x63\xe8\x4c\x3c\x9f\x39\xe4\x8e\x72\x26\xe3\xae\xaf\x60\xb5\x6d\x5e\xb1\x6c\xd6\xed\xa6\x46\xf5\x8c\xf2\xbd\xda\xe9\xdd\x28\x77\xb7\x0d\xc5\x5f\xb8\xf9\x6a\x75\xa7\xd5\x8d\xfe\x4d\xec\xc4\xd1\xc3\xe3\x13\xf6\x43\x66\x42\x5b\x57\x6f\x6d\x59\x28\xb5\x6c\x36\x9b\x4a\x17\xea\x7b\x00\x14\x53\x4f\x83\x7e\x01\x00\x00")
+
+func _1528395671_discussion_comment_attachments_upload_token_sha256UpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395671_discussion_comment_attachments_upload_token_sha256UpSql,
+		"1528395671_discussion_comment_attachments_upload_token_sha256.up.sql",
+	)
+}
+
+func _1528395671_discussion_comment_attachments_upload_token_sha256UpSql() (*asset, error) {
+	bytes, err := _1528395671_discussion_comment_attachments_upload_token_sha256UpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395671_discussion_comment_attachments_upload_token_sha256.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc9, 0xec, 0xcb, 0x7e, 0xb7, 0xbf, 0x65, 0x91, 0x4a, 0xef, 0x8d, 0x57, 0xcd, 0xec, 0x5e, 0xe7, 0x7a, 0xa5, 0x7, 0xd5, 0x54, 0x5f, 0x4f, 0x8, 0xa4, 0xdd, 0x88, 0x34, 0xf2, 0xaf, 0x3b, 0xce}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -1251,58 +3015,142 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"1528395604_squashed_migrations.down.sql":                            _1528395604_squashed_migrationsDownSql,
-	"1528395604_squashed_migrations.up.sql":                              _1528395604_squashed_migrationsUpSql,
-	"1528395605_drop_recent_searches.down.sql":                           _1528395605_drop_recent_searchesDownSql,
-	"1528395605_drop_recent_searches.up.sql":                             _1528395605_drop_recent_searchesUpSql,
-	"1528395606_lsif_add_visible_at_tip_flag.down.sql":                   _1528395606_lsif_add_visible_at_tip_flagDownSql,
-	"1528395606_lsif_add_visible_at_tip_flag.up.sql":                     _1528395606_lsif_add_visible_at_tip_flagUpSql,
-	"1528395607_lsif_add_dump_uploaded_at.down.sql":                      _1528395607_lsif_add_dump_uploaded_atDownSql,
-	"1528395607_lsif_add_dump_uploaded_at.up.sql":                        _1528395607_lsif_add_dump_uploaded_atUpSql,
-	"1528395608_create_campaign_plans_table.down.sql":                    _1528395608_create_campaign_plans_tableDownSql,
-	"1528395608_create_campaign_plans_table.up.sql":                      _1528395608_create_campaign_plans_tableUpSql,
-	"1528395609_create_campaign_jobs_table.down.sql":                     _1528395609_create_campaign_jobs_tableDownSql,
-	"1528395609_create_campaign_jobs_table.up.sql":                       _1528395609_create_campaign_jobs_tableUpSql,
-	"1528395610_change_campaign_plan_arguments_to_text.down.sql":         _1528395610_change_campaign_plan_arguments_to_textDownSql,
-	"1528395610_change_campaign_plan_arguments_to_text.up.sql":           _1528395610_change_campaign_plan_arguments_to_textUpSql,
-	"1528395611_add_unique_constraint_to_campaign_jobs.down.sql":         _1528395611_add_unique_constraint_to_campaign_jobsDownSql,
-	"1528395611_add_unique_constraint_to_campaign_jobs.up.sql":           _1528395611_add_unique_constraint_to_campaign_jobsUpSql,
-	"1528395612_validate_campaign_plan_completion_with_trigger.down.sql": _1528395612_validate_campaign_plan_completion_with_triggerDownSql,
-	"1528395612_validate_campaign_plan_completion_with_trigger.up.sql":   _1528395612_validate_campaign_plan_completion_with_triggerUpSql,
-	"1528395613_create_changeset_jobs_table.down.sql":                    _1528395613_create_changeset_jobs_tableDownSql,
-	"1528395613_create_changeset_jobs_table.up.sql":                      _1528395613_create_changeset_jobs_tableUpSql,
-	"1528395614_lsif_nullable_parent_commits.down.sql":                   _1528395614_lsif_nullable_parent_commitsDownSql,
-	"1528395614_lsif_nullable_parent_commits.up.sql":                     _1528395614_lsif_nullable_parent_commitsUpSql,
-	"1528395615_lsif_commit_constraints.down.sql":                        _1528395615_lsif_commit_constraintsDownSql,
-	"1528395615_lsif_commit_constraints.up.sql":                          _1528395615_lsif_commit_constraintsUpSql,
-	"1528395616_lsif_processed_at.down.sql":                              _1528395616_lsif_processed_atDownSql,
-	"1528395616_lsif_processed_at.up.sql":                                _1528395616_lsif_processed_atUpSql,
-	"1528395617_add_base_ref_to_campaign_jobs.down.sql":                  _1528395617_add_base_ref_to_campaign_jobsDownSql,
-	"1528395617_add_base_ref_to_campaign_jobs.up.sql":                    _1528395617_add_base_ref_to_campaign_jobsUpSql,
-	"1528395618_add_delete_cascade_to_changeset_jobs.down.sql":           _1528395618_add_delete_cascade_to_changeset_jobsDownSql,
-	"1528395618_add_delete_cascade_to_changeset_jobs.up.sql":             _1528395618_add_delete_cascade_to_changeset_jobsUpSql,
-	"1528395619_remove_unused_indexes.down.sql":                          _1528395619_remove_unused_indexesDownSql,
-	"1528395619_remove_unused_indexes.up.sql":                            _1528395619_remove_unused_indexesUpSql,
-	"1528395620_add_description_to_campaign_jobs.down.sql":               _1528395620_add_description_to_campaign_jobsDownSql,
-	"1528395620_add_description_to_campaign_jobs.up.sql":                 _1528395620_add_description_to_campaign_jobsUpSql,
-	"1528395621_add_delete_cascade_to_campaign_jobs.down.sql":            _1528395621_add_delete_cascade_to_campaign_jobsDownSql,
-	"1528395621_add_delete_cascade_to_campaign_jobs.up.sql":              _1528395621_add_delete_cascade_to_campaign_jobsUpSql,
-	"1528395622_add_more_delete_cascades_to_changeset_jobs.down.sql":     _1528395622_add_more_delete_cascades_to_changeset_jobsDownSql,
-	"1528395622_add_more_delete_cascades_to_changeset_jobs.up.sql":       _1528395622_add_more_delete_cascades_to_changeset_jobsUpSql,
-	"1528395623_add_canceled_at_to_campaign_plan.down.sql":               _1528395623_add_canceled_at_to_campaign_planDownSql,
-	"1528395623_add_canceled_at_to_campaign_plan.up.sql":                 _1528395623_add_canceled_at_to_campaign_planUpSql,
-	"1528395624_add_closed_at_to_campaigns.down.sql":                     _1528395624_add_closed_at_to_campaignsDownSql,
-	"1528395624_add_closed_at_to_campaigns.up.sql":                       _1528395624_add_closed_at_to_campaignsUpSql,
-	"1528395625_lsif_uploads.down.sql":                                   _1528395625_lsif_uploadsDownSql,
-	"1528395625_lsif_uploads.up.sql":                                     _1528395625_lsif_uploadsUpSql,
-	"1528395626_create_explicit_repo_permissions_tables.down.sql":        _1528395626_create_explicit_repo_permissions_tablesDownSql,
-	"1528395626_create_explicit_repo_permissions_tables.up.sql":          _1528395626_create_explicit_repo_permissions_tablesUpSql,
-	"1528395627_add_external_deleted_at_to_changesets.down.sql":          _1528395627_add_external_deleted_at_to_changesetsDownSql,
-	"1528395627_add_external_deleted_at_to_changesets.up.sql":            _1528395627_add_external_deleted_at_to_changesetsUpSql,
-	"1528395628_add_published_at_to_campaigns.down.sql":                  _1528395628_add_published_at_to_campaignsDownSql,
-	"1528395628_add_published_at_to_campaigns.up.sql":                    _1528395628_add_published_at_to_campaignsUpSql,
-	"1528395629_repo_external_always.down.sql":                           _1528395629_repo_external_alwaysDownSql,
-	"1528395629_repo_external_always.up.sql":                             _1528395629_repo_external_alwaysUpSql,
+	"1528395604_squashed_migrations.down.sql":                                _1528395604_squashed_migrationsDownSql,
+	"1528395604_squashed_migrations.up.sql":                                  _1528395604_squashed_migrationsUpSql,
+	"1528395605_drop_recent_searches.down.sql":                               _1528395605_drop_recent_searchesDownSql,
+	"1528395605_drop_recent_searches.up.sql":                                 _1528395605_drop_recent_searchesUpSql,
+	"1528395606_lsif_add_visible_at_tip_flag.down.sql":                       _1528395606_lsif_add_visible_at_tip_flagDownSql,
+	"1528395606_lsif_add_visible_at_tip_flag.up.sql":                         _1528395606_lsif_add_visible_at_tip_flagUpSql,
+	"1528395607_lsif_add_dump_uploaded_at.down.sql":                          _1528395607_lsif_add_dump_uploaded_atDownSql,
+	"1528395607_lsif_add_dump_uploaded_at.up.sql":                            _1528395607_lsif_add_dump_uploaded_atUpSql,
+	"1528395608_create_campaign_plans_table.down.sql":                        _1528395608_create_campaign_plans_tableDownSql,
+	"1528395608_create_campaign_plans_table.up.sql":                          _1528395608_create_campaign_plans_tableUpSql,
+	"1528395609_create_campaign_jobs_table.down.sql":                         _1528395609_create_campaign_jobs_tableDownSql,
+	"1528395609_create_campaign_jobs_table.up.sql":                           _1528395609_create_campaign_jobs_tableUpSql,
+	"1528395610_change_campaign_plan_arguments_to_text.down.sql":             _1528395610_change_campaign_plan_arguments_to_textDownSql,
+	"1528395610_change_campaign_plan_arguments_to_text.up.sql":               _1528395610_change_campaign_plan_arguments_to_textUpSql,
+	"1528395611_add_unique_constraint_to_campaign_jobs.down.sql":             _1528395611_add_unique_constraint_to_campaign_jobsDownSql,
+	"1528395611_add_unique_constraint_to_campaign_jobs.up.sql":               _1528395611_add_unique_constraint_to_campaign_jobsUpSql,
+	"1528395612_validate_campaign_plan_completion_with_trigger.down.sql":     _1528395612_validate_campaign_plan_completion_with_triggerDownSql,
+	"1528395612_validate_campaign_plan_completion_with_trigger.up.sql":       _1528395612_validate_campaign_plan_completion_with_triggerUpSql,
+	"1528395613_create_changeset_jobs_table.down.sql":                        _1528395613_create_changeset_jobs_tableDownSql,
+	"1528395613_create_changeset_jobs_table.up.sql":                          _1528395613_create_changeset_jobs_tableUpSql,
+	"1528395614_lsif_nullable_parent_commits.down.sql":                       _1528395614_lsif_nullable_parent_commitsDownSql,
+	"1528395614_lsif_nullable_parent_commits.up.sql":                         _1528395614_lsif_nullable_parent_commitsUpSql,
+	"1528395615_lsif_commit_constraints.down.sql":                            _1528395615_lsif_commit_constraintsDownSql,
+	"1528395615_lsif_commit_constraints.up.sql":                              _1528395615_lsif_commit_constraintsUpSql,
+	"1528395616_lsif_processed_at.down.sql":                                  _1528395616_lsif_processed_atDownSql,
+	"1528395616_lsif_processed_at.up.sql":                                    _1528395616_lsif_processed_atUpSql,
+	"1528395617_add_base_ref_to_campaign_jobs.down.sql":                      _1528395617_add_base_ref_to_campaign_jobsDownSql,
+	"1528395617_add_base_ref_to_campaign_jobs.up.sql":                        _1528395617_add_base_ref_to_campaign_jobsUpSql,
+	"1528395618_add_delete_cascade_to_changeset_jobs.down.sql":               _1528395618_add_delete_cascade_to_changeset_jobsDownSql,
+	"1528395618_add_delete_cascade_to_changeset_jobs.up.sql":                 _1528395618_add_delete_cascade_to_changeset_jobsUpSql,
+	"1528395619_remove_unused_indexes.down.sql":                              _1528395619_remove_unused_indexesDownSql,
+	"1528395619_remove_unused_indexes.up.sql":                                _1528395619_remove_unused_indexesUpSql,
+	"1528395620_add_description_to_campaign_jobs.down.sql":                   _1528395620_add_description_to_campaign_jobsDownSql,
+	"1528395620_add_description_to_campaign_jobs.up.sql":                     _1528395620_add_description_to_campaign_jobsUpSql,
+	"1528395621_add_delete_cascade_to_campaign_jobs.down.sql":                _1528395621_add_delete_cascade_to_campaign_jobsDownSql,
+	"1528395621_add_delete_cascade_to_campaign_jobs.up.sql":                  _1528395621_add_delete_cascade_to_campaign_jobsUpSql,
+	"1528395622_add_more_delete_cascades_to_changeset_jobs.down.sql":         _1528395622_add_more_delete_cascades_to_changeset_jobsDownSql,
+	"1528395622_add_more_delete_cascades_to_changeset_jobs.up.sql":           _1528395622_add_more_delete_cascades_to_changeset_jobsUpSql,
+	"1528395623_add_canceled_at_to_campaign_plan.down.sql":                   _1528395623_add_canceled_at_to_campaign_planDownSql,
+	"1528395623_add_canceled_at_to_campaign_plan.up.sql":                     _1528395623_add_canceled_at_to_campaign_planUpSql,
+	"1528395624_add_closed_at_to_campaigns.down.sql":                         _1528395624_add_closed_at_to_campaignsDownSql,
+	"1528395624_add_closed_at_to_campaigns.up.sql":                           _1528395624_add_closed_at_to_campaignsUpSql,
+	"1528395625_lsif_uploads.down.sql":                                       _1528395625_lsif_uploadsDownSql,
+	"1528395625_lsif_uploads.up.sql":                                         _1528395625_lsif_uploadsUpSql,
+	"1528395626_create_explicit_repo_permissions_tables.down.sql":            _1528395626_create_explicit_repo_permissions_tablesDownSql,
+	"1528395626_create_explicit_repo_permissions_tables.up.sql":              _1528395626_create_explicit_repo_permissions_tablesUpSql,
+	"1528395627_add_external_deleted_at_to_changesets.down.sql":              _1528395627_add_external_deleted_at_to_changesetsDownSql,
+	"1528395627_add_external_deleted_at_to_changesets.up.sql":                _1528395627_add_external_deleted_at_to_changesetsUpSql,
+	"1528395628_add_published_at_to_campaigns.down.sql":                      _1528395628_add_published_at_to_campaignsDownSql,
+	"1528395628_add_published_at_to_campaigns.up.sql":                        _1528395628_add_published_at_to_campaignsUpSql,
+	"1528395629_repo_external_always.down.sql":                               _1528395629_repo_external_alwaysDownSql,
+	"1528395629_repo_external_always.up.sql":                                 _1528395629_repo_external_alwaysUpSql,
+	"1528395630_discussion_labels.down.sql":                                  _1528395630_discussion_labelsDownSql,
+	"1528395630_discussion_labels.up.sql":                                    _1528395630_discussion_labelsUpSql,
+	"1528395631_discussion_threads_assignees.down.sql":                       _1528395631_discussion_threads_assigneesDownSql,
+	"1528395631_discussion_threads_assignees.up.sql":                         _1528395631_discussion_threads_assigneesUpSql,
+	"1528395632_discussion_thread_events.down.sql":                           _1528395632_discussion_thread_eventsDownSql,
+	"1528395632_discussion_thread_events.up.sql":                             _1528395632_discussion_thread_eventsUpSql,
+	"1528395633_discussion_threads_updated_at_idx.down.sql":                  _1528395633_discussion_threads_updated_at_idxDownSql,
+	"1528395633_discussion_threads_updated_at_idx.up.sql":                    _1528395633_discussion_threads_updated_at_idxUpSql,
+	"1528395634_discussion_threads_fulltext_idx.down.sql":                    _1528395634_discussion_threads_fulltext_idxDownSql,
+	"1528395634_discussion_threads_fulltext_idx.up.sql":                      _1528395634_discussion_threads_fulltext_idxUpSql,
+	"1528395635_discussion_thread_subscriptions.down.sql":                    _1528395635_discussion_thread_subscriptionsDownSql,
+	"1528395635_discussion_thread_subscriptions.up.sql":                      _1528395635_discussion_thread_subscriptionsUpSql,
+	"1528395636_discussion_threads_locked_at.down.sql":                       _1528395636_discussion_threads_locked_atDownSql,
+	"1528395636_discussion_threads_locked_at.up.sql":                         _1528395636_discussion_threads_locked_atUpSql,
+	"1528395637_discussion_milestones.down.sql":                              _1528395637_discussion_milestonesDownSql,
+	"1528395637_discussion_milestones.up.sql":                                _1528395637_discussion_milestonesUpSql,
+	"1528395638_discussion_thread_templates.down.sql":                        _1528395638_discussion_thread_templatesDownSql,
+	"1528395638_discussion_thread_templates.up.sql":                          _1528395638_discussion_thread_templatesUpSql,
+	"1528395639_discussion_webhooks.down.sql":                                _1528395639_discussion_webhooksDownSql,
+	"1528395639_discussion_webhooks.up.sql":                                  _1528395639_discussion_webhooksUpSql,
+	"1528395640_discussion_unsubscribe_tokens.down.sql":                      _1528395640_discussion_unsubscribe_tokensDownSql,
+	"1528395640_discussion_unsubscribe_tokens.up.sql":                        _1528395640_discussion_unsubscribe_tokensUpSql,
+	"1528395641_discussion_comment_reactions.down.sql":                       _1528395641_discussion_comment_reactionsDownSql,
+	"1528395641_discussion_comment_reactions.up.sql":                         _1528395641_discussion_comment_reactionsUpSql,
+	"1528395642_discussion_comment_mentions.down.sql":                        _1528395642_discussion_comment_mentionsDownSql,
+	"1528395642_discussion_comment_mentions.up.sql":                          _1528395642_discussion_comment_mentionsUpSql,
+	"1528395643_discussion_comment_edits.down.sql":                           _1528395643_discussion_comment_editsDownSql,
+	"1528395643_discussion_comment_edits.up.sql":                             _1528395643_discussion_comment_editsUpSql,
+	"1528395644_discussion_comments_parent.down.sql":                         _1528395644_discussion_comments_parentDownSql,
+	"1528395644_discussion_comments_parent.up.sql":                           _1528395644_discussion_comments_parentUpSql,
+	"1528395645_discussion_comment_suggestions.down.sql":                     _1528395645_discussion_comment_suggestionsDownSql,
+	"1528395645_discussion_comment_suggestions.up.sql":                       _1528395645_discussion_comment_suggestionsUpSql,
+	"1528395646_discussion_comments_resolved.down.sql":                       _1528395646_discussion_comments_resolvedDownSql,
+	"1528395646_discussion_comments_resolved.up.sql":                         _1528395646_discussion_comments_resolvedUpSql,
+	"1528395647_discussion_comment_attachments.down.sql":                     _1528395647_discussion_comment_attachmentsDownSql,
+	"1528395647_discussion_comment_attachments.up.sql":                       _1528395647_discussion_comment_attachmentsUpSql,
+	"1528395648_discussion_thread_references.down.sql":                       _1528395648_discussion_thread_referencesDownSql,
+	"1528395648_discussion_thread_references.up.sql":                         _1528395648_discussion_thread_referencesUpSql,
+	"1528395649_discussion_threads_stale_at.down.sql":                        _1528395649_discussion_threads_stale_atDownSql,
+	"1528395649_discussion_threads_stale_at.up.sql":                          _1528395649_discussion_threads_stale_atUpSql,
+	"1528395650_discussion_threads_duplicate_of.down.sql":                    _1528395650_discussion_threads_duplicate_ofDownSql,
+	"1528395650_discussion_threads_duplicate_of.up.sql":                      _1528395650_discussion_threads_duplicate_ofUpSql,
+	"1528395651_discussion_threads_pinned_at.down.sql":                       _1528395651_discussion_threads_pinned_atDownSql,
+	"1528395651_discussion_threads_pinned_at.up.sql":                         _1528395651_discussion_threads_pinned_atUpSql,
+	"1528395652_discussion_audit_log.down.sql":                               _1528395652_discussion_audit_logDownSql,
+	"1528395652_discussion_audit_log.up.sql":                                 _1528395652_discussion_audit_logUpSql,
+	"1528395653_discussion_thread_subscriptions_notification_level.down.sql": _1528395653_discussion_thread_subscriptions_notification_levelDownSql,
+	"1528395653_discussion_thread_subscriptions_notification_level.up.sql":   _1528395653_discussion_thread_subscriptions_notification_levelUpSql,
+	"1528395654_discussion_moderation.down.sql":                              _1528395654_discussion_moderationDownSql,
+	"1528395654_discussion_moderation.up.sql":                                _1528395654_discussion_moderationUpSql,
+	"1528395655_discussion_thread_reads.down.sql":                            _1528395655_discussion_thread_readsDownSql,
+	"1528395655_discussion_thread_reads.up.sql":                              _1528395655_discussion_thread_readsUpSql,
+	"1528395656_discussion_saved_replies.down.sql":                           _1528395656_discussion_saved_repliesDownSql,
+	"1528395656_discussion_saved_replies.up.sql":                             _1528395656_discussion_saved_repliesUpSql,
+	"1528395657_discussion_thread_dependencies.down.sql":                     _1528395657_discussion_thread_dependenciesDownSql,
+	"1528395657_discussion_thread_dependencies.up.sql":                       _1528395657_discussion_thread_dependenciesUpSql,
+	"1528395658_discussion_threads_counts.down.sql":                          _1528395658_discussion_threads_countsDownSql,
+	"1528395658_discussion_threads_counts.up.sql":                            _1528395658_discussion_threads_countsUpSql,
+	"1528395659_discussion_threads_order_indexes.down.sql":                   _1528395659_discussion_threads_order_indexesDownSql,
+	"1528395659_discussion_threads_order_indexes.up.sql":                     _1528395659_discussion_threads_order_indexesUpSql,
+	"1528395660_discussion_threads_idempotency_key.down.sql":                 _1528395660_discussion_threads_idempotency_keyDownSql,
+	"1528395660_discussion_threads_idempotency_key.up.sql":                   _1528395660_discussion_threads_idempotency_keyUpSql,
+	"1528395661_discussion_thread_numbers.down.sql":                          _1528395661_discussion_thread_numbersDownSql,
+	"1528395661_discussion_thread_numbers.up.sql":                            _1528395661_discussion_thread_numbersUpSql,
+	"1528395662_discussion_roles.down.sql":                                   _1528395662_discussion_rolesDownSql,
+	"1528395662_discussion_roles.up.sql":                                     _1528395662_discussion_rolesUpSql,
+	"1528395663_discussion_threads_target_repo_thread_id_idx.down.sql":       _1528395663_discussion_threads_target_repo_thread_id_idxDownSql,
+	"1528395663_discussion_threads_target_repo_thread_id_idx.up.sql":         _1528395663_discussion_threads_target_repo_thread_id_idxUpSql,
+	"1528395664_discussion_threads_open_updated_at_id_idx.down.sql":          _1528395664_discussion_threads_open_updated_at_id_idxDownSql,
+	"1528395664_discussion_threads_open_updated_at_id_idx.up.sql":            _1528395664_discussion_threads_open_updated_at_id_idxUpSql,
+	"1528395665_discussion_threads_repo_deleted_at.down.sql":                 _1528395665_discussion_threads_repo_deleted_atDownSql,
+	"1528395665_discussion_threads_repo_deleted_at.up.sql":                   _1528395665_discussion_threads_repo_deleted_atUpSql,
+	"1528395666_discussion_feed_tokens.down.sql":                             _1528395666_discussion_feed_tokensDownSql,
+	"1528395666_discussion_feed_tokens.up.sql":                               _1528395666_discussion_feed_tokensUpSql,
+	"1528395667_discussion_thread_diagnostics.down.sql":                      _1528395667_discussion_thread_diagnosticsDownSql,
+	"1528395667_discussion_thread_diagnostics.up.sql":                        _1528395667_discussion_thread_diagnosticsUpSql,
+	"1528395668_discussion_rules.down.sql":                                   _1528395668_discussion_rulesDownSql,
+	"1528395668_discussion_rules.up.sql":                                     _1528395668_discussion_rulesUpSql,
+	"1528395669_discussion_thread_previous_numbers.down.sql":                 _1528395669_discussion_thread_previous_numbersDownSql,
+	"1528395669_discussion_thread_previous_numbers.up.sql":                   _1528395669_discussion_thread_previous_numbersUpSql,
+	"1528395670_discussion_threads_closed_at.down.sql":                       _1528395670_discussion_threads_closed_atDownSql,
+	"1528395670_discussion_threads_closed_at.up.sql":                         _1528395670_discussion_threads_closed_atUpSql,
+	"1528395671_discussion_comment_attachments_upload_token_sha256.down.sql": _1528395671_discussion_comment_attachments_upload_token_sha256DownSql,
+	"1528395671_discussion_comment_attachments_upload_token_sha256.up.sql":   _1528395671_discussion_comment_attachments_upload_token_sha256UpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -1346,58 +3194,142 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"1528395604_squashed_migrations.down.sql":                            {_1528395604_squashed_migrationsDownSql, map[string]*bintree{}},
-	"1528395604_squashed_migrations.up.sql":                              {_1528395604_squashed_migrationsUpSql, map[string]*bintree{}},
-	"1528395605_drop_recent_searches.down.sql":                           {_1528395605_drop_recent_searchesDownSql, map[string]*bintree{}},
-	"1528395605_drop_recent_searches.up.sql":                             {_1528395605_drop_recent_searchesUpSql, map[string]*bintree{}},
-	"1528395606_lsif_add_visible_at_tip_flag.down.sql":                   {_1528395606_lsif_add_visible_at_tip_flagDownSql, map[string]*bintree{}},
-	"1528395606_lsif_add_visible_at_tip_flag.up.sql":                     {_1528395606_lsif_add_visible_at_tip_flagUpSql, map[string]*bintree{}},
-	"1528395607_lsif_add_dump_uploaded_at.down.sql":                      {_1528395607_lsif_add_dump_uploaded_atDownSql, map[string]*bintree{}},
-	"1528395607_lsif_add_dump_uploaded_at.up.sql":                        {_1528395607_lsif_add_dump_uploaded_atUpSql, map[string]*bintree{}},
-	"1528395608_create_campaign_plans_table.down.sql":                    {_1528395608_create_campaign_plans_tableDownSql, map[string]*bintree{}},
-	"1528395608_create_campaign_plans_table.up.sql":                      {_1528395608_create_campaign_plans_tableUpSql, map[string]*bintree{}},
-	"1528395609_create_campaign_jobs_table.down.sql":                     {_1528395609_create_campaign_jobs_tableDownSql, map[string]*bintree{}},
-	"1528395609_create_campaign_jobs_table.up.sql":                       {_1528395609_create_campaign_jobs_tableUpSql, map[string]*bintree{}},
-	"1528395610_change_campaign_plan_arguments_to_text.down.sql":         {_1528395610_change_campaign_plan_arguments_to_textDownSql, map[string]*bintree{}},
-	"1528395610_change_campaign_plan_arguments_to_text.up.sql":           {_1528395610_change_campaign_plan_arguments_to_textUpSql, map[string]*bintree{}},
-	"1528395611_add_unique_constraint_to_campaign_jobs.down.sql":         {_1528395611_add_unique_constraint_to_campaign_jobsDownSql, map[string]*bintree{}},
-	"1528395611_add_unique_constraint_to_campaign_jobs.up.sql":           {_1528395611_add_unique_constraint_to_campaign_jobsUpSql, map[string]*bintree{}},
-	"1528395612_validate_campaign_plan_completion_with_trigger.down.sql": {_1528395612_validate_campaign_plan_completion_with_triggerDownSql, map[string]*bintree{}},
-	"1528395612_validate_campaign_plan_completion_with_trigger.up.sql":   {_1528395612_validate_campaign_plan_completion_with_triggerUpSql, map[string]*bintree{}},
-	"1528395613_create_changeset_jobs_table.down.sql":                    {_1528395613_create_changeset_jobs_tableDownSql, map[string]*bintree{}},
-	"1528395613_create_changeset_jobs_table.up.sql":                      {_1528395613_create_changeset_jobs_tableUpSql, map[string]*bintree{}},
-	"1528395614_lsif_nullable_parent_commits.down.sql":                   {_1528395614_lsif_nullable_parent_commitsDownSql, map[string]*bintree{}},
-	"1528395614_lsif_nullable_parent_commits.up.sql":                     {_1528395614_lsif_nullable_parent_commitsUpSql, map[string]*bintree{}},
-	"1528395615_lsif_commit_constraints.down.sql":                        {_1528395615_lsif_commit_constraintsDownSql, map[string]*bintree{}},
-	"1528395615_lsif_commit_constraints.up.sql":                          {_1528395615_lsif_commit_constraintsUpSql, map[string]*bintree{}},
-	"1528395616_lsif_processed_at.down.sql":                              {_1528395616_lsif_processed_atDownSql, map[string]*bintree{}},
-	"1528395616_lsif_processed_at.up.sql":                                {_1528395616_lsif_processed_atUpSql, map[string]*bintree{}},
-	"1528395617_add_base_ref_to_campaign_jobs.down.sql":                  {_1528395617_add_base_ref_to_campaign_jobsDownSql, map[string]*bintree{}},
-	"1528395617_add_base_ref_to_campaign_jobs.up.sql":                    {_1528395617_add_base_ref_to_campaign_jobsUpSql, map[string]*bintree{}},
-	"1528395618_add_delete_cascade_to_changeset_jobs.down.sql":           {_1528395618_add_delete_cascade_to_changeset_jobsDownSql, map[string]*bintree{}},
-	"1528395618_add_delete_cascade_to_changeset_jobs.up.sql":             {_1528395618_add_delete_cascade_to_changeset_jobsUpSql, map[string]*bintree{}},
-	"1528395619_remove_unused_indexes.down.sql":                          {_1528395619_remove_unused_indexesDownSql, map[string]*bintree{}},
-	"1528395619_remove_unused_indexes.up.sql":                            {_1528395619_remove_unused_indexesUpSql, map[string]*bintree{}},
-	"1528395620_add_description_to_campaign_jobs.down.sql":               {_1528395620_add_description_to_campaign_jobsDownSql, map[string]*bintree{}},
-	"1528395620_add_description_to_campaign_jobs.up.sql":                 {_1528395620_add_description_to_campaign_jobsUpSql, map[string]*bintree{}},
-	"1528395621_add_delete_cascade_to_campaign_jobs.down.sql":            {_1528395621_add_delete_cascade_to_campaign_jobsDownSql, map[string]*bintree{}},
-	"1528395621_add_delete_cascade_to_campaign_jobs.up.sql":              {_1528395621_add_delete_cascade_to_campaign_jobsUpSql, map[string]*bintree{}},
-	"1528395622_add_more_delete_cascades_to_changeset_jobs.down.sql":     {_1528395622_add_more_delete_cascades_to_changeset_jobsDownSql, map[string]*bintree{}},
-	"1528395622_add_more_delete_cascades_to_changeset_jobs.up.sql":       {_1528395622_add_more_delete_cascades_to_changeset_jobsUpSql, map[string]*bintree{}},
-	"1528395623_add_canceled_at_to_campaign_plan.down.sql":               {_1528395623_add_canceled_at_to_campaign_planDownSql, map[string]*bintree{}},
-	"1528395623_add_canceled_at_to_campaign_plan.up.sql":                 {_1528395623_add_canceled_at_to_campaign_planUpSql, map[string]*bintree{}},
-	"1528395624_add_closed_at_to_campaigns.down.sql":                     {_1528395624_add_closed_at_to_campaignsDownSql, map[string]*bintree{}},
-	"1528395624_add_closed_at_to_campaigns.up.sql":                       {_1528395624_add_closed_at_to_campaignsUpSql, map[string]*bintree{}},
-	"1528395625_lsif_uploads.down.sql":                                   {_1528395625_lsif_uploadsDownSql, map[string]*bintree{}},
-	"1528395625_lsif_uploads.up.sql":                                     {_1528395625_lsif_uploadsUpSql, map[string]*bintree{}},
-	"1528395626_create_explicit_repo_permissions_tables.down.sql":        {_1528395626_create_explicit_repo_permissions_tablesDownSql, map[string]*bintree{}},
-	"1528395626_create_explicit_repo_permissions_tables.up.sql":          {_1528395626_create_explicit_repo_permissions_tablesUpSql, map[string]*bintree{}},
-	"1528395627_add_external_deleted_at_to_changesets.down.sql":          {_1528395627_add_external_deleted_at_to_changesetsDownSql, map[string]*bintree{}},
-	"1528395627_add_external_deleted_at_to_changesets.up.sql":            {_1528395627_add_external_deleted_at_to_changesetsUpSql, map[string]*bintree{}},
-	"1528395628_add_published_at_to_campaigns.down.sql":                  {_1528395628_add_published_at_to_campaignsDownSql, map[string]*bintree{}},
-	"1528395628_add_published_at_to_campaigns.up.sql":                    {_1528395628_add_published_at_to_campaignsUpSql, map[string]*bintree{}},
-	"1528395629_repo_external_always.down.sql":                           {_1528395629_repo_external_alwaysDownSql, map[string]*bintree{}},
-	"1528395629_repo_external_always.up.sql":                             {_1528395629_repo_external_alwaysUpSql, map[string]*bintree{}},
+	"1528395604_squashed_migrations.down.sql":                                {_1528395604_squashed_migrationsDownSql, map[string]*bintree{}},
+	"1528395604_squashed_migrations.up.sql":                                  {_1528395604_squashed_migrationsUpSql, map[string]*bintree{}},
+	"1528395605_drop_recent_searches.down.sql":                               {_1528395605_drop_recent_searchesDownSql, map[string]*bintree{}},
+	"1528395605_drop_recent_searches.up.sql":                                 {_1528395605_drop_recent_searchesUpSql, map[string]*bintree{}},
+	"1528395606_lsif_add_visible_at_tip_flag.down.sql":                       {_1528395606_lsif_add_visible_at_tip_flagDownSql, map[string]*bintree{}},
+	"1528395606_lsif_add_visible_at_tip_flag.up.sql":                         {_1528395606_lsif_add_visible_at_tip_flagUpSql, map[string]*bintree{}},
+	"1528395607_lsif_add_dump_uploaded_at.down.sql":                          {_1528395607_lsif_add_dump_uploaded_atDownSql, map[string]*bintree{}},
+	"1528395607_lsif_add_dump_uploaded_at.up.sql":                            {_1528395607_lsif_add_dump_uploaded_atUpSql, map[string]*bintree{}},
+	"1528395608_create_campaign_plans_table.down.sql":                        {_1528395608_create_campaign_plans_tableDownSql, map[string]*bintree{}},
+	"1528395608_create_campaign_plans_table.up.sql":                          {_1528395608_create_campaign_plans_tableUpSql, map[string]*bintree{}},
+	"1528395609_create_campaign_jobs_table.down.sql":                         {_1528395609_create_campaign_jobs_tableDownSql, map[string]*bintree{}},
+	"1528395609_create_campaign_jobs_table.up.sql":                           {_1528395609_create_campaign_jobs_tableUpSql, map[string]*bintree{}},
+	"1528395610_change_campaign_plan_arguments_to_text.down.sql":             {_1528395610_change_campaign_plan_arguments_to_textDownSql, map[string]*bintree{}},
+	"1528395610_change_campaign_plan_arguments_to_text.up.sql":               {_1528395610_change_campaign_plan_arguments_to_textUpSql, map[string]*bintree{}},
+	"1528395611_add_unique_constraint_to_campaign_jobs.down.sql":             {_1528395611_add_unique_constraint_to_campaign_jobsDownSql, map[string]*bintree{}},
+	"1528395611_add_unique_constraint_to_campaign_jobs.up.sql":               {_1528395611_add_unique_constraint_to_campaign_jobsUpSql, map[string]*bintree{}},
+	"1528395612_validate_campaign_plan_completion_with_trigger.down.sql":     {_1528395612_validate_campaign_plan_completion_with_triggerDownSql, map[string]*bintree{}},
+	"1528395612_validate_campaign_plan_completion_with_trigger.up.sql":       {_1528395612_validate_campaign_plan_completion_with_triggerUpSql, map[string]*bintree{}},
+	"1528395613_create_changeset_jobs_table.down.sql":                        {_1528395613_create_changeset_jobs_tableDownSql, map[string]*bintree{}},
+	"1528395613_create_changeset_jobs_table.up.sql":                          {_1528395613_create_changeset_jobs_tableUpSql, map[string]*bintree{}},
+	"1528395614_lsif_nullable_parent_commits.down.sql":                       {_1528395614_lsif_nullable_parent_commitsDownSql, map[string]*bintree{}},
+	"1528395614_lsif_nullable_parent_commits.up.sql":                         {_1528395614_lsif_nullable_parent_commitsUpSql, map[string]*bintree{}},
+	"1528395615_lsif_commit_constraints.down.sql":                            {_1528395615_lsif_commit_constraintsDownSql, map[string]*bintree{}},
+	"1528395615_lsif_commit_constraints.up.sql":                              {_1528395615_lsif_commit_constraintsUpSql, map[string]*bintree{}},
+	"1528395616_lsif_processed_at.down.sql":                                  {_1528395616_lsif_processed_atDownSql, map[string]*bintree{}},
+	"1528395616_lsif_processed_at.up.sql":                                    {_1528395616_lsif_processed_atUpSql, map[string]*bintree{}},
+	"1528395617_add_base_ref_to_campaign_jobs.down.sql":                      {_1528395617_add_base_ref_to_campaign_jobsDownSql, map[string]*bintree{}},
+	"1528395617_add_base_ref_to_campaign_jobs.up.sql":                        {_1528395617_add_base_ref_to_campaign_jobsUpSql, map[string]*bintree{}},
+	"1528395618_add_delete_cascade_to_changeset_jobs.down.sql":               {_1528395618_add_delete_cascade_to_changeset_jobsDownSql, map[string]*bintree{}},
+	"1528395618_add_delete_cascade_to_changeset_jobs.up.sql":                 {_1528395618_add_delete_cascade_to_changeset_jobsUpSql, map[string]*bintree{}},
+	"1528395619_remove_unused_indexes.down.sql":                              {_1528395619_remove_unused_indexesDownSql, map[string]*bintree{}},
+	"1528395619_remove_unused_indexes.up.sql":                                {_1528395619_remove_unused_indexesUpSql, map[string]*bintree{}},
+	"1528395620_add_description_to_campaign_jobs.down.sql":                   {_1528395620_add_description_to_campaign_jobsDownSql, map[string]*bintree{}},
+	"1528395620_add_description_to_campaign_jobs.up.sql":                     {_1528395620_add_description_to_campaign_jobsUpSql, map[string]*bintree{}},
+	"1528395621_add_delete_cascade_to_campaign_jobs.down.sql":                {_1528395621_add_delete_cascade_to_campaign_jobsDownSql, map[string]*bintree{}},
+	"1528395621_add_delete_cascade_to_campaign_jobs.up.sql":                  {_1528395621_add_delete_cascade_to_campaign_jobsUpSql, map[string]*bintree{}},
+	"1528395622_add_more_delete_cascades_to_changeset_jobs.down.sql":         {_1528395622_add_more_delete_cascades_to_changeset_jobsDownSql, map[string]*bintree{}},
+	"1528395622_add_more_delete_cascades_to_changeset_jobs.up.sql":           {_1528395622_add_more_delete_cascades_to_changeset_jobsUpSql, map[string]*bintree{}},
+	"1528395623_add_canceled_at_to_campaign_plan.down.sql":                   {_1528395623_add_canceled_at_to_campaign_planDownSql, map[string]*bintree{}},
+	"1528395623_add_canceled_at_to_campaign_plan.up.sql":                     {_1528395623_add_canceled_at_to_campaign_planUpSql, map[string]*bintree{}},
+	"1528395624_add_closed_at_to_campaigns.down.sql":                         {_1528395624_add_closed_at_to_campaignsDownSql, map[string]*bintree{}},
+	"1528395624_add_closed_at_to_campaigns.up.sql":                           {_1528395624_add_closed_at_to_campaignsUpSql, map[string]*bintree{}},
+	"1528395625_lsif_uploads.down.sql":                                       {_1528395625_lsif_uploadsDownSql, map[string]*bintree{}},
+	"1528395625_lsif_uploads.up.sql":                                         {_1528395625_lsif_uploadsUpSql, map[string]*bintree{}},
+	"1528395626_create_explicit_repo_permissions_tables.down.sql":            {_1528395626_create_explicit_repo_permissions_tablesDownSql, map[string]*bintree{}},
+	"1528395626_create_explicit_repo_permissions_tables.up.sql":              {_1528395626_create_explicit_repo_permissions_tablesUpSql, map[string]*bintree{}},
+	"1528395627_add_external_deleted_at_to_changesets.down.sql":              {_1528395627_add_external_deleted_at_to_changesetsDownSql, map[string]*bintree{}},
+	"1528395627_add_external_deleted_at_to_changesets.up.sql":                {_1528395627_add_external_deleted_at_to_changesetsUpSql, map[string]*bintree{}},
+	"1528395628_add_published_at_to_campaigns.down.sql":                      {_1528395628_add_published_at_to_campaignsDownSql, map[string]*bintree{}},
+	"1528395628_add_published_at_to_campaigns.up.sql":                        {_1528395628_add_published_at_to_campaignsUpSql, map[string]*bintree{}},
+	"1528395629_repo_external_always.down.sql":                               {_1528395629_repo_external_alwaysDownSql, map[string]*bintree{}},
+	"1528395629_repo_external_always.up.sql":                                 {_1528395629_repo_external_alwaysUpSql, map[string]*bintree{}},
+	"1528395630_discussion_labels.down.sql":                                  {_1528395630_discussion_labelsDownSql, map[string]*bintree{}},
+	"1528395630_discussion_labels.up.sql":                                    {_1528395630_discussion_labelsUpSql, map[string]*bintree{}},
+	"1528395631_discussion_threads_assignees.down.sql":                       {_1528395631_discussion_threads_assigneesDownSql, map[string]*bintree{}},
+	"1528395631_discussion_threads_assignees.up.sql":                         {_1528395631_discussion_threads_assigneesUpSql, map[string]*bintree{}},
+	"1528395632_discussion_thread_events.down.sql":                           {_1528395632_discussion_thread_eventsDownSql, map[string]*bintree{}},
+	"1528395632_discussion_thread_events.up.sql":                             {_1528395632_discussion_thread_eventsUpSql, map[string]*bintree{}},
+	"1528395633_discussion_threads_updated_at_idx.down.sql":                  {_1528395633_discussion_threads_updated_at_idxDownSql, map[string]*bintree{}},
+	"1528395633_discussion_threads_updated_at_idx.up.sql":                    {_1528395633_discussion_threads_updated_at_idxUpSql, map[string]*bintree{}},
+	"1528395634_discussion_threads_fulltext_idx.down.sql":                    {_1528395634_discussion_threads_fulltext_idxDownSql, map[string]*bintree{}},
+	"1528395634_discussion_threads_fulltext_idx.up.sql":                      {_1528395634_discussion_threads_fulltext_idxUpSql, map[string]*bintree{}},
+	"1528395635_discussion_thread_subscriptions.down.sql":                    {_1528395635_discussion_thread_subscriptionsDownSql, map[string]*bintree{}},
+	"1528395635_discussion_thread_subscriptions.up.sql":                      {_1528395635_discussion_thread_subscriptionsUpSql, map[string]*bintree{}},
+	"1528395636_discussion_threads_locked_at.down.sql":                       {_1528395636_discussion_threads_locked_atDownSql, map[string]*bintree{}},
+	"1528395636_discussion_threads_locked_at.up.sql":                         {_1528395636_discussion_threads_locked_atUpSql, map[string]*bintree{}},
+	"1528395637_discussion_milestones.down.sql":                              {_1528395637_discussion_milestonesDownSql, map[string]*bintree{}},
+	"1528395637_discussion_milestones.up.sql":                                {_1528395637_discussion_milestonesUpSql, map[string]*bintree{}},
+	"1528395638_discussion_thread_templates.down.sql":                        {_1528395638_discussion_thread_templatesDownSql, map[string]*bintree{}},
+	"1528395638_discussion_thread_templates.up.sql":                          {_1528395638_discussion_thread_templatesUpSql, map[string]*bintree{}},
+	"1528395639_discussion_webhooks.down.sql":                                {_1528395639_discussion_webhooksDownSql, map[string]*bintree{}},
+	"1528395639_discussion_webhooks.up.sql":                                  {_1528395639_discussion_webhooksUpSql, map[string]*bintree{}},
+	"1528395640_discussion_unsubscribe_tokens.down.sql":                      {_1528395640_discussion_unsubscribe_tokensDownSql, map[string]*bintree{}},
+	"1528395640_discussion_unsubscribe_tokens.up.sql":                        {_1528395640_discussion_unsubscribe_tokensUpSql, map[string]*bintree{}},
+	"1528395641_discussion_comment_reactions.down.sql":                       {_1528395641_discussion_comment_reactionsDownSql, map[string]*bintree{}},
+	"1528395641_discussion_comment_reactions.up.sql":                         {_1528395641_discussion_comment_reactionsUpSql, map[string]*bintree{}},
+	"1528395642_discussion_comment_mentions.down.sql":                        {_1528395642_discussion_comment_mentionsDownSql, map[string]*bintree{}},
+	"1528395642_discussion_comment_mentions.up.sql":                          {_1528395642_discussion_comment_mentionsUpSql, map[string]*bintree{}},
+	"1528395643_discussion_comment_edits.down.sql":                           {_1528395643_discussion_comment_editsDownSql, map[string]*bintree{}},
+	"1528395643_discussion_comment_edits.up.sql":                             {_1528395643_discussion_comment_editsUpSql, map[string]*bintree{}},
+	"1528395644_discussion_comments_parent.down.sql":                         {_1528395644_discussion_comments_parentDownSql, map[string]*bintree{}},
+	"1528395644_discussion_comments_parent.up.sql":                           {_1528395644_discussion_comments_parentUpSql, map[string]*bintree{}},
+	"1528395645_discussion_comment_suggestions.down.sql":                     {_1528395645_discussion_comment_suggestionsDownSql, map[string]*bintree{}},
+	"1528395645_discussion_comment_suggestions.up.sql":                       {_1528395645_discussion_comment_suggestionsUpSql, map[string]*bintree{}},
+	"1528395646_discussion_comments_resolved.down.sql":                       {_1528395646_discussion_comments_resolvedDownSql, map[string]*bintree{}},
+	"1528395646_discussion_comments_resolved.up.sql":                         {_1528395646_discussion_comments_resolvedUpSql, map[string]*bintree{}},
+	"1528395647_discussion_comment_attachments.down.sql":                     {_1528395647_discussion_comment_attachmentsDownSql, map[string]*bintree{}},
+	"1528395647_discussion_comment_attachments.up.sql":                       {_1528395647_discussion_comment_attachmentsUpSql, map[string]*bintree{}},
+	"1528395648_discussion_thread_references.down.sql":                       {_1528395648_discussion_thread_referencesDownSql, map[string]*bintree{}},
+	"1528395648_discussion_thread_references.up.sql":                         {_1528395648_discussion_thread_referencesUpSql, map[string]*bintree{}},
+	"1528395649_discussion_threads_stale_at.down.sql":                        {_1528395649_discussion_threads_stale_atDownSql, map[string]*bintree{}},
+	"1528395649_discussion_threads_stale_at.up.sql":                          {_1528395649_discussion_threads_stale_atUpSql, map[string]*bintree{}},
+	"1528395650_discussion_threads_duplicate_of.down.sql":                    {_1528395650_discussion_threads_duplicate_ofDownSql, map[string]*bintree{}},
+	"1528395650_discussion_threads_duplicate_of.up.sql":                      {_1528395650_discussion_threads_duplicate_ofUpSql, map[string]*bintree{}},
+	"1528395651_discussion_threads_pinned_at.down.sql":                       {_1528395651_discussion_threads_pinned_atDownSql, map[string]*bintree{}},
+	"1528395651_discussion_threads_pinned_at.up.sql":                         {_1528395651_discussion_threads_pinned_atUpSql, map[string]*bintree{}},
+	"1528395652_discussion_audit_log.down.sql":                               {_1528395652_discussion_audit_logDownSql, map[string]*bintree{}},
+	"1528395652_discussion_audit_log.up.sql":                                 {_1528395652_discussion_audit_logUpSql, map[string]*bintree{}},
+	"1528395653_discussion_thread_subscriptions_notification_level.down.sql": {_1528395653_discussion_thread_subscriptions_notification_levelDownSql, map[string]*bintree{}},
+	"1528395653_discussion_thread_subscriptions_notification_level.up.sql":   {_1528395653_discussion_thread_subscriptions_notification_levelUpSql, map[string]*bintree{}},
+	"1528395654_discussion_moderation.down.sql":                              {_1528395654_discussion_moderationDownSql, map[string]*bintree{}},
+	"1528395654_discussion_moderation.up.sql":                                {_1528395654_discussion_moderationUpSql, map[string]*bintree{}},
+	"1528395655_discussion_thread_reads.down.sql":                            {_1528395655_discussion_thread_readsDownSql, map[string]*bintree{}},
+	"1528395655_discussion_thread_reads.up.sql":                              {_1528395655_discussion_thread_readsUpSql, map[string]*bintree{}},
+	"1528395656_discussion_saved_replies.down.sql":                           {_1528395656_discussion_saved_repliesDownSql, map[string]*bintree{}},
+	"1528395656_discussion_saved_replies.up.sql":                             {_1528395656_discussion_saved_repliesUpSql, map[string]*bintree{}},
+	"1528395657_discussion_thread_dependencies.down.sql":                     {_1528395657_discussion_thread_dependenciesDownSql, map[string]*bintree{}},
+	"1528395657_discussion_thread_dependencies.up.sql":                       {_1528395657_discussion_thread_dependenciesUpSql, map[string]*bintree{}},
+	"1528395658_discussion_threads_counts.down.sql":                          {_1528395658_discussion_threads_countsDownSql, map[string]*bintree{}},
+	"1528395658_discussion_threads_counts.up.sql":                            {_1528395658_discussion_threads_countsUpSql, map[string]*bintree{}},
+	"1528395659_discussion_threads_order_indexes.down.sql":                   {_1528395659_discussion_threads_order_indexesDownSql, map[string]*bintree{}},
+	"1528395659_discussion_threads_order_indexes.up.sql":                     {_1528395659_discussion_threads_order_indexesUpSql, map[string]*bintree{}},
+	"1528395660_discussion_threads_idempotency_key.down.sql":                 {_1528395660_discussion_threads_idempotency_keyDownSql, map[string]*bintree{}},
+	"1528395660_discussion_threads_idempotency_key.up.sql":                   {_1528395660_discussion_threads_idempotency_keyUpSql, map[string]*bintree{}},
+	"1528395661_discussion_thread_numbers.down.sql":                          {_1528395661_discussion_thread_numbersDownSql, map[string]*bintree{}},
+	"1528395661_discussion_thread_numbers.up.sql":                            {_1528395661_discussion_thread_numbersUpSql, map[string]*bintree{}},
+	"1528395662_discussion_roles.down.sql":                                   {_1528395662_discussion_rolesDownSql, map[string]*bintree{}},
+	"1528395662_discussion_roles.up.sql":                                     {_1528395662_discussion_rolesUpSql, map[string]*bintree{}},
+	"1528395663_discussion_threads_target_repo_thread_id_idx.down.sql":       {_1528395663_discussion_threads_target_repo_thread_id_idxDownSql, map[string]*bintree{}},
+	"1528395663_discussion_threads_target_repo_thread_id_idx.up.sql":         {_1528395663_discussion_threads_target_repo_thread_id_idxUpSql, map[string]*bintree{}},
+	"1528395664_discussion_threads_open_updated_at_id_idx.down.sql":          {_1528395664_discussion_threads_open_updated_at_id_idxDownSql, map[string]*bintree{}},
+	"1528395664_discussion_threads_open_updated_at_id_idx.up.sql":            {_1528395664_discussion_threads_open_updated_at_id_idxUpSql, map[string]*bintree{}},
+	"1528395665_discussion_threads_repo_deleted_at.down.sql":                 {_1528395665_discussion_threads_repo_deleted_atDownSql, map[string]*bintree{}},
+	"1528395665_discussion_threads_repo_deleted_at.up.sql":                   {_1528395665_discussion_threads_repo_deleted_atUpSql, map[string]*bintree{}},
+	"1528395666_discussion_feed_tokens.down.sql":                             {_1528395666_discussion_feed_tokensDownSql, map[string]*bintree{}},
+	"1528395666_discussion_feed_tokens.up.sql":                               {_1528395666_discussion_feed_tokensUpSql, map[string]*bintree{}},
+	"1528395667_discussion_thread_diagnostics.down.sql":                      {_1528395667_discussion_thread_diagnosticsDownSql, map[string]*bintree{}},
+	"1528395667_discussion_thread_diagnostics.up.sql":                        {_1528395667_discussion_thread_diagnosticsUpSql, map[string]*bintree{}},
+	"1528395668_discussion_rules.down.sql":                                   {_1528395668_discussion_rulesDownSql, map[string]*bintree{}},
+	"1528395668_discussion_rules.up.sql":                                     {_1528395668_discussion_rulesUpSql, map[string]*bintree{}},
+	"1528395669_discussion_thread_previous_numbers.down.sql":                 {_1528395669_discussion_thread_previous_numbersDownSql, map[string]*bintree{}},
+	"1528395669_discussion_thread_previous_numbers.up.sql":                   {_1528395669_discussion_thread_previous_numbersUpSql, map[string]*bintree{}},
+	"1528395670_discussion_threads_closed_at.down.sql":                       {_1528395670_discussion_threads_closed_atDownSql, map[string]*bintree{}},
+	"1528395670_discussion_threads_closed_at.up.sql":                         {_1528395670_discussion_threads_closed_atUpSql, map[string]*bintree{}},
+	"1528395671_discussion_comment_attachments_upload_token_sha256.down.sql": {_1528395671_discussion_comment_attachments_upload_token_sha256DownSql, map[string]*bintree{}},
+	"1528395671_discussion_comment_attachments_upload_token_sha256.up.sql":   {_1528395671_discussion_comment_attachments_upload_token_sha256UpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
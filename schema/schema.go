@@ -286,6 +286,106 @@ type Discussions struct {
 	AbuseEmails []string `json:"abuseEmails,omitempty"`
 	// AbuseProtection description: Enable abuse protection features (for public instances like Sourcegraph.com, not recommended for private instances).
 	AbuseProtection bool `json:"abuseProtection,omitempty"`
+	// Attachments description: Enables file attachments (such as images and logs) on discussion comments. Attachments are disabled if this is not set.
+	Attachments *DiscussionsAttachments `json:"attachments,omitempty"`
+	// Cache description: Caches discussion threads and their comments in Redis, to reduce the database load of frequently viewed threads. Cached threads and comments are invalidated when they are changed. Caching is disabled if this is not set.
+	Cache *DiscussionsCache `json:"cache,omitempty"`
+	// DeletedRepositoryThreads description: What happens to the discussion threads on a repository when the repository is deleted (e.g. removed from its code host connection): "delete" deletes them, and "archive" archives and locks them so that they remain readable (by site admins only, because the permissions of the repository can no longer be checked).
+	DeletedRepositoryThreads string `json:"deletedRepositoryThreads,omitempty"`
+	// MaxCommentLength description: The maximum length of the contents of a comment (including the first comment of a thread), in characters. Longer comments are rejected when they are created or edited. Existing comments that are longer (e.g. because they were created before the limit was lowered) are shown truncated, with the full comment loaded on request.
+	MaxCommentLength int `json:"maxCommentLength,omitempty"`
+	// NotificationChannels description: Chat channels (in Slack or Microsoft Teams) that are notified of activity on discussion threads.
+	NotificationChannels []*DiscussionsNotificationChannel `json:"notificationChannels,omitempty"`
+	// RateLimits description: Per-user limits on how quickly threads and comments can be created, to protect the instance from spam and runaway automation. Each limit allows short bursts of up to the configured number. A limit that is not set is not enforced.
+	RateLimits *DiscussionsRateLimits `json:"rateLimits,omitempty"`
+	// StaleThreads description: Enables automatic management of inactive discussion threads. A thread that has been inactive for the configured period is marked as stale with a comment that warns its subscribers, and it is archived if it remains inactive for the grace period. Stale thread management is disabled if this is not set.
+	StaleThreads *DiscussionsStaleThreads `json:"staleThreads,omitempty"`
+}
+
+// DiscussionsAttachments description: Enables file attachments (such as images and logs) on discussion comments. Attachments are disabled if this is not set.
+type DiscussionsAttachments struct {
+	// AllowedContentTypes description: The MIME types of the files that may be attached. A type ending in "/*" (such as "image/*") allows all of its subtypes.
+	AllowedContentTypes []string `json:"allowedContentTypes,omitempty"`
+	// MaxSize description: The maximum size of an attachment, in bytes.
+	MaxSize int `json:"maxSize,omitempty"`
+	// Storage description: Where the contents of attachments are stored.
+	Storage DiscussionsAttachmentsStorage `json:"storage"`
+}
+
+// DiscussionsAttachmentsStorage description: Where the contents of attachments are stored.
+type DiscussionsAttachmentsStorage struct {
+	// AccessKeyID description: For S3 storage, the AWS access key ID of an IAM user that can read and write objects in the bucket.
+	AccessKeyID string `json:"accessKeyID,omitempty"`
+	// Bucket description: For S3 and GCS storage, the name of the bucket in which attachments are stored.
+	Bucket string `json:"bucket,omitempty"`
+	// CredentialsJSON description: For GCS storage, the JSON key of a service account that can read and write objects in the bucket. If empty, the application default credentials are used.
+	CredentialsJSON string `json:"credentialsJSON,omitempty"`
+	// Directory description: For filesystem storage, the directory in which attachments are stored. It must be on a persistent volume shared by all frontend instances.
+	Directory string `json:"directory,omitempty"`
+	// Region description: For S3 storage, the AWS region of the bucket.
+	Region string `json:"region,omitempty"`
+	// SecretAccessKey description: For S3 storage, the AWS secret access key of the IAM user.
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	// Type description: The kind of storage: a directory on the frontend's filesystem, an Amazon S3 bucket, or a Google Cloud Storage bucket.
+	Type string `json:"type"`
+}
+
+// DiscussionsCache description: Caches discussion threads and their comments in Redis, to reduce the database load of frequently viewed threads. Cached threads and comments are invalidated when they are changed. Caching is disabled if this is not set.
+type DiscussionsCache struct {
+	// TtlSeconds description: The number of seconds after which a cached thread or its comments are read from the database again, even if they have not been changed by Sourcegraph (e.g. because the thread's repository was deleted).
+	TtlSeconds int `json:"ttlSeconds,omitempty"`
+}
+
+// DiscussionsEmailNotifications description: The code discussions activity that you are notified of by email. Notifications are only sent for threads that you are subscribed to or mentioned in.
+type DiscussionsEmailNotifications struct {
+	// Comments description: Whether to receive emails about new threads and comments.
+	Comments *bool `json:"comments,omitempty"`
+	// Mentions description: Whether to receive emails when you are @-mentioned, even if you have turned off emails about comments.
+	Mentions *bool `json:"mentions,omitempty"`
+	// StateChanges description: Whether to receive emails when a thread is archived, unarchived, locked, or unlocked.
+	StateChanges *bool `json:"stateChanges,omitempty"`
+}
+type DiscussionsNotificationChannel struct {
+	// Events description: The thread activity to notify the channel of. If empty, the channel is notified of all activity.
+	Events []string `json:"events,omitempty"`
+	// Repositories description: The names of the repositories (e.g. "github.com/gorilla/mux") whose threads' activity is sent to the channel. If empty, activity on threads in all repositories is sent.
+	Repositories []string `json:"repositories,omitempty"`
+	// Template description: A Go text/template for the message text. The template is executed with the fields .Event, .ActorUsername, .ThreadTitle, .RepoName, .URL, and .CommentContents (which is empty for threadArchived). If empty, a default message is sent.
+	Template string `json:"template,omitempty"`
+	// Type description: The kind of chat service that the channel is in.
+	Type string `json:"type"`
+	// WebhookURL description: The incoming webhook URL of the channel, as configured in Slack or Microsoft Teams.
+	WebhookURL string `json:"webhookURL"`
+}
+
+// DiscussionsRateLimits description: Per-user limits on how quickly threads and comments can be created, to protect the instance from spam and runaway automation. Each limit allows short bursts of up to the configured number. A limit that is not set is not enforced.
+type DiscussionsRateLimits struct {
+	// CommentsPerMinute description: The maximum number of comments (including replies) that a user can add per minute.
+	CommentsPerMinute int `json:"commentsPerMinute,omitempty"`
+	// ThreadsPerHour description: The maximum number of threads that a user can create per hour.
+	ThreadsPerHour int `json:"threadsPerHour,omitempty"`
+}
+type DiscussionsSavedThreadFilter struct {
+	// Default description: Whether the threads UI shows this filter's threads by default. At most one filter should be the default.
+	Default bool `json:"default,omitempty"`
+	// Name description: The name of the filter, unique among your saved filters.
+	Name string `json:"name"`
+	// Query description: The thread filter query (the same syntax as the discussion threads search query).
+	Query string `json:"query"`
+}
+
+// DiscussionsStaleThreads description: Enables automatic management of inactive discussion threads. A thread that has been inactive for the configured period is marked as stale with a comment that warns its subscribers, and it is archived if it remains inactive for the grace period. Stale thread management is disabled if this is not set.
+type DiscussionsStaleThreads struct {
+	// ExcludedRepositories description: The names of the repositories (e.g. "github.com/gorilla/mux") whose threads are never marked as stale.
+	ExcludedRepositories []string `json:"excludedRepositories,omitempty"`
+	// GracePeriodDays description: The number of days after a thread is marked as stale that it is archived, unless it is commented on in the meantime.
+	GracePeriodDays int `json:"gracePeriodDays,omitempty"`
+	// InactiveDays description: The number of days without updates or comments after which an open thread is marked as stale.
+	InactiveDays int `json:"inactiveDays"`
+	// Username description: The username of the user (such as a bot account) that posts the warning comments and archives stale threads.
+	Username string `json:"username"`
+	// WarningComment description: The markdown contents of the comment posted on threads when they are marked as stale. If empty, a default comment is posted.
+	WarningComment string `json:"warningComment,omitempty"`
 }
 type ExcludedAWSCodeCommitRepo struct {
 	// Id description: The ID of an AWS Code Commit repository (as returned by the AWS API) to exclude from mirroring. Use this to exclude the repository, even if renamed, or to differentiate between repositories with the same name in multiple regions.
@@ -778,6 +878,12 @@ type Settings struct {
 	AlertsShowPatchUpdates bool `json:"alerts.showPatchUpdates,omitempty"`
 	// CodeHostUseNativeTooltips description: Whether to use the code host's native hover tooltips when they exist (GitHub's jump-to-definition tooltips, for example).
 	CodeHostUseNativeTooltips bool `json:"codeHost.useNativeTooltips,omitempty"`
+	// DiscussionsDigest description: How often to receive a digest email summarizing code discussions activity: new threads you are involved in, threads assigned to you that have new activity, and stale threads assigned to you. Digests are not sent if there is nothing to report.
+	DiscussionsDigest string `json:"discussions.digest,omitempty"`
+	// DiscussionsEmailNotifications description: The code discussions activity that you are notified of by email. Notifications are only sent for threads that you are subscribed to or mentioned in.
+	DiscussionsEmailNotifications *DiscussionsEmailNotifications `json:"discussions.emailNotifications,omitempty"`
+	// DiscussionsSavedThreadFilters description: Named discussion thread filter queries that you have saved, such as "assigned to me and open".
+	DiscussionsSavedThreadFilters []*DiscussionsSavedThreadFilter `json:"discussions.savedThreadFilters,omitempty"`
 	// ExperimentalFeatures description: Experimental features to enable or disable. Features that are now enabled by default are marked as deprecated.
 	ExperimentalFeatures *SettingsExperimentalFeatures `json:"experimentalFeatures,omitempty"`
 	// Extensions description: The Sourcegraph extensions to use. Enable an extension by adding a property `"my/extension": true` (where `my/extension` is the extension ID). Override a previously enabled extension and disable it by setting its value to `false`.
@@ -882,6 +988,10 @@ type SiteConfiguration struct {
 	GithubClientID string `json:"githubClientID,omitempty"`
 	// GithubClientSecret description: Client secret for GitHub.
 	GithubClientSecret string `json:"githubClientSecret,omitempty"`
+	// GraphqlMaxQueryCost description: The maximum estimated cost of a GraphQL query. The cost of a query is the number of fields it can resolve, where the fields inside a connection requested with `first: N` (or `last: N`) count N times (and 1000 times for connections requested without either). Queries that exceed it, or whose cost can't be estimated, are rejected before they are executed. Any value less than or equal to zero means unlimited.
+	GraphqlMaxQueryCost int `json:"graphql.maxQueryCost,omitempty"`
+	// GraphqlMaxQueryDepth description: The maximum nesting depth of the fields in a GraphQL query. Queries that exceed it are rejected before they are executed. Any value less than or equal to zero means unlimited.
+	GraphqlMaxQueryDepth int `json:"graphql.maxQueryDepth,omitempty"`
 	// HtmlBodyBottom description: HTML to inject at the bottom of the `<body>` element on each page, for analytics scripts
 	HtmlBodyBottom string `json:"htmlBodyBottom,omitempty"`
 	// HtmlBodyTop description: HTML to inject at the top of the `<body>` element on each page, for analytics scripts
@@ -152,6 +152,70 @@ const SettingsSchemaJSON = `{
       "description": "Whether to use the code host's native hover tooltips when they exist (GitHub's jump-to-definition tooltips, for example).",
       "type": "boolean",
       "default": false
+    },
+    "discussions.digest": {
+      "description": "How often to receive a digest email summarizing code discussions activity: new threads you are involved in, threads assigned to you that have new activity, and stale threads assigned to you. Digests are not sent if there is nothing to report.",
+      "type": "string",
+      "enum": ["off", "daily", "weekly"],
+      "default": "off"
+    },
+    "discussions.emailNotifications": {
+      "title": "DiscussionsEmailNotifications",
+      "description": "The code discussions activity that you are notified of by email. Notifications are only sent for threads that you are subscribed to or mentioned in.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "comments": {
+          "description": "Whether to receive emails about new threads and comments.",
+          "type": "boolean",
+          "default": true,
+          "!go": {
+            "pointer": true
+          }
+        },
+        "mentions": {
+          "description": "Whether to receive emails when you are @-mentioned, even if you have turned off emails about comments.",
+          "type": "boolean",
+          "default": true,
+          "!go": {
+            "pointer": true
+          }
+        },
+        "stateChanges": {
+          "description": "Whether to receive emails when a thread is archived, unarchived, locked, or unlocked.",
+          "type": "boolean",
+          "default": true,
+          "!go": {
+            "pointer": true
+          }
+        }
+      }
+    },
+    "discussions.savedThreadFilters": {
+      "description": "Named discussion thread filter queries that you have saved, such as \"assigned to me and open\".",
+      "type": "array",
+      "items": {
+        "title": "DiscussionsSavedThreadFilter",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "query"],
+        "properties": {
+          "name": {
+            "description": "The name of the filter, unique among your saved filters.",
+            "type": "string",
+            "minLength": 1
+          },
+          "query": {
+            "description": "The thread filter query (the same syntax as the discussion threads search query).",
+            "type": "string"
+          },
+          "default": {
+            "description": "Whether the threads UI shows this filter's threads by default. At most one filter should be the default.",
+            "type": "boolean",
+            "default": false
+          }
+        }
+      }
     }
   },
   "definitions": {
@@ -113,6 +113,20 @@ const SiteSchemaJSON = `{
       "default": false,
       "group": "Security"
     },
+    "graphql.maxQueryCost": {
+      "description": "The maximum estimated cost of a GraphQL query. The cost of a query is the number of fields it can resolve, where the fields inside a connection requested with ` + "`" + `first: N` + "`" + ` (or ` + "`" + `last: N` + "`" + `) count N times (and 1000 times for connections requested without either). Queries that exceed it, or whose cost can't be estimated, are rejected before they are executed. Any value less than or equal to zero means unlimited.",
+      "type": "integer",
+      "default": 0,
+      "examples": [100000],
+      "group": "Security"
+    },
+    "graphql.maxQueryDepth": {
+      "description": "The maximum nesting depth of the fields in a GraphQL query. Queries that exceed it are rejected before they are executed. Any value less than or equal to zero means unlimited.",
+      "type": "integer",
+      "default": 0,
+      "examples": [15],
+      "group": "Security"
+    },
     "disableAutoGitUpdates": {
       "description": "Disable periodically fetching git contents for existing repositories.",
       "type": "boolean",
@@ -415,6 +429,186 @@ const SiteSchemaJSON = `{
           "type": "array",
           "items": { "type": "string" },
           "default": []
+        },
+        "notificationChannels": {
+          "description": "Chat channels (in Slack or Microsoft Teams) that are notified of activity on discussion threads.",
+          "type": "array",
+          "items": {
+            "title": "DiscussionsNotificationChannel",
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["type", "webhookURL"],
+            "properties": {
+              "type": {
+                "description": "The kind of chat service that the channel is in.",
+                "type": "string",
+                "enum": ["slack", "teams"]
+              },
+              "webhookURL": {
+                "description": "The incoming webhook URL of the channel, as configured in Slack or Microsoft Teams.",
+                "type": "string",
+                "format": "uri",
+                "pattern": "^https?://"
+              },
+              "events": {
+                "description": "The thread activity to notify the channel of. If empty, the channel is notified of all activity.",
+                "type": "array",
+                "items": { "type": "string", "enum": ["threadCreated", "threadArchived", "commented"] },
+                "default": []
+              },
+              "repositories": {
+                "description": "The names of the repositories (e.g. \"github.com/gorilla/mux\") whose threads' activity is sent to the channel. If empty, activity on threads in all repositories is sent.",
+                "type": "array",
+                "items": { "type": "string" },
+                "default": []
+              },
+              "template": {
+                "description": "A Go text/template for the message text. The template is executed with the fields .Event, .ActorUsername, .ThreadTitle, .RepoName, .URL, and .CommentContents (which is empty for threadArchived). If empty, a default message is sent.",
+                "type": "string",
+                "examples": ["{{.ActorUsername}} {{.Event}} on {{.ThreadTitle}}: {{.URL}}"]
+              }
+            }
+          },
+          "default": []
+        },
+        "attachments": {
+          "title": "DiscussionsAttachments",
+          "description": "Enables file attachments (such as images and logs) on discussion comments. Attachments are disabled if this is not set.",
+          "type": "object",
+          "additionalProperties": false,
+          "required": ["storage"],
+          "properties": {
+            "storage": {
+              "title": "DiscussionsAttachmentsStorage",
+              "description": "Where the contents of attachments are stored.",
+              "type": "object",
+              "additionalProperties": false,
+              "required": ["type"],
+              "properties": {
+                "type": {
+                  "description": "The kind of storage: a directory on the frontend's filesystem, an Amazon S3 bucket, or a Google Cloud Storage bucket.",
+                  "type": "string",
+                  "enum": ["filesystem", "s3", "gcs"]
+                },
+                "directory": {
+                  "description": "For filesystem storage, the directory in which attachments are stored. It must be on a persistent volume shared by all frontend instances.",
+                  "type": "string"
+                },
+                "bucket": {
+                  "description": "For S3 and GCS storage, the name of the bucket in which attachments are stored.",
+                  "type": "string"
+                },
+                "region": {
+                  "description": "For S3 storage, the AWS region of the bucket.",
+                  "type": "string",
+                  "examples": ["us-west-2"]
+                },
+                "accessKeyID": {
+                  "description": "For S3 storage, the AWS access key ID of an IAM user that can read and write objects in the bucket.",
+                  "type": "string"
+                },
+                "secretAccessKey": {
+                  "description": "For S3 storage, the AWS secret access key of the IAM user.",
+                  "type": "string"
+                },
+                "credentialsJSON": {
+                  "description": "For GCS storage, the JSON key of a service account that can read and write objects in the bucket. If empty, the application default credentials are used.",
+                  "type": "string"
+                }
+              }
+            },
+            "maxSize": {
+              "description": "The maximum size of an attachment, in bytes.",
+              "type": "integer",
+              "minimum": 1,
+              "default": 10485760
+            },
+            "allowedContentTypes": {
+              "description": "The MIME types of the files that may be attached. A type ending in \"/*\" (such as \"image/*\") allows all of its subtypes.",
+              "type": "array",
+              "items": { "type": "string" },
+              "default": ["image/png", "image/jpeg", "image/gif", "text/plain"]
+            }
+          }
+        },
+        "cache": {
+          "title": "DiscussionsCache",
+          "description": "Caches discussion threads and their comments in Redis, to reduce the database load of frequently viewed threads. Cached threads and comments are invalidated when they are changed. Caching is disabled if this is not set.",
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "ttlSeconds": {
+              "description": "The number of seconds after which a cached thread or its comments are read from the database again, even if they have not been changed by Sourcegraph (e.g. because the thread's repository was deleted).",
+              "type": "integer",
+              "minimum": 1,
+              "default": 300
+            }
+          }
+        },
+        "deletedRepositoryThreads": {
+          "description": "What happens to the discussion threads on a repository when the repository is deleted (e.g. removed from its code host connection): \"delete\" deletes them, and \"archive\" archives and locks them so that they remain readable (by site admins only, because the permissions of the repository can no longer be checked).",
+          "type": "string",
+          "enum": ["delete", "archive"],
+          "default": "delete"
+        },
+        "maxCommentLength": {
+          "description": "The maximum length of the contents of a comment (including the first comment of a thread), in characters. Longer comments are rejected when they are created or edited. Existing comments that are longer (e.g. because they were created before the limit was lowered) are shown truncated, with the full comment loaded on request.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 100000
+        },
+        "rateLimits": {
+          "title": "DiscussionsRateLimits",
+          "description": "Per-user limits on how quickly threads and comments can be created, to protect the instance from spam and runaway automation. Each limit allows short bursts of up to the configured number. A limit that is not set is not enforced.",
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "threadsPerHour": {
+              "description": "The maximum number of threads that a user can create per hour.",
+              "type": "integer",
+              "minimum": 1
+            },
+            "commentsPerMinute": {
+              "description": "The maximum number of comments (including replies) that a user can add per minute.",
+              "type": "integer",
+              "minimum": 1
+            }
+          }
+        },
+        "staleThreads": {
+          "title": "DiscussionsStaleThreads",
+          "description": "Enables automatic management of inactive discussion threads. A thread that has been inactive for the configured period is marked as stale with a comment that warns its subscribers, and it is archived if it remains inactive for the grace period. Stale thread management is disabled if this is not set.",
+          "type": "object",
+          "additionalProperties": false,
+          "required": ["inactiveDays", "username"],
+          "properties": {
+            "inactiveDays": {
+              "description": "The number of days without updates or comments after which an open thread is marked as stale.",
+              "type": "integer",
+              "minimum": 1,
+              "examples": [60]
+            },
+            "gracePeriodDays": {
+              "description": "The number of days after a thread is marked as stale that it is archived, unless it is commented on in the meantime.",
+              "type": "integer",
+              "minimum": 1,
+              "default": 7
+            },
+            "username": {
+              "description": "The username of the user (such as a bot account) that posts the warning comments and archives stale threads.",
+              "type": "string"
+            },
+            "warningComment": {
+              "description": "The markdown contents of the comment posted on threads when they are marked as stale. If empty, a default comment is posted.",
+              "type": "string"
+            },
+            "excludedRepositories": {
+              "description": "The names of the repositories (e.g. \"github.com/gorilla/mux\") whose threads are never marked as stale.",
+              "type": "array",
+              "items": { "type": "string" },
+              "default": []
+            }
+          }
         }
       },
       "group": "Experimental",
@@ -237,6 +237,12 @@ export enum ContributableMenu {
     /** The search results toolbar. */
     SearchResultsToolbar = 'search/results/toolbar',
 
+    /**
+     * The toolbar of a discussion thread. The context contains the thread's discussionThread.id,
+     * discussionThread.idWithoutKind, discussionThread.title and discussionThread.archived keys.
+     */
+    DiscussionThreadToolbar = 'discussionThread/toolbar',
+
     /** The help menu in the application. */
     Help = 'help',
 }
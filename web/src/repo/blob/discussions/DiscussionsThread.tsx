@@ -5,10 +5,13 @@ import * as React from 'react'
 import { Redirect } from 'react-router'
 import { combineLatest, Subject, Subscription, throwError, Observable } from 'rxjs'
 import { catchError, delay, distinctUntilChanged, map, repeatWhen, startWith, switchMap, tap } from 'rxjs/operators'
+import { ContributableMenu } from '../../../../../shared/src/api/protocol'
 import { ExtensionsControllerProps } from '../../../../../shared/src/extensions/controller'
 import * as GQL from '../../../../../shared/src/graphql/schema'
+import { PlatformContextProps } from '../../../../../shared/src/platform/context'
 import { asError } from '../../../../../shared/src/util/errors'
 import { addCommentToThread, fetchDiscussionThreadAndComments, updateComment } from '../../../discussions/backend'
+import { WebActionsNavItems as ActionsNavItems } from '../../../components/shared'
 import { DiscussionsComment } from '../../../discussions/DiscussionsComment'
 import { eventLogger } from '../../../tracking/eventLogger'
 import { formatHash } from '../../../util/url'
@@ -16,7 +19,7 @@ import { DiscussionsInput, TitleMode } from './DiscussionsInput'
 import { DiscussionsNavbar } from './DiscussionsNavbar'
 import { ErrorAlert } from '../../../components/alerts'
 
-interface Props extends ExtensionsControllerProps {
+interface Props extends ExtensionsControllerProps, PlatformContextProps<'forceUpdateTooltip'> {
     threadIDWithoutKind: string
     commentIDWithoutKind?: string
     repoID: GQL.ID
@@ -100,6 +103,23 @@ export class DiscussionsThread extends React.PureComponent<Props, State> {
         return (
             <div className="discussions-thread">
                 <DiscussionsNavbar {...this.props} threadTitle={thread ? thread.title : undefined} />
+                {thread && (
+                    <ActionsNavItems
+                        {...this.props}
+                        extraContext={{
+                            'discussionThread.id': thread.id,
+                            'discussionThread.idWithoutKind': thread.idWithoutKind,
+                            'discussionThread.title': thread.title,
+                            'discussionThread.archived': thread.archivedAt !== null,
+                        }}
+                        menu={ContributableMenu.DiscussionThreadToolbar}
+                        wrapInList={true}
+                        listClass="discussions-thread__actions"
+                        showLoadingSpinnerDuringExecution={true}
+                        actionItemClass="btn btn-link text-decoration-none"
+                        telemetryService={eventLogger}
+                    />
+                )}
                 {loading && <LoadingSpinner className="icon-inline" />}
                 {error && (
                     <ErrorAlert className="discussions-thread__error" prefix="Error loading thread" error={error} />
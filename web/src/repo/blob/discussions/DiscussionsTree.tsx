@@ -3,12 +3,13 @@ import * as React from 'react'
 import { Subscription } from 'rxjs'
 import { ExtensionsControllerProps } from '../../../../../shared/src/extensions/controller'
 import * as GQL from '../../../../../shared/src/graphql/schema'
+import { PlatformContextProps } from '../../../../../shared/src/platform/context'
 import { DiscussionsList } from '../../../discussions/DiscussionsList'
 import { registerDiscussionsContributions } from './contributions'
 import { DiscussionsCreate } from './DiscussionsCreate'
 import { DiscussionsThread } from './DiscussionsThread'
 
-interface Props extends ExtensionsControllerProps {
+interface Props extends ExtensionsControllerProps, PlatformContextProps<'forceUpdateTooltip'> {
     repoID: GQL.ID
     repoName: string
     commitID: string
@@ -212,6 +212,7 @@ export class BlobPanel extends React.PureComponent<Props> {
                                                   location={this.props.location}
                                                   compact={true}
                                                   extensionsController={this.props.extensionsController}
+                                                  platformContext={this.props.platformContext}
                                               />
                                           ),
                                       }